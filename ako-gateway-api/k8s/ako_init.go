@@ -32,9 +32,11 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
 	akogatewayapinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/nodes"
+	akogatewayapiobjects "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/objects"
 	akogatewayapistatus "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/status"
 	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
@@ -50,6 +52,7 @@ import (
 func (c *GatewayController) InitController(informers k8s.K8sinformers, registeredInformers []string, ctrlCh <-chan struct{}, stopCh <-chan struct{}, quickSyncCh chan struct{}, waitGroupMap ...map[string]*sync.WaitGroup) {
 	// set up signals so we handle the first shutdown signal gracefully
 	var worker *utils.FullSyncThread
+	var vsRuntimeWorker *utils.FullSyncThread
 	informersArg := make(map[string]interface{})
 
 	c.informers = utils.NewInformers(utils.KubeClientIntf{ClientSet: informers.Cs}, registeredInformers, informersArg)
@@ -166,6 +169,14 @@ func (c *GatewayController) InitController(informers k8s.K8sinformers, registere
 	statusQueue.SyncFunc = SyncFromStatusQueue
 	statusQueue.Run(stopCh, statusWG)
 
+	if vsRuntimeSyncInterval := lib.GetVSRuntimeSyncInterval(); vsRuntimeSyncInterval != 0 {
+		vsRuntimeWorker = utils.NewFullSyncThread(time.Duration(vsRuntimeSyncInterval) * time.Second)
+		vsRuntimeWorker.SyncFunction = status.SyncVSRuntimeStatus
+		go vsRuntimeWorker.Run()
+	} else {
+		utils.AviLog.Infof("Virtual service runtime status sync interval set to 0, will not sync the runtime status")
+	}
+
 LABEL:
 	for {
 		select {
@@ -178,6 +189,9 @@ LABEL:
 	if worker != nil {
 		worker.Shutdown()
 	}
+	if vsRuntimeWorker != nil {
+		vsRuntimeWorker.Shutdown()
+	}
 
 	ingestionQueue.StopWorkers(stopCh)
 	graphQueue.StopWorkers(stopCh)
@@ -275,6 +289,18 @@ func (c *GatewayController) FullSyncK8s(sync bool) error {
 		akogatewayapinodes.DequeueIngestion(key, true)
 	}
 
+	// ReferenceGrant Section
+	if akogatewayapilib.AKOControlConfig().GatewayApiInformers().ReferenceGrantInformer != nil {
+		referenceGrantObjs, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().ReferenceGrantInformer.Lister().ReferenceGrants(metav1.NamespaceAll).List(labels.Set(nil).AsSelector())
+		if err != nil {
+			utils.AviLog.Errorf("Unable to retrieve the referencegrants during full sync: %s", err)
+			return err
+		}
+		for _, referenceGrantObj := range referenceGrantObjs {
+			akogatewayapiobjects.GatewayApiLister().UpdateReferenceGrant(referenceGrantObj.Namespace, referenceGrantObj.Name, referenceGrantObj.Spec)
+		}
+	}
+
 	// Gateway Section
 	var filteredGateways []*gatewayv1.Gateway
 	gatewayObjs, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayInformer.Lister().Gateways(metav1.NamespaceAll).List(labels.Set(nil).AsSelector())
@@ -335,6 +361,104 @@ func (c *GatewayController) FullSyncK8s(sync bool) error {
 		akogatewayapinodes.DequeueIngestion(key, true)
 	}
 
+	// GRPCRoute Section
+	if akogatewayapilib.AKOControlConfig().GatewayApiInformers().GRPCRouteInformer != nil {
+		var filteredGRPCRoutes []*gatewayv1.GRPCRoute
+		grpcRouteObjs, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GRPCRouteInformer.Lister().GRPCRoutes(metav1.NamespaceAll).List(labels.Set(nil).AsSelector())
+		if err != nil {
+			utils.AviLog.Errorf("Unable to retrieve the grpcroutes during full sync: %s", err)
+			return err
+		}
+
+		for _, grpcRouteObj := range grpcRouteObjs {
+			key := lib.GRPCRoute + "/" + utils.ObjKey(grpcRouteObj)
+			objects.SharedResourceVerInstanceLister().Save(key, grpcRouteObj.ResourceVersion)
+			if IsGRPCRouteConfigValid(key, grpcRouteObj) {
+				filteredGRPCRoutes = append(filteredGRPCRoutes, grpcRouteObj)
+			}
+		}
+		sort.Slice(filteredGRPCRoutes, func(i, j int) bool {
+			if filteredGRPCRoutes[i].GetCreationTimestamp().Unix() == filteredGRPCRoutes[j].GetCreationTimestamp().Unix() {
+				return filteredGRPCRoutes[i].Namespace+"/"+filteredGRPCRoutes[i].Name < filteredGRPCRoutes[j].Namespace+"/"+filteredGRPCRoutes[j].Name
+			}
+			return filteredGRPCRoutes[i].GetCreationTimestamp().Unix() < filteredGRPCRoutes[j].GetCreationTimestamp().Unix()
+		})
+		for _, filteredGRPCRoute := range filteredGRPCRoutes {
+			key := lib.GRPCRoute + "/" + utils.ObjKey(filteredGRPCRoute)
+			akogatewayapinodes.DequeueIngestion(key, true)
+		}
+	}
+
+	// TCPRoute, UDPRoute and TLSRoute Section
+	if akogatewayapilib.IsExperimentalRoutesEnabled() {
+		var l4Routes []metav1.Object
+		tcpRouteObjs, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().TCPRouteInformer.Lister().TCPRoutes(metav1.NamespaceAll).List(labels.Set(nil).AsSelector())
+		if err != nil {
+			utils.AviLog.Errorf("Unable to retrieve the tcproutes during full sync: %s", err)
+			return err
+		}
+		for _, tcpRouteObj := range tcpRouteObjs {
+			l4Routes = append(l4Routes, tcpRouteObj)
+		}
+		udpRouteObjs, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().UDPRouteInformer.Lister().UDPRoutes(metav1.NamespaceAll).List(labels.Set(nil).AsSelector())
+		if err != nil {
+			utils.AviLog.Errorf("Unable to retrieve the udproutes during full sync: %s", err)
+			return err
+		}
+		for _, udpRouteObj := range udpRouteObjs {
+			l4Routes = append(l4Routes, udpRouteObj)
+		}
+		tlsRouteObjs, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().TLSRouteInformer.Lister().TLSRoutes(metav1.NamespaceAll).List(labels.Set(nil).AsSelector())
+		if err != nil {
+			utils.AviLog.Errorf("Unable to retrieve the tlsroutes during full sync: %s", err)
+			return err
+		}
+		for _, tlsRouteObj := range tlsRouteObjs {
+			l4Routes = append(l4Routes, tlsRouteObj)
+		}
+		sort.Slice(l4Routes, func(i, j int) bool {
+			if l4Routes[i].GetCreationTimestamp().Unix() == l4Routes[j].GetCreationTimestamp().Unix() {
+				return l4Routes[i].GetNamespace()+"/"+l4Routes[i].GetName() < l4Routes[j].GetNamespace()+"/"+l4Routes[j].GetName()
+			}
+			return l4Routes[i].GetCreationTimestamp().Unix() < l4Routes[j].GetCreationTimestamp().Unix()
+		})
+		for _, l4Route := range l4Routes {
+			routeType := lib.TCPRoute
+			switch l4Route.(type) {
+			case *gatewayv1alpha2.UDPRoute:
+				routeType = lib.UDPRoute
+			case *gatewayv1alpha2.TLSRoute:
+				routeType = lib.TLSRoute
+			}
+			route, parentRefs, _ := getL4RouteObject(l4Route)
+			key := routeType + "/" + utils.ObjKey(route)
+			objects.SharedResourceVerInstanceLister().Save(key, route.GetResourceVersion())
+			if IsL4RouteConfigValid(key, parentRefs) {
+				akogatewayapinodes.DequeueIngestion(key, true)
+			}
+		}
+	}
+
+	// BackendTLSPolicy Section
+	if akogatewayapilib.AKOControlConfig().GatewayApiInformers().BackendTLSPolicyInformer != nil {
+		backendTLSPolicyObjs, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().BackendTLSPolicyInformer.Lister().BackendTLSPolicies(metav1.NamespaceAll).List(labels.Set(nil).AsSelector())
+		if err != nil {
+			utils.AviLog.Errorf("Unable to retrieve the backendtlspolicies during full sync: %s", err)
+			return err
+		}
+		// the oldest policy targeting a Service takes precedence, so policies are processed in creation order
+		sort.Slice(backendTLSPolicyObjs, func(i, j int) bool {
+			if backendTLSPolicyObjs[i].GetCreationTimestamp().Unix() == backendTLSPolicyObjs[j].GetCreationTimestamp().Unix() {
+				return backendTLSPolicyObjs[i].Namespace+"/"+backendTLSPolicyObjs[i].Name < backendTLSPolicyObjs[j].Namespace+"/"+backendTLSPolicyObjs[j].Name
+			}
+			return backendTLSPolicyObjs[i].GetCreationTimestamp().Unix() < backendTLSPolicyObjs[j].GetCreationTimestamp().Unix()
+		})
+		for _, backendTLSPolicyObj := range backendTLSPolicyObjs {
+			key := lib.BackendTLSPolicy + "/" + utils.ObjKey(backendTLSPolicyObj)
+			akogatewayapinodes.DequeueIngestion(key, true)
+		}
+	}
+
 	// Service Section
 	svcObjs, err := utils.GetInformers().ServiceInformer.Lister().Services(metav1.NamespaceAll).List(labels.Set(nil).AsSelector())
 	if err != nil {
@@ -15,18 +15,25 @@
 package k8s
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	discovery "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/runtime"
+	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1alpha3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	gatewayclientset "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 	gatewayexternalversions "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions"
 
@@ -43,17 +50,23 @@ import (
 // Used in VCF RBAC
 // +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gatewayclasses;gatewayclasses/status,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways;gateways/status,verbs=get;list;watch;update;patch
-// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes;httproutes/status,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes;httproutes/status;grpcroutes;grpcroutes/status,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=tcproutes;tcproutes/status;udproutes;udproutes/status;tlsroutes;tlsroutes/status,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=referencegrants,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=backendtlspolicies;backendtlspolicies/status,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
 
 var controllerInstance *GatewayController
 var ctrlonce sync.Once
 
 type GatewayController struct {
-	worker_id        uint32
-	informers        *utils.Informers
-	dynamicInformers *akogatewayapilib.DynamicInformers
-	workqueue        []workqueue.RateLimitingInterface //nolint:staticcheck
-	DisableSync      bool
+	worker_id             uint32
+	informers             *utils.Informers
+	dynamicInformers      *akogatewayapilib.DynamicInformers
+	workqueue             []workqueue.RateLimitingInterface //nolint:staticcheck
+	DisableSync           bool
+	stopCh                <-chan struct{}
+	configMapInformerOnce sync.Once
 }
 
 func SharedGatewayController() *GatewayController {
@@ -70,13 +83,61 @@ func SharedGatewayController() *GatewayController {
 
 func (c *GatewayController) InitGatewayAPIInformers(cs gatewayclientset.Interface) {
 	gatewayFactory := gatewayexternalversions.NewSharedInformerFactory(cs, time.Second*30)
-	akogatewayapilib.AKOControlConfig().SetGatewayApiInformers(&akogatewayapilib.GatewayAPIInformers{
+	informers := &akogatewayapilib.GatewayAPIInformers{
 		GatewayInformer:      gatewayFactory.Gateway().V1().Gateways(),
 		GatewayClassInformer: gatewayFactory.Gateway().V1().GatewayClasses(),
 		HTTPRouteInformer:    gatewayFactory.Gateway().V1().HTTPRoutes(),
+	}
+	// GRPCRoute is served in v1 only by the recent Gateway API CRDs, the cache of an informer
+	// for a resource which is not served never syncs.
+	if isGatewayAPIResourceServed("grpcroutes", func() error {
+		_, err := cs.GatewayV1().GRPCRoutes(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{Limit: 1})
+		return err
+	}) {
+		informers.GRPCRouteInformer = gatewayFactory.Gateway().V1().GRPCRoutes()
+	}
+	// Without the ReferenceGrant CRD no grant is known, so the cross namespace references are not permitted.
+	if isGatewayAPIResourceServed("referencegrants", func() error {
+		_, err := cs.GatewayV1beta1().ReferenceGrants(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{Limit: 1})
+		return err
+	}) {
+		informers.ReferenceGrantInformer = gatewayFactory.Gateway().V1beta1().ReferenceGrants()
+	}
+	if akogatewayapilib.IsExperimentalRoutesEnabled() {
+		informers.TCPRouteInformer = gatewayFactory.Gateway().V1alpha2().TCPRoutes()
+		informers.UDPRouteInformer = gatewayFactory.Gateway().V1alpha2().UDPRoutes()
+		informers.TLSRouteInformer = gatewayFactory.Gateway().V1alpha2().TLSRoutes()
+	}
+	if akogatewayapilib.IsBackendTLSPolicyEnabled() {
+		informers.BackendTLSPolicyInformer = gatewayFactory.Gateway().V1alpha3().BackendTLSPolicies()
+	}
+	// the ConfigMap informer is started only once the ConfigMaps are referred, see StartConfigMapInformer
+	informers.ConfigMapInformer = kubeinformers.NewSharedInformerFactory(utils.GetInformers().ClientSet, time.Second*30).Core().V1().ConfigMaps()
+	akogatewayapilib.AKOControlConfig().SetGatewayApiInformers(informers)
+}
+
+// StartConfigMapInformer starts watching the ConfigMaps of all the namespaces and waits for the cache to sync.
+// The ConfigMaps are referred only by the BackendTLSPolicies and the Gateway frontendValidation, hence the
+// informer is started with the BackendTLSPolicy support or when a Gateway first refers a CA ConfigMap.
+func (c *GatewayController) StartConfigMapInformer() {
+	c.configMapInformerOnce.Do(func() {
+		informer := akogatewayapilib.AKOControlConfig().GatewayApiInformers().ConfigMapInformer
+		utils.AviLog.Infof("Starting the ConfigMap informer")
+		go informer.Informer().Run(c.stopCh)
+		if !cache.WaitForCacheSync(c.stopCh, informer.Informer().HasSynced) {
+			runtime.HandleError(fmt.Errorf("timed out waiting for ConfigMap cache to sync"))
+		}
 	})
 }
 
+func isGatewayAPIResourceServed(resource string, list func() error) bool {
+	if err := list(); err != nil {
+		utils.AviLog.Warnf("Resource %s is not available in the cluster, err: %v", resource, err)
+		return false
+	}
+	return true
+}
+
 func NewInfraSettingCRDInformer() {
 	akoInformerFactory := v1beta1akoinformers.NewSharedInformerFactoryWithOptions(akogatewayapilib.AKOControlConfig().V1Beta1CRDClientSet(), time.Second*30)
 	aviSettingsInformer := akoInformerFactory.Ako().V1beta1().AviInfraSettings()
@@ -110,6 +171,34 @@ func (c *GatewayController) Start(stopCh <-chan struct{}) {
 	informersList = append(informersList, akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayInformer.Informer().HasSynced)
 	go akogatewayapilib.AKOControlConfig().GatewayApiInformers().HTTPRouteInformer.Informer().Run(stopCh)
 	informersList = append(informersList, akogatewayapilib.AKOControlConfig().GatewayApiInformers().HTTPRouteInformer.Informer().HasSynced)
+	if akogatewayapilib.AKOControlConfig().GatewayApiInformers().GRPCRouteInformer != nil {
+		go akogatewayapilib.AKOControlConfig().GatewayApiInformers().GRPCRouteInformer.Informer().Run(stopCh)
+		informersList = append(informersList, akogatewayapilib.AKOControlConfig().GatewayApiInformers().GRPCRouteInformer.Informer().HasSynced)
+	}
+	if akogatewayapilib.AKOControlConfig().GatewayApiInformers().ReferenceGrantInformer != nil {
+		go akogatewayapilib.AKOControlConfig().GatewayApiInformers().ReferenceGrantInformer.Informer().Run(stopCh)
+		informersList = append(informersList, akogatewayapilib.AKOControlConfig().GatewayApiInformers().ReferenceGrantInformer.Informer().HasSynced)
+	}
+	if akogatewayapilib.AKOControlConfig().GatewayApiInformers().TCPRouteInformer != nil {
+		go akogatewayapilib.AKOControlConfig().GatewayApiInformers().TCPRouteInformer.Informer().Run(stopCh)
+		informersList = append(informersList, akogatewayapilib.AKOControlConfig().GatewayApiInformers().TCPRouteInformer.Informer().HasSynced)
+	}
+	if akogatewayapilib.AKOControlConfig().GatewayApiInformers().UDPRouteInformer != nil {
+		go akogatewayapilib.AKOControlConfig().GatewayApiInformers().UDPRouteInformer.Informer().Run(stopCh)
+		informersList = append(informersList, akogatewayapilib.AKOControlConfig().GatewayApiInformers().UDPRouteInformer.Informer().HasSynced)
+	}
+	if akogatewayapilib.AKOControlConfig().GatewayApiInformers().TLSRouteInformer != nil {
+		go akogatewayapilib.AKOControlConfig().GatewayApiInformers().TLSRouteInformer.Informer().Run(stopCh)
+		informersList = append(informersList, akogatewayapilib.AKOControlConfig().GatewayApiInformers().TLSRouteInformer.Informer().HasSynced)
+	}
+	if akogatewayapilib.AKOControlConfig().GatewayApiInformers().BackendTLSPolicyInformer != nil {
+		go akogatewayapilib.AKOControlConfig().GatewayApiInformers().BackendTLSPolicyInformer.Informer().Run(stopCh)
+		informersList = append(informersList, akogatewayapilib.AKOControlConfig().GatewayApiInformers().BackendTLSPolicyInformer.Informer().HasSynced)
+	}
+	c.stopCh = stopCh
+	if akogatewayapilib.IsBackendTLSPolicyEnabled() {
+		c.StartConfigMapInformer()
+	}
 
 	if akogatewayapilib.AKOControlConfig().AviInfraSettingEnabled() {
 		go akogatewayapilib.AKOControlConfig().AviInfraSettingInformer().Informer().Run(stopCh)
@@ -120,6 +209,14 @@ func (c *GatewayController) Start(stopCh <-chan struct{}) {
 		go c.dynamicInformers.L7CRDInformer.Informer().Run(stopCh)
 		informersList = append(informersList, c.dynamicInformers.L7CRDInformer.Informer().HasSynced)
 	}
+	if c.dynamicInformers.AKOServiceImportInformer != nil {
+		go c.dynamicInformers.AKOServiceImportInformer.Informer().Run(stopCh)
+		informersList = append(informersList, c.dynamicInformers.AKOServiceImportInformer.Informer().HasSynced)
+	}
+	if c.dynamicInformers.MultiClusterServiceImportInformer != nil {
+		go c.dynamicInformers.MultiClusterServiceImportInformer.Informer().Run(stopCh)
+		informersList = append(informersList, c.dynamicInformers.MultiClusterServiceImportInformer.Informer().HasSynced)
+	}
 	// Add CRD informers only if AKO CRD Operator is enabled
 	if lib.IsAKOCRDOperatorEnabled() {
 		if c.dynamicInformers.AppProfileCRDInformer != nil {
@@ -158,6 +255,11 @@ func (c *GatewayController) SetupEventHandlers(k8sinfo k8s.K8sinformers) {
 			}
 			eps := obj.(*discovery.EndpointSlice)
 			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(eps))
+			if key := c.getMultiClusterServiceImportKey(eps); key != "" {
+				c.workqueue[utils.Bkt(namespace, numWorkers)].AddRateLimited(key)
+				utils.AviLog.Debugf("key: %s, msg: Endpointslice ADD", key)
+				return
+			}
 			svcName, ok := eps.Labels[discovery.LabelServiceName]
 			if !ok || svcName == "" {
 				utils.AviLog.Debugf("Endpointslice Add event: Endpointslice does not have backing svc")
@@ -187,6 +289,11 @@ func (c *GatewayController) SetupEventHandlers(k8sinfo k8s.K8sinformers) {
 				}
 			}
 			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(eps))
+			if key := c.getMultiClusterServiceImportKey(eps); key != "" {
+				c.workqueue[utils.Bkt(namespace, numWorkers)].AddRateLimited(key)
+				utils.AviLog.Debugf("key: %s, msg: Endpointslice DELETE", key)
+				return
+			}
 			svcName, ok := eps.Labels[discovery.LabelServiceName]
 			if !ok || svcName == "" {
 				utils.AviLog.Debugf("Endpointslice Delete event: Endpointslice does not have backing svc")
@@ -205,6 +312,11 @@ func (c *GatewayController) SetupEventHandlers(k8sinfo k8s.K8sinformers) {
 			currentEndpointSlice := cur.(*discovery.EndpointSlice)
 			if oldEndpointSlice.ResourceVersion != currentEndpointSlice.ResourceVersion {
 				namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(currentEndpointSlice))
+				if key := c.getMultiClusterServiceImportKey(currentEndpointSlice); key != "" {
+					c.workqueue[utils.Bkt(namespace, numWorkers)].AddRateLimited(key)
+					utils.AviLog.Debugf("key: %s, msg: Endpointslice UPDATE", key)
+					return
+				}
 				svcName, ok := currentEndpointSlice.Labels[discovery.LabelServiceName]
 				if !ok || svcName == "" {
 					svcNameOld, ok := oldEndpointSlice.Labels[discovery.LabelServiceName]
@@ -324,7 +436,7 @@ func (c *GatewayController) SetupEventHandlers(k8sinfo k8s.K8sinformers) {
 			svc := obj.(*corev1.Service)
 			key := utils.Service + "/" + utils.ObjKey(svc)
 			if !lib.ValidServiceType(svc) {
-				utils.AviLog.Warnf("key: %s, msg: Invalid service type: [%s] Currently Allowed: [ClusterIP, NodePort, LoadBalancer]", key, string(svc.Spec.Type))
+				utils.AviLog.Warnf("key: %s, msg: Invalid service type: [%s] Currently Allowed: [ClusterIP, NodePort, LoadBalancer, ExternalName]", key, string(svc.Spec.Type))
 				return
 			}
 			ok, resVer := objects.SharedResourceVerInstanceLister().Get(key)
@@ -357,7 +469,7 @@ func (c *GatewayController) SetupEventHandlers(k8sinfo k8s.K8sinformers) {
 			}
 			key := utils.Service + "/" + utils.ObjKey(svc)
 			if !lib.ValidServiceType(svc) {
-				utils.AviLog.Warnf("key: %s, msg: Invalid service type: [%s] Currently Allowed: [ClusterIP, NodePort, LoadBalancer]", key, string(svc.Spec.Type))
+				utils.AviLog.Warnf("key: %s, msg: Invalid service type: [%s] Currently Allowed: [ClusterIP, NodePort, LoadBalancer, ExternalName]", key, string(svc.Spec.Type))
 				return
 			}
 			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(svc))
@@ -374,7 +486,7 @@ func (c *GatewayController) SetupEventHandlers(k8sinfo k8s.K8sinformers) {
 			svc := cur.(*corev1.Service)
 			key := utils.Service + "/" + utils.ObjKey(svc)
 			if !lib.ValidServiceType(svc) {
-				utils.AviLog.Warnf("key: %s, msg: Invalid service type: [%s] Currently Allowed: [ClusterIP, NodePort, LoadBalancer]", key, string(svc.Spec.Type))
+				utils.AviLog.Warnf("key: %s, msg: Invalid service type: [%s] Currently Allowed: [ClusterIP, NodePort, LoadBalancer, ExternalName]", key, string(svc.Spec.Type))
 				return
 			}
 			if oldobj.ResourceVersion != svc.ResourceVersion {
@@ -404,6 +516,7 @@ func (c *GatewayController) SetupEventHandlers(k8sinfo k8s.K8sinformers) {
 			ValidateGatewayListenerWithSecret(key, namespace, name, false)
 			c.workqueue[bkt].AddRateLimited(key)
 			utils.AviLog.Debugf("key: %s, msg: ADD", key)
+			c.addCACertReferrersToIngestionQueue(key, utils.Secret, namespace, name, numWorkers)
 		},
 		DeleteFunc: func(obj interface{}) {
 			if c.DisableSync {
@@ -433,6 +546,7 @@ func (c *GatewayController) SetupEventHandlers(k8sinfo k8s.K8sinformers) {
 				ValidateGatewayListenerWithSecret(key, namespace, name, true)
 				c.workqueue[bkt].AddRateLimited(key)
 				utils.AviLog.Debugf("key: %s, msg: DELETE", key)
+				c.addCACertReferrersToIngestionQueue(key, utils.Secret, namespace, name, numWorkers)
 			}
 		},
 		UpdateFunc: func(old, cur interface{}) {
@@ -454,6 +568,7 @@ func (c *GatewayController) SetupEventHandlers(k8sinfo k8s.K8sinformers) {
 					ValidateGatewayListenerWithSecret(key, namespace, name, false)
 					c.workqueue[bkt].AddRateLimited(key)
 					utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
+					c.addCACertReferrersToIngestionQueue(key, utils.Secret, namespace, name, numWorkers)
 				}
 			}
 		},
@@ -494,6 +609,12 @@ func (c *GatewayController) SetupGatewayApiEventHandlers(numWorkers uint32) {
 				return
 			}
 			valid, _ := IsValidGateway(key, gw)
+			// a new gateway can only take over the listeners of the gateways it takes precedence over
+			addGatewaysWithConflictingListenersToIngestionQueue(numWorkers, c, getGatewaysWithLowerPrecedence(gw, akogatewayapiobjects.GatewayApiLister().GetGatewaysSharingListenerHostnamePorts(utils.ObjKey(gw))))
+			if akogatewayapilib.IsGatewayClassInMergeMode(string(gw.Spec.GatewayClassName)) {
+				// and the ports of the gateways merged with it
+				addGatewaysWithConflictingListenersToIngestionQueue(numWorkers, c, getGatewaysWithLowerPrecedence(gw, akogatewayapiobjects.GatewayApiLister().GetGatewayClassToMergedGateways(string(gw.Spec.GatewayClassName))))
+			}
 			if !valid {
 				return
 			}
@@ -526,6 +647,14 @@ func (c *GatewayController) SetupGatewayApiEventHandlers(numWorkers uint32) {
 			bkt := utils.Bkt(namespace, numWorkers)
 			c.workqueue[bkt].AddRateLimited(key)
 			akogatewayapiobjects.GatewayApiLister().DeleteGatewayToGatewayStatusMapping(utils.ObjKey(gw))
+			// the listeners conflicting with the deleted gateway are revalidated
+			sharedGateways := akogatewayapiobjects.GatewayApiLister().GetGatewaysSharingListenerHostnamePorts(utils.ObjKey(gw))
+			akogatewayapiobjects.GatewayApiLister().DeleteGatewayToListenerHostnamePorts(utils.ObjKey(gw))
+			addGatewaysWithConflictingListenersToIngestionQueue(numWorkers, c, sharedGateways)
+			// the ports of the deleted gateway are released for the gateways merged with it
+			if akogatewayapilib.IsGatewayClassInMergeMode(string(gw.Spec.GatewayClassName)) {
+				addMergedGatewayClassToIngestionQueue(numWorkers, c, string(gw.Spec.GatewayClassName))
+			}
 			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
 		},
 		UpdateFunc: func(old, obj interface{}) {
@@ -536,8 +665,29 @@ func (c *GatewayController) SetupGatewayApiEventHandlers(numWorkers uint32) {
 			gw := obj.(*gatewayv1.Gateway)
 			if IsGatewayUpdated(oldGw, gw) {
 				key := lib.Gateway + "/" + utils.ObjKey(gw)
+				// the gateways sharing the hostnames before and after the update are revalidated
+				sharedGateways := akogatewayapiobjects.GatewayApiLister().GetGatewaysSharingListenerHostnamePorts(utils.ObjKey(gw))
 				valid, _ := IsValidGateway(key, gw)
-				if !valid {
+				for _, gwNsName := range akogatewayapiobjects.GatewayApiLister().GetGatewaysSharingListenerHostnamePorts(utils.ObjKey(gw)) {
+					if !utils.HasElem(sharedGateways, gwNsName) {
+						sharedGateways = append(sharedGateways, gwNsName)
+					}
+				}
+				addGatewaysWithConflictingListenersToIngestionQueue(numWorkers, c, sharedGateways)
+				// the gateways merged with the gateway before and after the update are revalidated, and the
+				// gateway is processed even when invalid, to be removed from the parent VS shared with them
+				gwClasses := []string{string(gw.Spec.GatewayClassName)}
+				if oldGw.Spec.GatewayClassName != gw.Spec.GatewayClassName {
+					gwClasses = append(gwClasses, string(oldGw.Spec.GatewayClassName))
+				}
+				inMergeMode := false
+				for _, gwClass := range gwClasses {
+					if akogatewayapilib.IsGatewayClassInMergeMode(gwClass) {
+						addMergedGatewayClassToIngestionQueue(numWorkers, c, gwClass)
+						inMergeMode = true
+					}
+				}
+				if !valid && !inMergeMode {
 					return
 				}
 				namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(gw))
@@ -605,8 +755,19 @@ func (c *GatewayController) SetupGatewayApiEventHandlers(numWorkers uint32) {
 			}
 			oldGwClass := old.(*gatewayv1.GatewayClass)
 			gwClass := obj.(*gatewayv1.GatewayClass)
-			if !reflect.DeepEqual(oldGwClass.Spec, gwClass.Spec) || gwClass.GetDeletionTimestamp() != nil {
+			mergeModeUpdated := oldGwClass.GetAnnotations()[akogatewayapilib.MergeGatewaysAnnotation] != gwClass.GetAnnotations()[akogatewayapilib.MergeGatewaysAnnotation]
+			if !reflect.DeepEqual(oldGwClass.Spec, gwClass.Spec) || gwClass.GetDeletionTimestamp() != nil || mergeModeUpdated {
 				key := lib.GatewayClass + "/" + utils.ObjKey(gwClass)
+				if mergeModeUpdated {
+					// the parent VS of each gateway is replaced by the shared one, or the other way around
+					addGatewaysFromGatewayClassToIngestionQueue(numWorkers, c, gwClass.Name)
+				} else if !reflect.DeepEqual(oldGwClass.Spec.ParametersRef, gwClass.Spec.ParametersRef) {
+					if akogatewayapilib.IsGatewayClassInMergeMode(gwClass.Name) {
+						addMergedGatewayClassToIngestionQueue(numWorkers, c, gwClass.Name)
+					} else {
+						addGatewaysFromGatewayClassToIngestionQueue(numWorkers, c, gwClass.Name)
+					}
+				}
 				if !IsGatewayClassValid(key, gwClass) {
 					return
 				}
@@ -684,6 +845,477 @@ func (c *GatewayController) SetupGatewayApiEventHandlers(numWorkers uint32) {
 		},
 	}
 	informer.HTTPRouteInformer.Informer().AddEventHandler(httpRouteEventHandler)
+
+	grpcRouteEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			grpcRoute := obj.(*gatewayv1.GRPCRoute)
+			key := lib.GRPCRoute + "/" + utils.ObjKey(grpcRoute)
+			ok, resVer := objects.SharedResourceVerInstanceLister().Get(key)
+			if ok && resVer.(string) == grpcRoute.ResourceVersion {
+				utils.AviLog.Debugf("key: %s, msg: same resource version returning", key)
+				return
+			}
+			if !IsGRPCRouteConfigValid(key, grpcRoute) {
+				return
+			}
+			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(grpcRoute))
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+			utils.AviLog.Debugf("key: %s, msg: ADD", key)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			grpcRoute, ok := obj.(*gatewayv1.GRPCRoute)
+			if !ok {
+				// grpcRoute was deleted but its final state is unrecorded.
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
+					return
+				}
+				grpcRoute, ok = tombstone.Obj.(*gatewayv1.GRPCRoute)
+				if !ok {
+					utils.AviLog.Errorf("Tombstone contained object that is not a GRPCRoute: %#v", obj)
+					return
+				}
+			}
+			key := lib.GRPCRoute + "/" + utils.ObjKey(grpcRoute)
+			objects.SharedResourceVerInstanceLister().Delete(key)
+			namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(grpcRoute))
+			bkt := utils.Bkt(namespace, numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+			akogatewayapiobjects.GatewayApiLister().DeleteRouteToRouteStatusMapping(key)
+			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
+		},
+		UpdateFunc: func(old, obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			oldGRPCRoute := old.(*gatewayv1.GRPCRoute)
+			newGRPCRoute := obj.(*gatewayv1.GRPCRoute)
+			if IsGRPCRouteUpdated(oldGRPCRoute, newGRPCRoute) {
+				key := lib.GRPCRoute + "/" + utils.ObjKey(newGRPCRoute)
+				if !IsGRPCRouteConfigValid(key, newGRPCRoute) {
+					return
+				}
+				namespace, _, _ := cache.SplitMetaNamespaceKey(utils.ObjKey(newGRPCRoute))
+				bkt := utils.Bkt(namespace, numWorkers)
+				c.workqueue[bkt].AddRateLimited(key)
+				utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
+			}
+		},
+	}
+	if informer.GRPCRouteInformer != nil {
+		informer.GRPCRouteInformer.Informer().AddEventHandler(grpcRouteEventHandler)
+	}
+
+	if informer.TCPRouteInformer != nil {
+		informer.TCPRouteInformer.Informer().AddEventHandler(c.l4RouteEventHandler(lib.TCPRoute, numWorkers))
+	}
+	if informer.UDPRouteInformer != nil {
+		informer.UDPRouteInformer.Informer().AddEventHandler(c.l4RouteEventHandler(lib.UDPRoute, numWorkers))
+	}
+	if informer.TLSRouteInformer != nil {
+		informer.TLSRouteInformer.Informer().AddEventHandler(c.l4RouteEventHandler(lib.TLSRoute, numWorkers))
+	}
+
+	referenceGrantEventHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			referenceGrant := obj.(*gatewayv1beta1.ReferenceGrant)
+			key := lib.ReferenceGrant + "/" + utils.ObjKey(referenceGrant)
+			akogatewayapiobjects.GatewayApiLister().UpdateReferenceGrant(referenceGrant.Namespace, referenceGrant.Name, referenceGrant.Spec)
+			utils.AviLog.Debugf("key: %s, msg: ADD", key)
+			c.addReferenceGrantSourcesToIngestionQueue(key, referenceGrant.Spec.From, numWorkers)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			referenceGrant, ok := obj.(*gatewayv1beta1.ReferenceGrant)
+			if !ok {
+				// referenceGrant was deleted but its final state is unrecorded.
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
+					return
+				}
+				referenceGrant, ok = tombstone.Obj.(*gatewayv1beta1.ReferenceGrant)
+				if !ok {
+					utils.AviLog.Errorf("Tombstone contained object that is not a ReferenceGrant: %#v", obj)
+					return
+				}
+			}
+			key := lib.ReferenceGrant + "/" + utils.ObjKey(referenceGrant)
+			akogatewayapiobjects.GatewayApiLister().DeleteReferenceGrant(referenceGrant.Namespace, referenceGrant.Name)
+			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
+			c.addReferenceGrantSourcesToIngestionQueue(key, referenceGrant.Spec.From, numWorkers)
+		},
+		UpdateFunc: func(old, obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			oldReferenceGrant := old.(*gatewayv1beta1.ReferenceGrant)
+			referenceGrant := obj.(*gatewayv1beta1.ReferenceGrant)
+			if utils.Hash(utils.Stringify(oldReferenceGrant.Spec)) == utils.Hash(utils.Stringify(referenceGrant.Spec)) {
+				return
+			}
+			key := lib.ReferenceGrant + "/" + utils.ObjKey(referenceGrant)
+			akogatewayapiobjects.GatewayApiLister().UpdateReferenceGrant(referenceGrant.Namespace, referenceGrant.Name, referenceGrant.Spec)
+			utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
+			// objects which lost the grant have to be processed as well
+			fromList := make([]gatewayv1beta1.ReferenceGrantFrom, 0, len(oldReferenceGrant.Spec.From)+len(referenceGrant.Spec.From))
+			fromList = append(fromList, oldReferenceGrant.Spec.From...)
+			fromList = append(fromList, referenceGrant.Spec.From...)
+			c.addReferenceGrantSourcesToIngestionQueue(key, fromList, numWorkers)
+		},
+	}
+	if informer.ReferenceGrantInformer != nil {
+		informer.ReferenceGrantInformer.Informer().AddEventHandler(referenceGrantEventHandler)
+	}
+
+	if informer.BackendTLSPolicyInformer != nil {
+		backendTLSPolicyEventHandler := cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				if c.DisableSync {
+					return
+				}
+				backendTLSPolicy := obj.(*gatewayv1alpha3.BackendTLSPolicy)
+				key := lib.BackendTLSPolicy + "/" + utils.ObjKey(backendTLSPolicy)
+				bkt := utils.Bkt(backendTLSPolicy.Namespace, numWorkers)
+				c.workqueue[bkt].AddRateLimited(key)
+				utils.AviLog.Debugf("key: %s, msg: ADD", key)
+			},
+			DeleteFunc: func(obj interface{}) {
+				if c.DisableSync {
+					return
+				}
+				backendTLSPolicy, ok := obj.(*gatewayv1alpha3.BackendTLSPolicy)
+				if !ok {
+					// backendTLSPolicy was deleted but its final state is unrecorded.
+					tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+					if !ok {
+						utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
+						return
+					}
+					backendTLSPolicy, ok = tombstone.Obj.(*gatewayv1alpha3.BackendTLSPolicy)
+					if !ok {
+						utils.AviLog.Errorf("Tombstone contained object that is not a BackendTLSPolicy: %#v", obj)
+						return
+					}
+				}
+				key := lib.BackendTLSPolicy + "/" + utils.ObjKey(backendTLSPolicy)
+				bkt := utils.Bkt(backendTLSPolicy.Namespace, numWorkers)
+				c.workqueue[bkt].AddRateLimited(key)
+				utils.AviLog.Debugf("key: %s, msg: DELETE", key)
+				// policies which were conflicting with the deleted policy can now be applied
+				c.addConflictingBackendTLSPoliciesToIngestionQueue(key, backendTLSPolicy, numWorkers)
+			},
+			UpdateFunc: func(old, obj interface{}) {
+				if c.DisableSync {
+					return
+				}
+				oldBackendTLSPolicy := old.(*gatewayv1alpha3.BackendTLSPolicy)
+				backendTLSPolicy := obj.(*gatewayv1alpha3.BackendTLSPolicy)
+				if utils.Hash(utils.Stringify(oldBackendTLSPolicy.Spec)) == utils.Hash(utils.Stringify(backendTLSPolicy.Spec)) {
+					return
+				}
+				key := lib.BackendTLSPolicy + "/" + utils.ObjKey(backendTLSPolicy)
+				bkt := utils.Bkt(backendTLSPolicy.Namespace, numWorkers)
+				c.workqueue[bkt].AddRateLimited(key)
+				utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
+				c.addConflictingBackendTLSPoliciesToIngestionQueue(key, oldBackendTLSPolicy, numWorkers)
+			},
+		}
+		informer.BackendTLSPolicyInformer.Informer().AddEventHandler(backendTLSPolicyEventHandler)
+	}
+
+	if informer.ConfigMapInformer != nil {
+		configMapEventHandler := cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				if c.DisableSync {
+					return
+				}
+				configMap := obj.(*corev1.ConfigMap)
+				c.addConfigMapReferrersToIngestionQueue(akogatewayapilib.ConfigMapKind+"/"+utils.ObjKey(configMap), configMap, numWorkers)
+			},
+			DeleteFunc: func(obj interface{}) {
+				if c.DisableSync {
+					return
+				}
+				configMap, ok := obj.(*corev1.ConfigMap)
+				if !ok {
+					// configMap was deleted but its final state is unrecorded.
+					tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+					if !ok {
+						utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
+						return
+					}
+					configMap, ok = tombstone.Obj.(*corev1.ConfigMap)
+					if !ok {
+						utils.AviLog.Errorf("Tombstone contained object that is not a ConfigMap: %#v", obj)
+						return
+					}
+				}
+				c.addConfigMapReferrersToIngestionQueue(akogatewayapilib.ConfigMapKind+"/"+utils.ObjKey(configMap), configMap, numWorkers)
+			},
+			UpdateFunc: func(old, obj interface{}) {
+				if c.DisableSync {
+					return
+				}
+				oldConfigMap := old.(*corev1.ConfigMap)
+				configMap := obj.(*corev1.ConfigMap)
+				if oldConfigMap.ResourceVersion == configMap.ResourceVersion || reflect.DeepEqual(oldConfigMap.Data, configMap.Data) {
+					return
+				}
+				c.addConfigMapReferrersToIngestionQueue(akogatewayapilib.ConfigMapKind+"/"+utils.ObjKey(configMap), configMap, numWorkers)
+			},
+		}
+		informer.ConfigMapInformer.Informer().AddEventHandler(configMapEventHandler)
+	}
+}
+
+// addConfigMapReferrersToIngestionQueue enqueues the BackendTLSPolicies and Gateways which refer to the ConfigMap
+// for their CA certificates.
+func (c *GatewayController) addConfigMapReferrersToIngestionQueue(key string, configMap *corev1.ConfigMap, numWorkers uint32) {
+	for _, policyNsName := range akogatewayapiobjects.GatewayApiLister().GetConfigMapToBackendTLSPolicy(configMap.Namespace + "/" + configMap.Name) {
+		policyKey := lib.BackendTLSPolicy + "/" + policyNsName
+		bkt := utils.Bkt(configMap.Namespace, numWorkers)
+		c.workqueue[bkt].AddRateLimited(policyKey)
+		utils.AviLog.Debugf("key: %s, msg: BackendTLSPolicy %s added to ingestion queue", key, policyKey)
+	}
+	c.addCACertReferrersToIngestionQueue(key, akogatewayapilib.ConfigMapKind, configMap.Namespace, configMap.Name, numWorkers)
+}
+
+// addCACertReferrersToIngestionQueue revalidates and enqueues the Gateways which refer to the ConfigMap or Secret
+// for the CA certificates validating the client certificates of their listeners.
+func (c *GatewayController) addCACertReferrersToIngestionQueue(key, kind, namespace, name string, numWorkers uint32) {
+	gwNsNames := akogatewayapiobjects.GatewayApiLister().GetCACertToGateway(kind + "/" + namespace + "/" + name)
+	if len(gwNsNames) == 0 {
+		return
+	}
+	utils.AviLog.Debugf("key: %s, msg: Gateways %v refer to %s %s/%s for the CA certificates", key, gwNsNames, kind, namespace, name)
+	addGatewaysWithConflictingListenersToIngestionQueue(numWorkers, c, gwNsNames)
+}
+
+// addConflictingBackendTLSPoliciesToIngestionQueue enqueues the other BackendTLSPolicies in the
+// namespace which target any of the Services targeted by backendTLSPolicy.
+func (c *GatewayController) addConflictingBackendTLSPoliciesToIngestionQueue(key string, backendTLSPolicy *gatewayv1alpha3.BackendTLSPolicy, numWorkers uint32) {
+	backendTLSPolicies, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().BackendTLSPolicyInformer.Lister().BackendTLSPolicies(backendTLSPolicy.Namespace).List(labels.Set(nil).AsSelector())
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to list BackendTLSPolicies in namespace %s, err: %s", key, backendTLSPolicy.Namespace, err)
+		return
+	}
+	services := make(map[string]struct{})
+	for _, targetRef := range backendTLSPolicy.Spec.TargetRefs {
+		services[string(targetRef.Name)] = struct{}{}
+	}
+	bkt := utils.Bkt(backendTLSPolicy.Namespace, numWorkers)
+	for _, policy := range backendTLSPolicies {
+		if policy.Name == backendTLSPolicy.Name {
+			continue
+		}
+		for _, targetRef := range policy.Spec.TargetRefs {
+			if _, ok := services[string(targetRef.Name)]; ok {
+				policyKey := lib.BackendTLSPolicy + "/" + utils.ObjKey(policy)
+				c.workqueue[bkt].AddRateLimited(policyKey)
+				utils.AviLog.Debugf("key: %s, msg: BackendTLSPolicy %s added to ingestion queue", key, policyKey)
+				break
+			}
+		}
+	}
+}
+
+// addReferenceGrantSourcesToIngestionQueue re-validates and enqueues the Gateways and routes
+// whose references to other namespaces may be permitted or denied by a ReferenceGrant change.
+func (c *GatewayController) addReferenceGrantSourcesToIngestionQueue(key string, fromList []gatewayv1beta1.ReferenceGrantFrom, numWorkers uint32) {
+	processed := make(map[string]struct{})
+	for _, from := range fromList {
+		if string(from.Group) != akogatewayapilib.GatewayGroup {
+			continue
+		}
+		kindNamespace := string(from.Kind) + "/" + string(from.Namespace)
+		if _, ok := processed[kindNamespace]; ok {
+			continue
+		}
+		processed[kindNamespace] = struct{}{}
+		utils.AviLog.Debugf("key: %s, msg: processing %s objects in namespace %s", key, from.Kind, from.Namespace)
+		if string(from.Kind) == lib.Gateway {
+			addGatewaysFromNamespaceToIngestionQueue(utils.Namespace+"/"+string(from.Namespace), numWorkers, c)
+			continue
+		}
+		c.addRoutesFromNamespaceToIngestionQueue(string(from.Kind), string(from.Namespace), numWorkers)
+	}
+}
+
+func (c *GatewayController) addRoutesFromNamespaceToIngestionQueue(routeType, namespace string, numWorkers uint32) {
+	informer := akogatewayapilib.AKOControlConfig().GatewayApiInformers()
+	selector := labels.Set(nil).AsSelector()
+	var keys []string
+	var err error
+	switch routeType {
+	case lib.HTTPRoute:
+		var routes []*gatewayv1.HTTPRoute
+		routes, err = informer.HTTPRouteInformer.Lister().HTTPRoutes(namespace).List(selector)
+		for _, route := range routes {
+			key := routeType + "/" + utils.ObjKey(route)
+			if IsHTTPRouteConfigValid(key, route) {
+				keys = append(keys, key)
+			}
+		}
+	case lib.GRPCRoute:
+		if informer.GRPCRouteInformer == nil {
+			return
+		}
+		var routes []*gatewayv1.GRPCRoute
+		routes, err = informer.GRPCRouteInformer.Lister().GRPCRoutes(namespace).List(selector)
+		for _, route := range routes {
+			key := routeType + "/" + utils.ObjKey(route)
+			if IsGRPCRouteConfigValid(key, route) {
+				keys = append(keys, key)
+			}
+		}
+	case lib.TCPRoute:
+		if informer.TCPRouteInformer == nil {
+			return
+		}
+		var routes []*gatewayv1alpha2.TCPRoute
+		routes, err = informer.TCPRouteInformer.Lister().TCPRoutes(namespace).List(selector)
+		for _, route := range routes {
+			key := routeType + "/" + utils.ObjKey(route)
+			if IsL4RouteConfigValid(key, route.Spec.ParentRefs) {
+				keys = append(keys, key)
+			}
+		}
+	case lib.UDPRoute:
+		if informer.UDPRouteInformer == nil {
+			return
+		}
+		var routes []*gatewayv1alpha2.UDPRoute
+		routes, err = informer.UDPRouteInformer.Lister().UDPRoutes(namespace).List(selector)
+		for _, route := range routes {
+			key := routeType + "/" + utils.ObjKey(route)
+			if IsL4RouteConfigValid(key, route.Spec.ParentRefs) {
+				keys = append(keys, key)
+			}
+		}
+	case lib.TLSRoute:
+		if informer.TLSRouteInformer == nil {
+			return
+		}
+		var routes []*gatewayv1alpha2.TLSRoute
+		routes, err = informer.TLSRouteInformer.Lister().TLSRoutes(namespace).List(selector)
+		for _, route := range routes {
+			key := routeType + "/" + utils.ObjKey(route)
+			if IsL4RouteConfigValid(key, route.Spec.ParentRefs) {
+				keys = append(keys, key)
+			}
+		}
+	default:
+		return
+	}
+	if err != nil {
+		utils.AviLog.Warnf("failed to list %ss in the Namespace %s, err: %s", routeType, namespace, err.Error())
+		return
+	}
+	bkt := utils.Bkt(namespace, numWorkers)
+	for _, key := range keys {
+		c.workqueue[bkt].AddRateLimited(key)
+		utils.AviLog.Debugf("key: %s, msg: ADD for %s", key, routeType)
+	}
+}
+
+// l4RouteEventHandler returns the event handler for TCPRoute, UDPRoute and TLSRoute objects,
+// all of which are keyed as <routeType>/<namespace>/<name> in the ingestion queue.
+func (c *GatewayController) l4RouteEventHandler(routeType string, numWorkers uint32) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			route, parentRefs, _ := getL4RouteObject(obj)
+			if route == nil {
+				return
+			}
+			key := routeType + "/" + utils.ObjKey(route)
+			ok, resVer := objects.SharedResourceVerInstanceLister().Get(key)
+			if ok && resVer.(string) == route.GetResourceVersion() {
+				utils.AviLog.Debugf("key: %s, msg: same resource version returning", key)
+				return
+			}
+			if !IsL4RouteConfigValid(key, parentRefs) {
+				return
+			}
+			bkt := utils.Bkt(route.GetNamespace(), numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+			utils.AviLog.Debugf("key: %s, msg: ADD", key)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			route, _, _ := getL4RouteObject(obj)
+			if route == nil {
+				// route was deleted but its final state is unrecorded.
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
+					return
+				}
+				route, _, _ = getL4RouteObject(tombstone.Obj)
+				if route == nil {
+					utils.AviLog.Errorf("Tombstone contained object that is not a %s: %#v", routeType, obj)
+					return
+				}
+			}
+			key := routeType + "/" + utils.ObjKey(route)
+			objects.SharedResourceVerInstanceLister().Delete(key)
+			bkt := utils.Bkt(route.GetNamespace(), numWorkers)
+			c.workqueue[bkt].AddRateLimited(key)
+			akogatewayapiobjects.GatewayApiLister().DeleteRouteToRouteStatusMapping(key)
+			utils.AviLog.Debugf("key: %s, msg: DELETE", key)
+		},
+		UpdateFunc: func(old, obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			_, _, oldSpecHash := getL4RouteObject(old)
+			route, parentRefs, newSpecHash := getL4RouteObject(obj)
+			if route == nil {
+				return
+			}
+			if route.GetDeletionTimestamp() != nil || oldSpecHash != newSpecHash {
+				key := routeType + "/" + utils.ObjKey(route)
+				if !IsL4RouteConfigValid(key, parentRefs) {
+					return
+				}
+				bkt := utils.Bkt(route.GetNamespace(), numWorkers)
+				c.workqueue[bkt].AddRateLimited(key)
+				utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
+			}
+		},
+	}
+}
+
+// getL4RouteObject returns the object metadata, parent references and the spec hash of
+// a TCPRoute, UDPRoute or TLSRoute. nil is returned for any other object.
+func getL4RouteObject(obj interface{}) (metav1.Object, []gatewayv1.ParentReference, uint32) {
+	switch route := obj.(type) {
+	case *gatewayv1alpha2.TCPRoute:
+		return route, route.Spec.ParentRefs, utils.Hash(utils.Stringify(route.Spec))
+	case *gatewayv1alpha2.UDPRoute:
+		return route, route.Spec.ParentRefs, utils.Hash(utils.Stringify(route.Spec))
+	case *gatewayv1alpha2.TLSRoute:
+		return route, route.Spec.ParentRefs, utils.Hash(utils.Stringify(route.Spec))
+	}
+	return nil, nil, 0
 }
 
 func (c *GatewayController) SetupAviInfraSettingEventHandler(numWorkers uint32) {
@@ -699,6 +1331,7 @@ func (c *GatewayController) SetupAviInfraSettingEventHandler(numWorkers uint32)
 				utils.AviLog.Debugf("key: %s, msg: ADD", key)
 
 				addGatewayMappedToInfrasettingToIngestionQueue(numWorkers, c, utils.ObjKey(aviInfra))
+				addGatewaysReferringInfrasettingToIngestionQueue(numWorkers, c, utils.ObjKey(aviInfra))
 			},
 			UpdateFunc: func(old, new interface{}) {
 				if c.DisableSync {
@@ -711,6 +1344,7 @@ func (c *GatewayController) SetupAviInfraSettingEventHandler(numWorkers uint32)
 					utils.AviLog.Debugf("key: %s, msg: UPDATE", key)
 
 					addGatewayMappedToInfrasettingToIngestionQueue(numWorkers, c, utils.ObjKey(aviInfra))
+					addGatewaysReferringInfrasettingToIngestionQueue(numWorkers, c, utils.ObjKey(aviInfra))
 				}
 			},
 			DeleteFunc: func(obj interface{}) {
@@ -733,6 +1367,7 @@ func (c *GatewayController) SetupAviInfraSettingEventHandler(numWorkers uint32)
 				key := lib.AviInfraSetting + "/" + utils.ObjKey(aviInfra)
 				utils.AviLog.Debugf("key: %s, msg: DELETE", key)
 				addGatewayMappedToInfrasettingToIngestionQueue(numWorkers, c, utils.ObjKey(aviInfra))
+				addGatewaysReferringInfrasettingToIngestionQueue(numWorkers, c, utils.ObjKey(aviInfra))
 			},
 		}
 		akogatewayapilib.AKOControlConfig().AviInfraSettingInformer().Informer().AddEventHandler(aviInfraEventHandler)
@@ -752,8 +1387,30 @@ func IsHTTPRouteUpdated(oldHTTPRoute, newHTTPRoute *gatewayv1.HTTPRoute) bool {
 	if newHTTPRoute.GetDeletionTimestamp() != nil {
 		return true
 	}
-	oldHash := utils.Hash(utils.Stringify(oldHTTPRoute.Spec))
-	newHash := utils.Hash(utils.Stringify(newHTTPRoute.Spec))
+	oldHash := utils.Hash(utils.Stringify(oldHTTPRoute.Spec) + oldHTTPRoute.GetAnnotations()[akogatewayapilib.ClusterWeightsAnnotation])
+	newHash := utils.Hash(utils.Stringify(newHTTPRoute.Spec) + newHTTPRoute.GetAnnotations()[akogatewayapilib.ClusterWeightsAnnotation])
+	return oldHash != newHash
+}
+
+// getMultiClusterServiceImportKey returns the key of the ServiceImport of the Multi-Cluster Services API
+// whose endpoints in an exporting cluster are held by the EndpointSlice.
+func (c *GatewayController) getMultiClusterServiceImportKey(eps *discovery.EndpointSlice) string {
+	if c.dynamicInformers == nil || c.dynamicInformers.MultiClusterServiceImportInformer == nil {
+		return ""
+	}
+	siName, ok := eps.Labels[akogatewayapilib.MultiClusterServiceNameLabel]
+	if !ok || siName == "" {
+		return ""
+	}
+	return lib.ServiceImport + "/" + eps.Namespace + "/" + siName
+}
+
+func IsGRPCRouteUpdated(oldGRPCRoute, newGRPCRoute *gatewayv1.GRPCRoute) bool {
+	if newGRPCRoute.GetDeletionTimestamp() != nil {
+		return true
+	}
+	oldHash := utils.Hash(utils.Stringify(oldGRPCRoute.Spec))
+	newHash := utils.Hash(utils.Stringify(newGRPCRoute.Spec))
 	return oldHash != newHash
 }
 
@@ -827,3 +1484,132 @@ func addGatewayMappedToInfrasettingToIngestionQueue(numWorkers uint32, c *Gatewa
 		}
 	}
 }
+
+// addGatewaysReferringInfrasettingToIngestionQueue adds the GatewayClasses and Gateways referring
+// the AviInfraSetting through parametersRef to the ingestion queue.
+func addGatewaysReferringInfrasettingToIngestionQueue(numWorkers uint32, c *GatewayController, name string) {
+	gwClasses, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayClassInformer.Lister().List(labels.Set(nil).AsSelector())
+	if err != nil {
+		utils.AviLog.Warnf("failed to list GatewayClasses, err: %s", err.Error())
+		return
+	}
+	for _, gwClass := range gwClasses {
+		if !akogatewayapilib.CheckGatewayClassController(string(gwClass.Spec.ControllerName)) {
+			continue
+		}
+		infraSettingName, found, _ := akogatewayapilib.GetAviInfraSettingRefNameForGatewayClass(gwClass)
+		if !found || infraSettingName != name {
+			continue
+		}
+		key := lib.GatewayClass + "/" + utils.ObjKey(gwClass)
+		if !IsGatewayClassValid(key, gwClass) {
+			continue
+		}
+		bkt := utils.Bkt("", numWorkers)
+		c.workqueue[bkt].AddRateLimited(key)
+		utils.AviLog.Debugf("key: %s, msg: ADD for GatewayClass", key)
+	}
+
+	gateways, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayInformer.Lister().List(labels.Set(nil).AsSelector())
+	if err != nil {
+		utils.AviLog.Warnf("failed to list Gateways, err: %s", err.Error())
+		return
+	}
+	for _, gateway := range gateways {
+		infraSettingName, found, _ := akogatewayapilib.GetAviInfraSettingRefName(gateway)
+		if !found || infraSettingName != name {
+			continue
+		}
+		key := lib.Gateway + "/" + utils.ObjKey(gateway)
+		valid, _ := IsValidGateway(key, gateway)
+		if !valid {
+			continue
+		}
+		bkt := utils.Bkt(gateway.Namespace, numWorkers)
+		c.workqueue[bkt].AddRateLimited(key)
+		utils.AviLog.Debugf("key: %s, msg: ADD for Gateway", key)
+	}
+}
+
+// addGatewaysFromGatewayClassToIngestionQueue revalidates the Gateways of the GatewayClass
+// and adds the valid ones to the ingestion queue.
+func addGatewaysFromGatewayClassToIngestionQueue(numWorkers uint32, c *GatewayController, gwClassName string) {
+	for _, gateway := range revalidateGatewaysOfGatewayClass(gwClassName) {
+		key := lib.Gateway + "/" + utils.ObjKey(gateway)
+		bkt := utils.Bkt(gateway.Namespace, numWorkers)
+		c.workqueue[bkt].AddRateLimited(key)
+		utils.AviLog.Debugf("key: %s, msg: ADD for Gateway", key)
+	}
+}
+
+// addMergedGatewayClassToIngestionQueue revalidates the Gateways of the GatewayClass in merge mode and adds the
+// GatewayClass to the ingestion queue, to build the parent VS shared by the Gateways once for all of them.
+func addMergedGatewayClassToIngestionQueue(numWorkers uint32, c *GatewayController, gwClassName string) {
+	revalidateGatewaysOfGatewayClass(gwClassName)
+	key := lib.GatewayClass + "/" + gwClassName
+	bkt := utils.Bkt("", numWorkers)
+	c.workqueue[bkt].AddRateLimited(key)
+	utils.AviLog.Debugf("key: %s, msg: UPDATE for merged Gateways", key)
+}
+
+// revalidateGatewaysOfGatewayClass revalidates the Gateways of the GatewayClass and returns the valid ones.
+func revalidateGatewaysOfGatewayClass(gwClassName string) []*gatewayv1.Gateway {
+	gateways, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayInformer.Lister().List(labels.Set(nil).AsSelector())
+	if err != nil {
+		utils.AviLog.Warnf("failed to list Gateways of the GatewayClass %s, err: %s", gwClassName, err.Error())
+		return nil
+	}
+	// the Gateways merged in merge mode are validated against the ones which take precedence, so validate those first
+	sort.Slice(gateways, func(i, j int) bool {
+		return akogatewayapilib.HasGatewayPrecedence(gateways[i], gateways[j])
+	})
+	var validGateways []*gatewayv1.Gateway
+	for _, gateway := range gateways {
+		if string(gateway.Spec.GatewayClassName) != gwClassName {
+			continue
+		}
+		key := lib.Gateway + "/" + utils.ObjKey(gateway)
+		if valid, _ := IsValidGateway(key, gateway); valid {
+			validGateways = append(validGateways, gateway)
+		}
+	}
+	return validGateways
+}
+
+// addGatewaysWithConflictingListenersToIngestionQueue revalidates the Gateways with listeners claiming the same
+// hostname and port as a Gateway which got added, updated or deleted, and adds the valid ones to the ingestion queue.
+func addGatewaysWithConflictingListenersToIngestionQueue(numWorkers uint32, c *GatewayController, gwNsNames []string) {
+	for _, gwNsName := range gwNsNames {
+		namespace, name, _ := cache.SplitMetaNamespaceKey(gwNsName)
+		gateway, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayInformer.Lister().Gateways(namespace).Get(name)
+		if err != nil {
+			utils.AviLog.Debugf("failed to get the Gateway %s, err: %s", gwNsName, err.Error())
+			continue
+		}
+		key := lib.Gateway + "/" + utils.ObjKey(gateway)
+		valid, _ := IsValidGateway(key, gateway)
+		if !valid {
+			continue
+		}
+		bkt := utils.Bkt(gateway.Namespace, numWorkers)
+		c.workqueue[bkt].AddRateLimited(key)
+		utils.AviLog.Debugf("key: %s, msg: ADD for Gateway", key)
+	}
+}
+
+// getGatewaysWithLowerPrecedence returns the Gateways over which the gateway takes precedence in a listener conflict.
+func getGatewaysWithLowerPrecedence(gateway *gatewayv1.Gateway, gwNsNames []string) []string {
+	var lowerPrecedenceGateways []string
+	for _, gwNsName := range gwNsNames {
+		namespace, name, _ := cache.SplitMetaNamespaceKey(gwNsName)
+		otherGateway, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayInformer.Lister().Gateways(namespace).Get(name)
+		if err != nil {
+			utils.AviLog.Debugf("failed to get the Gateway %s, err: %s", gwNsName, err.Error())
+			continue
+		}
+		if akogatewayapilib.HasGatewayPrecedence(gateway, otherGateway) {
+			lowerPrecedenceGateways = append(lowerPrecedenceGateways, gwNsName)
+		}
+	}
+	return lowerPrecedenceGateways
+}
@@ -32,6 +32,9 @@ func (c *GatewayController) SetupCRDEventHandlers(numWorkers uint32) {
 	if !utils.IsWCP() {
 		c.setupL7CRDEventHandlers(numWorkers)
 	}
+	if akogatewayapilib.IsServiceImportEnabled() {
+		c.setupServiceImportEventHandlers(numWorkers)
+	}
 	// Skip setup if AKO CRD Operator is not enabled
 	if !lib.IsAKOCRDOperatorEnabled() {
 		utils.AviLog.Warnf("Skipping event handler setup for AKO CRD Operator managed CRDs as it is not enabled")
@@ -595,3 +598,89 @@ func (c *GatewayController) setupRouteBackendExtensionEventHandler(numWorkers ui
 	}
 	c.dynamicInformers.RouteBackendExtensionCRDInformer.Informer().AddEventHandler(RouteBackendExtensionCRDEventHandler)
 }
+
+func (c *GatewayController) setupServiceImportEventHandlers(numWorkers uint32) {
+	if c.dynamicInformers.AKOServiceImportInformer != nil {
+		c.dynamicInformers.AKOServiceImportInformer.Informer().AddEventHandler(c.serviceImportEventHandler(numWorkers, true))
+	}
+	if c.dynamicInformers.MultiClusterServiceImportInformer != nil {
+		c.dynamicInformers.MultiClusterServiceImportInformer.Informer().AddEventHandler(c.serviceImportEventHandler(numWorkers, false))
+	}
+}
+
+func (c *GatewayController) serviceImportEventHandler(numWorkers uint32, isAKOGroup bool) cache.ResourceEventHandlerFuncs {
+	enqueue := func(obj interface{}, event string) {
+		namespace, name := getServiceImportBackendNamespaceName(obj, isAKOGroup)
+		if namespace == "" || name == "" {
+			return
+		}
+		key := lib.ServiceImport + "/" + namespace + "/" + name
+		bkt := utils.Bkt(namespace, numWorkers)
+		c.workqueue[bkt].AddRateLimited(key)
+		utils.AviLog.Debugf("key: %s, msg: %s", key, event)
+	}
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			enqueue(obj, "ADD")
+		},
+		DeleteFunc: func(obj interface{}) {
+			if c.DisableSync {
+				return
+			}
+			if _, ok := obj.(*unstructured.Unstructured); !ok {
+				// ServiceImport was deleted but its final state is unrecorded.
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					utils.AviLog.Errorf("couldn't get object from tombstone %#v", obj)
+					return
+				}
+				obj = tombstone.Obj
+			}
+			enqueue(obj, "DELETE")
+		},
+		UpdateFunc: func(old, cur interface{}) {
+			if c.DisableSync {
+				return
+			}
+			oldObj, ok := old.(*unstructured.Unstructured)
+			if !ok {
+				return
+			}
+			curObj, ok := cur.(*unstructured.Unstructured)
+			if !ok || oldObj.GetResourceVersion() == curObj.GetResourceVersion() {
+				return
+			}
+			enqueue(curObj, "UPDATE")
+			// the Service imported by a ServiceImport of the AKO group may have changed
+			oldNamespace, oldName := getServiceImportBackendNamespaceName(oldObj, isAKOGroup)
+			if curNamespace, curName := getServiceImportBackendNamespaceName(curObj, isAKOGroup); oldNamespace != curNamespace || oldName != curName {
+				enqueue(oldObj, "UPDATE")
+			}
+		},
+	}
+}
+
+// getServiceImportBackendNamespaceName returns the namespace and name with which a ServiceImport is referred
+// as a backend. The ServiceImports of the AKO group are created in the AKO namespace, one per exporting
+// cluster, and are referred with the namespace and name of the imported Service.
+func getServiceImportBackendNamespaceName(obj interface{}, isAKOGroup bool) (string, string) {
+	if !isAKOGroup {
+		if _, ok := obj.(*unstructured.Unstructured); !ok {
+			utils.AviLog.Warnf("Error in converting object to ServiceImport object")
+			return "", ""
+		}
+		return getNamespaceName(obj)
+	}
+	serviceImport, err := akogatewayapilib.ConvertToAKOServiceImport(obj)
+	if err != nil {
+		utils.AviLog.Warnf("Error in converting object to ServiceImport object: %v", err)
+		return "", ""
+	}
+	if serviceImport.Namespace != utils.GetAKONamespace() {
+		return "", ""
+	}
+	return serviceImport.Spec.Namespace, serviceImport.Spec.Service
+}
@@ -17,9 +17,12 @@ package k8s
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	utilsnet "k8s.io/utils/net"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
@@ -39,6 +42,18 @@ func IsGatewayClassValid(key string, gatewayClass *gatewayv1.GatewayClass) bool
 	}
 
 	gatewayClassStatus := gatewayClass.Status.DeepCopy()
+	if err := validateGatewayClassParametersRef(gatewayClass); err != nil {
+		utils.AviLog.Errorf("key: %s, msg: GatewayClass object %s has invalid parametersRef, err: %s", key, gatewayClass.Name, err.Error())
+		akogatewayapistatus.NewCondition().
+			Type(string(gatewayv1.GatewayClassConditionStatusAccepted)).
+			Reason(string(gatewayv1.GatewayClassReasonInvalidParameters)).
+			Status(metav1.ConditionFalse).
+			ObservedGeneration(gatewayClass.ObjectMeta.Generation).
+			Message(err.Error()).
+			SetIn(&gatewayClassStatus.Conditions)
+		akogatewayapistatus.Record(key, gatewayClass, &status.Status{GatewayClassStatus: gatewayClassStatus})
+		return false
+	}
 	akogatewayapistatus.NewCondition().
 		Type(string(gatewayv1.GatewayClassConditionStatusAccepted)).
 		Reason(string(gatewayv1.GatewayClassReasonAccepted)).
@@ -69,6 +84,18 @@ func IsValidGateway(key string, gateway *gatewayv1.Gateway) (bool, bool) {
 
 	gatewayStatus := gateway.Status.DeepCopy()
 
+	// the claims are recorded irrespective of the validity, to revalidate the gateways sharing them on a change
+	akogatewayapiobjects.GatewayApiLister().UpdateGatewayToListenerHostnamePorts(gateway.Namespace+"/"+gateway.Name, getListenerHostnamePorts(gateway))
+	// and so are the CA certificates, to revalidate the gateway on their change
+	caCertRefs := getFrontendValidationCACertRefs(gateway)
+	akogatewayapiobjects.GatewayApiLister().UpdateGatewayToCACert(gateway.Namespace+"/"+gateway.Name, caCertRefs)
+	for _, caCertRef := range caCertRefs {
+		if strings.HasPrefix(caCertRef, akogatewayapilib.ConfigMapKind+"/") {
+			SharedGatewayController().StartConfigMapInformer()
+			break
+		}
+	}
+
 	// has 1 or more listeners
 	if len(spec.Listeners) == 0 {
 		utils.AviLog.Errorf("key: %s, msg: no listeners found in gateway %+v", key, gateway.Name)
@@ -81,11 +108,12 @@ func IsValidGateway(key string, gateway *gatewayv1.Gateway) (bool, bool) {
 		return false, allowedRoutesAll
 	}
 
-	// has 1 or none addresses
-	if len(spec.Addresses) > 1 {
-		utils.AviLog.Errorf("key: %s, msg: more than 1 gateway address found in gateway %+v", key, gateway.Name)
+	// has at most one IPv4, one IPv6 and one NamedAddress
+	if reason, message := validateGatewayAddresses(spec.Addresses); message != "" {
+		utils.AviLog.Errorf("key: %s, msg: invalid addresses in gateway %s, %s", key, gateway.Name, message)
 		defaultCondition.
-			Message("More than one address is not supported").
+			Reason(string(reason)).
+			Message(message).
 			SetIn(&gatewayStatus.Conditions)
 		programmedCondition.
 			Reason(string(gatewayv1.GatewayReasonAddressNotUsable)).
@@ -94,24 +122,39 @@ func IsValidGateway(key string, gateway *gatewayv1.Gateway) (bool, bool) {
 		return false, allowedRoutesAll
 	}
 
-	if len(spec.Addresses) == 1 && *spec.Addresses[0].Type != "IPAddress" {
-		utils.AviLog.Errorf("key: %s, msg: gateway address is not of type IPAddress %+v", key, gateway.Name)
+	if err := validateGatewayParametersRef(gateway); err != nil {
+		utils.AviLog.Errorf("key: %s, msg: invalid parametersRef in gateway %s, err: %s", key, gateway.Name, err.Error())
 		defaultCondition.
-			Reason(string(gatewayv1.GatewayReasonUnsupportedAddress)).
-			Message("Only IPAddress as AddressType is supported").
+			Reason(string(gatewayv1.GatewayReasonInvalidParameters)).
+			Message(err.Error()).
 			SetIn(&gatewayStatus.Conditions)
 		programmedCondition.
-			Reason(string(gatewayv1.GatewayReasonAddressNotUsable)).
 			SetIn(&gatewayStatus.Conditions)
 		akogatewayapistatus.Record(key, gateway, &status.Status{GatewayStatus: gatewayStatus})
 		return false, allowedRoutesAll
 	}
 
+	gatewayInMergeMode := akogatewayapilib.IsGatewayClassInMergeMode(string(gateway.Spec.GatewayClassName))
+	// the VIP and the AviInfraSetting of the parent VS shared in merge mode are taken from the Gateway which takes precedence
+	if gatewayInMergeMode {
+		if reason, message := validateMergedGatewayParent(key, gateway); message != "" {
+			utils.AviLog.Errorf("key: %s, msg: gateway %s can not be merged, %s", key, gateway.Name, message)
+			defaultCondition.
+				Reason(string(reason)).
+				Message(message).
+				SetIn(&gatewayStatus.Conditions)
+			programmedCondition.
+				SetIn(&gatewayStatus.Conditions)
+			akogatewayapistatus.Record(key, gateway, &status.Status{GatewayStatus: gatewayStatus})
+			return false, allowedRoutesAll
+		}
+	}
+
 	gatewayStatus.Listeners = make([]gatewayv1.ListenerStatus, len(gateway.Spec.Listeners))
 	gatewayInDedicatedMode := akogatewayapilib.IsGatewayInDedicatedMode(gateway.Namespace, gateway.Name)
 	var validListenerCount int
 	for index := range spec.Listeners {
-		if isValidListener(key, gateway, gatewayStatus, index, gatewayInDedicatedMode) {
+		if isValidListener(key, gateway, gatewayStatus, index, gatewayInDedicatedMode, gatewayInMergeMode) {
 			if !allowedRoutesAll {
 				if spec.Listeners[index].AllowedRoutes != nil && spec.Listeners[index].AllowedRoutes.Namespaces != nil && spec.Listeners[index].AllowedRoutes.Namespaces.From != nil {
 					if string(*spec.Listeners[index].AllowedRoutes.Namespaces.From) == akogatewayapilib.AllowedRoutesNamespaceFromAll {
@@ -155,10 +198,72 @@ func IsValidGateway(key string, gateway *gatewayv1.Gateway) (bool, bool) {
 	return true, allowedRoutesAll
 }
 
-func isValidListener(key string, gateway *gatewayv1.Gateway, gatewayStatus *gatewayv1.GatewayStatus, index int, gatewayInDedicatedMode bool) bool {
+// validateGatewayAddresses allows at most one IPv4 and one IPv6 static address, which are
+// configured together on a dual-stack vsvip, and at most one NamedAddress, which is the name
+// of the Avi IPAM network the VIPs are allocated from.
+func validateGatewayAddresses(addresses []gatewayv1.GatewaySpecAddress) (gatewayv1.GatewayConditionReason, string) {
+	var ipv4Count, ipv6Count, namedAddressCount int
+	for _, address := range addresses {
+		addressType := gatewayv1.IPAddressType
+		if address.Type != nil {
+			addressType = *address.Type
+		}
+		switch addressType {
+		case gatewayv1.IPAddressType:
+			if utilsnet.IsIPv4String(address.Value) {
+				ipv4Count++
+			} else if utilsnet.IsIPv6String(address.Value) {
+				ipv6Count++
+			} else {
+				return gatewayv1.GatewayReasonUnsupportedAddress, fmt.Sprintf("%s is not a valid IPAddress", address.Value)
+			}
+		case gatewayv1.NamedAddressType:
+			if address.Value == "" {
+				return gatewayv1.GatewayReasonUnsupportedAddress, "NamedAddress must not be empty"
+			}
+			namedAddressCount++
+		default:
+			return gatewayv1.GatewayReasonUnsupportedAddress, "Only IPAddress and NamedAddress as AddressType are supported"
+		}
+	}
+	if ipv4Count > 1 {
+		return gatewayv1.GatewayReasonInvalid, "More than one IPv4 address is not supported"
+	}
+	if ipv6Count > 1 {
+		return gatewayv1.GatewayReasonInvalid, "More than one IPv6 address is not supported"
+	}
+	if namedAddressCount > 1 {
+		return gatewayv1.GatewayReasonInvalid, "More than one NamedAddress is not supported"
+	}
+	return "", ""
+}
+
+func validateGatewayClassParametersRef(gatewayClass *gatewayv1.GatewayClass) error {
+	infraSettingName, found, err := akogatewayapilib.GetAviInfraSettingRefNameForGatewayClass(gatewayClass)
+	if err != nil || !found {
+		return err
+	}
+	_, err = akogatewayapilib.GetReferredAviInfraSetting(infraSettingName)
+	return err
+}
+
+// validateGatewayParametersRef validates the AviInfraSetting referred by the Gateway either
+// through its spec.infrastructure or through its GatewayClass. The Gateway is not processed
+// further on an invalid reference, so that its virtual service is not moved to a different
+// SE group or VIP network by falling back to the namespace AviInfraSetting.
+func validateGatewayParametersRef(gateway *gatewayv1.Gateway) error {
+	infraSettingName, found, err := akogatewayapilib.GetAviInfraSettingRefName(gateway)
+	if err != nil || !found {
+		return err
+	}
+	_, err = akogatewayapilib.GetReferredAviInfraSetting(infraSettingName)
+	return err
+}
+
+func isValidListener(key string, gateway *gatewayv1.Gateway, gatewayStatus *gatewayv1.GatewayStatus, index int, gatewayInDedicatedMode, gatewayInMergeMode bool) bool {
 	listener := gateway.Spec.Listeners[index]
 	gatewayStatus.Listeners[index].Name = gateway.Spec.Listeners[index].Name
-	gatewayStatus.Listeners[index].SupportedKinds = akogatewayapilib.SupportedKinds[listener.Protocol]
+	gatewayStatus.Listeners[index].SupportedKinds = akogatewayapilib.GetSupportedKinds(listener.Protocol, gatewayInDedicatedMode)
 	gatewayStatus.Listeners[index].AttachedRoutes = akogatewayapilib.ZeroAttachedRoutes
 
 	defaultCondition := akogatewayapistatus.NewCondition().
@@ -176,8 +281,7 @@ func isValidListener(key string, gateway *gatewayv1.Gateway, gatewayStatus *gate
 		ObservedGeneration(gateway.ObjectMeta.Generation)
 
 	// protocol validation
-	if listener.Protocol != gatewayv1.HTTPProtocolType &&
-		listener.Protocol != gatewayv1.HTTPSProtocolType {
+	if !isSupportedListenerProtocol(listener.Protocol) {
 		utils.AviLog.Errorf("key: %s, msg: protocol is not supported for listener %s", key, listener.Name)
 		defaultCondition.
 			Reason(string(gatewayv1.ListenerReasonUnsupportedProtocol)).
@@ -188,6 +292,67 @@ func isValidListener(key string, gateway *gatewayv1.Gateway, gatewayStatus *gate
 		return false
 	}
 
+	isTLSListener := listener.Protocol == gatewayv1.TLSProtocolType
+	if akogatewayapilib.IsL4Protocol(string(listener.Protocol)) || isTLSListener {
+		if gatewayInDedicatedMode {
+			utils.AviLog.Errorf("key: %s, msg: %s listener %s is not supported in dedicated mode for gateway %+v", key, listener.Protocol, listener.Name, gateway.Name)
+			defaultCondition.
+				Message("TCP/UDP/TLS listeners are not supported in dedicated mode").
+				SetIn(&gatewayStatus.Listeners[index].Conditions)
+			programmedCondition.
+				SetIn(&gatewayStatus.Listeners[index].Conditions)
+			return false
+		}
+		if gatewayInMergeMode {
+			utils.AviLog.Errorf("key: %s, msg: %s listener %s is not supported in merge mode for gateway %+v", key, listener.Protocol, listener.Name, gateway.Name)
+			defaultCondition.
+				Message("TCP/UDP/TLS listeners are not supported in merge mode").
+				SetIn(&gatewayStatus.Listeners[index].Conditions)
+			programmedCondition.
+				SetIn(&gatewayStatus.Listeners[index].Conditions)
+			return false
+		}
+		if isTLSListener && !akogatewayapilib.IsTLSPassthroughListener(listener) {
+			utils.AviLog.Errorf("key: %s, msg: only Passthrough mode is supported for TLS listener %s of gateway %+v", key, listener.Name, gateway.Name)
+			defaultCondition.
+				Message("Only Passthrough TLS mode is supported for TLS listeners").
+				SetIn(&gatewayStatus.Listeners[index].Conditions)
+			programmedCondition.
+				SetIn(&gatewayStatus.Listeners[index].Conditions)
+			return false
+		}
+		if !isTLSListener && listener.TLS != nil {
+			utils.AviLog.Errorf("key: %s, msg: TLS is not supported for %s listener %s of gateway %+v", key, listener.Protocol, listener.Name, gateway.Name)
+			defaultCondition.
+				Message("TLS configuration is not supported for TCP/UDP listeners").
+				SetIn(&gatewayStatus.Listeners[index].Conditions)
+			programmedCondition.
+				SetIn(&gatewayStatus.Listeners[index].Conditions)
+			return false
+		}
+		// a port can either be served by the L7 parent VS, by an L4 policyset or by the passthrough datascript.
+		// TLS passthrough listeners with different hostnames can share a port, the SNI selects the backends.
+		for i, gwListener := range gateway.Spec.Listeners {
+			if i == index || gwListener.Port != listener.Port {
+				continue
+			}
+			portConflict := !akogatewayapilib.IsL4Protocol(string(gwListener.Protocol)) || (gwListener.Protocol == listener.Protocol && i < index)
+			if isTLSListener {
+				portConflict = gwListener.Protocol != gatewayv1.TLSProtocolType
+			}
+			if portConflict {
+				utils.AviLog.Errorf("key: %s, msg: port %d of listener %s is already in use by listener %s", key, listener.Port, listener.Name, gwListener.Name)
+				defaultCondition.
+					Reason(string(gatewayv1.ListenerReasonPortUnavailable)).
+					Message(fmt.Sprintf("Port is already in use by listener %s", gwListener.Name)).
+					SetIn(&gatewayStatus.Listeners[index].Conditions)
+				programmedCondition.
+					SetIn(&gatewayStatus.Listeners[index].Conditions)
+				return false
+			}
+		}
+	}
+
 	if gatewayInDedicatedMode {
 		if listener.Hostname != nil {
 			utils.AviLog.Errorf("key: %s, msg: Hostname is not supported in dedicated mode for gateway %+v", key, gateway.Name)
@@ -212,28 +377,57 @@ func isValidListener(key string, gateway *gatewayv1.Gateway, gatewayStatus *gate
 		}
 	}
 
-	// hostname should not overlap with hostname of an existing gateway
-	// TODO: Current check is for gateways in the same namespace, it should be changed to all namespaces
-	gatewayNsList, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayInformer.Lister().Gateways(gateway.Namespace).List(labels.Set(nil).AsSelector())
-	if err != nil {
-		utils.AviLog.Errorf("Unable to retrieve the gateways during validation: %s", err)
+	// hostname and port should not be claimed by a listener which takes precedence
+	if conflictingListener := getConflictingListener(key, gateway, index); conflictingListener != "" {
+		utils.AviLog.Errorf("key: %s, msg: hostname and port of listener %s are already in use by listener %s %+v", key, listener.Name, conflictingListener, gateway.Name)
+		message := fmt.Sprintf("Hostname and port are already in use by listener %s", conflictingListener)
+		defaultCondition.
+			Message(message).
+			SetIn(&gatewayStatus.Listeners[index].Conditions)
+		akogatewayapistatus.NewCondition().
+			Type(string(gatewayv1.ListenerConditionConflicted)).
+			Reason(string(gatewayv1.ListenerReasonHostnameConflict)).
+			Status(metav1.ConditionTrue).
+			ObservedGeneration(gateway.ObjectMeta.Generation).
+			Message(message).
+			SetIn(&gatewayStatus.Listeners[index].Conditions)
+		programmedCondition.SetIn(&gatewayStatus.Listeners[index].Conditions)
 		return false
 	}
-	for _, gatewayInNamespace := range gatewayNsList {
-		if gateway.Name != gatewayInNamespace.Name {
-			for _, gwListener := range gatewayInNamespace.Spec.Listeners {
-				if gwListener.Hostname == nil {
-					continue
-				}
-				if listener.Hostname != nil && *listener.Hostname == *gwListener.Hostname {
-					utils.AviLog.Errorf("key: %s, msg: Hostname is same as an existing gateway %s hostname %s", key, gatewayInNamespace.Name, *gwListener.Hostname)
-					defaultCondition.
-						Message("Hostname is same as an existing gateway hostname").
-						SetIn(&gatewayStatus.Listeners[index].Conditions)
-					programmedCondition.SetIn(&gatewayStatus.Listeners[index].Conditions)
-					return false
-				}
-			}
+	// in merge mode, a port of the shared parent VS is served with the protocol of the Gateway which takes precedence
+	if gatewayInMergeMode {
+		if conflictingListener := getMergedGatewayConflictingListener(key, gateway, index); conflictingListener != "" {
+			utils.AviLog.Errorf("key: %s, msg: port of listener %s is already in use with a different protocol by listener %s %+v", key, listener.Name, conflictingListener, gateway.Name)
+			message := fmt.Sprintf("Port is already in use with a different protocol by listener %s", conflictingListener)
+			defaultCondition.
+				Message(message).
+				SetIn(&gatewayStatus.Listeners[index].Conditions)
+			akogatewayapistatus.NewCondition().
+				Type(string(gatewayv1.ListenerConditionConflicted)).
+				Reason(string(gatewayv1.ListenerReasonProtocolConflict)).
+				Status(metav1.ConditionTrue).
+				ObservedGeneration(gateway.ObjectMeta.Generation).
+				Message(message).
+				SetIn(&gatewayStatus.Listeners[index].Conditions)
+			programmedCondition.SetIn(&gatewayStatus.Listeners[index].Conditions)
+			return false
+		}
+		// the client certificates of a port are validated as per the frontendValidation of the Gateway which takes precedence
+		if conflictingListener := getMergedGatewayFrontendValidationConflict(key, gateway, index); conflictingListener != "" {
+			utils.AviLog.Errorf("key: %s, msg: frontendValidation of listener %s differs from listener %s serving the same port %+v", key, listener.Name, conflictingListener, gateway.Name)
+			message := fmt.Sprintf("FrontendValidation differs from listener %s serving the same port", conflictingListener)
+			defaultCondition.
+				Message(message).
+				SetIn(&gatewayStatus.Listeners[index].Conditions)
+			akogatewayapistatus.NewCondition().
+				Type(string(gatewayv1.ListenerConditionConflicted)).
+				Reason(string(gatewayv1.ListenerReasonProtocolConflict)).
+				Status(metav1.ConditionTrue).
+				ObservedGeneration(gateway.ObjectMeta.Generation).
+				Message(message).
+				SetIn(&gatewayStatus.Listeners[index].Conditions)
+			programmedCondition.SetIn(&gatewayStatus.Listeners[index].Conditions)
+			return false
 		}
 	}
 	// do not check subdomain for empty or * hostname
@@ -251,8 +445,8 @@ func isValidListener(key string, gateway *gatewayv1.Gateway, gatewayStatus *gate
 		Type(string(gatewayv1.ListenerConditionResolvedRefs)).
 		Status(metav1.ConditionFalse).
 		ObservedGeneration(gateway.ObjectMeta.Generation)
-	// has valid TLS config
-	if listener.TLS != nil {
+	// has valid TLS config, certificates are not required when the TLS connection is passed through
+	if listener.TLS != nil && !isTLSListener {
 		if (listener.TLS.Mode != nil && *listener.TLS.Mode != gatewayv1.TLSModeTerminate) || len(listener.TLS.CertificateRefs) == 0 {
 			utils.AviLog.Errorf("key: %s, msg: tls mode/ref not valid %+v/%+v", key, gateway.Name, listener.Name)
 			defaultCondition.SetIn(&gatewayStatus.Listeners[index].Conditions)
@@ -275,11 +469,26 @@ func isValidListener(key string, gateway *gatewayv1.Gateway, gatewayStatus *gate
 				return false
 			}
 			name := string(certRef.Name)
-			_, err := utils.GetInformers().ClientSet.CoreV1().Secrets(gateway.ObjectMeta.Namespace).Get(context.TODO(), name, metav1.GetOptions{})
+			certNamespace := gateway.ObjectMeta.Namespace
+			if certRef.Namespace != nil && *certRef.Namespace != "" {
+				certNamespace = string(*certRef.Namespace)
+			}
+			// Secret in another namespace must be permitted by a ReferenceGrant in that namespace
+			if !akogatewayapiobjects.GatewayApiLister().IsReferencePermitted(akogatewayapilib.GatewayGroup, lib.Gateway, gateway.ObjectMeta.Namespace, "", utils.Secret, certNamespace, name) {
+				utils.AviLog.Errorf("key: %s, msg: CertificateRef %s/%s is not permitted by any ReferenceGrant %+v/%+v", key, certNamespace, name, gateway.Name, listener.Name)
+				defaultCondition.SetIn(&gatewayStatus.Listeners[index].Conditions)
+				resolvedRefCondition.
+					Reason(string(gatewayv1.ListenerReasonRefNotPermitted)).
+					Message(fmt.Sprintf("CertificateRef %s/%s is not permitted by any ReferenceGrant", certNamespace, name)).
+					SetIn(&gatewayStatus.Listeners[index].Conditions)
+				programmedCondition.SetIn(&gatewayStatus.Listeners[index].Conditions)
+				return false
+			}
+			_, err := utils.GetInformers().ClientSet.CoreV1().Secrets(certNamespace).Get(context.TODO(), name, metav1.GetOptions{})
 			if err != nil {
 				utils.AviLog.Errorf("key: %s, msg: Secret specified in CertificateRef does not exist %+v/%+v", key, gateway.Name, listener.Name)
 				gWNSName := gateway.ObjectMeta.Namespace + "/" + gateway.ObjectMeta.Name
-				secretNSName := gateway.ObjectMeta.Namespace + "/" + name
+				secretNSName := certNamespace + "/" + name
 				akogatewayapiobjects.GatewayApiLister().UpdateSecretToGateway(secretNSName, []string{gWNSName})
 				defaultCondition.SetIn(&gatewayStatus.Listeners[index].Conditions)
 				resolvedRefCondition.
@@ -290,18 +499,61 @@ func isValidListener(key string, gateway *gatewayv1.Gateway, gatewayStatus *gate
 				return false
 			}
 		}
+		if listener.TLS.FrontendValidation != nil {
+			// client certificates are validated per port, listeners sharing the port must validate them alike
+			for i, gwListener := range gateway.Spec.Listeners {
+				if i == index || gwListener.Port != listener.Port || gwListener.TLS == nil {
+					continue
+				}
+				if !reflect.DeepEqual(gwListener.TLS.FrontendValidation, listener.TLS.FrontendValidation) {
+					utils.AviLog.Errorf("key: %s, msg: frontendValidation of listener %s differs from listener %s on port %d %+v", key, listener.Name, gwListener.Name, listener.Port, gateway.Name)
+					defaultCondition.
+						Message(fmt.Sprintf("FrontendValidation differs from listener %s on the same port", gwListener.Name)).
+						SetIn(&gatewayStatus.Listeners[index].Conditions)
+					programmedCondition.SetIn(&gatewayStatus.Listeners[index].Conditions)
+					return false
+				}
+			}
+			for _, caCertRef := range listener.TLS.FrontendValidation.CACertificateRefs {
+				caCertNamespace := gateway.ObjectMeta.Namespace
+				if caCertRef.Namespace != nil && *caCertRef.Namespace != "" {
+					caCertNamespace = string(*caCertRef.Namespace)
+				}
+				if !akogatewayapiobjects.GatewayApiLister().IsReferencePermitted(akogatewayapilib.GatewayGroup, lib.Gateway, gateway.ObjectMeta.Namespace, string(caCertRef.Group), string(caCertRef.Kind), caCertNamespace, string(caCertRef.Name)) {
+					utils.AviLog.Errorf("key: %s, msg: CACertificateRef %s/%s is not permitted by any ReferenceGrant %+v/%+v", key, caCertNamespace, caCertRef.Name, gateway.Name, listener.Name)
+					defaultCondition.SetIn(&gatewayStatus.Listeners[index].Conditions)
+					resolvedRefCondition.
+						Reason(string(gatewayv1.ListenerReasonRefNotPermitted)).
+						Message(fmt.Sprintf("CACertificateRef %s/%s is not permitted by any ReferenceGrant", caCertNamespace, caCertRef.Name)).
+						SetIn(&gatewayStatus.Listeners[index].Conditions)
+					programmedCondition.SetIn(&gatewayStatus.Listeners[index].Conditions)
+					return false
+				}
+			}
+			if _, err := akogatewayapilib.GetFrontendValidationCACert(gateway.ObjectMeta.Namespace, listener.TLS.FrontendValidation); err != nil {
+				utils.AviLog.Errorf("key: %s, msg: frontendValidation is not valid %+v/%+v, err: %s", key, gateway.Name, listener.Name, err.Error())
+				defaultCondition.SetIn(&gatewayStatus.Listeners[index].Conditions)
+				resolvedRefCondition.
+					Reason(string(gatewayv1.ListenerReasonInvalidCertificateRef)).
+					Message(err.Error()).
+					SetIn(&gatewayStatus.Listeners[index].Conditions)
+				programmedCondition.SetIn(&gatewayStatus.Listeners[index].Conditions)
+				return false
+			}
+		}
 	}
 
 	//allowedRoutes validation
 	if listener.AllowedRoutes != nil {
 		if listener.AllowedRoutes.Kinds != nil {
 			for _, kindInAllowedRoute := range listener.AllowedRoutes.Kinds {
-				if kindInAllowedRoute.Kind != "" && string(kindInAllowedRoute.Kind) != utils.HTTPRoute {
-					utils.AviLog.Errorf("key: %s, msg: AllowedRoute kind is invalid %+v/%+v. Supported AllowedRoute kind is HTTPRoute.", key, gateway.Name, listener.Name)
+				if kindInAllowedRoute.Kind != "" && !akogatewayapilib.IsSupportedRouteKind(listener.Protocol, string(kindInAllowedRoute.Kind), gatewayInDedicatedMode) {
+					supportedKinds := akogatewayapilib.GetSupportedKinds(listener.Protocol, gatewayInDedicatedMode)
+					utils.AviLog.Errorf("key: %s, msg: AllowedRoute kind is invalid %+v/%+v. Supported AllowedRoute kinds are %v.", key, gateway.Name, listener.Name, supportedKinds)
 					defaultCondition.SetIn(&gatewayStatus.Listeners[index].Conditions)
 					resolvedRefCondition.
 						Reason(string(gatewayv1.ListenerReasonInvalidRouteKinds)).
-						Message("AllowedRoute kind is invalid. Only HTTPRoute is supported currently").
+						Message(fmt.Sprintf("AllowedRoute kind is invalid. Only %s supported currently", akogatewayapilib.SupportedKindsMessage(supportedKinds))).
 						SetIn(&gatewayStatus.Listeners[index].Conditions)
 					programmedCondition.SetIn(&gatewayStatus.Listeners[index].Conditions)
 					return false
@@ -334,10 +586,210 @@ func isValidListener(key string, gateway *gatewayv1.Gateway, gatewayStatus *gate
 		Message("All the references are valid").
 		SetIn(&gatewayStatus.Listeners[index].Conditions)
 
+	akogatewayapistatus.NewCondition().
+		Type(string(gatewayv1.ListenerConditionConflicted)).
+		Reason(string(gatewayv1.ListenerReasonNoConflicts)).
+		Status(metav1.ConditionFalse).
+		ObservedGeneration(gateway.ObjectMeta.Generation).
+		Message("Listener does not conflict with any other listener").
+		SetIn(&gatewayStatus.Listeners[index].Conditions)
+
 	utils.AviLog.Infof("key: %s, msg: Listener %s/%s is valid", key, gateway.Name, listener.Name)
 	return true
 }
 
+// getListenerHostnamePorts returns the port/hostname explicitly claimed by the HTTP and HTTPS listeners of the gateway.
+func getListenerHostnamePorts(gateway *gatewayv1.Gateway) []string {
+	var hostnamePorts []string
+	for _, listener := range gateway.Spec.Listeners {
+		if listener.Protocol != gatewayv1.HTTPProtocolType && listener.Protocol != gatewayv1.HTTPSProtocolType {
+			continue
+		}
+		hostname := getListenerHostname(listener)
+		if hostname == utils.WILDCARD {
+			continue
+		}
+		hostnamePort := fmt.Sprintf("%d/%s", listener.Port, hostname)
+		if !utils.HasElem(hostnamePorts, hostnamePort) {
+			hostnamePorts = append(hostnamePorts, hostnamePort)
+		}
+	}
+	return hostnamePorts
+}
+
+func getListenerHostname(listener gatewayv1.Listener) string {
+	if listener.Hostname == nil || *listener.Hostname == "" {
+		return utils.WILDCARD
+	}
+	return string(*listener.Hostname)
+}
+
+// getConflictingListener returns the listener which claims the same hostname and port as the listener at the
+// index and takes precedence over it. Within a Gateway, the listener listed first takes precedence. Across
+// Gateways, only explicit hostnames conflict since the routes of every Gateway get their own virtual services,
+// even when the Gateways are merged onto the parent VS of their GatewayClass. As per the Gateway API conflict
+// resolution the oldest Gateway takes precedence, followed by the first in the alphabetical order of namespace/name.
+func getConflictingListener(key string, gateway *gatewayv1.Gateway, index int) string {
+	listener := gateway.Spec.Listeners[index]
+	if listener.Protocol != gatewayv1.HTTPProtocolType && listener.Protocol != gatewayv1.HTTPSProtocolType {
+		return ""
+	}
+	hostname := getListenerHostname(listener)
+	for i := 0; i < index; i++ {
+		gwListener := gateway.Spec.Listeners[i]
+		if (gwListener.Protocol == gatewayv1.HTTPProtocolType || gwListener.Protocol == gatewayv1.HTTPSProtocolType) &&
+			gwListener.Port == listener.Port && getListenerHostname(gwListener) == hostname {
+			return string(gwListener.Name)
+		}
+	}
+	if hostname == utils.WILDCARD {
+		return ""
+	}
+
+	gatewayList, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayInformer.Lister().List(labels.Set(nil).AsSelector())
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to retrieve the gateways during validation: %s", key, err)
+		return ""
+	}
+	for _, otherGateway := range gatewayList {
+		if otherGateway.Namespace == gateway.Namespace && otherGateway.Name == gateway.Name {
+			continue
+		}
+		if otherGateway.GetDeletionTimestamp() != nil || !akogatewayapilib.HasGatewayPrecedence(otherGateway, gateway) {
+			continue
+		}
+		// gateways of the same class are handled by the same controller
+		if otherGateway.Spec.GatewayClassName != gateway.Spec.GatewayClassName {
+			if _, isAKOController := akogatewayapiobjects.GatewayApiLister().IsGatewayClassControllerAKO(string(otherGateway.Spec.GatewayClassName)); !isAKOController {
+				continue
+			}
+		}
+		for _, gwListener := range otherGateway.Spec.Listeners {
+			if (gwListener.Protocol == gatewayv1.HTTPProtocolType || gwListener.Protocol == gatewayv1.HTTPSProtocolType) &&
+				gwListener.Port == listener.Port && getListenerHostname(gwListener) == hostname {
+				return otherGateway.Namespace + "/" + otherGateway.Name + "/" + string(gwListener.Name)
+			}
+		}
+	}
+	return ""
+}
+
+// getMergedGatewayConflictingListener returns the listener of a Gateway merged onto the same parent VS, which
+// takes precedence over the listener at the index and serves its port with a different protocol.
+func getMergedGatewayConflictingListener(key string, gateway *gatewayv1.Gateway, index int) string {
+	listener := gateway.Spec.Listeners[index]
+	gatewayList, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayInformer.Lister().List(labels.Set(nil).AsSelector())
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to retrieve the gateways during validation: %s", key, err)
+		return ""
+	}
+	for _, otherGateway := range gatewayList {
+		if otherGateway.Namespace == gateway.Namespace && otherGateway.Name == gateway.Name {
+			continue
+		}
+		if otherGateway.Spec.GatewayClassName != gateway.Spec.GatewayClassName ||
+			otherGateway.GetDeletionTimestamp() != nil || !akogatewayapilib.HasGatewayPrecedence(otherGateway, gateway) {
+			continue
+		}
+		for _, gwListener := range otherGateway.Spec.Listeners {
+			if gwListener.Port == listener.Port && gwListener.Protocol != listener.Protocol {
+				return otherGateway.Namespace + "/" + otherGateway.Name + "/" + string(gwListener.Name)
+			}
+		}
+	}
+	return ""
+}
+
+// getMergedGatewaysWithPrecedence returns the valid Gateways of the GatewayClass of the gateway, which take precedence
+// over it on the parent VS shared in merge mode.
+func getMergedGatewaysWithPrecedence(key string, gateway *gatewayv1.Gateway) []*gatewayv1.Gateway {
+	gatewayList, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayInformer.Lister().List(labels.Set(nil).AsSelector())
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to retrieve the gateways during validation: %s", key, err)
+		return nil
+	}
+	var gateways []*gatewayv1.Gateway
+	for _, otherGateway := range gatewayList {
+		if otherGateway.Namespace == gateway.Namespace && otherGateway.Name == gateway.Name {
+			continue
+		}
+		if otherGateway.Spec.GatewayClassName != gateway.Spec.GatewayClassName ||
+			otherGateway.GetDeletionTimestamp() != nil || !akogatewayapilib.HasGatewayPrecedence(otherGateway, gateway) {
+			continue
+		}
+		gwStatus := akogatewayapiobjects.GatewayApiLister().GetGatewayToGatewayStatusMapping(otherGateway.Namespace + "/" + otherGateway.Name)
+		if gwStatus == nil || len(gwStatus.Conditions) == 0 || akogatewayapilib.IsGatewayInvalid(gwStatus) {
+			continue
+		}
+		gateways = append(gateways, otherGateway)
+	}
+	return gateways
+}
+
+// validateMergedGatewayParent checks that the addresses and the AviInfraSetting of a Gateway in merge mode match the
+// ones of the Gateways which take precedence, as the VIP and the settings of the shared parent VS are taken from them.
+func validateMergedGatewayParent(key string, gateway *gatewayv1.Gateway) (gatewayv1.GatewayConditionReason, string) {
+	infraSettingName := getAviInfraSettingNameForGateway(key, gateway)
+	for _, otherGateway := range getMergedGatewaysWithPrecedence(key, gateway) {
+		otherGwNsName := otherGateway.Namespace + "/" + otherGateway.Name
+		if (len(gateway.Spec.Addresses) != 0 || len(otherGateway.Spec.Addresses) != 0) &&
+			!reflect.DeepEqual(gateway.Spec.Addresses, otherGateway.Spec.Addresses) {
+			return gatewayv1.GatewayReasonUnsupportedAddress, fmt.Sprintf("Addresses differ from Gateway %s, the parent VS of the GatewayClass is shared with", otherGwNsName)
+		}
+		if infraSettingName != getAviInfraSettingNameForGateway(key, otherGateway) {
+			return gatewayv1.GatewayReasonInvalidParameters, fmt.Sprintf("AviInfraSetting differs from Gateway %s, the parent VS of the GatewayClass is shared with", otherGwNsName)
+		}
+	}
+	return "", ""
+}
+
+// getAviInfraSettingNameForGateway returns the name of the AviInfraSetting applicable to the Gateway, empty if none.
+func getAviInfraSettingNameForGateway(key string, gateway *gatewayv1.Gateway) string {
+	infraSetting, err := akogatewayapilib.GetAviInfraSettingForGateway(key, gateway)
+	if err != nil || infraSetting == nil {
+		return ""
+	}
+	return infraSetting.Name
+}
+
+// getMergedGatewayFrontendValidationConflict returns the HTTPS listener of a Gateway merged onto the same parent VS,
+// which takes precedence over the listener at the index, serves its port and validates the client certificates
+// differently.
+func getMergedGatewayFrontendValidationConflict(key string, gateway *gatewayv1.Gateway, index int) string {
+	listener := gateway.Spec.Listeners[index]
+	if listener.Protocol != gatewayv1.HTTPSProtocolType {
+		return ""
+	}
+	for _, otherGateway := range getMergedGatewaysWithPrecedence(key, gateway) {
+		for _, gwListener := range otherGateway.Spec.Listeners {
+			if gwListener.Port != listener.Port || gwListener.Protocol != listener.Protocol {
+				continue
+			}
+			if !reflect.DeepEqual(getListenerFrontendValidation(gwListener), getListenerFrontendValidation(listener)) {
+				return otherGateway.Namespace + "/" + otherGateway.Name + "/" + string(gwListener.Name)
+			}
+		}
+	}
+	return ""
+}
+
+func getListenerFrontendValidation(listener gatewayv1.Listener) *gatewayv1.FrontendTLSValidation {
+	if listener.TLS == nil {
+		return nil
+	}
+	return listener.TLS.FrontendValidation
+}
+
+func isSupportedListenerProtocol(protocol gatewayv1.ProtocolType) bool {
+	switch protocol {
+	case gatewayv1.HTTPProtocolType, gatewayv1.HTTPSProtocolType:
+		return true
+	case gatewayv1.TCPProtocolType, gatewayv1.UDPProtocolType, gatewayv1.TLSProtocolType:
+		return akogatewayapilib.IsExperimentalRoutesEnabled()
+	}
+	return false
+}
+
 func IsHTTPRouteConfigValid(key string, obj *gatewayv1.HTTPRoute) bool {
 
 	httpRoute := obj.DeepCopy()
@@ -348,6 +800,22 @@ func IsHTTPRouteConfigValid(key string, obj *gatewayv1.HTTPRoute) bool {
 	return true
 }
 
+func IsGRPCRouteConfigValid(key string, obj *gatewayv1.GRPCRoute) bool {
+	if len(obj.Spec.ParentRefs) == 0 {
+		utils.AviLog.Errorf("key: %s, msg: Parent Reference is empty for the GRPCRoute %s", key, obj.Name)
+		return false
+	}
+	return true
+}
+
+func IsL4RouteConfigValid(key string, parentRefs []gatewayv1.ParentReference) bool {
+	if len(parentRefs) == 0 {
+		utils.AviLog.Errorf("key: %s, msg: Parent Reference is empty for the route", key)
+		return false
+	}
+	return true
+}
+
 func ValidateGatewayListenerWithSecret(key, namespace, name string, deleteFlag bool) {
 	secretNSName := namespace + "/" + name
 	present, gwList := akogatewayapiobjects.GatewayApiLister().GetSecretToGateway(secretNSName)
@@ -374,11 +842,14 @@ func ValidateGatewayListenerWithSecret(key, namespace, name string, deleteFlag b
 						continue
 					}
 					for _, certRef := range listener.TLS.CertificateRefs {
-						// TODO: In future, ref grant impact needs to be considered.
-						certNamespace := namespace
-						if certRef.Namespace != nil {
+						certNamespace := gwNamespace
+						if certRef.Namespace != nil && *certRef.Namespace != "" {
 							certNamespace = string(*certRef.Namespace)
 						}
+						// listener stays invalid till the cross namespace reference is permitted
+						if !akogatewayapiobjects.GatewayApiLister().IsReferencePermitted(akogatewayapilib.GatewayGroup, lib.Gateway, gwNamespace, "", utils.Secret, certNamespace, string(certRef.Name)) {
+							continue
+						}
 						// add condition for checking gateway status
 						if gwStatus != nil && string(certRef.Name) == name && certNamespace == namespace {
 							setListenerConditions(gwStatus, listenerIndex, gatewayObj.ObjectMeta.Generation, deleteFlag)
@@ -464,3 +935,20 @@ func setGatewayCondition(gwStatus *gatewayv1.GatewayStatus, validListenerCount,
 		Status(metav1.ConditionTrue).
 		ObservedGeneration(observedGeneration).SetIn(&gwStatus.Conditions)
 }
+
+// getFrontendValidationCACertRefs returns the ConfigMaps and Secrets referred by the frontendValidation of the
+// HTTPS listeners, in kind/namespace/name format.
+func getFrontendValidationCACertRefs(gateway *gatewayv1.Gateway) []string {
+	var caCertRefs []string
+	for _, listener := range gateway.Spec.Listeners {
+		if listener.TLS == nil || listener.TLS.FrontendValidation == nil || listener.Protocol != gatewayv1.HTTPSProtocolType {
+			continue
+		}
+		for _, caCertRef := range akogatewayapilib.GetFrontendValidationCACertRefs(gateway.Namespace, listener.TLS.FrontendValidation) {
+			if !utils.HasElem(caCertRefs, caCertRef) {
+				caCertRefs = append(caCertRefs, caCertRef)
+			}
+		}
+	}
+	return caCertRefs
+}
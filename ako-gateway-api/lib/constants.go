@@ -23,17 +23,43 @@ const (
 	RouteBackendExtensionKind = "RouteBackendExtension"
 	AKOCRDController          = "AKOCRDController"
 	CRDOperatorPrefix         = "ako-crd-operator-"
+	ExperimentalRoutesEnv     = "ENABLE_GATEWAY_API_EXPERIMENTAL_ROUTES"
+	BackendTLSPolicyEnv       = "ENABLE_GATEWAY_API_BACKEND_TLS_POLICY"
+	ServiceImportEnv          = "ENABLE_GATEWAY_API_SERVICE_IMPORT"
 )
 
 const (
 	ZeroAttachedRoutes = 0
 )
 
+const (
+	// Key of the CA bundle in the ConfigMaps and Secrets referred by the listener frontend validation
+	CACertificateKey = "ca.crt"
+	ConfigMapKind    = "ConfigMap"
+)
+
 const (
 	// Gateway annotations
 	DedicatedGatewayModeAnnotation = "ako.vmware.com/dedicated-gateway-mode"
 )
 
+const (
+	// GatewayClass annotations
+	MergeGatewaysAnnotation = "ako.vmware.com/merge-gateways"
+)
+
+const (
+	// HTTPRoute annotations
+	ClusterWeightsAnnotation = "ako.vmware.com/cluster-weights"
+)
+
+const (
+	// Group and EndpointSlice labels of the ServiceImports of the Multi-Cluster Services API
+	MultiClusterServiceGroup       = "multicluster.x-k8s.io"
+	MultiClusterServiceNameLabel   = "multicluster.kubernetes.io/service-name"
+	MultiClusterSourceClusterLabel = "multicluster.kubernetes.io/source-cluster"
+)
+
 const (
 	GatewayClassGatewayControllerIndex = "GatewayClassGatewayController"
 	REGULAREXPRESSION                  = "RegularExpression"
@@ -43,6 +69,32 @@ const (
 	VCFGatewayClassName                = "avi-lb"
 )
 
+const (
+	// Limits of the Avi pool timeouts in milliseconds
+	PoolServerTimeoutMax              = 21600000
+	PoolServerReselectRetryTimeoutMax = 3600000
+)
+
+const (
+	// Limit of the Avi pool server reselect retries
+	PoolServerReselectNumRetriesMax = 5
+)
+
+const (
+	// Limit of the Avi application cookie persistence timeout in minutes
+	AppCookiePersistenceTimeoutMax = 720
+)
+
+const (
+	// Default duration in seconds for which the response of a CORS preflight request can be cached
+	CORSDefaultMaxAge = 5
+)
+
+const (
+	// Index of the URI token denoting the end of the request path
+	URITokenEndOfString = 65535
+)
+
 const (
 	AllowedRoutesNamespaceFromAll  = "All"
 	AllowedRoutesNamespaceFromSame = "Same"
@@ -19,9 +19,13 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
 	gatewayclientset "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
 	gatewayinformerv1 "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions/apis/v1"
+	gatewayinformerv1alpha2 "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions/apis/v1alpha2"
+	gatewayinformerv1alpha3 "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions/apis/v1alpha3"
+	gatewayinformerv1beta1 "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions/apis/v1beta1"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	v1beta1akocrd "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/client/v1beta1/clientset/versioned"
@@ -30,9 +34,18 @@ import (
 )
 
 type GatewayAPIInformers struct {
-	GatewayInformer      gatewayinformerv1.GatewayInformer
-	GatewayClassInformer gatewayinformerv1.GatewayClassInformer
-	HTTPRouteInformer    gatewayinformerv1.HTTPRouteInformer
+	GatewayInformer          gatewayinformerv1.GatewayInformer
+	GatewayClassInformer     gatewayinformerv1.GatewayClassInformer
+	HTTPRouteInformer        gatewayinformerv1.HTTPRouteInformer
+	GRPCRouteInformer        gatewayinformerv1.GRPCRouteInformer
+	TCPRouteInformer         gatewayinformerv1alpha2.TCPRouteInformer
+	UDPRouteInformer         gatewayinformerv1alpha2.UDPRouteInformer
+	TLSRouteInformer         gatewayinformerv1alpha2.TLSRouteInformer
+	ReferenceGrantInformer   gatewayinformerv1beta1.ReferenceGrantInformer
+	BackendTLSPolicyInformer gatewayinformerv1alpha3.BackendTLSPolicyInformer
+	// ConfigMapInformer watches the ConfigMaps of all the namespaces, which carry the CA certificates
+	// referred by the Gateway API objects. It runs only once such a ConfigMap can be referred.
+	ConfigMapInformer coreinformers.ConfigMapInformer
 }
 
 // akoControlConfig struct is intended to store all AKO related global
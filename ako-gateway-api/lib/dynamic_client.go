@@ -27,6 +27,7 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/dynamic"
@@ -36,6 +37,7 @@ import (
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	akov1alpha1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1alpha1"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 )
 
@@ -68,6 +70,16 @@ var (
 		Version:  "v1alpha1",
 		Resource: "pkiprofiles",
 	}
+	AKOServiceImportGVR = schema.GroupVersionResource{
+		Group:    "ako.vmware.com",
+		Version:  "v1alpha1",
+		Resource: "serviceimports",
+	}
+	MultiClusterServiceImportGVR = schema.GroupVersionResource{
+		Group:    MultiClusterServiceGroup,
+		Version:  "v1alpha1",
+		Resource: "serviceimports",
+	}
 )
 
 // NewDynamicClientSet initializes dynamic client set instance
@@ -100,10 +112,12 @@ func GetDynamicClientSet() dynamic.Interface {
 
 // DynamicInformers holds third party generic informers
 type DynamicInformers struct {
-	L7CRDInformer                    informers.GenericInformer
-	HealthMonitorInformer            informers.GenericInformer
-	RouteBackendExtensionCRDInformer informers.GenericInformer
-	AppProfileCRDInformer            informers.GenericInformer
+	L7CRDInformer                     informers.GenericInformer
+	HealthMonitorInformer             informers.GenericInformer
+	RouteBackendExtensionCRDInformer  informers.GenericInformer
+	AppProfileCRDInformer             informers.GenericInformer
+	AKOServiceImportInformer          informers.GenericInformer
+	MultiClusterServiceImportInformer informers.GenericInformer
 }
 
 // NewDynamicInformers initializes the DynamicInformers struct
@@ -121,10 +135,40 @@ func NewDynamicInformers(client dynamic.Interface, akoInfra bool) *DynamicInform
 		informers.AppProfileCRDInformer = f.ForResource(AppProfileCRDGVR)
 		informers.RouteBackendExtensionCRDInformer = f.ForResource(RouteBackendExtensionCRDGVR)
 	}
+	// Initialize ServiceImport informers only for the ServiceImport CRDs installed in the cluster
+	if IsServiceImportEnabled() {
+		if isResourceServed(client, AKOServiceImportGVR) {
+			informers.AKOServiceImportInformer = f.ForResource(AKOServiceImportGVR)
+		}
+		if isResourceServed(client, MultiClusterServiceImportGVR) {
+			informers.MultiClusterServiceImportInformer = f.ForResource(MultiClusterServiceImportGVR)
+		}
+	}
 	dynamicInformerInstance = informers
 	return dynamicInformerInstance
 }
 
+// ConvertToAKOServiceImport converts a ServiceImport of the AKO group fetched by the dynamic informer.
+func ConvertToAKOServiceImport(obj interface{}) (*akov1alpha1.ServiceImport, error) {
+	object, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("invalid ServiceImport object of type %T", obj)
+	}
+	serviceImport := &akov1alpha1.ServiceImport{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.UnstructuredContent(), serviceImport); err != nil {
+		return nil, fmt.Errorf("unable to convert the ServiceImport %s/%s, err: %v", object.GetNamespace(), object.GetName(), err)
+	}
+	return serviceImport, nil
+}
+
+func isResourceServed(client dynamic.Interface, gvr schema.GroupVersionResource) bool {
+	if _, err := client.Resource(gvr).List(context.TODO(), metav1.ListOptions{Limit: 1}); err != nil {
+		utils.AviLog.Warnf("Resource %s is not available in the cluster, err: %v", gvr.String(), err)
+		return false
+	}
+	return true
+}
+
 // GetDynamicInformers returns DynamicInformers instance
 func GetDynamicInformers() *DynamicInformers {
 	if dynamicInformerInstance == nil {
@@ -435,6 +479,20 @@ func ParseRouteBackendExtensionCR(key, namespace, name string, poolNode *nodes.A
 
 }
 
+// IsRouteBackendExtensionBackendTLSSet returns true if the RouteBackendExtension CR configures backendTLS.
+func IsRouteBackendExtensionBackendTLSSet(namespace, name string) bool {
+	clientSet := GetDynamicClientSet()
+	if clientSet == nil {
+		return false
+	}
+	obj, err := clientSet.Resource(RouteBackendExtensionCRDGVR).Namespace(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return false
+	}
+	_, found, err := unstructured.NestedMap(obj.UnstructuredContent(), "spec", "backendTLS")
+	return err == nil && found
+}
+
 // IsApplicationProfileValid checks if the ApplicationProfile CRD is valid as well as ready and processed by AKO CRD Operator.
 func IsApplicationProfileValid(namespace, name string) (bool, bool) {
 	clientSet := GetDynamicClientSet()
@@ -16,9 +16,12 @@ package lib
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 
 	"os"
+	"strconv"
 	"strings"
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -29,6 +32,7 @@ import (
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	akov1beta1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1beta1"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 )
 
@@ -60,6 +64,16 @@ func GetGatewayParentName(namespace, gwName string) string {
 	return lib.GetNamePrefix() + namespace + "-" + gwName + "-EVH"
 }
 
+// parent vs name format of the Gateways merged in a GatewayClass - ako-gw-clustername--gatewayclass-gatewayClassName-EVH
+func GetMergedGatewayParentName(gwClass string) string {
+	return lib.GetNamePrefix() + "gatewayclass-" + gwClass + "-EVH"
+}
+
+// L4 vs name format - ako-gw-clustername--gatewayNs-gatewayName-L4, the parent vs name with -L4 in place of -EVH
+func GetGatewayL4VSName(parentVsName string) string {
+	return strings.TrimSuffix(parentVsName, "-EVH") + "-L4"
+}
+
 // child vs name format - ako-gw-clustername--encoded value of ako-gw-clustername--parentNs-parentName-routeNs-routeName-encodedMatch
 func GetChildName(parentNs, parentName, routeNs, routeName, matchName string) string {
 	name := parentNs + "-" + parentName + "-" + routeNs + "-" + routeName
@@ -94,11 +108,47 @@ func GetPersistenceProfileName(parentNs, parentName, routeNs, routeName, matchNa
 	return lib.Encode(name, lib.ApplicationPersistenceProfile)
 }
 
+func GetTrafficCloneProfileName(parentNs, parentName, routeNs, routeName, matchName string) string {
+	name := parentNs + "-" + parentName + "-" + routeNs + "-" + routeName
+	if matchName != "" {
+		name = fmt.Sprintf("%s-%s", name, utils.Stringify(utils.Hash(matchName)))
+	}
+	return lib.Encode(name, lib.TrafficCloneProfile)
+}
+
+// GetFrontendApplicationProfileName returns the name of the application profile which enforces the client
+// certificate validation on a port of the Gateway.
+func GetFrontendApplicationProfileName(gwNamespace, gwName string, port int32) string {
+	name := fmt.Sprintf("%s-%s-%d-frontend-validation", gwNamespace, gwName, port)
+	return lib.Encode(name, lib.ApplicationProfile)
+}
+
+func GetFrontendPkiProfileName(gwNamespace, gwName string, port int32) string {
+	name := fmt.Sprintf("%s-%s-%d-pkiprofile", gwNamespace, gwName, port)
+	return lib.Encode(name, lib.PKIProfile)
+}
+
 func GetHttpPolicySetName(parentNs, parentName, routeNs, routeName string) string {
 	name := parentNs + "-" + parentName + "-" + routeNs + "-" + routeName + "-httproute"
 	return lib.Encode(name, lib.HTTPPS)
 }
 
+func GetL4PolicySetName(parentNs, parentName, routeNs, routeName, routeType string) string {
+	name := parentNs + "-" + parentName + "-" + routeNs + "-" + routeName + "-" + strings.ToLower(routeType)
+	return lib.Encode(name, lib.L4PS)
+}
+
+// GetTLSPassthroughPGInfix returns the part of the TLS passthrough poolgroup name between the cluster name and the SNI.
+// The passthrough datascript of the gateway selects the poolgroup <clustername>--<infix><sni> for a connection.
+func GetTLSPassthroughPGInfix(parentNs, parentName string) string {
+	return Prefix + parentNs + "-" + parentName + "-"
+}
+
+// GetTLSPassthroughPGName is not encoded, as the name is derived from the SNI by the passthrough datascript.
+func GetTLSPassthroughPGName(parentNs, parentName, hostname string) string {
+	return lib.GetClusterName() + "--" + GetTLSPassthroughPGInfix(parentNs, parentName) + hostname
+}
+
 func GetDedicatedPoolName(poolGroupName, backendNs, backendName string, backendPort int32, backendIndex int) string {
 	var name string
 	if backendName != "" {
@@ -203,16 +253,81 @@ func VerifyHostnameSubdomainMatch(hostname string) bool {
 	return false
 }
 
-func ProtocolToRoute(proto string) string {
-	innerMap := map[string]string{
-		"HTTP":  lib.HTTPRoute,
-		"HTTPS": lib.HTTPRoute,
-		"TCP":   lib.TCPRoute,
-		"TLS":   lib.TLSRoute,
-		"UDP":   lib.UDPRoute,
+// GetSupportedKinds returns the route kinds that can be attached to a listener of the given protocol.
+// GRPCRoutes are translated to child virtual services, so they are not supported in dedicated mode.
+func GetSupportedKinds(protocol gatewayv1.ProtocolType, dedicatedMode bool) []gatewayv1.RouteGroupKind {
+	if !dedicatedMode {
+		return SupportedKinds[protocol]
+	}
+	var kinds []gatewayv1.RouteGroupKind
+	for _, kind := range SupportedKinds[protocol] {
+		if kind.Kind != lib.GRPCRoute {
+			kinds = append(kinds, kind)
+		}
 	}
+	return kinds
+}
 
-	return innerMap[proto]
+// SupportedKindsMessage lists the route kinds for the listener status message,
+// e.g. "HTTPRoute and GRPCRoute are" or "TCPRoute is".
+func SupportedKindsMessage(kinds []gatewayv1.RouteGroupKind) string {
+	names := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		names = append(names, string(kind.Kind))
+	}
+	if len(names) == 1 {
+		return names[0] + " is"
+	}
+	return strings.Join(names, " and ") + " are"
+}
+
+// IsL4Protocol returns true for the listener protocols that are served through
+// L4 policysets on the parent VS.
+func IsL4Protocol(proto string) bool {
+	return proto == string(gatewayv1.TCPProtocolType) || proto == string(gatewayv1.UDPProtocolType)
+}
+
+// IsL4RouteType returns true for the route kinds which are not processed as L7 child VSes.
+func IsL4RouteType(routeType string) bool {
+	return routeType == lib.TCPRoute || routeType == lib.UDPRoute || routeType == lib.TLSRoute
+}
+
+// IsTLSPassthroughListener returns true for TLS listeners which pass the TLS connection through to the backends.
+func IsTLSPassthroughListener(listener gatewayv1.Listener) bool {
+	return listener.Protocol == gatewayv1.TLSProtocolType &&
+		listener.TLS != nil && listener.TLS.Mode != nil && *listener.TLS.Mode == gatewayv1.TLSModePassthrough
+}
+
+// IsSupportedRouteKind returns true if a route of the given kind can be attached to a listener of the given protocol.
+// GRPCRoute is not supported on the listeners of a Gateway in dedicated mode.
+func IsSupportedRouteKind(protocol gatewayv1.ProtocolType, kind string, dedicatedMode bool) bool {
+	for _, supportedKind := range GetSupportedKinds(protocol, dedicatedMode) {
+		if string(supportedKind.Kind) == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExperimentalRoutesEnabled returns true when the experimental channel routes
+// (TCPRoute, UDPRoute, TLSRoute) are to be processed by AKO.
+func IsExperimentalRoutesEnabled() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv(ExperimentalRoutesEnv))
+	return enabled
+}
+
+// IsBackendTLSPolicyEnabled returns true when the experimental channel BackendTLSPolicy
+// is to be processed by AKO.
+func IsBackendTLSPolicyEnabled() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv(BackendTLSPolicyEnv))
+	return enabled
+}
+
+// IsServiceImportEnabled returns true when the ServiceImports referred as HTTPRoute backends
+// are to be processed by AKO.
+func IsServiceImportEnabled() bool {
+	enabled, _ := strconv.ParseBool(os.Getenv(ServiceImportEnv))
+	return enabled
 }
 
 func GetDefaultHTTPPSName() string {
@@ -269,6 +384,11 @@ func IsGatewayInDedicatedMode(namespace, gatewayName string) bool {
 		return false
 	}
 
+	// Gateways merged onto the parent VS of their GatewayClass can not be dedicated
+	if IsGatewayClassInMergeMode(string(gateway.Spec.GatewayClassName)) {
+		return false
+	}
+
 	// Check annotation for dedicated mode
 	if annotation, exists := gateway.GetAnnotations()[DedicatedGatewayModeAnnotation]; exists {
 		return annotation == "true"
@@ -277,6 +397,172 @@ func IsGatewayInDedicatedMode(namespace, gatewayName string) bool {
 	return false
 }
 
+// IsGatewayClassInMergeMode returns true if the Gateways of the GatewayClass are merged onto
+// a single parent VS and VIP, as set by the merge gateways annotation of the GatewayClass.
+func IsGatewayClassInMergeMode(gwClassName string) bool {
+	gwClass, err := AKOControlConfig().GatewayApiInformers().GatewayClassInformer.Lister().Get(gwClassName)
+	if err != nil {
+		utils.AviLog.Debugf("Failed to get gateway class %s: %v", gwClassName, err)
+		return false
+	}
+	return gwClass.GetAnnotations()[MergeGatewaysAnnotation] == "true"
+}
+
+// HasGatewayPrecedence returns true if the gateway takes precedence over the other gateway. As per the
+// Gateway API conflict resolution the oldest Gateway takes precedence, followed by the first in the
+// alphabetical order of namespace/name.
+func HasGatewayPrecedence(gateway, otherGateway *gatewayv1.Gateway) bool {
+	if !gateway.CreationTimestamp.Equal(&otherGateway.CreationTimestamp) {
+		return gateway.CreationTimestamp.Before(&otherGateway.CreationTimestamp)
+	}
+	return gateway.Namespace+"/"+gateway.Name < otherGateway.Namespace+"/"+otherGateway.Name
+}
+
 func GetGatewayDedicatedVSName(namespace, gatewayName string) string {
 	return lib.GetNamePrefix() + namespace + "-" + gatewayName
 }
+
+func isAviInfraSettingParametersRef(group gatewayv1.Group, kind gatewayv1.Kind) bool {
+	return string(group) == lib.AkoGroup && string(kind) == lib.AviInfraSetting
+}
+
+// GetAviInfraSettingRefNameForGatewayClass returns the name of the AviInfraSetting referred
+// by the parametersRef of the GatewayClass. found is false when no parametersRef is set.
+func GetAviInfraSettingRefNameForGatewayClass(gwClass *gatewayv1.GatewayClass) (string, bool, error) {
+	ref := gwClass.Spec.ParametersRef
+	if ref == nil {
+		return "", false, nil
+	}
+	if !isAviInfraSettingParametersRef(ref.Group, ref.Kind) {
+		return "", true, fmt.Errorf("parametersRef %s/%s is not supported, only %s/%s is supported", ref.Group, ref.Kind, lib.AkoGroup, lib.AviInfraSetting)
+	}
+	if ref.Namespace != nil && *ref.Namespace != "" {
+		return "", true, fmt.Errorf("parametersRef namespace must not be set, %s is a cluster scoped resource", lib.AviInfraSetting)
+	}
+	return ref.Name, true, nil
+}
+
+// GetAviInfraSettingRefName returns the name of the AviInfraSetting referred by the Gateway.
+// The spec.infrastructure.parametersRef of the Gateway takes precedence over the parametersRef
+// of its GatewayClass. found is false when neither of them is set, in which case the
+// AviInfraSetting annotated on the namespace of the Gateway applies.
+func GetAviInfraSettingRefName(gateway *gatewayv1.Gateway) (string, bool, error) {
+	if gateway.Spec.Infrastructure != nil && gateway.Spec.Infrastructure.ParametersRef != nil {
+		ref := gateway.Spec.Infrastructure.ParametersRef
+		if !isAviInfraSettingParametersRef(ref.Group, ref.Kind) {
+			return "", true, fmt.Errorf("parametersRef %s/%s is not supported, only %s/%s is supported", ref.Group, ref.Kind, lib.AkoGroup, lib.AviInfraSetting)
+		}
+		return ref.Name, true, nil
+	}
+	gwClass, err := AKOControlConfig().GatewayApiInformers().GatewayClassInformer.Lister().Get(string(gateway.Spec.GatewayClassName))
+	if err != nil {
+		return "", false, nil
+	}
+	return GetAviInfraSettingRefNameForGatewayClass(gwClass)
+}
+
+// GetReferredAviInfraSetting returns the AviInfraSetting referred through a parametersRef,
+// or an error when it does not exist or is not Accepted.
+func GetReferredAviInfraSetting(name string) (*akov1beta1.AviInfraSetting, error) {
+	if !AKOControlConfig().AviInfraSettingEnabled() {
+		return nil, fmt.Errorf("%s is not enabled", lib.AviInfraSetting)
+	}
+	infraSetting, err := AKOControlConfig().AviInfraSettingInformer().Lister().Get(name)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s not found", lib.AviInfraSetting, name)
+	}
+	if infraSetting.Status.Status != lib.StatusAccepted {
+		return nil, fmt.Errorf("%s %s is not Accepted", lib.AviInfraSetting, name)
+	}
+	return infraSetting, nil
+}
+
+// GetAviInfraSettingForGateway returns the AviInfraSetting applicable to the Gateway. An
+// AviInfraSetting referred through parametersRef takes precedence over the one annotated
+// on the namespace of the Gateway.
+func GetAviInfraSettingForGateway(key string, gateway *gatewayv1.Gateway) (*akov1beta1.AviInfraSetting, error) {
+	name, found, err := GetAviInfraSettingRefName(gateway)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return lib.GetNamespacedAviInfraSetting(key, gateway.Namespace, AKOControlConfig().AviInfraSettingInformer())
+	}
+	return GetReferredAviInfraSetting(name)
+}
+
+// GetFrontendValidationCACert returns the CA bundle used to validate the client certificates on a listener.
+// The CA certificates are read from the ca.crt key of the referred ConfigMaps and Secrets, each of them
+// must hold at least one PEM encoded certificate.
+func GetFrontendValidationCACert(gatewayNamespace string, frontendValidation *gatewayv1.FrontendTLSValidation) (string, error) {
+	var caCerts []string
+	for _, caCertRef := range frontendValidation.CACertificateRefs {
+		namespace := gatewayNamespace
+		if caCertRef.Namespace != nil && *caCertRef.Namespace != "" {
+			namespace = string(*caCertRef.Namespace)
+		}
+		name := string(caCertRef.Name)
+		if caCertRef.Group != "" {
+			return "", fmt.Errorf("CACertificateRef %s/%s of group %s is not supported", namespace, name, caCertRef.Group)
+		}
+		var caCert string
+		switch string(caCertRef.Kind) {
+		case ConfigMapKind:
+			configMap, err := AKOControlConfig().GatewayApiInformers().ConfigMapInformer.Lister().ConfigMaps(namespace).Get(name)
+			if err != nil {
+				return "", fmt.Errorf("CACertificateRef ConfigMap %s/%s not found", namespace, name)
+			}
+			caCert = configMap.Data[CACertificateKey]
+		case utils.Secret:
+			secret, err := utils.GetInformers().SecretInformer.Lister().Secrets(namespace).Get(name)
+			if err != nil {
+				return "", fmt.Errorf("CACertificateRef Secret %s/%s not found", namespace, name)
+			}
+			caCert = string(secret.Data[CACertificateKey])
+		default:
+			return "", fmt.Errorf("CACertificateRef %s/%s of kind %s is not supported", namespace, name, caCertRef.Kind)
+		}
+		if caCert == "" {
+			return "", fmt.Errorf("CACertificateRef %s %s/%s does not contain %s", caCertRef.Kind, namespace, name, CACertificateKey)
+		}
+		if !isValidCABundle(caCert) {
+			return "", fmt.Errorf("CACertificateRef %s %s/%s does not contain a valid PEM encoded CA certificate", caCertRef.Kind, namespace, name)
+		}
+		caCerts = append(caCerts, strings.TrimSpace(caCert))
+	}
+	return strings.Join(caCerts, "\n"), nil
+}
+
+// GetFrontendValidationCACertRefs returns the ConfigMaps and Secrets referred by the frontendValidation
+// of a listener, in kind/namespace/name format.
+func GetFrontendValidationCACertRefs(gatewayNamespace string, frontendValidation *gatewayv1.FrontendTLSValidation) []string {
+	var caCertRefs []string
+	for _, caCertRef := range frontendValidation.CACertificateRefs {
+		namespace := gatewayNamespace
+		if caCertRef.Namespace != nil && *caCertRef.Namespace != "" {
+			namespace = string(*caCertRef.Namespace)
+		}
+		caCertRefs = append(caCertRefs, string(caCertRef.Kind)+"/"+namespace+"/"+string(caCertRef.Name))
+	}
+	return caCertRefs
+}
+
+func isValidCABundle(caBundle string) bool {
+	rest := []byte(caBundle)
+	certCount := 0
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return false
+		}
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return false
+		}
+		certCount++
+	}
+	return certCount > 0 && strings.TrimSpace(string(rest)) == ""
+}
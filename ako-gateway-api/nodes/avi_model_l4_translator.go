@@ -15,19 +15,354 @@
 package nodes
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/vmware/alb-sdk/go/models"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	akogatewayapiobjects "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 )
 
 func (o *AviObjectGraph) ProcessL4Routes(key string, routeModel RouteModel, parentNsName string) {
-	for _, rule := range routeModel.ParseRouteConfig(key).Rules {
-		parentNode := o.GetAviEvhVS()
-		// create L4 policyset per rule
-		o.BuildL4PolicySet(key, parentNode[0], routeModel, rule)
-		// Logic to override the app profile per port also must be taken care here.
+	parentNode := o.GetAviEvhVS()
+	// the policyset or the passthrough poolgroups, along with the pools, of the route are rebuilt on every update
+	removeL4RouteObjects(key, parentNsName, parentNode[0], routeModel)
+
+	rules := routeModel.ParseRouteConfig(key).Rules
+	if len(rules) == 0 {
+		utils.AviLog.Warnf("key: %s, msg: no rules found in %s %s/%s", key, routeModel.GetType(), routeModel.GetNamespace(), routeModel.GetName())
+		return
+	}
+	if len(rules) > 1 {
+		// traffic on a listener port can be sent to a single set of backends, so only the first rule is honoured.
+		// The dropped rules are reported with the PartiallyInvalid condition in the route status.
+		utils.AviLog.Warnf("key: %s, msg: only the first rule of %s %s/%s is processed", key, routeModel.GetType(), routeModel.GetNamespace(), routeModel.GetName())
+	}
+	if routeModel.GetType() == lib.TLSRoute {
+		o.BuildTLSPassthrough(key, parentNsName, parentNode[0], routeModel, rules[0])
+		return
+	}
+	l4VsNode := getL4ChildVS(parentNode[0])
+	if l4VsNode == nil {
+		utils.AviLog.Warnf("key: %s, msg: no TCP/UDP listener port is served by the VS %s", key, parentNode[0].Name)
+		return
+	}
+	o.BuildL4PolicySet(key, parentNsName, l4VsNode, routeModel, rules[0])
+}
+
+// BuildL4PolicySet adds a L4 policyset to the L4 VS of the gateway, which selects the poolgroup of the route for the
+// ports of the listeners the route is attached to.
+func (o *AviObjectGraph) BuildL4PolicySet(key, parentNsName string, vsNode *nodes.AviVsNode, routeModel RouteModel, rule *Rule) {
+	routeTypeNsName := routeModel.GetType() + "/" + routeModel.GetNamespace() + "/" + routeModel.GetName()
+	parentNs, _, parentName := lib.ExtractTypeNameNamespace(parentNsName)
+	listeners := akogatewayapiobjects.GatewayApiLister().GetRouteToGatewayListener(routeTypeNsName, parentNsName)
+	if len(listeners) == 0 {
+		utils.AviLog.Warnf("key: %s, msg: No matching listener available for the route : %s", key, routeTypeNsName)
+		return
+	}
+	routeType := strings.ToLower(routeModel.GetType())
+	pgName := akogatewayapilib.GetPoolGroupName(parentNs, parentName, routeModel.GetNamespace(), routeModel.GetName(), routeType)
+	PG, pools := buildL4PoolGroup(key, parentNsName, vsNode.Tenant, routeModel, rule, pgName, routeType, listeners[0].Protocol)
+	if PG == nil {
+		utils.AviLog.Warnf("key: %s, msg: no valid backends found for the route : %s", key, routeTypeNsName)
+		return
+	}
+	vsNode.PoolRefs = append(vsNode.PoolRefs, pools...)
+	vsNode.PoolGroupRefs = append(vsNode.PoolGroupRefs, PG)
+
+	l4PolicyNode := &nodes.AviL4PolicyNode{
+		Name:       akogatewayapilib.GetL4PolicySetName(parentNs, parentName, routeModel.GetNamespace(), routeModel.GetName(), routeModel.GetType()),
+		Tenant:     vsNode.Tenant,
+		AviMarkers: PG.AviMarkers,
+	}
+	for _, listener := range listeners {
+		l4PolicyNode.PortPool = append(l4PolicyNode.PortPool, nodes.AviHostPathPortPoolPG{
+			Name:      listener.Name,
+			Port:      uint32(listener.Port),
+			PoolGroup: PG.Name,
+			Protocol:  listener.Protocol,
+		})
+	}
+	vsNode.L4PolicyRefs = append(vsNode.L4PolicyRefs, l4PolicyNode)
+	utils.AviLog.Infof("key: %s, msg: evaluated L4 policyset for the route %s: %v", key, routeTypeNsName, utils.Stringify(l4PolicyNode))
+}
+
+// BuildTLSPassthrough adds a poolgroup per SNI hostname of the TLSRoute to the parent VS. The passthrough
+// datascript of the parent VS selects the poolgroup by the SNI of the incoming TLS connection.
+func (o *AviObjectGraph) BuildTLSPassthrough(key, parentNsName string, vsNode *nodes.AviEvhVsNode, routeModel RouteModel, rule *Rule) {
+	routeTypeNsName := routeModel.GetType() + "/" + routeModel.GetNamespace() + "/" + routeModel.GetName()
+	parentNs, _, parentName := lib.ExtractTypeNameNamespace(parentNsName)
+	listeners := akogatewayapiobjects.GatewayApiLister().GetRouteToGatewayListener(routeTypeNsName, parentNsName)
+	if len(listeners) == 0 {
+		utils.AviLog.Warnf("key: %s, msg: No matching listener available for the route : %s", key, routeTypeNsName)
+		return
+	}
+	gateway, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayInformer.Lister().Gateways(parentNs).Get(parentName)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to get the gateway %s, err: %v", key, parentNsName, err)
+		return
+	}
+	var routeHostnames []gatewayv1.Hostname
+	for _, host := range routeModel.ParseRouteConfig(key).Hosts {
+		routeHostnames = append(routeHostnames, gatewayv1.Hostname(host))
+	}
+	var sniHostnames []string
+	for _, listener := range listeners {
+		i := akogatewayapilib.FindListenerByName(listener.Name, gateway.Spec.Listeners)
+		if i == -1 {
+			continue
+		}
+		_, hostnames, _ := getTLSPassthroughHostnames(key, gateway.Spec.Listeners[i], routeHostnames)
+		for _, hostname := range hostnames {
+			if !utils.HasElem(sniHostnames, hostname) {
+				sniHostnames = append(sniHostnames, hostname)
+			}
+		}
+	}
+
+	for _, hostname := range sniHostnames {
+		pgName := akogatewayapilib.GetTLSPassthroughPGName(parentNs, parentName, hostname)
+		if lib.CheckObjectNameLength(pgName, lib.PG) {
+			continue
+		}
+		if pg := findPoolGroupInVS(vsNode, pgName); pg != nil {
+			// the hostname is served by the route which was processed first.
+			utils.AviLog.Warnf("key: %s, msg: hostname %s of the route %s is already routed by the TLSRoute %s/%s", key, hostname, routeTypeNsName, pg.AviMarkers.HTTPRouteNamespace, pg.AviMarkers.HTTPRouteName)
+			continue
+		}
+		PG, pools := buildL4PoolGroup(key, parentNsName, vsNode.Tenant, routeModel, rule, pgName, hostname, utils.TCP)
+		if PG == nil {
+			utils.AviLog.Warnf("key: %s, msg: no valid backends found for the route : %s", key, routeTypeNsName)
+			continue
+		}
+		vsNode.PoolRefs = append(vsNode.PoolRefs, pools...)
+		vsNode.PoolGroupRefs = append(vsNode.PoolGroupRefs, PG)
+
+		dsNode := getTLSPassthroughDataScript(vsNode)
+		if dsNode == nil {
+			dsNode = nodes.ConstructL4DataScriptForEvh(vsNode.Name, key, vsNode)
+			dsNode.Script = strings.Replace(dsNode.Script, "AVIINFRA", akogatewayapilib.GetTLSPassthroughPGInfix(parentNs, parentName), 1)
+		}
+		dsNode.PoolGroupRefs = append(dsNode.PoolGroupRefs, PG.Name)
+		utils.AviLog.Infof("key: %s, msg: added the passthrough poolgroup %s for the route %s", key, PG.Name, routeTypeNsName)
+	}
+}
+
+// buildL4PoolGroup builds the pools for the backends of the rule and returns them along with the poolgroup having
+// the pools as members. A nil poolgroup is returned when none of the backends could be resolved.
+func buildL4PoolGroup(key, parentNsName, tenant string, routeModel RouteModel, rule *Rule, pgName, matchName, protocol string) (*nodes.AviPoolGroupNode, []*nodes.AviPoolNode) {
+	parentNs, _, parentName := lib.ExtractTypeNameNamespace(parentNsName)
+	markers := utils.AviObjectMarkers{
+		GatewayName:        parentName,
+		GatewayNamespace:   parentNs,
+		HTTPRouteName:      routeModel.GetName(),
+		HTTPRouteNamespace: routeModel.GetNamespace(),
+	}
+	if rule.Name != "" {
+		markers.HTTPRouteRuleName = rule.Name
+	}
+
+	PG := &nodes.AviPoolGroupNode{
+		Name:       pgName,
+		Tenant:     tenant,
+		AviMarkers: markers,
+	}
+	var pools []*nodes.AviPoolNode
+	for _, backend := range rule.Backends {
+		poolName := akogatewayapilib.GetPoolName(parentNs, parentName,
+			routeModel.GetNamespace(), routeModel.GetName(), matchName,
+			backend.Backend.Namespace, backend.Backend.Name, strconv.Itoa(int(backend.Backend.Port)))
+		svcObj, err := utils.GetInformers().ServiceInformer.Lister().Services(backend.Backend.Namespace).Get(backend.Backend.Name)
+		if err != nil {
+			utils.AviLog.Debugf("key: %s, msg: there was an error in retrieving the service", key)
+			continue
+		}
+		poolNode := &nodes.AviPoolNode{
+			Name:       poolName,
+			Tenant:     tenant,
+			Protocol:   protocol,
+			PortName:   akogatewayapilib.FindPortName(backend.Backend.Name, backend.Backend.Namespace, backend.Backend.Port, key),
+			TargetPort: akogatewayapilib.FindTargetPort(backend.Backend.Name, backend.Backend.Namespace, backend.Backend.Port, key),
+			Port:       backend.Backend.Port,
+			ServiceMetadata: lib.ServiceMetadataObj{
+				NamespaceServiceName: []string{backend.Backend.Namespace + "/" + backend.Backend.Name},
+			},
+			VrfContext: lib.GetVrf(),
+		}
+		poolNode.AviMarkers = markers
+		poolNode.AviMarkers.BackendNs = backend.Backend.Namespace
+		poolNode.AviMarkers.BackendName = backend.Backend.Name
+
+		if lib.IsIstioEnabled() {
+			poolNode.UpdatePoolNodeForIstio()
+		}
+
+		t1LR := lib.GetT1LRPath()
+		if found, infraSettingName := akogatewayapiobjects.GatewayApiLister().GetGatewayToAviInfraSetting(parentNsName); found {
+			if infraSetting, err := akogatewayapilib.AKOControlConfig().AviInfraSettingInformer().Lister().Get(infraSettingName); err != nil {
+				utils.AviLog.Warnf("key: %s, msg: failed to retrieve AviInfraSetting %s, err: %s", key, infraSettingName, err.Error())
+			} else if infraSetting != nil && infraSetting.Status.Status == lib.StatusAccepted && infraSetting.Spec.NSXSettings.T1LR != nil {
+				t1LR = *infraSetting.Spec.NSXSettings.T1LR
+			}
+		}
+		if t1LR != "" {
+			poolNode.T1Lr = t1LR
+			poolNode.VrfContext = ""
+		}
+		poolNode.NetworkPlacementSettings = lib.GetNodeNetworkMap()
+		var servers []nodes.AviPoolMetaServer
+		switch lib.GetServiceType() {
+		case lib.NodePortLocal:
+			servers = nodes.PopulateServersForNPL(poolNode, svcObj.Namespace, svcObj.Name, false, key)
+		case lib.NodePort:
+			servers = nodes.PopulateServersForNodePort(poolNode, svcObj.Namespace, svcObj.Name, false, key)
+		default:
+			servers = nodes.PopulateServers(poolNode, svcObj.Namespace, svcObj.Name, false, key)
+		}
+		if servers != nil {
+			poolNode.Servers = servers
+		}
+		pools = append(pools, poolNode)
+
+		poolRef := fmt.Sprintf("/api/pool?name=%s", poolNode.Name)
+		ratio := uint32(backend.Backend.Weight)
+		PG.Members = append(PG.Members, &models.PoolGroupMember{PoolRef: &poolRef, Ratio: &ratio})
+	}
+	if len(PG.Members) == 0 {
+		return nil, nil
+	}
+	return PG, pools
+}
+
+func findPoolGroupInVS(vsNode *nodes.AviEvhVsNode, pgName string) *nodes.AviPoolGroupNode {
+	for _, pg := range vsNode.PoolGroupRefs {
+		if pg.Name == pgName {
+			return pg
+		}
+	}
+	return nil
+}
+
+// getL4ChildVS returns the L4 VS serving the TCP and UDP listeners of the gateway, nil if there is none.
+func getL4ChildVS(vsNode *nodes.AviEvhVsNode) *nodes.AviVsNode {
+	if len(vsNode.L4ChildNodes) == 0 {
+		return nil
+	}
+	return vsNode.L4ChildNodes[0]
+}
+
+func getTLSPassthroughDataScript(vsNode *nodes.AviEvhVsNode) *nodes.AviHTTPDataScriptNode {
+	dsName := lib.GetL7InsecureDSName(vsNode.Name)
+	for _, ds := range vsNode.HTTPDSrefs {
+		if ds.Name == dsName {
+			return ds
+		}
+	}
+	return nil
+}
+
+// removeL4RouteObjects removes the objects built for a TCPRoute or UDPRoute from the L4 VS of the gateway, and the
+// ones built for a TLSRoute from the parent VS.
+func removeL4RouteObjects(key, parentNsName string, vsNode *nodes.AviEvhVsNode, routeModel RouteModel) {
+	parentNs, _, parentName := lib.ExtractTypeNameNamespace(parentNsName)
+	if routeModel.GetType() == lib.TLSRoute {
+		removeTLSPassthroughPoolGroups(key, parentNs, parentName, vsNode, routeModel.GetNamespace(), routeModel.GetName())
+		return
 	}
+	l4VsNode := getL4ChildVS(vsNode)
+	if l4VsNode == nil {
+		return
+	}
+	l4PSName := akogatewayapilib.GetL4PolicySetName(parentNs, parentName, routeModel.GetNamespace(), routeModel.GetName(), routeModel.GetType())
+	removeL4PolicySet(key, l4VsNode, l4PSName)
+}
+
+// removeL4PolicySet removes the L4 policyset along with the poolgroups and pools referred by it from the L4 VS.
+func removeL4PolicySet(key string, vsNode *nodes.AviVsNode, l4PSName string) {
+	pgNames := make(map[string]struct{})
+	var l4PolicyRefs []*nodes.AviL4PolicyNode
+	for _, l4Policy := range vsNode.L4PolicyRefs {
+		if l4Policy.Name != l4PSName {
+			l4PolicyRefs = append(l4PolicyRefs, l4Policy)
+			continue
+		}
+		for _, portPool := range l4Policy.PortPool {
+			pgNames[portPool.PoolGroup] = struct{}{}
+		}
+	}
+	if len(l4PolicyRefs) == len(vsNode.L4PolicyRefs) {
+		return
+	}
+	vsNode.L4PolicyRefs = l4PolicyRefs
+	vsNode.PoolGroupRefs, vsNode.PoolRefs = removePoolGroups(vsNode.PoolGroupRefs, vsNode.PoolRefs, pgNames)
+	utils.AviLog.Infof("key: %s, msg: removed L4 policyset %s from the VS %s", key, l4PSName, vsNode.Name)
 }
 
-func (o *AviObjectGraph) BuildL4PolicySet(key string, vsNode *nodes.AviEvhVsNode, routeModel RouteModel, rule *Rule) {
+// removeTLSPassthroughPoolGroups removes the passthrough poolgroups of the TLSRoute along with their pools from the
+// parent VS. The passthrough datascript is removed once it does not refer any poolgroup.
+func removeTLSPassthroughPoolGroups(key, parentNs, parentName string, vsNode *nodes.AviEvhVsNode, routeNs, routeName string) {
+	pgPrefix := akogatewayapilib.GetTLSPassthroughPGName(parentNs, parentName, "")
+	pgNames := make(map[string]struct{})
+	for _, pg := range vsNode.PoolGroupRefs {
+		if strings.HasPrefix(pg.Name, pgPrefix) && pg.AviMarkers.HTTPRouteNamespace == routeNs && pg.AviMarkers.HTTPRouteName == routeName {
+			pgNames[pg.Name] = struct{}{}
+		}
+	}
+	if len(pgNames) == 0 {
+		return
+	}
+	vsNode.PoolGroupRefs, vsNode.PoolRefs = removePoolGroups(vsNode.PoolGroupRefs, vsNode.PoolRefs, pgNames)
 
-	// TODO: add the l4policset code here
+	dsNode := getTLSPassthroughDataScript(vsNode)
+	if dsNode == nil {
+		return
+	}
+	var pgRefs []string
+	for _, pgName := range dsNode.PoolGroupRefs {
+		if _, ok := pgNames[pgName]; !ok {
+			pgRefs = append(pgRefs, pgName)
+		}
+	}
+	dsNode.PoolGroupRefs = pgRefs
+	if len(dsNode.PoolGroupRefs) == 0 {
+		var dsRefs []*nodes.AviHTTPDataScriptNode
+		for _, ds := range vsNode.HTTPDSrefs {
+			if ds.Name != dsNode.Name {
+				dsRefs = append(dsRefs, ds)
+			}
+		}
+		vsNode.HTTPDSrefs = dsRefs
+	}
+	utils.AviLog.Infof("key: %s, msg: removed the passthrough poolgroups of the TLSRoute %s/%s from the VS %s", key, routeNs, routeName, vsNode.Name)
+}
+
+// removePoolGroups returns the poolgroups and pools of a VS without the given poolgroups and their member pools.
+func removePoolGroups(pgs []*nodes.AviPoolGroupNode, pools []*nodes.AviPoolNode, pgNames map[string]struct{}) ([]*nodes.AviPoolGroupNode, []*nodes.AviPoolNode) {
+	poolNames := make(map[string]struct{})
+	var pgRefs []*nodes.AviPoolGroupNode
+	for _, pg := range pgs {
+		if _, ok := pgNames[pg.Name]; !ok {
+			pgRefs = append(pgRefs, pg)
+			continue
+		}
+		for _, member := range pg.Members {
+			if member.PoolRef != nil {
+				poolNames[strings.TrimPrefix(*member.PoolRef, "/api/pool?name=")] = struct{}{}
+			}
+		}
+	}
+
+	var poolRefs []*nodes.AviPoolNode
+	for _, pool := range pools {
+		if _, ok := poolNames[pool.Name]; !ok {
+			poolRefs = append(poolRefs, pool)
+		}
+	}
+	return pgRefs, poolRefs
 }
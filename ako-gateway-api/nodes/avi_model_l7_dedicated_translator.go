@@ -45,6 +45,13 @@ func (o *AviObjectGraph) ProcessL7RoutesForDedicatedGateway(key string, routeMod
 	ruleToPoolGroupIndex := make(map[*Rule]int)
 	poolGroupIndex := 0
 	for _, rule := range httpRouteRules {
+		for _, filter := range rule.Filters {
+			if filter.RequestMirror != nil {
+				// traffic clone profile applies to all the requests of a VS, hence not supported per rule on a dedicated VS.
+				// Such rules are rejected in the route status by the route validation.
+				utils.AviLog.Warnf("key: %s, msg: RequestMirror filter is not supported for dedicated gateway %s, ignoring it", key, parentNsName)
+			}
+		}
 		if _, exists := ruleToPoolGroupIndex[rule]; !exists {
 			ruleToPoolGroupIndex[rule] = poolGroupIndex
 			poolGroupIndex++
@@ -116,7 +123,7 @@ func (o *AviObjectGraph) BuildHTTPPolicySetsForDedicatedMode(key string, vsNode
 	var allMatches []MatchWithMetadata
 	for ruleIndex, rule := range httpRouteRules {
 		for matchIndex, match := range rule.Matches {
-			if match.PathMatch.Path == "/" {
+			if match.PathMatch.Path == "/" && match.Method == "" && len(match.QueryParamMatch) == 0 {
 				rootPathPresent = true
 			}
 			allMatches = append(allMatches, MatchWithMetadata{
@@ -146,6 +153,19 @@ func (o *AviObjectGraph) BuildHTTPPolicySetsForDedicatedMode(key string, vsNode
 			return len(pathI) > len(pathJ)
 		}
 
+		// as per Gateway API precedence, method match and then more header and query param matches win
+		methodI := allMatches[i].Match.Method != ""
+		methodJ := allMatches[j].Match.Method != ""
+		if methodI != methodJ {
+			return methodI
+		}
+		if len(allMatches[i].Match.HeaderMatch) != len(allMatches[j].Match.HeaderMatch) {
+			return len(allMatches[i].Match.HeaderMatch) > len(allMatches[j].Match.HeaderMatch)
+		}
+		if len(allMatches[i].Match.QueryParamMatch) != len(allMatches[j].Match.QueryParamMatch) {
+			return len(allMatches[i].Match.QueryParamMatch) > len(allMatches[j].Match.QueryParamMatch)
+		}
+
 		// maintain rule index
 		if allMatches[i].RuleIndex != allMatches[j].RuleIndex {
 			return allMatches[i].RuleIndex < allMatches[j].RuleIndex
@@ -257,17 +277,7 @@ func (o *AviObjectGraph) BuildHTTPRequestRuleWithMatch(key string, policy *nodes
 	for _, filter := range rule.Filters {
 		// Handle redirect filter
 		if filter.RedirectFilter != nil {
-			redirect := &models.HTTPRedirectAction{}
-			if filter.RedirectFilter.Host != "" {
-				redirect.Host = &models.URIParam{
-					Type:   proto.String("HTTP_TYPE_HOST"),
-					Tokens: []*models.URIParamToken{{Type: proto.String("URI_TOKEN_TYPE_STRING"), StrValue: &filter.RedirectFilter.Host}},
-				}
-			}
-			if filter.RedirectFilter.StatusCode != 0 {
-				redirect.StatusCode = proto.String(fmt.Sprintf("HTTP_REDIRECT_%d", filter.RedirectFilter.StatusCode))
-			}
-			httpRequestRule.RedirectAction = redirect
+			httpRequestRule.RedirectAction = buildRedirectAction(filter.RedirectFilter, match)
 		}
 		// Handle request header modifications
 		if filter.RequestFilter != nil {
@@ -292,15 +302,9 @@ func (o *AviObjectGraph) BuildHTTPRequestRuleWithMatch(key string, policy *nodes
 		if filter.UrlRewriteFilter != nil {
 			rewriteAction := &models.HTTPRewriteURLAction{}
 			if filter.UrlRewriteFilter.path != nil {
-				var pathValue string
-				if filter.UrlRewriteFilter.path.ReplaceFullPath != nil {
-					pathValue = *filter.UrlRewriteFilter.path.ReplaceFullPath
-				}
-				if pathValue != "" {
-					rewriteAction.Path = &models.URIParam{
-						Type:   proto.String("URI_PARAM_TYPE_TOKENIZED"),
-						Tokens: []*models.URIParamToken{{Type: proto.String("URI_TOKEN_TYPE_STRING"), StrValue: &pathValue}},
-					}
+				// the request path is retained when the full path is empty
+				if filter.UrlRewriteFilter.path.ReplaceFullPath == nil || *filter.UrlRewriteFilter.path.ReplaceFullPath != "" {
+					rewriteAction.Path = buildPathURIParam(filter.UrlRewriteFilter.path, match)
 				}
 			}
 			if filter.UrlRewriteFilter.hostname != "" {
@@ -327,6 +331,13 @@ func (o *AviObjectGraph) BuildHTTPRequestRuleWithMatch(key string, policy *nodes
 		httpRequestRule.SwitchingAction = switchAction
 	}
 
+	// requests on the exact path prefix are matched first, when the path prefix is replaced
+	if exactPrefixRule := buildExactPrefixRequestRule(httpRequestRule, rule.Filters, match); exactPrefixRule != nil {
+		policy.RequestRules = append(policy.RequestRules, exactPrefixRule)
+		*requestRuleIndex++
+		httpRequestRule.Index = proto.Int32(*requestRuleIndex)
+	}
+
 	// Add the rule to the policy
 	policy.RequestRules = append(policy.RequestRules, httpRequestRule)
 	*requestRuleIndex++
@@ -421,6 +432,22 @@ func (o *AviObjectGraph) BuildMatchTarget(matchTarget *models.MatchTarget, match
 		}
 	}
 
+	// Handle query param matching, the regex is attached via string group
+	if len(match.QueryParamMatch) > 0 {
+		queryRegex := getQueryParamRegex(match.QueryParamMatch[0])
+		regexStringGroupName := lib.GetEncodedStringGroupName("", queryRegex)
+		matchTarget.Query = &models.QueryMatch{
+			MatchCase:       proto.String("SENSITIVE"),
+			MatchCriteria:   proto.String("QUERY_MATCH_REGEX_MATCH"),
+			StringGroupRefs: []string{"/api/stringgroup?name=" + regexStringGroupName},
+		}
+		o.addStringGroup(regexStringGroupName, queryRegex, tenant, vsNode)
+	}
+
+	// Handle method matching
+	if match.Method != "" {
+		matchTarget.Method = getMethodMatch(match.Method)
+	}
 }
 
 // BuildResponseMatchTarget builds the ResponseMatchTarget for HTTP response rules
@@ -462,6 +489,23 @@ func (o *AviObjectGraph) BuildResponseMatchTarget(responseMatchTarget *models.Re
 			responseMatchTarget.Hdrs = append(responseMatchTarget.Hdrs, hdrMatch)
 		}
 	}
+
+	// Query param matching (applicable to response - matches the request query)
+	if len(match.QueryParamMatch) > 0 {
+		queryRegex := getQueryParamRegex(match.QueryParamMatch[0])
+		regexStringGroupName := lib.GetEncodedStringGroupName("", queryRegex)
+		responseMatchTarget.Query = &models.QueryMatch{
+			MatchCase:       proto.String("SENSITIVE"),
+			MatchCriteria:   proto.String("QUERY_MATCH_REGEX_MATCH"),
+			StringGroupRefs: []string{"/api/stringgroup?name=" + regexStringGroupName},
+		}
+		o.addStringGroup(regexStringGroupName, queryRegex, tenant, vsNode)
+	}
+
+	// Method matching (applicable to response - matches the request method)
+	if match.Method != "" {
+		responseMatchTarget.Method = getMethodMatch(match.Method)
+	}
 }
 
 // addStringGroup checks if a string group already exists in vsNode and adds it only if it doesn't exist
@@ -623,6 +667,9 @@ func (o *AviObjectGraph) BuildPGPoolForDedicatedMode(key string, vsNode *nodes.A
 		}
 
 		buildPoolWithBackendExtensionRefs(key, poolNode, routeModel.GetNamespace(), httpbackend)
+		buildPoolWithBackendTLSPolicy(key, poolNode, httpbackend)
+		buildPoolWithAppProtocol(key, poolNode, svcObj, backend.Port)
+		buildPoolWithTimeoutsAndRetry(key, poolNode, rule)
 		if vsNode.CheckPoolNChecksum(poolNode.Name, poolNode.GetCheckSum()) {
 			// Replace the poolNode.
 			vsNode.ReplaceEvhPoolInEVHNode(poolNode, key)
@@ -16,7 +16,9 @@ package nodes
 
 import (
 	"fmt"
+	"regexp"
 	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -25,11 +27,15 @@ import (
 
 	"github.com/vmware/alb-sdk/go/models"
 	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
 	akogatewayapiobjects "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/objects"
+	akogatewayapistatus "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/status"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
@@ -96,7 +102,7 @@ func (o *AviObjectGraph) BuildChildVS(key string, routeModel RouteModel, parentN
 
 	parentNode := o.GetAviEvhVS()
 	parentNs, _, parentName := lib.ExtractTypeNameNamespace(parentNsName)
-	routeTypeNsName := lib.HTTPRoute + "/" + routeModel.GetNamespace() + "/" + routeModel.GetName()
+	routeTypeNsName := routeModel.GetType() + "/" + routeModel.GetNamespace() + "/" + routeModel.GetName()
 
 	gwRouteNsName := fmt.Sprintf("%s/%s", parentNsName, routeTypeNsName)
 	found, hosts := akogatewayapiobjects.GatewayApiLister().GetGatewayRouteToHostname(gwRouteNsName)
@@ -155,6 +161,8 @@ func (o *AviObjectGraph) BuildChildVS(key string, routeModel RouteModel, parentN
 	o.BuildHTTPPolicySet(key, childNode, routeModel, rule, 0, childVSName)
 	// Apply Extension Ref
 	o.ApplyRuleExtensionRefs(key, childNode, routeModel, rule)
+	// create the traffic clone profile if the request mirror filter is present
+	o.BuildTrafficCloneProfile(key, parentNsName, childNode, routeModel, rule)
 	foundEvhModel := nodes.FindAndReplaceEvhInModel(childNode, parentNode, key)
 	if !foundEvhModel {
 		parentNode[0].EvhNodes = append(parentNode[0].EvhNodes, childNode)
@@ -164,23 +172,32 @@ func (o *AviObjectGraph) BuildChildVS(key string, routeModel RouteModel, parentN
 	utils.AviLog.Infof("key: %s, msg: processing of child vs %s attached to parent vs %s completed", key, childNode.Name, childNode.VHParentName)
 }
 
+// updateHostname sets the FQDNs of the parent VS VIP from the hostnames of the routes attached to the
+// gateway, or to all the Gateways sharing the parent VS when they are merged in a GatewayClass.
 func updateHostname(key, parentNsName string, parentNode *nodes.AviEvhVsNode) {
-	ok, routeNsNames := akogatewayapiobjects.GatewayApiLister().GetGatewayToRoute(parentNsName)
-	if !ok || len(routeNsNames) == 0 {
+	uniqueHostnamesSet := sets.NewString()
+	routesFound := false
+	for _, gwNsName := range akogatewayapiobjects.GatewayApiLister().GetMergedGateways(parentNsName) {
+		ok, routeNsNames := akogatewayapiobjects.GatewayApiLister().GetGatewayToRoute(gwNsName)
+		if !ok || len(routeNsNames) == 0 {
+			continue
+		}
+		routesFound = true
+		for _, routeNsName := range routeNsNames {
+			gwRouteNsName := fmt.Sprintf("%s/%s", gwNsName, routeNsName)
+			ok, hostnames := akogatewayapiobjects.GatewayApiLister().GetGatewayRouteToHostname(gwRouteNsName)
+			if !ok {
+				utils.AviLog.Warnf("key: %s, msg: Unable to fetch hostname from route: %s", key, routeNsName)
+			} else {
+				uniqueHostnamesSet.Insert(hostnames...)
+			}
+		}
+	}
+	if !routesFound {
 		utils.AviLog.Warnf("key: %s, msg: No routes from gateway, removing all FQDNs", key)
 		parentNode.VSVIPRefs[0].FQDNs = []string{}
 		return
 	}
-	uniqueHostnamesSet := sets.NewString()
-	for _, routeNsName := range routeNsNames {
-		gwRouteNsName := fmt.Sprintf("%s/%s", parentNsName, routeNsName)
-		ok, hostnames := akogatewayapiobjects.GatewayApiLister().GetGatewayRouteToHostname(gwRouteNsName)
-		if !ok {
-			utils.AviLog.Warnf("key: %s, msg: Unable to fetch hostname from route: %s", key, routeNsName)
-		} else {
-			uniqueHostnamesSet.Insert(hostnames...)
-		}
-	}
 
 	uniqueHostnames := slices.DeleteFunc(uniqueHostnamesSet.List(), func(s string) bool {
 		return strings.Contains(s, utils.WILDCARD)
@@ -190,6 +207,28 @@ func updateHostname(key, parentNsName string, parentNode *nodes.AviEvhVsNode) {
 	parentNode.VSVIPRefs[0].FQDNs = uniqueHostnames
 }
 
+// updateHTTP2Ports enables HTTP/2 on the parent VS ports of the listeners to which GRPCRoutes
+// are attached, as gRPC clients require HTTP/2 between the client and the virtual service.
+// HTTP/2 is disabled on the ports which no longer carry a GRPCRoute.
+func updateHTTP2Ports(key, parentNsName string, parentNode *nodes.AviEvhVsNode) {
+	http2Ports := sets.New[int32]()
+	for _, gwNsName := range akogatewayapiobjects.GatewayApiLister().GetMergedGateways(parentNsName) {
+		_, routeTypeNsNames := akogatewayapiobjects.GatewayApiLister().GetGatewayToRoute(gwNsName)
+		for _, routeTypeNsName := range routeTypeNsNames {
+			if routeType, _, _ := lib.ExtractTypeNameNamespace(routeTypeNsName); routeType != lib.GRPCRoute {
+				continue
+			}
+			for _, listener := range akogatewayapiobjects.GatewayApiLister().GetRouteToGatewayListener(routeTypeNsName, gwNsName) {
+				http2Ports.Insert(listener.Port)
+			}
+		}
+	}
+	for i := range parentNode.PortProto {
+		parentNode.PortProto[i].EnableHTTP2 = http2Ports.Has(parentNode.PortProto[i].Port)
+	}
+	utils.AviLog.Debugf("key: %s, msg: HTTP/2 enabled ports %v for gateway %s", key, sets.List(http2Ports), parentNsName)
+}
+
 // parseGatewayDurationToMinutes converts Gateway API Duration string to minutes (int32).
 // Returns nil if duration is nil.
 // Returns 0 (Avi's representation for infinite) if parsing fails
@@ -228,6 +267,47 @@ func parseGatewayDurationToMinutes(key string, gwDuration *gatewayv1.Duration) *
 	return &minutes
 }
 
+// parseHeaderPersistenceTimeout converts the absolute and idle timeouts of a header based session persistence
+// to the timeout of the Avi application cookie persistence profile, in minutes.
+// Returns nil if neither is set, in which case the Avi default is used.
+// Avi supports a single timeout, hence the smaller of the two is used when both are set.
+// Avi specific: Timeout for AppCookiePersistenceProfile: Allowed values are 1-720, there is no infinite timeout.
+func parseHeaderPersistenceTimeout(key string, absoluteTimeout, idleTimeout *gatewayv1.Duration) *int32 {
+	var timeout *int32
+	for _, gwDuration := range []*gatewayv1.Duration{absoluteTimeout, idleTimeout} {
+		minutes := parseGatewayDurationToMinutes(key, gwDuration)
+		if minutes == nil {
+			continue
+		}
+		if *minutes == 0 || *minutes > akogatewayapilib.AppCookiePersistenceTimeoutMax {
+			utils.AviLog.Warnf("key: %s, msg: duration %s is not supported for header based persistence, clamping to %d minutes.", key, *gwDuration, akogatewayapilib.AppCookiePersistenceTimeoutMax)
+			maxTimeout := int32(akogatewayapilib.AppCookiePersistenceTimeoutMax)
+			minutes = &maxTimeout
+		}
+		if timeout == nil || *minutes < *timeout {
+			timeout = minutes
+		}
+	}
+	return timeout
+}
+
+// parseGatewayDurationToMilliseconds converts Gateway API Duration string to milliseconds.
+// Gateway API durations have millisecond granularity, so the value is translated exactly.
+// Returns an error if the duration is negative or exceeds maxMilliseconds.
+func parseGatewayDurationToMilliseconds(gwDuration gatewayv1.Duration, maxMilliseconds uint32) (uint32, error) {
+	d, err := time.ParseDuration(string(gwDuration))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse duration %s: %v", gwDuration, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("negative duration %s is not supported", gwDuration)
+	}
+	if d.Milliseconds() > int64(maxMilliseconds) {
+		return 0, fmt.Errorf("duration %s exceeds the maximum of %dms", gwDuration, maxMilliseconds)
+	}
+	return uint32(d.Milliseconds()), nil
+}
+
 func (o *AviObjectGraph) BuildApplicationPersistenceProfile(key string, rule *Rule, routeModel RouteModel, parentNs, parentName string, markers utils.AviObjectMarkers) *nodes.AviApplicationPersistenceProfileNode {
 	sp := rule.SessionPersistence
 	persistProfileNode := &nodes.AviApplicationPersistenceProfileNode{
@@ -254,6 +334,14 @@ func (o *AviObjectGraph) BuildApplicationPersistenceProfile(key string, rule *Ru
 		}
 		persistProfileNode.HTTPCookiePersistenceProfile = httpCookiePersistenceProfileNode
 
+	case gatewayv1.HeaderBasedSessionPersistence:
+		// app cookie persistence learns the session from the header value set by the backend
+		persistProfileNode.PersistenceType = "PERSISTENCE_TYPE_APP_COOKIE"
+		persistProfileNode.AppCookiePersistenceProfile = &nodes.AppCookiePersistenceProfileNode{
+			HeaderName: *sp.SessionName,
+			Timeout:    parseHeaderPersistenceTimeout(key, sp.AbsoluteTimeout, sp.IdleTimeout),
+		}
+
 	default:
 		utils.AviLog.Errorf("key: %s, msg: unsupported session persistence type: %s in route %s/%s. No persistence profile will be applied.", key, persistenceType, routeModel.GetNamespace(), routeModel.GetName())
 		return nil
@@ -272,6 +360,107 @@ func (o *AviObjectGraph) BuildApplicationPersistenceProfile(key string, rule *Ru
 	return persistProfileNode
 }
 
+// BuildTrafficCloneProfile translates the RequestMirror filters of a rule to a TrafficCloneProfile on the child VS.
+// Avi clones the requests to the endpoints of the mirror backends, the responses from these are discarded.
+// The clone servers are IP addresses and the cloned requests keep the port of the pool servers, hence only the
+// mirror endpoints listening on the port of the rule backends are used.
+func (o *AviObjectGraph) BuildTrafficCloneProfile(key, parentNsName string, childVsNode *nodes.AviEvhVsNode, routeModel RouteModel, rule *Rule) {
+	childVsNode.TrafficCloneProfile = nil
+	var mirrorBackends []*Backend
+	for _, filter := range rule.Filters {
+		if filter.RequestMirror != nil {
+			mirrorBackends = append(mirrorBackends, filter.RequestMirror.Backend)
+		}
+	}
+	if len(mirrorBackends) == 0 {
+		return
+	}
+	if childVsNode.GetGeneratedFields().TrafficCloneProfileRef != nil {
+		utils.AviLog.Warnf("key: %s, msg: TrafficCloneProfile set via L7Rule takes precedence over the RequestMirror filter for child vs %s", key, childVsNode.Name)
+		return
+	}
+
+	serverPorts := sets.New[int32]()
+	for _, pool := range childVsNode.PoolRefs {
+		for _, server := range pool.Servers {
+			serverPorts.Insert(server.Port)
+		}
+	}
+	cloneServers := sets.New[string]()
+	for _, backend := range mirrorBackends {
+		svcObj, err := utils.GetInformers().ServiceInformer.Lister().Services(backend.Namespace).Get(backend.Name)
+		if err != nil {
+			utils.AviLog.Warnf("key: %s, msg: unable to retrieve the mirror service %s/%s, err: %v", key, backend.Namespace, backend.Name, err)
+			continue
+		}
+		// pool node is only used to compute the endpoints of the mirror service
+		poolNode := &nodes.AviPoolNode{
+			Name:       childVsNode.Name,
+			Tenant:     childVsNode.Tenant,
+			PortName:   akogatewayapilib.FindPortName(backend.Name, backend.Namespace, backend.Port, key),
+			TargetPort: akogatewayapilib.FindTargetPort(backend.Name, backend.Namespace, backend.Port, key),
+			Port:       backend.Port,
+		}
+		var servers []nodes.AviPoolMetaServer
+		serviceType := lib.GetServiceType()
+		if serviceType == lib.NodePortLocal {
+			servers = nodes.PopulateServersForNPL(poolNode, svcObj.ObjectMeta.Namespace, svcObj.ObjectMeta.Name, false, key)
+		} else if serviceType == lib.NodePort {
+			servers = nodes.PopulateServersForNodePort(poolNode, svcObj.ObjectMeta.Namespace, svcObj.ObjectMeta.Name, false, key)
+		} else {
+			servers = nodes.PopulateServers(poolNode, svcObj.ObjectMeta.Namespace, svcObj.ObjectMeta.Name, false, key)
+		}
+		for _, server := range servers {
+			// clone servers are IP addresses, servers of ExternalName services are not mirrored.
+			if server.Ip.Addr == nil || server.Hostname != "" {
+				continue
+			}
+			if !serverPorts.Has(server.Port) {
+				utils.AviLog.Warnf("key: %s, msg: mirror endpoint %s:%d of service %s/%s does not listen on the port of the rule backends %v, skipping it",
+					key, *server.Ip.Addr, server.Port, backend.Namespace, backend.Name, sets.List(serverPorts))
+				continue
+			}
+			cloneServers.Insert(*server.Ip.Addr)
+		}
+	}
+	if cloneServers.Len() == 0 {
+		utils.AviLog.Warnf("key: %s, msg: no endpoints found for the mirror backends of child vs %s", key, childVsNode.Name)
+		resolvedRefCondition := akogatewayapistatus.NewCondition().
+			Type(string(gatewayv1.RouteConditionResolvedRefs)).
+			Status(metav1.ConditionFalse).
+			Reason(string(gatewayv1.RouteReasonBackendNotFound)).
+			Message(fmt.Sprintf("RequestMirror backends have no endpoints on the target port of the rule backends %v", sets.List(serverPorts)))
+		setResolvedRefConditionInHTTPRouteStatus(key, resolvedRefCondition, routeModel.GetType()+"/"+routeModel.GetNamespace()+"/"+routeModel.GetName())
+		return
+	}
+
+	parentNs, _, parentName := lib.ExtractTypeNameNamespace(parentNsName)
+	var trafficCloneProfileName string
+	if rule.Name == "" {
+		trafficCloneProfileName = akogatewayapilib.GetTrafficCloneProfileName(parentNs, parentName,
+			routeModel.GetNamespace(), routeModel.GetName(), utils.Stringify(rule.Matches))
+	} else {
+		trafficCloneProfileName = akogatewayapilib.GetTrafficCloneProfileName(parentNs, parentName,
+			routeModel.GetNamespace(), routeModel.GetName(), rule.Name)
+	}
+	trafficCloneProfileNode := &nodes.AviTrafficCloneProfileNode{
+		Name:         trafficCloneProfileName,
+		Tenant:       childVsNode.Tenant,
+		CloneServers: sets.List(cloneServers),
+		AviMarkers: utils.AviObjectMarkers{
+			GatewayName:        parentName,
+			GatewayNamespace:   parentNs,
+			HTTPRouteName:      routeModel.GetName(),
+			HTTPRouteNamespace: routeModel.GetNamespace(),
+		},
+	}
+	if rule.Name != "" {
+		trafficCloneProfileNode.AviMarkers.HTTPRouteRuleName = rule.Name
+	}
+	childVsNode.TrafficCloneProfile = trafficCloneProfileNode
+	utils.AviLog.Infof("key: %s, msg: traffic clone profile %s with clone servers %v attached to child vs %s", key, trafficCloneProfileName, trafficCloneProfileNode.CloneServers, childVsNode.Name)
+}
+
 func (o *AviObjectGraph) ApplyRuleExtensionRefs(key string, childNode *nodes.AviEvhVsNode, routeModel RouteModel, rule *Rule) {
 
 	if rule != nil && rule.Filters != nil {
@@ -360,13 +549,115 @@ func buildPoolWithBackendExtensionRefs(key string, poolNode *nodes.AviPoolNode,
 	}
 }
 
+// buildPoolWithBackendTLSPolicy configures TLS towards the backend Service of the pool when the Service
+// is targeted by a BackendTLSPolicy. backendTLS configured in a RouteBackendExtension takes precedence.
+func buildPoolWithBackendTLSPolicy(key string, poolNode *nodes.AviPoolNode, backend *HTTPBackend) {
+	if !akogatewayapilib.IsBackendTLSPolicyEnabled() || backend == nil {
+		return
+	}
+	svcNsName := backend.Backend.Namespace + "/" + backend.Backend.Name
+	found, config := akogatewayapiobjects.GatewayApiLister().GetServiceToBackendTLSPolicy(svcNsName)
+	if !found {
+		return
+	}
+	if poolNode.SslProfileRef != nil {
+		utils.AviLog.Warnf("key: %s, msg: backendTLS of RouteBackendExtension takes precedence over BackendTLSPolicy %s for pool %s", key, config.PolicyNsName, poolNode.Name)
+	} else {
+		poolNode.SniEnabled = true
+		poolNode.SslProfileRef = proto.String(fmt.Sprintf("/api/sslprofile?name=%s", lib.DefaultPoolSSLProfile))
+		poolNode.ServerName = proto.String(config.Hostname)
+		poolNode.HostCheckEnabled = proto.Bool(true)
+		poolNode.DomainName = []string{config.Hostname}
+		if len(config.SubjectAltNames) > 0 {
+			poolNode.DomainName = config.SubjectAltNames
+		}
+		poolNode.PkiProfile = &nodes.AviPkiProfileNode{
+			Name:       lib.GetPoolPKIProfileName(poolNode.Name),
+			Tenant:     poolNode.Tenant,
+			CACert:     config.CACert,
+			AviMarkers: poolNode.AviMarkers,
+		}
+		utils.AviLog.Infof("key: %s, msg: applied BackendTLSPolicy %s to pool %s", key, config.PolicyNsName, poolNode.Name)
+	}
+	// the RouteBackendExtensions of the route may have changed, so the conflicts are re-evaluated
+	policyNs, policyName := splitNsName(config.PolicyNsName)
+	policy, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().BackendTLSPolicyInformer.Lister().BackendTLSPolicies(policyNs).Get(policyName)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to get the BackendTLSPolicy %s. err: %s", key, config.PolicyNsName, err)
+		return
+	}
+	updateBackendTLSPolicyStatus(key, policy, "")
+}
+
+// buildPoolWithAppProtocol enables HTTP/2 or SSL on the pool as per the appProtocol of the backend Service
+// port, unless already configured by the route, a RouteBackendExtension or a BackendTLSPolicy.
+func buildPoolWithAppProtocol(key string, poolNode *nodes.AviPoolNode, svcObj *corev1.Service, port int32) {
+	message := nodes.BuildPoolWithAppProtocol(key, poolNode, nodes.FindServicePort(svcObj, "", port, intstr.IntOrString{}))
+	nodes.RecordAppProtocolEvent(akogatewayapilib.AKOControlConfig().EventRecorder(), svcObj, poolNode.Name, message)
+}
+
+// buildPoolWithTimeoutsAndRetry sets the server timeout and the server reselect of the pool from the rule
+// timeouts and retry. Avi does not have a timeout for the whole transaction of a rule, so the request timeout
+// bounds the request to the backend when backendRequest timeout is not set.
+func buildPoolWithTimeoutsAndRetry(key string, poolNode *nodes.AviPoolNode, rule *Rule) {
+	if rule.Timeouts != nil {
+		backendTimeout := rule.Timeouts.BackendRequest
+		if backendTimeout == nil {
+			backendTimeout = rule.Timeouts.Request
+		}
+		if backendTimeout != nil {
+			serverTimeout, err := parseGatewayDurationToMilliseconds(*backendTimeout, akogatewayapilib.PoolServerTimeoutMax)
+			if err != nil {
+				utils.AviLog.Warnf("key: %s, msg: unable to set server timeout for pool %s. err: %v", key, poolNode.Name, err)
+			} else {
+				// zero duration disables the timeout, which is the longest timeout for Avi
+				if serverTimeout == 0 {
+					serverTimeout = akogatewayapilib.PoolServerTimeoutMax
+				}
+				poolNode.ServerTimeout = &serverTimeout
+			}
+		}
+	}
+
+	if rule.Retry == nil || (rule.Retry.Attempts != nil && *rule.Retry.Attempts == 0) {
+		return
+	}
+	serverReselect := &models.HttpserverReselect{
+		Enabled: proto.Bool(true),
+	}
+	if rule.Retry.Attempts != nil {
+		serverReselect.NumRetries = proto.Uint32(uint32(*rule.Retry.Attempts))
+	}
+	// the status codes every implementation must retry on, when the codes are not specified
+	codes := []int64{500, 502, 503, 504}
+	if len(rule.Retry.Codes) > 0 {
+		codes = make([]int64, 0, len(rule.Retry.Codes))
+		for _, code := range rule.Retry.Codes {
+			codes = append(codes, int64(code))
+		}
+	}
+	serverReselect.SvrRespCode = &models.HTTPReselectRespCode{Codes: codes}
+	if rule.Timeouts != nil && rule.Timeouts.BackendRequest != nil {
+		retryTimeout, err := parseGatewayDurationToMilliseconds(*rule.Timeouts.BackendRequest, akogatewayapilib.PoolServerReselectRetryTimeoutMax)
+		if err != nil {
+			utils.AviLog.Warnf("key: %s, msg: unable to set retry timeout for pool %s. err: %v", key, poolNode.Name, err)
+		} else {
+			if retryTimeout == 0 {
+				retryTimeout = akogatewayapilib.PoolServerReselectRetryTimeoutMax
+			}
+			serverReselect.RetryTimeout = &retryTimeout
+		}
+	}
+	poolNode.ServerReselect = serverReselect
+}
+
 func (o *AviObjectGraph) BuildPGPool(key, parentNsName string, childVsNode *nodes.AviEvhVsNode, routeModel RouteModel, rule *Rule) {
 	//reset pool, poolgroupreferences
 	childVsNode.PoolGroupRefs = nil
 	childVsNode.DefaultPoolGroup = ""
 	childVsNode.PoolRefs = nil
 	// create the PG from backends
-	routeTypeNsName := lib.HTTPRoute + "/" + routeModel.GetNamespace() + "/" + routeModel.GetName()
+	routeTypeNsName := routeModel.GetType() + "/" + routeModel.GetNamespace() + "/" + routeModel.GetName()
 	parentNs, _, parentName := lib.ExtractTypeNameNamespace(parentNsName)
 	listeners := akogatewayapiobjects.GatewayApiLister().GetRouteToGatewayListener(routeTypeNsName, parentNsName)
 	if len(listeners) == 0 {
@@ -406,6 +697,10 @@ func (o *AviObjectGraph) BuildPGPool(key, parentNsName string, childVsNode *node
 		}
 	}
 	for _, httpbackend := range rule.Backends {
+		if httpbackend.Backend.Kind == lib.ServiceImport {
+			o.BuildServiceImportPools(key, parentNsName, childVsNode, routeModel, rule, httpbackend, listenerProtocol, persistenceProfile, PG)
+			continue
+		}
 		var poolName string
 		if rule.Name == "" {
 			poolName = akogatewayapilib.GetPoolName(parentNs, parentName,
@@ -424,52 +719,12 @@ func (o *AviObjectGraph) BuildPGPool(key, parentNsName string, childVsNode *node
 			o.RemovePoolRefsFromPG(poolName, o.GetPoolGroupByName(PGName))
 			continue
 		}
-		poolNode := &nodes.AviPoolNode{
-			Name:       poolName,
-			Tenant:     childVsNode.Tenant,
-			Protocol:   listenerProtocol,
-			PortName:   akogatewayapilib.FindPortName(httpbackend.Backend.Name, httpbackend.Backend.Namespace, httpbackend.Backend.Port, key),
-			TargetPort: akogatewayapilib.FindTargetPort(httpbackend.Backend.Name, httpbackend.Backend.Namespace, httpbackend.Backend.Port, key),
-			Port:       httpbackend.Backend.Port,
-			ServiceMetadata: lib.ServiceMetadataObj{
-				NamespaceServiceName: []string{httpbackend.Backend.Namespace + "/" + httpbackend.Backend.Name},
-			},
-			VrfContext: lib.GetVrf(),
+		poolNode := buildRulePoolNode(key, parentNsName, childVsNode, routeModel, rule, httpbackend, poolName, listenerProtocol, persistenceProfile)
+		poolNode.PortName = akogatewayapilib.FindPortName(httpbackend.Backend.Name, httpbackend.Backend.Namespace, httpbackend.Backend.Port, key)
+		poolNode.TargetPort = akogatewayapilib.FindTargetPort(httpbackend.Backend.Name, httpbackend.Backend.Namespace, httpbackend.Backend.Port, key)
+		poolNode.ServiceMetadata = lib.ServiceMetadataObj{
+			NamespaceServiceName: []string{httpbackend.Backend.Namespace + "/" + httpbackend.Backend.Name},
 		}
-		poolNode.AviMarkers = utils.AviObjectMarkers{
-			GatewayName:        parentName,
-			GatewayNamespace:   parentNs,
-			HTTPRouteName:      routeModel.GetName(),
-			HTTPRouteNamespace: routeModel.GetNamespace(),
-			BackendNs:          httpbackend.Backend.Namespace,
-			BackendName:        httpbackend.Backend.Name,
-		}
-		if rule.SessionPersistence != nil {
-			poolNode.ApplicationPersistenceProfile = persistenceProfile
-		}
-		if rule.Name != "" {
-			poolNode.AviMarkers.HTTPRouteRuleName = rule.Name
-		}
-
-		if lib.IsIstioEnabled() {
-			poolNode.UpdatePoolNodeForIstio()
-		}
-
-		t1LR := lib.GetT1LRPath()
-		if found, infraSettingName := akogatewayapiobjects.GatewayApiLister().GetGatewayToAviInfraSetting(parentNsName); found {
-			if infraSetting, err := akogatewayapilib.AKOControlConfig().AviInfraSettingInformer().Lister().Get(infraSettingName); err != nil {
-				utils.AviLog.Warnf("key: %s, msg: failed to retrieve AviInfraSetting %s, err: %s", key, infraSettingName, err.Error())
-			} else if infraSetting != nil && infraSetting.Status.Status == lib.StatusAccepted && infraSetting.Spec.NSXSettings.T1LR != nil {
-				t1LR = *infraSetting.Spec.NSXSettings.T1LR
-			}
-		}
-
-		if t1LR != "" {
-			poolNode.T1Lr = t1LR
-			poolNode.VrfContext = ""
-			utils.AviLog.Infof("key: %s, msg: setting t1LR: %s for pool node.", key, t1LR)
-		}
-		poolNode.NetworkPlacementSettings = lib.GetNodeNetworkMap()
 		serviceType := lib.GetServiceType()
 		if serviceType == lib.NodePortLocal {
 			servers := nodes.PopulateServersForNPL(poolNode, svcObj.ObjectMeta.Namespace, svcObj.ObjectMeta.Name, false, key)
@@ -488,6 +743,9 @@ func (o *AviObjectGraph) BuildPGPool(key, parentNsName string, childVsNode *node
 			}
 		}
 		buildPoolWithBackendExtensionRefs(key, poolNode, routeModel.GetNamespace(), httpbackend)
+		buildPoolWithBackendTLSPolicy(key, poolNode, httpbackend)
+		buildPoolWithAppProtocol(key, poolNode, svcObj, httpbackend.Backend.Port)
+		buildPoolWithTimeoutsAndRetry(key, poolNode, rule)
 		if childVsNode.CheckPoolNChecksum(poolNode.Name, poolNode.GetCheckSum()) {
 			// Replace the poolNode.
 			childVsNode.ReplaceEvhPoolInEVHNode(poolNode, key)
@@ -503,10 +761,103 @@ func (o *AviObjectGraph) BuildPGPool(key, parentNsName string, childVsNode *node
 	}
 }
 
+// buildRulePoolNode returns a pool node for a backend of a route rule, with the settings common to the
+// pools of Services and ServiceImports.
+func buildRulePoolNode(key, parentNsName string, childVsNode *nodes.AviEvhVsNode, routeModel RouteModel, rule *Rule, httpbackend *HTTPBackend, poolName, listenerProtocol string, persistenceProfile *nodes.AviApplicationPersistenceProfileNode) *nodes.AviPoolNode {
+	parentNs, _, parentName := lib.ExtractTypeNameNamespace(parentNsName)
+	poolNode := &nodes.AviPoolNode{
+		Name:       poolName,
+		Tenant:     childVsNode.Tenant,
+		Protocol:   listenerProtocol,
+		Port:       httpbackend.Backend.Port,
+		VrfContext: lib.GetVrf(),
+	}
+	// gRPC requests are forwarded to the backends over HTTP/2
+	if routeModel.GetType() == lib.GRPCRoute {
+		poolNode.EnableHttp2 = proto.Bool(true)
+	}
+	poolNode.AviMarkers = utils.AviObjectMarkers{
+		GatewayName:        parentName,
+		GatewayNamespace:   parentNs,
+		HTTPRouteName:      routeModel.GetName(),
+		HTTPRouteNamespace: routeModel.GetNamespace(),
+		BackendNs:          httpbackend.Backend.Namespace,
+		BackendName:        httpbackend.Backend.Name,
+	}
+	if rule.SessionPersistence != nil {
+		poolNode.ApplicationPersistenceProfile = persistenceProfile
+	}
+	if rule.Name != "" {
+		poolNode.AviMarkers.HTTPRouteRuleName = rule.Name
+	}
+
+	if lib.IsIstioEnabled() {
+		poolNode.UpdatePoolNodeForIstio()
+	}
+
+	t1LR := lib.GetT1LRPath()
+	if found, infraSettingName := akogatewayapiobjects.GatewayApiLister().GetGatewayToAviInfraSetting(parentNsName); found {
+		if infraSetting, err := akogatewayapilib.AKOControlConfig().AviInfraSettingInformer().Lister().Get(infraSettingName); err != nil {
+			utils.AviLog.Warnf("key: %s, msg: failed to retrieve AviInfraSetting %s, err: %s", key, infraSettingName, err.Error())
+		} else if infraSetting != nil && infraSetting.Status.Status == lib.StatusAccepted && infraSetting.Spec.NSXSettings.T1LR != nil {
+			t1LR = *infraSetting.Spec.NSXSettings.T1LR
+		}
+	}
+
+	if t1LR != "" {
+		poolNode.T1Lr = t1LR
+		poolNode.VrfContext = ""
+		utils.AviLog.Infof("key: %s, msg: setting t1LR: %s for pool node.", key, t1LR)
+	}
+	poolNode.NetworkPlacementSettings = lib.GetNodeNetworkMap()
+	return poolNode
+}
+
+// BuildServiceImportPools adds a pool for each cluster exporting a ServiceImport backend to the pool group of
+// the rule. The ratio of a pool is the weight of the backend multiplied by the weight of its cluster set in
+// the cluster weights annotation of the route, 1 by default. Clusters with weight 0 receive no traffic.
+func (o *AviObjectGraph) BuildServiceImportPools(key, parentNsName string, childVsNode *nodes.AviEvhVsNode, routeModel RouteModel, rule *Rule, httpbackend *HTTPBackend, listenerProtocol string, persistenceProfile *nodes.AviApplicationPersistenceProfileNode, PG *nodes.AviPoolGroupNode) {
+	parentNs, _, parentName := lib.ExtractTypeNameNamespace(parentNsName)
+	clusterWeights := routeModel.ParseRouteConfig(key).ClusterWeights
+	for _, siCluster := range getServiceImportClusters(key, httpbackend.Backend) {
+		clusterWeight := int32(1)
+		if weight, ok := clusterWeights[siCluster.Cluster]; ok {
+			clusterWeight = weight
+		}
+		if clusterWeight == 0 {
+			utils.AviLog.Infof("key: %s, msg: skipping cluster %s of ServiceImport %s/%s with weight 0", key, siCluster.Cluster, httpbackend.Backend.Namespace, httpbackend.Backend.Name)
+			continue
+		}
+		matchName := rule.Name
+		if matchName == "" {
+			matchName = utils.Stringify(rule.Matches)
+		}
+		poolName := akogatewayapilib.GetPoolName(parentNs, parentName,
+			routeModel.GetNamespace(), routeModel.GetName(),
+			matchName,
+			httpbackend.Backend.Namespace, httpbackend.Backend.Name+"--"+siCluster.Cluster, strconv.Itoa(int(httpbackend.Backend.Port)))
+		poolNode := buildRulePoolNode(key, parentNsName, childVsNode, routeModel, rule, httpbackend, poolName, listenerProtocol, persistenceProfile)
+		poolNode.Port = siCluster.Port
+		poolNode.Servers = siCluster.Servers
+		buildPoolWithBackendExtensionRefs(key, poolNode, routeModel.GetNamespace(), httpbackend)
+		buildPoolWithTimeoutsAndRetry(key, poolNode, rule)
+		if childVsNode.CheckPoolNChecksum(poolNode.Name, poolNode.GetCheckSum()) {
+			childVsNode.ReplaceEvhPoolInEVHNode(poolNode, key)
+		}
+		utils.AviLog.Infof("key: %s, msg: servers of cluster %s for ServiceImport %s/%s are: %s", key, siCluster.Cluster, httpbackend.Backend.Namespace, httpbackend.Backend.Name, utils.Stringify(poolNode.Servers))
+
+		poolRef := fmt.Sprintf("/api/pool?name=%s", poolNode.Name)
+		ratio := uint32(httpbackend.Backend.Weight * clusterWeight)
+		PG.Members = append(PG.Members, &models.PoolGroupMember{PoolRef: &poolRef, Ratio: &ratio})
+	}
+}
+
 func (o *AviObjectGraph) BuildVHMatch(key string, parentNsName string, routeTypeNsName string, vsNode *nodes.AviEvhVsNode, rule *Rule, hosts []string) {
 	var vhMatches []*models.VHMatch
 
 	listeners := akogatewayapiobjects.GatewayApiLister().GetRouteToGatewayListener(routeTypeNsName, parentNsName)
+	// string groups of the child vs are only referred by the query matches
+	vsNode.StringGroupRefs = nil
 
 	for _, host := range hosts {
 		hostname := host
@@ -549,6 +900,24 @@ func (o *AviObjectGraph) BuildVHMatch(key string, parentNsName string, routeType
 				rule.Matches.Hdrs = append(rule.Matches.Hdrs, hdrMatch)
 			}
 
+			// query param match, only a single query param per match is allowed by validation.
+			// The regex is attached via string group, as done for the dedicated mode.
+			if len(match.QueryParamMatch) > 0 {
+				queryRegex := getQueryParamRegex(match.QueryParamMatch[0])
+				regexStringGroupName := lib.GetEncodedStringGroupName("", queryRegex)
+				rule.Matches.Query = &models.QueryMatch{
+					MatchCase:       proto.String("SENSITIVE"),
+					MatchCriteria:   proto.String("QUERY_MATCH_REGEX_MATCH"),
+					StringGroupRefs: []string{"/api/stringgroup?name=" + regexStringGroupName},
+				}
+				o.addStringGroup(regexStringGroupName, queryRegex, vsNode.Tenant, vsNode)
+			}
+
+			// method match
+			if match.Method != "" {
+				rule.Matches.Method = getMethodMatch(match.Method)
+			}
+
 			//port match from listener
 			matchCriteria := "IS_IN"
 			rule.Matches.VsPort = &models.PortMatch{
@@ -569,6 +938,23 @@ func (o *AviObjectGraph) BuildVHMatch(key string, parentNsName string, routeType
 	utils.AviLog.Infof("key: %s, msg: Attached match criteria to vs %s", key, vsNode.Name)
 }
 
+// getQueryParamRegex returns the regular expression to match a query param, as Avi matches
+// against the complete query string of the request instead of the individual query params.
+func getQueryParamRegex(queryParam *QueryParamMatch) string {
+	value := regexp.QuoteMeta(queryParam.Value)
+	if queryParam.Type == akogatewayapilib.REGULAREXPRESSION {
+		value = "(" + queryParam.Value + ")"
+	}
+	return "(^|&)" + regexp.QuoteMeta(queryParam.Name) + "=" + value + "(&|$)"
+}
+
+func getMethodMatch(method string) *models.MethodMatch {
+	return &models.MethodMatch{
+		MatchCriteria: proto.String("IS_IN"),
+		Methods:       []string{"HTTP_METHOD_" + method},
+	}
+}
+
 func (o *AviObjectGraph) BuildHTTPPolicySet(key string, vsNode *nodes.AviEvhVsNode, routeModel RouteModel, rule *Rule, index int, httpPSName string) {
 
 	if len(rule.Filters) == 0 {
@@ -579,7 +965,7 @@ func (o *AviObjectGraph) BuildHTTPPolicySet(key string, vsNode *nodes.AviEvhVsNo
 	var otherFiltersPresent bool
 
 	for _, filter := range rule.Filters {
-		if filter.RedirectFilter != nil || filter.UrlRewriteFilter != nil || filter.ResponseFilter != nil || filter.RequestFilter != nil {
+		if filter.RedirectFilter != nil || filter.UrlRewriteFilter != nil || filter.ResponseFilter != nil || filter.RequestFilter != nil || filter.CORSFilter != nil {
 			otherFiltersPresent = true
 			break
 		}
@@ -603,19 +989,215 @@ func (o *AviObjectGraph) BuildHTTPPolicySet(key string, vsNode *nodes.AviEvhVsNo
 		vsNode.HttpPolicyRefs = append(vsNode.HttpPolicyRefs, policy)
 		index = len(vsNode.HttpPolicyRefs) - 1
 	}
-	isRedirectPresent := o.BuildHTTPPolicySetHTTPRequestRedirectRules(key, httpPSName, vsNode, routeModel, rule.Filters, index)
+	// the path prefix of the first match is replaced by the request rules, which are built for each of the
+	// matches by addPrefixRequestRules
+	var match *Match
+	if len(rule.Matches) > 0 {
+		match = rule.Matches[0]
+	}
+	isRedirectPresent := o.BuildHTTPPolicySetHTTPRequestRedirectRules(key, httpPSName, vsNode, routeModel, rule.Filters, match, index)
 	if isRedirectPresent {
 		// When the RedirectAction is specified the Request and Response Modify Header Action
 		// won't have any effect, hence returning.
+		addPrefixRequestRules(policy, rule.Filters, rule.Matches, index)
 		utils.AviLog.Infof("key: %s, msg: Attached HTTP redirect policy to vs %s", key, vsNode.Name)
 		return
 	}
 	o.BuildHTTPPolicySetHTTPRequestRules(key, httpPSName, vsNode, routeModel, rule.Filters, index)
-	o.BuildHTTPPolicySetHTTPRequestUrlRewriteRules(key, httpPSName, vsNode, routeModel, rule.Filters, index)
+	o.BuildHTTPPolicySetHTTPRequestUrlRewriteRules(key, httpPSName, vsNode, routeModel, rule.Filters, match, index)
 	o.BuildHTTPPolicySetHTTPResponseRules(key, vsNode, routeModel, rule.Filters, index)
+	o.BuildHTTPPolicySetCORSRules(key, httpPSName, vsNode, rule.Filters, index)
+	addPrefixRequestRules(policy, rule.Filters, rule.Matches, index)
 	utils.AviLog.Infof("key: %s, msg: Attached HTTP policies to vs %s", key, vsNode.Name)
 }
 
+// addPrefixRequestRules adds the request rules replacing the path prefix of the matches of the rule. The prefix is
+// replaced on the segments of the matched prefix, hence a rule with more than one match gets a request rule for
+// each of the matches, matching the requests on the path prefix of the match. The rules of the longer prefixes
+// come first, so that the requests are rewritten as per the longest prefix they match.
+func addPrefixRequestRules(policy *nodes.AviHttpPolicySetNode, filters []*Filter, matches []*Match, index int) {
+	if len(matches) <= 1 {
+		var match *Match
+		if len(matches) == 1 {
+			match = matches[0]
+		}
+		addExactPrefixRequestRule(policy, filters, match, index)
+		return
+	}
+	for i, requestRule := range policy.RequestRules {
+		pathModifier := getPrefixPathModifier(requestRule, filters)
+		if pathModifier == nil {
+			continue
+		}
+		sortedMatches := make([]*Match, len(matches))
+		copy(sortedMatches, matches)
+		sort.SliceStable(sortedMatches, func(i, j int) bool {
+			return getPathPrefixSegments(sortedMatches[i]) > getPathPrefixSegments(sortedMatches[j])
+		})
+		var prefixRules []*models.HTTPRequestRule
+		for j, match := range sortedMatches {
+			prefixRules = append(prefixRules, buildMatchPrefixRequestRules(requestRule, filters, pathModifier, match, j)...)
+		}
+		policy.RequestRules = append(policy.RequestRules[:i], append(prefixRules, policy.RequestRules[i+1:]...)...)
+		break
+	}
+	for i, requestRule := range policy.RequestRules {
+		requestRule.Index = proto.Int32(int32(index + i + 1))
+	}
+}
+
+// buildMatchPrefixRequestRules returns the copies of the request rule for the requests on the exact path prefix
+// of the match, and on the paths below the prefix, with the path of the action built from the prefix of the match.
+func buildMatchPrefixRequestRules(requestRule *models.HTTPRequestRule, filters []*Filter, pathModifier *gatewayv1.HTTPPathModifier, match *Match, matchIndex int) []*models.HTTPRequestRule {
+	prefix := "/"
+	if match != nil && match.PathMatch != nil {
+		prefix += strings.Trim(match.PathMatch.Path, "/")
+	}
+	prefixRule := *requestRule
+	prefixRule.Name = proto.String(fmt.Sprintf("%s-%d", *requestRule.Name, matchIndex))
+	prefixRule.Match = &models.MatchTarget{}
+	if requestRule.Match != nil {
+		matchTarget := *requestRule.Match
+		prefixRule.Match = &matchTarget
+	}
+	matchStr := prefix
+	if prefix != "/" {
+		matchStr += "/"
+	}
+	prefixRule.Match.Path = &models.PathMatch{
+		MatchCase:     proto.String("SENSITIVE"),
+		MatchCriteria: proto.String("BEGINS_WITH"),
+		MatchStr:      []string{matchStr},
+	}
+	if requestRule.RedirectAction != nil {
+		redirectAction := *requestRule.RedirectAction
+		redirectAction.Path = buildPathURIParam(pathModifier, match)
+		prefixRule.RedirectAction = &redirectAction
+	} else {
+		rewriteAction := *requestRule.RewriteURLAction
+		rewriteAction.Path = buildPathURIParam(pathModifier, match)
+		prefixRule.RewriteURLAction = &rewriteAction
+	}
+	if prefix == "/" {
+		return []*models.HTTPRequestRule{&prefixRule}
+	}
+
+	exactPrefixRule := buildExactPrefixRequestRule(&prefixRule, filters, match)
+	if exactPrefixRule == nil {
+		// the prefix is replaced with /, which is the same for the requests on the exact prefix
+		rule := prefixRule
+		rule.Name = proto.String(*prefixRule.Name + "-exact-prefix")
+		matchTarget := *prefixRule.Match
+		matchTarget.Path = &models.PathMatch{
+			MatchCase:     proto.String("SENSITIVE"),
+			MatchCriteria: proto.String("EQUALS"),
+			MatchStr:      []string{prefix},
+		}
+		rule.Match = &matchTarget
+		exactPrefixRule = &rule
+	}
+	return []*models.HTTPRequestRule{exactPrefixRule, &prefixRule}
+}
+
+// addExactPrefixRequestRule inserts the rule for the requests on the exact path prefix ahead of the request rule
+// replacing the path prefix, and renumbers the request rules of the HTTPPolicySet.
+func addExactPrefixRequestRule(policy *nodes.AviHttpPolicySetNode, filters []*Filter, match *Match, index int) {
+	for i, requestRule := range policy.RequestRules {
+		if exactPrefixRule := buildExactPrefixRequestRule(requestRule, filters, match); exactPrefixRule != nil {
+			policy.RequestRules = append(policy.RequestRules[:i], append([]*models.HTTPRequestRule{exactPrefixRule}, policy.RequestRules[i:]...)...)
+			break
+		}
+	}
+	for i, requestRule := range policy.RequestRules {
+		requestRule.Index = proto.Int32(int32(index + i + 1))
+	}
+}
+
+// buildExactPrefixRequestRule returns a copy of the request rule for the requests on the exact path prefix of the
+// match. ReplacePrefixMatch appends the remaining path segments after the separator, hence such requests are
+// translated separately to avoid the trailing separator, e.g. /foo replaced with /bar results in /bar and not /bar/.
+func buildExactPrefixRequestRule(requestRule *models.HTTPRequestRule, filters []*Filter, match *Match) *models.HTTPRequestRule {
+	pathModifier := getPrefixPathModifier(requestRule, filters)
+	if pathModifier == nil {
+		return nil
+	}
+	replacement := strings.Trim(*pathModifier.ReplacePrefixMatch, "/")
+	if replacement == "" {
+		return nil
+	}
+	prefix := "/"
+	if match != nil && match.PathMatch != nil {
+		prefix += strings.Trim(match.PathMatch.Path, "/")
+	}
+	path := &models.URIParam{
+		Tokens: []*models.URIParamToken{{
+			StrValue: proto.String(replacement),
+			Type:     proto.String("URI_TOKEN_TYPE_STRING"),
+		}},
+		Type: proto.String("URI_PARAM_TYPE_TOKENIZED"),
+	}
+
+	exactPrefixRule := *requestRule
+	exactPrefixRule.Name = proto.String(*requestRule.Name + "-exact-prefix")
+	exactPrefixRule.Match = &models.MatchTarget{}
+	if requestRule.Match != nil {
+		matchTarget := *requestRule.Match
+		exactPrefixRule.Match = &matchTarget
+	}
+	exactPrefixRule.Match.Path = &models.PathMatch{
+		MatchCase:     proto.String("SENSITIVE"),
+		MatchCriteria: proto.String("EQUALS"),
+		MatchStr:      []string{prefix},
+	}
+	if requestRule.RedirectAction != nil {
+		redirectAction := *requestRule.RedirectAction
+		redirectAction.Path = path
+		exactPrefixRule.RedirectAction = &redirectAction
+	} else {
+		rewriteAction := *requestRule.RewriteURLAction
+		rewriteAction.Path = path
+		exactPrefixRule.RewriteURLAction = &rewriteAction
+	}
+	return &exactPrefixRule
+}
+
+// getPrefixPathModifier returns the path modifier of the filter translated to the action of the request rule, when
+// it replaces the path prefix.
+func getPrefixPathModifier(requestRule *models.HTTPRequestRule, filters []*Filter) *gatewayv1.HTTPPathModifier {
+	var pathModifier *gatewayv1.HTTPPathModifier
+	for _, filter := range filters {
+		if requestRule.RedirectAction != nil && filter.RedirectFilter != nil {
+			pathModifier = filter.RedirectFilter.Path
+			break
+		}
+		if requestRule.RedirectAction == nil && requestRule.RewriteURLAction != nil && filter.UrlRewriteFilter != nil {
+			pathModifier = filter.UrlRewriteFilter.path
+			break
+		}
+	}
+	if pathModifier == nil || pathModifier.Type != gatewayv1.PrefixMatchHTTPPathModifier || pathModifier.ReplacePrefixMatch == nil {
+		return nil
+	}
+	return pathModifier
+}
+
+// BuildHTTPPolicySetCORSRules appends the preflight and response header rules of the CORS filter after the
+// other rules of the HTTPPolicySet, hence the preflight requests are answered after the request is modified.
+func (o *AviObjectGraph) BuildHTTPPolicySetCORSRules(key, httpPSName string, vsNode *nodes.AviEvhVsNode, filters []*Filter, index int) {
+	for _, filter := range filters {
+		// considering only the first CORSFilter
+		if filter.CORSFilter != nil {
+			policy := vsNode.HttpPolicyRefs[index]
+			requestRules, responseRules := nodes.BuildCORSHTTPPolicyRules(httpPSName+"-cors", filter.CORSFilter, nil, nil,
+				int32(index+len(policy.RequestRules)+1), int32(index+len(policy.ResponseRules)+1))
+			policy.RequestRules = append(policy.RequestRules, requestRules...)
+			policy.ResponseRules = append(policy.ResponseRules, responseRules...)
+			utils.AviLog.Debugf("key: %s, msg: Attached HTTP CORS policies %s to vs %s", key, utils.Stringify(policy), vsNode.Name)
+			break
+		}
+	}
+}
+
 func (o *AviObjectGraph) BuildHTTPPolicySetHTTPRequestRules(key, httpPSName string, vsNode *nodes.AviEvhVsNode, routeModel RouteModel, filters []*Filter, index int) {
 	requestRule := &models.HTTPRequestRule{Name: &httpPSName, Enable: proto.Bool(true), Index: proto.Int32(int32(index + 1))}
 	vsNode.HttpPolicyRefs[index].RequestRules = []*models.HTTPRequestRule{}
@@ -649,6 +1231,7 @@ func (o *AviObjectGraph) BuildHTTPPolicySetHTTPRequestRules(key, httpPSName stri
 
 func (o *AviObjectGraph) BuildHTTPPolicySetHTTPResponseRules(key string, vsNode *nodes.AviEvhVsNode, routeModel RouteModel, filters []*Filter, index int) {
 	responseRule := &models.HTTPResponseRule{Name: &vsNode.Name, Enable: proto.Bool(true), Index: proto.Int32(int32(index + 1))}
+	vsNode.HttpPolicyRefs[index].ResponseRules = nil
 	for _, filter := range filters {
 		if filter.ResponseFilter != nil {
 			var j uint32 = 0
@@ -691,27 +1274,12 @@ func (o *AviObjectGraph) BuildHTTPPolicySetHTTPRuleHdrAction(key string, action
 	return hdrAction
 }
 
-func (o *AviObjectGraph) BuildHTTPPolicySetHTTPRequestRedirectRules(key, httpPSname string, vsNode *nodes.AviEvhVsNode, routeModel RouteModel, filters []*Filter, index int) bool {
-	redirectAction := &models.HTTPRedirectAction{}
+func (o *AviObjectGraph) BuildHTTPPolicySetHTTPRequestRedirectRules(key, httpPSname string, vsNode *nodes.AviEvhVsNode, routeModel RouteModel, filters []*Filter, match *Match, index int) bool {
 	isRedirectPresent := false
 	for _, filter := range filters {
 		// considering only the first RedirectFilter
 		if filter.RedirectFilter != nil {
-			uriParamToken := &models.URIParamToken{
-				StrValue: &filter.RedirectFilter.Host,
-				Type:     proto.String("URI_TOKEN_TYPE_STRING"),
-			}
-			redirectAction.Host = &models.URIParam{
-				Tokens: []*models.URIParamToken{uriParamToken},
-				Type:   proto.String("URI_PARAM_TYPE_TOKENIZED"),
-			}
-			redirectAction.Protocol = proto.String("HTTP")
-			statusCode := "HTTP_REDIRECT_STATUS_CODE_302"
-			switch filter.RedirectFilter.StatusCode {
-			case 301, 302, 307:
-				statusCode = fmt.Sprintf("HTTP_REDIRECT_STATUS_CODE_%d", filter.RedirectFilter.StatusCode)
-			}
-			redirectAction.StatusCode = &statusCode
+			redirectAction := buildRedirectAction(filter.RedirectFilter, match)
 			requestRule := &models.HTTPRequestRule{Name: &httpPSname, Enable: proto.Bool(true), RedirectAction: redirectAction, Index: proto.Int32(int32(index + 1))}
 			vsNode.HttpPolicyRefs[index].RequestRules = []*models.HTTPRequestRule{requestRule}
 			isRedirectPresent = true
@@ -722,7 +1290,95 @@ func (o *AviObjectGraph) BuildHTTPPolicySetHTTPRequestRedirectRules(key, httpPSn
 	return isRedirectPresent
 }
 
-func (o *AviObjectGraph) BuildHTTPPolicySetHTTPRequestUrlRewriteRules(key, httpPSname string, vsNode *nodes.AviEvhVsNode, routeModel RouteModel, filters []*Filter, index int) {
+// buildRedirectAction translates the RequestRedirect filter to the Avi redirect action.
+// The host of the request is retained when the hostname is not specified, and the port
+// defaults to the well-known port of the scheme when only the scheme is specified.
+func buildRedirectAction(redirectFilter *RedirectFilter, match *Match) *models.HTTPRedirectAction {
+	redirectAction := &models.HTTPRedirectAction{}
+	if redirectFilter.Host != "" {
+		redirectAction.Host = &models.URIParam{
+			Tokens: []*models.URIParamToken{{
+				StrValue: proto.String(redirectFilter.Host),
+				Type:     proto.String("URI_TOKEN_TYPE_STRING"),
+			}},
+			Type: proto.String("URI_PARAM_TYPE_TOKENIZED"),
+		}
+	}
+	redirectAction.Protocol = proto.String("HTTP")
+	if redirectFilter.Scheme != "" {
+		redirectAction.Protocol = proto.String(strings.ToUpper(redirectFilter.Scheme))
+	}
+	if redirectFilter.Port != 0 {
+		redirectAction.Port = proto.Uint32(uint32(redirectFilter.Port))
+	} else if redirectFilter.Scheme == "https" {
+		redirectAction.Port = proto.Uint32(443)
+	} else if redirectFilter.Scheme == "http" {
+		redirectAction.Port = proto.Uint32(80)
+	}
+	if redirectFilter.Path != nil {
+		redirectAction.Path = buildPathURIParam(redirectFilter.Path, match)
+	}
+	statusCode := "HTTP_REDIRECT_STATUS_CODE_302"
+	switch redirectFilter.StatusCode {
+	case 301, 302, 307:
+		statusCode = fmt.Sprintf("HTTP_REDIRECT_STATUS_CODE_%d", redirectFilter.StatusCode)
+	}
+	redirectAction.StatusCode = &statusCode
+	return redirectAction
+}
+
+// buildPathURIParam translates the path modifier of the redirect and rewrite filters to the path of the
+// Avi action, which does not contain the leading "/". ReplacePrefixMatch is translated to the replacement
+// followed by the path segments of the request after the matched prefix, the requests on the exact path prefix
+// are handled by buildExactPrefixRequestRule.
+func buildPathURIParam(pathModifier *gatewayv1.HTTPPathModifier, match *Match) *models.URIParam {
+	var tokens []*models.URIParamToken
+	switch pathModifier.Type {
+	case gatewayv1.FullPathHTTPPathModifier:
+		if pathModifier.ReplaceFullPath == nil {
+			return nil
+		}
+		tokens = append(tokens, &models.URIParamToken{
+			StrValue: proto.String(strings.TrimPrefix(*pathModifier.ReplaceFullPath, "/")),
+			Type:     proto.String("URI_TOKEN_TYPE_STRING"),
+		})
+	case gatewayv1.PrefixMatchHTTPPathModifier:
+		if pathModifier.ReplacePrefixMatch == nil {
+			return nil
+		}
+		if replacement := strings.Trim(*pathModifier.ReplacePrefixMatch, "/"); replacement != "" {
+			tokens = append(tokens, &models.URIParamToken{
+				StrValue: proto.String(replacement + "/"),
+				Type:     proto.String("URI_TOKEN_TYPE_STRING"),
+			})
+		}
+		tokens = append(tokens, &models.URIParamToken{
+			StartIndex: proto.Uint32(getPathPrefixSegments(match)),
+			EndIndex:   proto.Uint32(akogatewayapilib.URITokenEndOfString),
+			Type:       proto.String("URI_TOKEN_TYPE_PATH"),
+		})
+	default:
+		return nil
+	}
+	return &models.URIParam{
+		Tokens: tokens,
+		Type:   proto.String("URI_PARAM_TYPE_TOKENIZED"),
+	}
+}
+
+// getPathPrefixSegments returns the number of path segments in the path prefix of the match.
+func getPathPrefixSegments(match *Match) uint32 {
+	if match == nil || match.PathMatch == nil {
+		return 0
+	}
+	prefix := strings.Trim(match.PathMatch.Path, "/")
+	if prefix == "" {
+		return 0
+	}
+	return uint32(len(strings.Split(prefix, "/")))
+}
+
+func (o *AviObjectGraph) BuildHTTPPolicySetHTTPRequestUrlRewriteRules(key, httpPSname string, vsNode *nodes.AviEvhVsNode, routeModel RouteModel, filters []*Filter, match *Match, index int) {
 	urlRewriteAction := &models.HTTPRewriteURLAction{}
 	for _, filter := range filters {
 		// considering only the first reWriteFilter
@@ -739,13 +1395,7 @@ func (o *AviObjectGraph) BuildHTTPPolicySetHTTPRequestUrlRewriteRules(key, httpP
 				}
 			}
 			if filter.UrlRewriteFilter.path != nil {
-				urlRewriteAction.Path = &models.URIParam{
-					Tokens: []*models.URIParamToken{{
-						StrValue: filter.UrlRewriteFilter.path.ReplaceFullPath,
-						Type:     proto.String("URI_TOKEN_TYPE_STRING"),
-					}},
-					Type: proto.String("URI_PARAM_TYPE_TOKENIZED"),
-				}
+				urlRewriteAction.Path = buildPathURIParam(filter.UrlRewriteFilter.path, match)
 			}
 			urlRewriteAction.Query = &models.URIParamQuery{
 				AddString: nil,
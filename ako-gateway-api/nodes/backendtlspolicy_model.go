@@ -0,0 +1,342 @@
+/*
+ * Copyright © 2025 Broadcom Inc. and/or its subsidiaries. All Rights Reserved.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package nodes
+
+import (
+	"fmt"
+	"strings"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1alpha3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	akogatewayapiobjects "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/objects"
+	akogatewayapistatus "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/status"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/status"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+const backendTLSPolicyCACertKey = "ca.crt"
+
+// getBackendTLSPolicyServices returns the Services the BackendTLSPolicy is currently applied to
+// along with the Services targeted by its spec, so that both old and new targets are processed.
+func getBackendTLSPolicyServices(namespace, name, key string) []string {
+	policyNsName := namespace + "/" + name
+	svcNsNames := sets.NewString(akogatewayapiobjects.GatewayApiLister().GetBackendTLSPolicyToService(policyNsName)...)
+	policy, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().BackendTLSPolicyInformer.Lister().BackendTLSPolicies(namespace).Get(name)
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			utils.AviLog.Warnf("key: %s, msg: unable to get the BackendTLSPolicy object. err: %s", key, err)
+		}
+		return svcNsNames.List()
+	}
+	for _, targetRef := range policy.Spec.TargetRefs {
+		if isBackendTLSPolicyTargetService(targetRef) {
+			svcNsNames.Insert(namespace + "/" + string(targetRef.Name))
+		}
+	}
+	return svcNsNames.List()
+}
+
+func isBackendTLSPolicyTargetService(targetRef gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName) bool {
+	return targetRef.Group == "" && string(targetRef.Kind) == utils.Service
+}
+
+// processBackendTLSPolicy validates the BackendTLSPolicy, updates the Services to which it is applied
+// and updates its status.
+func processBackendTLSPolicy(namespace, name, key string) {
+	policyNsName := namespace + "/" + name
+	policy, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().BackendTLSPolicyInformer.Lister().BackendTLSPolicies(namespace).Get(name)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			utils.AviLog.Debugf("key: %s, msg: BackendTLSPolicy %s deleted", key, policyNsName)
+			akogatewayapiobjects.GatewayApiLister().DeleteBackendTLSPolicy(policyNsName)
+			akogatewayapiobjects.GatewayApiLister().UpdateBackendTLSPolicyToConfigMap(policyNsName, nil)
+		} else {
+			utils.AviLog.Warnf("key: %s, msg: unable to get the BackendTLSPolicy object. err: %s", key, err)
+		}
+		return
+	}
+
+	// the ConfigMaps are watched, so that the policy is re-validated when its CA certificates change
+	var configMapNsNames []string
+	for _, caCertRef := range policy.Spec.Validation.CACertificateRefs {
+		if caCertRef.Group == "" && string(caCertRef.Kind) == akogatewayapilib.ConfigMapKind {
+			configMapNsNames = append(configMapNsNames, namespace+"/"+string(caCertRef.Name))
+		}
+	}
+	akogatewayapiobjects.GatewayApiLister().UpdateBackendTLSPolicyToConfigMap(policyNsName, configMapNsNames)
+
+	configs, invalidMessage := validateBackendTLSPolicy(key, policy)
+	if invalidMessage != "" {
+		utils.AviLog.Warnf("key: %s, msg: BackendTLSPolicy %s is invalid: %s", key, policyNsName, invalidMessage)
+		akogatewayapiobjects.GatewayApiLister().DeleteBackendTLSPolicy(policyNsName)
+	} else {
+		// the oldest BackendTLSPolicy targeting a Service takes precedence
+		var displacedPolicies []*gatewayv1alpha3.BackendTLSPolicy
+		for svcNsName := range configs {
+			found, config := akogatewayapiobjects.GatewayApiLister().GetServiceToBackendTLSPolicy(svcNsName)
+			if !found || config.PolicyNsName == policyNsName {
+				continue
+			}
+			ownerNs, ownerName := splitNsName(config.PolicyNsName)
+			owner, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().BackendTLSPolicyInformer.Lister().BackendTLSPolicies(ownerNs).Get(ownerName)
+			if err != nil {
+				continue
+			}
+			if isOlderBackendTLSPolicy(owner, policy) {
+				utils.AviLog.Warnf("key: %s, msg: Service %s is already targeted by BackendTLSPolicy %s", key, svcNsName, config.PolicyNsName)
+				delete(configs, svcNsName)
+			} else {
+				displacedPolicies = append(displacedPolicies, owner)
+			}
+		}
+		akogatewayapiobjects.GatewayApiLister().UpdateBackendTLSPolicyToService(policyNsName, configs)
+		for _, displacedPolicy := range displacedPolicies {
+			updateBackendTLSPolicyStatus(key, displacedPolicy, "")
+		}
+	}
+	updateBackendTLSPolicyStatus(key, policy, invalidMessage)
+}
+
+func isOlderBackendTLSPolicy(policy, other *gatewayv1alpha3.BackendTLSPolicy) bool {
+	if policy.CreationTimestamp.Equal(&other.CreationTimestamp) {
+		return policy.Name < other.Name
+	}
+	return policy.CreationTimestamp.Before(&other.CreationTimestamp)
+}
+
+func splitNsName(nsName string) (string, string) {
+	parts := strings.SplitN(nsName, "/", 2)
+	if len(parts) != 2 {
+		return "", nsName
+	}
+	return parts[0], parts[1]
+}
+
+// validateBackendTLSPolicy returns the configuration to be applied per targeted Service, or a
+// message describing why the BackendTLSPolicy is invalid.
+func validateBackendTLSPolicy(key string, policy *gatewayv1alpha3.BackendTLSPolicy) (map[string]akogatewayapiobjects.BackendTLSPolicyConfig, string) {
+	validation := policy.Spec.Validation
+	if validation.WellKnownCACertificates != nil && *validation.WellKnownCACertificates != "" {
+		return nil, fmt.Sprintf("WellKnownCACertificates %s is not supported", *validation.WellKnownCACertificates)
+	}
+	if len(validation.CACertificateRefs) == 0 {
+		return nil, "CACertificateRefs must be specified"
+	}
+
+	var caCerts []string
+	for _, caCertRef := range validation.CACertificateRefs {
+		if caCertRef.Group != "" || string(caCertRef.Kind) != akogatewayapilib.ConfigMapKind {
+			return nil, fmt.Sprintf("CACertificateRef %s of kind %s is not supported", caCertRef.Name, caCertRef.Kind)
+		}
+		configMap, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().ConfigMapInformer.Lister().ConfigMaps(policy.Namespace).Get(string(caCertRef.Name))
+		if err != nil {
+			utils.AviLog.Warnf("key: %s, msg: unable to get the ConfigMap %s/%s. err: %s", key, policy.Namespace, caCertRef.Name, err)
+			return nil, fmt.Sprintf("CACertificateRef ConfigMap %s not found", caCertRef.Name)
+		}
+		caCert, ok := configMap.Data[backendTLSPolicyCACertKey]
+		if !ok || caCert == "" {
+			return nil, fmt.Sprintf("CACertificateRef ConfigMap %s does not contain %s", caCertRef.Name, backendTLSPolicyCACertKey)
+		}
+		caCerts = append(caCerts, caCert)
+	}
+
+	var subjectAltNames []string
+	for _, subjectAltName := range validation.SubjectAltNames {
+		if subjectAltName.Type != gatewayv1alpha3.HostnameSubjectAltNameType {
+			return nil, fmt.Sprintf("SubjectAltName of type %s is not supported", subjectAltName.Type)
+		}
+		subjectAltNames = append(subjectAltNames, string(subjectAltName.Hostname))
+	}
+
+	configs := make(map[string]akogatewayapiobjects.BackendTLSPolicyConfig)
+	for _, targetRef := range policy.Spec.TargetRefs {
+		if !isBackendTLSPolicyTargetService(targetRef) {
+			utils.AviLog.Warnf("key: %s, msg: BackendTLSPolicy target %s of kind %s is not supported", key, targetRef.Name, targetRef.Kind)
+			continue
+		}
+		if targetRef.SectionName != nil && *targetRef.SectionName != "" {
+			return nil, fmt.Sprintf("SectionName %s in targetRef %s is not supported", *targetRef.SectionName, targetRef.Name)
+		}
+		configs[policy.Namespace+"/"+string(targetRef.Name)] = akogatewayapiobjects.BackendTLSPolicyConfig{
+			PolicyNsName:    policy.Namespace + "/" + policy.Name,
+			CACert:          strings.Join(caCerts, "\n"),
+			Hostname:        string(validation.Hostname),
+			SubjectAltNames: subjectAltNames,
+		}
+	}
+	if len(configs) == 0 {
+		return nil, "No Service is targeted by the policy"
+	}
+	return configs, ""
+}
+
+// updateBackendTLSPolicyStatus sets the Accepted condition of the BackendTLSPolicy for every Gateway
+// which routes traffic to one of its target Services.
+func updateBackendTLSPolicyStatus(key string, policy *gatewayv1alpha3.BackendTLSPolicy, invalidMessage string) {
+	policyNsName := policy.Namespace + "/" + policy.Name
+	gwToServices := make(map[string][]string)
+	var gwNsNames []string
+	for _, targetRef := range policy.Spec.TargetRefs {
+		if !isBackendTLSPolicyTargetService(targetRef) {
+			continue
+		}
+		svcNsName := policy.Namespace + "/" + string(targetRef.Name)
+		found, gateways := akogatewayapiobjects.GatewayApiLister().GetServiceToGateway(svcNsName)
+		if !found {
+			continue
+		}
+		for _, gwNsName := range gateways {
+			if _, ok := gwToServices[gwNsName]; !ok {
+				gwNsNames = append(gwNsNames, gwNsName)
+			}
+			gwToServices[gwNsName] = append(gwToServices[gwNsName], svcNsName)
+		}
+	}
+
+	policyStatus := &gatewayv1alpha2.PolicyStatus{}
+	for _, gwNsName := range gwNsNames {
+		gwNs, gwName := splitNsName(gwNsName)
+		group := gatewayv1.Group(akogatewayapilib.GatewayGroup)
+		kind := gatewayv1.Kind(lib.Gateway)
+		namespace := gatewayv1.Namespace(gwNs)
+		ancestorStatus := gatewayv1alpha2.PolicyAncestorStatus{
+			AncestorRef: gatewayv1.ParentReference{
+				Group:     &group,
+				Kind:      &kind,
+				Namespace: &namespace,
+				Name:      gatewayv1.ObjectName(gwName),
+			},
+			ControllerName: akogatewayapilib.GatewayController,
+		}
+
+		condition := akogatewayapistatus.NewCondition().
+			Type(string(gatewayv1alpha2.PolicyConditionAccepted)).
+			ObservedGeneration(policy.ObjectMeta.Generation)
+		if invalidMessage != "" {
+			condition.Status(metav1.ConditionFalse).
+				Reason(string(gatewayv1alpha2.PolicyReasonInvalid)).
+				Message(invalidMessage)
+		} else if conflictMessage := getBackendTLSPolicyConflict(key, policyNsName, gwNsName, gwToServices[gwNsName]); conflictMessage != "" {
+			condition.Status(metav1.ConditionFalse).
+				Reason(string(gatewayv1alpha2.PolicyReasonConflicted)).
+				Message(conflictMessage)
+		} else {
+			condition.Status(metav1.ConditionTrue).
+				Reason(string(gatewayv1alpha2.PolicyReasonAccepted)).
+				Message("Policy has been accepted")
+		}
+		condition.SetIn(&ancestorStatus.Conditions)
+		policyStatus.Ancestors = append(policyStatus.Ancestors, ancestorStatus)
+	}
+	if akogatewayapistatus.IsPolicyStatusEqual(&policy.Status, policyStatus) {
+		utils.AviLog.Debugf("key: %s, msg: status of BackendTLSPolicy %s is unchanged", key, policyNsName)
+		return
+	}
+	akogatewayapistatus.Record(key, policy, &status.Status{PolicyStatus: policyStatus})
+}
+
+// getBackendTLSPolicyConflict returns a message describing why the BackendTLSPolicy cannot be applied
+// to the Services routed by the Gateway, if any.
+func getBackendTLSPolicyConflict(key, policyNsName, gwNsName string, svcNsNames []string) string {
+	var appliedSvcNsNames []string
+	var owners []string
+	for _, svcNsName := range svcNsNames {
+		found, config := akogatewayapiobjects.GatewayApiLister().GetServiceToBackendTLSPolicy(svcNsName)
+		if found && config.PolicyNsName == policyNsName {
+			appliedSvcNsNames = append(appliedSvcNsNames, svcNsName)
+		} else if found {
+			owners = append(owners, config.PolicyNsName)
+		}
+	}
+	if len(appliedSvcNsNames) == 0 {
+		return fmt.Sprintf("Target Services are already targeted by BackendTLSPolicy %s", strings.Join(owners, ", "))
+	}
+
+	_, gwRoutes := akogatewayapiobjects.GatewayApiLister().GetGatewayToRoute(gwNsName)
+	gwRouteSet := sets.NewString(gwRoutes...)
+	for _, svcNsName := range appliedSvcNsNames {
+		_, svcRoutes := akogatewayapiobjects.GatewayApiLister().GetServiceToRoute(svcNsName)
+		for _, routeTypeNsName := range svcRoutes {
+			if !gwRouteSet.Has(routeTypeNsName) {
+				continue
+			}
+			if isRouteBackendExtensionBackendTLSSet(key, routeTypeNsName, svcNsName) {
+				return fmt.Sprintf("RouteBackendExtension backendTLS configured in %s for Service %s takes precedence", routeTypeNsName, svcNsName)
+			}
+		}
+	}
+	return ""
+}
+
+// isRouteBackendExtensionBackendTLSSet returns true if a backendRef of the route to the Service
+// refers to a RouteBackendExtension which configures backendTLS.
+func isRouteBackendExtensionBackendTLSSet(key, routeTypeNsName, svcNsName string) bool {
+	routeType, routeNs, routeName := lib.ExtractTypeNameNamespace(routeTypeNsName)
+	var extensionRefs []*gatewayv1.LocalObjectReference
+	isTargetService := func(backendRef gatewayv1.BackendRef) bool {
+		backendNs := routeNs
+		if backendRef.Namespace != nil {
+			backendNs = string(*backendRef.Namespace)
+		}
+		return backendNs+"/"+string(backendRef.Name) == svcNsName
+	}
+	switch routeType {
+	case lib.HTTPRoute:
+		route, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().HTTPRouteInformer.Lister().HTTPRoutes(routeNs).Get(routeName)
+		if err != nil {
+			return false
+		}
+		for _, rule := range route.Spec.Rules {
+			for _, backendRef := range rule.BackendRefs {
+				if !isTargetService(backendRef.BackendRef) {
+					continue
+				}
+				for _, filter := range backendRef.Filters {
+					extensionRefs = append(extensionRefs, filter.ExtensionRef)
+				}
+			}
+		}
+	case lib.GRPCRoute:
+		route, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GRPCRouteInformer.Lister().GRPCRoutes(routeNs).Get(routeName)
+		if err != nil {
+			return false
+		}
+		for _, rule := range route.Spec.Rules {
+			for _, backendRef := range rule.BackendRefs {
+				if !isTargetService(backendRef.BackendRef) {
+					continue
+				}
+				for _, filter := range backendRef.Filters {
+					extensionRefs = append(extensionRefs, filter.ExtensionRef)
+				}
+			}
+		}
+	}
+	for _, extensionRef := range extensionRefs {
+		if extensionRef != nil && string(extensionRef.Kind) == akogatewayapilib.RouteBackendExtensionKind &&
+			akogatewayapilib.IsRouteBackendExtensionBackendTLSSet(routeNs, string(extensionRef.Name)) {
+			utils.AviLog.Debugf("key: %s, msg: RouteBackendExtension %s/%s configures backendTLS for Service %s", key, routeNs, extensionRef.Name, svcNsName)
+			return true
+		}
+	}
+	return false
+}
@@ -16,9 +16,12 @@ package nodes
 
 import (
 	"context"
+	"sort"
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
 	akogatewayapiobjects "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/objects"
@@ -46,6 +49,24 @@ func DequeueIngestion(key string, fullsync bool) {
 			}
 		}
 	}
+	if objType == lib.GRPCRoute {
+		grpcRoute, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GRPCRouteInformer.Lister().GRPCRoutes(namespace).Get(name)
+		if err == nil {
+			utils.AviLog.Debugf("key: %s, msg: Successfully retrieved the GRPCRoute object %s", key, name)
+			if !IsGRPCRouteValid(key, grpcRoute) {
+				return
+			}
+		}
+	}
+	if akogatewayapilib.IsL4RouteType(objType) {
+		route, err := getL4RouteObject(objType, namespace, name)
+		if err == nil {
+			utils.AviLog.Debugf("key: %s, msg: Successfully retrieved the %s object %s", key, objType, name)
+			if !IsL4RouteValid(key, objType, route) {
+				return
+			}
+		}
+	}
 
 	gatewayNsNameList, found := schema.GetGateways(namespace, name, key)
 	if !found {
@@ -54,6 +75,12 @@ func DequeueIngestion(key string, fullsync bool) {
 		return
 	}
 	utils.AviLog.Infof("key: %s, msg: processing gateways %v", key, gatewayNsNameList)
+	if objType == lib.GatewayClass && akogatewayapilib.IsGatewayClassInMergeMode(name) {
+		// The parent VS shared by the Gateways of the GatewayClass is built once, along with the routes of all of them.
+		handleMergedGateways(name, fullsync, key)
+		utils.AviLog.Infof("key: %s, msg: finished graph Sync", key)
+		return
+	}
 	if objType == lib.Gateway {
 		handleGateway(namespace, name, fullsync, key)
 	}
@@ -83,18 +110,16 @@ func DequeueIngestion(key string, fullsync bool) {
 	for _, gatewayNsName := range gatewayNsNameList {
 
 		parentNs, _, parentName := lib.ExtractTypeNameNamespace(gatewayNsName)
-		tenant := objects.SharedNamespaceTenantLister().GetTenantInNamespace(gatewayNsName)
-		if tenant == "" {
-			tenant = lib.GetTenant()
-		}
-		modelName := lib.GetModelName(tenant, akogatewayapilib.GetGatewayParentName(parentNs, parentName))
+		modelName := getGatewayModelName(parentNs, parentName)
 
 		modelFound, modelIntf := objects.SharedAviGraphLister().Get(modelName)
 		// Seq: GW first and the secret created.
 		modelNil := !modelFound || modelIntf == nil
 		if objType == utils.Secret {
-			if modelNil {
+			// The parent VS shared by merged Gateways is rebuilt, as it carries the certificates of all of them.
+			if modelNil || akogatewayapiobjects.GatewayApiLister().GetMergedGatewayClass(gatewayNsName) != "" {
 				handleGateway(parentNs, parentName, fullsync, key)
+				modelName = getGatewayModelName(parentNs, parentName)
 				modelFound, modelIntf = objects.SharedAviGraphLister().Get(modelName)
 				modelNil = !modelFound || modelIntf == nil
 				if modelNil {
@@ -128,31 +153,7 @@ func DequeueIngestion(key string, fullsync bool) {
 		}
 
 		model := &AviObjectGraph{modelIntf.(*nodes.AviObjectGraph)}
-		utils.AviLog.Infof("key: %s, msg: processing routes %v", key, routeTypeNsNameList)
-		for _, routeTypeNsName := range routeTypeNsNameList {
-			objType, namespace, name := lib.ExtractTypeNameNamespace(routeTypeNsName)
-			utils.AviLog.Infof("key: %s, msg: processing route %s mapped to gateway %s", key, routeTypeNsName, gatewayNsName)
-
-			routeModel, err := NewRouteModel(key, objType, name, namespace)
-			if err != nil {
-				if k8serrors.IsNotFound(err) {
-					utils.AviLog.Infof("key: %s, msg: deleting configurations corresponding to route %s", key, routeTypeNsName)
-					model.ProcessRouteDeletion(key, gatewayNsName, routeModel, fullsync)
-				}
-				continue
-			}
-
-			childVSes := make(map[string]struct{}, 0)
-
-			switch objType {
-			case lib.HTTPRoute:
-				model.ProcessL7Routes(key, routeModel, gatewayNsName, childVSes, fullsync)
-			default:
-				utils.AviLog.Warnf("key: %s, msg: route of type %s not supported", key, objType)
-				continue
-			}
-			model.DeleteStaleChildVSes(key, routeModel, childVSes, fullsync)
-		}
+		model.processRoutes(key, gatewayNsName, routeTypeNsNameList, fullsync)
 		if !akogatewayapilib.IsGatewayInDedicatedMode(parentNs, parentName) {
 			model.AddDefaultHTTPPolicySet(key)
 		}
@@ -166,6 +167,44 @@ func DequeueIngestion(key string, fullsync bool) {
 	}
 	utils.AviLog.Infof("key: %s, msg: finished graph Sync", key)
 }
+
+// processRoutes translates the routes attached to the gateway to the child VSes of its parent VS.
+func (o *AviObjectGraph) processRoutes(key, gatewayNsName string, routeTypeNsNameList []string, fullsync bool) {
+	utils.AviLog.Infof("key: %s, msg: processing routes %v", key, routeTypeNsNameList)
+	for _, routeTypeNsName := range routeTypeNsNameList {
+		objType, namespace, name := lib.ExtractTypeNameNamespace(routeTypeNsName)
+		utils.AviLog.Infof("key: %s, msg: processing route %s mapped to gateway %s", key, routeTypeNsName, gatewayNsName)
+
+		routeModel, err := NewRouteModel(key, objType, name, namespace)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				utils.AviLog.Infof("key: %s, msg: deleting configurations corresponding to route %s", key, routeTypeNsName)
+				o.ProcessRouteDeletion(key, gatewayNsName, routeModel, fullsync)
+			}
+			continue
+		}
+
+		childVSes := make(map[string]struct{}, 0)
+
+		switch objType {
+		case lib.HTTPRoute, lib.GRPCRoute:
+			o.ProcessL7Routes(key, routeModel, gatewayNsName, childVSes, fullsync)
+		case lib.TCPRoute, lib.UDPRoute, lib.TLSRoute:
+			o.ProcessL4Routes(key, routeModel, gatewayNsName)
+			continue
+		default:
+			utils.AviLog.Warnf("key: %s, msg: route of type %s not supported", key, objType)
+			continue
+		}
+		o.DeleteStaleChildVSes(key, gatewayNsName, routeModel, childVSes, fullsync)
+	}
+	// the routes may have been detached from the listeners without being deleted, so the HTTP/2 ports
+	// are re-evaluated once all the routes are processed.
+	if parentNode := o.GetAviEvhVS(); len(parentNode) > 0 && !parentNode[0].Dedicated {
+		updateHTTP2Ports(key, gatewayNsName, parentNode[0])
+	}
+}
+
 func handleSecrets(gatewayNamespace string, gatewayName string, key string, object *AviObjectGraph) bool {
 	_, _, secretName := lib.ExtractTypeNameNamespace(key)
 	utils.AviLog.Infof("key: %s, msg: Processing secret update %s has been added.", key, secretName)
@@ -189,6 +228,8 @@ func handleSecrets(gatewayNamespace string, gatewayName string, key string, obje
 func handleGateway(namespace, name string, fullsync bool, key string) {
 	utils.AviLog.Debugf("key: %s, msg: processing gateway: %s", key, name)
 
+	gwNsName := namespace + "/" + name
+	mergedGwClass := akogatewayapiobjects.GatewayApiLister().GetMergedGatewayClass(gwNsName)
 	tenant := objects.SharedNamespaceTenantLister().GetTenantInNamespace(namespace + "/" + name)
 	if tenant == "" {
 		tenant = lib.GetTenant()
@@ -208,6 +249,13 @@ func handleGateway(namespace, name string, fullsync bool, key string) {
 			return
 		}
 		utils.AviLog.Debugf("key: %s, msg: gateway not found: %s/%s", key, namespace, name)
+		if mergedGwClass != "" {
+			// Remove the gateway from the parent VS shared with the other Gateways of the GatewayClass.
+			akogatewayapiobjects.GatewayApiLister().DeleteGatewayFromStore(gwNsName)
+			handleMergedGateways(mergedGwClass, fullsync, key)
+			objects.SharedNamespaceTenantLister().RemoveNamespaceToTenantCache(gwNsName)
+			return
+		}
 		if !modelFound {
 			// try to get model if it was dedicated mode since there is no way to find the annotation once gateway is deleted
 			modelName = lib.GetModelName(tenant, lib.GetNamePrefix()+namespace+"-"+name+lib.DedicatedSuffix+"-EVH")
@@ -215,7 +263,6 @@ func handleGateway(namespace, name string, fullsync bool, key string) {
 		}
 		if modelFound {
 			// As gateway is not present, we need to remove mapping.
-			gwNsName := namespace + "/" + name
 			akogatewayapiobjects.GatewayApiLister().DeleteGatewayFromStore(gwNsName)
 			objects.SharedAviGraphLister().Save(modelName, nil)
 			objects.SharedNamespaceTenantLister().RemoveNamespaceToTenantCache(gwNsName)
@@ -227,6 +274,10 @@ func handleGateway(namespace, name string, fullsync bool, key string) {
 		return
 	}
 	gwClass := string(gatewayObj.Spec.GatewayClassName)
+	if mergedGwClass != "" && (mergedGwClass != gwClass || !akogatewayapilib.IsGatewayClassInMergeMode(mergedGwClass)) {
+		// The gateway moved to another GatewayClass, or its GatewayClass is no longer in merge mode.
+		handleMergedGateways(mergedGwClass, fullsync, key)
+	}
 	utils.AviLog.Debugf("key: %s, msg: fetching gateway class %s for gateway: %s/%s", key, gwClass, namespace, name)
 	found, isAkoCtrl := akogatewayapiobjects.GatewayApiLister().IsGatewayClassControllerAKO(gwClass)
 	if !found {
@@ -245,6 +296,22 @@ func handleGateway(namespace, name string, fullsync bool, key string) {
 		utils.AviLog.Infof("key: %s, msg: Controller is not AKO for %s, not building VS model", key, modelName)
 		return
 	}
+	if akogatewayapilib.IsGatewayClassInMergeMode(gwClass) {
+		// The parent VS of the gateway is replaced by the one shared by the Gateways of the GatewayClass.
+		dedicatedModelName := lib.GetModelName(tenant, lib.GetNamePrefix()+namespace+"-"+name+lib.DedicatedSuffix+"-EVH")
+		for _, gwModelName := range []string{modelName, dedicatedModelName} {
+			if found, aviModel := objects.SharedAviGraphLister().Get(gwModelName); found && aviModel != nil {
+				utils.AviLog.Infof("key: %s, msg: Deleting the model %s of the merged gateway", key, gwModelName)
+				objects.SharedAviGraphLister().Save(gwModelName, nil)
+				if !fullsync {
+					sharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
+					nodes.PublishKeyToRestLayer(gwModelName, key, sharedQueue)
+				}
+			}
+		}
+		handleMergedGateways(gwClass, fullsync, key)
+		return
+	}
 	aviModelGraph := NewAviObjectGraph()
 	aviModelGraph.BuildGatewayVs(gatewayObj, key)
 
@@ -261,6 +328,108 @@ func handleGateway(namespace, name string, fullsync bool, key string) {
 	}
 }
 
+// handleMergedGateways builds the parent VS shared by the valid Gateways of a GatewayClass in merge mode along
+// with the child VSes of their routes, and deletes it once no Gateway is left to be merged.
+func handleMergedGateways(gwClass string, fullsync bool, key string) {
+	vsName := akogatewayapilib.GetMergedGatewayParentName(gwClass)
+	oldTenant := lib.GetTenant()
+	if oldGwNsNames := akogatewayapiobjects.GatewayApiLister().GetGatewayClassToMergedGateways(gwClass); len(oldGwNsNames) > 0 {
+		if tenant := objects.SharedNamespaceTenantLister().GetTenantInNamespace(oldGwNsNames[0]); tenant != "" {
+			oldTenant = tenant
+		}
+	}
+	oldModelName := lib.GetModelName(oldTenant, vsName)
+
+	gateways := getMergedGateways(gwClass, key)
+	if len(gateways) == 0 {
+		utils.AviLog.Infof("key: %s, msg: no gateway left to be merged in gateway class %s", key, gwClass)
+		akogatewayapiobjects.GatewayApiLister().UpdateGatewayClassToMergedGateways(gwClass, nil)
+		if found, aviModel := objects.SharedAviGraphLister().Get(oldModelName); found && aviModel != nil {
+			objects.SharedAviGraphLister().Save(oldModelName, nil)
+			if !fullsync {
+				sharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
+				nodes.PublishKeyToRestLayer(oldModelName, key, sharedQueue)
+			}
+		}
+		return
+	}
+	gwNsNames := make([]string, 0, len(gateways))
+	for _, gateway := range gateways {
+		gwNsNames = append(gwNsNames, gateway.Namespace+"/"+gateway.Name)
+	}
+	utils.AviLog.Infof("key: %s, msg: merging gateways %v of gateway class %s", key, gwNsNames, gwClass)
+	akogatewayapiobjects.GatewayApiLister().UpdateGatewayClassToMergedGateways(gwClass, gwNsNames)
+
+	aviModelGraph := NewAviObjectGraph()
+	aviModelGraph.BuildMergedGatewayVs(gwClass, gateways, key)
+	modelName := lib.GetModelName(aviModelGraph.GetAviEvhVS()[0].Tenant, vsName)
+	if modelName != oldModelName {
+		if found, aviModel := objects.SharedAviGraphLister().Get(oldModelName); found && aviModel != nil {
+			utils.AviLog.Infof("key: %s, msg: Deleting old model data, model: %s", key, oldModelName)
+			objects.SharedAviGraphLister().Save(oldModelName, nil)
+			if !fullsync {
+				sharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
+				nodes.PublishKeyToRestLayer(oldModelName, key, sharedQueue)
+			}
+		}
+	}
+	for _, gateway := range gateways {
+		routeTypeNsNameList, found := GatewayToRoutes(gateway.Namespace, gateway.Name, key)
+		if !found {
+			continue
+		}
+		aviModelGraph.processRoutes(key, gateway.Namespace+"/"+gateway.Name, routeTypeNsNameList, fullsync)
+	}
+	aviModelGraph.AddDefaultHTTPPolicySet(key)
+
+	modelChanged := saveAviModel(modelName, aviModelGraph.AviObjectGraph, key)
+	if modelChanged && !fullsync {
+		sharedQueue := utils.SharedWorkQueue().GetQueueByName(utils.GraphLayer)
+		nodes.PublishKeyToRestLayer(modelName, key, sharedQueue)
+	}
+}
+
+// getMergedGateways returns the valid Gateways of a GatewayClass in merge mode, in the order of precedence.
+func getMergedGateways(gwClass, key string) []*gatewayv1.Gateway {
+	found, isAkoCtrl := akogatewayapiobjects.GatewayApiLister().IsGatewayClassControllerAKO(gwClass)
+	if !found || !isAkoCtrl || !akogatewayapilib.IsGatewayClassInMergeMode(gwClass) {
+		return nil
+	}
+	gwList, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayInformer.Lister().List(labels.Everything())
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to list the gateways, err: %v", key, err)
+		return nil
+	}
+	var gateways []*gatewayv1.Gateway
+	for _, gateway := range gwList {
+		if string(gateway.Spec.GatewayClassName) != gwClass || gateway.GetDeletionTimestamp() != nil {
+			continue
+		}
+		gwStatus := akogatewayapiobjects.GatewayApiLister().GetGatewayToGatewayStatusMapping(gateway.Namespace + "/" + gateway.Name)
+		if gwStatus == nil || akogatewayapilib.IsGatewayInvalid(gwStatus) {
+			continue
+		}
+		gateways = append(gateways, gateway)
+	}
+	sort.Slice(gateways, func(i, j int) bool {
+		return akogatewayapilib.HasGatewayPrecedence(gateways[i], gateways[j])
+	})
+	return gateways
+}
+
+// getGatewayModelName returns the name of the model of the parent VS of the gateway, which is shared by
+// all the Gateways of the GatewayClass when the gateway is merged.
+func getGatewayModelName(namespace, name string) string {
+	tenant := objects.SharedNamespaceTenantLister().GetTenantInNamespace(namespace + "/" + name)
+	if tenant == "" {
+		tenant = lib.GetTenant()
+	}
+	if gwClass := akogatewayapiobjects.GatewayApiLister().GetMergedGatewayClass(namespace + "/" + name); gwClass != "" {
+		return lib.GetModelName(tenant, akogatewayapilib.GetMergedGatewayParentName(gwClass))
+	}
+	return lib.GetModelName(tenant, akogatewayapilib.GetGatewayParentName(namespace, name))
+}
+
 func saveAviModel(modelName string, aviGraph *nodes.AviObjectGraph, key string) bool {
 	utils.AviLog.Debugf("key: %s, msg: Evaluating model :%s", key, modelName)
 	if lib.DisableSync {
@@ -293,6 +462,8 @@ func (o *AviObjectGraph) ProcessRouteDeletion(key, parentNsName string, routeMod
 	if parentNode[0].Dedicated {
 		o.ProcessRouteDeletionForDedicatedMode(key, parentNsName, routeModel, fullsync)
 
+	} else if akogatewayapilib.IsL4RouteType(routeModel.GetType()) {
+		removeL4RouteObjects(key, parentNsName, parentNode[0], routeModel)
 	} else {
 		found, childVSNames := akogatewayapiobjects.GatewayApiLister().GetRouteToChildVS(routeTypeNsName)
 		if found {
@@ -307,6 +478,7 @@ func (o *AviObjectGraph) ProcessRouteDeletion(key, parentNsName string, routeMod
 		}
 	}
 	updateHostname(key, parentNsName, parentNode[0])
+	updateHTTP2Ports(key, parentNsName, parentNode[0])
 	modelName := parentNode[0].Tenant + "/" + parentNode[0].Name
 	ok := saveAviModel(modelName, o.AviObjectGraph, key)
 	if ok && len(o.AviObjectGraph.GetOrderedNodes()) != 0 && !fullsync {
@@ -350,7 +522,7 @@ func (o *AviObjectGraph) ProcessRouteDeletionForDedicatedMode(key, parentNsName
 	utils.AviLog.Infof("key: %s, msg: Completed route deletion for dedicated mode: %s/%s", key, routeModel.GetNamespace(), routeModel.GetName())
 }
 
-func (o *AviObjectGraph) DeleteStaleChildVSes(key string, routeModel RouteModel, childVSes map[string]struct{}, fullsync bool) {
+func (o *AviObjectGraph) DeleteStaleChildVSes(key, parentNsName string, routeModel RouteModel, childVSes map[string]struct{}, fullsync bool) {
 
 	parentNode := o.GetAviEvhVS()
 
@@ -358,6 +530,11 @@ func (o *AviObjectGraph) DeleteStaleChildVSes(key string, routeModel RouteModel,
 
 	for _, childVSName := range storedChildVSes {
 		if _, ok := childVSes[childVSName]; !ok {
+			// The child VSes of the other Gateways merged onto the same parent VS are retained.
+			childNode := parentNode[0].GetEvhNodeForName(childVSName)
+			if childNode != nil && childNode.AviMarkers.GatewayNamespace+"/"+childNode.AviMarkers.GatewayName != parentNsName {
+				continue
+			}
 			utils.AviLog.Infof("key: %s, msg: child VS retrieved for deletion %v", key, childVSName)
 			removed := nodes.RemoveEvhInModel(childVSName, parentNode, key)
 			if removed {
@@ -16,11 +16,13 @@ package nodes
 
 import (
 	"context"
+	"slices"
 	"strings"
 
 	"google.golang.org/protobuf/proto"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/net"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
@@ -46,14 +48,73 @@ func (o *AviObjectGraph) BuildGatewayVs(gateway *gatewayv1.Gateway, key string)
 	defer o.Lock.Unlock()
 
 	vsNode := o.BuildGatewayParent(gateway, key)
+	buildL4ChildForGateway(key, vsNode)
 
 	o.AddModelNode(vsNode)
 	utils.AviLog.Infof("key: %s, msg: checksum for AVI VS object %v", key, vsNode.GetCheckSum())
 }
 
+// BuildMergedGatewayVs builds the parent VS shared by the Gateways of a GatewayClass in merge mode. The VIP and
+// the settings of the parent VS are taken from the first Gateway, which takes precedence, while the ports,
+// certificates and frontend validation of the listeners of all the Gateways are merged. Gateways with different
+// addresses or AviInfraSetting than the first one are rejected during validation.
+func (o *AviObjectGraph) BuildMergedGatewayVs(gwClass string, gateways []*gatewayv1.Gateway, key string) {
+	o.Lock.Lock()
+	defer o.Lock.Unlock()
+
+	vsNode := o.buildGatewayParent(gateways[0], akogatewayapilib.GetMergedGatewayParentName(gwClass), key)
+	// the dedicated mode is not supported for merged Gateways
+	vsNode.EVHParent = true
+	vsNode.Dedicated = false
+	for _, gateway := range gateways[1:] {
+		mergeGatewayListeners(key, vsNode, gateway)
+	}
+	buildL4ChildForGateway(key, vsNode)
+	for _, gateway := range gateways {
+		objects.SharedNamespaceTenantLister().UpdateNamespacedResourceToTenantStore(gateway.Namespace+"/"+gateway.Name, vsNode.Tenant)
+	}
+
+	o.AddModelNode(vsNode)
+	utils.AviLog.Infof("key: %s, msg: checksum for AVI VS object %v", key, vsNode.GetCheckSum())
+}
+
+// mergeGatewayListeners adds the ports of the listeners of the gateway, which are not served by the parent VS
+// yet, along with their frontend validation, and the certificates of the listeners to the shared parent VS.
+// Listeners on a served port with a different protocol or frontend validation are rejected during validation.
+func mergeGatewayListeners(key string, parentVsNode *nodes.AviEvhVsNode, gateway *gatewayv1.Gateway) {
+	gatewayVsNode := &nodes.AviEvhVsNode{
+		Name:   parentVsNode.Name,
+		Tenant: parentVsNode.Tenant,
+	}
+	gatewayVsNode.PortProto = BuildPortProtocols(gateway, key)
+	BuildFrontendValidationForGateway(gateway, gatewayVsNode, key)
+	for _, pp := range gatewayVsNode.PortProto {
+		if slices.ContainsFunc(parentVsNode.PortProto, func(parentPP nodes.AviPortHostProtocol) bool {
+			return parentPP.Port == pp.Port
+		}) {
+			continue
+		}
+		parentVsNode.PortProto = append(parentVsNode.PortProto, pp)
+		for _, appProfile := range gatewayVsNode.ApplicationProfileRefs {
+			if appProfile.Name == pp.ApplicationProfile {
+				parentVsNode.ApplicationProfileRefs = append(parentVsNode.ApplicationProfileRefs, appProfile)
+			}
+		}
+	}
+	for _, tlsNode := range BuildTLSNodesForGateway(gateway, parentVsNode, key) {
+		if utils.HasElemWithName(parentVsNode.SSLKeyCertRefs, tlsNode) == -1 {
+			parentVsNode.SSLKeyCertRefs = append(parentVsNode.SSLKeyCertRefs, tlsNode)
+		}
+	}
+	utils.AviLog.Debugf("key: %s, msg: merged the listeners of gateway %s/%s onto the parent VS %s", key, gateway.Namespace, gateway.Name, parentVsNode.Name)
+}
+
 func (o *AviObjectGraph) BuildGatewayParent(gateway *gatewayv1.Gateway, key string) *nodes.AviEvhVsNode {
+	return o.buildGatewayParent(gateway, akogatewayapilib.GetGatewayParentName(gateway.Namespace, gateway.Name), key)
+}
+
+func (o *AviObjectGraph) buildGatewayParent(gateway *gatewayv1.Gateway, vsName, key string) *nodes.AviEvhVsNode {
 	tenant := lib.GetTenantInNamespace(gateway.Namespace)
-	vsName := akogatewayapilib.GetGatewayParentName(gateway.Namespace, gateway.Name)
 
 	oldTenant := objects.SharedNamespaceTenantLister().GetTenantInNamespace(gateway.Namespace + "/" + gateway.Name)
 	if oldTenant == "" {
@@ -90,7 +151,7 @@ func (o *AviObjectGraph) BuildGatewayParent(gateway *gatewayv1.Gateway, key stri
 		TrafficEnabled: trafficEnabled,
 		Caller:         utils.GATEWAY_API, // Always Populate this field to recognise caller at rest layer
 	}
-	infraSetting, err := lib.GetNamespacedAviInfraSetting(key, gateway.GetNamespace(), akogatewayapilib.AKOControlConfig().AviInfraSettingInformer())
+	infraSetting, err := akogatewayapilib.GetAviInfraSettingForGateway(key, gateway)
 	if err != nil {
 		utils.AviLog.Warnf("key: %s, msg: failed to get AviInfraSetting, err: %s", key, err.Error())
 	}
@@ -103,6 +164,7 @@ func (o *AviObjectGraph) BuildGatewayParent(gateway *gatewayv1.Gateway, key stri
 		parentVsNode.VrfContext = ""
 	}
 	parentVsNode.PortProto = BuildPortProtocols(gateway, key)
+	BuildFrontendValidationForGateway(gateway, parentVsNode, key)
 
 	tlsNodes := BuildTLSNodesForGateway(gateway, parentVsNode, key)
 	if len(tlsNodes) > 0 {
@@ -115,6 +177,16 @@ func (o *AviObjectGraph) BuildGatewayParent(gateway *gatewayv1.Gateway, key stri
 		GatewayName:      gateway.Name,
 		GatewayNamespace: gateway.Namespace,
 	}
+	if gateway.Spec.Infrastructure != nil && len(gateway.Spec.Infrastructure.Labels) > 0 {
+		parentVsNode.AviMarkers.Labels = make(map[string]string, len(gateway.Spec.Infrastructure.Labels))
+		for labelKey, labelValue := range gateway.Spec.Infrastructure.Labels {
+			if lib.IsReservedMarkerKey(string(labelKey)) {
+				utils.AviLog.Warnf("key: %s, msg: infrastructure label %s of gateway %s/%s is reserved for AKO markers, skipping it", key, labelKey, gateway.Namespace, gateway.Name)
+				continue
+			}
+			parentVsNode.AviMarkers.Labels[string(labelKey)] = string(labelValue)
+		}
+	}
 
 	buildWithInfraSettingForGateway(key, parentVsNode, vsvipNode, infraSetting)
 
@@ -134,6 +206,48 @@ func (o *AviObjectGraph) BuildGatewayParent(gateway *gatewayv1.Gateway, key stri
 	return parentVsNode
 }
 
+// buildL4ChildForGateway moves the ports of the TCP and UDP listeners from the parent VS to a L4 VS sharing the
+// vsvip of the parent VS, as done for the L4 services. The L4 policysets of the TCPRoutes and UDPRoutes are
+// attached to the L4 VS.
+func buildL4ChildForGateway(key string, parentVsNode *nodes.AviEvhVsNode) {
+	var portProtocols, l4PortProtocols []nodes.AviPortHostProtocol
+	isTCP, isUDP := false, false
+	for _, pp := range parentVsNode.PortProto {
+		switch pp.Protocol {
+		case utils.TCP:
+			isTCP = true
+		case utils.UDP:
+			isUDP = true
+		default:
+			portProtocols = append(portProtocols, pp)
+			continue
+		}
+		l4PortProtocols = append(l4PortProtocols, pp)
+	}
+	if len(l4PortProtocols) == 0 {
+		return
+	}
+	parentVsNode.PortProto = portProtocols
+
+	l4VsNode := &nodes.AviVsNode{
+		Name:               akogatewayapilib.GetGatewayL4VSName(parentVsNode.Name),
+		Tenant:             parentVsNode.Tenant,
+		ServiceEngineGroup: parentVsNode.ServiceEngineGroup,
+		ApplicationProfile: utils.DEFAULT_L4_APP_PROFILE,
+		NetworkProfile:     nodes.GetNetworkProfile(false, isTCP, isUDP),
+		PortProto:          l4PortProtocols,
+		VrfContext:         parentVsNode.VrfContext,
+		TrafficEnabled:     parentVsNode.TrafficEnabled,
+		AviMarkers:         parentVsNode.AviMarkers,
+		IsL4VS:             true,
+	}
+	l4VsNode.VSVIPRefs = append(l4VsNode.VSVIPRefs, parentVsNode.VSVIPRefs...)
+	l4VsNode.ServiceMetadata.L4ParentRef = parentVsNode.Name
+	parentVsNode.ServiceMetadata.L4ChildRef = l4VsNode.Name
+	parentVsNode.L4ChildNodes = []*nodes.AviVsNode{l4VsNode}
+	utils.AviLog.Debugf("key: %s, msg: the TCP/UDP listener ports %v of the VS %s are served by the L4 VS %s", key, utils.Stringify(l4PortProtocols), parentVsNode.Name, l4VsNode.Name)
+}
+
 func BuildPortProtocols(gateway *gatewayv1.Gateway, key string) []nodes.AviPortHostProtocol {
 	var portProtocols []nodes.AviPortHostProtocol
 	gwStatus := akogatewayapiobjects.GatewayApiLister().GetGatewayToGatewayStatusMapping(gateway.Namespace + "/" + gateway.Name)
@@ -154,6 +268,70 @@ func BuildPortProtocols(gateway *gatewayv1.Gateway, key string) []nodes.AviPortH
 	return portProtocols
 }
 
+// BuildFrontendValidationForGateway translates the frontendValidation of the HTTPS listeners to AKO managed
+// application profiles, which require and validate the client certificates against a PKI profile holding the
+// referred CA certificates. The application profile overrides the one of the parent VS for the listener port and
+// inherits the settings of the System-Secure-HTTP profile.
+func BuildFrontendValidationForGateway(gateway *gatewayv1.Gateway, parentVsNode *nodes.AviEvhVsNode, key string) {
+	parentVsNode.ApplicationProfileRefs = nil
+	gwStatus := akogatewayapiobjects.GatewayApiLister().GetGatewayToGatewayStatusMapping(gateway.Namespace + "/" + gateway.Name)
+	appProfiles := make(map[int32]*nodes.AviApplicationProfileNode)
+	invalidPorts := sets.New[int32]()
+	for i, listener := range gateway.Spec.Listeners {
+		if akogatewayapilib.IsListenerInvalid(gwStatus, i) {
+			continue
+		}
+		if listener.TLS == nil || listener.TLS.FrontendValidation == nil || listener.Protocol != gatewayv1.HTTPSProtocolType {
+			continue
+		}
+		port := int32(listener.Port)
+		if _, ok := appProfiles[port]; ok || invalidPorts.Has(port) {
+			continue
+		}
+		caCert, err := akogatewayapilib.GetFrontendValidationCACert(gateway.Namespace, listener.TLS.FrontendValidation)
+		if err != nil {
+			// do not serve the port without validating the client certificates
+			utils.AviLog.Warnf("key: %s, msg: unable to get the CA certificates of listener %s, port %d will not be configured, err: %s", key, listener.Name, port, err.Error())
+			invalidPorts.Insert(port)
+			continue
+		}
+		markers := utils.AviObjectMarkers{
+			GatewayName:      gateway.Name,
+			GatewayNamespace: gateway.Namespace,
+		}
+		appProfiles[port] = &nodes.AviApplicationProfileNode{
+			Name:                     akogatewayapilib.GetFrontendApplicationProfileName(gateway.Namespace, gateway.Name, port),
+			Tenant:                   parentVsNode.Tenant,
+			SSLClientCertificateMode: lib.SSLClientCertificateModeRequire,
+			ParentApplicationProfile: utils.DEFAULT_L7_SECURE_APP_PROFILE,
+			AviMarkers:               markers,
+			PkiProfile: &nodes.AviPkiProfileNode{
+				Name:       akogatewayapilib.GetFrontendPkiProfileName(gateway.Namespace, gateway.Name, port),
+				Tenant:     parentVsNode.Tenant,
+				CACert:     caCert,
+				AviMarkers: markers,
+			},
+		}
+	}
+
+	var portProtocols []nodes.AviPortHostProtocol
+	for _, pp := range parentVsNode.PortProto {
+		if invalidPorts.Has(pp.Port) {
+			continue
+		}
+		if appProfile, ok := appProfiles[pp.Port]; ok {
+			pp.ApplicationProfile = appProfile.Name
+		}
+		portProtocols = append(portProtocols, pp)
+	}
+	parentVsNode.PortProto = portProtocols
+
+	ports := sets.List(sets.KeySet(appProfiles))
+	for _, port := range ports {
+		parentVsNode.ApplicationProfileRefs = append(parentVsNode.ApplicationProfileRefs, appProfiles[port])
+	}
+}
+
 func BuildTLSNodesForGateway(gateway *gatewayv1.Gateway, parentVsNode *nodes.AviEvhVsNode, key string) []*nodes.AviTLSKeyCertNode {
 	var tlsNodes []*nodes.AviTLSKeyCertNode
 	var ns, name string
@@ -223,13 +401,24 @@ func BuildVsVipNodeForGateway(key string, gateway *gatewayv1.Gateway, parentVsNo
 		vsvipNode.VrfContext = ""
 		vsvipNode.T1Lr = t1LR
 	}
-	//Type is validated at ingestion
-	if len(gateway.Spec.Addresses) == 1 {
-		ipAddr := gateway.Spec.Addresses[0].Value
-		if net.IsIPv4String(ipAddr) || net.IsIPv6String(ipAddr) {
-			vsvipNode.IPAddress = ipAddr
+	// Addresses are validated at ingestion. A v4 and a v6 static address are configured
+	// together on a dual-stack vip, and a NamedAddress selects the VIP network.
+	var ipv4Address, ipv6Address string
+	for _, address := range gateway.Spec.Addresses {
+		if address.Type != nil && *address.Type == gatewayv1.NamedAddressType {
+			vsvipNode.VipNetworks = []v1beta1.AviInfraSettingVipNetwork{getNamedAddressVipNetwork(address.Value)}
+		} else if net.IsIPv4String(address.Value) {
+			ipv4Address = address.Value
+		} else if net.IsIPv6String(address.Value) {
+			ipv6Address = address.Value
 		}
 	}
+	if ipv4Address != "" {
+		vsvipNode.IPAddress = ipv4Address
+		vsvipNode.IPv6Address = ipv6Address
+	} else {
+		vsvipNode.IPAddress = ipv6Address
+	}
 
 	// This section is currently only applicable to NSX-T cloud in VPC mode.
 	// Allocate public VIP by default
@@ -242,6 +431,17 @@ func BuildVsVipNodeForGateway(key string, gateway *gatewayv1.Gateway, parentVsNo
 	return vsvipNode
 }
 
+// getNamedAddressVipNetwork returns the VIP network configured in AKO with the given name,
+// so that its CIDRs are retained, or else a VIP network referring the Avi network by name.
+func getNamedAddressVipNetwork(networkName string) v1beta1.AviInfraSettingVipNetwork {
+	for _, vipNetwork := range utils.GetVipNetworkList() {
+		if vipNetwork.NetworkName == networkName {
+			return vipNetwork
+		}
+	}
+	return v1beta1.AviInfraSettingVipNetwork{NetworkName: networkName}
+}
+
 func DeleteTLSNode(key string, object *AviObjectGraph, gateway *gatewayv1.Gateway, secretObj *corev1.Secret) bool {
 	var tlsNodes []*nodes.AviTLSKeyCertNode
 	_, certNamespace, secretName := lib.ExtractTypeNameNamespace(key)
@@ -17,6 +17,7 @@ package nodes
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"sort"
 	"strings"
 
@@ -88,11 +89,41 @@ var (
 		GetGateways: HTTPRouteToGateway,
 		GetRoutes:   HTTPRouteChanges,
 	}
+	GRPCRoute = GraphSchema{
+		Type:        lib.GRPCRoute,
+		GetGateways: GRPCRouteToGateway,
+		GetRoutes:   GRPCRouteChanges,
+	}
 	Pod = GraphSchema{
 		Type:        "Pod",
 		GetGateways: PodToGateway,
 		GetRoutes:   PodToHTTPRoute,
 	}
+	TCPRoute = GraphSchema{
+		Type:        lib.TCPRoute,
+		GetGateways: TCPRouteToGateway,
+		GetRoutes:   TCPRouteChanges,
+	}
+	UDPRoute = GraphSchema{
+		Type:        lib.UDPRoute,
+		GetGateways: UDPRouteToGateway,
+		GetRoutes:   UDPRouteChanges,
+	}
+	TLSRoute = GraphSchema{
+		Type:        lib.TLSRoute,
+		GetGateways: TLSRouteToGateway,
+		GetRoutes:   TLSRouteChanges,
+	}
+	BackendTLSPolicy = GraphSchema{
+		Type:        lib.BackendTLSPolicy,
+		GetGateways: BackendTLSPolicyToGateways,
+		GetRoutes:   BackendTLSPolicyToRoutes,
+	}
+	ServiceImport = GraphSchema{
+		Type:        lib.ServiceImport,
+		GetGateways: ServiceImportToGateways,
+		GetRoutes:   ServiceImportToRoutes,
+	}
 	SupportedGraphTypes = GraphDescriptor{
 		Gateway,
 		GatewayClass,
@@ -100,7 +131,13 @@ var (
 		Service,
 		EndpointSlices,
 		HTTPRoute,
+		GRPCRoute,
 		Pod,
+		TCPRoute,
+		UDPRoute,
+		TLSRoute,
+		BackendTLSPolicy,
+		ServiceImport,
 	}
 )
 
@@ -142,8 +179,8 @@ func GatewayGetGw(namespace, name, key string) ([]string, bool) {
 
 		if listenerObj.AllowedRoutes == nil {
 			gwListener.AllowedRouteNs = gwObj.Namespace
-			gwListener.AllowedRouteTypes = []akogatewayapiobjects.GatewayRouteKind{
-				{Group: akogatewayapilib.GatewayGroup, Kind: akogatewayapilib.ProtocolToRoute(gwListener.Protocol)},
+			for _, kind := range akogatewayapilib.SupportedKinds[listenerObj.Protocol] {
+				gwListener.AllowedRouteTypes = append(gwListener.AllowedRouteTypes, akogatewayapiobjects.GatewayRouteKind{Group: akogatewayapilib.GatewayGroup, Kind: string(kind.Kind)})
 			}
 		} else {
 			if listenerObj.AllowedRoutes.Namespaces != nil {
@@ -224,7 +261,15 @@ func GatewayToRoutes(namespace, name, key string) ([]string, bool) {
 			}
 		}
 	}
-	routeTypeNsNameList, _ := validateReferredHTTPRoute(key, name, namespace, allowedRoutes)
+	routeTypeNsNameList, _ := validateReferredL7Route(key, lib.HTTPRoute, name, namespace, allowedRoutes)
+	grpcRouteTypeNsNameList, _ := validateReferredL7Route(key, lib.GRPCRoute, name, namespace, allowedRoutes)
+	routeTypeNsNameList = append(routeTypeNsNameList, grpcRouteTypeNsNameList...)
+	if akogatewayapilib.IsExperimentalRoutesEnabled() {
+		for _, routeType := range []string{lib.TCPRoute, lib.UDPRoute, lib.TLSRoute} {
+			l4RouteTypeNsNameList, _ := validateReferredL4Route(key, routeType, name, namespace, allowedRoutes)
+			routeTypeNsNameList = append(routeTypeNsNameList, l4RouteTypeNsNameList...)
+		}
+	}
 	return routeTypeNsNameList, true
 }
 
@@ -273,13 +318,41 @@ func HTTPRouteToGateway(namespace, name, key string) ([]string, bool) {
 		}
 		return gwNsNameList, true
 	}
+	gwNsNameList := l7RouteToGateways(hrObj, namespace, key)
+	utils.AviLog.Debugf("key: %s, msg: Gateways retrieved %s", key, gwNsNameList)
+	return gwNsNameList, true
+}
+
+func GRPCRouteToGateway(namespace, name, key string) ([]string, bool) {
+	routeTypeNsName := lib.GRPCRoute + "/" + namespace + "/" + name
+	grObj, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GRPCRouteInformer.Lister().GRPCRoutes(namespace).Get(name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			utils.AviLog.Errorf("key: %s, msg: got error while getting grpcroute: %v", key, err)
+			return []string{}, false
+		}
+		found, gwNsNameList := akogatewayapiobjects.GatewayApiLister().GetRouteToGateway(routeTypeNsName)
+		if !found {
+			return []string{}, true
+		}
+		return gwNsNameList, true
+	}
+	gwNsNameList := l7RouteToGateways(grObj, namespace, key)
+	utils.AviLog.Debugf("key: %s, msg: Gateways retrieved %s", key, gwNsNameList)
+	return gwNsNameList, true
+}
+
+// l7RouteToGateways maps the HTTPRoute or GRPCRoute to the listeners of the parent gateways, which have accepted the route.
+func l7RouteToGateways(route l7RouteObject, namespace, key string) []string {
+	routeType, spec, _ := getL7RouteSpec(route)
+	routeTypeNsName := routeType + "/" + route.GetNamespace() + "/" + route.GetName()
 	var gwNsNameList []string
 	parentNameToHostnameMap := make(map[string][]string)
 	gatewayToListenersMap := make(map[string][]akogatewayapiobjects.GatewayListenerStore)
 	statusIndex := 0
 	httpRouteStatus := akogatewayapiobjects.GatewayApiLister().GetRouteToRouteStatusMapping(routeTypeNsName)
 outerLoop:
-	for _, parentRef := range hrObj.Spec.ParentRefs {
+	for _, parentRef := range spec.ParentRefs {
 		if statusIndex >= len(httpRouteStatus.Parents) {
 			break
 		}
@@ -317,11 +390,10 @@ outerLoop:
 			utils.AviLog.Errorf("key: %s, msg: Error in fetching gateway details %s/%s. Error: %v", key, ns, parentRef.Name, err.Error())
 			continue
 		}
-		parentRefGatewayMappings(parentRef, parentNameToHostnameMap, gatewayToListenersMap, &gwNsNameList, hrObj, namespace, key)
+		parentRefGatewayMappings(parentRef, parentNameToHostnameMap, gatewayToListenersMap, &gwNsNameList, route, namespace, key)
 		statusIndex += 1
 	}
-	utils.AviLog.Debugf("key: %s, msg: Gateways retrieved %s", key, gwNsNameList)
-	return gwNsNameList, true
+	return gwNsNameList
 }
 
 func HTTPRouteChanges(namespace, name, key string) ([]string, bool) {
@@ -380,6 +452,18 @@ func HTTPRouteChanges(namespace, name, key string) ([]string, bool) {
 			}
 		}
 		for _, filter := range rule.Filters {
+			// mirror backends are tracked as services of the route, so that endpoint changes update the clone servers
+			if filter.Type == gatewayv1.HTTPRouteFilterRequestMirror && filter.RequestMirror != nil {
+				ns := namespace
+				if filter.RequestMirror.BackendRef.Namespace != nil {
+					ns = string(*filter.RequestMirror.BackendRef.Namespace)
+				}
+				svcNsName := ns + "/" + string(filter.RequestMirror.BackendRef.Name)
+				if !utils.HasElem(svcNsNameList, svcNsName) {
+					svcNsNameList = append(svcNsNameList, svcNsName)
+				}
+				continue
+			}
 			// Do we need to check first condition??
 			if filter.Type == gatewayv1.HTTPRouteFilterExtensionRef && filter.ExtensionRef != nil {
 				ns := namespace + "/" + string(filter.ExtensionRef.Name)
@@ -509,6 +593,50 @@ func HTTPRouteChanges(namespace, name, key string) ([]string, bool) {
 	return []string{routeTypeNsName}, true
 }
 
+func GRPCRouteChanges(namespace, name, key string) ([]string, bool) {
+	routeTypeNsName := lib.GRPCRoute + "/" + namespace + "/" + name
+	grObj, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GRPCRouteInformer.Lister().GRPCRoutes(namespace).Get(name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			utils.AviLog.Errorf("key: %s, msg: got error while getting grpcroute: %v", key, err)
+			return []string{}, false
+		}
+		// grpcroute must be deleted so remove mappings
+		akogatewayapiobjects.GatewayApiLister().DeleteRouteFromStore(routeTypeNsName, key)
+		return []string{routeTypeNsName}, true
+	}
+
+	var gwNsNameList []string
+	for _, parentRef := range grObj.Spec.ParentRefs {
+		ns := namespace
+		if parentRef.Namespace != nil {
+			ns = string(*parentRef.Namespace)
+		}
+		gwNsName := ns + "/" + string(parentRef.Name)
+		if !utils.HasElem(gwNsNameList, gwNsName) {
+			gwNsNameList = append(gwNsNameList, gwNsName)
+		}
+	}
+
+	var svcNsNameList []string
+	for _, rule := range grObj.Spec.Rules {
+		for _, backendRef := range rule.BackendRefs {
+			ns := namespace
+			if backendRef.Namespace != nil {
+				ns = string(*backendRef.Namespace)
+			}
+			svcNsName := ns + "/" + string(backendRef.Name)
+			if !utils.HasElem(svcNsNameList, svcNsName) {
+				svcNsNameList = append(svcNsNameList, svcNsName)
+			}
+		}
+	}
+	updateRouteGatewayServiceMappings(routeTypeNsName, gwNsNameList, svcNsNameList, key)
+
+	utils.AviLog.Debugf("key: %s, msg: GRPCRoutes retrieved %s", key, []string{routeTypeNsName})
+	return []string{routeTypeNsName}, true
+}
+
 func ServiceToGateways(namespace, name, key string) ([]string, bool) {
 	svcNsName := namespace + "/" + name
 	found, gwNsNameList := akogatewayapiobjects.GatewayApiLister().GetServiceToGateway(svcNsName)
@@ -519,6 +647,32 @@ func ServiceToGateways(namespace, name, key string) ([]string, bool) {
 	return gwNsNameList, found
 }
 
+func BackendTLSPolicyToGateways(namespace, name, key string) ([]string, bool) {
+	gwNsNames := sets.NewString()
+	for _, svcNsName := range getBackendTLSPolicyServices(namespace, name, key) {
+		if found, gateways := akogatewayapiobjects.GatewayApiLister().GetServiceToGateway(svcNsName); found {
+			gwNsNames.Insert(gateways...)
+		}
+	}
+	utils.AviLog.Debugf("key: %s, msg: Gateways retrieved %s", key, gwNsNames.List())
+	return gwNsNames.List(), true
+}
+
+func BackendTLSPolicyToRoutes(namespace, name, key string) ([]string, bool) {
+	// the services are fetched before processing, so that the routes of the services
+	// to which the policy is no longer applied are processed as well
+	svcNsNames := getBackendTLSPolicyServices(namespace, name, key)
+	processBackendTLSPolicy(namespace, name, key)
+	routeTypeNsNames := sets.NewString()
+	for _, svcNsName := range svcNsNames {
+		if found, routes := akogatewayapiobjects.GatewayApiLister().GetServiceToRoute(svcNsName); found {
+			routeTypeNsNames.Insert(routes...)
+		}
+	}
+	utils.AviLog.Debugf("key: %s, msg: Routes retrieved %s", key, routeTypeNsNames.List())
+	return routeTypeNsNames.List(), true
+}
+
 func ServiceToRoutes(namespace, name, key string) ([]string, bool) {
 	svcNsName := namespace + "/" + name
 	found, routeTypeNsNameList := akogatewayapiobjects.GatewayApiLister().GetServiceToRoute(svcNsName)
@@ -559,6 +713,22 @@ func EndpointSlicesToRoutes(namespace, name, key string) ([]string, bool) {
 	return ServiceToRoutes(namespace, name, key)
 }
 
+// ServiceImportToGateways returns the Gateways of the routes referring the ServiceImport as a backend, which
+// are tracked along with the Service backends of the routes.
+func ServiceImportToGateways(namespace, name, key string) ([]string, bool) {
+	return ServiceToGateways(namespace, name, key)
+}
+
+func ServiceImportToRoutes(namespace, name, key string) ([]string, bool) {
+	siNsName := namespace + "/" + name
+	found, routeTypeNsNameList := akogatewayapiobjects.GatewayApiLister().GetServiceToRoute(siNsName)
+	if !found {
+		return []string{}, true
+	}
+	utils.AviLog.Debugf("key: %s, msg: Routes retrieved %s", key, routeTypeNsNameList)
+	return routeTypeNsNameList, found
+}
+
 func SecretToGateways(namespace, name, key string) ([]string, bool) {
 	secretNsName := namespace + "/" + name
 	found, gwNsNameList := akogatewayapiobjects.GatewayApiLister().GetSecretToGateway(secretNsName)
@@ -681,10 +851,11 @@ func parentRefGatewayMappings(parentRef gatewayv1.ParentReference,
 	parentNameToHostnameMap map[string][]string,
 	gatewayToListenersMap map[string][]akogatewayapiobjects.GatewayListenerStore,
 	gwNsNameList *[]string,
-	hrObj *gatewayv1.HTTPRoute,
+	route l7RouteObject,
 	namespace, key string) {
-	routeTypeNsName := lib.HTTPRoute + "/" + hrObj.Namespace + "/" + hrObj.Name
-	httpGroupKind := akogatewayapiobjects.GatewayRouteKind{Group: akogatewayapilib.GatewayGroup, Kind: lib.HTTPRoute}
+	routeType, _, hostnames := getL7RouteSpec(route)
+	routeTypeNsName := routeType + "/" + route.GetNamespace() + "/" + route.GetName()
+	routeGroupKind := akogatewayapiobjects.GatewayRouteKind{Group: akogatewayapilib.GatewayGroup, Kind: routeType}
 	hostnameIntersection, _ := parentNameToHostnameMap[string(parentRef.Name)]
 	ns := namespace
 	if parentRef.Namespace != nil {
@@ -700,8 +871,8 @@ func parentRefGatewayMappings(parentRef gatewayv1.ParentReference,
 	for _, listener := range listeners {
 		//check if namespace is allowed
 		// TODO: akshay: add selector condition here.
-		if (len(listener.AllowedRouteTypes) == 0 || utils.HasElem(listener.AllowedRouteTypes, httpGroupKind)) &&
-			(listener.AllowedRouteNs == akogatewayapilib.AllowedRoutesNamespaceFromAll || listener.AllowedRouteNs == hrObj.Namespace) {
+		if (len(listener.AllowedRouteTypes) == 0 || utils.HasElem(listener.AllowedRouteTypes, routeGroupKind)) &&
+			(listener.AllowedRouteNs == akogatewayapilib.AllowedRoutesNamespaceFromAll || listener.AllowedRouteNs == route.GetNamespace()) {
 			//if provided, check if section name and port matches
 			if (parentRef.SectionName == nil || string(*parentRef.SectionName) == listener.Name) &&
 				(parentRef.Port == nil || int32(*parentRef.Port) == listener.Port) {
@@ -710,7 +881,7 @@ func parentRefGatewayMappings(parentRef gatewayv1.ParentReference,
 				listenerHostname := akogatewayapiobjects.GatewayApiLister().GetGatewayListenerToHostname(gwListenerNsName)
 
 				hostnameMatched := false
-				for _, routeHostname := range hrObj.Spec.Hostnames {
+				for _, routeHostname := range hostnames {
 					// When Gateway hostname is empty, then just check validity of hostname and append it.
 					// When hostname in HTTProute has wildcard
 					// When there is exact match
@@ -723,11 +894,11 @@ func parentRefGatewayMappings(parentRef gatewayv1.ParentReference,
 				}
 				// If no hostname in HTTPRoute and listener hostname is not empty, include listener hostname
 				// into list of mapped hostname between httproute and gateway (empty hostname at route and gateway)
-				if len(hrObj.Spec.Hostnames) == 0 && (listenerHostname != "" && listenerHostname != "*") {
+				if len(hostnames) == 0 && (listenerHostname != "" && listenerHostname != "*") {
 					hostnameIntersection = append(hostnameIntersection, string(listenerHostname))
 				}
 
-				if (hostnameMatched && !utils.HasElem(gatewayListenerList, listener)) || (len(hrObj.Spec.Hostnames) == 0 && (listenerHostname != "" && listenerHostname != "*")) {
+				if (hostnameMatched && !utils.HasElem(gatewayListenerList, listener)) || (len(hostnames) == 0 && (listenerHostname != "" && listenerHostname != "*")) {
 					gatewayListenerList = append(gatewayListenerList, listener)
 				}
 			}
@@ -756,20 +927,41 @@ func parentRefGatewayMappings(parentRef gatewayv1.ParentReference,
 	parentNameToHostnameMap[string(parentRef.Name)] = hostnameIntersection
 }
 
-func validateReferredHTTPRoute(key, name, namespace string, allowedRoutesAll bool) ([]string, error) {
+// validateReferredL7Route re-validates the HTTPRoutes or GRPCRoutes referring the gateway and maps the
+// valid ones to the gateway listeners.
+func validateReferredL7Route(key, routeType, name, namespace string, allowedRoutesAll bool) ([]string, error) {
 	ns := namespace
 	if allowedRoutesAll {
 		ns = metav1.NamespaceAll
 	}
-	hrObjs, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().HTTPRouteInformer.Lister().HTTPRoutes(ns).List(labels.Set(nil).AsSelector())
-	if err != nil {
-		return nil, err
+	var routeObjs []l7RouteObject
+	switch routeType {
+	case lib.HTTPRoute:
+		hrObjs, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().HTTPRouteInformer.Lister().HTTPRoutes(ns).List(labels.Set(nil).AsSelector())
+		if err != nil {
+			return nil, err
+		}
+		for _, hrObj := range hrObjs {
+			routeObjs = append(routeObjs, hrObj)
+		}
+	case lib.GRPCRoute:
+		if akogatewayapilib.AKOControlConfig().GatewayApiInformers().GRPCRouteInformer == nil {
+			return nil, nil
+		}
+		grObjs, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GRPCRouteInformer.Lister().GRPCRoutes(ns).List(labels.Set(nil).AsSelector())
+		if err != nil {
+			return nil, err
+		}
+		for _, grObj := range grObjs {
+			routeObjs = append(routeObjs, grObj)
+		}
 	}
-	httpRoutes := make([]*gatewayv1.HTTPRoute, 0)
-	for _, httpRoute := range hrObjs {
-		httpRouteStatus := httpRoute.Status.DeepCopy()
-		httpRouteStatus.Parents = make([]gatewayv1.RouteParentStatus, 0, len(httpRoute.Spec.ParentRefs))
-		routeTypeNsName := lib.HTTPRoute + "/" + httpRoute.Namespace + "/" + httpRoute.Name
+	l7Routes := make([]l7RouteObject, 0)
+	for _, route := range routeObjs {
+		_, spec, _ := getL7RouteSpec(route)
+		httpRouteStatus := getL7RouteStatus(route)
+		httpRouteStatus.Parents = make([]gatewayv1.RouteParentStatus, 0, len(spec.ParentRefs))
+		routeTypeNsName := routeType + "/" + route.GetNamespace() + "/" + route.GetName()
 		httpRouteStatusInCache := akogatewayapiobjects.GatewayApiLister().GetRouteToRouteStatusMapping(routeTypeNsName)
 		if httpRouteStatusInCache == nil {
 			continue
@@ -777,28 +969,28 @@ func validateReferredHTTPRoute(key, name, namespace string, allowedRoutesAll boo
 		parentRefIndexInHttpRouteStatus := 0
 		indexInCache := 0
 		appendRoute := false
-		for parentRefIndexFromSpec, parentRef := range httpRoute.Spec.ParentRefs {
-			matchNamespace := httpRoute.Namespace
+		for parentRefIndexFromSpec, parentRef := range spec.ParentRefs {
+			matchNamespace := route.GetNamespace()
 			if parentRef.Namespace != nil {
 				matchNamespace = string(*parentRef.Namespace)
 			}
 			if (parentRef.Name == gatewayv1.ObjectName(name)) && (matchNamespace == namespace) {
-				isValidHttprouteRules := validateHTTPRouteRules(key, httpRoute, httpRouteStatus)
-				if isValidHttprouteRules {
-					err := validateParentReference(key, httpRoute, httpRouteStatus, parentRefIndexFromSpec, &parentRefIndexInHttpRouteStatus, &indexInCache)
+				isValidRouteRules := validateL7RouteRules(key, route, httpRouteStatus)
+				if isValidRouteRules {
+					err := validateParentReference(key, route, httpRouteStatus, parentRefIndexFromSpec, &parentRefIndexInHttpRouteStatus, &indexInCache)
 					if err != nil {
 						parentRefName := parentRef.Name
-						utils.AviLog.Warnf("key: %s, msg: Parent Reference %s of HTTPRoute object %s is not valid, err: %v", key, parentRefName, httpRoute.Name, err)
+						utils.AviLog.Warnf("key: %s, msg: Parent Reference %s of %s object %s is not valid, err: %v", key, parentRefName, routeType, route.GetName(), err)
 					} else {
 						appendRoute = true
 					}
 				} else {
-					utils.AviLog.Warnf("key: %s, msg: HTTPUrlRewrite PathType has Unsupported value.", key)
+					utils.AviLog.Warnf("key: %s, msg: rules of %s object %s are not valid.", key, routeType, route.GetName())
 					appendRoute = false
 				}
 			} else {
 				gwName := parentRef.Name
-				namespace := httpRoute.Namespace
+				namespace := route.GetNamespace()
 				if parentRef.Namespace != nil {
 					namespace = string(*parentRef.Namespace)
 				}
@@ -810,41 +1002,42 @@ func validateReferredHTTPRoute(key, name, namespace string, allowedRoutesAll boo
 				gwClass := string(gateway.Spec.GatewayClassName)
 				_, isAKOCtrl := akogatewayapiobjects.GatewayApiLister().IsGatewayClassControllerAKO(gwClass)
 				if !isAKOCtrl {
-					utils.AviLog.Warnf("key: %s, msg: controller for the parent reference %s of HTTPRoute object %s is not ako", key, name, httpRoute.Name)
+					utils.AviLog.Warnf("key: %s, msg: controller for the parent reference %s of %s object %s is not ako", key, name, routeType, route.GetName())
 				} else {
 					httpRouteStatus.Parents = append(httpRouteStatus.Parents, httpRouteStatusInCache.Parents[indexInCache])
 				}
 			}
 		}
 
-		akogatewayapistatus.Record(key, httpRoute, &status.Status{HTTPRouteStatus: httpRouteStatus})
+		akogatewayapistatus.Record(key, route, &status.Status{HTTPRouteStatus: httpRouteStatus})
 		if appendRoute {
-			httpRoutes = append(httpRoutes, httpRoute)
+			l7Routes = append(l7Routes, route)
 		}
 	}
-	sort.Slice(httpRoutes, func(i, j int) bool {
-		if httpRoutes[i].GetCreationTimestamp().Unix() == httpRoutes[j].GetCreationTimestamp().Unix() {
-			return httpRoutes[i].Namespace+"/"+httpRoutes[i].Name < httpRoutes[j].Namespace+"/"+httpRoutes[j].Name
+	sort.Slice(l7Routes, func(i, j int) bool {
+		if l7Routes[i].GetCreationTimestamp().Unix() == l7Routes[j].GetCreationTimestamp().Unix() {
+			return l7Routes[i].GetNamespace()+"/"+l7Routes[i].GetName() < l7Routes[j].GetNamespace()+"/"+l7Routes[j].GetName()
 		}
-		return httpRoutes[i].GetCreationTimestamp().Unix() < httpRoutes[j].GetCreationTimestamp().Unix()
+		return l7Routes[i].GetCreationTimestamp().Unix() < l7Routes[j].GetCreationTimestamp().Unix()
 	})
 	var routes []string
-	for _, httpRoute := range httpRoutes {
-		httpRouteToGatewayOperation(httpRoute, key, name, namespace)
-		routeTypeNsName := lib.HTTPRoute + "/" + httpRoute.Namespace + "/" + httpRoute.Name
+	for _, route := range l7Routes {
+		l7RouteToGatewayOperation(route, key, name, namespace)
+		routeTypeNsName := routeType + "/" + route.GetNamespace() + "/" + route.GetName()
 		routes = append(routes, routeTypeNsName)
 	}
 	return routes, nil
 }
 
-func httpRouteToGatewayOperation(hrObj *gatewayv1.HTTPRoute, key, gwName, gwNamespace string) {
-	routeTypeNsName := lib.HTTPRoute + "/" + hrObj.Namespace + "/" + hrObj.Name
+func l7RouteToGatewayOperation(route l7RouteObject, key, gwName, gwNamespace string) {
+	routeType, spec, _ := getL7RouteSpec(route)
+	routeTypeNsName := routeType + "/" + route.GetNamespace() + "/" + route.GetName()
 	var gwNsNameList []string
 	parentNameToHostnameMap := make(map[string][]string)
 	gatewayToListenersMap := make(map[string][]akogatewayapiobjects.GatewayListenerStore)
 	statusIndex := 0
 	httpRouteStatus := akogatewayapiobjects.GatewayApiLister().GetRouteToRouteStatusMapping(routeTypeNsName)
-	for _, parentRef := range hrObj.Spec.ParentRefs {
+	for _, parentRef := range spec.ParentRefs {
 		if statusIndex >= len(httpRouteStatus.Parents) {
 			break
 		}
@@ -863,8 +1056,290 @@ func httpRouteToGatewayOperation(hrObj *gatewayv1.HTTPRoute, key, gwName, gwName
 			statusIndex += 1
 			continue
 		}
-		parentRefGatewayMappings(parentRef, parentNameToHostnameMap, gatewayToListenersMap, &gwNsNameList, hrObj, gwNamespace, key)
+		parentRefGatewayMappings(parentRef, parentNameToHostnameMap, gatewayToListenersMap, &gwNsNameList, route, gwNamespace, key)
 		statusIndex += 1
 	}
 	utils.AviLog.Debugf("key: %s, msg: Gateways retrieved %s", key, gwNsNameList)
 }
+
+func TCPRouteToGateway(namespace, name, key string) ([]string, bool) {
+	return l4RouteToGateway(lib.TCPRoute, namespace, name, key)
+}
+
+func UDPRouteToGateway(namespace, name, key string) ([]string, bool) {
+	return l4RouteToGateway(lib.UDPRoute, namespace, name, key)
+}
+
+func TLSRouteToGateway(namespace, name, key string) ([]string, bool) {
+	return l4RouteToGateway(lib.TLSRoute, namespace, name, key)
+}
+
+func TCPRouteChanges(namespace, name, key string) ([]string, bool) {
+	return l4RouteChanges(lib.TCPRoute, namespace, name, key)
+}
+
+func UDPRouteChanges(namespace, name, key string) ([]string, bool) {
+	return l4RouteChanges(lib.UDPRoute, namespace, name, key)
+}
+
+func TLSRouteChanges(namespace, name, key string) ([]string, bool) {
+	return l4RouteChanges(lib.TLSRoute, namespace, name, key)
+}
+
+func l4RouteToGateway(routeType, namespace, name, key string) ([]string, bool) {
+	routeTypeNsName := routeType + "/" + namespace + "/" + name
+	route, err := getL4RouteObject(routeType, namespace, name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			utils.AviLog.Errorf("key: %s, msg: got error while getting %s: %v", key, routeType, err)
+			return []string{}, false
+		}
+		found, gwNsNameList := akogatewayapiobjects.GatewayApiLister().GetRouteToGateway(routeTypeNsName)
+		if !found {
+			return []string{}, true
+		}
+		return gwNsNameList, true
+	}
+	gwNsNameList := l4RouteToGatewayOperation(route, routeType, key, "", "")
+	utils.AviLog.Debugf("key: %s, msg: Gateways retrieved %s", key, gwNsNameList)
+	return gwNsNameList, true
+}
+
+// l4RouteToGatewayOperation maps the TCPRoute, UDPRoute or TLSRoute to the listeners of the accepted parent gateways.
+// When gwName is provided, only the parent references to that gateway are mapped.
+func l4RouteToGatewayOperation(route l4RouteObject, routeType, key, gwName, gwNamespace string) []string {
+	routeTypeNsName := routeType + "/" + route.GetNamespace() + "/" + route.GetName()
+	routeGroupKind := akogatewayapiobjects.GatewayRouteKind{Group: akogatewayapilib.GatewayGroup, Kind: routeType}
+	routeStatus := akogatewayapiobjects.GatewayApiLister().GetRouteToRouteStatusMapping(routeTypeNsName)
+	if routeStatus == nil {
+		return nil
+	}
+	spec, _ := getL4RouteSpecAndStatus(route)
+	gatewayToListenersMap := make(map[string][]akogatewayapiobjects.GatewayListenerStore)
+	var gwNsNameList []string
+	for _, parentRef := range spec.ParentRefs {
+		ns := route.GetNamespace()
+		if parentRef.Namespace != nil {
+			ns = string(*parentRef.Namespace)
+		}
+		if gwName != "" && (string(parentRef.Name) != gwName || ns != gwNamespace) {
+			continue
+		}
+		if !isL4ParentRefAccepted(parentRef, ns, routeStatus) {
+			continue
+		}
+		gwNsName := ns + "/" + string(parentRef.Name)
+		listeners := akogatewayapiobjects.GatewayApiLister().GetGatewayToListeners(gwNsName)
+		for _, listener := range listeners {
+			if !utils.HasElem(listener.AllowedRouteTypes, routeGroupKind) ||
+				(listener.AllowedRouteNs != akogatewayapilib.AllowedRoutesNamespaceFromAll && listener.AllowedRouteNs != route.GetNamespace()) {
+				continue
+			}
+			if (parentRef.SectionName == nil || string(*parentRef.SectionName) == listener.Name) &&
+				(parentRef.Port == nil || int32(*parentRef.Port) == listener.Port) &&
+				!utils.HasElem(gatewayToListenersMap[gwNsName], listener) {
+				gatewayToListenersMap[gwNsName] = append(gatewayToListenersMap[gwNsName], listener)
+			}
+		}
+		if !utils.HasElem(gwNsNameList, gwNsName) {
+			gwNsNameList = append(gwNsNameList, gwNsName)
+		}
+	}
+	for _, gwNsName := range gwNsNameList {
+		akogatewayapiobjects.GatewayApiLister().UpdateGatewayRouteMappings(gwNsName, routeTypeNsName)
+		akogatewayapiobjects.GatewayApiLister().UpdateRouteToGatewayListenerMappings(gatewayToListenersMap[gwNsName], routeTypeNsName, gwNsName)
+		utils.AviLog.Infof("key: %s, msg: Routes mapped to Gateway [%v] are : [%v]", key, gwNsName, routeTypeNsName)
+	}
+	return gwNsNameList
+}
+
+// isL4ParentRefAccepted checks the Accepted condition of the parent reference in the route status.
+func isL4ParentRefAccepted(parentRef gatewayv1.ParentReference, namespace string, routeStatus *gatewayv1.HTTPRouteStatus) bool {
+	for _, parentStatus := range routeStatus.Parents {
+		if parentStatus.ParentRef.Name != parentRef.Name ||
+			(parentStatus.ParentRef.Namespace != nil && string(*parentStatus.ParentRef.Namespace) != namespace) ||
+			!reflect.DeepEqual(parentStatus.ParentRef.SectionName, parentRef.SectionName) ||
+			!reflect.DeepEqual(parentStatus.ParentRef.Port, parentRef.Port) {
+			continue
+		}
+		for _, condition := range parentStatus.Conditions {
+			if condition.Type == string(gatewayv1.RouteConditionAccepted) {
+				return condition.Status == metav1.ConditionTrue
+			}
+		}
+	}
+	return false
+}
+
+func l4RouteChanges(routeType, namespace, name, key string) ([]string, bool) {
+	routeTypeNsName := routeType + "/" + namespace + "/" + name
+	route, err := getL4RouteObject(routeType, namespace, name)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			utils.AviLog.Errorf("key: %s, msg: got error while getting %s: %v", key, routeType, err)
+			return []string{}, false
+		}
+		// route must be deleted so remove mappings
+		akogatewayapiobjects.GatewayApiLister().DeleteRouteFromStore(routeTypeNsName, key)
+		return []string{routeTypeNsName}, true
+	}
+
+	routeModel := &l4Route{key: key, name: name, namespace: namespace, routeType: routeType}
+	routeModel.spec, _ = getL4RouteSpecAndStatus(route)
+	routeModel.rules = getL4RouteRules(route)
+
+	var gwNsNameList []string
+	for _, parentRef := range routeModel.spec.ParentRefs {
+		ns := namespace
+		if parentRef.Namespace != nil {
+			ns = string(*parentRef.Namespace)
+		}
+		gwNsName := ns + "/" + string(parentRef.Name)
+		if !utils.HasElem(gwNsNameList, gwNsName) {
+			gwNsNameList = append(gwNsNameList, gwNsName)
+		}
+	}
+
+	var svcNsNameList []string
+	for _, rule := range routeModel.rules {
+		for _, backendRef := range rule.backendRefs {
+			ns := namespace
+			if backendRef.Namespace != nil {
+				ns = string(*backendRef.Namespace)
+			}
+			svcNsName := ns + "/" + string(backendRef.Name)
+			if !utils.HasElem(svcNsNameList, svcNsName) {
+				svcNsNameList = append(svcNsNameList, svcNsName)
+			}
+		}
+	}
+
+	updateRouteGatewayServiceMappings(routeTypeNsName, gwNsNameList, svcNsNameList, key)
+
+	utils.AviLog.Debugf("key: %s, msg: %ss retrieved %s", key, routeType, []string{routeTypeNsName})
+	return []string{routeTypeNsName}, true
+}
+
+// updateRouteGatewayServiceMappings updates the route <-> gateway, route <-> service and gateway <-> service
+// mappings of the routes, which do not refer any AKO CRDs.
+func updateRouteGatewayServiceMappings(routeTypeNsName string, gwNsNameList, svcNsNameList []string, key string) {
+	// deletes the services, which are removed, from the gateway <-> service and route <-> service mappings
+	found, oldSvcs := akogatewayapiobjects.GatewayApiLister().GetRouteToService(routeTypeNsName)
+	if found {
+		for _, svcNsName := range oldSvcs {
+			if !utils.HasElem(svcNsNameList, svcNsName) {
+				akogatewayapiobjects.GatewayApiLister().DeleteRouteToServiceMappings(routeTypeNsName, svcNsName, key)
+			}
+		}
+	}
+
+	found, oldGateways := akogatewayapiobjects.GatewayApiLister().GetRouteToGateway(routeTypeNsName)
+	if found {
+		for _, gwNsName := range oldGateways {
+			if !utils.HasElem(gwNsNameList, gwNsName) {
+				akogatewayapiobjects.GatewayApiLister().DeleteRouteToGatewayMappings(routeTypeNsName, gwNsName)
+			}
+		}
+	}
+
+	// updates route <-> service mappings with new services
+	for _, svcNsName := range svcNsNameList {
+		akogatewayapiobjects.GatewayApiLister().UpdateRouteServiceMappings(routeTypeNsName, svcNsName, key)
+	}
+
+	// updates gateway <-> service mappings with new services
+	for _, gwNsName := range gwNsNameList {
+		for _, svcNsName := range svcNsNameList {
+			akogatewayapiobjects.GatewayApiLister().UpdateGatewayServiceMappings(gwNsName, svcNsName)
+		}
+	}
+
+	for _, gwNsName := range oldGateways {
+		if utils.HasElem(gwNsNameList, gwNsName) {
+			continue
+		}
+		for _, svcNsName := range oldSvcs {
+			if utils.HasElem(svcNsNameList, svcNsName) {
+				continue
+			}
+			akogatewayapiobjects.GatewayApiLister().DeleteGatewayServiceMappings(gwNsName, svcNsName)
+		}
+	}
+}
+
+// validateReferredL4Route re-validates the TCPRoutes, UDPRoutes or TLSRoutes referring the gateway and maps the
+// valid ones to the gateway listeners.
+func validateReferredL4Route(key, routeType, name, namespace string, allowedRoutesAll bool) ([]string, error) {
+	ns := namespace
+	if allowedRoutesAll {
+		ns = metav1.NamespaceAll
+	}
+	var routeObjs []l4RouteObject
+	informers := akogatewayapilib.AKOControlConfig().GatewayApiInformers()
+	switch routeType {
+	case lib.TCPRoute:
+		if informers.TCPRouteInformer == nil {
+			return nil, nil
+		}
+		tcpRoutes, err := informers.TCPRouteInformer.Lister().TCPRoutes(ns).List(labels.Set(nil).AsSelector())
+		if err != nil {
+			return nil, err
+		}
+		for _, tcpRoute := range tcpRoutes {
+			routeObjs = append(routeObjs, tcpRoute)
+		}
+	case lib.UDPRoute:
+		if informers.UDPRouteInformer == nil {
+			return nil, nil
+		}
+		udpRoutes, err := informers.UDPRouteInformer.Lister().UDPRoutes(ns).List(labels.Set(nil).AsSelector())
+		if err != nil {
+			return nil, err
+		}
+		for _, udpRoute := range udpRoutes {
+			routeObjs = append(routeObjs, udpRoute)
+		}
+	case lib.TLSRoute:
+		if informers.TLSRouteInformer == nil {
+			return nil, nil
+		}
+		tlsRoutes, err := informers.TLSRouteInformer.Lister().TLSRoutes(ns).List(labels.Set(nil).AsSelector())
+		if err != nil {
+			return nil, err
+		}
+		for _, tlsRoute := range tlsRoutes {
+			routeObjs = append(routeObjs, tlsRoute)
+		}
+	}
+
+	var validRoutes []l4RouteObject
+	for _, route := range routeObjs {
+		spec, _ := getL4RouteSpecAndStatus(route)
+		refersGateway := false
+		for _, parentRef := range spec.ParentRefs {
+			parentNs := route.GetNamespace()
+			if parentRef.Namespace != nil {
+				parentNs = string(*parentRef.Namespace)
+			}
+			if string(parentRef.Name) == name && parentNs == namespace {
+				refersGateway = true
+				break
+			}
+		}
+		if refersGateway && IsL4RouteValid(key, routeType, route) {
+			validRoutes = append(validRoutes, route)
+		}
+	}
+	sort.Slice(validRoutes, func(i, j int) bool {
+		if validRoutes[i].GetCreationTimestamp().Unix() == validRoutes[j].GetCreationTimestamp().Unix() {
+			return validRoutes[i].GetNamespace()+"/"+validRoutes[i].GetName() < validRoutes[j].GetNamespace()+"/"+validRoutes[j].GetName()
+		}
+		return validRoutes[i].GetCreationTimestamp().Unix() < validRoutes[j].GetCreationTimestamp().Unix()
+	})
+	var routes []string
+	for _, route := range validRoutes {
+		l4RouteToGatewayOperation(route, routeType, key, name, namespace)
+		routes = append(routes, routeType+"/"+route.GetNamespace()+"/"+route.GetName())
+	}
+	return routes, nil
+}
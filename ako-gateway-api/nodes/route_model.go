@@ -16,16 +16,22 @@ package nodes
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	akogatewayapiobjects "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/objects"
 	akogatewayapistatus "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/status"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 )
 
 type RouteModel interface {
@@ -42,6 +48,10 @@ func NewRouteModel(key, objType, name, namespace string) (RouteModel, error) {
 	switch objType {
 	case lib.HTTPRoute:
 		return GetHTTPRouteModel(key, name, namespace)
+	case lib.GRPCRoute:
+		return GetGRPCRouteModel(key, name, namespace)
+	case lib.TCPRoute, lib.UDPRoute, lib.TLSRoute:
+		return GetL4RouteModel(key, objType, name, namespace)
 	}
 	return nil, fmt.Errorf("object of type %s not supported", objType)
 }
@@ -58,9 +68,19 @@ type PathMatch struct {
 	Type string
 }
 
+type QueryParamMatch struct {
+	Type  string
+	Name  string
+	Value string
+}
+
+// Match is serialized to derive the names of the Avi objects of a rule,
+// hence the newer fields are omitted when empty to retain the existing names.
 type Match struct {
-	PathMatch   *PathMatch
-	HeaderMatch []*HeaderMatch
+	PathMatch       *PathMatch
+	HeaderMatch     []*HeaderMatch
+	QueryParamMatch []*QueryParamMatch `json:",omitempty"`
+	Method          string             `json:",omitempty"`
 }
 
 type Matches []*Match
@@ -69,7 +89,13 @@ func (m Matches) Len() int      { return len(m) }
 func (m Matches) Swap(i, j int) { m[i], m[j] = m[j], m[i] }
 func (m Matches) Less(i, j int) bool {
 	if m[i].PathMatch != nil && m[j].PathMatch != nil {
-		return m[i].PathMatch.Path < m[j].PathMatch.Path // TODO: need to check this logic
+		if m[i].PathMatch.Path != m[j].PathMatch.Path {
+			return m[i].PathMatch.Path < m[j].PathMatch.Path // TODO: need to check this logic
+		}
+		if m[i].Method != m[j].Method {
+			return m[i].Method < m[j].Method
+		}
+		return utils.Stringify(m[i].QueryParamMatch) < utils.Stringify(m[j].QueryParamMatch)
 	}
 	return false
 }
@@ -94,6 +120,9 @@ type HeaderFilter struct {
 type RedirectFilter struct {
 	Host       string
 	StatusCode int32
+	Scheme     string
+	Port       int32
+	Path       *gatewayv1.HTTPPathModifier
 }
 
 type HTTPUrlRewriteFilter struct {
@@ -106,6 +135,11 @@ type ExtensionRefFilter struct {
 	Kind  string
 	Name  string
 }
+
+type RequestMirrorFilter struct {
+	Backend *Backend
+}
+
 type Filter struct {
 	Type             string
 	RequestFilter    *HeaderFilter
@@ -113,6 +147,8 @@ type Filter struct {
 	RedirectFilter   *RedirectFilter
 	UrlRewriteFilter *HTTPUrlRewriteFilter
 	ExtensionRef     *ExtensionRefFilter
+	RequestMirror    *RequestMirrorFilter
+	CORSFilter       *nodes.CORSPolicy
 }
 
 type Backend struct {
@@ -121,6 +157,7 @@ type Backend struct {
 	Port      int32
 	Weight    int32
 	Kind      string
+	Group     string
 }
 
 type HTTPBackend struct {
@@ -134,11 +171,14 @@ type Rule struct {
 	Filters            []*Filter
 	Backends           []*HTTPBackend
 	SessionPersistence *gatewayv1.SessionPersistence
+	Timeouts           *gatewayv1.HTTPRouteTimeouts
+	Retry              *gatewayv1.HTTPRouteRetry
 }
 
 type RouteConfig struct {
-	Rules []*Rule
-	Hosts []string
+	Rules          []*Rule
+	Hosts          []string
+	ClusterWeights map[string]int32
 }
 
 type httpRoute struct {
@@ -147,6 +187,7 @@ type httpRoute struct {
 	namespace   string
 	routeConfig *RouteConfig
 	spec        *gatewayv1.HTTPRouteSpec
+	annotations map[string]string
 }
 
 func GetHTTPRouteModel(key string, name, namespace string) (RouteModel, error) {
@@ -161,6 +202,7 @@ func GetHTTPRouteModel(key string, name, namespace string) (RouteModel, error) {
 		return hr, err
 	}
 	hr.spec = hrObj.Spec.DeepCopy()
+	hr.annotations = hrObj.GetAnnotations()
 	return hr, nil
 }
 
@@ -190,10 +232,16 @@ func (hr *httpRoute) ParseRouteConfig(key string) *RouteConfig {
 	for i := range hr.spec.Hostnames {
 		routeConfig.Hosts[i] = string(hr.spec.Hostnames[i])
 	}
+	routeConfig.ClusterWeights = getClusterWeights(key, hr.annotations)
 	var resolvedRefCondition, resolvedRefConditionRuleFilter, resolvedRefConditionRuleBackend akogatewayapistatus.Condition
 	routeConfig.Rules = make([]*Rule, 0, len(hr.spec.Rules))
 
-	for _, rule := range hr.spec.Rules {
+	// the invalid rules are dropped, the valid rules of the route are processed
+	invalidRules := akogatewayapiobjects.GatewayApiLister().GetRouteToInvalidRules(lib.HTTPRoute + "/" + hr.namespace + "/" + hr.name)
+	for i, rule := range hr.spec.Rules {
+		if _, ok := invalidRules[i]; ok {
+			continue
+		}
 		routeConfigRule := &Rule{}
 		routeConfigRule.Matches = make([]*Match, 0, len(rule.Matches))
 		for _, ruleMatch := range rule.Matches {
@@ -226,6 +274,24 @@ func (hr *httpRoute) ParseRouteConfig(key string) *RouteConfig {
 				match.HeaderMatch = append(match.HeaderMatch, headerMatch)
 			}
 
+			// query param match
+			for _, queryParam := range ruleMatch.QueryParams {
+				queryParamMatch := &QueryParamMatch{
+					Type:  akogatewayapilib.EXACT,
+					Name:  string(queryParam.Name),
+					Value: queryParam.Value,
+				}
+				if queryParam.Type != nil {
+					queryParamMatch.Type = string(*queryParam.Type)
+				}
+				match.QueryParamMatch = append(match.QueryParamMatch, queryParamMatch)
+			}
+
+			// method match
+			if ruleMatch.Method != nil {
+				match.Method = string(*ruleMatch.Method)
+			}
+
 			routeConfigRule.Matches = append(routeConfigRule.Matches, match)
 		}
 		sort.Sort((Matches)(routeConfigRule.Matches))
@@ -235,6 +301,12 @@ func (hr *httpRoute) ParseRouteConfig(key string) *RouteConfig {
 		if rule.SessionPersistence != nil {
 			routeConfigRule.SessionPersistence = rule.SessionPersistence.DeepCopy()
 		}
+		if rule.Timeouts != nil {
+			routeConfigRule.Timeouts = rule.Timeouts.DeepCopy()
+		}
+		if rule.Retry != nil {
+			routeConfigRule.Retry = rule.Retry.DeepCopy()
+		}
 		routeConfigRule.Filters = make([]*Filter, 0, len(rule.Filters))
 
 		// var hasInvalidFilter bool
@@ -244,56 +316,12 @@ func (hr *httpRoute) ParseRouteConfig(key string) *RouteConfig {
 
 			// request header filter
 			if ruleFilter.RequestHeaderModifier != nil {
-				filter.RequestFilter = &HeaderFilter{}
-				filter.RequestFilter.Add = make([]*Header, 0, len(ruleFilter.RequestHeaderModifier.Add))
-				for _, addFilter := range ruleFilter.RequestHeaderModifier.Add {
-					addHeader := &Header{
-						Name:  string(addFilter.Name),
-						Value: addFilter.Value,
-					}
-					filter.RequestFilter.Add = append(filter.RequestFilter.Add, addHeader)
-				}
-				filter.RequestFilter.Set = make([]*Header, 0, len(ruleFilter.RequestHeaderModifier.Set))
-				for _, setFilter := range ruleFilter.RequestHeaderModifier.Set {
-					setHeader := &Header{
-						Name:  string(setFilter.Name),
-						Value: setFilter.Value,
-					}
-					filter.RequestFilter.Set = append(filter.RequestFilter.Set, setHeader)
-				}
-				filter.RequestFilter.Remove = make([]string, len(ruleFilter.RequestHeaderModifier.Remove))
-				copy(filter.RequestFilter.Remove, ruleFilter.RequestHeaderModifier.Remove)
-
-				sort.Sort((Headers)(filter.RequestFilter.Add))
-				sort.Sort((Headers)(filter.RequestFilter.Set))
-				sort.Strings(filter.RequestFilter.Remove)
+				filter.RequestFilter = buildHeaderFilter(ruleFilter.RequestHeaderModifier)
 			}
 
 			// response header filter
 			if ruleFilter.ResponseHeaderModifier != nil {
-				filter.ResponseFilter = &HeaderFilter{}
-				filter.ResponseFilter.Add = make([]*Header, 0, len(ruleFilter.ResponseHeaderModifier.Add))
-				for _, addFilter := range ruleFilter.ResponseHeaderModifier.Add {
-					addHeader := &Header{
-						Name:  string(addFilter.Name),
-						Value: addFilter.Value,
-					}
-					filter.ResponseFilter.Add = append(filter.ResponseFilter.Add, addHeader)
-				}
-				filter.ResponseFilter.Set = make([]*Header, 0, len(ruleFilter.ResponseHeaderModifier.Set))
-				for _, setFilter := range ruleFilter.ResponseHeaderModifier.Set {
-					setHeader := &Header{
-						Name:  string(setFilter.Name),
-						Value: setFilter.Value,
-					}
-					filter.ResponseFilter.Set = append(filter.ResponseFilter.Set, setHeader)
-				}
-				filter.ResponseFilter.Remove = make([]string, len(ruleFilter.ResponseHeaderModifier.Remove))
-				copy(filter.ResponseFilter.Remove, ruleFilter.ResponseHeaderModifier.Remove)
-
-				sort.Sort((Headers)(filter.ResponseFilter.Add))
-				sort.Sort((Headers)(filter.ResponseFilter.Set))
-				sort.Strings(filter.ResponseFilter.Remove)
+				filter.ResponseFilter = buildHeaderFilter(ruleFilter.ResponseHeaderModifier)
 			}
 
 			// request redirect filter
@@ -305,6 +333,15 @@ func (hr *httpRoute) ParseRouteConfig(key string) *RouteConfig {
 				if ruleFilter.RequestRedirect.StatusCode != nil {
 					filter.RedirectFilter.StatusCode = int32(*ruleFilter.RequestRedirect.StatusCode)
 				}
+				if ruleFilter.RequestRedirect.Scheme != nil {
+					filter.RedirectFilter.Scheme = *ruleFilter.RequestRedirect.Scheme
+				}
+				if ruleFilter.RequestRedirect.Port != nil {
+					filter.RedirectFilter.Port = int32(*ruleFilter.RequestRedirect.Port)
+				}
+				if ruleFilter.RequestRedirect.Path != nil {
+					filter.RedirectFilter.Path = ruleFilter.RequestRedirect.Path.DeepCopy()
+				}
 			}
 
 			// URL rewrite filter
@@ -315,12 +352,42 @@ func (hr *httpRoute) ParseRouteConfig(key string) *RouteConfig {
 				}
 				if ruleFilter.URLRewrite.Path != nil {
 					filter.UrlRewriteFilter.path = ruleFilter.URLRewrite.Path.DeepCopy()
-					if strings.HasPrefix(*filter.UrlRewriteFilter.path.ReplaceFullPath, "/") {
+					if filter.UrlRewriteFilter.path.ReplaceFullPath != nil && strings.HasPrefix(*filter.UrlRewriteFilter.path.ReplaceFullPath, "/") {
 						*filter.UrlRewriteFilter.path.ReplaceFullPath = (*filter.UrlRewriteFilter.path.ReplaceFullPath)[1:]
 					}
 
 				}
 			}
+			// CORS filter
+			if ruleFilter.CORS != nil {
+				filter.CORSFilter = buildCORSPolicy(ruleFilter.CORS)
+			}
+			// request mirror filter
+			if ruleFilter.RequestMirror != nil {
+				mirrorBackend := &Backend{
+					Name:      string(ruleFilter.RequestMirror.BackendRef.Name),
+					Namespace: hr.namespace,
+					Weight:    1,
+				}
+				if ruleFilter.RequestMirror.BackendRef.Namespace != nil {
+					mirrorBackend.Namespace = string(*ruleFilter.RequestMirror.BackendRef.Namespace)
+				}
+				if ruleFilter.RequestMirror.BackendRef.Port != nil {
+					mirrorBackend.Port = int32(*ruleFilter.RequestMirror.BackendRef.Port)
+				}
+				if ruleFilter.RequestMirror.BackendRef.Kind != nil {
+					mirrorBackend.Kind = string(*ruleFilter.RequestMirror.BackendRef.Kind)
+				}
+				var isValid bool
+				isValid, resolvedRefConditionRuleFilter = validateBackendReference(key, lib.HTTPRoute, *mirrorBackend, nil, hr.namespace)
+				if !isValid {
+					if resolvedRefConditionRuleFilter != nil {
+						resolvedRefCondition = resolvedRefConditionRuleFilter
+					}
+					continue
+				}
+				filter.RequestMirror = &RequestMirrorFilter{Backend: mirrorBackend}
+			}
 			// ExtensionRef filters
 			if ruleFilter.ExtensionRef != nil {
 				filter.ExtensionRef = &ExtensionRefFilter{}
@@ -356,6 +423,9 @@ func (hr *httpRoute) ParseRouteConfig(key string) *RouteConfig {
 			if ruleBackend.BackendRef.Kind != nil {
 				backend.Kind = string(*ruleBackend.Kind)
 			}
+			if ruleBackend.BackendRef.Group != nil {
+				backend.Group = string(*ruleBackend.Group)
+			}
 			backend.Weight = 1
 			if ruleBackend.Weight != nil {
 				backend.Weight = *ruleBackend.Weight
@@ -375,7 +445,7 @@ func (hr *httpRoute) ParseRouteConfig(key string) *RouteConfig {
 				}
 			}
 			isValidBackend := false
-			isValidBackend, resolvedRefConditionRuleBackend = validateBackendReference(key, *backend, httpBackend.Filters, hr.namespace)
+			isValidBackend, resolvedRefConditionRuleBackend = validateBackendReference(key, lib.HTTPRoute, *backend, httpBackend.Filters, hr.namespace)
 			if isValidBackend {
 				routeConfigRule.Backends = append(routeConfigRule.Backends, httpBackend)
 			}
@@ -415,3 +485,444 @@ func (hr *httpRoute) GetParents() sets.Set[string] {
 	}
 	return parents
 }
+
+func buildHeaderFilter(headerModifier *gatewayv1.HTTPHeaderFilter) *HeaderFilter {
+	headerFilter := &HeaderFilter{}
+	headerFilter.Add = make([]*Header, 0, len(headerModifier.Add))
+	for _, addFilter := range headerModifier.Add {
+		addHeader := &Header{
+			Name:  string(addFilter.Name),
+			Value: addFilter.Value,
+		}
+		headerFilter.Add = append(headerFilter.Add, addHeader)
+	}
+	headerFilter.Set = make([]*Header, 0, len(headerModifier.Set))
+	for _, setFilter := range headerModifier.Set {
+		setHeader := &Header{
+			Name:  string(setFilter.Name),
+			Value: setFilter.Value,
+		}
+		headerFilter.Set = append(headerFilter.Set, setHeader)
+	}
+	headerFilter.Remove = make([]string, len(headerModifier.Remove))
+	copy(headerFilter.Remove, headerModifier.Remove)
+
+	sort.Sort((Headers)(headerFilter.Add))
+	sort.Sort((Headers)(headerFilter.Set))
+	sort.Strings(headerFilter.Remove)
+	return headerFilter
+}
+
+func buildCORSPolicy(corsFilter *gatewayv1.HTTPCORSFilter) *nodes.CORSPolicy {
+	cors := &nodes.CORSPolicy{
+		AllowCredentials: bool(corsFilter.AllowCredentials),
+		MaxAge:           corsFilter.MaxAge,
+	}
+	for _, origin := range corsFilter.AllowOrigins {
+		cors.AllowOrigins = append(cors.AllowOrigins, string(origin))
+	}
+	for _, method := range corsFilter.AllowMethods {
+		cors.AllowMethods = append(cors.AllowMethods, string(method))
+	}
+	for _, header := range corsFilter.AllowHeaders {
+		cors.AllowHeaders = append(cors.AllowHeaders, string(header))
+	}
+	for _, header := range corsFilter.ExposeHeaders {
+		cors.ExposeHeaders = append(cors.ExposeHeaders, string(header))
+	}
+	if cors.MaxAge == 0 {
+		cors.MaxAge = akogatewayapilib.CORSDefaultMaxAge
+	}
+	return cors
+}
+
+// grpcRoute implements the RouteModel for GRPCRoute objects. The gRPC service and method
+// matches are translated to path matches, as gRPC requests are sent to /<service>/<method>.
+type grpcRoute struct {
+	key         string
+	name        string
+	namespace   string
+	routeConfig *RouteConfig
+	spec        *gatewayv1.GRPCRouteSpec
+}
+
+func GetGRPCRouteModel(key string, name, namespace string) (RouteModel, error) {
+	gr := &grpcRoute{
+		key:       key,
+		name:      name,
+		namespace: namespace,
+	}
+
+	grObj, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GRPCRouteInformer.Lister().GRPCRoutes(namespace).Get(name)
+	if err != nil {
+		return gr, err
+	}
+	gr.spec = grObj.Spec.DeepCopy()
+	return gr, nil
+}
+
+func (gr *grpcRoute) GetName() string {
+	return gr.name
+}
+
+func (gr *grpcRoute) GetNamespace() string {
+	return gr.namespace
+}
+
+func (gr *grpcRoute) GetType() string {
+	return lib.GRPCRoute
+}
+
+func (gr *grpcRoute) GetSpec() interface{} {
+	return gr.spec
+}
+
+func (gr *grpcRoute) ParseRouteConfig(key string) *RouteConfig {
+	if gr.routeConfig != nil {
+		return gr.routeConfig
+	}
+	routeConfig := &RouteConfig{}
+
+	routeConfig.Hosts = make([]string, len(gr.spec.Hostnames))
+	for i := range gr.spec.Hostnames {
+		routeConfig.Hosts[i] = string(gr.spec.Hostnames[i])
+	}
+	var resolvedRefCondition akogatewayapistatus.Condition
+	routeConfig.Rules = make([]*Rule, 0, len(gr.spec.Rules))
+
+	invalidRules := akogatewayapiobjects.GatewayApiLister().GetRouteToInvalidRules(lib.GRPCRoute + "/" + gr.namespace + "/" + gr.name)
+	for i, rule := range gr.spec.Rules {
+		if _, ok := invalidRules[i]; ok {
+			continue
+		}
+		routeConfigRule := &Rule{}
+		routeConfigRule.Matches = make([]*Match, 0, len(rule.Matches))
+		for _, ruleMatch := range rule.Matches {
+			match := &Match{}
+			match.PathMatch = grpcMethodToPathMatch(ruleMatch.Method)
+
+			match.HeaderMatch = make([]*HeaderMatch, 0, len(ruleMatch.Headers))
+			for _, header := range ruleMatch.Headers {
+				headerMatch := &HeaderMatch{}
+				if header.Type != nil {
+					headerMatch.Type = string(*header.Type)
+				}
+				headerMatch.Name = string(header.Name)
+				headerMatch.Value = header.Value
+				match.HeaderMatch = append(match.HeaderMatch, headerMatch)
+			}
+			routeConfigRule.Matches = append(routeConfigRule.Matches, match)
+		}
+		// a rule without matches matches all the gRPC requests
+		if len(routeConfigRule.Matches) == 0 {
+			routeConfigRule.Matches = append(routeConfigRule.Matches, &Match{
+				PathMatch:   &PathMatch{Path: "/", Type: akogatewayapilib.PATHPREFIX},
+				HeaderMatch: []*HeaderMatch{},
+			})
+		}
+		sort.Sort((Matches)(routeConfigRule.Matches))
+		if rule.Name != nil {
+			routeConfigRule.Name = string(*rule.Name)
+		}
+
+		// only the header modifier filters are accepted during validation
+		routeConfigRule.Filters = make([]*Filter, 0, len(rule.Filters))
+		for _, ruleFilter := range rule.Filters {
+			filter := &Filter{}
+			filter.Type = string(ruleFilter.Type)
+			if ruleFilter.RequestHeaderModifier != nil {
+				filter.RequestFilter = buildHeaderFilter(ruleFilter.RequestHeaderModifier)
+			}
+			if ruleFilter.ResponseHeaderModifier != nil {
+				filter.ResponseFilter = buildHeaderFilter(ruleFilter.ResponseHeaderModifier)
+			}
+			routeConfigRule.Filters = append(routeConfigRule.Filters, filter)
+		}
+
+		for _, ruleBackend := range rule.BackendRefs {
+			backend := &Backend{}
+			backend.Name = string(ruleBackend.BackendRef.Name)
+			if ruleBackend.BackendRef.Namespace != nil {
+				backend.Namespace = string(*ruleBackend.BackendRef.Namespace)
+			} else {
+				backend.Namespace = gr.namespace
+			}
+			if ruleBackend.BackendRef.Port != nil {
+				backend.Port = int32(*ruleBackend.Port)
+			}
+			if ruleBackend.BackendRef.Kind != nil {
+				backend.Kind = string(*ruleBackend.Kind)
+			}
+			backend.Weight = 1
+			if ruleBackend.Weight != nil {
+				backend.Weight = *ruleBackend.Weight
+			}
+			isValidBackend, resolvedRefConditionRuleBackend := validateBackendReference(key, lib.GRPCRoute, *backend, nil, gr.namespace)
+			if isValidBackend {
+				routeConfigRule.Backends = append(routeConfigRule.Backends, &HTTPBackend{Backend: backend})
+			}
+			if resolvedRefConditionRuleBackend != nil {
+				resolvedRefCondition = resolvedRefConditionRuleBackend
+			}
+		}
+		routeConfig.Rules = append(routeConfig.Rules, routeConfigRule)
+	}
+	if resolvedRefCondition == nil {
+		resolvedRefCondition = akogatewayapistatus.NewCondition().
+			Type(string(gatewayv1.RouteConditionResolvedRefs)).
+			Status(metav1.ConditionTrue).
+			Reason(string(gatewayv1.RouteReasonResolvedRefs))
+	}
+	gr.routeConfig = routeConfig
+	setResolvedRefConditionInHTTPRouteStatus(key, resolvedRefCondition, lib.GRPCRoute+"/"+gr.GetNamespace()+"/"+gr.GetName())
+	return gr.routeConfig
+}
+
+// grpcMethodToPathMatch converts the gRPC method match to a match on the request path /<service>/<method>.
+func grpcMethodToPathMatch(method *gatewayv1.GRPCMethodMatch) *PathMatch {
+	if method == nil || (method.Service == nil && method.Method == nil) {
+		return &PathMatch{Path: "/", Type: akogatewayapilib.PATHPREFIX}
+	}
+	var service, methodName string
+	if method.Service != nil {
+		service = *method.Service
+	}
+	if method.Method != nil {
+		methodName = *method.Method
+	}
+	if method.Type != nil && *method.Type == gatewayv1.GRPCMethodMatchRegularExpression {
+		if service == "" {
+			service = "[^/]+"
+		}
+		if methodName == "" {
+			methodName = "[^/]+"
+		}
+		return &PathMatch{Path: "^/" + service + "/" + methodName + "$", Type: akogatewayapilib.REGULAREXPRESSION}
+	}
+	switch {
+	case service != "" && methodName != "":
+		return &PathMatch{Path: "/" + service + "/" + methodName, Type: akogatewayapilib.EXACT}
+	case service != "":
+		return &PathMatch{Path: "/" + service + "/", Type: akogatewayapilib.PATHPREFIX}
+	}
+	return &PathMatch{Path: "^/[^/]+/" + regexp.QuoteMeta(methodName) + "$", Type: akogatewayapilib.REGULAREXPRESSION}
+}
+
+func (gr *grpcRoute) Exists() bool {
+	return gr != nil
+}
+
+func (gr *grpcRoute) GetParents() sets.Set[string] {
+	parents := sets.New[string]()
+	for _, ref := range gr.spec.ParentRefs {
+		namespace := gr.namespace
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
+		parents.Insert(namespace + "/" + string(ref.Name))
+	}
+	return parents
+}
+
+// l4RouteRule is the common representation of a TCPRouteRule, an UDPRouteRule and a TLSRouteRule.
+type l4RouteRule struct {
+	name        *gatewayv1.SectionName
+	backendRefs []gatewayv1.BackendRef
+}
+
+// l4Route implements the RouteModel for TCPRoute, UDPRoute and TLSRoute objects.
+type l4Route struct {
+	key         string
+	name        string
+	namespace   string
+	routeType   string
+	routeConfig *RouteConfig
+	spec        *gatewayv1.CommonRouteSpec
+	rules       []l4RouteRule
+	// hostnames are matched against the SNI, only TLSRoutes carry them.
+	hostnames []gatewayv1.Hostname
+}
+
+func GetL4RouteModel(key, routeType, name, namespace string) (RouteModel, error) {
+	lr := &l4Route{
+		key:       key,
+		name:      name,
+		namespace: namespace,
+		routeType: routeType,
+	}
+	obj, err := getL4RouteObject(routeType, namespace, name)
+	if err != nil {
+		return lr, err
+	}
+	spec, _ := getL4RouteSpecAndStatus(obj)
+	lr.spec = spec.DeepCopy()
+	lr.rules = getL4RouteRules(obj)
+	if tlsRoute, ok := obj.(*gatewayv1alpha2.TLSRoute); ok {
+		lr.hostnames = tlsRoute.Spec.Hostnames
+	}
+	return lr, nil
+}
+
+func getL4RouteRules(obj l4RouteObject) []l4RouteRule {
+	var rules []l4RouteRule
+	switch route := obj.(type) {
+	case *gatewayv1alpha2.TCPRoute:
+		for _, rule := range route.Spec.Rules {
+			rules = append(rules, l4RouteRule{name: rule.Name, backendRefs: rule.BackendRefs})
+		}
+	case *gatewayv1alpha2.UDPRoute:
+		for _, rule := range route.Spec.Rules {
+			rules = append(rules, l4RouteRule{name: rule.Name, backendRefs: rule.BackendRefs})
+		}
+	case *gatewayv1alpha2.TLSRoute:
+		for _, rule := range route.Spec.Rules {
+			rules = append(rules, l4RouteRule{name: rule.Name, backendRefs: rule.BackendRefs})
+		}
+	}
+	return rules
+}
+
+// l4RouteObject is satisfied by TCPRoute, UDPRoute and TLSRoute objects.
+type l4RouteObject interface {
+	metav1.Object
+	runtime.Object
+}
+
+// l7RouteObject is satisfied by HTTPRoute and GRPCRoute objects.
+type l7RouteObject interface {
+	metav1.Object
+	runtime.Object
+}
+
+// getL4RouteObject fetches the TCPRoute, UDPRoute or TLSRoute object from the informer cache.
+func getL4RouteObject(routeType, namespace, name string) (l4RouteObject, error) {
+	informers := akogatewayapilib.AKOControlConfig().GatewayApiInformers()
+	switch routeType {
+	case lib.TCPRoute:
+		if informers.TCPRouteInformer == nil {
+			return nil, fmt.Errorf("informer for %s is not initialised", routeType)
+		}
+		route, err := informers.TCPRouteInformer.Lister().TCPRoutes(namespace).Get(name)
+		if err != nil {
+			return nil, err
+		}
+		return route, nil
+	case lib.UDPRoute:
+		if informers.UDPRouteInformer == nil {
+			return nil, fmt.Errorf("informer for %s is not initialised", routeType)
+		}
+		route, err := informers.UDPRouteInformer.Lister().UDPRoutes(namespace).Get(name)
+		if err != nil {
+			return nil, err
+		}
+		return route, nil
+	case lib.TLSRoute:
+		if informers.TLSRouteInformer == nil {
+			return nil, fmt.Errorf("informer for %s is not initialised", routeType)
+		}
+		route, err := informers.TLSRouteInformer.Lister().TLSRoutes(namespace).Get(name)
+		if err != nil {
+			return nil, err
+		}
+		return route, nil
+	}
+	return nil, fmt.Errorf("object of type %s not supported", routeType)
+}
+
+func (lr *l4Route) GetName() string {
+	return lr.name
+}
+
+func (lr *l4Route) GetNamespace() string {
+	return lr.namespace
+}
+
+func (lr *l4Route) GetType() string {
+	return lr.routeType
+}
+
+func (lr *l4Route) GetSpec() interface{} {
+	return lr.spec
+}
+
+// ParseRouteConfig converts the rules of the L4 route to a RouteConfig. L4 routes do not
+// have any matches or filters, the listener port (and the SNI for TLSRoutes) decides the
+// traffic sent to the backends.
+func (lr *l4Route) ParseRouteConfig(key string) *RouteConfig {
+	if lr.routeConfig != nil {
+		return lr.routeConfig
+	}
+	routeConfig := &RouteConfig{}
+	for _, hostname := range lr.hostnames {
+		routeConfig.Hosts = append(routeConfig.Hosts, string(hostname))
+	}
+	routeConfig.Rules = make([]*Rule, 0, len(lr.rules))
+	var resolvedRefCondition akogatewayapistatus.Condition
+	for _, rule := range lr.rules {
+		routeConfigRule := &Rule{}
+		if rule.name != nil {
+			routeConfigRule.Name = string(*rule.name)
+		}
+		for _, ruleBackend := range rule.backendRefs {
+			backend := &Backend{
+				Name:      string(ruleBackend.Name),
+				Namespace: lr.namespace,
+				Weight:    1,
+			}
+			if ruleBackend.Namespace != nil {
+				backend.Namespace = string(*ruleBackend.Namespace)
+			}
+			if ruleBackend.Port != nil {
+				backend.Port = int32(*ruleBackend.Port)
+			}
+			if ruleBackend.Kind != nil {
+				backend.Kind = string(*ruleBackend.Kind)
+			}
+			if ruleBackend.Weight != nil {
+				backend.Weight = *ruleBackend.Weight
+			}
+			isValidBackend, resolvedRefConditionRuleBackend := validateBackendReference(key, lr.routeType, *backend, nil, lr.namespace)
+			if isValidBackend && backend.Port == 0 {
+				utils.AviLog.Errorf("key: %s, msg: BackendRef %s of %s %s does not have a port", key, backend.Name, lr.routeType, lr.name)
+				isValidBackend = false
+				resolvedRefConditionRuleBackend = akogatewayapistatus.NewCondition().
+					Type(string(gatewayv1.RouteConditionResolvedRefs)).
+					Status(metav1.ConditionFalse).
+					Reason(string(gatewayv1.RouteReasonUnsupportedValue)).
+					Message(fmt.Sprintf("backendRef %s must specify a port", backend.Name))
+			}
+			if isValidBackend {
+				routeConfigRule.Backends = append(routeConfigRule.Backends, &HTTPBackend{Backend: backend})
+			}
+			if resolvedRefConditionRuleBackend != nil {
+				resolvedRefCondition = resolvedRefConditionRuleBackend
+			}
+		}
+		routeConfig.Rules = append(routeConfig.Rules, routeConfigRule)
+	}
+	if resolvedRefCondition == nil {
+		resolvedRefCondition = akogatewayapistatus.NewCondition().
+			Type(string(gatewayv1.RouteConditionResolvedRefs)).
+			Status(metav1.ConditionTrue).
+			Reason(string(gatewayv1.RouteReasonResolvedRefs))
+	}
+	lr.routeConfig = routeConfig
+	setResolvedRefConditionInHTTPRouteStatus(key, resolvedRefCondition, lr.routeType+"/"+lr.namespace+"/"+lr.name)
+	return lr.routeConfig
+}
+
+func (lr *l4Route) Exists() bool {
+	return lr != nil
+}
+
+func (lr *l4Route) GetParents() sets.Set[string] {
+	parents := sets.New[string]()
+	for _, ref := range lr.spec.ParentRefs {
+		namespace := lr.namespace
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
+		parents.Insert(namespace + "/" + string(ref.Name))
+	}
+	return parents
+}
@@ -21,8 +21,11 @@ import (
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
 	akogatewayapiobjects "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/objects"
@@ -55,41 +58,62 @@ func isRegexMatch(stringWithWildCard string, stringToBeMatched string, key strin
 
 func IsHTTPRouteValid(key string, obj *gatewayv1.HTTPRoute) bool {
 	httpRoute := obj.DeepCopy()
-	httpRouteStatus := obj.Status.DeepCopy()
-	httpRouteStatus.Parents = make([]gatewayv1.RouteParentStatus, 0, len(httpRoute.Spec.ParentRefs))
+	return isL7RouteValid(key, httpRoute)
+}
+
+func IsGRPCRouteValid(key string, obj *gatewayv1.GRPCRoute) bool {
+	grpcRoute := obj.DeepCopy()
+	return isL7RouteValid(key, grpcRoute)
+}
+
+// isL7RouteValid validates the rules and the parent references of an HTTPRoute or a GRPCRoute and records its status.
+func isL7RouteValid(key string, route l7RouteObject) bool {
+	routeType, spec, _ := getL7RouteSpec(route)
+	httpRouteStatus := getL7RouteStatus(route)
+	httpRouteStatus.Parents = make([]gatewayv1.RouteParentStatus, 0, len(spec.ParentRefs))
 	var invalidParentRefCount int
 	parentRefIndexInHttpRouteStatus := 0
 	indexInCache := -1
-	isValidHttprouteRules := validateHTTPRouteRules(key, httpRoute, httpRouteStatus)
-	if isValidHttprouteRules {
-		for parentRefIndexFromSpec := range httpRoute.Spec.ParentRefs {
-			err := validateParentReference(key, httpRoute, httpRouteStatus, parentRefIndexFromSpec, &parentRefIndexInHttpRouteStatus, &indexInCache)
+	isValidRouteRules := validateL7RouteRules(key, route, httpRouteStatus)
+	if isValidRouteRules {
+		for parentRefIndexFromSpec := range spec.ParentRefs {
+			err := validateParentReference(key, route, httpRouteStatus, parentRefIndexFromSpec, &parentRefIndexInHttpRouteStatus, &indexInCache)
 			if err != nil {
 				invalidParentRefCount++
-				parentRefName := httpRoute.Spec.ParentRefs[parentRefIndexFromSpec].Name
-				utils.AviLog.Warnf("key: %s, msg: Parent Reference %s of HTTPRoute object %s is not valid, err: %v", key, parentRefName, httpRoute.Name, err)
+				parentRefName := spec.ParentRefs[parentRefIndexFromSpec].Name
+				utils.AviLog.Warnf("key: %s, msg: Parent Reference %s of %s object %s is not valid, err: %v", key, parentRefName, routeType, route.GetName(), err)
 			}
 		}
 	}
 
-	akogatewayapistatus.Record(key, httpRoute, &status.Status{HTTPRouteStatus: httpRouteStatus})
+	akogatewayapistatus.Record(key, route, &status.Status{HTTPRouteStatus: httpRouteStatus})
 
-	// No valid attachment, we can't proceed with this HTTPRoute object.
-	if invalidParentRefCount == len(httpRoute.Spec.ParentRefs) || !isValidHttprouteRules {
-		utils.AviLog.Errorf("key: %s, msg: HTTPRoute object %s is not valid", key, httpRoute.Name)
-		akogatewayapilib.AKOControlConfig().EventRecorder().Eventf(httpRoute, corev1.EventTypeWarning,
-			lib.Detached, "HTTPRoute object %s is not valid", httpRoute.Name)
+	// No valid attachment, we can't proceed with this route object.
+	if invalidParentRefCount == len(spec.ParentRefs) || !isValidRouteRules {
+		utils.AviLog.Errorf("key: %s, msg: %s object %s is not valid", key, routeType, route.GetName())
+		akogatewayapilib.AKOControlConfig().EventRecorder().Eventf(route, corev1.EventTypeWarning,
+			lib.Detached, "%s object %s is not valid", routeType, route.GetName())
 		return false
 	}
-	utils.AviLog.Infof("key: %s, msg: HTTPRoute object %s is valid", key, httpRoute.Name)
+	utils.AviLog.Infof("key: %s, msg: %s object %s is valid", key, routeType, route.GetName())
 	return true
 }
 
-func validateBackendReference(key string, backend Backend, backendFilters []*Filter, httpRouteNamespace string) (bool, akogatewayapistatus.Condition) {
+func validateBackendReference(key, routeType string, backend Backend, backendFilters []*Filter, httpRouteNamespace string) (bool, akogatewayapistatus.Condition) {
 	routeConditionResolvedRef := akogatewayapistatus.NewCondition().
 		Type(string(gatewayv1.RouteConditionResolvedRefs)).
 		Status(metav1.ConditionFalse)
-	if backend.Kind != "" && backend.Kind != "Service" {
+	toGroup, toKind := "", utils.Service
+	if backend.Kind == lib.ServiceImport {
+		if err := validateServiceImportBackend(routeType, backend); err != nil {
+			utils.AviLog.Errorf("key: %s, msg: %v", key, err)
+			routeConditionResolvedRef.
+				Reason(string(gatewayv1.RouteReasonInvalidKind)).
+				Message(err.Error())
+			return false, routeConditionResolvedRef
+		}
+		toGroup, toKind = backend.Group, lib.ServiceImport
+	} else if backend.Kind != "" && backend.Kind != "Service" {
 		utils.AviLog.Errorf("key: %s, msg: BackendRef %s has invalid kind %s.", key, backend.Name, backend.Kind)
 		err := fmt.Errorf("backendRef %s has invalid kind %s", backend.Name, backend.Kind)
 		routeConditionResolvedRef.
@@ -104,7 +128,17 @@ func validateBackendReference(key string, backend Backend, backendFilters []*Fil
 	if backendRefTenant != httpRouteTenant {
 		utils.AviLog.Errorf("key: %s, msg: BackendRef %s tenant %s is not equal to HTTPRoute tenant %s", key, backend.Name, backendRefTenant, httpRouteTenant)
 		err := fmt.Errorf("backendRef %s tenant %s is not equal to HTTPRoute tenant %s", backend.Name, backendRefTenant, httpRouteTenant)
-		// using RouteReasonRefNotPermitted for now, may change when reference grant is supported
+		// backends across tenants are not supported even when a ReferenceGrant permits the reference
+		routeConditionResolvedRef.
+			Reason(string(gatewayv1.RouteReasonRefNotPermitted)).
+			Message(err.Error())
+		return false, routeConditionResolvedRef
+	}
+
+	// backendRef to a Service or a ServiceImport in another namespace must be permitted by a ReferenceGrant in that namespace
+	if !akogatewayapiobjects.GatewayApiLister().IsReferencePermitted(akogatewayapilib.GatewayGroup, routeType, httpRouteNamespace, toGroup, toKind, backend.Namespace, backend.Name) {
+		utils.AviLog.Errorf("key: %s, msg: BackendRef %s/%s is not permitted by any ReferenceGrant", key, backend.Namespace, backend.Name)
+		err := fmt.Errorf("backendRef %s/%s is not permitted by any ReferenceGrant", backend.Namespace, backend.Name)
 		routeConditionResolvedRef.
 			Reason(string(gatewayv1.RouteReasonRefNotPermitted)).
 			Message(err.Error())
@@ -119,6 +153,28 @@ func validateBackendReference(key string, backend Backend, backendFilters []*Fil
 	return true, nil
 }
 
+// validateServiceImportBackend checks that a ServiceImport backend is referred by an HTTPRoute, and that
+// the ServiceImports of its group are watched by AKO.
+func validateServiceImportBackend(routeType string, backend Backend) error {
+	if routeType != lib.HTTPRoute {
+		return fmt.Errorf("backendRef %s of kind %s is not supported on %s", backend.Name, backend.Kind, routeType)
+	}
+	dynamicInformers := akogatewayapilib.GetDynamicInformers()
+	switch backend.Group {
+	case lib.AkoGroup:
+		if dynamicInformers == nil || dynamicInformers.AKOServiceImportInformer == nil {
+			return fmt.Errorf("backendRef %s of kind %s in group %s is not enabled", backend.Name, backend.Kind, backend.Group)
+		}
+	case akogatewayapilib.MultiClusterServiceGroup:
+		if dynamicInformers == nil || dynamicInformers.MultiClusterServiceImportInformer == nil {
+			return fmt.Errorf("backendRef %s of kind %s in group %s is not enabled", backend.Name, backend.Kind, backend.Group)
+		}
+	default:
+		return fmt.Errorf("backendRef %s has invalid group %s for kind %s", backend.Name, backend.Group, backend.Kind)
+	}
+	return nil
+}
+
 func validatedBackendRefExtensions(backendFilters []*Filter, routeConditionResolvedRef akogatewayapistatus.Condition, key string, backend Backend) (bool, akogatewayapistatus.Condition) {
 	extensionRefType := make(map[string]struct{})
 	for _, filter := range backendFilters {
@@ -248,102 +304,365 @@ func validateFilterExtensionRef(key, namespace string, filter *ExtensionRefFilte
 	return true, nil
 }
 
-// Current behaviour: Any invalid Rule, that HTTPRoute object is not processed.
-// TODO: Need to modify this behaviour by keeping map of rule to valid/invalid for a given route.
+// ruleValidationError is the reason for which a rule of a route is not processed, along with the route condition
+// type it is reported in.
+type ruleValidationError struct {
+	conditionType string
+	reason        string
+	message       string
+}
+
+func newRuleValidationError(conditionType gatewayv1.RouteConditionType, reason gatewayv1.RouteConditionReason, message string) *ruleValidationError {
+	return &ruleValidationError{
+		conditionType: string(conditionType),
+		reason:        string(reason),
+		message:       message,
+	}
+}
+
+// validateHTTPRouteRules validates the rules of the HTTPRoute. An invalid rule is dropped and only the valid rules
+// of the HTTPRoute are processed, the HTTPRoute object is not processed when all of its rules are invalid.
 func validateHTTPRouteRules(key string, httpRoute *gatewayv1.HTTPRoute, httpRouteStatus *gatewayv1.HTTPRouteStatus) bool {
-	//Validate Filters
-	//Validate URL Rewrite Filter, ExtensionRef
-	if httpRoute.Spec.Rules != nil {
-		for _, rule := range httpRoute.Spec.Rules {
-			extensionRefType := utils.NewSet[string]()
-			for _, filter := range rule.Filters {
-				if filter.Type == gatewayv1.HTTPRouteFilterURLRewrite && filter.URLRewrite != nil && filter.URLRewrite.Path != nil && filter.URLRewrite.Path.Type != gatewayv1.FullPathHTTPPathModifier {
-					setRouteConditionInHTTPRouteStatus(key,
-						string(gatewayv1.RouteReasonUnsupportedValue),
-						"HTTPUrlRewrite PathType has Unsupported value",
-						httpRoute, httpRouteStatus, "False", "Accepted")
-					utils.AviLog.Errorf("key: %s, msg: HTTPUrlRewrite PathType has Unsupported value %s.", key, filter.URLRewrite.Path.Type)
-					return false
-				} else if filter.Type == gatewayv1.HTTPRouteFilterExtensionRef && filter.ExtensionRef != nil {
-					// can convert to function
-					// Allows only ako.vmware.com
-					if string(filter.ExtensionRef.Group) != lib.AkoGroup {
-						utils.AviLog.Warnf("key: %s, msg: Extension Ref is not handled by AKO. Group of extension filter %s != %s ", key, filter.ExtensionRef.Group, lib.AkoGroup)
-						continue
-					}
-					// Allow only one instance of each kind.
-					// If user wants to define multiple instances of that kind, use an instance of AKO defined CRD
-					kind := string(filter.ExtensionRef.Kind)
-					if _, ok := SupportedExtensionRefKindsOnHTTPRouteRule[kind]; !ok {
-						utils.AviLog.Errorf("key: %s, msg: AKO does not support a kind: %s on HTTPRoute-Rule", key, kind)
-						// set the status
-						setRouteConditionInHTTPRouteStatus(key,
-							string(gatewayv1.RouteReasonInvalidKind),
-							fmt.Sprintf("Unsupported kind %s defined on HTTPRoute-Rule", kind),
-							httpRoute, httpRouteStatus, "False", "ResolvedRefs")
-						return false
-					}
-					if extensionRefType.Has(kind) {
-						utils.AviLog.Warnf("key: %s, msg: multiple entries for a kind %s. AKO handles only one object of each kind in ExtensionRef", key, kind)
-						// set the status
-						// Setting Invalid kind as there is no flag indicating multiple entries error
-						setRouteConditionInHTTPRouteStatus(key,
-							string(gatewayv1.RouteReasonInvalidKind),
-							"MultipleExtensionRef of same kind defined on HTTPRoute-Rule",
-							httpRoute, httpRouteStatus, "False", "ResolvedRefs")
-					}
+	ruleNames := make([]*gatewayv1.SectionName, 0, len(httpRoute.Spec.Rules))
+	ruleErrs := make(map[int]*ruleValidationError)
+	for i, rule := range httpRoute.Spec.Rules {
+		ruleNames = append(ruleNames, rule.Name)
+		if ruleErr := validateHTTPRouteRule(key, httpRoute, rule, httpRouteStatus); ruleErr != nil {
+			ruleErrs[i] = ruleErr
+		}
+	}
+	return processRuleValidationErrors(key, httpRoute, ruleNames, ruleErrs, httpRouteStatus)
+}
 
-					extensionRefType.Add(kind)
-					// Now validate Object defined in ExtensionRef
-					// L7Rule
-					if filter.ExtensionRef.Kind == lib.L7Rule {
-						name := string(filter.ExtensionRef.Name)
-						namespace := httpRoute.Namespace
-						_, _, err := akogatewayapilib.IsL7CRDValid(key, namespace, name)
-						if err != nil {
-							setRouteConditionInHTTPRouteStatus(key,
-								string(gatewayv1.RouteReasonBackendNotFound),
-								err.Error(),
-								httpRoute, httpRouteStatus, "False", "ResolvedRefs")
-						}
-					}
-				}
+// processRuleValidationErrors records the invalid rules of the route, so that these are skipped while building
+// the model, and sets the route conditions. A route with both valid and invalid rules is accepted with the
+// PartiallyInvalid condition listing the dropped rules.
+func processRuleValidationErrors(key string, route l7RouteObject, ruleNames []*gatewayv1.SectionName, ruleErrs map[int]*ruleValidationError, httpRouteStatus *gatewayv1.HTTPRouteStatus) bool {
+	routeType, _, _ := getL7RouteSpec(route)
+	routeTypeNsName := routeType + "/" + route.GetNamespace() + "/" + route.GetName()
+	invalidRules := make(map[int]struct{}, len(ruleErrs))
+	droppedRules := make([]string, 0, len(ruleErrs))
+	var firstRuleErr *ruleValidationError
+	for i := range ruleNames {
+		ruleErr, ok := ruleErrs[i]
+		if !ok {
+			continue
+		}
+		ruleName := fmt.Sprintf("rules[%d]", i)
+		if ruleNames[i] != nil {
+			ruleName = string(*ruleNames[i])
+		}
+		utils.AviLog.Errorf("key: %s, msg: rule %s of %s %s is not valid. err: %s", key, ruleName, routeType, route.GetName(), ruleErr.message)
+		invalidRules[i] = struct{}{}
+		droppedRules = append(droppedRules, fmt.Sprintf("%s: %s", ruleName, ruleErr.message))
+		if firstRuleErr == nil {
+			firstRuleErr = ruleErr
+		}
+	}
+	akogatewayapiobjects.GatewayApiLister().UpdateRouteToInvalidRules(routeTypeNsName, invalidRules)
+	if firstRuleErr == nil {
+		return true
+	}
+	if len(invalidRules) == len(ruleNames) {
+		setRouteConditionInHTTPRouteStatus(key, firstRuleErr.reason, firstRuleErr.message, route, httpRouteStatus, "False", firstRuleErr.conditionType)
+		return false
+	}
+	// the references of the dropped rules remain unresolved, even though the route is accepted
+	if firstRuleErr.conditionType == string(gatewayv1.RouteConditionResolvedRefs) {
+		setRouteConditionInHTTPRouteStatus(key, firstRuleErr.reason, firstRuleErr.message, route, httpRouteStatus, "False", firstRuleErr.conditionType)
+	}
+	setRouteConditionInHTTPRouteStatus(key,
+		string(gatewayv1.RouteReasonUnsupportedValue),
+		fmt.Sprintf("Dropped Rule(s): %s", strings.Join(droppedRules, "; ")),
+		route, httpRouteStatus, "True", string(gatewayv1.RouteConditionPartiallyInvalid))
+	return true
+}
+
+// validateHTTPRouteRule validates the matches, filters and the session persistence of a rule of the HTTPRoute.
+func validateHTTPRouteRule(key string, httpRoute *gatewayv1.HTTPRoute, rule gatewayv1.HTTPRouteRule, httpRouteStatus *gatewayv1.HTTPRouteStatus) *ruleValidationError {
+	for _, match := range rule.Matches {
+		if err := validateQueryParamMatches(match.QueryParams); err != nil {
+			return newRuleValidationError(gatewayv1.RouteConditionAccepted, gatewayv1.RouteReasonUnsupportedValue, err.Error())
+		}
+	}
+	if err := validateTimeoutsAndRetry(rule.Timeouts, rule.Retry); err != nil {
+		return newRuleValidationError(gatewayv1.RouteConditionAccepted, gatewayv1.RouteReasonUnsupportedValue, err.Error())
+	}
+	extensionRefType := utils.NewSet[string]()
+	for _, filter := range rule.Filters {
+		if err := validatePathModifier(filter, rule.Matches); err != nil {
+			return newRuleValidationError(gatewayv1.RouteConditionAccepted, gatewayv1.RouteReasonUnsupportedValue, err.Error())
+		} else if filter.Type == gatewayv1.HTTPRouteFilterRequestMirror && filter.RequestMirror != nil {
+			if err := validateRequestMirror(key, httpRoute, rule, filter.RequestMirror); err != nil {
+				return newRuleValidationError(gatewayv1.RouteConditionAccepted, gatewayv1.RouteReasonUnsupportedValue, err.Error())
 			}
-			/*
-				if isExtensionRefPresent {
-					// set Resolved ref accepted
-					// But this will be per rule.
-					routeConditionResolvedRef := akogatewayapistatus.NewCondition().
-						Type(string(gatewayv1.RouteConditionResolvedRefs)).
-						Status(metav1.ConditionTrue).
-						ObservedGeneration(httpRoute.ObjectMeta.Generation).
-						Reason(string(gatewayv1.RouteReasonResolvedRefs)).
-						Message("All ExtensionRefs are valid")
-
-					setResolvedRefConditionInHTTPRouteStatus(key, routeConditionResolvedRef, httpRouteNSName)
-				}*/
-			if rule.SessionPersistence != nil {
-				if rule.SessionPersistence.Type != nil && *rule.SessionPersistence.Type == gatewayv1.HeaderBasedSessionPersistence {
-					utils.AviLog.Errorf("key: %s, msg: Header based session persistence type is not supported ", key)
-					return false
-				}
-				if rule.SessionPersistence.SessionName == nil || *rule.SessionPersistence.SessionName == "" {
-					utils.AviLog.Errorf("key: %s, msg: Session Name is needed in SessionPersistence", key)
-					return false
+		} else if filter.Type == gatewayv1.HTTPRouteFilterCORS && filter.CORS != nil {
+			if err := buildCORSPolicy(filter.CORS).Validate(); err != nil {
+				return newRuleValidationError(gatewayv1.RouteConditionAccepted, gatewayv1.RouteReasonUnsupportedValue, err.Error())
+			}
+		} else if filter.Type == gatewayv1.HTTPRouteFilterExtensionRef && filter.ExtensionRef != nil {
+			// can convert to function
+			// Allows only ako.vmware.com
+			if string(filter.ExtensionRef.Group) != lib.AkoGroup {
+				utils.AviLog.Warnf("key: %s, msg: Extension Ref is not handled by AKO. Group of extension filter %s != %s ", key, filter.ExtensionRef.Group, lib.AkoGroup)
+				continue
+			}
+			// Allow only one instance of each kind.
+			// If user wants to define multiple instances of that kind, use an instance of AKO defined CRD
+			kind := string(filter.ExtensionRef.Kind)
+			if _, ok := SupportedExtensionRefKindsOnHTTPRouteRule[kind]; !ok {
+				utils.AviLog.Errorf("key: %s, msg: AKO does not support a kind: %s on HTTPRoute-Rule", key, kind)
+				return newRuleValidationError(gatewayv1.RouteConditionResolvedRefs, gatewayv1.RouteReasonInvalidKind,
+					fmt.Sprintf("Unsupported kind %s defined on HTTPRoute-Rule", kind))
+			}
+			if extensionRefType.Has(kind) {
+				utils.AviLog.Warnf("key: %s, msg: multiple entries for a kind %s. AKO handles only one object of each kind in ExtensionRef", key, kind)
+				// set the status
+				// Setting Invalid kind as there is no flag indicating multiple entries error
+				setRouteConditionInHTTPRouteStatus(key,
+					string(gatewayv1.RouteReasonInvalidKind),
+					"MultipleExtensionRef of same kind defined on HTTPRoute-Rule",
+					httpRoute, httpRouteStatus, "False", "ResolvedRefs")
+			}
+
+			extensionRefType.Add(kind)
+			// Now validate Object defined in ExtensionRef
+			// L7Rule
+			if filter.ExtensionRef.Kind == lib.L7Rule {
+				name := string(filter.ExtensionRef.Name)
+				namespace := httpRoute.Namespace
+				_, _, err := akogatewayapilib.IsL7CRDValid(key, namespace, name)
+				if err != nil {
+					setRouteConditionInHTTPRouteStatus(key,
+						string(gatewayv1.RouteReasonBackendNotFound),
+						err.Error(),
+						httpRoute, httpRouteStatus, "False", "ResolvedRefs")
 				}
 			}
 		}
 	}
+	if rule.SessionPersistence != nil {
+		if rule.SessionPersistence.SessionName == nil || *rule.SessionPersistence.SessionName == "" {
+			return newRuleValidationError(gatewayv1.RouteConditionAccepted, gatewayv1.RouteReasonUnsupportedValue,
+				"Session Name is needed in SessionPersistence")
+		}
+	}
+	return nil
+}
+
+// validateQueryParamMatches validates that the query param matches can be expressed as a single Avi query match.
+func validateQueryParamMatches(queryParams []gatewayv1.HTTPQueryParamMatch) error {
+	if len(queryParams) > 1 {
+		return fmt.Errorf("multiple QueryParams in a HTTPRoute match are not supported")
+	}
+	for _, queryParam := range queryParams {
+		if queryParam.Type != nil && *queryParam.Type == gatewayv1.QueryParamMatchRegularExpression {
+			if _, err := regexp.Compile(queryParam.Value); err != nil {
+				return fmt.Errorf("QueryParam %s has invalid regular expression %s", queryParam.Name, queryParam.Value)
+			}
+		}
+	}
+	return nil
+}
+
+// validatePathModifier validates the path modifier of the URLRewrite and RequestRedirect filters. The prefix
+// can be replaced only on the requests matched by a path prefix.
+func validatePathModifier(filter gatewayv1.HTTPRouteFilter, matches []gatewayv1.HTTPRouteMatch) error {
+	var pathModifier *gatewayv1.HTTPPathModifier
+	if filter.Type == gatewayv1.HTTPRouteFilterURLRewrite && filter.URLRewrite != nil {
+		pathModifier = filter.URLRewrite.Path
+	} else if filter.Type == gatewayv1.HTTPRouteFilterRequestRedirect && filter.RequestRedirect != nil {
+		pathModifier = filter.RequestRedirect.Path
+	}
+	if pathModifier == nil || pathModifier.Type == gatewayv1.FullPathHTTPPathModifier {
+		return nil
+	}
+	if pathModifier.Type != gatewayv1.PrefixMatchHTTPPathModifier {
+		return fmt.Errorf("%s PathType has Unsupported value %s", filter.Type, pathModifier.Type)
+	}
+	for _, match := range matches {
+		if match.Path != nil && match.Path.Type != nil && *match.Path.Type != gatewayv1.PathMatchPathPrefix {
+			return fmt.Errorf("%s ReplacePrefixMatch requires PathPrefix match", filter.Type)
+		}
+	}
+	return nil
+}
+
+// validateTimeoutsAndRetry validates that the rule timeouts and retry can be set on the Avi pools of the rule.
+func validateTimeoutsAndRetry(timeouts *gatewayv1.HTTPRouteTimeouts, retry *gatewayv1.HTTPRouteRetry) error {
+	if timeouts != nil {
+		if timeouts.Request != nil {
+			if _, err := parseGatewayDurationToMilliseconds(*timeouts.Request, akogatewayapilib.PoolServerTimeoutMax); err != nil {
+				return fmt.Errorf("request timeout has unsupported value: %v", err)
+			}
+		}
+		if timeouts.BackendRequest != nil {
+			maxTimeout := uint32(akogatewayapilib.PoolServerTimeoutMax)
+			if retry != nil {
+				maxTimeout = akogatewayapilib.PoolServerReselectRetryTimeoutMax
+			}
+			if _, err := parseGatewayDurationToMilliseconds(*timeouts.BackendRequest, maxTimeout); err != nil {
+				return fmt.Errorf("backendRequest timeout has unsupported value: %v", err)
+			}
+		}
+	}
+	if retry != nil && retry.Attempts != nil && (*retry.Attempts < 0 || *retry.Attempts > akogatewayapilib.PoolServerReselectNumRetriesMax) {
+		return fmt.Errorf("retry attempts %d is not supported, must be between 0 and %d", *retry.Attempts, akogatewayapilib.PoolServerReselectNumRetriesMax)
+	}
+	if retry != nil && retry.Backoff != nil {
+		backoff, err := parseGatewayDurationToMilliseconds(*retry.Backoff, akogatewayapilib.PoolServerTimeoutMax)
+		if err != nil {
+			return fmt.Errorf("retry backoff has unsupported value: %v", err)
+		}
+		// Avi retries the request on another server immediately, there is no wait between the attempts.
+		if backoff != 0 {
+			return fmt.Errorf("retry backoff is not supported")
+		}
+	}
+	return nil
+}
+
+// validateRequestMirror validates that the RequestMirror filter can be translated to a TrafficCloneProfile.
+// Avi clones the requests to the IP addresses of the mirror endpoints without changing the destination port,
+// hence the mirror backend must resolve to the same target port as the backends of the rule.
+func validateRequestMirror(key string, httpRoute *gatewayv1.HTTPRoute, rule gatewayv1.HTTPRouteRule, requestMirror *gatewayv1.HTTPRequestMirrorFilter) error {
+	// Avi traffic cloning mirrors every request, sampling a part of the requests is not supported.
+	if !isFullRequestMirror(requestMirror) {
+		return fmt.Errorf("RequestMirror supports mirroring of 100 percent of the requests only")
+	}
+	// traffic clone profile applies to all the requests of a VS, hence not supported per rule on a dedicated VS
+	if isRouteAttachedToDedicatedGateway(httpRoute) {
+		return fmt.Errorf("RequestMirror is not supported in dedicated mode")
+	}
+	// every Service has its own node port, hence the cloned requests would not reach the mirror backend
+	if serviceType := lib.GetServiceType(); serviceType == lib.NodePort || serviceType == lib.NodePortLocal {
+		return fmt.Errorf("RequestMirror is not supported in %s mode", serviceType)
+	}
+	mirrorTargetPort := getBackendRefTargetPort(key, httpRoute.Namespace, requestMirror.BackendRef)
+	if mirrorTargetPort == 0 {
+		return nil
+	}
+	for _, backendRef := range rule.BackendRefs {
+		targetPort := getBackendRefTargetPort(key, httpRoute.Namespace, backendRef.BackendObjectReference)
+		if targetPort != 0 && targetPort != mirrorTargetPort {
+			return fmt.Errorf("RequestMirror backend %s has target port %d, requests are mirrored on the target port %d of the rule backends only",
+				requestMirror.BackendRef.Name, mirrorTargetPort, targetPort)
+		}
+	}
+	return nil
+}
+
+// isRouteAttachedToDedicatedGateway returns true if the only parent reference of the route is a Gateway in dedicated mode.
+func isRouteAttachedToDedicatedGateway(httpRoute *gatewayv1.HTTPRoute) bool {
+	if len(httpRoute.Spec.ParentRefs) != 1 {
+		return false
+	}
+	parentRef := httpRoute.Spec.ParentRefs[0]
+	if parentRef.Kind != nil && string(*parentRef.Kind) != lib.Gateway {
+		return false
+	}
+	namespace := httpRoute.Namespace
+	if parentRef.Namespace != nil {
+		namespace = string(*parentRef.Namespace)
+	}
+	return akogatewayapilib.IsGatewayInDedicatedMode(namespace, string(parentRef.Name))
+}
+
+// getBackendRefTargetPort returns the numeric target port of a Service backend, 0 is returned
+// when the target port can not be resolved from the Service, e.g. a named target port.
+func getBackendRefTargetPort(key, namespace string, backendRef gatewayv1.BackendObjectReference) int32 {
+	if backendRef.Port == nil || (backendRef.Kind != nil && string(*backendRef.Kind) != utils.Service) {
+		return 0
+	}
+	if backendRef.Namespace != nil {
+		namespace = string(*backendRef.Namespace)
+	}
+	svcObj, err := utils.GetInformers().ServiceInformer.Lister().Services(namespace).Get(string(backendRef.Name))
+	if err != nil {
+		utils.AviLog.Debugf("key: %s, msg: unable to retrieve the service %s/%s, err: %v", key, namespace, backendRef.Name, err)
+		return 0
+	}
+	for _, port := range svcObj.Spec.Ports {
+		if port.Port != int32(*backendRef.Port) {
+			continue
+		}
+		if port.TargetPort.Type != intstr.Int {
+			return 0
+		}
+		if port.TargetPort.IntVal == 0 {
+			return port.Port
+		}
+		return port.TargetPort.IntVal
+	}
+	return 0
+}
+
+func isFullRequestMirror(requestMirror *gatewayv1.HTTPRequestMirrorFilter) bool {
+	if requestMirror.Percent != nil {
+		return *requestMirror.Percent == 100
+	}
+	if requestMirror.Fraction != nil {
+		denominator := int32(100)
+		if requestMirror.Fraction.Denominator != nil {
+			denominator = *requestMirror.Fraction.Denominator
+		}
+		return requestMirror.Fraction.Numerator == denominator
+	}
 	return true
 }
 
-func setRouteConditionInHTTPRouteStatus(key, reason, msg string, httpRoute *gatewayv1.HTTPRoute, httpRouteStatus *gatewayv1.HTTPRouteStatus, conditionStatus, conditionType string) {
-	for parentRefIndexFromSpec := range httpRoute.Spec.ParentRefs {
+func validateL7RouteRules(key string, route l7RouteObject, httpRouteStatus *gatewayv1.HTTPRouteStatus) bool {
+	switch obj := route.(type) {
+	case *gatewayv1.HTTPRoute:
+		return validateHTTPRouteRules(key, obj, httpRouteStatus)
+	case *gatewayv1.GRPCRoute:
+		return validateGRPCRouteRules(key, obj, httpRouteStatus)
+	}
+	return false
+}
+
+// validateGRPCRouteRules allows only the header modifier filters on the GRPCRoute rules, as the other filters
+// and the session persistence are not translated to the child virtual service.
+func validateGRPCRouteRules(key string, grpcRoute *gatewayv1.GRPCRoute, httpRouteStatus *gatewayv1.HTTPRouteStatus) bool {
+	ruleNames := make([]*gatewayv1.SectionName, 0, len(grpcRoute.Spec.Rules))
+	ruleErrs := make(map[int]*ruleValidationError)
+	for i, rule := range grpcRoute.Spec.Rules {
+		ruleNames = append(ruleNames, rule.Name)
+		if ruleErr := validateGRPCRouteRule(rule); ruleErr != nil {
+			ruleErrs[i] = ruleErr
+		}
+	}
+	return processRuleValidationErrors(key, grpcRoute, ruleNames, ruleErrs, httpRouteStatus)
+}
+
+func validateGRPCRouteRule(rule gatewayv1.GRPCRouteRule) *ruleValidationError {
+	for _, filter := range rule.Filters {
+		if filter.Type != gatewayv1.GRPCRouteFilterRequestHeaderModifier && filter.Type != gatewayv1.GRPCRouteFilterResponseHeaderModifier {
+			return newRuleValidationError(gatewayv1.RouteConditionAccepted, gatewayv1.RouteReasonUnsupportedValue,
+				fmt.Sprintf("GRPCRoute filter type %s is not supported", filter.Type))
+		}
+	}
+	for _, backendRef := range rule.BackendRefs {
+		if len(backendRef.Filters) > 0 {
+			return newRuleValidationError(gatewayv1.RouteConditionAccepted, gatewayv1.RouteReasonUnsupportedValue,
+				"GRPCRoute backendRef filters are not supported")
+		}
+	}
+	if rule.SessionPersistence != nil {
+		return newRuleValidationError(gatewayv1.RouteConditionAccepted, gatewayv1.RouteReasonUnsupportedValue,
+			"SessionPersistence is not supported for GRPCRoute")
+	}
+	return nil
+}
+
+func setRouteConditionInHTTPRouteStatus(key, reason, msg string, route l7RouteObject, httpRouteStatus *gatewayv1.HTTPRouteStatus, conditionStatus, conditionType string) {
+	routeType, spec, _ := getL7RouteSpec(route)
+	for parentRefIndexFromSpec := range spec.ParentRefs {
 		// creates the Parent status only when the AKO is the gateway controller
-		name := string(httpRoute.Spec.ParentRefs[parentRefIndexFromSpec].Name)
-		namespace := httpRoute.Namespace
-		if httpRoute.Spec.ParentRefs[parentRefIndexFromSpec].Namespace != nil {
-			namespace = string(*httpRoute.Spec.ParentRefs[parentRefIndexFromSpec].Namespace)
+		name := string(spec.ParentRefs[parentRefIndexFromSpec].Name)
+		namespace := route.GetNamespace()
+		if spec.ParentRefs[parentRefIndexFromSpec].Namespace != nil {
+			namespace = string(*spec.ParentRefs[parentRefIndexFromSpec].Namespace)
 		}
 		obj, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayInformer.Lister().Gateways(namespace).Get(name)
 		if err != nil {
@@ -355,30 +674,32 @@ func setRouteConditionInHTTPRouteStatus(key, reason, msg string, httpRoute *gate
 		gwClass := string(gateway.Spec.GatewayClassName)
 		_, isAKOCtrl := akogatewayapiobjects.GatewayApiLister().IsGatewayClassControllerAKO(gwClass)
 		if !isAKOCtrl {
-			utils.AviLog.Warnf("key: %s, msg: controller for the parent reference %s of HTTPRoute object %s is not ako", key, name, httpRoute.Name)
+			utils.AviLog.Warnf("key: %s, msg: controller for the parent reference %s of %s object %s is not ako", key, name, routeType, route.GetName())
 			continue
 		}
 
-		httpRouteStatus.Parents = append(httpRouteStatus.Parents, gatewayv1.RouteParentStatus{})
+		if len(httpRouteStatus.Parents) <= parentRefIndexFromSpec {
+			httpRouteStatus.Parents = append(httpRouteStatus.Parents, gatewayv1.RouteParentStatus{})
+		}
 		httpRouteStatus.Parents[parentRefIndexFromSpec].ControllerName = akogatewayapilib.GatewayController
 		httpRouteStatus.Parents[parentRefIndexFromSpec].ParentRef.Name = gatewayv1.ObjectName(name)
 		httpRouteStatus.Parents[parentRefIndexFromSpec].ParentRef.Namespace = (*gatewayv1.Namespace)(&namespace)
-		if httpRoute.Spec.ParentRefs[parentRefIndexFromSpec].SectionName != nil {
-			httpRouteStatus.Parents[parentRefIndexFromSpec].ParentRef.SectionName = httpRoute.Spec.ParentRefs[parentRefIndexFromSpec].SectionName
+		if spec.ParentRefs[parentRefIndexFromSpec].SectionName != nil {
+			httpRouteStatus.Parents[parentRefIndexFromSpec].ParentRef.SectionName = spec.ParentRefs[parentRefIndexFromSpec].SectionName
 		}
-		if conditionType == string(gatewayv1.RouteConditionResolvedRefs) {
-			routeResolvedRef := akogatewayapistatus.NewCondition().
-				Type(string(gatewayv1.RouteConditionResolvedRefs)).
+		if conditionType == string(gatewayv1.RouteConditionResolvedRefs) || conditionType == string(gatewayv1.RouteConditionPartiallyInvalid) {
+			routeCondition := akogatewayapistatus.NewCondition().
+				Type(conditionType).
 				Status(metav1.ConditionStatus(conditionStatus)).
-				ObservedGeneration(httpRoute.ObjectMeta.Generation).
+				ObservedGeneration(route.GetGeneration()).
 				Reason(reason).
 				Message(msg)
-			routeResolvedRef.SetIn(&httpRouteStatus.Parents[parentRefIndexFromSpec].Conditions)
+			routeCondition.SetIn(&httpRouteStatus.Parents[parentRefIndexFromSpec].Conditions)
 		} else {
 			routeConditionAccepted := akogatewayapistatus.NewCondition().
 				Type(string(gatewayv1.RouteConditionAccepted)).
 				Status(metav1.ConditionFalse).
-				ObservedGeneration(httpRoute.ObjectMeta.Generation).
+				ObservedGeneration(route.GetGeneration()).
 				Reason(reason).
 				Message(msg)
 			routeConditionAccepted.SetIn(&httpRouteStatus.Parents[parentRefIndexFromSpec].Conditions)
@@ -408,7 +729,25 @@ func setResolvedRefConditionInHTTPRouteStatus(key string, routeConditionResolved
 			routeConditionResolvedRef.SetIn(&httpRouteStatus.Parents[parentRefIndex].Conditions)
 		}
 	}
-	_, namespace, name := lib.ExtractTypeNameNamespace(routeTypeNamespaceName)
+	routeType, namespace, name := lib.ExtractTypeNameNamespace(routeTypeNamespaceName)
+	if akogatewayapilib.IsL4RouteType(routeType) {
+		route, err := getL4RouteObject(routeType, namespace, name)
+		if err != nil {
+			utils.AviLog.Warnf("key: %s, msg: Unable to extract the %s object %s for BackendRef validation", key, routeType, name)
+			return
+		}
+		akogatewayapistatus.Record(key, route, &status.Status{HTTPRouteStatus: httpRouteStatus})
+		return
+	}
+	if routeType == lib.GRPCRoute {
+		grpcRoute, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GRPCRouteInformer.Lister().GRPCRoutes(namespace).Get(name)
+		if err != nil {
+			utils.AviLog.Warnf("key: %s, msg: Unable to extract the GRPCRoute object %s for BackendRef validation", key, name)
+			return
+		}
+		akogatewayapistatus.Record(key, grpcRoute, &status.Status{HTTPRouteStatus: httpRouteStatus})
+		return
+	}
 	httpRoute, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().HTTPRouteInformer.Lister().HTTPRoutes(namespace).Get(name)
 	if err != nil {
 		utils.AviLog.Warnf("key: %s, msg: Unable to extract the HTTPRoute object %s for BackendRef validation", key, name)
@@ -417,12 +756,34 @@ func setResolvedRefConditionInHTTPRouteStatus(key string, routeConditionResolved
 	akogatewayapistatus.Record(key, httpRoute, &status.Status{HTTPRouteStatus: httpRouteStatus})
 }
 
-func validateParentReference(key string, httpRoute *gatewayv1.HTTPRoute, httpRouteStatus *gatewayv1.HTTPRouteStatus, parentRefIndexFromSpec int, parentRefIndexInHttpRouteStatus *int, indexInCache *int) error {
+// getL7RouteSpec returns the route kind, the common spec and the hostnames of an HTTPRoute or a GRPCRoute.
+func getL7RouteSpec(route l7RouteObject) (string, *gatewayv1.CommonRouteSpec, []gatewayv1.Hostname) {
+	switch obj := route.(type) {
+	case *gatewayv1.HTTPRoute:
+		return lib.HTTPRoute, &obj.Spec.CommonRouteSpec, obj.Spec.Hostnames
+	case *gatewayv1.GRPCRoute:
+		return lib.GRPCRoute, &obj.Spec.CommonRouteSpec, obj.Spec.Hostnames
+	}
+	return "", &gatewayv1.CommonRouteSpec{}, nil
+}
 
-	name := string(httpRoute.Spec.ParentRefs[parentRefIndexFromSpec].Name)
-	namespace := httpRoute.Namespace
-	if httpRoute.Spec.ParentRefs[parentRefIndexFromSpec].Namespace != nil {
-		namespace = string(*httpRoute.Spec.ParentRefs[parentRefIndexFromSpec].Namespace)
+// getL7RouteStatus returns a copy of the status of an HTTPRoute or a GRPCRoute in the HTTPRoute status form.
+func getL7RouteStatus(route l7RouteObject) *gatewayv1.HTTPRouteStatus {
+	switch obj := route.(type) {
+	case *gatewayv1.HTTPRoute:
+		return obj.Status.DeepCopy()
+	case *gatewayv1.GRPCRoute:
+		return &gatewayv1.HTTPRouteStatus{RouteStatus: *obj.Status.RouteStatus.DeepCopy()}
+	}
+	return &gatewayv1.HTTPRouteStatus{}
+}
+
+func validateParentReference(key string, route l7RouteObject, httpRouteStatus *gatewayv1.HTTPRouteStatus, parentRefIndexFromSpec int, parentRefIndexInHttpRouteStatus *int, indexInCache *int) error {
+	routeType, spec, hostnames := getL7RouteSpec(route)
+	name := string(spec.ParentRefs[parentRefIndexFromSpec].Name)
+	namespace := route.GetNamespace()
+	if spec.ParentRefs[parentRefIndexFromSpec].Namespace != nil {
+		namespace = string(*spec.ParentRefs[parentRefIndexFromSpec].Namespace)
 	}
 	gwNsName := namespace + "/" + name
 	obj, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayInformer.Lister().Gateways(namespace).Get(name)
@@ -435,8 +796,8 @@ func validateParentReference(key string, httpRoute *gatewayv1.HTTPRoute, httpRou
 	gwClass := string(gateway.Spec.GatewayClassName)
 	_, isAKOCtrl := akogatewayapiobjects.GatewayApiLister().IsGatewayClassControllerAKO(gwClass)
 	if !isAKOCtrl {
-		utils.AviLog.Warnf("key: %s, msg: controller for the parent reference %s of HTTPRoute object %s is not ako", key, name, httpRoute.Name)
-		return fmt.Errorf("controller for the parent reference %s of HTTPRoute object %s is not ako", name, httpRoute.Name)
+		utils.AviLog.Warnf("key: %s, msg: controller for the parent reference %s of %s object %s is not ako", key, name, routeType, route.GetName())
+		return fmt.Errorf("controller for the parent reference %s of %s object %s is not ako", name, routeType, route.GetName())
 	}
 	// creates the Parent status only when the AKO is the gateway controller
 	if len(httpRouteStatus.Parents) <= *parentRefIndexInHttpRouteStatus {
@@ -445,10 +806,10 @@ func validateParentReference(key string, httpRoute *gatewayv1.HTTPRoute, httpRou
 	httpRouteStatus.Parents[*parentRefIndexInHttpRouteStatus].ControllerName = akogatewayapilib.GatewayController
 	httpRouteStatus.Parents[*parentRefIndexInHttpRouteStatus].ParentRef.Name = gatewayv1.ObjectName(name)
 	httpRouteStatus.Parents[*parentRefIndexInHttpRouteStatus].ParentRef.Namespace = (*gatewayv1.Namespace)(&namespace)
-	if httpRoute.Spec.ParentRefs[parentRefIndexFromSpec].SectionName != nil {
-		httpRouteStatus.Parents[*parentRefIndexInHttpRouteStatus].ParentRef.SectionName = httpRoute.Spec.ParentRefs[parentRefIndexFromSpec].SectionName
+	if spec.ParentRefs[parentRefIndexFromSpec].SectionName != nil {
+		httpRouteStatus.Parents[*parentRefIndexInHttpRouteStatus].ParentRef.SectionName = spec.ParentRefs[parentRefIndexFromSpec].SectionName
 	}
-	routeTypeNsName := lib.HTTPRoute + "/" + httpRoute.Namespace + "/" + httpRoute.Name
+	routeTypeNsName := routeType + "/" + route.GetNamespace() + "/" + route.GetName()
 	routeStatusInCache := akogatewayapiobjects.GatewayApiLister().GetRouteToRouteStatusMapping(routeTypeNsName)
 	if *indexInCache != -1 && routeStatusInCache != nil {
 		if *indexInCache < len(routeStatusInCache.Parents) {
@@ -460,7 +821,7 @@ func validateParentReference(key string, httpRoute *gatewayv1.HTTPRoute, httpRou
 	defaultCondition := akogatewayapistatus.NewCondition().
 		Type(string(gatewayv1.RouteConditionAccepted)).
 		Status(metav1.ConditionFalse).
-		ObservedGeneration(httpRoute.ObjectMeta.Generation)
+		ObservedGeneration(route.GetGeneration())
 
 	gwStatus := akogatewayapiobjects.GatewayApiLister().GetGatewayToGatewayStatusMapping(gwNsName)
 	if len(gwStatus.Conditions) == 0 {
@@ -477,15 +838,18 @@ func validateParentReference(key string, httpRoute *gatewayv1.HTTPRoute, httpRou
 	dedicatedGatewayMode := akogatewayapilib.IsGatewayInDedicatedMode(namespace, name)
 	if dedicatedGatewayMode {
 		var err error
-		if len(httpRoute.Spec.ParentRefs) > 1 {
-			utils.AviLog.Errorf("key: %s, msg: Dedicated Gateway Mode is enabled. Only one parent reference is allowed in HTTPRoute %s", key, httpRoute.Name)
-			err = fmt.Errorf("Dedicated Gateway Mode is enabled. Only one parent reference is allowed in HTTPRoute")
-		} else if httpRoute.Spec.ParentRefs[0].Namespace != nil && string(*httpRoute.Spec.ParentRefs[0].Namespace) != namespace {
-			utils.AviLog.Errorf("key: %s, msg: Dedicated Gateway Mode is enabled. Parent Reference %s is not in the same namespace as HTTPRoute %s", key, name, httpRoute.Name)
-			err = fmt.Errorf("Dedicated Gateway Mode is enabled. Parent Reference %s is not in the same namespace as HTTPRoute %s", name, httpRoute.Name)
-		} else if len(httpRoute.Spec.Hostnames) > 0 {
-			utils.AviLog.Errorf("key: %s, msg: Dedicated Gateway Mode is enabled. Hostnames are not allowed in HTTPRoute %s", key, httpRoute.Name)
-			err = fmt.Errorf("Dedicated Gateway Mode is enabled. Hostnames are not allowed in HTTPRoute")
+		if routeType == lib.GRPCRoute {
+			utils.AviLog.Errorf("key: %s, msg: Dedicated Gateway Mode is enabled. GRPCRoute %s is not supported in dedicated mode", key, route.GetName())
+			err = fmt.Errorf("Dedicated Gateway Mode is enabled. GRPCRoute is not supported in dedicated mode")
+		} else if len(spec.ParentRefs) > 1 {
+			utils.AviLog.Errorf("key: %s, msg: Dedicated Gateway Mode is enabled. Only one parent reference is allowed in %s %s", key, routeType, route.GetName())
+			err = fmt.Errorf("Dedicated Gateway Mode is enabled. Only one parent reference is allowed in %s", routeType)
+		} else if spec.ParentRefs[0].Namespace != nil && string(*spec.ParentRefs[0].Namespace) != namespace {
+			utils.AviLog.Errorf("key: %s, msg: Dedicated Gateway Mode is enabled. Parent Reference %s is not in the same namespace as %s %s", key, name, routeType, route.GetName())
+			err = fmt.Errorf("Dedicated Gateway Mode is enabled. Parent Reference %s is not in the same namespace as %s %s", name, routeType, route.GetName())
+		} else if len(hostnames) > 0 {
+			utils.AviLog.Errorf("key: %s, msg: Dedicated Gateway Mode is enabled. Hostnames are not allowed in %s %s", key, routeType, route.GetName())
+			err = fmt.Errorf("Dedicated Gateway Mode is enabled. Hostnames are not allowed in %s", routeType)
 		}
 		if err != nil {
 			defaultCondition.
@@ -497,11 +861,11 @@ func validateParentReference(key string, httpRoute *gatewayv1.HTTPRoute, httpRou
 		}
 	}
 
-	// If Gateway and HTTPRoute are in different namespace, validate that both namespaces are scoped to the same tenant
-	if httpRoute.Namespace != namespace {
-		if lib.GetTenantInNamespace(httpRoute.Namespace) != lib.GetTenantInNamespace(namespace) {
-			utils.AviLog.Errorf("key: %s, msg: Tenant mismatch between HTTPRoute %s and Parent Reference %s", key, httpRoute.GetName(), name)
-			err := fmt.Errorf("Tenant mismatch between HTTPRoute %s and Parent Reference %s", httpRoute.GetName(), name)
+	// If Gateway and the route are in different namespace, validate that both namespaces are scoped to the same tenant
+	if route.GetNamespace() != namespace {
+		if lib.GetTenantInNamespace(route.GetNamespace()) != lib.GetTenantInNamespace(namespace) {
+			utils.AviLog.Errorf("key: %s, msg: Tenant mismatch between %s %s and Parent Reference %s", key, routeType, route.GetName(), name)
+			err := fmt.Errorf("Tenant mismatch between %s %s and Parent Reference %s", routeType, route.GetName(), name)
 			defaultCondition.
 				Reason(string(gatewayv1.RouteReasonPending)).
 				Message(err.Error()).
@@ -527,8 +891,8 @@ func validateParentReference(key string, httpRoute *gatewayv1.HTTPRoute, httpRou
 
 	//section name is optional
 	var listenersForRoute []gatewayv1.Listener
-	if httpRoute.Spec.ParentRefs[parentRefIndexFromSpec].SectionName != nil {
-		listenerName := *httpRoute.Spec.ParentRefs[parentRefIndexFromSpec].SectionName
+	if spec.ParentRefs[parentRefIndexFromSpec].SectionName != nil {
+		listenerName := *spec.ParentRefs[parentRefIndexFromSpec].SectionName
 		i := akogatewayapilib.FindListenerByName(string(listenerName), gateway.Spec.Listeners)
 		if i == -1 {
 			// listener is not present in gateway
@@ -560,6 +924,10 @@ func validateParentReference(key string, httpRoute *gatewayv1.HTTPRoute, httpRou
 	// Here I need to check
 	var listenersMatchedToRoute []gatewayv1.Listener
 	for _, listenerObj := range listenersForRoute {
+		// TCP, UDP and TLS listeners carry L4 routes only
+		if !akogatewayapilib.IsSupportedRouteKind(listenerObj.Protocol, routeType, dedicatedGatewayMode) {
+			continue
+		}
 		// check from store
 		hostInListener := listenerObj.Hostname
 		isListenerFqdnWildcard := false
@@ -569,7 +937,7 @@ func validateParentReference(key string, httpRoute *gatewayv1.HTTPRoute, httpRou
 		// USe case 1: Shouldn't contain mor than 1 *
 		// USe case 2: * should be at the beginning only
 		if hostInListener == nil || *hostInListener == "" || *hostInListener == utils.WILDCARD {
-			if len(httpRoute.Spec.Hostnames) != 0 || dedicatedGatewayMode {
+			if len(hostnames) != 0 || dedicatedGatewayMode {
 				matched = true
 			}
 		} else {
@@ -577,7 +945,7 @@ func validateParentReference(key string, httpRoute *gatewayv1.HTTPRoute, httpRou
 			if hostInListener != nil && strings.HasPrefix(string(*hostInListener), utils.WILDCARD) {
 				isListenerFqdnWildcard = true
 			}
-			for _, host := range httpRoute.Spec.Hostnames {
+			for _, host := range hostnames {
 				// casese to consider:
 				// Case 1: hostname of gateway is wildcard(empty) and hostname from httproute is not wild card
 				// Case 2: hostname of gateway is not wild card and hostname from httproute is wildcard
@@ -615,28 +983,28 @@ func validateParentReference(key string, httpRoute *gatewayv1.HTTPRoute, httpRou
 
 			}
 			// if there are no hostnames specified, all parent listneres should be matched.
-			if len(httpRoute.Spec.Hostnames) == 0 && (hostInListener != nil && *hostInListener != "" && *hostInListener != utils.WILDCARD) {
+			if len(hostnames) == 0 && (hostInListener != nil && *hostInListener != "" && *hostInListener != utils.WILDCARD) {
 				matched = true
 			}
 		}
 		if !matched {
-			utils.AviLog.Warnf("key: %s, msg: Gateway object %s don't have any listeners that matches the hostnames in HTTPRoute %s", key, gateway.Name, httpRoute.Name)
+			utils.AviLog.Warnf("key: %s, msg: Gateway object %s don't have any listeners that matches the hostnames in %s %s", key, gateway.Name, routeType, route.GetName())
 			continue
 		}
 		listenersMatchedToRoute = append(listenersMatchedToRoute, listenerObj)
 	}
 	if len(listenersMatchedToRoute) == 0 {
-		err := fmt.Errorf("Hostname in Gateway Listener doesn't match with any of the hostnames in HTTPRoute")
+		err := fmt.Errorf("Hostname in Gateway Listener doesn't match with any of the hostnames in %s", routeType)
 		defaultCondition.
 			Reason(string(gatewayv1.RouteReasonNoMatchingListenerHostname)).
 			Message(err.Error()).
 			SetIn(&httpRouteStatus.Parents[*parentRefIndexInHttpRouteStatus].Conditions)
 		*parentRefIndexInHttpRouteStatus = *parentRefIndexInHttpRouteStatus + 1
-		gwRouteNsName := fmt.Sprintf("%s/%s/%s/%s", gwNsName, lib.HTTPRoute, httpRoute.Namespace, httpRoute.Name)
+		gwRouteNsName := fmt.Sprintf("%s/%s/%s/%s", gwNsName, routeType, route.GetNamespace(), route.GetName())
 		found, hosts := akogatewayapiobjects.GatewayApiLister().GetGatewayRouteToHostname(gwRouteNsName)
 		if found {
-			utils.AviLog.Warnf("key: %s, msg: Hostname in Gateway Listener doesn't match with any of the hostnames in HTTPRoute", key)
-			utils.AviLog.Debugf("key: %s, msg: %d hosts mapped to the route %s/%s/%s", key, len(hosts), "HTTPRoute", httpRoute.Namespace, httpRoute.Name)
+			utils.AviLog.Warnf("key: %s, msg: Hostname in Gateway Listener doesn't match with any of the hostnames in %s", key, routeType)
+			utils.AviLog.Debugf("key: %s, msg: %d hosts mapped to the route %s/%s/%s", key, len(hosts), routeType, route.GetNamespace(), route.GetName())
 			return nil
 		}
 		return err
@@ -683,7 +1051,363 @@ func validateParentReference(key string, httpRoute *gatewayv1.HTTPRoute, httpRou
 		Status(metav1.ConditionTrue).
 		Message("Parent reference is valid").
 		SetIn(&httpRouteStatus.Parents[*parentRefIndexInHttpRouteStatus].Conditions)
-	utils.AviLog.Infof("key: %s, msg: Parent Reference %s of HTTPRoute object %s is valid", key, name, httpRoute.Name)
+	utils.AviLog.Infof("key: %s, msg: Parent Reference %s of %s object %s is valid", key, name, routeType, route.GetName())
 	*parentRefIndexInHttpRouteStatus = *parentRefIndexInHttpRouteStatus + 1
 	return nil
 }
+
+func getL4RouteSpecAndStatus(route l4RouteObject) (*gatewayv1.CommonRouteSpec, *gatewayv1.RouteStatus) {
+	switch obj := route.(type) {
+	case *gatewayv1alpha2.TCPRoute:
+		return &obj.Spec.CommonRouteSpec, &obj.Status.RouteStatus
+	case *gatewayv1alpha2.UDPRoute:
+		return &obj.Spec.CommonRouteSpec, &obj.Status.RouteStatus
+	case *gatewayv1alpha2.TLSRoute:
+		return &obj.Spec.CommonRouteSpec, &obj.Status.RouteStatus
+	}
+	return &gatewayv1.CommonRouteSpec{}, &gatewayv1.RouteStatus{}
+}
+
+// IsL4RouteValid validates the parent references of a TCPRoute, UDPRoute or TLSRoute and records its status.
+// The route status is stored in the same form as the HTTPRoute status as both share the RouteStatus.
+func IsL4RouteValid(key, routeType string, route l4RouteObject) bool {
+	spec, currentStatus := getL4RouteSpecAndStatus(route)
+	routeStatus := &gatewayv1.HTTPRouteStatus{RouteStatus: *currentStatus.DeepCopy()}
+	routeStatus.Parents = make([]gatewayv1.RouteParentStatus, 0, len(spec.ParentRefs))
+	var invalidParentRefCount int
+	for parentRefIndex := range spec.ParentRefs {
+		err := validateL4ParentReference(key, routeType, route, spec, parentRefIndex, routeStatus)
+		if err != nil {
+			invalidParentRefCount++
+			utils.AviLog.Warnf("key: %s, msg: Parent Reference %s of %s object %s is not valid, err: %v", key, spec.ParentRefs[parentRefIndex].Name, routeType, route.GetName(), err)
+		}
+	}
+	setL4RouteDroppedRulesCondition(key, routeType, route, routeStatus)
+
+	akogatewayapistatus.Record(key, route, &status.Status{HTTPRouteStatus: routeStatus})
+
+	// No valid attachment, we can't proceed with this route object.
+	if invalidParentRefCount == len(spec.ParentRefs) {
+		utils.AviLog.Errorf("key: %s, msg: %s object %s is not valid", key, routeType, route.GetName())
+		akogatewayapilib.AKOControlConfig().EventRecorder().Eventf(route, corev1.EventTypeWarning,
+			lib.Detached, "%s object %s is not valid", routeType, route.GetName())
+		return false
+	}
+	utils.AviLog.Infof("key: %s, msg: %s object %s is valid", key, routeType, route.GetName())
+	return true
+}
+
+// setL4RouteDroppedRulesCondition marks the accepted parents of the route with the PartiallyInvalid condition when
+// the route has more than one rule. Traffic on a listener port can be sent to a single set of backends, so only the
+// first rule of the route is honoured and the rest are dropped.
+func setL4RouteDroppedRulesCondition(key, routeType string, route l4RouteObject, routeStatus *gatewayv1.HTTPRouteStatus) {
+	rules := getL4RouteRules(route)
+	if len(rules) <= 1 {
+		return
+	}
+	droppedRules := make([]string, 0, len(rules)-1)
+	for i := 1; i < len(rules); i++ {
+		ruleName := fmt.Sprintf("rules[%d]", i)
+		if rules[i].name != nil {
+			ruleName = string(*rules[i].name)
+		}
+		droppedRules = append(droppedRules, ruleName)
+	}
+	msg := fmt.Sprintf("Dropped Rule(s): %s: only a single rule is supported for %s", strings.Join(droppedRules, ", "), routeType)
+	utils.AviLog.Warnf("key: %s, msg: %s %s/%s: %s", key, routeType, route.GetNamespace(), route.GetName(), msg)
+	for i := range routeStatus.Parents {
+		accepted := apimeta.FindStatusCondition(routeStatus.Parents[i].Conditions, string(gatewayv1.RouteConditionAccepted))
+		if accepted == nil || accepted.Status != metav1.ConditionTrue {
+			continue
+		}
+		parentMsg := msg
+		// keep the hostnames ignored for the parent, which are reported in the same condition
+		if partiallyInvalid := apimeta.FindStatusCondition(routeStatus.Parents[i].Conditions, string(gatewayv1.RouteConditionPartiallyInvalid)); partiallyInvalid != nil {
+			parentMsg = partiallyInvalid.Message + "; " + msg
+		}
+		akogatewayapistatus.NewCondition().
+			Type(string(gatewayv1.RouteConditionPartiallyInvalid)).
+			Status(metav1.ConditionTrue).
+			ObservedGeneration(route.GetGeneration()).
+			Reason(string(gatewayv1.RouteReasonUnsupportedValue)).
+			Message(parentMsg).
+			SetIn(&routeStatus.Parents[i].Conditions)
+	}
+}
+
+func validateL4ParentReference(key, routeType string, route l4RouteObject, spec *gatewayv1.CommonRouteSpec, parentRefIndex int, routeStatus *gatewayv1.HTTPRouteStatus) error {
+	parentRef := spec.ParentRefs[parentRefIndex]
+	name := string(parentRef.Name)
+	namespace := route.GetNamespace()
+	if parentRef.Namespace != nil {
+		namespace = string(*parentRef.Namespace)
+	}
+	gwNsName := namespace + "/" + name
+	obj, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GatewayInformer.Lister().Gateways(namespace).Get(name)
+	if err != nil {
+		utils.AviLog.Errorf("key: %s, msg: unable to get the gateway object. err: %s", key, err)
+		return err
+	}
+	gateway := obj.DeepCopy()
+
+	gwClass := string(gateway.Spec.GatewayClassName)
+	_, isAKOCtrl := akogatewayapiobjects.GatewayApiLister().IsGatewayClassControllerAKO(gwClass)
+	if !isAKOCtrl {
+		utils.AviLog.Warnf("key: %s, msg: controller for the parent reference %s of %s object %s is not ako", key, name, routeType, route.GetName())
+		return fmt.Errorf("controller for the parent reference %s of %s object %s is not ako", name, routeType, route.GetName())
+	}
+	// creates the Parent status only when the AKO is the gateway controller
+	parentStatus := gatewayv1.RouteParentStatus{ControllerName: akogatewayapilib.GatewayController}
+	parentStatus.ParentRef.Name = gatewayv1.ObjectName(name)
+	parentStatus.ParentRef.Namespace = (*gatewayv1.Namespace)(&namespace)
+	parentStatus.ParentRef.SectionName = parentRef.SectionName
+	parentStatus.ParentRef.Port = parentRef.Port
+	routeStatus.Parents = append(routeStatus.Parents, parentStatus)
+	parentStatusIndex := len(routeStatus.Parents) - 1
+
+	defaultCondition := akogatewayapistatus.NewCondition().
+		Type(string(gatewayv1.RouteConditionAccepted)).
+		Status(metav1.ConditionFalse).
+		ObservedGeneration(route.GetGeneration())
+
+	gwStatus := akogatewayapiobjects.GatewayApiLister().GetGatewayToGatewayStatusMapping(gwNsName)
+	if len(gwStatus.Conditions) == 0 {
+		// Gateway processing by AKO has not started.
+		utils.AviLog.Errorf("key: %s, msg: AKO is yet to process Gateway %s for parent reference %s.", key, gateway.Name, name)
+		err := fmt.Errorf("AKO is yet to process Gateway %s for parent reference %s", gateway.Name, name)
+		defaultCondition.
+			Reason(string(gatewayv1.RouteReasonPending)).
+			Message(err.Error()).
+			SetIn(&routeStatus.Parents[parentStatusIndex].Conditions)
+		return err
+	}
+
+	if akogatewayapilib.IsGatewayInDedicatedMode(namespace, name) {
+		utils.AviLog.Errorf("key: %s, msg: Dedicated Gateway Mode is enabled for Gateway %s. %s is not supported", key, gateway.Name, routeType)
+		err := fmt.Errorf("Dedicated Gateway Mode is enabled. %s is not supported", routeType)
+		defaultCondition.
+			Reason(string(gatewayv1.RouteReasonUnsupportedValue)).
+			Message(err.Error()).
+			SetIn(&routeStatus.Parents[parentStatusIndex].Conditions)
+		return err
+	}
+
+	// If Gateway and route are in different namespace, validate that both namespaces are scoped to the same tenant
+	if route.GetNamespace() != namespace {
+		if lib.GetTenantInNamespace(route.GetNamespace()) != lib.GetTenantInNamespace(namespace) {
+			utils.AviLog.Errorf("key: %s, msg: Tenant mismatch between %s %s and Parent Reference %s", key, routeType, route.GetName(), name)
+			err := fmt.Errorf("Tenant mismatch between %s %s and Parent Reference %s", routeType, route.GetName(), name)
+			defaultCondition.
+				Reason(string(gatewayv1.RouteReasonPending)).
+				Message(err.Error()).
+				SetIn(&routeStatus.Parents[parentStatusIndex].Conditions)
+			return err
+		}
+	}
+
+	// Attach only when gateway configuration is valid
+	if gwStatus.Conditions[0].Status != metav1.ConditionTrue {
+		utils.AviLog.Errorf("key: %s, msg: Gateway %s for parent reference %s is in Invalid State", key, gateway.Name, name)
+		err := fmt.Errorf("Gateway %s is in Invalid State", gateway.Name)
+		defaultCondition.
+			Reason(string(gatewayv1.RouteReasonPending)).
+			Message(err.Error()).
+			SetIn(&routeStatus.Parents[parentStatusIndex].Conditions)
+		return err
+	}
+
+	if parentRef.SectionName != nil {
+		i := akogatewayapilib.FindListenerByName(string(*parentRef.SectionName), gateway.Spec.Listeners)
+		if i == -1 {
+			utils.AviLog.Errorf("key: %s, msg: unable to find the listener from the Section Name %s in Parent Reference %s", key, *parentRef.SectionName, name)
+			err := fmt.Errorf("Invalid listener name provided")
+			defaultCondition.
+				Reason(string(gatewayv1.RouteReasonNoMatchingParent)).
+				Message(err.Error()).
+				SetIn(&routeStatus.Parents[parentStatusIndex].Conditions)
+			return err
+		}
+		if akogatewayapilib.IsListenerInvalid(gwStatus, i) {
+			utils.AviLog.Errorf("key: %s, msg: Matching gateway listener %s in Parent Reference is in invalid state", key, *parentRef.SectionName)
+			err := fmt.Errorf("Matching gateway listener is in Invalid state")
+			defaultCondition.
+				Reason(string(gatewayv1.RouteReasonPending)).
+				Message(err.Error()).
+				SetIn(&routeStatus.Parents[parentStatusIndex].Conditions)
+			return err
+		}
+	}
+
+	var listenersMatchedToRoute []gatewayv1.Listener
+	for i, listenerObj := range gateway.Spec.Listeners {
+		if parentRef.SectionName != nil && *parentRef.SectionName != listenerObj.Name {
+			continue
+		}
+		if parentRef.Port != nil && *parentRef.Port != listenerObj.Port {
+			continue
+		}
+		if akogatewayapilib.IsListenerInvalid(gwStatus, i) || !isRouteKindAllowedByListener(listenerObj, routeType) {
+			continue
+		}
+		listenersMatchedToRoute = append(listenersMatchedToRoute, listenerObj)
+	}
+	if len(listenersMatchedToRoute) == 0 {
+		utils.AviLog.Errorf("key: %s, msg: Gateway %s does not have a listener which allows %s %s", key, gateway.Name, routeType, route.GetName())
+		err := fmt.Errorf("No listener of Gateway %s allows %s", gateway.Name, routeType)
+		defaultCondition.
+			Reason(string(gatewayv1.RouteReasonNotAllowedByListeners)).
+			Message(err.Error()).
+			SetIn(&routeStatus.Parents[parentStatusIndex].Conditions)
+		return err
+	}
+
+	var unsupportedHostnames []string
+	if tlsRoute, ok := route.(*gatewayv1alpha2.TLSRoute); ok {
+		var reason gatewayv1.RouteConditionReason
+		var err error
+		listenersMatchedToRoute, unsupportedHostnames, reason, err = validateTLSRouteHostnames(key, tlsRoute, gateway.Name, listenersMatchedToRoute)
+		if err != nil {
+			defaultCondition.
+				Reason(string(reason)).
+				Message(err.Error()).
+				SetIn(&routeStatus.Parents[parentStatusIndex].Conditions)
+			return err
+		}
+	}
+
+	gatewayStatus := gwStatus.DeepCopy()
+	for _, listenerObj := range listenersMatchedToRoute {
+		i := akogatewayapilib.FindListenerStatusByName(string(listenerObj.Name), gatewayStatus.Listeners)
+		if i == -1 {
+			utils.AviLog.Errorf("key: %s, msg: Gateway status is missing for the listener with name %s", key, listenerObj.Name)
+			err := fmt.Errorf("Couldn't find the listener %s in the Gateway status", listenerObj.Name)
+			defaultCondition.
+				Reason(string(gatewayv1.RouteReasonNoMatchingParent)).
+				Message(err.Error()).
+				SetIn(&routeStatus.Parents[parentStatusIndex].Conditions)
+			return err
+		}
+		gatewayStatus.Listeners[i].AttachedRoutes += 1
+	}
+	akogatewayapistatus.Record(key, gateway, &status.Status{GatewayStatus: gatewayStatus})
+
+	defaultCondition.
+		Reason(string(gatewayv1.RouteReasonAccepted)).
+		Status(metav1.ConditionTrue).
+		Message("Parent reference is valid").
+		SetIn(&routeStatus.Parents[parentStatusIndex].Conditions)
+	if len(unsupportedHostnames) != 0 {
+		akogatewayapistatus.NewCondition().
+			Type(string(gatewayv1.RouteConditionPartiallyInvalid)).
+			Status(metav1.ConditionTrue).
+			ObservedGeneration(route.GetGeneration()).
+			Reason(string(gatewayv1.RouteReasonUnsupportedValue)).
+			Message(fmt.Sprintf("Ignored Hostname(s): %s: wildcard hostnames are not supported for TLS passthrough", strings.Join(unsupportedHostnames, ", "))).
+			SetIn(&routeStatus.Parents[parentStatusIndex].Conditions)
+	}
+	utils.AviLog.Infof("key: %s, msg: Parent Reference %s of %s object %s is valid", key, name, routeType, route.GetName())
+	return nil
+}
+
+// isRouteKindAllowedByListener checks the route kind against the allowedRoutes kinds of the listener,
+// the kinds supported for the listener protocol are used when allowedRoutes kinds are not specified.
+func isRouteKindAllowedByListener(listener gatewayv1.Listener, routeType string) bool {
+	if listener.AllowedRoutes == nil || len(listener.AllowedRoutes.Kinds) == 0 {
+		return akogatewayapilib.IsSupportedRouteKind(listener.Protocol, routeType, false)
+	}
+	for _, kind := range listener.AllowedRoutes.Kinds {
+		if string(kind.Kind) == routeType {
+			return true
+		}
+	}
+	return false
+}
+
+// validateTLSRouteHostnames filters the listeners whose hostname matches the hostnames of the TLSRoute.
+// The passthrough datascript selects the backends by an exact match on the SNI, so a listener is attached
+// only if it yields a hostname without wildcard. The matching wildcard hostnames which can not be
+// programmed are returned, so that they are reported in the route status.
+func validateTLSRouteHostnames(key string, tlsRoute *gatewayv1alpha2.TLSRoute, gwName string, listeners []gatewayv1.Listener) ([]gatewayv1.Listener, []string, gatewayv1.RouteConditionReason, error) {
+	var listenersMatchedToRoute []gatewayv1.Listener
+	var unsupportedHostnames []string
+	anyListenerMatched := false
+	for _, listenerObj := range listeners {
+		matched, hostnames, wildcardHostnames := getTLSPassthroughHostnames(key, listenerObj, tlsRoute.Spec.Hostnames)
+		if !matched {
+			continue
+		}
+		anyListenerMatched = true
+		for _, hostname := range wildcardHostnames {
+			if !utils.HasElem(unsupportedHostnames, hostname) {
+				unsupportedHostnames = append(unsupportedHostnames, hostname)
+			}
+		}
+		if len(hostnames) == 0 {
+			utils.AviLog.Warnf("key: %s, msg: listener %s of Gateway %s matches TLSRoute %s only through wildcard hostnames", key, listenerObj.Name, gwName, tlsRoute.Name)
+			continue
+		}
+		listenersMatchedToRoute = append(listenersMatchedToRoute, listenerObj)
+	}
+	if !anyListenerMatched {
+		utils.AviLog.Errorf("key: %s, msg: Gateway %s does not have a listener which matches the hostnames of TLSRoute %s", key, gwName, tlsRoute.Name)
+		return nil, nil, gatewayv1.RouteReasonNoMatchingListenerHostname, fmt.Errorf("Hostnames of TLSRoute do not match any listener of Gateway %s", gwName)
+	}
+	if len(listenersMatchedToRoute) == 0 {
+		utils.AviLog.Errorf("key: %s, msg: TLSRoute %s does not resolve to any hostname without wildcard on Gateway %s", key, tlsRoute.Name, gwName)
+		return nil, nil, gatewayv1.RouteReasonUnsupportedValue, fmt.Errorf("Wildcard hostnames are not supported for TLS passthrough: %s", strings.Join(unsupportedHostnames, ", "))
+	}
+	if len(unsupportedHostnames) != 0 {
+		utils.AviLog.Warnf("key: %s, msg: wildcard hostnames %v of TLSRoute %s are not supported for TLS passthrough on Gateway %s and are ignored", key, unsupportedHostnames, tlsRoute.Name, gwName)
+	}
+	return listenersMatchedToRoute, unsupportedHostnames, "", nil
+}
+
+// getTLSPassthroughHostnames returns whether the listener hostname matches the TLSRoute hostnames and
+// the SNI hostnames to be routed through the listener. Hostnames with wildcard are not routed and are
+// returned separately.
+func getTLSPassthroughHostnames(key string, listener gatewayv1.Listener, routeHostnames []gatewayv1.Hostname) (bool, []string, []string) {
+	var hostnames, wildcardHostnames []string
+	if listener.Hostname == nil || *listener.Hostname == "" || *listener.Hostname == utils.WILDCARD {
+		for _, host := range routeHostnames {
+			if strings.HasPrefix(string(host), utils.WILDCARD) {
+				wildcardHostnames = append(wildcardHostnames, string(host))
+				continue
+			}
+			hostnames = append(hostnames, string(host))
+		}
+		return len(routeHostnames) != 0, hostnames, wildcardHostnames
+	}
+	hostInListener := string(*listener.Hostname)
+	isListenerFqdnWildcard := strings.HasPrefix(hostInListener, utils.WILDCARD)
+	if len(routeHostnames) == 0 {
+		if isListenerFqdnWildcard {
+			return true, nil, []string{hostInListener}
+		}
+		return true, []string{hostInListener}, nil
+	}
+	matched := false
+	for _, host := range routeHostnames {
+		isRouteHostFqdnWildcard := strings.HasPrefix(string(host), utils.WILDCARD)
+		switch {
+		case isRouteHostFqdnWildcard && isListenerFqdnWildcard:
+			if utils.CheckSubdomainOverlapping(string(host), hostInListener) {
+				matched = true
+				wildcardHostnames = append(wildcardHostnames, string(host))
+			}
+		case isRouteHostFqdnWildcard:
+			if isRegexMatch(string(host), hostInListener, key) {
+				matched = true
+				hostnames = append(hostnames, hostInListener)
+			}
+		case isListenerFqdnWildcard:
+			if isRegexMatch(hostInListener, string(host), key) {
+				matched = true
+				hostnames = append(hostnames, string(host))
+			}
+		case string(host) == hostInListener:
+			matched = true
+			hostnames = append(hostnames, string(host))
+		}
+	}
+	return matched, hostnames, wildcardHostnames
+}
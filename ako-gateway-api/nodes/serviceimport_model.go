@@ -0,0 +1,219 @@
+/*
+ * Copyright © 2025 Broadcom Inc. and/or its subsidiaries. All Rights Reserved.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package nodes
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/vmware/alb-sdk/go/models"
+	discovery "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	k8net "k8s.io/utils/net"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// serviceImportCluster holds the endpoints of a ServiceImport backend exported by a cluster.
+type serviceImportCluster struct {
+	Cluster string
+	Port    int32
+	Servers []nodes.AviPoolMetaServer
+}
+
+// getServiceImportClusters returns the endpoints of a ServiceImport backend grouped by the exporting
+// cluster, sorted by the cluster name.
+func getServiceImportClusters(key string, backend *Backend) []*serviceImportCluster {
+	var clusters map[string]*serviceImportCluster
+	switch backend.Group {
+	case lib.AkoGroup:
+		clusters = getAKOServiceImportClusters(key, backend)
+	case akogatewayapilib.MultiClusterServiceGroup:
+		clusters = getMultiClusterServiceImportClusters(key, backend)
+	}
+	clusterNames := make([]string, 0, len(clusters))
+	for cluster := range clusters {
+		clusterNames = append(clusterNames, cluster)
+	}
+	sort.Strings(clusterNames)
+	serviceImportClusters := make([]*serviceImportCluster, 0, len(clusterNames))
+	for _, cluster := range clusterNames {
+		serviceImportClusters = append(serviceImportClusters, clusters[cluster])
+	}
+	utils.AviLog.Debugf("key: %s, msg: clusters of ServiceImport %s/%s: %s", key, backend.Namespace, backend.Name, utils.Stringify(serviceImportClusters))
+	return serviceImportClusters
+}
+
+// getAKOServiceImportClusters returns the endpoints of the ServiceImports in the AKO namespace, one per
+// exporting cluster, that import the Service with the name and namespace of the backend.
+func getAKOServiceImportClusters(key string, backend *Backend) map[string]*serviceImportCluster {
+	clusters := make(map[string]*serviceImportCluster)
+	dynamicInformers := akogatewayapilib.GetDynamicInformers()
+	if dynamicInformers == nil || dynamicInformers.AKOServiceImportInformer == nil {
+		return clusters
+	}
+	objs, err := dynamicInformers.AKOServiceImportInformer.Lister().ByNamespace(utils.GetAKONamespace()).List(labels.Everything())
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to list the ServiceImports, err: %v", key, err)
+		return clusters
+	}
+	for _, obj := range objs {
+		serviceImport, err := akogatewayapilib.ConvertToAKOServiceImport(obj)
+		if err != nil {
+			utils.AviLog.Warnf("key: %s, msg: %v", key, err)
+			continue
+		}
+		if serviceImport.Spec.Namespace != backend.Namespace || serviceImport.Spec.Service != backend.Name || serviceImport.Spec.Cluster == "" {
+			continue
+		}
+		siCluster := &serviceImportCluster{Cluster: serviceImport.Spec.Cluster, Port: backend.Port}
+		for _, svcPort := range serviceImport.Spec.SvcPorts {
+			if svcPort.Port != backend.Port {
+				continue
+			}
+			for _, ep := range svcPort.Endpoints {
+				if server := buildServiceImportServer(ep.IP, ep.Port, true); server != nil {
+					siCluster.Servers = append(siCluster.Servers, *server)
+				}
+			}
+		}
+		clusters[siCluster.Cluster] = siCluster
+	}
+	return clusters
+}
+
+// getMultiClusterServiceImportClusters returns the endpoints of a ServiceImport of the Multi-Cluster Services
+// API, read from the EndpointSlices imported for each exporting cluster.
+func getMultiClusterServiceImportClusters(key string, backend *Backend) map[string]*serviceImportCluster {
+	clusters := make(map[string]*serviceImportCluster)
+	dynamicInformers := akogatewayapilib.GetDynamicInformers()
+	if dynamicInformers == nil || dynamicInformers.MultiClusterServiceImportInformer == nil {
+		return clusters
+	}
+	obj, err := dynamicInformers.MultiClusterServiceImportInformer.Lister().ByNamespace(backend.Namespace).Get(backend.Name)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to get the ServiceImport %s/%s, err: %v", key, backend.Namespace, backend.Name, err)
+		return clusters
+	}
+	serviceImport, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		utils.AviLog.Warnf("key: %s, msg: invalid ServiceImport object %s/%s", key, backend.Namespace, backend.Name)
+		return clusters
+	}
+
+	// the EndpointSlices carry the name of the port of the ServiceImport
+	var portName string
+	ports, _, _ := unstructured.NestedSlice(serviceImport.UnstructuredContent(), "spec", "ports")
+	for _, portIntf := range ports {
+		port, ok := portIntf.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if portNumber, _, _ := unstructured.NestedInt64(port, "port"); int32(portNumber) == backend.Port {
+			portName, _, _ = unstructured.NestedString(port, "name")
+			break
+		}
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{akogatewayapilib.MultiClusterServiceNameLabel: backend.Name})
+	epSlices, err := utils.GetInformers().EpSlicesInformer.Lister().EndpointSlices(backend.Namespace).List(selector)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to list the EndpointSlices of ServiceImport %s/%s, err: %v", key, backend.Namespace, backend.Name, err)
+		return clusters
+	}
+	uniqueEndpoints := make(map[string]struct{})
+	for _, epSlice := range epSlices {
+		cluster := epSlice.Labels[akogatewayapilib.MultiClusterSourceClusterLabel]
+		if cluster == "" {
+			continue
+		}
+		var port int32
+		for _, epp := range epSlice.Ports {
+			if epp.Port != nil && ((epp.Name != nil && *epp.Name == portName) || len(epSlice.Ports) == 1) {
+				port = *epp.Port
+				break
+			}
+		}
+		if port == 0 {
+			continue
+		}
+		siCluster, ok := clusters[cluster]
+		if !ok {
+			siCluster = &serviceImportCluster{Cluster: cluster, Port: port}
+			clusters[cluster] = siCluster
+		}
+		for _, ep := range epSlice.Endpoints {
+			if len(ep.Addresses) == 0 {
+				continue
+			}
+			// use only first address. Refer to: https://issue.k8s.io/106267
+			epKey := fmt.Sprintf("%s/%s:%d", cluster, ep.Addresses[0], port)
+			if _, ok := uniqueEndpoints[epKey]; ok {
+				continue
+			}
+			uniqueEndpoints[epKey] = struct{}{}
+			if server := buildServiceImportServer(ep.Addresses[0], port, isEndpointReady(ep.Conditions)); server != nil {
+				siCluster.Servers = append(siCluster.Servers, *server)
+			}
+		}
+	}
+	return clusters
+}
+
+func buildServiceImportServer(ip string, port int32, enabled bool) *nodes.AviPoolMetaServer {
+	var addrType string
+	if utils.IsV4(ip) {
+		addrType = "V4"
+	} else if k8net.IsIPv6String(ip) {
+		addrType = "V6"
+	} else {
+		return nil
+	}
+	return &nodes.AviPoolMetaServer{
+		Ip:      models.IPAddr{Addr: &ip, Type: &addrType},
+		Port:    port,
+		Enabled: &enabled,
+	}
+}
+
+func isEndpointReady(conditions discovery.EndpointConditions) bool {
+	return (conditions.Ready == nil || *conditions.Ready) && (conditions.Terminating == nil || !*conditions.Terminating)
+}
+
+// getClusterWeights returns the weights of the exporting clusters of the ServiceImport backends of an
+// HTTPRoute, set in its cluster weights annotation.
+func getClusterWeights(key string, annotations map[string]string) map[string]int32 {
+	clusterWeights := make(map[string]int32)
+	annotation, ok := annotations[akogatewayapilib.ClusterWeightsAnnotation]
+	if !ok {
+		return clusterWeights
+	}
+	if err := json.Unmarshal([]byte(annotation), &clusterWeights); err != nil {
+		utils.AviLog.Warnf("key: %s, msg: invalid value %s of annotation %s, err: %v", key, annotation, akogatewayapilib.ClusterWeightsAnnotation, err)
+		return make(map[string]int32)
+	}
+	for cluster, weight := range clusterWeights {
+		if weight < 0 {
+			utils.AviLog.Warnf("key: %s, msg: ignoring negative weight %d of cluster %s in annotation %s", key, weight, cluster, akogatewayapilib.ClusterWeightsAnnotation)
+			delete(clusterWeights, cluster)
+		}
+	}
+	return clusterWeights
+}
@@ -20,6 +20,7 @@ import (
 	"sync"
 
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
@@ -36,6 +37,8 @@ func GatewayApiLister() *GWLister {
 			gatewayClassStore:                     objects.NewObjectMapStore(),
 			gatewayToGatewayClassStore:            objects.NewObjectMapStore(),
 			gatewayClassToGatewayStore:            objects.NewObjectMapStore(),
+			gatewayClassToMergedGatewayStore:      objects.NewObjectMapStore(),
+			mergedGatewayToGatewayClassStore:      objects.NewObjectMapStore(),
 			gatewayToListenerStore:                objects.NewObjectMapStore(),
 			routeToGateway:                        objects.NewObjectMapStore(),
 			routeToGatewayListener:                objects.NewObjectMapStore(),
@@ -47,8 +50,11 @@ func GatewayApiLister() *GWLister {
 			secretToGateway:                       objects.NewObjectMapStore(),
 			gatewayToSecret:                       objects.NewObjectMapStore(),
 			routeToChildVS:                        objects.NewObjectMapStore(),
+			routeToInvalidRules:                   objects.NewObjectMapStore(),
 			gatewayToHostnameStore:                objects.NewObjectMapStore(),
 			gatewayListenerToHostnameStore:        objects.NewObjectMapStore(),
+			gatewayToListenerHostnamePortStore:    objects.NewObjectMapStore(),
+			listenerHostnamePortToGatewayStore:    objects.NewObjectMapStore(),
 			gatewayRouteToHostnameStore:           objects.NewObjectMapStore(),
 			gatewayRouteToHTTPSPGPoolStore:        objects.NewObjectMapStore(),
 			podToServiceStore:                     objects.NewObjectMapStore(),
@@ -63,6 +69,12 @@ func GatewayApiLister() *GWLister {
 			httpRouteToRouteBackendExtensionCache: objects.NewObjectMapStore(),
 			appProfileToHTTPRouteCache:            objects.NewObjectMapStore(),
 			httpRouteToAppProfileCache:            objects.NewObjectMapStore(),
+			referenceGrantStore:                   objects.NewObjectMapStore(),
+			serviceToBackendTLSPolicy:             objects.NewObjectMapStore(),
+			backendTLSPolicyToService:             objects.NewObjectMapStore(),
+			configMapToBackendTLSPolicy:           objects.NewObjectMapStore(),
+			caCertToGateway:                       objects.NewObjectMapStore(),
+			gatewayToCACert:                       objects.NewObjectMapStore(),
 		}
 	})
 	return gwLister
@@ -80,6 +92,12 @@ type GWLister struct {
 	//GatewayClass -> [ns1/gateway1, ns2/gateway2, ...]
 	gatewayClassToGatewayStore *objects.ObjectMapStore
 
+	// GatewayClass -> [namespace/gateway, ...] merged onto the parent VS of the GatewayClass, in the order of precedence
+	gatewayClassToMergedGatewayStore *objects.ObjectMapStore
+
+	// namespace/gateway -> GatewayClass whose parent VS the gateway is merged onto
+	mergedGatewayToGatewayClassStore *objects.ObjectMapStore
+
 	//Namespace/Gateway -> [listener1, listener2, ...]
 	gatewayToListenerStore *objects.ObjectMapStore
 
@@ -113,12 +131,21 @@ type GWLister struct {
 	// routeType/routeNs/routeName -> [childvs, ...]
 	routeToChildVS *objects.ObjectMapStore
 
+	// routeType/routeNs/routeName -> {ruleIndex, ...}
+	routeToInvalidRules *objects.ObjectMapStore
+
 	//check overlap across gateways
 	gatewayToHostnameStore *objects.ObjectMapStore
 
 	//namespace/gateway/listener -> hostname
 	gatewayListenerToHostnameStore *objects.ObjectMapStore
 
+	// namespace/gateway -> [port/hostname, ...] claimed by the listeners, including the conflicted ones
+	gatewayToListenerHostnamePortStore *objects.ObjectMapStore
+
+	// port/hostname -> [namespace/gateway, ...]
+	listenerHostnamePortToGatewayStore *objects.ObjectMapStore
+
 	//FQDNs in parent VS
 	//gatewayns/gatewayname -> [hostname, ...]
 	gatewayRouteToHostnameStore *objects.ObjectMapStore
@@ -162,6 +189,24 @@ type GWLister struct {
 
 	// HTTPRoute --> ApplicationProfile
 	httpRouteToAppProfileCache *objects.ObjectMapStore
+
+	// ReferenceGrant namespace -> [referenceGrantName -> ReferenceGrantSpec, ...]
+	referenceGrantStore *objects.ObjectMapStore
+
+	// serviceNs/serviceName -> BackendTLSPolicyConfig
+	serviceToBackendTLSPolicy *objects.ObjectMapStore
+
+	// policyNs/policyName -> [serviceNs/serviceName, ...]
+	backendTLSPolicyToService *objects.ObjectMapStore
+
+	// configMapNs/configMapName -> [policyNs/policyName, ...]
+	configMapToBackendTLSPolicy *objects.ObjectMapStore
+
+	// kind/caCertNs/caCertName -> [gatewayNs/gatewayName, ...]
+	caCertToGateway *objects.ObjectMapStore
+
+	// gatewayNs/gatewayName -> [kind/caCertNs/caCertName, ...]
+	gatewayToCACert *objects.ObjectMapStore
 }
 
 type GatewayRouteKind struct {
@@ -178,6 +223,15 @@ type GatewayListenerStore struct {
 	AllowedRouteTypes []GatewayRouteKind
 }
 
+// BackendTLSPolicyConfig is the validated configuration of a BackendTLSPolicy
+// which is applied to the pools of the targeted Service.
+type BackendTLSPolicyConfig struct {
+	PolicyNsName    string
+	CACert          string
+	Hostname        string
+	SubjectAltNames []string
+}
+
 // This struct is used to store HTTPPS, PG, Pool associated with Parent VS (HTTPRoute that is mapped to parent VS)
 
 type HTTPPSPGPool struct {
@@ -255,6 +309,69 @@ func (g *GWLister) UpdateGatewayToGatewayClass(gwNsName, gwClass string) {
 	}
 }
 
+// UpdateGatewayClassToMergedGateways records the gateways merged onto the parent VS of the GatewayClass,
+// the mappings are removed when no gateway is merged.
+func (g *GWLister) UpdateGatewayClassToMergedGateways(gwClass string, gwNsNames []string) {
+	g.gwLock.Lock()
+	defer g.gwLock.Unlock()
+
+	if found, oldGwNsNames := g.gatewayClassToMergedGatewayStore.Get(gwClass); found {
+		for _, gwNsName := range oldGwNsNames.([]string) {
+			if !utils.HasElem(gwNsNames, gwNsName) {
+				g.mergedGatewayToGatewayClassStore.Delete(gwNsName)
+			}
+		}
+	}
+	if len(gwNsNames) == 0 {
+		g.gatewayClassToMergedGatewayStore.Delete(gwClass)
+		return
+	}
+	g.gatewayClassToMergedGatewayStore.AddOrUpdate(gwClass, gwNsNames)
+	for _, gwNsName := range gwNsNames {
+		g.mergedGatewayToGatewayClassStore.AddOrUpdate(gwNsName, gwClass)
+	}
+}
+
+func (g *GWLister) GetGatewayClassToMergedGateways(gwClass string) []string {
+	g.gwLock.RLock()
+	defer g.gwLock.RUnlock()
+
+	found, gwNsNames := g.gatewayClassToMergedGatewayStore.Get(gwClass)
+	if !found {
+		return make([]string, 0)
+	}
+	return gwNsNames.([]string)
+}
+
+// GetMergedGatewayClass returns the GatewayClass whose parent VS the gateway is merged onto,
+// empty if the gateway has its own parent VS.
+func (g *GWLister) GetMergedGatewayClass(gwNsName string) string {
+	g.gwLock.RLock()
+	defer g.gwLock.RUnlock()
+
+	found, gwClass := g.mergedGatewayToGatewayClassStore.Get(gwNsName)
+	if !found {
+		return ""
+	}
+	return gwClass.(string)
+}
+
+// GetMergedGateways returns the gateways sharing the parent VS with the gateway, including the gateway itself.
+func (g *GWLister) GetMergedGateways(gwNsName string) []string {
+	g.gwLock.RLock()
+	defer g.gwLock.RUnlock()
+
+	found, gwClass := g.mergedGatewayToGatewayClassStore.Get(gwNsName)
+	if !found {
+		return []string{gwNsName}
+	}
+	found, gwNsNames := g.gatewayClassToMergedGatewayStore.Get(gwClass.(string))
+	if !found {
+		return []string{gwNsName}
+	}
+	return gwNsNames.([]string)
+}
+
 func (g *GWLister) UpdateGatewayToHostnames(gwNsName string, hostnames []string) {
 	g.gwLock.Lock()
 	defer g.gwLock.Unlock()
@@ -755,6 +872,8 @@ func (g *GWLister) DeleteGatewayFromStore(gwNsName string) {
 		g.gatewayToGatewayClassStore.Delete(gwNsName)
 	}
 
+	// delete gateway to CA certificates
+	g.updateGatewayToCACert(gwNsName, nil)
 }
 
 // =====All route <-> child vs go here.
@@ -807,6 +926,28 @@ func (g *GWLister) DeleteRouteChildVSMappings(routeTypeNsName, childVS string) {
 	}
 }
 
+// =====All route <-> invalid rules go here.
+func (g *GWLister) GetRouteToInvalidRules(routeTypeNsName string) map[int]struct{} {
+	g.gwLock.RLock()
+	defer g.gwLock.RUnlock()
+
+	if found, obj := g.routeToInvalidRules.Get(routeTypeNsName); found {
+		return obj.(map[int]struct{})
+	}
+	return map[int]struct{}{}
+}
+
+func (g *GWLister) UpdateRouteToInvalidRules(routeTypeNsName string, invalidRules map[int]struct{}) {
+	g.gwLock.Lock()
+	defer g.gwLock.Unlock()
+
+	if len(invalidRules) == 0 {
+		g.routeToInvalidRules.Delete(routeTypeNsName)
+		return
+	}
+	g.routeToInvalidRules.AddOrUpdate(routeTypeNsName, invalidRules)
+}
+
 //=====All route functions go here.
 
 func (g *GWLister) DeleteRouteFromStore(routeTypeNsName string, key string) {
@@ -837,39 +978,43 @@ func (g *GWLister) DeleteRouteFromStore(routeTypeNsName string, key string) {
 		}
 	}
 	g.routeToGateway.Delete(routeTypeNsName)
-
-	// delete Route entries from L7RuleHTTPRoute mapping
-	// Fetch routenamespace/name from routeTypeNsName
-	_, httpRouteNSName := utils.ExtractNamespaceObjectName(routeTypeNsName)
-
-	if found, l7Rules := g.httpRouteToL7RuleCache.Get(httpRouteNSName); found {
-		l7RuleList := l7Rules.(map[string]struct{})
-		for l7Rule := range l7RuleList {
-			g.deleteL7RuleToHTTPRouteMapping(l7Rule, httpRouteNSName)
+	g.routeToInvalidRules.Delete(routeTypeNsName)
+
+	// L7Rule, HealthMonitor and RouteBackendExtension mappings are maintained only for HTTPRoutes
+	if routeType, _, _ := lib.ExtractTypeNameNamespace(routeTypeNsName); routeType == lib.HTTPRoute {
+		// delete Route entries from L7RuleHTTPRoute mapping
+		// Fetch routenamespace/name from routeTypeNsName
+		_, httpRouteNSName := utils.ExtractNamespaceObjectName(routeTypeNsName)
+
+		if found, l7Rules := g.httpRouteToL7RuleCache.Get(httpRouteNSName); found {
+			l7RuleList := l7Rules.(map[string]struct{})
+			for l7Rule := range l7RuleList {
+				g.deleteL7RuleToHTTPRouteMapping(l7Rule, httpRouteNSName)
+			}
 		}
-	}
-	// delete the httproutekey
-	g.httpRouteToL7RuleCache.Delete(httpRouteNSName)
-
-	// delete Route entries from HealthMonitorHTTPRoute mapping
-	if found, healthMonitorList := g.httpRouteToHealthMonitorCache.Get(httpRouteNSName); found {
-		healthMonitorListObj := healthMonitorList.(map[string]struct{})
-		for healthMonitor := range healthMonitorListObj {
-			g.deleteHealthMonitorToHTTPRoutesMapping(healthMonitor, httpRouteNSName)
+		// delete the httproutekey
+		g.httpRouteToL7RuleCache.Delete(httpRouteNSName)
+
+		// delete Route entries from HealthMonitorHTTPRoute mapping
+		if found, healthMonitorList := g.httpRouteToHealthMonitorCache.Get(httpRouteNSName); found {
+			healthMonitorListObj := healthMonitorList.(map[string]struct{})
+			for healthMonitor := range healthMonitorListObj {
+				g.deleteHealthMonitorToHTTPRoutesMapping(healthMonitor, httpRouteNSName)
+			}
 		}
-	}
-	// delete the httproutekey
-	g.httpRouteToHealthMonitorCache.Delete(httpRouteNSName)
-
-	// delete Route entries from RouteBackendExtensionHTTPRoute mapping
-	if found, routeBackendExtensionList := g.httpRouteToRouteBackendExtensionCache.Get(httpRouteNSName); found {
-		routeBackendExtensionListObj := routeBackendExtensionList.(map[string]struct{})
-		for routeBackendExtension := range routeBackendExtensionListObj {
-			g.deleteRouteBackendExtensionToHTTPRoutesMapping(routeBackendExtension, httpRouteNSName)
+		// delete the httproutekey
+		g.httpRouteToHealthMonitorCache.Delete(httpRouteNSName)
+
+		// delete Route entries from RouteBackendExtensionHTTPRoute mapping
+		if found, routeBackendExtensionList := g.httpRouteToRouteBackendExtensionCache.Get(httpRouteNSName); found {
+			routeBackendExtensionListObj := routeBackendExtensionList.(map[string]struct{})
+			for routeBackendExtension := range routeBackendExtensionListObj {
+				g.deleteRouteBackendExtensionToHTTPRoutesMapping(routeBackendExtension, httpRouteNSName)
+			}
 		}
+		// delete the httproutekey
+		g.httpRouteToRouteBackendExtensionCache.Delete(httpRouteNSName)
 	}
-	// delete the httproutekey
-	g.httpRouteToRouteBackendExtensionCache.Delete(httpRouteNSName)
 
 	//delete route to service
 	found, svcList := g.routeToService.Get(routeTypeNsName)
@@ -996,6 +1141,77 @@ func (g *GWLister) UpdateGatewayListenerToHostname(gwListenerNsName, hostname st
 	g.gatewayListenerToHostnameStore.AddOrUpdate(gwListenerNsName, hostname)
 }
 
+// UpdateGatewayToListenerHostnamePorts records the port/hostname claimed by the listeners of the gateway.
+func (g *GWLister) UpdateGatewayToListenerHostnamePorts(gwNsName string, hostnamePorts []string) {
+	g.gwLock.Lock()
+	defer g.gwLock.Unlock()
+
+	g.deleteGatewayToListenerHostnamePorts(gwNsName)
+	if len(hostnamePorts) == 0 {
+		return
+	}
+	g.gatewayToListenerHostnamePortStore.AddOrUpdate(gwNsName, hostnamePorts)
+	for _, hostnamePort := range hostnamePorts {
+		if found, gwNsNameList := g.listenerHostnamePortToGatewayStore.Get(hostnamePort); found {
+			gwNsNameListObj := gwNsNameList.([]string)
+			if !utils.HasElem(gwNsNameListObj, gwNsName) {
+				gwNsNameListObj = append(gwNsNameListObj, gwNsName)
+				g.listenerHostnamePortToGatewayStore.AddOrUpdate(hostnamePort, gwNsNameListObj)
+			}
+		} else {
+			g.listenerHostnamePortToGatewayStore.AddOrUpdate(hostnamePort, []string{gwNsName})
+		}
+	}
+}
+
+// GetGatewaysSharingListenerHostnamePorts returns the other gateways with a listener claiming
+// the same port/hostname as a listener of the gateway.
+func (g *GWLister) GetGatewaysSharingListenerHostnamePorts(gwNsName string) []string {
+	g.gwLock.RLock()
+	defer g.gwLock.RUnlock()
+
+	var gwNsNames []string
+	found, hostnamePorts := g.gatewayToListenerHostnamePortStore.Get(gwNsName)
+	if !found {
+		return gwNsNames
+	}
+	for _, hostnamePort := range hostnamePorts.([]string) {
+		if found, gwNsNameList := g.listenerHostnamePortToGatewayStore.Get(hostnamePort); found {
+			for _, sharedGwNsName := range gwNsNameList.([]string) {
+				if sharedGwNsName != gwNsName && !utils.HasElem(gwNsNames, sharedGwNsName) {
+					gwNsNames = append(gwNsNames, sharedGwNsName)
+				}
+			}
+		}
+	}
+	return gwNsNames
+}
+
+func (g *GWLister) DeleteGatewayToListenerHostnamePorts(gwNsName string) {
+	g.gwLock.Lock()
+	defer g.gwLock.Unlock()
+
+	g.deleteGatewayToListenerHostnamePorts(gwNsName)
+}
+
+func (g *GWLister) deleteGatewayToListenerHostnamePorts(gwNsName string) {
+	found, hostnamePorts := g.gatewayToListenerHostnamePortStore.Get(gwNsName)
+	if !found {
+		return
+	}
+	for _, hostnamePort := range hostnamePorts.([]string) {
+		if found, gwNsNameList := g.listenerHostnamePortToGatewayStore.Get(hostnamePort); found {
+			gwNsNameListObj := utils.Remove(gwNsNameList.([]string), gwNsName)
+			if len(gwNsNameListObj) == 0 {
+				g.listenerHostnamePortToGatewayStore.Delete(hostnamePort)
+			} else {
+				g.listenerHostnamePortToGatewayStore.AddOrUpdate(hostnamePort, gwNsNameListObj)
+			}
+		}
+	}
+	g.gatewayToListenerHostnamePortStore.Delete(gwNsName)
+}
+
 func (g *GWLister) UpdateGatewayRouteToHostname(gwRouteNsName string, hostnames []string) {
 	g.gwLock.Lock()
 	defer g.gwLock.Unlock()
@@ -1394,3 +1610,237 @@ func (g *GWLister) UpdateHTTPRouteToApplicationProfileMapping(httpRouteName, app
 	appProfiles[appProfile] = struct{}{}
 	g.httpRouteToAppProfileCache.AddOrUpdate(httpRouteName, appProfiles)
 }
+
+// ReferenceGrant
+
+func (g *GWLister) UpdateReferenceGrant(namespace, name string, spec gatewayv1beta1.ReferenceGrantSpec) {
+	g.gwLock.Lock()
+	defer g.gwLock.Unlock()
+
+	grants := make(map[string]gatewayv1beta1.ReferenceGrantSpec)
+	if found, obj := g.referenceGrantStore.Get(namespace); found {
+		for grantName, grantSpec := range obj.(map[string]gatewayv1beta1.ReferenceGrantSpec) {
+			grants[grantName] = grantSpec
+		}
+	}
+	grants[name] = spec
+	g.referenceGrantStore.AddOrUpdate(namespace, grants)
+}
+
+func (g *GWLister) DeleteReferenceGrant(namespace, name string) {
+	g.gwLock.Lock()
+	defer g.gwLock.Unlock()
+
+	found, obj := g.referenceGrantStore.Get(namespace)
+	if !found {
+		return
+	}
+	grants := make(map[string]gatewayv1beta1.ReferenceGrantSpec)
+	for grantName, grantSpec := range obj.(map[string]gatewayv1beta1.ReferenceGrantSpec) {
+		if grantName != name {
+			grants[grantName] = grantSpec
+		}
+	}
+	if len(grants) == 0 {
+		g.referenceGrantStore.Delete(namespace)
+		return
+	}
+	g.referenceGrantStore.AddOrUpdate(namespace, grants)
+}
+
+// IsReferencePermitted returns true if a ReferenceGrant in toNamespace allows objects of
+// fromGroup/fromKind in fromNamespace to refer to the toGroup/toKind object named toName.
+// References within the same namespace are always permitted.
+func (g *GWLister) IsReferencePermitted(fromGroup, fromKind, fromNamespace, toGroup, toKind, toNamespace, toName string) bool {
+	if fromNamespace == toNamespace {
+		return true
+	}
+	g.gwLock.RLock()
+	defer g.gwLock.RUnlock()
+
+	found, obj := g.referenceGrantStore.Get(toNamespace)
+	if !found {
+		return false
+	}
+	for _, spec := range obj.(map[string]gatewayv1beta1.ReferenceGrantSpec) {
+		fromMatched := false
+		for _, from := range spec.From {
+			if string(from.Group) == fromGroup && string(from.Kind) == fromKind && string(from.Namespace) == fromNamespace {
+				fromMatched = true
+				break
+			}
+		}
+		if !fromMatched {
+			continue
+		}
+		for _, to := range spec.To {
+			if string(to.Group) == toGroup && string(to.Kind) == toKind && (to.Name == nil || string(*to.Name) == toName) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// BackendTLSPolicy
+
+func (g *GWLister) GetServiceToBackendTLSPolicy(svcNsName string) (bool, BackendTLSPolicyConfig) {
+	g.gwLock.RLock()
+	defer g.gwLock.RUnlock()
+
+	found, obj := g.serviceToBackendTLSPolicy.Get(svcNsName)
+	if !found {
+		return false, BackendTLSPolicyConfig{}
+	}
+	return true, obj.(BackendTLSPolicyConfig)
+}
+
+func (g *GWLister) GetBackendTLSPolicyToService(policyNsName string) []string {
+	g.gwLock.RLock()
+	defer g.gwLock.RUnlock()
+
+	found, obj := g.backendTLSPolicyToService.Get(policyNsName)
+	if !found {
+		return []string{}
+	}
+	return obj.([]string)
+}
+
+// UpdateBackendTLSPolicyToService replaces the Services to which the BackendTLSPolicy is applied
+// with the services in configs, keyed by serviceNs/serviceName.
+func (g *GWLister) UpdateBackendTLSPolicyToService(policyNsName string, configs map[string]BackendTLSPolicyConfig) {
+	g.gwLock.Lock()
+	defer g.gwLock.Unlock()
+
+	g.deleteBackendTLSPolicyToService(policyNsName)
+	if len(configs) == 0 {
+		return
+	}
+	svcNsNameList := make([]string, 0, len(configs))
+	for svcNsName, config := range configs {
+		g.serviceToBackendTLSPolicy.AddOrUpdate(svcNsName, config)
+		svcNsNameList = append(svcNsNameList, svcNsName)
+	}
+	g.backendTLSPolicyToService.AddOrUpdate(policyNsName, svcNsNameList)
+}
+
+func (g *GWLister) DeleteBackendTLSPolicy(policyNsName string) {
+	g.gwLock.Lock()
+	defer g.gwLock.Unlock()
+
+	g.deleteBackendTLSPolicyToService(policyNsName)
+}
+
+func (g *GWLister) deleteBackendTLSPolicyToService(policyNsName string) {
+	found, obj := g.backendTLSPolicyToService.Get(policyNsName)
+	if !found {
+		return
+	}
+	for _, svcNsName := range obj.([]string) {
+		// the service may have been taken over by another policy
+		if found, config := g.serviceToBackendTLSPolicy.Get(svcNsName); found && config.(BackendTLSPolicyConfig).PolicyNsName == policyNsName {
+			g.serviceToBackendTLSPolicy.Delete(svcNsName)
+		}
+	}
+	g.backendTLSPolicyToService.Delete(policyNsName)
+}
+
+// GetConfigMapToBackendTLSPolicy returns the BackendTLSPolicies which refer to the ConfigMap for their CA certificates.
+func (g *GWLister) GetConfigMapToBackendTLSPolicy(configMapNsName string) []string {
+	g.gwLock.RLock()
+	defer g.gwLock.RUnlock()
+
+	found, obj := g.configMapToBackendTLSPolicy.Get(configMapNsName)
+	if !found {
+		return []string{}
+	}
+	return obj.([]string)
+}
+
+// UpdateBackendTLSPolicyToConfigMap replaces the ConfigMaps referred by the BackendTLSPolicy with configMapNsNames.
+func (g *GWLister) UpdateBackendTLSPolicyToConfigMap(policyNsName string, configMapNsNames []string) {
+	g.gwLock.Lock()
+	defer g.gwLock.Unlock()
+
+	for _, configMapNsName := range g.configMapToBackendTLSPolicy.GetAllKeys() {
+		if utils.HasElem(configMapNsNames, configMapNsName) {
+			continue
+		}
+		g.deleteConfigMapToBackendTLSPolicy(configMapNsName, policyNsName)
+	}
+	for _, configMapNsName := range configMapNsNames {
+		policies := []string{}
+		if found, obj := g.configMapToBackendTLSPolicy.Get(configMapNsName); found {
+			policies = obj.([]string)
+		}
+		if !utils.HasElem(policies, policyNsName) {
+			g.configMapToBackendTLSPolicy.AddOrUpdate(configMapNsName, append(policies, policyNsName))
+		}
+	}
+}
+
+func (g *GWLister) deleteConfigMapToBackendTLSPolicy(configMapNsName, policyNsName string) {
+	found, obj := g.configMapToBackendTLSPolicy.Get(configMapNsName)
+	if !found {
+		return
+	}
+	policies := utils.Remove(obj.([]string), policyNsName)
+	if len(policies) == 0 {
+		g.configMapToBackendTLSPolicy.Delete(configMapNsName)
+		return
+	}
+	g.configMapToBackendTLSPolicy.AddOrUpdate(configMapNsName, policies)
+}
+
+// GetCACertToGateway returns the Gateways which refer to the ConfigMap or Secret, in kind/namespace/name
+// format, for the CA certificates validating the client certificates.
+func (g *GWLister) GetCACertToGateway(caCertRef string) []string {
+	g.gwLock.RLock()
+	defer g.gwLock.RUnlock()
+
+	found, obj := g.caCertToGateway.Get(caCertRef)
+	if !found {
+		return []string{}
+	}
+	return obj.([]string)
+}
+
+// UpdateGatewayToCACert replaces the CA certificate references of the Gateway with caCertRefs.
+func (g *GWLister) UpdateGatewayToCACert(gwNsName string, caCertRefs []string) {
+	g.gwLock.Lock()
+	defer g.gwLock.Unlock()
+
+	g.updateGatewayToCACert(gwNsName, caCertRefs)
+}
+
+func (g *GWLister) updateGatewayToCACert(gwNsName string, caCertRefs []string) {
+	if found, obj := g.gatewayToCACert.Get(gwNsName); found {
+		for _, caCertRef := range obj.([]string) {
+			if utils.HasElem(caCertRefs, caCertRef) {
+				continue
+			}
+			if found, gwList := g.caCertToGateway.Get(caCertRef); found {
+				gateways := utils.Remove(gwList.([]string), gwNsName)
+				if len(gateways) == 0 {
+					g.caCertToGateway.Delete(caCertRef)
+				} else {
+					g.caCertToGateway.AddOrUpdate(caCertRef, gateways)
+				}
+			}
+		}
+	}
+	if len(caCertRefs) == 0 {
+		g.gatewayToCACert.Delete(gwNsName)
+		return
+	}
+	for _, caCertRef := range caCertRefs {
+		gateways := []string{}
+		if found, gwList := g.caCertToGateway.Get(caCertRef); found {
+			gateways = gwList.([]string)
+		}
+		if !utils.HasElem(gateways, gwNsName) {
+			g.caCertToGateway.AddOrUpdate(caCertRef, append(gateways, gwNsName))
+		}
+	}
+	g.gatewayToCACert.AddOrUpdate(gwNsName, caCertRefs)
+}
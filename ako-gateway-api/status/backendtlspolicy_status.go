@@ -0,0 +1,101 @@
+/*
+ * Copyright © 2025 Broadcom Inc. and/or its subsidiaries. All Rights Reserved.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1alpha3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/status"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+type backendTLSPolicy struct{}
+
+func (o *backendTLSPolicy) Get(key string, name string, namespace string) *gatewayv1alpha3.BackendTLSPolicy {
+	obj, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().BackendTLSPolicyInformer.Lister().BackendTLSPolicies(namespace).Get(name)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to get the BackendTLSPolicy object. err: %s", key, err)
+		return nil
+	}
+	utils.AviLog.Debugf("key: %s, msg: Successfully retrieved the BackendTLSPolicy object %s", key, name)
+	return obj.DeepCopy()
+}
+
+func (o *backendTLSPolicy) Delete(key string, option status.StatusOptions) {
+}
+
+func (o *backendTLSPolicy) Update(key string, option status.StatusOptions) {
+}
+
+func (o *backendTLSPolicy) BulkUpdate(key string, options []status.StatusOptions) {
+}
+
+func (o *backendTLSPolicy) Patch(key string, obj runtime.Object, status *status.Status, retryNum ...int) error {
+	retry := 0
+	if len(retryNum) > 0 {
+		retry = retryNum[0]
+		if retry >= 5 {
+			utils.AviLog.Errorf("key: %s, msg: Patch retried 5 times, aborting", key)
+			return errors.New("Patch retried 5 times, aborting")
+		}
+	}
+
+	policy := obj.(*gatewayv1alpha3.BackendTLSPolicy)
+	if IsPolicyStatusEqual(&policy.Status, status.PolicyStatus) {
+		return nil
+	}
+
+	patchPayload, _ := json.Marshal(map[string]interface{}{
+		"status": status.PolicyStatus,
+	})
+	_, err := akogatewayapilib.AKOControlConfig().GatewayAPIClientset().GatewayV1alpha3().BackendTLSPolicies(policy.Namespace).Patch(context.TODO(), policy.Name, types.MergePatchType, patchPayload, metav1.PatchOptions{}, "status")
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: there was an error in updating the BackendTLSPolicy status. err: %+v, retry: %d", key, err, retry)
+		updatedObj := o.Get(key, policy.Name, policy.Namespace)
+		if updatedObj == nil {
+			return err
+		}
+		return o.Patch(key, updatedObj, status, retry+1)
+	}
+	utils.AviLog.Infof("key: %s, msg: Successfully updated the BackendTLSPolicy %s/%s status %+v", key, policy.Namespace, policy.Name, utils.Stringify(status))
+	return nil
+}
+
+// IsPolicyStatusEqual compares the policy statuses, ignoring the last transition time of the conditions.
+func IsPolicyStatusEqual(old, new *gatewayv1alpha2.PolicyStatus) bool {
+	oldStatus, newStatus := old.DeepCopy(), new.DeepCopy()
+	currentTime := metav1.Now()
+	for i := range oldStatus.Ancestors {
+		for j := range oldStatus.Ancestors[i].Conditions {
+			oldStatus.Ancestors[i].Conditions[j].LastTransitionTime = currentTime
+		}
+	}
+	for i := range newStatus.Ancestors {
+		for j := range newStatus.Ancestors[i].Conditions {
+			newStatus.Ancestors[i].Conditions[j].LastTransitionTime = currentTime
+		}
+	}
+	return reflect.DeepEqual(oldStatus, newStatus)
+}
@@ -93,7 +93,25 @@ func (o *gateway) GetAll(key string) map[string]*gatewayv1.Gateway {
 	return gwMap
 }
 
+// forEachMergedGateway applies the status update to each of the Gateways sharing the parent VS, as the parent
+// VS of the Gateways merged in a GatewayClass only carries the Gateway taking precedence in its metadata.
+func forEachMergedGateway(option status.StatusOptions, update func(gwOption status.StatusOptions)) {
+	for _, gwNsName := range akogatewayapiobjects.GatewayApiLister().GetMergedGateways(option.Options.ServiceMetadata.Gateway) {
+		options := *option.Options
+		options.ServiceMetadata.Gateway = gwNsName
+		gwOption := option
+		gwOption.Options = &options
+		update(gwOption)
+	}
+}
+
 func (o *gateway) Delete(key string, option status.StatusOptions) {
+	forEachMergedGateway(option, func(gwOption status.StatusOptions) {
+		o.delete(key, gwOption)
+	})
+}
+
+func (o *gateway) delete(key string, option status.StatusOptions) {
 
 	gw := o.Get(key, option)
 	if gw == nil {
@@ -144,13 +162,20 @@ func (o *gateway) Delete(key string, option status.StatusOptions) {
 }
 
 func (o *gateway) Update(key string, option status.StatusOptions) {
-	gw := o.Get(key, option)
-	if gw == nil {
+	if option.Options != nil && option.Options.Status != nil && option.Options.Status.GatewayStatus != nil {
+		if gw := o.Get(key, option); gw != nil {
+			o.Patch(key, gw, option.Options.Status)
+		}
 		return
 	}
+	forEachMergedGateway(option, func(gwOption status.StatusOptions) {
+		o.update(key, gwOption)
+	})
+}
 
-	if option.Options != nil && option.Options.Status != nil && option.Options.Status.GatewayStatus != nil {
-		o.Patch(key, gw, option.Options.Status)
+func (o *gateway) update(key string, option status.StatusOptions) {
+	gw := o.Get(key, option)
+	if gw == nil {
 		return
 	}
 
@@ -171,12 +196,19 @@ func (o *gateway) Update(key string, option status.StatusOptions) {
 	condition := NewCondition()
 	var conditionType, reason, message string
 	conditionStatus := metav1.ConditionTrue
+	listenerReason := string(gatewayv1.ListenerReasonProgrammed)
 
 	if option.Options.Message != "" {
 		conditionType = string(gatewayv1.GatewayConditionProgrammed)
 		conditionStatus = metav1.ConditionFalse
 		reason = string(gatewayv1.GatewayReasonInvalid)
 		message = option.Options.Message
+	} else if vsRuntimeStatus, ok := status.GetVSRuntimeStatus(option.Options.Tenant, option.Options.VSName); ok && !vsRuntimeStatus.IsUp() {
+		conditionType = string(gatewayv1.GatewayConditionProgrammed)
+		conditionStatus = metav1.ConditionFalse
+		reason = string(gatewayv1.GatewayReasonPending)
+		listenerReason = string(gatewayv1.ListenerReasonPending)
+		message = status.VSRuntimeStatusMessage(option.Options.VSName, vsRuntimeStatus)
 	} else {
 		conditionType = string(gatewayv1.GatewayConditionProgrammed)
 		reason = string(gatewayv1.GatewayReasonProgrammed)
@@ -196,7 +228,7 @@ func (o *gateway) Update(key string, option status.StatusOptions) {
 			listenerCondition.
 				Type(string(gatewayv1.ListenerConditionProgrammed)).
 				Status(conditionStatus).
-				Reason(string(gatewayv1.ListenerReasonProgrammed)).
+				Reason(listenerReason).
 				ObservedGeneration(gw.ObjectMeta.Generation).
 				Message(message).
 				SetIn(&gatewaystatus.Listeners[i].Conditions)
@@ -219,14 +251,19 @@ func (o *gateway) BulkUpdate(key string, options []status.StatusOptions) {
 
 	gwMap := o.GetAll(key)
 	for _, option := range options {
-		nsName := option.Options.ServiceMetadata.Gateway
-		if gw, ok := gwMap[nsName]; ok {
+		for _, nsName := range akogatewayapiobjects.GatewayApiLister().GetMergedGateways(option.Options.ServiceMetadata.Gateway) {
+			gw, ok := gwMap[nsName]
+			if !ok {
+				continue
+			}
 			gatewaystatus := &gatewayv1.GatewayStatus{}
 			addressType := gatewayv1.IPAddressType
-			gatewaystatus.Addresses = append(gatewaystatus.Addresses, gatewayv1.GatewayStatusAddress{
-				Type:  &addressType,
-				Value: option.Options.Vip[0],
-			})
+			for _, vip := range option.Options.Vip {
+				gatewaystatus.Addresses = append(gatewaystatus.Addresses, gatewayv1.GatewayStatusAddress{
+					Type:  &addressType,
+					Value: vip,
+				})
+			}
 			apimeta.SetStatusCondition(&gatewaystatus.Conditions, metav1.Condition{
 				Type:               string(gatewayv1.GatewayConditionProgrammed),
 				Status:             metav1.ConditionTrue,
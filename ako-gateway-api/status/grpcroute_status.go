@@ -0,0 +1,101 @@
+/*
+ * Copyright © 2025 Broadcom Inc. and/or its subsidiaries. All Rights Reserved.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/status"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// grpcroute updates the status of GRPCRoute objects. The status is computed in the
+// same form as the HTTPRoute status, as both share the RouteStatus.
+type grpcroute struct{}
+
+func (o *grpcroute) Get(key string, name string, namespace string) *gatewayv1.GRPCRoute {
+
+	obj, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GRPCRouteInformer.Lister().GRPCRoutes(namespace).Get(name)
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to get the GRPCRoute object. err: %s", key, err)
+		return nil
+	}
+	utils.AviLog.Debugf("key: %s, msg: Successfully retrieved the GRPCRoute object %s", key, name)
+	return obj.DeepCopy()
+}
+
+func (o *grpcroute) Delete(key string, option status.StatusOptions) {
+}
+
+func (o *grpcroute) Update(key string, option status.StatusOptions) {
+	nsName := strings.Split(option.Options.ServiceMetadata.HTTPRoute, "/")
+	if len(nsName) != 2 {
+		utils.AviLog.Warnf("key: %s, msg: invalid GRPCRoute name and namespace", key)
+		return
+	}
+	grpcRoute := o.Get(key, nsName[1], nsName[0])
+	if grpcRoute != nil {
+		o.Patch(key, grpcRoute, option.Options.Status)
+	}
+}
+
+func (o *grpcroute) BulkUpdate(key string, options []status.StatusOptions) {
+}
+
+func (o *grpcroute) Patch(key string, obj runtime.Object, status *status.Status, retryNum ...int) error {
+	retry := 0
+	if len(retryNum) > 0 {
+		retry = retryNum[0]
+		if retry >= 5 {
+			utils.AviLog.Errorf("key: %s, msg: Patch retried 5 times, aborting", key)
+			akogatewayapilib.AKOControlConfig().EventRecorder().Eventf(obj, corev1.EventTypeWarning, lib.PatchFailed, "Patch of status failed after multiple retries")
+			return errors.New("Patch retried 5 times, aborting")
+		}
+	}
+
+	grpcRoute := obj.(*gatewayv1.GRPCRoute)
+	httpRoute := &httproute{}
+	if httpRoute.isStatusEqual(&gatewayv1.HTTPRouteStatus{RouteStatus: grpcRoute.Status.RouteStatus}, status.HTTPRouteStatus) {
+		return nil
+	}
+
+	patchPayload, _ := json.Marshal(map[string]interface{}{
+		"status": status.HTTPRouteStatus,
+	})
+	_, err := akogatewayapilib.AKOControlConfig().GatewayAPIClientset().GatewayV1().GRPCRoutes(grpcRoute.Namespace).Patch(context.TODO(), grpcRoute.Name, types.MergePatchType, patchPayload, metav1.PatchOptions{}, "status")
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: there was an error in updating the GRPCRoute status. err: %+v, retry: %d", key, err, retry)
+		updatedObj, err := akogatewayapilib.AKOControlConfig().GatewayApiInformers().GRPCRouteInformer.Lister().GRPCRoutes(grpcRoute.Namespace).Get(grpcRoute.Name)
+		if err != nil {
+			utils.AviLog.Warnf("GRPCRoute not found %v", err)
+			return err
+		}
+		return o.Patch(key, updatedObj, status, retry+1)
+	}
+
+	utils.AviLog.Infof("key: %s, msg: Successfully updated the GRPCRoute %s/%s status %+v", key, grpcRoute.Namespace, grpcRoute.Name, utils.Stringify(status))
+	return nil
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright © 2025 Broadcom Inc. and/or its subsidiaries. All Rights Reserved.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/status"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// l4route updates the status of TCPRoute, UDPRoute and TLSRoute objects, objType is the route kind.
+type l4route struct {
+	objType string
+}
+
+func (o *l4route) Get(key string, name string, namespace string) runtime.Object {
+	informers := akogatewayapilib.AKOControlConfig().GatewayApiInformers()
+	var obj runtime.Object
+	var err error
+	switch o.objType {
+	case lib.TCPRoute:
+		var tcpRoute *gatewayv1alpha2.TCPRoute
+		if tcpRoute, err = informers.TCPRouteInformer.Lister().TCPRoutes(namespace).Get(name); err == nil {
+			obj = tcpRoute.DeepCopy()
+		}
+	case lib.UDPRoute:
+		var udpRoute *gatewayv1alpha2.UDPRoute
+		if udpRoute, err = informers.UDPRouteInformer.Lister().UDPRoutes(namespace).Get(name); err == nil {
+			obj = udpRoute.DeepCopy()
+		}
+	case lib.TLSRoute:
+		var tlsRoute *gatewayv1alpha2.TLSRoute
+		if tlsRoute, err = informers.TLSRouteInformer.Lister().TLSRoutes(namespace).Get(name); err == nil {
+			obj = tlsRoute.DeepCopy()
+		}
+	}
+	if err != nil || obj == nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to get the %s object. err: %v", key, o.objType, err)
+		return nil
+	}
+	utils.AviLog.Debugf("key: %s, msg: Successfully retrieved the %s object %s", key, o.objType, name)
+	return obj
+}
+
+func (o *l4route) Delete(key string, option status.StatusOptions) {
+}
+
+func (o *l4route) Update(key string, option status.StatusOptions) {
+	nsName := strings.Split(option.Options.ServiceMetadata.HTTPRoute, "/")
+	if len(nsName) != 2 {
+		utils.AviLog.Warnf("key: %s, msg: invalid %s name and namespace", key, o.objType)
+		return
+	}
+	route := o.Get(key, nsName[1], nsName[0])
+	if route != nil {
+		o.Patch(key, route, option.Options.Status)
+	}
+}
+
+func (o *l4route) BulkUpdate(key string, options []status.StatusOptions) {
+}
+
+func (o *l4route) Patch(key string, obj runtime.Object, status *status.Status, retryNum ...int) error {
+	retry := 0
+	if len(retryNum) > 0 {
+		retry = retryNum[0]
+		if retry >= 5 {
+			utils.AviLog.Errorf("key: %s, msg: Patch retried 5 times, aborting", key)
+			akogatewayapilib.AKOControlConfig().EventRecorder().Eventf(obj, corev1.EventTypeWarning, lib.PatchFailed, "Patch of status failed after multiple retries")
+			return errors.New("Patch retried 5 times, aborting")
+		}
+	}
+
+	var namespace, name string
+	var currentStatus *gatewayv1.RouteStatus
+	switch route := obj.(type) {
+	case *gatewayv1alpha2.TCPRoute:
+		namespace, name, currentStatus = route.Namespace, route.Name, &route.Status.RouteStatus
+	case *gatewayv1alpha2.UDPRoute:
+		namespace, name, currentStatus = route.Namespace, route.Name, &route.Status.RouteStatus
+	case *gatewayv1alpha2.TLSRoute:
+		namespace, name, currentStatus = route.Namespace, route.Name, &route.Status.RouteStatus
+	default:
+		utils.AviLog.Warnf("key: %s, msg: unsupported object %T received for status update", key, obj)
+		return nil
+	}
+	httpRoute := &httproute{}
+	if httpRoute.isStatusEqual(&gatewayv1.HTTPRouteStatus{RouteStatus: *currentStatus}, status.HTTPRouteStatus) {
+		return nil
+	}
+
+	patchPayload, _ := json.Marshal(map[string]interface{}{
+		"status": status.HTTPRouteStatus,
+	})
+	var err error
+	cs := akogatewayapilib.AKOControlConfig().GatewayAPIClientset().GatewayV1alpha2()
+	switch o.objType {
+	case lib.TCPRoute:
+		_, err = cs.TCPRoutes(namespace).Patch(context.TODO(), name, types.MergePatchType, patchPayload, metav1.PatchOptions{}, "status")
+	case lib.UDPRoute:
+		_, err = cs.UDPRoutes(namespace).Patch(context.TODO(), name, types.MergePatchType, patchPayload, metav1.PatchOptions{}, "status")
+	case lib.TLSRoute:
+		_, err = cs.TLSRoutes(namespace).Patch(context.TODO(), name, types.MergePatchType, patchPayload, metav1.PatchOptions{}, "status")
+	}
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: there was an error in updating the %s status. err: %+v, retry: %d", key, o.objType, err, retry)
+		updatedObj := o.Get(key, name, namespace)
+		if updatedObj == nil {
+			return err
+		}
+		return o.Patch(key, updatedObj, status, retry+1)
+	}
+
+	utils.AviLog.Infof("key: %s, msg: Successfully updated the %s %s/%s status %+v", key, o.objType, namespace, name, utils.Stringify(status))
+	return nil
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright © 2025 Broadcom Inc. and/or its subsidiaries. All Rights Reserved.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package status
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/status"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// UpdateRuntimeStatus folds the operational state of a virtual service into the status of the Gateway, or
+// raises an event on the HTTPRoute, placed on the virtual service.
+func UpdateRuntimeStatus(key string, option status.StatusOptions) {
+	if option.Options == nil || option.Options.RuntimeStatus == nil {
+		return
+	}
+	switch option.ObjType {
+	case lib.Gateway:
+		gw := &gateway{}
+		forEachMergedGateway(option, func(gwOption status.StatusOptions) {
+			gw.UpdateRuntimeStatus(key, gwOption)
+		})
+	case lib.HTTPRoute:
+		route := &httproute{}
+		route.RecordRuntimeStatus(key, option)
+	}
+}
+
+// UpdateRuntimeStatus sets the Programmed condition of the Gateway and its listeners to False while the
+// virtual service is not up, and restores it once the virtual service is up again. Conditions set for other
+// reasons, such as an invalid configuration, are left untouched.
+func (o *gateway) UpdateRuntimeStatus(key string, option status.StatusOptions) {
+	gw := o.Get(key, option)
+	if gw == nil {
+		return
+	}
+	gatewayStatus := gw.Status.DeepCopy()
+	vsName := option.Options.VSName
+	programmed := apimeta.FindStatusCondition(gatewayStatus.Conditions, string(gatewayv1.GatewayConditionProgrammed))
+	if programmed == nil {
+		return
+	}
+
+	conditionStatus := metav1.ConditionTrue
+	reason := string(gatewayv1.GatewayReasonProgrammed)
+	listenerReason := string(gatewayv1.ListenerReasonProgrammed)
+	message := "Virtual service configured/updated"
+	if !option.Options.RuntimeStatus.IsUp() {
+		if programmed.Status != metav1.ConditionTrue && !isVSRuntimeCondition(programmed, vsName) {
+			return
+		}
+		conditionStatus = metav1.ConditionFalse
+		reason = string(gatewayv1.GatewayReasonPending)
+		listenerReason = string(gatewayv1.ListenerReasonPending)
+		message = status.VSRuntimeStatusMessage(vsName, *option.Options.RuntimeStatus)
+	} else if !isVSRuntimeCondition(programmed, vsName) {
+		return
+	}
+
+	NewCondition().
+		Type(string(gatewayv1.GatewayConditionProgrammed)).
+		Status(conditionStatus).
+		Reason(reason).
+		ObservedGeneration(gw.ObjectMeta.Generation).
+		Message(message).
+		SetIn(&gatewayStatus.Conditions)
+
+	for i := range gatewayStatus.Listeners {
+		listenerProgrammed := apimeta.FindStatusCondition(gatewayStatus.Listeners[i].Conditions, string(gatewayv1.ListenerConditionProgrammed))
+		if listenerProgrammed == nil ||
+			(listenerProgrammed.Status != metav1.ConditionTrue && !isVSRuntimeCondition(listenerProgrammed, vsName)) {
+			continue
+		}
+		NewCondition().
+			Type(string(gatewayv1.ListenerConditionProgrammed)).
+			Status(conditionStatus).
+			Reason(listenerReason).
+			ObservedGeneration(gw.ObjectMeta.Generation).
+			Message(message).
+			SetIn(&gatewayStatus.Listeners[i].Conditions)
+	}
+	o.Patch(key, gw, &status.Status{GatewayStatus: gatewayStatus})
+}
+
+// RecordRuntimeStatus raises an event on the HTTPRoute whose rule is placed on a virtual service whose
+// operational state has changed.
+func (o *httproute) RecordRuntimeStatus(key string, option status.StatusOptions) {
+	nsName := strings.Split(option.Options.ServiceMetadata.HTTPRoute, "/")
+	if len(nsName) != 2 {
+		utils.AviLog.Warnf("key: %s, msg: invalid HTTPRoute name and namespace", key)
+		return
+	}
+	httpRoute := o.Get(key, nsName[1], nsName[0])
+	if httpRoute == nil {
+		return
+	}
+	eventType, reason := corev1.EventTypeWarning, lib.VirtualServiceDown
+	if option.Options.RuntimeStatus.IsUp() {
+		eventType, reason = corev1.EventTypeNormal, lib.VirtualServiceUp
+	}
+	akogatewayapilib.AKOControlConfig().EventRecorder().Event(httpRoute, eventType, reason, status.VSRuntimeStatusMessage(option.Options.VSName, *option.Options.RuntimeStatus))
+}
+
+// isVSRuntimeCondition returns true if the Programmed condition was set to False because the virtual service
+// was not up.
+func isVSRuntimeCondition(condition *metav1.Condition, vsName string) bool {
+	return condition.Status == metav1.ConditionFalse &&
+		condition.Reason == string(gatewayv1.GatewayReasonPending) &&
+		strings.HasPrefix(condition.Message, "Virtual service "+vsName+" is ")
+}
@@ -17,6 +17,8 @@ package status
 import (
 	"k8s.io/apimachinery/pkg/runtime"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1alpha3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
 
 	akogatewayapiobjects "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/objects"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
@@ -39,8 +41,14 @@ func New(ObjectType string) StatusUpdater {
 		return &gateway{}
 	case lib.HTTPRoute:
 		return &httproute{}
+	case lib.GRPCRoute:
+		return &grpcroute{}
+	case lib.TCPRoute, lib.UDPRoute, lib.TLSRoute:
+		return &l4route{objType: ObjectType}
 	case lib.NPLService:
 		return &nplservice{publisher: status.NewStatusPublisher()}
+	case lib.BackendTLSPolicy:
+		return &backendTLSPolicy{}
 	}
 	return nil
 }
@@ -57,6 +65,10 @@ func DequeueStatus(objIntf interface{}) error {
 		utils.AviLog.Debugf("key: %s, msg: unknown object received", option.Key)
 		return nil
 	}
+	if option.Op == lib.UpdateRuntimeStatus {
+		UpdateRuntimeStatus(option.Key, option)
+		return nil
+	}
 	if option.Options != nil && option.Options.ServiceMetadata.HTTPRoute != "" && option.Options.Status == nil {
 		utils.AviLog.Debugf("key: %s, msg: Status update for ChildVs received", option.Options.ServiceMetadata.HTTPRoute)
 		return nil
@@ -88,6 +100,11 @@ func Record(key string, obj runtime.Object, objStatus *status.Status) {
 		o := New(objectType)
 		o.Patch(key, obj, objStatus)
 		return
+	case *gatewayv1alpha3.BackendTLSPolicy:
+		objectType = lib.BackendTLSPolicy
+		o := New(objectType)
+		o.Patch(key, obj, objStatus)
+		return
 	case *gatewayv1.Gateway:
 		objectType = lib.Gateway
 		serviceMetadata.Gateway = gwObject.Namespace + "/" + gwObject.Name
@@ -98,6 +115,26 @@ func Record(key string, obj runtime.Object, objStatus *status.Status) {
 		serviceMetadata.HTTPRoute = gwObject.Namespace + "/" + gwObject.Name
 		key = serviceMetadata.HTTPRoute
 		akogatewayapiobjects.GatewayApiLister().UpdateRouteToRouteStatusMapping(objectType+"/"+serviceMetadata.HTTPRoute, objStatus.HTTPRouteStatus)
+	case *gatewayv1.GRPCRoute:
+		objectType = lib.GRPCRoute
+		serviceMetadata.HTTPRoute = gwObject.Namespace + "/" + gwObject.Name
+		key = objectType + "/" + serviceMetadata.HTTPRoute
+		akogatewayapiobjects.GatewayApiLister().UpdateRouteToRouteStatusMapping(key, objStatus.HTTPRouteStatus)
+	case *gatewayv1alpha2.TCPRoute:
+		objectType = lib.TCPRoute
+		serviceMetadata.HTTPRoute = gwObject.Namespace + "/" + gwObject.Name
+		key = objectType + "/" + serviceMetadata.HTTPRoute
+		akogatewayapiobjects.GatewayApiLister().UpdateRouteToRouteStatusMapping(key, objStatus.HTTPRouteStatus)
+	case *gatewayv1alpha2.UDPRoute:
+		objectType = lib.UDPRoute
+		serviceMetadata.HTTPRoute = gwObject.Namespace + "/" + gwObject.Name
+		key = objectType + "/" + serviceMetadata.HTTPRoute
+		akogatewayapiobjects.GatewayApiLister().UpdateRouteToRouteStatusMapping(key, objStatus.HTTPRouteStatus)
+	case *gatewayv1alpha2.TLSRoute:
+		objectType = lib.TLSRoute
+		serviceMetadata.HTTPRoute = gwObject.Namespace + "/" + gwObject.Name
+		key = objectType + "/" + serviceMetadata.HTTPRoute
+		akogatewayapiobjects.GatewayApiLister().UpdateRouteToRouteStatusMapping(key, objStatus.HTTPRouteStatus)
 	default:
 		utils.AviLog.Warnf("key %s, msg: Unsupported object received at the status layer, %T", key, obj)
 		return
@@ -59,7 +59,13 @@ var (
 	version    = "dev"
 )
 
+const deleteCRDValidationWebhookCmd = "delete-crd-validation-webhook"
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == deleteCRDValidationWebhookCmd {
+		DeleteCRDValidationWebhook(os.Args[2:])
+		return
+	}
 
 	InitializeAKOApi()
 
@@ -75,6 +81,31 @@ func InitializeAKOApi() {
 	lib.SetApiServerInstance(akoApi)
 }
 
+// DeleteCRDValidationWebhook removes the CRD validation webhook configuration and exits, it is run
+// by the pre-delete hook of the Helm chart from the AKO image. The subcommand parses its own flags,
+// so that the start-up path of AKO is left as is.
+func DeleteCRDValidationWebhook(args []string) {
+	flags := flag.NewFlagSet(deleteCRDValidationWebhookCmd, flag.ExitOnError)
+	cmdKubeconfig := flags.String("kubeconfig", kubeconfig, "Path to a kubeconfig. Only required if out-of-cluster.")
+	cmdMasterURL := flags.String("master", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig. Only required if out-of-cluster.")
+	flags.Parse(args)
+
+	cfg, err := rest.InClusterConfig()
+	if err != nil {
+		cfg, err = clientcmd.BuildConfigFromFlags(*cmdMasterURL, *cmdKubeconfig)
+		if err != nil {
+			utils.AviLog.Fatalf("Error building kubeconfig: %s", err.Error())
+		}
+	}
+	kubeClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		utils.AviLog.Fatalf("Error building kubernetes clientset: %s", err.Error())
+	}
+	if err := k8s.DeleteCRDValidationWebhookConfiguration(kubeClient); err != nil {
+		os.Exit(1)
+	}
+}
+
 func InitializeAKC() {
 	var err error
 	kubeCluster := false
@@ -360,6 +391,7 @@ func InitializeAKC() {
 
 	go c.InitController(informers, registeredInformers, ctrlCh, stopCh, quickSyncCh, waitGroupMap)
 	<-stopCh
+	k8s.StopCRDValidationWebhook(kubeClient)
 	close(ctrlCh)
 	doneChan := make(chan struct{})
 	go func() {
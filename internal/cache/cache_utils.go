@@ -68,27 +68,29 @@ type AviClusterRuntimeCache struct {
 }
 
 type AviVsCache struct {
-	Name                     string
-	Tenant                   string
-	Uuid                     string
-	CloudConfigCksum         string
-	PGKeyCollection          []NamespaceName
-	VSVipKeyCollection       []NamespaceName
-	PoolKeyCollection        []NamespaceName
-	DSKeyCollection          []NamespaceName
-	HTTPKeyCollection        []NamespaceName
-	SSLKeyCertCollection     []NamespaceName
-	L4PolicyCollection       []NamespaceName
-	SNIChildCollection       []string
-	ParentVSRef              NamespaceName
-	PassthroughParentRef     NamespaceName
-	PassthroughChildRef      NamespaceName
-	ServiceMetadataObj       lib.ServiceMetadataObj
-	LastModified             string
-	EnableRhi                bool
-	InvalidData              bool
-	VSCacheLock              sync.RWMutex
-	StringGroupKeyCollection []NamespaceName
+	Name                             string
+	Tenant                           string
+	Uuid                             string
+	CloudConfigCksum                 string
+	PGKeyCollection                  []NamespaceName
+	VSVipKeyCollection               []NamespaceName
+	PoolKeyCollection                []NamespaceName
+	DSKeyCollection                  []NamespaceName
+	HTTPKeyCollection                []NamespaceName
+	SSLKeyCertCollection             []NamespaceName
+	L4PolicyCollection               []NamespaceName
+	SNIChildCollection               []string
+	ParentVSRef                      NamespaceName
+	PassthroughParentRef             NamespaceName
+	PassthroughChildRef              NamespaceName
+	ServiceMetadataObj               lib.ServiceMetadataObj
+	LastModified                     string
+	EnableRhi                        bool
+	InvalidData                      bool
+	VSCacheLock                      sync.RWMutex
+	StringGroupKeyCollection         []NamespaceName
+	TrafficCloneProfileKeyCollection []NamespaceName
+	ApplicationProfileKeyCollection  []NamespaceName
 }
 
 func (c *AviCache) AviCacheAddVS(k NamespaceName) *AviVsCache {
@@ -235,6 +237,38 @@ func (v *AviVsCache) RemoveFromStringGroupKeyCollection(k NamespaceName) {
 	v.StringGroupKeyCollection = RemoveNamespaceName(v.StringGroupKeyCollection, k)
 }
 
+func (v *AviVsCache) AddToTrafficCloneProfileKeyCollection(k NamespaceName) {
+	if v.TrafficCloneProfileKeyCollection == nil {
+		v.TrafficCloneProfileKeyCollection = []NamespaceName{k}
+	}
+	if !utils.HasElem(v.TrafficCloneProfileKeyCollection, k) {
+		v.TrafficCloneProfileKeyCollection = append(v.TrafficCloneProfileKeyCollection, k)
+	}
+}
+
+func (v *AviVsCache) RemoveFromTrafficCloneProfileKeyCollection(k NamespaceName) {
+	if v.TrafficCloneProfileKeyCollection == nil {
+		return
+	}
+	v.TrafficCloneProfileKeyCollection = RemoveNamespaceName(v.TrafficCloneProfileKeyCollection, k)
+}
+
+func (v *AviVsCache) AddToApplicationProfileKeyCollection(k NamespaceName) {
+	if v.ApplicationProfileKeyCollection == nil {
+		v.ApplicationProfileKeyCollection = []NamespaceName{k}
+	}
+	if !utils.HasElem(v.ApplicationProfileKeyCollection, k) {
+		v.ApplicationProfileKeyCollection = append(v.ApplicationProfileKeyCollection, k)
+	}
+}
+
+func (v *AviVsCache) RemoveFromApplicationProfileKeyCollection(k NamespaceName) {
+	if v.ApplicationProfileKeyCollection == nil {
+		return
+	}
+	v.ApplicationProfileKeyCollection = RemoveNamespaceName(v.ApplicationProfileKeyCollection, k)
+}
+
 func (v *AviVsCache) AddToSSLKeyCertCollection(k NamespaceName) {
 	if v.SSLKeyCertCollection == nil {
 		v.SSLKeyCertCollection = []NamespaceName{k}
@@ -320,6 +354,27 @@ type AviPersistenceProfileCache struct {
 	InvalidData      bool
 }
 
+type AviTrafficCloneProfileCache struct {
+	Name             string
+	Tenant           string
+	Uuid             string
+	CloudConfigCksum uint32
+	LastModified     string
+	InvalidData      bool
+	HasReference     bool
+}
+
+type AviApplicationProfileCache struct {
+	Name                 string
+	Tenant               string
+	Uuid                 string
+	CloudConfigCksum     uint32
+	PkiProfileCollection NamespaceName
+	LastModified         string
+	InvalidData          bool
+	HasReference         bool
+}
+
 type NextPage struct {
 	NextURI    string
 	Collection interface{}
@@ -439,7 +494,7 @@ func (c *AviCache) AviCacheGetAllParentVSKeys() []NamespaceName {
 	var keys []NamespaceName
 	for k, val := range c.cache {
 		vsCache := val.(*AviVsCache)
-		if vsCache.ParentVSRef == (NamespaceName{}) && vsCache.ServiceMetadataObj.PassthroughParentRef == "" {
+		if vsCache.ParentVSRef == (NamespaceName{}) && vsCache.ServiceMetadataObj.PassthroughParentRef == "" && vsCache.ServiceMetadataObj.L4ParentRef == "" {
 			keys = append(keys, k.(NamespaceName))
 		}
 	}
@@ -555,6 +610,18 @@ func (c *AviCache) AviCacheGetNameByUuid(uuid string) (interface{}, bool) {
 			} else if value.(*AviPersistenceProfileCache).Uuid == uuid {
 				return value.(*AviPersistenceProfileCache).Name, true
 			}
+		case *AviApplicationProfileCache:
+			if value.(*AviApplicationProfileCache) == nil {
+				utils.AviLog.Warnf("Got nil value in cache for application profile key %v", reflect.ValueOf(key))
+			} else if value.(*AviApplicationProfileCache).Uuid == uuid {
+				return value.(*AviApplicationProfileCache).Name, true
+			}
+		case *AviTrafficCloneProfileCache:
+			if value.(*AviTrafficCloneProfileCache) == nil {
+				utils.AviLog.Warnf("Got nil value in cache for traffic clone profile key %v", reflect.ValueOf(key))
+			} else if value.(*AviTrafficCloneProfileCache).Uuid == uuid {
+				return value.(*AviTrafficCloneProfileCache).Name, true
+			}
 		}
 	}
 	return nil, false
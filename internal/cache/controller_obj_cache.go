@@ -42,21 +42,23 @@ import (
 )
 
 type AviObjCache struct {
-	PgCache             *AviCache
-	DSCache             *AviCache
-	StringGroupCache    *AviCache
-	PoolCache           *AviCache
-	CloudKeyCache       *AviCache
-	HTTPPolicyCache     *AviCache
-	L4PolicyCache       *AviCache
-	SSLKeyCache         *AviCache
-	PKIProfileCache     *AviCache
-	VSVIPCache          *AviCache
-	VrfCache            *AviCache
-	VsCacheMeta         *AviCache
-	VsCacheLocal        *AviCache
-	AppPersProfileCache *AviCache
-	ClusterStatusCache  *AviCache
+	PgCache                  *AviCache
+	DSCache                  *AviCache
+	StringGroupCache         *AviCache
+	PoolCache                *AviCache
+	CloudKeyCache            *AviCache
+	HTTPPolicyCache          *AviCache
+	L4PolicyCache            *AviCache
+	SSLKeyCache              *AviCache
+	PKIProfileCache          *AviCache
+	VSVIPCache               *AviCache
+	VrfCache                 *AviCache
+	VsCacheMeta              *AviCache
+	VsCacheLocal             *AviCache
+	AppPersProfileCache      *AviCache
+	ClusterStatusCache       *AviCache
+	TrafficCloneProfileCache *AviCache
+	AppProfileCache          *AviCache
 }
 
 func NewAviObjCache() *AviObjCache {
@@ -76,6 +78,8 @@ func NewAviObjCache() *AviObjCache {
 	c.PKIProfileCache = NewAviCache()
 	c.AppPersProfileCache = NewAviCache()
 	c.ClusterStatusCache = NewAviCache()
+	c.TrafficCloneProfileCache = NewAviCache()
+	c.AppProfileCache = NewAviCache()
 	return &c
 }
 
@@ -103,6 +107,8 @@ func (c *AviObjCache) AviRefreshObjectCache(client []*clients.AviClient, cloud s
 	}()
 	c.PopulatePkiProfilesToCache(client[0])
 	c.PopulateAppPersistenceProfileToCache(client[0])
+	c.PopulateTrafficCloneProfileToCache(client[0])
+	c.PopulateApplicationProfileToCache(client[0])
 	c.PopulatePoolsToCache(client[1], cloud)
 	c.PopulatePgDataToCache(client[2], cloud)
 	c.PopulateStringGroupDataToCache(client[8], cloud)
@@ -126,6 +132,7 @@ func (c *AviObjCache) AviRefreshObjectCache(client []*clients.AviClient, cloud s
 
 func (c *AviObjCache) AviCacheRefresh(client *clients.AviClient, cloud string) {
 	c.AviCloudPropertiesPopulate(client, cloud)
+	c.AviParentApplicationProfilePopulate(client, utils.DEFAULT_L7_SECURE_APP_PROFILE)
 }
 
 func (c *AviObjCache) AviObjCachePopulate(client []*clients.AviClient, version string, cloud string) ([]NamespaceName, []NamespaceName, error) {
@@ -166,6 +173,9 @@ func (c *AviObjCache) AviObjCachePopulate(client []*clients.AviClient, version s
 	if err != nil {
 		return vsCacheCopy, allVsKeys, err
 	}
+	if err = c.AviParentApplicationProfilePopulate(client[0], utils.DEFAULT_L7_SECURE_APP_PROFILE); err != nil {
+		utils.AviLog.Warnf("Failed to populate the parent applicationprofile %s, err: %v", utils.DEFAULT_L7_SECURE_APP_PROFILE, err)
+	}
 	if lib.GetDeleteConfigMap() {
 		allParentVsKeys := c.VsCacheMeta.AviCacheGetAllParentVSKeys()
 		return vsCacheCopy, allParentVsKeys, err
@@ -311,6 +321,22 @@ func (c *AviObjCache) MarkReference(vsCacheObj *AviVsCache) {
 			}
 		}
 	}
+
+	for _, objKey := range vsCacheObj.TrafficCloneProfileKeyCollection {
+		if intf, found := c.TrafficCloneProfileCache.AviCacheGet(objKey); found {
+			if obj, ok := intf.(*AviTrafficCloneProfileCache); ok {
+				obj.HasReference = true
+			}
+		}
+	}
+
+	for _, objKey := range vsCacheObj.ApplicationProfileKeyCollection {
+		if intf, found := c.AppProfileCache.AviCacheGet(objKey); found {
+			if obj, ok := intf.(*AviApplicationProfileCache); ok {
+				obj.HasReference = true
+			}
+		}
+	}
 }
 
 // DeleteUnmarked : Adds non referenced cached objects to a Dummy VS, which
@@ -441,19 +467,49 @@ func (c *AviObjCache) DeleteUnmarked(childCollection map[string][]string) {
 
 	}
 
+	trafficCloneKeys := make(map[string][]NamespaceName)
+	for _, objkey := range c.TrafficCloneProfileCache.AviGetAllKeys() {
+		if _, ok := allTenants[objkey.Namespace]; !ok {
+			allTenants[objkey.Namespace] = struct{}{}
+		}
+		intf, _ := c.TrafficCloneProfileCache.AviCacheGet(objkey)
+		if obj, ok := intf.(*AviTrafficCloneProfileCache); ok {
+			if !obj.HasReference {
+				utils.AviLog.Infof("Reference Not found for trafficcloneprofile: %s", objkey)
+				trafficCloneKeys[objkey.Namespace] = append(trafficCloneKeys[objkey.Namespace], objkey)
+			}
+		}
+	}
+
+	appProfileKeys := make(map[string][]NamespaceName)
+	for _, objkey := range c.AppProfileCache.AviGetAllKeys() {
+		if _, ok := allTenants[objkey.Namespace]; !ok {
+			allTenants[objkey.Namespace] = struct{}{}
+		}
+		intf, _ := c.AppProfileCache.AviCacheGet(objkey)
+		if obj, ok := intf.(*AviApplicationProfileCache); ok {
+			if !obj.HasReference {
+				utils.AviLog.Infof("Reference Not found for applicationprofile: %s", objkey)
+				appProfileKeys[objkey.Namespace] = append(appProfileKeys[objkey.Namespace], objkey)
+			}
+		}
+	}
+
 	for tenant := range allTenants {
 		// Only add this if we have stale data
 		vsMetaObj := AviVsCache{
-			Name:                     lib.DummyVSForStaleData,
-			VSVipKeyCollection:       vsVipKeys[tenant],
-			HTTPKeyCollection:        httpKeys[tenant],
-			DSKeyCollection:          dsKeys[tenant],
-			SSLKeyCertCollection:     sslKeys[tenant],
-			PGKeyCollection:          pgKeys[tenant],
-			PoolKeyCollection:        poolKeys[tenant],
-			L4PolicyCollection:       l4Keys[tenant],
-			StringGroupKeyCollection: sgKeys[tenant],
-			SNIChildCollection:       childCollection[tenant],
+			Name:                             lib.DummyVSForStaleData,
+			VSVipKeyCollection:               vsVipKeys[tenant],
+			HTTPKeyCollection:                httpKeys[tenant],
+			DSKeyCollection:                  dsKeys[tenant],
+			SSLKeyCertCollection:             sslKeys[tenant],
+			PGKeyCollection:                  pgKeys[tenant],
+			PoolKeyCollection:                poolKeys[tenant],
+			L4PolicyCollection:               l4Keys[tenant],
+			StringGroupKeyCollection:         sgKeys[tenant],
+			SNIChildCollection:               childCollection[tenant],
+			TrafficCloneProfileKeyCollection: trafficCloneKeys[tenant],
+			ApplicationProfileKeyCollection:  appProfileKeys[tenant],
 		}
 		vsKey := NamespaceName{
 			Namespace: tenant,
@@ -1270,15 +1326,186 @@ func (c *AviObjCache) AviPopulateOnePersistenceProfileCache(client *clients.AviC
 	return nil
 }
 
+func (c *AviObjCache) AviPopulateOneTrafficCloneProfileCache(client *clients.AviClient, objName string) error {
+	var uri string
+	uri = "/api/trafficcloneprofile?name=" + objName + "&include_name=true"
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for trafficcloneprofile %v", uri, err)
+		return err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal trafficcloneprofile data, err: %v", err)
+		return err
+	}
+	for i := 0; i < len(elems); i++ {
+		trafficCloneProfile := models.TrafficCloneProfile{}
+		err = json.Unmarshal(elems[i], &trafficCloneProfile)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal trafficcloneprofile data, err: %v", err)
+			continue
+		}
+		if trafficCloneProfile.Name == nil || trafficCloneProfile.UUID == nil {
+			utils.AviLog.Warnf("Incomplete trafficcloneprofile data unmarshalled, %s", utils.Stringify(trafficCloneProfile))
+			continue
+		}
+		//Only cache a Traffic Clone Profile that belongs to this AKO.
+		if !strings.HasPrefix(*trafficCloneProfile.Name, lib.GetNamePrefix()) {
+			continue
+		}
+		tenant := getTenantFromTenantRef(*trafficCloneProfile.TenantRef)
+		cacheObj := AviTrafficCloneProfileCache{
+			Name:             *trafficCloneProfile.Name,
+			Tenant:           tenant,
+			Uuid:             *trafficCloneProfile.UUID,
+			CloudConfigCksum: CalculateTrafficCloneProfileChecksum(trafficCloneProfile),
+		}
+		if trafficCloneProfile.LastModified != nil {
+			cacheObj.LastModified = *trafficCloneProfile.LastModified
+		}
+		k := NamespaceName{Namespace: tenant, Name: *trafficCloneProfile.Name}
+		c.TrafficCloneProfileCache.AviCacheAdd(k, &cacheObj)
+		utils.AviLog.Debugf("Adding trafficcloneprofile to Cache during refresh %s", k)
+	}
+	return nil
+}
+
+func (c *AviObjCache) AviPopulateOneApplicationProfileCache(client *clients.AviClient, objName string) error {
+	var uri string
+	uri = "/api/applicationprofile?name=" + objName + "&include_name=true"
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for applicationprofile %v", uri, err)
+		return err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal applicationprofile data, err: %v", err)
+		return err
+	}
+	for i := 0; i < len(elems); i++ {
+		appProfile := models.ApplicationProfile{}
+		err = json.Unmarshal(elems[i], &appProfile)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal applicationprofile data, err: %v", err)
+			continue
+		}
+		if appProfile.Name == nil || appProfile.UUID == nil {
+			utils.AviLog.Warnf("Incomplete applicationprofile data unmarshalled, %s", utils.Stringify(appProfile))
+			continue
+		}
+		//Only cache an Application Profile that belongs to this AKO.
+		if !strings.HasPrefix(*appProfile.Name, lib.GetNamePrefix()) {
+			continue
+		}
+		tenant := getTenantFromTenantRef(*appProfile.TenantRef)
+		cacheObj := AviApplicationProfileCache{
+			Name:                 *appProfile.Name,
+			Tenant:               tenant,
+			Uuid:                 *appProfile.UUID,
+			CloudConfigCksum:     CalculateApplicationProfileChecksum(appProfile),
+			PkiProfileCollection: c.getApplicationProfilePkiKey(appProfile, tenant),
+		}
+		if appProfile.LastModified != nil {
+			cacheObj.LastModified = *appProfile.LastModified
+		}
+		k := NamespaceName{Namespace: tenant, Name: *appProfile.Name}
+		c.AppProfileCache.AviCacheAdd(k, &cacheObj)
+		utils.AviLog.Debugf("Adding applicationprofile to Cache during refresh %s", k)
+	}
+	return nil
+}
+
 func CalculatePersistenProfileChecksum(appPersProfileModel models.ApplicationPersistenceProfile) uint32 {
 	emptyIngestionMarkers := utils.AviObjectMarkers{}
 	chksum := lib.PersistenceProfileChecksum(*appPersProfileModel.Name, *appPersProfileModel.PersistenceType, emptyIngestionMarkers, appPersProfileModel.Markers, true)
 	if appPersProfileModel.HTTPCookiePersistenceProfile != nil {
 		chksum += lib.HTTPCookiePersistenceProfileChecksum(*appPersProfileModel.HTTPCookiePersistenceProfile.CookieName, appPersProfileModel.HTTPCookiePersistenceProfile.Timeout, appPersProfileModel.HTTPCookiePersistenceProfile.IsPersistentCookie)
 	}
+	if appPersProfileModel.AppCookiePersistenceProfile != nil && appPersProfileModel.AppCookiePersistenceProfile.PrstHdrName != nil {
+		chksum += lib.AppCookiePersistenceProfileChecksum(*appPersProfileModel.AppCookiePersistenceProfile.PrstHdrName, appPersProfileModel.AppCookiePersistenceProfile.Timeout)
+	}
 	return chksum
 }
 
+func CalculateTrafficCloneProfileChecksum(trafficCloneProfileModel models.TrafficCloneProfile) uint32 {
+	emptyIngestionMarkers := utils.AviObjectMarkers{}
+	var cloneServers []string
+	for _, cloneServer := range trafficCloneProfileModel.CloneServers {
+		if cloneServer.IPAddress != nil && cloneServer.IPAddress.Addr != nil {
+			cloneServers = append(cloneServers, *cloneServer.IPAddress.Addr)
+		}
+	}
+	return lib.TrafficCloneProfileChecksum(*trafficCloneProfileModel.Name, cloneServers, emptyIngestionMarkers, trafficCloneProfileModel.Markers, true)
+}
+
+func CalculateApplicationProfileChecksum(appProfileModel models.ApplicationProfile) uint32 {
+	emptyIngestionMarkers := utils.AviObjectMarkers{}
+	var sslClientCertificateMode string
+	if appProfileModel.HTTPProfile != nil && appProfileModel.HTTPProfile.SslClientCertificateMode != nil {
+		sslClientCertificateMode = *appProfileModel.HTTPProfile.SslClientCertificateMode
+	}
+	return lib.ApplicationProfileChecksum(*appProfileModel.Name, sslClientCertificateMode, lib.ApplicationProfileSettingsChecksum(appProfileModel),
+		emptyIngestionMarkers, appProfileModel.Markers, true)
+}
+
+// parentApplicationProfiles holds the ApplicationProfiles, keyed by name, from which the AKO managed
+// ApplicationProfiles inherit their settings.
+var parentApplicationProfiles sync.Map
+
+// AviParentApplicationProfilePopulate fetches the ApplicationProfile from which the AKO managed ApplicationProfiles
+// inherit their settings, so that the profiles are built without fetching the parent on every sync.
+func (c *AviObjCache) AviParentApplicationProfilePopulate(client *clients.AviClient, objName string) error {
+	uri := "/api/applicationprofile/?name=" + objName
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for applicationprofile %v", uri, err)
+		return err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	if err = json.Unmarshal(result.Results, &elems); err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal applicationprofile data, err: %v", err)
+		return err
+	}
+	if len(elems) == 0 {
+		return fmt.Errorf("applicationprofile %s not found", objName)
+	}
+	appProfile := models.ApplicationProfile{}
+	if err = json.Unmarshal(elems[0], &appProfile); err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal applicationprofile data, err: %v", err)
+		return err
+	}
+	parentApplicationProfiles.Store(objName, appProfile)
+	utils.AviLog.Debugf("Added parent applicationprofile %s to cache", objName)
+	return nil
+}
+
+// GetParentApplicationProfile returns a copy of the parent ApplicationProfile fetched during the cache population.
+func GetParentApplicationProfile(name string) (models.ApplicationProfile, bool) {
+	appProfile, ok := parentApplicationProfiles.Load(name)
+	if !ok {
+		return models.ApplicationProfile{}, false
+	}
+	return appProfile.(models.ApplicationProfile), true
+}
+
+// getApplicationProfilePkiKey returns the cache key of the PKI profile used by the application profile
+// to validate the client certificates.
+func (c *AviObjCache) getApplicationProfilePkiKey(appProfileModel models.ApplicationProfile, tenant string) NamespaceName {
+	if appProfileModel.HTTPProfile == nil || appProfileModel.HTTPProfile.PkiProfileRef == nil {
+		return NamespaceName{}
+	}
+	pkiUuid := ExtractUUID(*appProfileModel.HTTPProfile.PkiProfileRef, "pkiprofile-.*.#")
+	pkiName, found := c.PKIProfileCache.AviCacheGetNameByUuid(pkiUuid)
+	if !found {
+		return NamespaceName{}
+	}
+	return NamespaceName{Namespace: tenant, Name: pkiName.(string)}
+}
+
 func (c *AviObjCache) AviPopulateOnePoolCache(client *clients.AviClient,
 	cloud string, objName string) error {
 	var uri string
@@ -1693,10 +1920,17 @@ func (c *AviObjCache) AviPopulateOneVsL4PolCache(client *clients.AviClient,
 			for _, rule := range l4pol.L4ConnectionPolicy.Rules {
 				protocols = append(protocols, *rule.Match.Protocol.Protocol)
 				if rule.Action != nil {
-					poolUuid := ExtractUUID(*rule.Action.SelectPool.PoolRef, "pool-.*.#")
-					poolName, found := c.PoolCache.AviCacheGetNameByUuid(poolUuid)
-					if found {
-						pools = append(pools, poolName.(string))
+					if rule.Action.SelectPool.PoolGroupRef != nil {
+						pgUuid := ExtractUUID(*rule.Action.SelectPool.PoolGroupRef, "poolgroup-.*.#")
+						if pgName, found := c.PgCache.AviCacheGetNameByUuid(pgUuid); found {
+							pools = append(pools, pgName.(string))
+						}
+					} else {
+						poolUuid := ExtractUUID(*rule.Action.SelectPool.PoolRef, "pool-.*.#")
+						poolName, found := c.PoolCache.AviCacheGetNameByUuid(poolUuid)
+						if found {
+							pools = append(pools, poolName.(string))
+						}
 					}
 				}
 				if rule.Match != nil {
@@ -1999,10 +2233,17 @@ func (c *AviObjCache) AviPopulateAllL4PolicySets(client *clients.AviClient, clou
 						protocol = utils.UDP
 					}
 					protocols = append(protocols, protocol)
-					poolUuid := ExtractUUID(*rule.Action.SelectPool.PoolRef, "pool-.*.#")
-					poolName, found := c.PoolCache.AviCacheGetNameByUuid(poolUuid)
-					if found {
-						pools = append(pools, poolName.(string))
+					if rule.Action.SelectPool.PoolGroupRef != nil {
+						pgUuid := ExtractUUID(*rule.Action.SelectPool.PoolGroupRef, "poolgroup-.*.#")
+						if pgName, found := c.PgCache.AviCacheGetNameByUuid(pgUuid); found {
+							pools = append(pools, pgName.(string))
+						}
+					} else {
+						poolUuid := ExtractUUID(*rule.Action.SelectPool.PoolRef, "pool-.*.#")
+						poolName, found := c.PoolCache.AviCacheGetNameByUuid(poolUuid)
+						if found {
+							pools = append(pools, poolName.(string))
+						}
 					}
 				}
 				if rule.Match != nil {
@@ -2318,6 +2559,244 @@ func (c *AviObjCache) PopulateAppPersistenceProfileToCache(client *clients.AviCl
 		c.AppPersProfileCache.AviCacheDelete(key)
 	}
 }
+func (c *AviObjCache) AviPopulateAllTrafficCloneProfiles(client *clients.AviClient, trafficCloneProfileData *[]AviTrafficCloneProfileCache, nextPage ...NextPage) (*[]AviTrafficCloneProfileCache, int, error) {
+	var uri string
+	if len(nextPage) == 1 {
+		uri = nextPage[0].NextURI
+	} else {
+		uri = "/api/trafficcloneprofile/?" + "name.contains=" + lib.GetNamePrefix() + "&include_name=true" + "&page_size=100"
+	}
+	utils.AviLog.Debugf("Get uri %v for trafficcloneprofile: ", uri)
+
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for trafficcloneprofile %v", uri, err)
+		return nil, 0, err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal trafficcloneprofile data, err: %v", err)
+		return nil, 0, err
+	}
+	for i := 0; i < len(elems); i++ {
+		trafficCloneProfile := models.TrafficCloneProfile{}
+		err = json.Unmarshal(elems[i], &trafficCloneProfile)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal trafficcloneprofile data, err: %v", err)
+			continue
+		}
+		if trafficCloneProfile.Name == nil || trafficCloneProfile.UUID == nil {
+			utils.AviLog.Warnf("Incomplete trafficcloneprofile data unmarshalled, %s", utils.Stringify(trafficCloneProfile))
+			continue
+		}
+
+		trafficCloneProfileCacheObj := AviTrafficCloneProfileCache{
+			Name:             *trafficCloneProfile.Name,
+			Tenant:           getTenantFromTenantRef(*trafficCloneProfile.TenantRef),
+			Uuid:             *trafficCloneProfile.UUID,
+			CloudConfigCksum: CalculateTrafficCloneProfileChecksum(trafficCloneProfile),
+		}
+		if trafficCloneProfile.LastModified != nil {
+			trafficCloneProfileCacheObj.LastModified = *trafficCloneProfile.LastModified
+		}
+		*trafficCloneProfileData = append(*trafficCloneProfileData, trafficCloneProfileCacheObj)
+	}
+
+	if result.Next != "" {
+		next_uri := strings.Split(result.Next, "/api/trafficcloneprofile")
+		if len(next_uri) > 1 {
+			overrideUri := "/api/trafficcloneprofile" + next_uri[1]
+			nextPage := NextPage{NextURI: overrideUri}
+			_, _, err := c.AviPopulateAllTrafficCloneProfiles(client, trafficCloneProfileData, nextPage)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+	return trafficCloneProfileData, result.Count, nil
+}
+
+func (c *AviObjCache) PopulateTrafficCloneProfileToCache(client *clients.AviClient) {
+	var trafficCloneProfileData []AviTrafficCloneProfileCache
+	setDefaultTenant := session.SetTenant(lib.GetTenant())
+	setTenant := session.SetTenant(lib.GetQueryTenant())
+	setTenant(client.AviSession)
+	defer setDefaultTenant(client.AviSession)
+	c.AviPopulateAllTrafficCloneProfiles(client, &trafficCloneProfileData)
+
+	trafficCloneProfileCacheData := c.TrafficCloneProfileCache.ShallowCopy()
+	for i, trafficCloneProfile := range trafficCloneProfileData {
+		k := NamespaceName{Namespace: trafficCloneProfile.Tenant, Name: trafficCloneProfile.Name}
+		oldTrafficCloneProfileIntf, found := c.TrafficCloneProfileCache.AviCacheGet(k)
+		if found {
+			oldTrafficCloneProfileData, ok := oldTrafficCloneProfileIntf.(*AviTrafficCloneProfileCache)
+			if ok {
+				if oldTrafficCloneProfileData.InvalidData {
+					trafficCloneProfileData[i].InvalidData = true
+					utils.AviLog.Infof("Invalid cache data for traffic clone profile: %s", k)
+				}
+			} else {
+				utils.AviLog.Infof("Wrong data type for traffic clone profile: %s in cache", k)
+			}
+		}
+		utils.AviLog.Infof("Adding key to traffic clone profile cache :%s value :%s", k, trafficCloneProfile.Uuid)
+		c.TrafficCloneProfileCache.AviCacheAdd(k, &trafficCloneProfileData[i])
+		delete(trafficCloneProfileCacheData, k)
+	}
+	// The data that is left in trafficCloneProfileCacheData should be explicitly removed
+	for key := range trafficCloneProfileCacheData {
+		_, ok := key.(NamespaceName)
+		if !ok {
+			continue
+		}
+		utils.AviLog.Infof("Deleting key from traffic clone profile cache :%s", key)
+		c.TrafficCloneProfileCache.AviCacheDelete(key)
+	}
+}
+
+// getTrafficCloneProfileKeys returns the cache key of the traffic clone profile referred by the VS
+func (c *AviObjCache) getTrafficCloneProfileKeys(vs map[string]interface{}, tenant string) []NamespaceName {
+	trafficCloneProfileRef, ok := vs["traffic_clone_profile_ref"].(string)
+	if !ok {
+		return nil
+	}
+	trafficCloneProfileUuid := ExtractUUID(trafficCloneProfileRef, "trafficcloneprofile-.*.#")
+	trafficCloneProfileName, found := c.TrafficCloneProfileCache.AviCacheGetNameByUuid(trafficCloneProfileUuid)
+	if !found {
+		return nil
+	}
+	return []NamespaceName{{Namespace: tenant, Name: trafficCloneProfileName.(string)}}
+}
+
+func (c *AviObjCache) AviPopulateAllApplicationProfiles(client *clients.AviClient, appProfileData *[]AviApplicationProfileCache, nextPage ...NextPage) (*[]AviApplicationProfileCache, int, error) {
+	var uri string
+	if len(nextPage) == 1 {
+		uri = nextPage[0].NextURI
+	} else {
+		uri = "/api/applicationprofile/?" + "name.contains=" + lib.GetNamePrefix() + "&include_name=true" + "&page_size=100"
+	}
+	utils.AviLog.Debugf("Get uri %v for applicationprofile: ", uri)
+
+	result, err := lib.AviGetCollectionRaw(client, uri)
+	if err != nil {
+		utils.AviLog.Warnf("Get uri %v returned err for applicationprofile %v", uri, err)
+		return nil, 0, err
+	}
+	elems := make([]json.RawMessage, result.Count)
+	err = json.Unmarshal(result.Results, &elems)
+	if err != nil {
+		utils.AviLog.Warnf("Failed to unmarshal applicationprofile data, err: %v", err)
+		return nil, 0, err
+	}
+	for i := 0; i < len(elems); i++ {
+		appProfile := models.ApplicationProfile{}
+		err = json.Unmarshal(elems[i], &appProfile)
+		if err != nil {
+			utils.AviLog.Warnf("Failed to unmarshal applicationprofile data, err: %v", err)
+			continue
+		}
+		if appProfile.Name == nil || appProfile.UUID == nil {
+			utils.AviLog.Warnf("Incomplete applicationprofile data unmarshalled, %s", utils.Stringify(appProfile))
+			continue
+		}
+
+		tenant := getTenantFromTenantRef(*appProfile.TenantRef)
+		appProfileCacheObj := AviApplicationProfileCache{
+			Name:                 *appProfile.Name,
+			Tenant:               tenant,
+			Uuid:                 *appProfile.UUID,
+			CloudConfigCksum:     CalculateApplicationProfileChecksum(appProfile),
+			PkiProfileCollection: c.getApplicationProfilePkiKey(appProfile, tenant),
+		}
+		if appProfile.LastModified != nil {
+			appProfileCacheObj.LastModified = *appProfile.LastModified
+		}
+		*appProfileData = append(*appProfileData, appProfileCacheObj)
+	}
+
+	if result.Next != "" {
+		next_uri := strings.Split(result.Next, "/api/applicationprofile")
+		if len(next_uri) > 1 {
+			overrideUri := "/api/applicationprofile" + next_uri[1]
+			nextPage := NextPage{NextURI: overrideUri}
+			_, _, err := c.AviPopulateAllApplicationProfiles(client, appProfileData, nextPage)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+	return appProfileData, result.Count, nil
+}
+
+func (c *AviObjCache) PopulateApplicationProfileToCache(client *clients.AviClient) {
+	var appProfileData []AviApplicationProfileCache
+	setDefaultTenant := session.SetTenant(lib.GetTenant())
+	setTenant := session.SetTenant(lib.GetQueryTenant())
+	setTenant(client.AviSession)
+	defer setDefaultTenant(client.AviSession)
+	c.AviPopulateAllApplicationProfiles(client, &appProfileData)
+
+	appProfileCacheData := c.AppProfileCache.ShallowCopy()
+	for i, appProfile := range appProfileData {
+		k := NamespaceName{Namespace: appProfile.Tenant, Name: appProfile.Name}
+		oldAppProfileIntf, found := c.AppProfileCache.AviCacheGet(k)
+		if found {
+			oldAppProfileData, ok := oldAppProfileIntf.(*AviApplicationProfileCache)
+			if ok {
+				if oldAppProfileData.InvalidData {
+					appProfileData[i].InvalidData = true
+					utils.AviLog.Infof("Invalid cache data for application profile: %s", k)
+				}
+			} else {
+				utils.AviLog.Infof("Wrong data type for application profile: %s in cache", k)
+			}
+		}
+		utils.AviLog.Infof("Adding key to application profile cache :%s value :%s", k, appProfile.Uuid)
+		c.AppProfileCache.AviCacheAdd(k, &appProfileData[i])
+		delete(appProfileCacheData, k)
+	}
+	// The data that is left in appProfileCacheData should be explicitly removed
+	for key := range appProfileCacheData {
+		_, ok := key.(NamespaceName)
+		if !ok {
+			continue
+		}
+		utils.AviLog.Infof("Deleting key from application profile cache :%s", key)
+		c.AppProfileCache.AviCacheDelete(key)
+	}
+}
+
+// getApplicationProfileKeys returns the cache keys of the AKO managed application profiles
+// set as overrides on the services of the VS
+func (c *AviObjCache) getApplicationProfileKeys(vs map[string]interface{}, tenant string) []NamespaceName {
+	services, ok := vs["services"].([]interface{})
+	if !ok {
+		return nil
+	}
+	var appProfileKeys []NamespaceName
+	for _, serviceIntf := range services {
+		service, ok := serviceIntf.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		appProfileRef, ok := service["override_application_profile_ref"].(string)
+		if !ok {
+			continue
+		}
+		appProfileUuid := ExtractUUID(appProfileRef, "applicationprofile-.*.#")
+		appProfileName, found := c.AppProfileCache.AviCacheGetNameByUuid(appProfileUuid)
+		if !found {
+			continue
+		}
+		appProfileKey := NamespaceName{Namespace: tenant, Name: appProfileName.(string)}
+		if !utils.HasElem(appProfileKeys, appProfileKey) {
+			appProfileKeys = append(appProfileKeys, appProfileKey)
+		}
+	}
+	return appProfileKeys
+}
+
 func (c *AviObjCache) AviObjVrfCachePopulate(client *clients.AviClient, cloud string) error {
 	if lib.GetDisableStaticRoute() {
 		utils.AviLog.Debugf("Static route sync disabled, skipping vrf cache population")
@@ -2549,6 +3028,14 @@ func (c *AviObjCache) AviObjVSCachePopulate(client *clients.AviClient, cloud str
 								l4key := NamespaceName{Namespace: tenant, Name: l4Name.(string)}
 								l4Obj, _ := c.L4PolicyCache.AviCacheGet(l4key)
 								for _, poolName := range l4Obj.(*AviL4PolicyCache).Pools {
+									// The rules of the policysets of a Gateway L4 VS select poolgroups.
+									pgKey := NamespaceName{Namespace: tenant, Name: poolName}
+									if _, foundpg := c.PgCache.AviCacheGet(pgKey); foundpg {
+										poolgroupKeys = append(poolgroupKeys, pgKey)
+										pgpoolKeys := c.AviPGPoolCachePopulate(client, cloud, poolName, tenant)
+										poolKeys = append(poolKeys, pgpoolKeys...)
+										continue
+									}
 									poolKey := NamespaceName{Namespace: tenant, Name: poolName}
 									poolKeys = append(poolKeys, poolKey)
 								}
@@ -2612,22 +3099,24 @@ func (c *AviObjCache) AviObjVSCachePopulate(client *clients.AviClient, cloud str
 
 				// Populate the vscache meta object here.
 				vsMetaObj := AviVsCache{
-					Name:                     vs["name"].(string),
-					Tenant:                   tenant,
-					Uuid:                     vs["uuid"].(string),
-					VSVipKeyCollection:       vsVipKey,
-					HTTPKeyCollection:        httpKeys,
-					DSKeyCollection:          dsKeys,
-					SSLKeyCertCollection:     sslKeys,
-					PGKeyCollection:          poolgroupKeys,
-					PoolKeyCollection:        poolKeys,
-					CloudConfigCksum:         vs["cloud_config_cksum"].(string),
-					SNIChildCollection:       sni_child_collection,
-					ParentVSRef:              parentVSKey,
-					ServiceMetadataObj:       svc_mdata_obj,
-					L4PolicyCollection:       l4Keys,
-					LastModified:             vs["_last_modified"].(string),
-					StringGroupKeyCollection: stringgroupKeys,
+					Name:                             vs["name"].(string),
+					Tenant:                           tenant,
+					Uuid:                             vs["uuid"].(string),
+					VSVipKeyCollection:               vsVipKey,
+					HTTPKeyCollection:                httpKeys,
+					DSKeyCollection:                  dsKeys,
+					SSLKeyCertCollection:             sslKeys,
+					PGKeyCollection:                  poolgroupKeys,
+					PoolKeyCollection:                poolKeys,
+					CloudConfigCksum:                 vs["cloud_config_cksum"].(string),
+					SNIChildCollection:               sni_child_collection,
+					ParentVSRef:                      parentVSKey,
+					ServiceMetadataObj:               svc_mdata_obj,
+					L4PolicyCollection:               l4Keys,
+					LastModified:                     vs["_last_modified"].(string),
+					StringGroupKeyCollection:         stringgroupKeys,
+					TrafficCloneProfileKeyCollection: c.getTrafficCloneProfileKeys(vs, tenant),
+					ApplicationProfileKeyCollection:  c.getApplicationProfileKeys(vs, tenant),
 				}
 				if val, ok := vs["enable_rhi"]; ok {
 					vsMetaObj.EnableRhi = val.(bool)
@@ -2864,6 +3353,14 @@ func (c *AviObjCache) AviObjOneVSCachePopulate(client *clients.AviClient, cloud
 								l4key := NamespaceName{Namespace: tenant, Name: l4Name.(string)}
 								l4Obj, _ := c.L4PolicyCache.AviCacheGet(l4key)
 								for _, poolName := range l4Obj.(*AviL4PolicyCache).Pools {
+									// The rules of the policysets of a Gateway L4 VS select poolgroups.
+									pgKey := NamespaceName{Namespace: tenant, Name: poolName}
+									if _, foundpg := c.PgCache.AviCacheGet(pgKey); foundpg {
+										poolgroupKeys = append(poolgroupKeys, pgKey)
+										pgpoolKeys := c.AviPGPoolCachePopulate(client, cloud, poolName, tenant)
+										poolKeys = append(poolKeys, pgpoolKeys...)
+										continue
+									}
 									poolKey := NamespaceName{Namespace: tenant, Name: poolName}
 									poolKeys = append(poolKeys, poolKey)
 								}
@@ -2918,21 +3415,23 @@ func (c *AviObjCache) AviObjOneVSCachePopulate(client *clients.AviClient, cloud
 				}
 				// Populate the vscache meta object here.
 				vsMetaObj := AviVsCache{
-					Name:                     vs["name"].(string),
-					Tenant:                   tenant,
-					Uuid:                     vs["uuid"].(string),
-					VSVipKeyCollection:       vsVipKey,
-					HTTPKeyCollection:        httpKeys,
-					DSKeyCollection:          dsKeys,
-					SSLKeyCertCollection:     sslKeys,
-					PGKeyCollection:          poolgroupKeys,
-					PoolKeyCollection:        poolKeys,
-					CloudConfigCksum:         vs["cloud_config_cksum"].(string),
-					SNIChildCollection:       sni_child_collection,
-					ParentVSRef:              parentVSKey,
-					L4PolicyCollection:       l4Keys,
-					ServiceMetadataObj:       svc_mdata_obj,
-					StringGroupKeyCollection: stringgroupKeys,
+					Name:                             vs["name"].(string),
+					Tenant:                           tenant,
+					Uuid:                             vs["uuid"].(string),
+					VSVipKeyCollection:               vsVipKey,
+					HTTPKeyCollection:                httpKeys,
+					DSKeyCollection:                  dsKeys,
+					SSLKeyCertCollection:             sslKeys,
+					PGKeyCollection:                  poolgroupKeys,
+					PoolKeyCollection:                poolKeys,
+					CloudConfigCksum:                 vs["cloud_config_cksum"].(string),
+					SNIChildCollection:               sni_child_collection,
+					ParentVSRef:                      parentVSKey,
+					L4PolicyCollection:               l4Keys,
+					ServiceMetadataObj:               svc_mdata_obj,
+					StringGroupKeyCollection:         stringgroupKeys,
+					TrafficCloneProfileKeyCollection: c.getTrafficCloneProfileKeys(vs, tenant),
+					ApplicationProfileKeyCollection:  c.getApplicationProfileKeys(vs, tenant),
 				}
 				if val, ok := vs["enable_rhi"]; ok {
 					vsMetaObj.EnableRhi = val.(bool)
@@ -0,0 +1,105 @@
+/*
+ * Copyright © 2025 Broadcom Inc. and/or its subsidiaries. All Rights Reserved.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package cache
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/vmware/alb-sdk/go/clients"
+	"github.com/vmware/alb-sdk/go/session"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// FetchVSRuntimeStatus fetches the operational state of all the virtual services created by AKO in the cloud,
+// using a single paginated query on the virtual service inventory.
+func FetchVSRuntimeStatus(client *clients.AviClient, cloud string) (map[NamespaceName]lib.VSRuntimeStatus, error) {
+	setDefaultTenant := session.SetTenant(lib.GetTenant())
+	setTenant := session.SetTenant(lib.GetQueryTenant())
+	setTenant(client.AviSession)
+	defer setDefaultTenant(client.AviSession)
+
+	vsRuntimeStatus := make(map[NamespaceName]lib.VSRuntimeStatus)
+	uri := "/api/virtualservice-inventory/?" + "include_name=true" + "&cloud_ref.name=" + cloud + "&created_by=" + lib.AKOUser + "&page_size=100"
+	for uri != "" {
+		var restResponse interface{}
+		if err := lib.AviGet(client, uri, &restResponse); err != nil {
+			utils.AviLog.Warnf("VS inventory Get uri %v returned err %v", uri, err)
+			return nil, err
+		}
+		resp, ok := restResponse.(map[string]interface{})
+		if !ok {
+			utils.AviLog.Warnf("VS inventory Get uri %v returned %v type %T", uri, restResponse, restResponse)
+			return nil, errors.New("VS inventory type is wrong")
+		}
+		results, ok := resp["results"].([]interface{})
+		if !ok {
+			utils.AviLog.Warnf("results not of type []interface{} Instead of type %T", resp["results"])
+			return nil, errors.New("Results are not of right type for VS inventory")
+		}
+		for _, result := range results {
+			vsInventory, ok := result.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			config, ok := vsInventory["config"].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, ok := config["name"].(string)
+			if !ok {
+				continue
+			}
+			tenantRef, _ := config["tenant_ref"].(string)
+			k := NamespaceName{Namespace: getTenantFromTenantRef(tenantRef), Name: name}
+			vsRuntimeStatus[k] = parseVSOperStatus(vsInventory["runtime"])
+		}
+
+		uri = ""
+		if next, ok := resp["next"].(string); ok {
+			nextURI := strings.Split(next, "/api/virtualservice-inventory")
+			if len(nextURI) > 1 {
+				uri = "/api/virtualservice-inventory" + nextURI[1]
+				utils.AviLog.Debugf("Next page uri for vs inventory: %s", uri)
+			}
+		}
+	}
+	return vsRuntimeStatus, nil
+}
+
+func parseVSOperStatus(runtimeIntf interface{}) lib.VSRuntimeStatus {
+	var vsRuntimeStatus lib.VSRuntimeStatus
+	runtime, ok := runtimeIntf.(map[string]interface{})
+	if !ok {
+		return vsRuntimeStatus
+	}
+	operStatus, ok := runtime["oper_status"].(map[string]interface{})
+	if !ok {
+		return vsRuntimeStatus
+	}
+	vsRuntimeStatus.OperState, _ = operStatus["state"].(string)
+	if reasons, ok := operStatus["reason"].([]interface{}); ok {
+		var reasonList []string
+		for _, reason := range reasons {
+			if reasonStr, ok := reason.(string); ok && reasonStr != "" {
+				reasonList = append(reasonList, reasonStr)
+			}
+		}
+		vsRuntimeStatus.Reason = strings.Join(reasonList, "; ")
+	}
+	return vsRuntimeStatus
+}
@@ -476,6 +476,7 @@ func (c *AviController) InitController(informers K8sinformers, registeredInforme
 	// set up signals so we handle the first shutdown signal gracefully
 	var worker *utils.FullSyncThread
 	var tokenWorker *utils.FullSyncThread
+	var vsRuntimeWorker *utils.FullSyncThread
 	informersArg := make(map[string]interface{})
 	informersArg[utils.INFORMERS_OPENSHIFT_CLIENT] = informers.OshiftClient
 	if lib.GetNamespaceToSync() != "" {
@@ -617,6 +618,14 @@ func (c *AviController) InitController(informers K8sinformers, registeredInforme
 	statusQueue.SyncFunc = SyncFromStatusQueue
 	statusQueue.Run(stopCh, statusWG)
 
+	if vsRuntimeSyncInterval := lib.GetVSRuntimeSyncInterval(); vsRuntimeSyncInterval != 0 {
+		vsRuntimeWorker = utils.NewFullSyncThread(time.Duration(vsRuntimeSyncInterval) * time.Second)
+		vsRuntimeWorker.SyncFunction = status.SyncVSRuntimeStatus
+		go vsRuntimeWorker.Run()
+	} else {
+		utils.AviLog.Infof("Virtual service runtime status sync interval set to 0, will not sync the runtime status")
+	}
+
 LABEL:
 	for {
 		select {
@@ -629,6 +638,9 @@ LABEL:
 	if worker != nil {
 		worker.Shutdown()
 	}
+	if vsRuntimeWorker != nil {
+		vsRuntimeWorker.Shutdown()
+	}
 
 	cancel()
 	if !utils.IsWCP() {
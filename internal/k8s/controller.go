@@ -843,7 +843,7 @@ func (c *AviController) SetupEventHandlers(k8sinfo K8sinformers) {
 			var key string
 			if !lib.ValidServiceType(svc) {
 				key := utils.Service + "/" + utils.ObjKey(svc)
-				utils.AviLog.Warnf("key: %s, msg: Invalid service type: [%s] Currently Allowed: [ClusterIP, NodePort, LoadBalancer]", key, string(svc.Spec.Type))
+				utils.AviLog.Warnf("key: %s, msg: Invalid service type: [%s] Currently Allowed: [ClusterIP, NodePort, LoadBalancer, ExternalName]", key, string(svc.Spec.Type))
 				return
 			}
 			if isSvcLb && !lib.GetLayer7Only() {
@@ -905,7 +905,7 @@ func (c *AviController) SetupEventHandlers(k8sinfo K8sinformers) {
 			}
 			if !lib.ValidServiceType(svc) {
 				key := utils.Service + "/" + utils.ObjKey(svc)
-				utils.AviLog.Warnf("key: %s, msg: Invalid service type: [%s] Currently Allowed: [ClusterIP, NodePort, LoadBalancer]", key, string(svc.Spec.Type))
+				utils.AviLog.Warnf("key: %s, msg: Invalid service type: [%s] Currently Allowed: [ClusterIP, NodePort, LoadBalancer, ExternalName]", key, string(svc.Spec.Type))
 				return
 			}
 			isSvcLb := isServiceLBType(svc)
@@ -946,7 +946,7 @@ func (c *AviController) SetupEventHandlers(k8sinfo K8sinformers) {
 			svc := cur.(*corev1.Service)
 			if !lib.ValidServiceType(svc) {
 				key := utils.Service + "/" + utils.ObjKey(svc)
-				utils.AviLog.Warnf("key: %s, msg: Invalid service type: [%s] Currently Allowed: [ClusterIP, NodePort, LoadBalancer]", key, string(svc.Spec.Type))
+				utils.AviLog.Warnf("key: %s, msg: Invalid service type: [%s] Currently Allowed: [ClusterIP, NodePort, LoadBalancer, ExternalName]", key, string(svc.Spec.Type))
 				return
 			}
 			if oldobj.ResourceVersion != svc.ResourceVersion || !reflect.DeepEqual(svc.Annotations, oldobj.Annotations) {
@@ -0,0 +1,649 @@
+/*
+ * Copyright © 2025 Broadcom Inc. and/or its subsidiaries. All Rights Reserved.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	akov1alpha2 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1alpha2"
+	akov1beta1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1beta1"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+const (
+	CRDValidationWebhookName       = "ako-crd-validation-webhook"
+	CRDValidationWebhookPath       = "/validate-ako-vmware-com"
+	CRDValidationWebhookCertSecret = "ako-crd-validation-webhook-cert"
+	CRDValidationWebhookPort       = 9443
+
+	crdValidationWebhookCertValidity    = 365 * 24 * time.Hour
+	crdValidationWebhookCertRenewBefore = 30 * 24 * time.Hour
+	crdValidationWebhookCertCheckPeriod = 24 * time.Hour
+	statefulSetPodNameLabel             = "statefulset.kubernetes.io/pod-name"
+)
+
+var (
+	crdValidationWebhookOnce sync.Once
+	// crdValidationWebhookRegistered is set once this process has created or updated the
+	// ValidatingWebhookConfiguration, which is then removed again on shutdown.
+	crdValidationWebhookRegistered atomic.Bool
+	// crdValidationWebhookServingCert is the certificate served by the webhook, which is swapped
+	// when the certificate is renewed.
+	crdValidationWebhookServingCert atomic.Pointer[tls.Certificate]
+)
+
+// StartCRDValidationWebhook serves the validating admission webhook for the AKO CRDs from the leader.
+// The webhook Service selects the leader pod by name, so that only the leader answers the admission
+// requests. AKO restarts on losing the leadership, hence the server lives as long as the process.
+func StartCRDValidationWebhook(kubeClient kubernetes.Interface) {
+	crdValidationWebhookOnce.Do(func() {
+		go func() {
+			retryInterval := 10 * time.Second
+			for {
+				if err := setupAndStartCRDValidationWebhook(kubeClient); err != nil {
+					utils.AviLog.Warnf("CRD validation webhook: setup failed, will retry in %v: %v", retryInterval, err)
+					time.Sleep(retryInterval)
+					continue
+				}
+				return
+			}
+		}()
+	})
+}
+
+// StopCRDValidationWebhook removes the webhook configuration registered by this process on shutdown.
+// The configuration is cluster scoped and can not be owned by the AKO StatefulSet, hence a fail closed
+// webhook would otherwise keep rejecting the CRD objects after AKO is gone. The next leader registers
+// the webhook again.
+func StopCRDValidationWebhook(kubeClient kubernetes.Interface) {
+	if !crdValidationWebhookRegistered.Load() {
+		return
+	}
+	DeleteCRDValidationWebhookConfiguration(kubeClient)
+}
+
+// DeleteCRDValidationWebhookConfiguration removes the webhook configuration left behind by an
+// earlier run, so that a disabled webhook does not keep intercepting the CRD objects.
+func DeleteCRDValidationWebhookConfiguration(kubeClient kubernetes.Interface) error {
+	err := kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().Delete(context.TODO(), CRDValidationWebhookName, metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		utils.AviLog.Warnf("CRD validation webhook: failed to delete ValidatingWebhookConfiguration %s: %v", CRDValidationWebhookName, err)
+		return err
+	}
+	if err == nil {
+		utils.AviLog.Infof("CRD validation webhook: deleted ValidatingWebhookConfiguration %s", CRDValidationWebhookName)
+	}
+	return nil
+}
+
+func setupAndStartCRDValidationWebhook(kubeClient kubernetes.Interface) error {
+	namespace := utils.GetAKONamespace()
+	ownerRefs, err := getCRDValidationWebhookOwnerReferences(kubeClient, namespace)
+	if err != nil {
+		return fmt.Errorf("failed to get owner references: %v", err)
+	}
+	caPEM, certPEM, keyPEM, err := ensureCRDValidationWebhookCert(kubeClient, namespace, ownerRefs)
+	if err != nil {
+		return fmt.Errorf("failed to ensure certificate: %v", err)
+	}
+	serverCert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return fmt.Errorf("failed to load certificate: %v", err)
+	}
+
+	// Listen before registering the webhook, so that the API server does not see a refused connection.
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", CRDValidationWebhookPort))
+	if err != nil {
+		return fmt.Errorf("failed to listen on port %d: %v", CRDValidationWebhookPort, err)
+	}
+	defer listener.Close()
+
+	if err := ensureCRDValidationWebhookService(kubeClient, namespace, ownerRefs); err != nil {
+		return fmt.Errorf("failed to ensure service: %v", err)
+	}
+	if err := ensureCRDValidationWebhookConfiguration(kubeClient, namespace, caPEM); err != nil {
+		return fmt.Errorf("failed to ensure webhook configuration: %v", err)
+	}
+	crdValidationWebhookRegistered.Store(true)
+	crdValidationWebhookServingCert.Store(&serverCert)
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go renewCRDValidationWebhookCert(kubeClient, namespace, ownerRefs, caPEM, stopCh)
+
+	mux := http.NewServeMux()
+	mux.Handle(CRDValidationWebhookPath, NewCRDValidationWebhook())
+	server := &http.Server{
+		Handler: mux,
+		TLSConfig: &tls.Config{
+			GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+				return crdValidationWebhookServingCert.Load(), nil
+			},
+			MinVersion: tls.VersionTLS12,
+		},
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 30 * time.Second,
+	}
+	utils.AviLog.Infof("CRD validation webhook: starting server on port %d", CRDValidationWebhookPort)
+	if err := server.ServeTLS(listener, "", ""); err != nil && err != http.ErrServerClosed {
+		return fmt.Errorf("server failed: %v", err)
+	}
+	return nil
+}
+
+// CRDValidationWebhook handles admission requests for the AKO CRDs by running the same checks
+// as the leader does on ingestion, without updating the status of the objects.
+type CRDValidationWebhook struct {
+	validator Validator
+}
+
+func NewCRDValidationWebhook() *CRDValidationWebhook {
+	return &CRDValidationWebhook{
+		validator: NewAdmissionValidator(),
+	}
+}
+
+func (w *CRDValidationWebhook) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		utils.AviLog.Errorf("CRD validation webhook: failed to read request body: %v", err)
+		http.Error(rw, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	var admissionReview admissionv1.AdmissionReview
+	if err := json.Unmarshal(body, &admissionReview); err != nil {
+		utils.AviLog.Errorf("CRD validation webhook: failed to unmarshal admission review: %v", err)
+		http.Error(rw, "failed to unmarshal admission review", http.StatusBadRequest)
+		return
+	}
+
+	if admissionReview.Request == nil {
+		utils.AviLog.Errorf("CRD validation webhook: admission review request is nil")
+		http.Error(rw, "admission review request is nil", http.StatusBadRequest)
+		return
+	}
+
+	responseAdmissionReview := &admissionv1.AdmissionReview{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "admission.k8s.io/v1",
+			Kind:       "AdmissionReview",
+		},
+		Response: w.ProcessAdmissionRequest(admissionReview.Request),
+	}
+	responseAdmissionReview.Response.UID = admissionReview.Request.UID
+
+	responseBytes, err := json.Marshal(responseAdmissionReview)
+	if err != nil {
+		utils.AviLog.Errorf("CRD validation webhook: failed to marshal admission response: %v", err)
+		http.Error(rw, "failed to marshal admission response", http.StatusInternalServerError)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusOK)
+	rw.Write(responseBytes)
+}
+
+func (w *CRDValidationWebhook) ProcessAdmissionRequest(req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return &admissionv1.AdmissionResponse{Allowed: true}
+	}
+	if err := w.validate(req); err != nil {
+		utils.AviLog.Infof("CRD validation webhook: denied %s %s %s/%s: %v", req.Operation, req.Kind.Kind, req.Namespace, req.Name, err)
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status:  metav1.StatusFailure,
+				Reason:  metav1.StatusReasonInvalid,
+				Code:    http.StatusUnprocessableEntity,
+				Message: err.Error(),
+			},
+		}
+	}
+	return &admissionv1.AdmissionResponse{Allowed: true}
+}
+
+// validate runs the validator of the kind in the request. Updates which leave the spec untouched,
+// like the metadata updates, are always admitted.
+func (w *CRDValidationWebhook) validate(req *admissionv1.AdmissionRequest) error {
+	switch req.Kind.Kind {
+	case lib.HostRule:
+		obj, oldObj := &akov1beta1.HostRule{}, &akov1beta1.HostRule{}
+		if err := decodeAdmissionObjects(req, obj, oldObj); err != nil {
+			return err
+		}
+		if req.Operation == admissionv1.Update && reflect.DeepEqual(obj.Spec, oldObj.Spec) {
+			return nil
+		}
+		return w.validator.ValidateHostRuleObj(lib.HostRule+"/"+utils.ObjKey(obj), obj)
+	case lib.HTTPRule:
+		obj, oldObj := &akov1beta1.HTTPRule{}, &akov1beta1.HTTPRule{}
+		if err := decodeAdmissionObjects(req, obj, oldObj); err != nil {
+			return err
+		}
+		if req.Operation == admissionv1.Update && reflect.DeepEqual(obj.Spec, oldObj.Spec) {
+			return nil
+		}
+		return w.validator.ValidateHTTPRuleObj(lib.HTTPRule+"/"+utils.ObjKey(obj), obj)
+	case lib.AviInfraSetting:
+		obj, oldObj := &akov1beta1.AviInfraSetting{}, &akov1beta1.AviInfraSetting{}
+		if err := decodeAdmissionObjects(req, obj, oldObj); err != nil {
+			return err
+		}
+		if req.Operation == admissionv1.Update && reflect.DeepEqual(obj.Spec, oldObj.Spec) {
+			return nil
+		}
+		return w.validator.ValidateAviInfraSetting(lib.AviInfraSetting+"/"+utils.ObjKey(obj), obj)
+	case lib.SSORule:
+		obj, oldObj := &akov1alpha2.SSORule{}, &akov1alpha2.SSORule{}
+		if err := decodeAdmissionObjects(req, obj, oldObj); err != nil {
+			return err
+		}
+		if req.Operation == admissionv1.Update && reflect.DeepEqual(obj.Spec, oldObj.Spec) {
+			return nil
+		}
+		return w.validator.ValidateSSORuleObj(lib.SSORule+"/"+utils.ObjKey(obj), obj)
+	case lib.L4Rule:
+		obj, oldObj := &akov1alpha2.L4Rule{}, &akov1alpha2.L4Rule{}
+		if err := decodeAdmissionObjects(req, obj, oldObj); err != nil {
+			return err
+		}
+		if req.Operation == admissionv1.Update && reflect.DeepEqual(obj.Spec, oldObj.Spec) {
+			return nil
+		}
+		return w.validator.ValidateL4RuleObj(lib.L4Rule+"/"+utils.ObjKey(obj), obj)
+	case lib.L7Rule:
+		obj, oldObj := &akov1alpha2.L7Rule{}, &akov1alpha2.L7Rule{}
+		if err := decodeAdmissionObjects(req, obj, oldObj); err != nil {
+			return err
+		}
+		if req.Operation == admissionv1.Update && reflect.DeepEqual(obj.Spec, oldObj.Spec) {
+			return nil
+		}
+		return w.validator.ValidateL7RuleObj(lib.L7Rule+"/"+utils.ObjKey(obj), obj)
+	}
+	return nil
+}
+
+func decodeAdmissionObjects(req *admissionv1.AdmissionRequest, obj, oldObj interface{}) error {
+	if err := json.Unmarshal(req.Object.Raw, obj); err != nil {
+		return fmt.Errorf("failed to decode %s object: %v", req.Kind.Kind, err)
+	}
+	if req.Operation == admissionv1.Update {
+		if err := json.Unmarshal(req.OldObject.Raw, oldObj); err != nil {
+			return fmt.Errorf("failed to decode old %s object: %v", req.Kind.Kind, err)
+		}
+	}
+	return nil
+}
+
+// renewCRDValidationWebhookCert checks the serving certificate once a day, and swaps in the new certificate
+// once ensureCRDValidationWebhookCert regenerates it. The caBundle of the webhook carries both the new and
+// the old CA while the certificate is swapped, so that the API server trusts the webhook throughout.
+func renewCRDValidationWebhookCert(kubeClient kubernetes.Interface, namespace string, ownerRefs []metav1.OwnerReference, caPEM []byte, stopCh <-chan struct{}) {
+	ticker := time.NewTicker(crdValidationWebhookCertCheckPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+		}
+		newCAPEM, certPEM, keyPEM, err := ensureCRDValidationWebhookCert(kubeClient, namespace, ownerRefs)
+		if err != nil {
+			utils.AviLog.Warnf("CRD validation webhook: failed to renew certificate: %v", err)
+			continue
+		}
+		if bytes.Equal(newCAPEM, caPEM) {
+			continue
+		}
+		serverCert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			utils.AviLog.Warnf("CRD validation webhook: failed to load renewed certificate: %v", err)
+			continue
+		}
+		caBundle := append(append([]byte{}, newCAPEM...), caPEM...)
+		if err := ensureCRDValidationWebhookConfiguration(kubeClient, namespace, caBundle); err != nil {
+			utils.AviLog.Warnf("CRD validation webhook: failed to update the caBundle with the renewed CA: %v", err)
+			continue
+		}
+		crdValidationWebhookServingCert.Store(&serverCert)
+		caPEM = newCAPEM
+		utils.AviLog.Infof("CRD validation webhook: serving the renewed certificate")
+	}
+}
+
+// ensureCRDValidationWebhookCert returns the CA, the serving certificate and key of the webhook.
+// The certificates are self signed, kept in a Secret in the AKO namespace, and regenerated
+// when they are about to expire, which is checked at startup and then daily by
+// renewCRDValidationWebhookCert. The Secret is owned by the AKO StatefulSet.
+func ensureCRDValidationWebhookCert(kubeClient kubernetes.Interface, namespace string, ownerRefs []metav1.OwnerReference) ([]byte, []byte, []byte, error) {
+	secret, err := kubeClient.CoreV1().Secrets(namespace).Get(context.TODO(), CRDValidationWebhookCertSecret, metav1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, nil, nil, err
+	}
+	if err == nil && isCRDValidationWebhookCertValid(secret.Data) {
+		if !reflect.DeepEqual(secret.OwnerReferences, ownerRefs) {
+			secret.OwnerReferences = ownerRefs
+			if _, err = kubeClient.CoreV1().Secrets(namespace).Update(context.TODO(), secret, metav1.UpdateOptions{}); err != nil {
+				return nil, nil, nil, err
+			}
+		}
+		return secret.Data["ca.crt"], secret.Data[utils.K8S_TLS_SECRET_CERT], secret.Data[utils.K8S_TLS_SECRET_KEY], nil
+	}
+
+	caPEM, certPEM, keyPEM, genErr := newCRDValidationWebhookCert(namespace)
+	if genErr != nil {
+		return nil, nil, nil, genErr
+	}
+	data := map[string][]byte{
+		"ca.crt":                  caPEM,
+		utils.K8S_TLS_SECRET_CERT: certPEM,
+		utils.K8S_TLS_SECRET_KEY:  keyPEM,
+	}
+	if k8serrors.IsNotFound(err) {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            CRDValidationWebhookCertSecret,
+				Namespace:       namespace,
+				OwnerReferences: ownerRefs,
+			},
+			Type: corev1.SecretTypeTLS,
+			Data: data,
+		}
+		_, err = kubeClient.CoreV1().Secrets(namespace).Create(context.TODO(), secret, metav1.CreateOptions{})
+	} else {
+		secret.Data = data
+		secret.OwnerReferences = ownerRefs
+		_, err = kubeClient.CoreV1().Secrets(namespace).Update(context.TODO(), secret, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	utils.AviLog.Infof("CRD validation webhook: generated the serving certificate in secret %s/%s", namespace, CRDValidationWebhookCertSecret)
+	return caPEM, certPEM, keyPEM, nil
+}
+
+func isCRDValidationWebhookCertValid(data map[string][]byte) bool {
+	if len(data["ca.crt"]) == 0 || len(data[utils.K8S_TLS_SECRET_KEY]) == 0 {
+		return false
+	}
+	block, _ := pem.Decode(data[utils.K8S_TLS_SECRET_CERT])
+	if block == nil {
+		return false
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return false
+	}
+	return time.Now().Add(crdValidationWebhookCertRenewBefore).Before(cert.NotAfter)
+}
+
+func newCRDValidationWebhookCert(namespace string) ([]byte, []byte, []byte, error) {
+	notBefore := time.Now().Add(-time.Hour)
+	notAfter := notBefore.Add(crdValidationWebhookCertValidity)
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	caSerial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          caSerial,
+		Subject:               pkix.Name{CommonName: CRDValidationWebhookName + "-ca"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	serviceHost := fmt.Sprintf("%s.%s.svc", CRDValidationWebhookName, namespace)
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: serviceHost},
+		DNSNames:     []string{serviceHost, serviceHost + ".cluster.local"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return caPEM, certPEM, keyPEM, nil
+}
+
+// getCRDValidationWebhookOwnerReferences returns the owner reference to the StatefulSet of the
+// current pod, so that the webhook Service and Secret are garbage collected with AKO.
+func getCRDValidationWebhookOwnerReferences(kubeClient kubernetes.Interface, namespace string) ([]metav1.OwnerReference, error) {
+	podName := os.Getenv("POD_NAME")
+	if podName == "" {
+		return nil, fmt.Errorf("POD_NAME is not set")
+	}
+	pod, err := kubeClient.CoreV1().Pods(namespace).Get(context.TODO(), podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	controllerRef := metav1.GetControllerOf(pod)
+	if controllerRef == nil {
+		utils.AviLog.Warnf("CRD validation webhook: pod %s/%s has no controller, the webhook Service and Secret will not be garbage collected", namespace, podName)
+		return nil, nil
+	}
+	return []metav1.OwnerReference{{
+		APIVersion: controllerRef.APIVersion,
+		Kind:       controllerRef.Kind,
+		Name:       controllerRef.Name,
+		UID:        controllerRef.UID,
+	}}, nil
+}
+
+// ensureCRDValidationWebhookService points the webhook Service to the current pod, which is the leader.
+// The Service is owned by the AKO StatefulSet.
+func ensureCRDValidationWebhookService(kubeClient kubernetes.Interface, namespace string, ownerRefs []metav1.OwnerReference) error {
+	podName := os.Getenv("POD_NAME")
+	if podName == "" {
+		return fmt.Errorf("POD_NAME is not set")
+	}
+	selector := map[string]string{statefulSetPodNameLabel: podName}
+	ports := []corev1.ServicePort{{
+		Name:       "webhook",
+		Protocol:   corev1.ProtocolTCP,
+		Port:       443,
+		TargetPort: intstr.FromInt32(CRDValidationWebhookPort),
+	}}
+
+	svc, err := kubeClient.CoreV1().Services(namespace).Get(context.TODO(), CRDValidationWebhookName, metav1.GetOptions{})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+		svc = &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            CRDValidationWebhookName,
+				Namespace:       namespace,
+				OwnerReferences: ownerRefs,
+			},
+			Spec: corev1.ServiceSpec{
+				Selector: selector,
+				Ports:    ports,
+			},
+		}
+		_, err = kubeClient.CoreV1().Services(namespace).Create(context.TODO(), svc, metav1.CreateOptions{})
+		return err
+	}
+	if reflect.DeepEqual(svc.Spec.Selector, selector) && len(svc.Spec.Ports) == 1 &&
+		svc.Spec.Ports[0].Port == ports[0].Port && svc.Spec.Ports[0].TargetPort == ports[0].TargetPort &&
+		reflect.DeepEqual(svc.OwnerReferences, ownerRefs) {
+		return nil
+	}
+	svc.OwnerReferences = ownerRefs
+	svc.Spec.Selector = selector
+	svc.Spec.Ports = ports
+	_, err = kubeClient.CoreV1().Services(namespace).Update(context.TODO(), svc, metav1.UpdateOptions{})
+	return err
+}
+
+func ensureCRDValidationWebhookConfiguration(kubeClient kubernetes.Interface, namespace string, caPEM []byte) error {
+	webhook := BuildCRDValidationWebhook(namespace, caPEM)
+	webhookConfig, err := kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().Get(context.TODO(), CRDValidationWebhookName, metav1.GetOptions{})
+	if err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+		webhookConfig = &admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: CRDValidationWebhookName},
+			Webhooks:   []admissionregistrationv1.ValidatingWebhook{webhook},
+		}
+		if _, err = kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().Create(context.TODO(), webhookConfig, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+		utils.AviLog.Infof("CRD validation webhook: created ValidatingWebhookConfiguration %s", CRDValidationWebhookName)
+		return nil
+	}
+	webhookConfig.Webhooks = []admissionregistrationv1.ValidatingWebhook{webhook}
+	if _, err = kubeClient.AdmissionregistrationV1().ValidatingWebhookConfigurations().Update(context.TODO(), webhookConfig, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	utils.AviLog.Infof("CRD validation webhook: updated ValidatingWebhookConfiguration %s", CRDValidationWebhookName)
+	return nil
+}
+
+// BuildCRDValidationWebhook builds the webhook for the CRDs enabled in AKO. The failure policy
+// follows the fail open setting.
+func BuildCRDValidationWebhook(namespace string, caPEM []byte) admissionregistrationv1.ValidatingWebhook {
+	webhookPath := CRDValidationWebhookPath
+	failurePolicy := admissionregistrationv1.Fail
+	if lib.IsCRDValidationWebhookFailOpen() {
+		failurePolicy = admissionregistrationv1.Ignore
+	}
+	matchPolicy := admissionregistrationv1.Equivalent
+	sideEffects := admissionregistrationv1.SideEffectClassNone
+	timeoutSeconds := int32(10)
+
+	resources := map[string][]string{}
+	if lib.AKOControlConfig().HostRuleEnabled() {
+		resources["v1beta1"] = append(resources["v1beta1"], "hostrules")
+	}
+	if lib.AKOControlConfig().HttpRuleEnabled() {
+		resources["v1beta1"] = append(resources["v1beta1"], "httprules")
+	}
+	if lib.AKOControlConfig().AviInfraSettingEnabled() {
+		resources["v1beta1"] = append(resources["v1beta1"], "aviinfrasettings")
+	}
+	if lib.AKOControlConfig().SsoRuleEnabled() {
+		resources["v1alpha2"] = append(resources["v1alpha2"], "ssorules")
+	}
+	if lib.AKOControlConfig().L4RuleEnabled() {
+		resources["v1alpha2"] = append(resources["v1alpha2"], "l4rules")
+	}
+	if lib.AKOControlConfig().L7RuleEnabled() {
+		resources["v1alpha2"] = append(resources["v1alpha2"], "l7rules")
+	}
+	var rules []admissionregistrationv1.RuleWithOperations
+	for _, version := range []string{"v1beta1", "v1alpha2"} {
+		if len(resources[version]) == 0 {
+			continue
+		}
+		rules = append(rules, admissionregistrationv1.RuleWithOperations{
+			Operations: []admissionregistrationv1.OperationType{
+				admissionregistrationv1.Create,
+				admissionregistrationv1.Update,
+			},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{"ako.vmware.com"},
+				APIVersions: []string{version},
+				Resources:   resources[version],
+			},
+		})
+	}
+
+	return admissionregistrationv1.ValidatingWebhook{
+		Name: "crd-validation.ako.vmware.com",
+		ClientConfig: admissionregistrationv1.WebhookClientConfig{
+			Service: &admissionregistrationv1.ServiceReference{
+				Name:      CRDValidationWebhookName,
+				Namespace: namespace,
+				Path:      &webhookPath,
+			},
+			CABundle: caPEM,
+		},
+		Rules:                   rules,
+		FailurePolicy:           &failurePolicy,
+		MatchPolicy:             &matchPolicy,
+		SideEffects:             &sideEffects,
+		TimeoutSeconds:          &timeoutSeconds,
+		AdmissionReviewVersions: []string{"v1"},
+	}
+}
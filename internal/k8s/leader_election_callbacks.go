@@ -60,6 +60,7 @@ func (c *AviController) OnStartedLeadingDuringBootup() {
 	// once the l3 cache is populated, we can call the updatestatus functions from here
 	restlayer := rest.NewRestOperations(avicache.SharedAviObjCache())
 	restlayer.SyncObjectStatuses()
+	c.syncCRDValidationWebhook()
 }
 
 func (c *AviController) OnStartedLeadingAfterFailover() {
@@ -68,6 +69,20 @@ func (c *AviController) OnStartedLeadingAfterFailover() {
 	// once the l3 cache is populated, we can call the updatestatus functions from here
 	restlayer := rest.NewRestOperations(avicache.SharedAviObjCache())
 	restlayer.SyncObjectStatuses()
+	c.syncCRDValidationWebhook()
+}
+
+// syncCRDValidationWebhook starts serving the CRD validation webhook from the leader, or removes
+// the webhook configuration if the webhook has been disabled.
+func (c *AviController) syncCRDValidationWebhook() {
+	if !lib.AKOControlConfig().GetAKOInstanceFlag() {
+		return
+	}
+	if lib.IsCRDValidationWebhookEnabled() {
+		StartCRDValidationWebhook(c.informers.ClientSet)
+		return
+	}
+	DeleteCRDValidationWebhookConfiguration(c.informers.ClientSet)
 }
 
 func (c *AviController) OnNewLeaderDuringBootup() {
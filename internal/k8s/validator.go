@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"reflect"
 	"regexp"
 	"strings"
 
@@ -28,6 +29,7 @@ import (
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/status"
 	akov1alpha1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1alpha1"
@@ -49,7 +51,11 @@ type Validator interface {
 
 type (
 	follower struct{}
-	leader   struct{}
+	leader   struct {
+		// dryRun skips the CRD status updates and the cache updates, so that
+		// objects can be validated before they are persisted.
+		dryRun bool
+	}
 )
 
 func NewValidator() Validator {
@@ -59,6 +65,12 @@ func NewValidator() Validator {
 	return &follower{}
 }
 
+// NewAdmissionValidator returns a Validator that runs the leader checks without
+// any side effects, to be used by the CRD validation webhook.
+func NewAdmissionValidator() Validator {
+	return &leader{dryRun: true}
+}
+
 // validateHostRuleObj would do validation checks
 // update internal CRD caches, and push relevant ingresses to ingestion
 func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule) error {
@@ -68,7 +80,7 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule)
 	foundHost, foundHR := objects.SharedCRDLister().GetFQDNToHostruleMapping(fqdn)
 	if foundHost && foundHR != hostrule.Namespace+"/"+hostrule.Name {
 		err = fmt.Errorf("duplicate fqdn %s found in %s", fqdn, foundHR)
-		status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+		l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 		return err
 	}
 
@@ -85,7 +97,7 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule)
 		re := regexp.MustCompile(lib.IPRegex)
 		if !re.MatchString(hostrule.Spec.VirtualHost.TCPSettings.LoadBalancerIP) {
 			err = fmt.Errorf("loadBalancerIP %s is not a valid IP", hostrule.Spec.VirtualHost.TCPSettings.LoadBalancerIP)
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+			l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 	}
@@ -93,7 +105,7 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule)
 	if hostrule.Spec.VirtualHost.Gslb.Fqdn != "" {
 		if fqdn == hostrule.Spec.VirtualHost.Gslb.Fqdn {
 			err = fmt.Errorf("GSLB FQDN and local FQDN are same")
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+			l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 	}
@@ -108,7 +120,7 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule)
 		}
 		if !sslEnabled {
 			err = fmt.Errorf("Hosting parent virtualservice must have SSL enabled")
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+			l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 	}
@@ -116,26 +128,26 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule)
 	if hostrule.Spec.VirtualHost.Aliases != nil {
 		if hostrule.Spec.VirtualHost.FqdnType != akov1beta1.Exact {
 			err = fmt.Errorf("Aliases is supported only when FQDN type is set as Exact")
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+			l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 
 		if utils.HasElem(hostrule.Spec.VirtualHost.Aliases, fqdn) {
 			err = fmt.Errorf("Duplicate entry found. Aliases field has same entry as the FQDN field")
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+			l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 
 		if utils.ContainsDuplicate(hostrule.Spec.VirtualHost.Aliases) {
 			err = fmt.Errorf("Aliases must be unique")
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+			l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 
 		if hostrule.Spec.VirtualHost.Gslb.Fqdn != "" &&
 			utils.HasElem(hostrule.Spec.VirtualHost.Aliases, hostrule.Spec.VirtualHost.Gslb.Fqdn) {
 			err = fmt.Errorf("Aliases must not contain GSLB FQDN")
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+			l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 
@@ -147,7 +159,7 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule)
 			for _, alias := range hostrule.Spec.VirtualHost.Aliases {
 				if utils.HasElem(aliases, alias) {
 					err = fmt.Errorf("%s is already in use by hostrule %s", alias, cachedFQDN)
-					status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+					l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 					return err
 				}
 			}
@@ -169,7 +181,7 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule)
 		secretName := hostrule.Spec.VirtualHost.TLS.SSLKeyCertificate.Name
 		err := validateSecretReferenceInHostrule(hostrule.Namespace, secretName)
 		if err != nil {
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+			l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 	}
@@ -181,12 +193,12 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule)
 		secretName := hostrule.Spec.VirtualHost.TLS.SSLKeyCertificate.AlternateCertificate.Name
 		err := validateSecretReferenceInHostrule(hostrule.Namespace, secretName)
 		if err != nil {
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+			l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 	}
 	if len(hostrule.Spec.VirtualHost.ICAPProfile) > 1 {
-		status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: "Can only have 1 ICAP profile associated with VS"})
+		l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: "Can only have 1 ICAP profile associated with VS"})
 		return fmt.Errorf("Can only have 1 ICAP profile associated with VS")
 	} else {
 		for _, icapprofile := range hostrule.Spec.VirtualHost.ICAPProfile {
@@ -210,28 +222,30 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule)
 	tenant := lib.GetTenantInNamespace(hostrule.Namespace)
 
 	if err := checkRefsOnController(key, refData, tenant); err != nil {
-		status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+		l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 		return err
 	}
 
 	if hostrule.Spec.VirtualHost.L7Rule != "" {
-		objects.SharedCRDLister().UpdateL7RuleToHostRuleMapping(hostrule.Namespace+"/"+hostrule.Spec.VirtualHost.L7Rule, hostrule.Name)
+		if !l.dryRun {
+			objects.SharedCRDLister().UpdateL7RuleToHostRuleMapping(hostrule.Namespace+"/"+hostrule.Spec.VirtualHost.L7Rule, hostrule.Name)
+		}
 		_, err := lib.AKOControlConfig().CRDInformers().L7RuleInformer.Lister().L7Rules(hostrule.Namespace).Get(hostrule.Spec.VirtualHost.L7Rule)
 		if err != nil {
-			status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+			l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 			return err
 		}
 	}
 
 	if strings.Contains(fqdn, lib.ShardVSSubstring) && hostrule.Spec.VirtualHost.UseRegex {
 		err = fmt.Errorf("hostrule useRegex with fqdn %s cannot be applied to shared virtualservices", fqdn)
-		status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+		l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 		return err
 	}
 
 	if strings.Contains(fqdn, lib.ShardVSSubstring) && hostrule.Spec.VirtualHost.ApplicationRootPath != "" {
 		err = fmt.Errorf("hostrule applicationRootPath with fqdn %s cannot be applied to shared virtualservices", fqdn)
-		status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+		l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 		return err
 	}
 
@@ -240,7 +254,7 @@ func (l *leader) ValidateHostRuleObj(key string, hostrule *akov1beta1.HostRule)
 		return nil
 	}
 
-	status.UpdateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusAccepted, Error: ""})
+	l.updateHostRuleStatus(key, hostrule, status.UpdateCRDStatusOptions{Status: lib.StatusAccepted, Error: ""})
 	return nil
 }
 
@@ -282,12 +296,47 @@ func (l *leader) ValidateHTTPRuleObj(key string, httprule *akov1beta1.HTTPRule)
 	for _, path := range httprule.Spec.Paths {
 		if path.TLS.PKIProfile != "" && path.TLS.DestinationCA != "" {
 			//if both pkiProfile and destCA set, reject httprule
-			status.UpdateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
+			l.updateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
 				Status: lib.StatusRejected,
 				Error:  lib.HttpRulePkiAndDestCASetErr,
 			})
 			return fmt.Errorf("key: %s, msg: %s", key, lib.HttpRulePkiAndDestCASetErr)
 		}
+		if path.CORS != nil {
+			if err := nodes.NewCORSPolicyFromHTTPRule(path.CORS).Validate(); err != nil {
+				l.updateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
+					Status: lib.StatusRejected,
+					Error:  err.Error(),
+				})
+				return err
+			}
+		}
+		if path.Canary != nil {
+			if err := nodes.ValidateHTTPRuleCanary(path.Canary); err != nil {
+				l.updateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
+					Status: lib.StatusRejected,
+					Error:  err.Error(),
+				})
+				return err
+			}
+		}
+		if path.RateLimit != nil {
+			if err := nodes.ValidateHTTPRuleRateLimit(path.RateLimit); err != nil {
+				l.updateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
+					Status: lib.StatusRejected,
+					Error:  err.Error(),
+				})
+				return err
+			}
+			if nodes.IsHTTPRuleRateLimitHostShared(key, httprule.Spec.Fqdn) {
+				err := fmt.Errorf("rate limit is not supported for the insecure host %s of the shared virtualservices", httprule.Spec.Fqdn)
+				l.updateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
+					Status: lib.StatusRejected,
+					Error:  err.Error(),
+				})
+				return err
+			}
+		}
 		refData[path.TLS.SSLProfile] = "SslProfile"
 		refData[path.ApplicationPersistence] = "ApplicationPersistence"
 		if path.TLS.PKIProfile != "" {
@@ -301,19 +350,21 @@ func (l *leader) ValidateHTTPRuleObj(key string, httprule *akov1beta1.HTTPRule)
 	tenant := lib.GetTenantInNamespace(httprule.Namespace)
 
 	if err := checkRefsOnController(key, refData, tenant); err != nil {
-		status.UpdateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
+		l.updateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
 			Status: lib.StatusRejected,
 			Error:  err.Error(),
 		})
 		return err
 	}
 
-	// No need to update status of httprule object as accepted since it was accepted before.
-	if httprule.Status.Status == lib.StatusAccepted {
+	// No need to update status of httprule object as accepted since it was accepted before,
+	// unless the traffic split of the canaries has changed.
+	if httprule.Status.Status == lib.StatusAccepted &&
+		reflect.DeepEqual(httprule.Status.Canary, status.BuildHTTPRuleCanaryStatus(httprule)) {
 		return nil
 	}
 
-	status.UpdateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
+	l.updateHTTPRuleStatus(key, httprule, status.UpdateCRDStatusOptions{
 		Status: lib.StatusAccepted,
 		Error:  "",
 	})
@@ -327,7 +378,7 @@ func (l *leader) ValidateAviInfraSetting(key string, infraSetting *akov1beta1.Av
 	if ((infraSetting.Spec.Network.EnableRhi != nil && !*infraSetting.Spec.Network.EnableRhi) || infraSetting.Spec.Network.EnableRhi == nil) &&
 		len(infraSetting.Spec.Network.BgpPeerLabels) > 0 {
 		err := fmt.Errorf("BGPPeerLabels cannot be set if EnableRhi is false.")
-		status.UpdateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
+		l.updateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
 			Status: lib.StatusRejected,
 			Error:  err.Error(),
 		})
@@ -340,7 +391,7 @@ func (l *leader) ValidateAviInfraSetting(key string, infraSetting *akov1beta1.Av
 			re := regexp.MustCompile(lib.IPCIDRRegex)
 			if !re.MatchString(vipNetwork.Cidr) {
 				err := fmt.Errorf("invalid CIDR configuration %s detected for networkName %s in vipNetworkList", vipNetwork.Cidr, vipNetwork.NetworkName)
-				status.UpdateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
+				l.updateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
 					Status: lib.StatusRejected,
 					Error:  err.Error(),
 				})
@@ -351,7 +402,7 @@ func (l *leader) ValidateAviInfraSetting(key string, infraSetting *akov1beta1.Av
 			re := regexp.MustCompile(lib.IPV6CIDRRegex)
 			if !re.MatchString(vipNetwork.V6Cidr) {
 				err := fmt.Errorf("invalid IPv6 CIDR configuration %s detected for networkName %s in vipNetworkList", vipNetwork.V6Cidr, vipNetwork.NetworkName)
-				status.UpdateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
+				l.updateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
 					Status: lib.StatusRejected,
 					Error:  err.Error(),
 				})
@@ -386,7 +437,7 @@ func (l *leader) ValidateAviInfraSetting(key string, infraSetting *akov1beta1.Av
 			projectArr := strings.Split(vpcArr[0], "/projects/")
 			tenant, err = lib.GetTenantForProject(projectArr[len(projectArr)-1], aviClientPool.AviClient[0])
 			if err != nil {
-				status.UpdateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
+				l.updateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
 					Status: lib.StatusRejected,
 					Error:  err.Error(),
 				})
@@ -405,7 +456,7 @@ func (l *leader) ValidateAviInfraSetting(key string, infraSetting *akov1beta1.Av
 		}
 		if !sslEnabled {
 			err := fmt.Errorf("One of the port in aviInfraSetting must have SSL enabled")
-			status.UpdateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
+			l.updateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
 				Status: lib.StatusRejected,
 				Error:  err.Error(),
 			})
@@ -413,13 +464,17 @@ func (l *leader) ValidateAviInfraSetting(key string, infraSetting *akov1beta1.Av
 		}
 	}
 	if err := checkRefsOnController(key, refData, tenant); err != nil {
-		status.UpdateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
+		l.updateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
 			Status: lib.StatusRejected,
 			Error:  err.Error(),
 		})
 		return err
 	}
 
+	if l.dryRun {
+		return nil
+	}
+
 	// This would add SEG labels only if they are not configured yet. In case there is a label mismatch
 	// to any pre-existing SEG labels, the AviInfraSettig CR will get Rejected from the checkRefsOnController
 	// step before this.
@@ -453,7 +508,7 @@ func (l *leader) ValidateAviInfraSetting(key string, infraSetting *akov1beta1.Av
 		return nil
 	}
 
-	status.UpdateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
+	l.updateAviInfraSettingStatus(key, infraSetting, status.UpdateCRDStatusOptions{
 		Status: lib.StatusAccepted,
 		Error:  "",
 	})
@@ -513,7 +568,7 @@ func (l *leader) ValidateSSORuleObj(key string, ssoRule *akov1alpha2.SSORule) er
 	foundHost, foundSR := objects.SharedCRDLister().GetFQDNToSSORuleMapping(fqdn)
 	if foundHost && foundSR != ssoRule.Namespace+"/"+ssoRule.Name {
 		err = fmt.Errorf("duplicate fqdn %s found in %s", fqdn, foundSR)
-		status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+		l.updateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 		return err
 	}
 
@@ -521,7 +576,7 @@ func (l *leader) ValidateSSORuleObj(key string, ssoRule *akov1alpha2.SSORule) er
 
 	if ssoRule.Spec.SsoPolicyRef == nil {
 		err = fmt.Errorf("SsoPolicyRef is not specified")
-		status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+		l.updateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 		return err
 	}
 	refData[*ssoRule.Spec.SsoPolicyRef] = "SSOPolicy"
@@ -538,18 +593,18 @@ func (l *leader) ValidateSSORuleObj(key string, ssoRule *akov1alpha2.SSORule) er
 					clientSecretObj, err := validateSecretReferenceInSSORule(ssoRule.Namespace, clientSecret)
 					if err != nil {
 						err = fmt.Errorf("Got error while fetching %s secret : %s", clientSecret, err.Error())
-						status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+						l.updateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 						return err
 					}
 					if clientSecretObj == nil {
 						err = fmt.Errorf("specified client secret is empty : %s", clientSecret)
-						status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+						l.updateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 						return err
 					}
 					clientSecretString := string(clientSecretObj.Data["clientSecret"])
 					if clientSecretString == "" {
 						err = fmt.Errorf("clientSecret field not found in %s secret", clientSecret)
-						status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+						l.updateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 						return err
 					}
 				}
@@ -557,12 +612,12 @@ func (l *leader) ValidateSSORuleObj(key string, ssoRule *akov1alpha2.SSORule) er
 				if profile.ResourceServer != nil {
 					if *profile.ResourceServer.AccessType == lib.ACCESS_TOKEN_TYPE_JWT && profile.ResourceServer.JwtParams == nil {
 						err = fmt.Errorf("Access Type is %s, but Jwt Params have not been specified", *profile.ResourceServer.AccessType)
-						status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+						l.updateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 						return err
 					}
 					if *profile.ResourceServer.AccessType == lib.ACCESS_TOKEN_TYPE_OPAQUE && profile.ResourceServer.OpaqueTokenParams == nil {
 						err = fmt.Errorf("Access Type is %s, but Opaque Token Params have not been specified", *profile.ResourceServer.AccessType)
-						status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+						l.updateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 						return err
 					}
 
@@ -571,18 +626,18 @@ func (l *leader) ValidateSSORuleObj(key string, ssoRule *akov1alpha2.SSORule) er
 						serverSecretObj, err := utils.GetInformers().ClientSet.CoreV1().Secrets(ssoRule.Namespace).Get(context.TODO(), serverSecret, metav1.GetOptions{})
 						if err != nil {
 							err = fmt.Errorf("Got error while fetching %s secret : %s", serverSecret, err.Error())
-							status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+							l.updateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 							return err
 						}
 						if serverSecretObj == nil {
 							err = fmt.Errorf("specified server secret is empty : %s", serverSecret)
-							status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+							l.updateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 							return err
 						}
 						serverSecretString := string(serverSecretObj.Data["serverSecret"])
 						if serverSecretString == "" {
 							err = fmt.Errorf("serverSecret field not found in %s secret", serverSecret)
-							status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+							l.updateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 							return err
 						}
 					}
@@ -600,7 +655,7 @@ func (l *leader) ValidateSSORuleObj(key string, ssoRule *akov1alpha2.SSORule) er
 	tenant := lib.GetTenantInNamespace(ssoRule.Namespace)
 
 	if err := checkRefsOnController(key, refData, tenant); err != nil {
-		status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
+		l.updateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusRejected, Error: err.Error()})
 		return err
 	}
 
@@ -609,7 +664,7 @@ func (l *leader) ValidateSSORuleObj(key string, ssoRule *akov1alpha2.SSORule) er
 		return nil
 	}
 
-	status.UpdateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusAccepted, Error: ""})
+	l.updateSSORuleStatus(key, ssoRule, status.UpdateCRDStatusOptions{Status: lib.StatusAccepted, Error: ""})
 	return nil
 }
 
@@ -622,7 +677,7 @@ func (l *leader) ValidateL4RuleObj(key string, l4Rule *akov1alpha2.L4Rule) error
 	if l4RuleSpec.LoadBalancerIP != nil &&
 		net.ParseIP(*l4RuleSpec.LoadBalancerIP) == nil {
 		err := fmt.Errorf("loadBalancerIP %s is not valid", *l4RuleSpec.LoadBalancerIP)
-		rejectL4Rule(key, l4Rule, err)
+		l.rejectL4Rule(key, l4Rule, err)
 		return err
 	}
 
@@ -643,13 +698,13 @@ func (l *leader) ValidateL4RuleObj(key string, l4Rule *akov1alpha2.L4Rule) error
 		}
 		isL4SSL, err := checkForL4SSLAppProfile(key, *l4RuleSpec.ApplicationProfileRef, tenant)
 		if err != nil {
-			rejectL4Rule(key, l4Rule, err)
+			l.rejectL4Rule(key, l4Rule, err)
 			return err
 		}
 		if isL4SSL {
 			if !isSSLEnabled {
 				sslErr := fmt.Errorf("SSL is not enabled in l4rule listener Spec but App Profile %s is of type SSL", *l4RuleSpec.ApplicationProfileRef)
-				rejectL4Rule(key, l4Rule, sslErr)
+				l.rejectL4Rule(key, l4Rule, sslErr)
 				return sslErr
 			}
 			if l4RuleSpec.SslProfileRef != nil {
@@ -661,7 +716,7 @@ func (l *leader) ValidateL4RuleObj(key string, l4Rule *akov1alpha2.L4Rule) error
 			if l4RuleSpec.NetworkProfileRef != nil {
 				isNetworkProfileTypeTCP, err = checkForNetworkProfileTypeTCP(key, *l4RuleSpec.NetworkProfileRef, tenant)
 				if err != nil {
-					rejectL4Rule(key, l4Rule, err)
+					l.rejectL4Rule(key, l4Rule, err)
 					return err
 				}
 			}
@@ -669,18 +724,18 @@ func (l *leader) ValidateL4RuleObj(key string, l4Rule *akov1alpha2.L4Rule) error
 			if *l4RuleSpec.ApplicationProfileRef != utils.DEFAULT_L4_APP_PROFILE {
 				if isSSLEnabled {
 					sslErr := fmt.Errorf("SSL is enabled in l4rule listener Spec but App Profile %s is not of type SSL", *l4RuleSpec.ApplicationProfileRef)
-					rejectL4Rule(key, l4Rule, sslErr)
+					l.rejectL4Rule(key, l4Rule, sslErr)
 					return sslErr
 				}
 			}
 			if l4RuleSpec.SslProfileRef != nil {
 				sslProfileErr := fmt.Errorf("App Profile %s is not of type SSL but SslProfileRef is set", *l4RuleSpec.ApplicationProfileRef)
-				rejectL4Rule(key, l4Rule, sslProfileErr)
+				l.rejectL4Rule(key, l4Rule, sslProfileErr)
 				return sslProfileErr
 			}
 			if len(l4RuleSpec.SslKeyAndCertificateRefs) != 0 {
 				sslKeyCertErr := fmt.Errorf("App Profile %s is not of type SSL but SslKeyAndCertificateRefs are set", *l4RuleSpec.ApplicationProfileRef)
-				rejectL4Rule(key, l4Rule, sslKeyCertErr)
+				l.rejectL4Rule(key, l4Rule, sslKeyCertErr)
 				return sslKeyCertErr
 			}
 		}
@@ -725,7 +780,7 @@ func (l *leader) ValidateL4RuleObj(key string, l4Rule *akov1alpha2.L4Rule) error
 		}
 
 		if err := validateLBAlgorithm(backendProperties); err != nil {
-			rejectL4Rule(key, l4Rule, err)
+			l.rejectL4Rule(key, l4Rule, err)
 			return err
 		}
 
@@ -733,14 +788,14 @@ func (l *leader) ValidateL4RuleObj(key string, l4Rule *akov1alpha2.L4Rule) error
 		for _, healthMonitorName := range backendProperties.HealthMonitorCrdRefs {
 			if healthMonitorName == "" {
 				err := fmt.Errorf("Empty HealthMonitor name in healthMonitorCrdRefs")
-				rejectL4Rule(key, l4Rule, err)
+				l.rejectL4Rule(key, l4Rule, err)
 				return err
 			}
 
 			// Check if AKO CRD Operator is enabled when HealthMonitor CRDs are referenced
 			if !lib.IsAKOCRDOperatorEnabled() {
 				err := fmt.Errorf("HealthMonitor CRD %s/%s referenced but AKO CRD Operator is not enabled", l4Rule.Namespace, healthMonitorName)
-				rejectL4Rule(key, l4Rule, err)
+				l.rejectL4Rule(key, l4Rule, err)
 				return err
 			}
 
@@ -748,25 +803,27 @@ func (l *leader) ValidateL4RuleObj(key string, l4Rule *akov1alpha2.L4Rule) error
 			// This ensures that even rejected L4Rules can be re-evaluated when HealthMonitors change
 			healthMonitorNsName := l4Rule.Namespace + "/" + healthMonitorName
 			l4RuleNsName := l4Rule.Namespace + "/" + l4Rule.Name
-			objects.SharedCRDLister().UpdateHealthMonitorToL4RuleMapping(healthMonitorNsName, l4RuleNsName)
+			if !l.dryRun {
+				objects.SharedCRDLister().UpdateHealthMonitorToL4RuleMapping(healthMonitorNsName, l4RuleNsName)
+			}
 
 			// Validate HealthMonitor CRD exists, is processed, and type is compatible with backend protocol
 			if err := validateHealthMonitorForL4Rule(key, l4Rule.Namespace, healthMonitorName, *backendProperties.Protocol); err != nil {
-				rejectL4Rule(key, l4Rule, err)
+				l.rejectL4Rule(key, l4Rule, err)
 				return err
 			}
 		}
 	}
 
 	if err := checkRefsOnController(key, refData, tenant); err != nil {
-		rejectL4Rule(key, l4Rule, err)
+		l.rejectL4Rule(key, l4Rule, err)
 		return err
 	}
 
 	revokeVipRoute := l4Rule.Spec.RevokeVipRoute
 	if lib.GetCloudType() != lib.CLOUD_NSXT && revokeVipRoute != nil && *revokeVipRoute {
 		revokeVipRouteErr := fmt.Errorf("RevokeVipRoute is only supported in NSX-T Cloud")
-		rejectL4Rule(key, l4Rule, revokeVipRouteErr)
+		l.rejectL4Rule(key, l4Rule, revokeVipRouteErr)
 		return revokeVipRouteErr
 	}
 
@@ -775,7 +832,7 @@ func (l *leader) ValidateL4RuleObj(key string, l4Rule *akov1alpha2.L4Rule) error
 		return nil
 	}
 
-	status.UpdateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
+	l.updateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
 		Status: lib.StatusAccepted,
 		Error:  "",
 	})
@@ -839,7 +896,7 @@ func (l *leader) ValidateL7RuleObj(key string, l7Rule *akov1alpha2.L7Rule) error
 	tenant := lib.GetTenantInNamespace(l7Rule.Namespace)
 
 	if err := checkRefsOnController(key, refData, tenant); err != nil {
-		status.UpdateL7RuleStatus(key, l7Rule, status.UpdateCRDStatusOptions{
+		l.updateL7RuleStatus(key, l7Rule, status.UpdateCRDStatusOptions{
 			Status: lib.StatusRejected,
 			Error:  err.Error(),
 		})
@@ -849,7 +906,7 @@ func (l *leader) ValidateL7RuleObj(key string, l7Rule *akov1alpha2.L7Rule) error
 	if l7Rule.Status.Status == lib.StatusAccepted {
 		return nil
 	}
-	status.UpdateL7RuleStatus(key, l7Rule, status.UpdateCRDStatusOptions{Status: lib.StatusAccepted, Error: ""})
+	l.updateL7RuleStatus(key, l7Rule, status.UpdateCRDStatusOptions{Status: lib.StatusAccepted, Error: ""})
 	return nil
 }
 
@@ -943,13 +1000,55 @@ func (f *follower) ValidateL7RuleObj(key string, l7Rule *akov1alpha2.L7Rule) err
 }
 
 // rejectL4Rule updates the status of L4Rule CR to "rejected" and adds error message
-func rejectL4Rule(key string, l4Rule *akov1alpha2.L4Rule, err error) {
-	status.UpdateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
+func (l *leader) rejectL4Rule(key string, l4Rule *akov1alpha2.L4Rule, err error) {
+	l.updateL4RuleStatus(key, l4Rule, status.UpdateCRDStatusOptions{
 		Status: lib.StatusRejected,
 		Error:  err.Error(),
 	})
 }
 
+func (l *leader) updateHostRuleStatus(key string, hostrule *akov1beta1.HostRule, updateStatus status.UpdateCRDStatusOptions) {
+	if l.dryRun {
+		return
+	}
+	status.UpdateHostRuleStatus(key, hostrule, updateStatus)
+}
+
+func (l *leader) updateHTTPRuleStatus(key string, httprule *akov1beta1.HTTPRule, updateStatus status.UpdateCRDStatusOptions) {
+	if l.dryRun {
+		return
+	}
+	status.UpdateHTTPRuleStatus(key, httprule, updateStatus)
+}
+
+func (l *leader) updateAviInfraSettingStatus(key string, infraSetting *akov1beta1.AviInfraSetting, updateStatus status.UpdateCRDStatusOptions) {
+	if l.dryRun {
+		return
+	}
+	status.UpdateAviInfraSettingStatus(key, infraSetting, updateStatus)
+}
+
+func (l *leader) updateSSORuleStatus(key string, ssoRule *akov1alpha2.SSORule, updateStatus status.UpdateCRDStatusOptions) {
+	if l.dryRun {
+		return
+	}
+	status.UpdateSSORuleStatus(key, ssoRule, updateStatus)
+}
+
+func (l *leader) updateL4RuleStatus(key string, l4Rule *akov1alpha2.L4Rule, updateStatus status.UpdateCRDStatusOptions) {
+	if l.dryRun {
+		return
+	}
+	status.UpdateL4RuleStatus(key, l4Rule, updateStatus)
+}
+
+func (l *leader) updateL7RuleStatus(key string, l7Rule *akov1alpha2.L7Rule, updateStatus status.UpdateCRDStatusOptions) {
+	if l.dryRun {
+		return
+	}
+	status.UpdateL7RuleStatus(key, l7Rule, updateStatus)
+}
+
 // validateHealthMonitorForL4Rule validates that HealthMonitor exists, is processed by AKO CRD Operator, and type is compatible with backend protocol
 func validateHealthMonitorForL4Rule(key, namespace, healthMonitorName, protocol string) error {
 	// Get HealthMonitor object using dynamic client (single fetch for all validations)
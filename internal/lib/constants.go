@@ -79,6 +79,9 @@ const (
 	AKOPrefix                                  = "ako-"
 	DedicatedSuffix                            = "-L7-dedicated"
 	EVHSuffix                                  = "-EVH"
+	DefaultBackendSuffix                       = "-default-backend"
+	HTTPRuleCanarySuffix                       = "-canary"
+	MaxPoolGroupMemberRatio                    = 1000
 	PassthroughPrefix                          = "Shared-Passthrough-"
 	PolicyAllow                                = "ALLOW"
 	PolicyNone                                 = "NONE"
@@ -117,9 +120,12 @@ const (
 	Gateway                                    = "Gateway"
 	GatewayClass                               = "GatewayClass"
 	HTTPRoute                                  = "HTTPRoute"
+	GRPCRoute                                  = "GRPCRoute"
 	TCPRoute                                   = "TCPRoute"
 	TLSRoute                                   = "TLSRoute"
 	UDPRoute                                   = "UDPRoute"
+	ReferenceGrant                             = "ReferenceGrant"
+	BackendTLSPolicy                           = "BackendTLSPolicy"
 	DuplicateBackends                          = "MultipleBackendsWithSameServiceError"
 	HostAlreadyClaimed                         = "Host already Claimed"
 	DummyVSForStaleData                        = "DummyVSForStaleData"
@@ -138,6 +144,10 @@ const (
 	ClusterNameLabelKey                        = "clustername"
 	UpdateStatus                               = "UpdateStatus"
 	DeleteStatus                               = "DeleteStatus"
+	UpdateRuntimeStatus                        = "UpdateRuntimeStatus"
+	VSOperStateUp                              = "OPER_UP"
+	DefaultVSRuntimeStatusSyncInterval         = 60 // Seconds
+	MinVSRuntimeStatusSyncInterval             = 30 // Seconds
 	NPLService                                 = "NPLService"
 	SyncStatusKey                              = "syncstatus"
 	NoFreeIPError                              = "No available free IPs"
@@ -168,10 +178,14 @@ const (
 	PG                                         = "Poolgroup"
 	ApplicationPersistenceProfile              = "PersistenceProfile"
 	ApplicationPersistenceProfileNode          = "ApplicationPersistenceProfileNode"
+	TrafficCloneProfile                        = "TrafficCloneProfile"
+	TrafficCloneProfileNode                    = "TrafficCloneProfileNode"
 	PriorityLabel                              = "PriorityLabel"
 	SSLKeyCert                                 = "SSLKeyandCertificate"
 	PKIProfile                                 = "PKI Profile"
 	ApplicationProfile                         = "ApplicationProfile"
+	ApplicationProfileNode                     = "ApplicationProfileNode"
+	SSLClientCertificateModeRequire            = "SSL_CLIENT_CERTIFICATE_REQUIRE"
 	HealthMonitor                              = "HealthMonitor"
 	AllowedTCPHealthMonitorType                = "HEALTH_MONITOR_TCP"
 	AllowedUDPHealthMonitorType                = "HEALTH_MONITOR_UDP"
@@ -233,11 +247,15 @@ const (
 	AKOPause                 = "AKOPause"
 	DuplicateHostPath        = "DuplicateHostPath"
 	DuplicateHost            = "DuplicateHost"
+	DuplicateDefaultBackend  = "DuplicateDefaultBackend"
 	Removed                  = "Removed"
 	Synced                   = "Synced"
 	Attached                 = "Attached"
 	Detached                 = "Detached"
 	PatchFailed              = "PatchFailed"
+	VirtualServiceDown       = "VirtualServiceDown"
+	VirtualServiceUp         = "VirtualServiceUp"
+	AppProtocolApplied       = "AppProtocolApplied"
 	InvalidConfiguration     = "InvalidConfiguration"
 	AKODeleteConfigSet       = "AKODeleteConfigSet"
 	AKODeleteConfigUnset     = "AKODeleteConfigUnset"
@@ -300,6 +318,15 @@ const (
 	// License types
 	LicenseTypeEnterprise              = "ENTERPRISE"
 	LicenseTypeEnterpriseCloudServices = "ENTERPRISE_WITH_CLOUD_SERVICES"
+
+	// Service appProtocol values that set the protocol of the pools
+	AppProtocolH2C   = "kubernetes.io/h2c"
+	AppProtocolWS    = "kubernetes.io/ws"
+	AppProtocolWSS   = "kubernetes.io/wss"
+	AppProtocolHTTP  = "http"
+	AppProtocolHTTP2 = "http2"
+	AppProtocolHTTPS = "https"
+	AppProtocolGRPC  = "grpc"
 )
 
 // Cache Indexer constants.
@@ -94,6 +94,8 @@ type ServiceMetadataObj struct {
 	PoolRatio                  uint32              `json:"pool_ratio"`
 	PassthroughParentRef       string              `json:"passthrough_parent_ref"`
 	PassthroughChildRef        string              `json:"passthrough_child_ref"`
+	L4ParentRef                string              `json:"l4_parent_ref"`
+	L4ChildRef                 string              `json:"l4_child_ref"`
 	Gateway                    string              `json:"gateway"`   // ns/name
 	HTTPRoute                  string              `json:"httproute"` // ns/name
 	InsecureEdgeTermAllow      bool                `json:"insecureedgetermallow"`
@@ -538,6 +540,31 @@ func GetHeaderRewritePolicy(vsName, localHost string) string {
 	return headerWriterPolicy
 }
 
+func GetCORSPolicy(vsName string) string {
+	corsPolicy := vsName + "--cors"
+	CheckObjectNameLength(corsPolicy, HTTPPS)
+	return corsPolicy
+}
+
+func GetHTTPRuleCanaryPolicy(vsName string) string {
+	canaryPolicy := vsName + "--canary"
+	CheckObjectNameLength(canaryPolicy, HTTPPS)
+	return canaryPolicy
+}
+
+func GetHTTPRuleRateLimitPolicy(vsName string) string {
+	rateLimitPolicy := vsName + "--ratelimit"
+	CheckObjectNameLength(rateLimitPolicy, HTTPPS)
+	return rateLimitPolicy
+}
+
+// GetHTTPRuleCanaryPoolName returns the name of the pool created for the canary service of an HTTPRule path,
+// the unencoded name follows the EVH pool naming so that the HTTPRule path settings apply to the pool as well.
+func GetHTTPRuleCanaryPoolName(ingName, namespace, host, path, infrasetting, svcName string, dedicatedVS bool) string {
+	poolName := GetEvhPoolNameNoEncoding(ingName, namespace, host, path, infrasetting, svcName, dedicatedVS) + HTTPRuleCanarySuffix
+	return Encode(poolName, Pool)
+}
+
 func GetSniNodeName(infrasetting, sniHostName string) string {
 	namePrefix := NamePrefix
 	if infrasetting != "" {
@@ -614,6 +641,16 @@ func GetSniPGName(ingName, namespace, host, path, infrasetting string, dedicated
 	return sniPGName
 }
 
+// GetL7DefaultBackendPoolName returns the name of the catch-all pool created on a VS for the
+// defaultBackend of an Ingress.
+func GetL7DefaultBackendPoolName(vsName, namespace, ingName string) string {
+	return Encode(vsName+"-"+namespace+"-"+ingName+DefaultBackendSuffix, Pool)
+}
+
+func GetL7DefaultBackendPGName(vsName, namespace, ingName string) string {
+	return Encode(vsName+"-"+namespace+"-"+ingName+DefaultBackendSuffix, PG)
+}
+
 // evh child
 func GetEvhPoolName(ingName, namespace, host, path, infrasetting, svcName string, dedicatedVS bool) string {
 	poolName := GetEvhPoolNameNoEncoding(ingName, namespace, host, path, infrasetting, svcName, dedicatedVS)
@@ -696,6 +733,22 @@ func GetPoolPKIProfileName(poolName string) string {
 	return Encode(poolName+"-pkiprofile", PKIProfile)
 }
 
+// GetAppProtocolPoolSettings returns whether the pools of a Service port with the given appProtocol talk
+// HTTP/2 and SSL to the backends. ok is false for the appProtocol values which do not set the pool protocol.
+// WebSocket upgrades are carried over HTTP/1.1, so kubernetes.io/ws keeps HTTP/2 disabled. WebSockets are enabled
+// in the default application profiles, hence the ws appProtocol values do not change the application profile.
+func GetAppProtocolPoolSettings(appProtocol string) (enableHTTP2, enableSSL, ok bool) {
+	switch strings.ToLower(appProtocol) {
+	case AppProtocolH2C, AppProtocolHTTP2, AppProtocolGRPC:
+		return true, false, true
+	case AppProtocolHTTPS, AppProtocolWSS:
+		return false, true, true
+	case AppProtocolHTTP, AppProtocolWS:
+		return false, false, true
+	}
+	return false, false, false
+}
+
 var VRFContext string
 var VRFUuid string
 
@@ -767,6 +820,52 @@ func GetQueryTenant() string {
 	return "*"
 }
 
+// VSRuntimeStatus is the operational state of a virtual service as reported by the Avi controller.
+type VSRuntimeStatus struct {
+	OperState string
+	Reason    string
+}
+
+func (s VSRuntimeStatus) IsUp() bool {
+	return s.OperState == VSOperStateUp
+}
+
+// GetVSRuntimeSyncInterval returns the interval in seconds at which AKO polls the runtime state of the
+// virtual services. A value of 0 disables the polling.
+func GetVSRuntimeSyncInterval() int64 {
+	syncInterval := os.Getenv(utils.VS_RUNTIME_SYNC_INTERVAL)
+	if syncInterval == "" {
+		return DefaultVSRuntimeStatusSyncInterval
+	}
+	interval, err := strconv.ParseInt(syncInterval, 10, 64)
+	if err != nil || interval < 0 {
+		utils.AviLog.Warnf("Invalid value %s for %s, using the default interval of %d seconds", syncInterval, utils.VS_RUNTIME_SYNC_INTERVAL, DefaultVSRuntimeStatusSyncInterval)
+		return DefaultVSRuntimeStatusSyncInterval
+	}
+	if interval != 0 && interval < MinVSRuntimeStatusSyncInterval {
+		utils.AviLog.Warnf("%s value %d is lower than the minimum interval, using %d seconds", utils.VS_RUNTIME_SYNC_INTERVAL, interval, MinVSRuntimeStatusSyncInterval)
+		return MinVSRuntimeStatusSyncInterval
+	}
+	return interval
+}
+
+// IsCRDValidationWebhookEnabled returns true if the leader AKO should serve the validating
+// admission webhook for the AKO CRDs.
+func IsCRDValidationWebhookEnabled() bool {
+	ok, _ := strconv.ParseBool(os.Getenv(utils.CRD_VALIDATION_WEBHOOK))
+	return ok
+}
+
+// IsCRDValidationWebhookFailOpen returns true if the AKO CRD objects should be admitted when the
+// validating webhook cannot be reached. Defaults to true.
+func IsCRDValidationWebhookFailOpen() bool {
+	failOpen, err := strconv.ParseBool(os.Getenv(utils.CRD_WEBHOOK_FAIL_OPEN))
+	if err != nil {
+		return true
+	}
+	return failOpen
+}
+
 func IsIstioEnabled() bool {
 	if ok, _ := strconv.ParseBool(os.Getenv("ISTIO_ENABLED")); ok {
 		utils.AviLog.Debugf("Istio is enabled")
@@ -1232,6 +1331,16 @@ func GetMarkersChecksum(markers utils.AviObjectMarkers) uint32 {
 		if vals.Field(i).Interface() != "" {
 			field := typeOfVals.Field(i).Name
 			var value string
+			if field == "Labels" {
+				// each label is a marker of its own, keyed by the label key
+				for labelKey, labelValue := range markers.Labels {
+					if IsReservedMarkerKey(labelKey) {
+						continue
+					}
+					markersStr = append(markersStr, labelKey+"="+labelValue)
+				}
+				continue
+			}
 			if field == "Path" || field == "IngressName" || field == "Host" {
 				pathArr := vals.Field(i).Interface().([]string)
 				sort.Strings(pathArr)
@@ -1254,6 +1363,16 @@ func GetMarkersChecksum(markers utils.AviObjectMarkers) uint32 {
 	return cksum
 }
 
+// IsReservedMarkerKey returns true if the key is used by AKO for the markers of the Avi objects, i.e. the
+// cluster name key and the fields of the AviObjectMarkers. Labels with such keys are not added as markers.
+func IsReservedMarkerKey(key string) bool {
+	if key == ClusterNameLabelKey {
+		return true
+	}
+	_, found := reflect.TypeOf(utils.AviObjectMarkers{}).FieldByName(key)
+	return found
+}
+
 func ObjectLabelChecksum(objectLabels []*models.RoleFilterMatchLabel) uint32 {
 	var objChecksum uint32
 	//Assumption here is User is not adding additional marker fields from UI/CLI
@@ -1269,7 +1388,12 @@ func ObjectLabelChecksum(objectLabels []*models.RoleFilterMatchLabel) uint32 {
 			}
 		} else {
 			if len(label.Values) != 0 {
-				markersStr[j] = label.Values[0]
+				if IsReservedMarkerKey(*label.Key) {
+					markersStr[j] = label.Values[0]
+				} else {
+					// markers built from labels are hashed along with the label key
+					markersStr[j] = *label.Key + "=" + label.Values[0]
+				}
 				j = j + 1
 			}
 		}
@@ -1560,6 +1684,14 @@ func HTTPCookiePersistenceProfileChecksum(cookieName string, timeout *int32, isP
 	return checksum
 }
 
+func AppCookiePersistenceProfileChecksum(headerName string, timeout *int32) uint32 {
+	checksum := utils.Hash(headerName)
+	if timeout != nil {
+		checksum += utils.Hash(utils.Stringify(*timeout))
+	}
+	return checksum
+}
+
 func PersistenceProfileChecksum(name, persistenceType string, ingestionMarkers utils.AviObjectMarkers, markers []*models.RoleFilterMatchLabel, populateCache bool) uint32 {
 	var checksum uint32 = 0
 	checksum += utils.Hash(name)
@@ -1574,6 +1706,69 @@ func PersistenceProfileChecksum(name, persistenceType string, ingestionMarkers u
 	return checksum
 }
 
+func TrafficCloneProfileChecksum(name string, cloneServers []string, ingestionMarkers utils.AviObjectMarkers, markers []*models.RoleFilterMatchLabel, populateCache bool) uint32 {
+	var checksum uint32 = 0
+	checksum += utils.Hash(name)
+	sortedCloneServers := make([]string, len(cloneServers))
+	copy(sortedCloneServers, cloneServers)
+	sort.Strings(sortedCloneServers)
+	checksum += utils.Hash(utils.Stringify(sortedCloneServers))
+	if populateCache {
+		if markers != nil {
+			checksum += ObjectLabelChecksum(markers)
+		}
+		return checksum
+	}
+	checksum += GetMarkersChecksum(ingestionMarkers)
+	return checksum
+}
+
+func ApplicationProfileChecksum(name, sslClientCertificateMode string, settingsChecksum uint32, ingestionMarkers utils.AviObjectMarkers, markers []*models.RoleFilterMatchLabel, populateCache bool) uint32 {
+	var checksum uint32 = 0
+	checksum += utils.Hash(name)
+	checksum += utils.Hash(sslClientCertificateMode)
+	checksum += settingsChecksum
+	if populateCache {
+		if markers != nil {
+			checksum += ObjectLabelChecksum(markers)
+		}
+		return checksum
+	}
+	checksum += GetMarkersChecksum(ingestionMarkers)
+	return checksum
+}
+
+var objectRefNameRegex = regexp.MustCompile(`(/api/[^"#]+)#[^"]*"`)
+
+// ApplicationProfileSettingsChecksum returns the checksum of the settings an AKO managed ApplicationProfile
+// inherits from its parent profile, i.e. of the profile without the fields which AKO sets on it.
+func ApplicationProfileSettingsChecksum(appProfile models.ApplicationProfile) uint32 {
+	appProfile.Name = nil
+	appProfile.UUID = nil
+	appProfile.URL = nil
+	appProfile.TenantRef = nil
+	appProfile.CreatedBy = nil
+	appProfile.Type = nil
+	appProfile.Markers = nil
+	appProfile.LastModified = nil
+	appProfile.Description = nil
+	appProfile.ConfigpbAttributes = nil
+	appProfile.CloudConfigCksum = nil
+	if appProfile.HTTPProfile != nil {
+		httpProfile := *appProfile.HTTPProfile
+		httpProfile.SslClientCertificateMode = nil
+		httpProfile.PkiProfileRef = nil
+		appProfile.HTTPProfile = &httpProfile
+	}
+	settings, err := json.Marshal(appProfile)
+	if err != nil {
+		utils.AviLog.Warnf("Unable to marshal ApplicationProfile settings: %s", err)
+		return 0
+	}
+	// The refs are compared without the object names, which are present only when fetched with include_name.
+	return utils.Hash(objectRefNameRegex.ReplaceAllString(string(settings), `$1"`))
+}
+
 func IsNodePortMode() bool {
 	nodePortType := os.Getenv(SERVICE_TYPE)
 	if nodePortType == NODE_PORT {
@@ -1758,6 +1953,23 @@ func GetAllMarkers(markers utils.AviObjectMarkers) []*models.RoleFilterMatchLabe
 		if vals.Field(i).Interface() != "" {
 			field := typeOfVals.Field(i).Name
 			value := vals.Field(i).Interface()
+			if field == "Labels" {
+				labelKeys := make([]string, 0, len(markers.Labels))
+				for labelKey := range markers.Labels {
+					labelKeys = append(labelKeys, labelKey)
+				}
+				sort.Strings(labelKeys)
+				for _, labelKey := range labelKeys {
+					if IsReservedMarkerKey(labelKey) {
+						continue
+					}
+					rfmls = append(rfmls, &models.RoleFilterMatchLabel{
+						Key:    proto.String(labelKey),
+						Values: []string{markers.Labels[labelKey]},
+					})
+				}
+				continue
+			}
 			if field == "Path" || field == "IngressName" || field == "Host" {
 				values := value.([]string)
 				if len(values) == 0 {
@@ -2353,7 +2565,7 @@ func init() {
 
 func ValidServiceType(service *v1.Service) bool {
 	switch service.Spec.Type {
-	case v1.ServiceTypeLoadBalancer, v1.ServiceTypeClusterIP, v1.ServiceTypeNodePort:
+	case v1.ServiceTypeLoadBalancer, v1.ServiceTypeClusterIP, v1.ServiceTypeNodePort, v1.ServiceTypeExternalName:
 		return true
 	default:
 		return false
@@ -0,0 +1,130 @@
+/*
+ * Copyright © 2025 Broadcom Inc. and/or its subsidiaries. All Rights Reserved.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package nodes
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// FindServicePort returns the port of the Service referred by a pool, matched by the port name when it is
+// known, otherwise by the port or the target port. Like the endpoints of the pool, the port of a Service with
+// a single port is used when none matches.
+func FindServicePort(svcObj *corev1.Service, portName string, port int32, targetPort intstr.IntOrString) *corev1.ServicePort {
+	if svcObj == nil {
+		return nil
+	}
+	for i, svcPort := range svcObj.Spec.Ports {
+		if portName != "" {
+			if svcPort.Name == portName {
+				return &svcObj.Spec.Ports[i]
+			}
+			continue
+		}
+		if port != 0 && svcPort.Port == port {
+			return &svcObj.Spec.Ports[i]
+		}
+		if targetPort.Type == intstr.Int && targetPort.IntVal != 0 &&
+			(svcPort.TargetPort.IntVal == targetPort.IntVal || svcPort.Port == targetPort.IntVal) {
+			return &svcObj.Spec.Ports[i]
+		}
+	}
+	if len(svcObj.Spec.Ports) == 1 {
+		return &svcObj.Spec.Ports[0]
+	}
+	return nil
+}
+
+// BuildPoolWithAppProtocol enables HTTP/2 or SSL on the pool as per the appProtocol of its Service port. HTTP/2
+// and SSL already configured on the pool are kept, so the settings of the route and of the CRDs take precedence.
+// It returns a message with the resulting pool protocol, empty when the appProtocol keeps the default HTTP/1.1.
+func BuildPoolWithAppProtocol(key string, poolNode *AviPoolNode, svcPort *corev1.ServicePort) string {
+	if svcPort == nil || svcPort.AppProtocol == nil || *svcPort.AppProtocol == "" {
+		return ""
+	}
+	appProtocol := *svcPort.AppProtocol
+	enableHTTP2, enableSSL, ok := lib.GetAppProtocolPoolSettings(appProtocol)
+	if !ok || (!enableHTTP2 && !enableSSL) {
+		utils.AviLog.Debugf("key: %s, msg: appProtocol %s does not change the protocol of pool %s", key, appProtocol, poolNode.Name)
+		return ""
+	}
+	if enableHTTP2 && poolNode.EnableHttp2 == nil {
+		poolNode.EnableHttp2 = proto.Bool(true)
+	}
+	if enableSSL && poolNode.SslProfileRef == nil {
+		poolNode.SniEnabled = true
+		poolNode.SslProfileRef = proto.String(fmt.Sprintf("/api/sslprofile?name=%s", lib.DefaultPoolSSLProfile))
+		setExternalNameServerName(poolNode, poolNode.Servers)
+	}
+
+	protocol := "HTTP/1.1"
+	if poolNode.EnableHttp2 != nil && *poolNode.EnableHttp2 {
+		protocol = "HTTP/2"
+	}
+	if poolNode.SslProfileRef != nil {
+		protocol += " over SSL"
+	}
+	port := svcPort.Name
+	if port == "" {
+		port = strconv.Itoa(int(svcPort.Port))
+	}
+	message := fmt.Sprintf("Pool %s uses %s for port %s with appProtocol %s", poolNode.Name, protocol, port, appProtocol)
+	utils.AviLog.Infof("key: %s, msg: %s", key, message)
+	return message
+}
+
+// buildPoolWithServiceAppProtocol applies the appProtocol of the Service port of an Ingress or Route pool, and
+// records the resulting pool protocol as an event on the Service. An HTTPRule applied later on the pool
+// overrides enableHTTP2.
+func buildPoolWithServiceAppProtocol(key, namespace, serviceName string, poolNode *AviPoolNode) {
+	svcObj, err := utils.GetInformers().ServiceInformer.Lister().Services(namespace).Get(serviceName)
+	if err != nil {
+		utils.AviLog.Debugf("key: %s, msg: unable to get the service %s/%s, err: %v", key, namespace, serviceName, err)
+		return
+	}
+	message := BuildPoolWithAppProtocol(key, poolNode, FindServicePort(svcObj, poolNode.PortName, poolNode.Port, poolNode.TargetPort))
+	RecordAppProtocolEvent(lib.AKOControlConfig().EventRecorder(), svcObj, poolNode.Name, message)
+}
+
+// appProtocolEvents holds the pool protocol last recorded on the Service of a pool, pool name -> message.
+// The entry of a pool is removed with the pool, see ForgetAppProtocolEvent.
+var appProtocolEvents sync.Map
+
+// RecordAppProtocolEvent records the pool protocol derived from the appProtocol as an event on the Service.
+// The pools are rebuilt on every change of the objects referring them, so the event is only recorded when the
+// derived protocol of the pool changes.
+func RecordAppProtocolEvent(recorder *utils.EventRecorder, svcObj *corev1.Service, poolName, message string) {
+	if message == "" {
+		appProtocolEvents.Delete(poolName)
+		return
+	}
+	if lastMessage, found := appProtocolEvents.Swap(poolName, message); found && lastMessage.(string) == message {
+		return
+	}
+	recorder.Event(svcObj, corev1.EventTypeNormal, lib.AppProtocolApplied, message)
+}
+
+// ForgetAppProtocolEvent removes the pool protocol last recorded for a pool, once the pool is deleted.
+func ForgetAppProtocolEvent(poolName string) {
+	appProtocolEvents.Delete(poolName)
+}
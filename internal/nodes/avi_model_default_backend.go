@@ -0,0 +1,288 @@
+/*
+ * Copyright © 2025 Broadcom Inc. and/or its subsidiaries. All Rights Reserved.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package nodes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	avimodels "github.com/vmware/alb-sdk/go/models"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// GetDefaultBackendModels returns the models on which the defaultBackend of an Ingress is configured, mapped to the
+// hostname of the VS. The defaultBackend is configured on the dedicated VS of each host of the Ingress, and on the
+// shard or EVH parent VS when the Ingress is hostless.
+func GetDefaultBackendModels(routeIgrObj RouteIngressModel, parsedIng IngressConfig, key string) map[string]string {
+	models := make(map[string]string)
+	if routeIgrObj.GetType() != utils.Ingress || parsedIng.DefaultBackend == nil {
+		return models
+	}
+
+	var hosts []string
+	for host := range parsedIng.IngressHostMap {
+		hosts = append(hosts, host)
+	}
+	for _, tlssetting := range parsedIng.TlsCollection {
+		for host := range tlssetting.Hosts {
+			if !utils.HasElem(hosts, host) {
+				hosts = append(hosts, host)
+			}
+		}
+	}
+
+	if len(hosts) == 0 {
+		vsName := deriveDefaultBackendVS("", key, routeIgrObj)
+		if vsName.Dedicated {
+			utils.AviLog.Warnf("key: %s, msg: defaultBackend of hostless ingress %s/%s is not supported on dedicated virtualservices",
+				key, routeIgrObj.GetNamespace(), routeIgrObj.GetName())
+			return models
+		}
+		models[lib.GetModelName(vsName.Tenant, vsName.Name)] = ""
+		return models
+	}
+
+	for _, host := range hosts {
+		vsName := deriveDefaultBackendVS(host, key, routeIgrObj)
+		if !vsName.Dedicated {
+			utils.AviLog.Infof("key: %s, msg: defaultBackend of ingress %s/%s is not applied for host %s on a shared virtualservice",
+				key, routeIgrObj.GetNamespace(), routeIgrObj.GetName(), host)
+			continue
+		}
+		models[lib.GetModelName(vsName.Tenant, vsName.Name)] = host
+	}
+	return models
+}
+
+func deriveDefaultBackendVS(hostname, key string, routeIgrObj RouteIngressModel) lib.VSNameMetadata {
+	if lib.IsEvhEnabled() {
+		_, vsName := DeriveShardVSForEvh(hostname, key, routeIgrObj)
+		return vsName
+	}
+	_, vsName := DeriveShardVS(hostname, key, routeIgrObj)
+	return vsName
+}
+
+// DeleteStaleDefaultBackends removes the Ingress from the models that its defaultBackend no longer targets, and hands
+// the default backend of those VSes over to the next Ingress in line. This must run before the pools of the hosts are
+// deleted, so that a dedicated VS left without paths is not kept alive by the default backend pool.
+func DeleteStaleDefaultBackends(routeIgrObj RouteIngressModel, defaultBackendModels map[string]string, key string, modelList *[]string) {
+	if routeIgrObj.GetType() != utils.Ingress {
+		return
+	}
+	ingNsName := routeIgrObj.GetNamespace() + "/" + routeIgrObj.GetName()
+	for modelName, hostname := range objects.SharedDefaultBackendLister().GetIngressToModels(ingNsName) {
+		if _, ok := defaultBackendModels[modelName]; ok {
+			continue
+		}
+		objects.SharedDefaultBackendLister().RemoveIngressToModelMapping(ingNsName, modelName)
+		buildDefaultBackendForModel(modelName, hostname, key, modelList)
+	}
+}
+
+// ProcessDefaultBackends records the Ingress as a candidate for the default backend of its models, and rebuilds
+// the default backend of each of them from the Ingress that owns it.
+func ProcessDefaultBackends(routeIgrObj RouteIngressModel, defaultBackendModels map[string]string, key string, modelList *[]string) {
+	ingNsName := routeIgrObj.GetNamespace() + "/" + routeIgrObj.GetName()
+	for modelName, hostname := range defaultBackendModels {
+		objects.SharedDefaultBackendLister().AddIngressToModelMapping(ingNsName, modelName, hostname)
+		buildDefaultBackendForModel(modelName, hostname, key, modelList)
+	}
+}
+
+func buildDefaultBackendForModel(modelName, hostname, key string, modelList *[]string) {
+	owner, others := getDefaultBackendOwner(objects.SharedDefaultBackendLister().GetModelToIngresses(modelName), key)
+
+	found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	if !found || aviModel == nil {
+		// Dedicated VSes are built along with the hosts of the Ingress, only the shared VS of a
+		// hostless Ingress is built here.
+		if owner == nil || hostname != "" {
+			return
+		}
+		tenantVsName := strings.SplitN(modelName, "/", 2)
+		utils.AviLog.Infof("key: %s, msg: model not found, generating new model with name: %s", key, modelName)
+		aviModel = NewAviObjectGraph()
+		if lib.IsEvhEnabled() {
+			aviModel.(*AviObjectGraph).ConstructAviL7SharedVsNodeForEvh(tenantVsName[1], tenantVsName[0], key, owner, false, false)
+		} else {
+			aviModel.(*AviObjectGraph).ConstructAviL7VsNode(tenantVsName[1], tenantVsName[0], key, owner, false, false)
+		}
+	}
+
+	var vsNode AviVsEvhSniModel
+	if lib.IsEvhEnabled() {
+		if vsNodes := aviModel.(*AviObjectGraph).GetAviEvhVS(); len(vsNodes) > 0 {
+			vsNode = vsNodes[0]
+		}
+	} else {
+		if vsNodes := aviModel.(*AviObjectGraph).GetAviVS(); len(vsNodes) > 0 {
+			vsNode = vsNodes[0]
+		}
+	}
+	if vsNode == nil {
+		utils.AviLog.Warnf("key: %s, msg: virtualservice not found in model %s", key, modelName)
+		return
+	}
+
+	aviModel.(*AviObjectGraph).BuildDefaultBackendForVS(vsNode, hostname, owner, key)
+	for _, other := range others {
+		ingObj, err := utils.GetInformers().IngressInformer.Lister().Ingresses(other.GetNamespace()).Get(other.GetName())
+		if err != nil {
+			continue
+		}
+		lib.AKOControlConfig().EventRecorder().Eventf(ingObj, corev1.EventTypeWarning, lib.DuplicateDefaultBackend,
+			"Default backend of virtualservice %s is already owned by ingress %s/%s", vsNode.GetName(), owner.GetNamespace(), owner.GetName())
+		utils.AviLog.Warnf("key: %s, msg: default backend of virtualservice %s is already owned by ingress %s/%s, ignoring defaultBackend of ingress %s/%s",
+			key, vsNode.GetName(), owner.GetNamespace(), owner.GetName(), other.GetNamespace(), other.GetName())
+	}
+
+	if changedModel := saveAviModel(modelName, aviModel.(*AviObjectGraph), key); changedModel && !utils.HasElem(*modelList, modelName) {
+		*modelList = append(*modelList, modelName)
+	}
+}
+
+// getDefaultBackendOwner elects the Ingress owning the default backend of a VS amongst the Ingresses declaring one
+// on it. The oldest Ingress wins, ties are broken by namespace/name.
+func getDefaultBackendOwner(ingNsNames []string, key string) (*K8sIngressModel, []*K8sIngressModel) {
+	var ingresses []*networkingv1.Ingress
+	for _, ingNsName := range ingNsNames {
+		nsName := strings.SplitN(ingNsName, "/", 2)
+		ingObj, err := utils.GetInformers().IngressInformer.Lister().Ingresses(nsName[0]).Get(nsName[1])
+		if err != nil || ingObj.GetDeletionTimestamp() != nil ||
+			ingObj.Spec.DefaultBackend == nil || ingObj.Spec.DefaultBackend.Service == nil {
+			continue
+		}
+		ingresses = append(ingresses, ingObj)
+	}
+	sort.Slice(ingresses, func(i, j int) bool {
+		if !ingresses[i].CreationTimestamp.Equal(&ingresses[j].CreationTimestamp) {
+			return ingresses[i].CreationTimestamp.Before(&ingresses[j].CreationTimestamp)
+		}
+		return ingresses[i].Namespace+"/"+ingresses[i].Name < ingresses[j].Namespace+"/"+ingresses[j].Name
+	})
+
+	var owner *K8sIngressModel
+	var others []*K8sIngressModel
+	for _, ingObj := range ingresses {
+		routeIgrObj, err, processObj := GetK8sIngressModel(ingObj.Name, ingObj.Namespace, key)
+		if err != nil || !processObj {
+			continue
+		}
+		if owner == nil {
+			owner = routeIgrObj
+		} else {
+			others = append(others, routeIgrObj)
+		}
+	}
+	return owner, others
+}
+
+// BuildDefaultBackendForVS replaces the catch-all pool and pool group of the VS with the ones built from the
+// defaultBackend of the owner Ingress. The pool group is attached as the default pool group of the VS, to serve
+// the requests that do not match any host or path.
+func (o *AviObjectGraph) BuildDefaultBackendForVS(vsNode AviVsEvhSniModel, hostname string, owner *K8sIngressModel, key string) {
+	o.Lock.Lock()
+	defer o.Lock.Unlock()
+
+	if pgName := vsNode.GetDefaultPoolGroup(); pgName != "" {
+		var poolRefs []string
+		var pgNodes []*AviPoolGroupNode
+		for _, pgNode := range vsNode.GetPoolGroupRefs() {
+			if pgNode.Name != pgName {
+				pgNodes = append(pgNodes, pgNode)
+				continue
+			}
+			for _, member := range pgNode.Members {
+				poolRefs = append(poolRefs, *member.PoolRef)
+			}
+		}
+		vsNode.SetPoolGroupRefs(pgNodes)
+		var poolNodes []*AviPoolNode
+		for _, poolNode := range vsNode.GetPoolRefs() {
+			if !utils.HasElem(poolRefs, fmt.Sprintf("/api/pool?name=%s", poolNode.Name)) {
+				poolNodes = append(poolNodes, poolNode)
+			}
+		}
+		vsNode.SetPoolRefs(poolNodes)
+		vsNode.SetDefaultPoolGroup("")
+	}
+
+	if owner == nil {
+		utils.AviLog.Infof("key: %s, msg: no default backend for virtualservice %s", key, vsNode.GetName())
+		return
+	}
+
+	ingSpec := owner.GetSpec().(networkingv1.IngressSpec)
+	defaultBackend := NewNodesValidator().parseDefaultBackend(owner.GetNamespace(), owner.GetName(), ingSpec.DefaultBackend, key)
+	if defaultBackend == nil {
+		return
+	}
+	infraSetting := owner.GetAviInfraSetting()
+	var infraSettingName string
+	if infraSetting != nil && !lib.IsInfraSettingNSScoped(infraSetting.Name, owner.GetNamespace()) {
+		infraSettingName = infraSetting.Name
+	}
+
+	poolName := lib.GetL7DefaultBackendPoolName(vsNode.GetName(), owner.GetNamespace(), owner.GetName())
+	pgName := lib.GetL7DefaultBackendPGName(vsNode.GetName(), owner.GetNamespace(), owner.GetName())
+	if lib.CheckObjectNameLength(poolName, lib.Pool) || lib.CheckObjectNameLength(pgName, lib.PG) {
+		return
+	}
+	poolNode := buildPoolNode(key, poolName, owner.GetName(), owner.GetNamespace(), "", hostname, infraSetting,
+		defaultBackend.ServiceName, []string{hostname}, false, *defaultBackend)
+	pgNode := &AviPoolGroupNode{Name: pgName, Tenant: vsNode.GetTenant()}
+	pgNode.AviMarkers = lib.PopulatePGNodeMarkers(owner.GetNamespace(), hostname, infraSettingName, []string{owner.GetName()}, []string{defaultBackend.Path})
+	if hostname == "" {
+		// hostless Ingress, the markers do not carry an empty host.
+		poolNode.AviMarkers.Host = nil
+		pgNode.AviMarkers.Host = nil
+	}
+	poolRef := fmt.Sprintf("/api/pool?name=%s", poolNode.Name)
+	ratio := defaultBackend.weight
+	pgNode.Members = append(pgNode.Members, &avimodels.PoolGroupMember{PoolRef: &poolRef, Ratio: &ratio})
+
+	vsNode.SetPoolRefs(append(vsNode.GetPoolRefs(), poolNode))
+	vsNode.SetPoolGroupRefs(append(vsNode.GetPoolGroupRefs(), pgNode))
+	vsNode.SetDefaultPoolGroup(pgNode.Name)
+	utils.AviLog.Infof("key: %s, msg: default backend of virtualservice %s set to service %s/%s of ingress %s",
+		key, vsNode.GetName(), owner.GetNamespace(), defaultBackend.ServiceName, owner.GetName())
+}
+
+// isDefaultBackendPool returns true if the pool is the catch-all pool of the VS, which is not part of
+// the pool group shared by the hosts.
+func isDefaultBackendPool(vsNode AviVsEvhSniModel, poolName string) bool {
+	if vsNode.GetDefaultPoolGroup() == "" {
+		return false
+	}
+	poolRef := fmt.Sprintf("/api/pool?name=%s", poolName)
+	for _, pgNode := range vsNode.GetPoolGroupRefs() {
+		if pgNode.Name != vsNode.GetDefaultPoolGroup() {
+			continue
+		}
+		for _, member := range pgNode.Members {
+			if *member.PoolRef == poolRef {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -53,6 +53,9 @@ type AviVsEvhSniModel interface {
 	GetPoolGroupRefs() []*AviPoolGroupNode
 	SetPoolGroupRefs([]*AviPoolGroupNode)
 
+	GetDefaultPoolGroup() string
+	SetDefaultPoolGroup(string)
+
 	GetSSLKeyCertRefs() []*AviTLSKeyCertNode
 	SetSSLKeyCertRefs([]*AviTLSKeyCertNode)
 
@@ -156,6 +159,11 @@ type AviEvhVsNode struct {
 	Caller              string
 	StringGroupRefs     []*AviStringGroupNode
 	TrafficEnabled      *bool
+	TrafficCloneProfile *AviTrafficCloneProfileNode
+	// ApplicationProfileRefs are the AKO managed application profiles set as per port overrides
+	ApplicationProfileRefs []*AviApplicationProfileNode
+	// L4ChildNodes are the L4 VSes sharing the vsvip of the VS, which serve the TCP and UDP listeners of a Gateway
+	L4ChildNodes []*AviVsNode
 
 	AviVsNodeCommonFields
 
@@ -200,6 +208,14 @@ func (v *AviEvhVsNode) SetPoolRefs(poolRefs []*AviPoolNode) {
 	v.PoolRefs = poolRefs
 }
 
+func (v *AviEvhVsNode) GetDefaultPoolGroup() string {
+	return v.DefaultPoolGroup
+}
+
+func (v *AviEvhVsNode) SetDefaultPoolGroup(defaultPoolGroup string) {
+	v.DefaultPoolGroup = defaultPoolGroup
+}
+
 func (v *AviEvhVsNode) GetPoolGroupRefs() []*AviPoolGroupNode {
 	return v.PoolGroupRefs
 }
@@ -674,6 +690,10 @@ func (v *AviEvhVsNode) CalculateCheckSum() {
 		}
 	}
 
+	for _, l4Child := range v.L4ChildNodes {
+		checksumStringSlice = append(checksumStringSlice, "L4Child"+l4Child.Name)
+	}
+
 	if lib.AKOControlConfig().GetAKOFQDNReusePolicy() == lib.FQDNReusePolicyStrict {
 		// Why do we need to change checksum of VS? As we are appending hostname--> list of ingresses mapping
 		// so we need to have updated list at vs metadata so that during AKO bootup we will have updated list
@@ -768,6 +788,10 @@ func (v *AviEvhVsNode) CalculateCheckSum() {
 		checksum += utils.Hash(v.DefaultPoolGroup)
 	}
 
+	if v.TrafficCloneProfile != nil {
+		checksum += utils.Hash(v.TrafficCloneProfile.Name)
+	}
+
 	v.CloudConfigCksum = checksum
 }
 
@@ -998,6 +1022,7 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForEVH(vsNode []*AviEvhVsNode, childN
 		if tlsSettings != nil && tlsSettings.reencrypt {
 			o.BuildPoolSecurity(poolNode, *tlsSettings, key, poolNode.AviMarkers)
 		}
+		buildPoolWithServiceAppProtocol(key, namespace, path.ServiceName, poolNode)
 
 		serviceType := lib.GetServiceType()
 		if serviceType == lib.NodePortLocal {
@@ -2001,6 +2026,7 @@ func (o *AviObjectGraph) RemovePGNodeRefsForEvh(pgName string, vsNode *AviEvhVsN
 }
 func (o *AviObjectGraph) manipulateEVHVsNode(vsNode *AviEvhVsNode, ingName, namespace, hostname string, pathSvc map[string][]string, infraSettingName, key string, deleteHostMapEntry bool) {
 	for path, services := range pathSvc {
+		removeHTTPRuleCanaryPools(vsNode, ingName, namespace, []string{path})
 		pgName := lib.GetEvhPGName(ingName, namespace, hostname, path, infraSettingName, vsNode.Dedicated)
 		pgNode := vsNode.GetPGForVSByName(pgName)
 		for _, svc := range services {
@@ -2037,6 +2063,7 @@ func (o *AviObjectGraph) manipulateEVHVsNode(vsNode *AviEvhVsNode, ingName, name
 			}
 		}
 	}
+	removeHTTPRuleRateLimitRules(vsNode)
 }
 func (o *AviObjectGraph) ManipulateEvhNode(currentEvhNodeName, ingName, namespace, hostname string, pathSvc map[string][]string, vsNode []*AviEvhVsNode, infraSettingName, key string, deleteHostMapEntry bool) bool {
 	if vsNode[0].Dedicated {
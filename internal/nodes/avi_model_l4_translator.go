@@ -129,7 +129,7 @@ func (o *AviObjectGraph) ConstructAviL4VsNode(svcObj *corev1.Service, key string
 		avi_vs_meta.ApplicationProfile = utils.DEFAULT_L4_APP_PROFILE
 	}
 
-	avi_vs_meta.NetworkProfile = getNetworkProfile(isSCTP, isTCP, isUDP)
+	avi_vs_meta.NetworkProfile = GetNetworkProfile(isSCTP, isTCP, isUDP)
 
 	vsVipName := lib.GetL4VSVipName(svcObj.ObjectMeta.Name, svcObj.ObjectMeta.Namespace)
 	vsVipNode := &AviVSVIPNode{
@@ -274,6 +274,9 @@ func (o *AviObjectGraph) ConstructAviL4PolPoolNodes(svcObj *corev1.Service, vsNo
 }
 
 func PopulateServersForNPL(poolNode *AviPoolNode, ns string, serviceName string, ingress bool, key string) []AviPoolMetaServer {
+	if servers, ok := populateServersForExternalName(poolNode, ns, serviceName, key); ok {
+		return servers
+	}
 	if ingress {
 		found, _ := objects.SharedClusterIpLister().Get(ns + "/" + serviceName)
 		if !found {
@@ -396,6 +399,9 @@ func PopulateServersForNPL(poolNode *AviPoolNode, ns string, serviceName string,
 }
 
 func PopulateServersForNodePort(poolNode *AviPoolNode, ns string, serviceName string, ingress bool, key string) []AviPoolMetaServer {
+	if servers, ok := populateServersForExternalName(poolNode, ns, serviceName, key); ok {
+		return servers
+	}
 
 	ipFamily := lib.GetIPFamily()
 	v4enabled := ipFamily == "V4" || ipFamily == "V4_V6"
@@ -559,6 +565,9 @@ type endpointKey struct {
 }
 
 func PopulateServers(poolNode *AviPoolNode, ns string, serviceName string, ingress bool, key string) []AviPoolMetaServer {
+	if servers, ok := populateServersForExternalName(poolNode, ns, serviceName, key); ok {
+		return servers
+	}
 
 	// Find the servers that match the port.
 	if ingress {
@@ -673,6 +682,43 @@ func PopulateServers(poolNode *AviPoolNode, ns string, serviceName string, ingre
 	return pool_meta
 }
 
+// populateServersForExternalName returns the server of the pool of an ExternalName Service, which is the external FQDN
+// resolved by the Avi controller. The port of the Service is used as is. It returns false when the Service is not of
+// type ExternalName.
+func populateServersForExternalName(poolNode *AviPoolNode, ns string, serviceName string, key string) ([]AviPoolMetaServer, bool) {
+	svcObj, err := utils.GetInformers().ServiceInformer.Lister().Services(ns).Get(serviceName)
+	if err != nil || svcObj.Spec.Type != corev1.ServiceTypeExternalName {
+		return nil, false
+	}
+	externalName := strings.TrimSuffix(svcObj.Spec.ExternalName, ".")
+	if externalName == "" {
+		utils.AviLog.Warnf("key: %s, msg: externalName not set for service %s/%s", key, ns, serviceName)
+		return make([]AviPoolMetaServer, 0), true
+	}
+	if svcPort := FindServicePort(svcObj, poolNode.PortName, poolNode.Port, poolNode.TargetPort); svcPort != nil {
+		poolNode.Port = svcPort.Port
+	}
+
+	atype := "DNS"
+	server := AviPoolMetaServer{
+		Ip:       avimodels.IPAddr{Type: &atype, Addr: &externalName},
+		Hostname: externalName,
+		Port:     poolNode.Port,
+	}
+	setExternalNameServerName(poolNode, []AviPoolMetaServer{server})
+	utils.AviLog.Infof("key: %s, msg: server for ExternalName service %s/%s: %s:%d", key, ns, serviceName, externalName, poolNode.Port)
+	return []AviPoolMetaServer{server}, true
+}
+
+// setExternalNameServerName sends the FQDN of an ExternalName Service as SNI when SSL is enabled on its pool,
+// by the appProtocol of the Service port or by an HTTPRule, as the Host header of the request does not match it.
+func setExternalNameServerName(poolNode *AviPoolNode, servers []AviPoolMetaServer) {
+	if poolNode.SslProfileRef == nil || poolNode.ServerName != nil || len(servers) != 1 || servers[0].Hostname == "" {
+		return
+	}
+	poolNode.ServerName = proto.String(servers[0].Hostname)
+}
+
 func enableServer(condition discovery.EndpointConditions) *bool {
 	var ready, terminating bool
 	enabled := new(bool)
@@ -1045,7 +1091,7 @@ func buildPoolWithHealthMonitorCrdRefs(key string, poolNode *AviPoolNode, backen
 // and override required services with UDP Fast Path or SCTP proxy. Having a separate
 // internally used network profile (MIXED_NET_PROFILE) helps ensure PUT calls
 // on existing VSes.
-func getNetworkProfile(isSCTP, isTCP, isUDP bool) string {
+func GetNetworkProfile(isSCTP, isTCP, isUDP bool) string {
 	if isSCTP && !isTCP && !isUDP {
 		return utils.SYSTEM_SCTP_PROXY
 	}
@@ -334,6 +334,9 @@ func (o *AviObjectGraph) BuildL7VSGraphHostNameShard(vsName, hostname string, ro
 	// Reset the PG Node members and rebuild them
 	pgNode.Members = nil
 	for _, poolNode := range vsNode[0].PoolRefs {
+		if isDefaultBackendPool(vsNode[0], poolNode.Name) {
+			continue
+		}
 		ratio := poolNode.ServiceMetadata.PoolRatio
 		pool_ref := fmt.Sprintf("/api/pool?name=%s", poolNode.Name)
 		pgNode.Members = append(pgNode.Members, &avimodels.PoolGroupMember{PoolRef: &pool_ref, PriorityLabel: &poolNode.PriorityLabel, Ratio: &ratio})
@@ -372,6 +375,7 @@ func buildPoolNode(key, poolName, ingName, namespace, priorityLabel, hostname st
 		// Unset the poolnode's vrfcontext.
 		poolNode.VrfContext = ""
 	}
+	buildPoolWithServiceAppProtocol(key, namespace, obj.ServiceName, poolNode)
 
 	serviceType := lib.GetServiceType()
 	if serviceType == lib.NodePortLocal {
@@ -442,6 +446,9 @@ func (o *AviObjectGraph) DeletePoolForHostname(vsName, hostname string, routeIgr
 		if pgNode != nil {
 			pgNode.Members = nil
 			for _, poolNode := range vsNode[0].PoolRefs {
+				if isDefaultBackendPool(vsNode[0], poolNode.Name) {
+					continue
+				}
 				ratio := poolNode.ServiceMetadata.PoolRatio
 				pool_ref := fmt.Sprintf("/api/pool?name=%s", poolNode.Name)
 				pgNode.Members = append(pgNode.Members, &avimodels.PoolGroupMember{PoolRef: &pool_ref, PriorityLabel: &poolNode.PriorityLabel, Ratio: &ratio})
@@ -495,6 +502,7 @@ func (o *AviObjectGraph) DeletePoolForHostname(vsName, hostname string, routeIgr
 }
 func (o *AviObjectGraph) manipulateVsNode(vsNode *AviVsNode, ingName, namespace, hostname, infraSettingName string, pathSvc map[string][]string, isIngr bool) {
 	for path, services := range pathSvc {
+		removeHTTPRuleCanaryPools(vsNode, ingName, namespace, []string{path})
 		pgName := lib.GetSniPGName(ingName, namespace, hostname, path, infraSettingName, vsNode.Dedicated)
 		pgNode := vsNode.GetPGForVSByName(pgName)
 		if pgNode == nil {
@@ -536,6 +544,7 @@ func (o *AviObjectGraph) manipulateVsNode(vsNode *AviVsNode, ingName, namespace,
 			o.RemoveHTTPRefsStringGroupsFromSni(httppolname, hppmapname, vsNode)
 		}
 	}
+	removeHTTPRuleRateLimitRules(vsNode)
 }
 
 func (o *AviObjectGraph) ManipulateSniNode(currentSniNodeName, ingName, namespace, hostname string, pathSvc map[string][]string, vsNode []*AviVsNode, key string, isIngr bool, infraSettingName string) bool {
@@ -475,6 +475,7 @@ func (o *AviObjectGraph) BuildPolicyPGPoolsForSNI(vsNode []*AviVsNode, tlsNode *
 			if hostpath.reencrypt {
 				o.BuildPoolSecurity(poolNode, hostpath, key, poolNode.AviMarkers)
 			}
+			buildPoolWithServiceAppProtocol(key, namespace, path.ServiceName, poolNode)
 
 			serviceType := lib.GetServiceType()
 			if serviceType == lib.NodePortLocal {
@@ -24,6 +24,7 @@ import (
 
 	akov1beta1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1beta1"
 
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 
@@ -216,6 +217,9 @@ func (v *AviEvhVsNode) CalculateForGraphChecksum() uint32 {
 	for _, stringGroup := range v.StringGroupRefs {
 		checksumStringSlice = append(checksumStringSlice, fmt.Sprint(stringGroup.GetCheckSum()))
 	}
+	for _, l4Child := range v.L4ChildNodes {
+		checksumStringSlice = append(checksumStringSlice, fmt.Sprint(l4Child.CalculateForGraphChecksum()))
+	}
 	return utils.Hash(strings.Join(checksumStringSlice, ":"))
 }
 
@@ -491,6 +495,14 @@ func (v *AviVsNode) SetPoolRefs(PoolRefs []*AviPoolNode) {
 	v.PoolRefs = PoolRefs
 }
 
+func (v *AviVsNode) GetDefaultPoolGroup() string {
+	return v.DefaultPoolGroup
+}
+
+func (v *AviVsNode) SetDefaultPoolGroup(defaultPoolGroup string) {
+	v.DefaultPoolGroup = defaultPoolGroup
+}
+
 func (v *AviVsNode) GetPoolGroupRefs() []*AviPoolGroupNode {
 	return v.PoolGroupRefs
 }
@@ -1088,6 +1100,10 @@ func (v *AviVsNode) CalculateCheckSum() {
 
 	checksum += v.AviVsNodeGeneratedFields.CalculateCheckSumOfGeneratedCode()
 
+	if v.DefaultPoolGroup != "" {
+		checksum += utils.Hash(v.DefaultPoolGroup)
+	}
+
 	v.CloudConfigCksum = checksum
 }
 
@@ -1134,6 +1150,9 @@ func (v *AviL4PolicyNode) CalculateCheckSum() {
 		protocols = append(protocols, hpp.Protocol)
 		// Include Pool name in checksum logic
 		pool := strings.TrimPrefix(hpp.Pool, "/api/pool?name=")
+		if pool == "" {
+			pool = hpp.PoolGroup
+		}
 		pools = append(pools, pool)
 
 	}
@@ -1173,6 +1192,7 @@ type AviHttpPolicySetNode struct {
 	AttachedToSharedVS bool
 	RequestRules       []*avimodels.HTTPRequestRule
 	ResponseRules      []*avimodels.HTTPResponseRule
+	HTTPSecurityRules  []*avimodels.HttpsecurityRule
 }
 
 func (v *AviHttpPolicySetNode) GetCheckSum() uint32 {
@@ -1209,6 +1229,10 @@ func (v *AviHttpPolicySetNode) CalculateCheckSum() {
 		checksum += utils.Hash(utils.Stringify(v.ResponseRules))
 	}
 
+	if v.HTTPSecurityRules != nil {
+		checksum += utils.Hash(utils.Stringify(v.HTTPSecurityRules))
+	}
+
 	v.CloudConfigCksum = checksum
 }
 
@@ -1340,6 +1364,8 @@ type AviPortHostProtocol struct {
 	EnableSSL   bool
 	EnableHTTP2 bool
 	Name        string
+	// ApplicationProfile is the name of the application profile overriding the one of the VS for this port
+	ApplicationProfile string
 }
 
 type AviVSVIPNode struct {
@@ -1349,6 +1375,7 @@ type AviVSVIPNode struct {
 	FQDNs                   []string
 	VrfContext              string
 	IPAddress               string
+	IPv6Address             string
 	VipNetworks             []akov1beta1.AviInfraSettingVipNetwork
 	EnablePublicIP          *bool
 	BGPPeerLabels           []string
@@ -1375,6 +1402,10 @@ func (v *AviVSVIPNode) CalculateCheckSum() {
 		checksum += utils.Hash(v.IPAddress)
 	}
 
+	if v.IPv6Address != "" {
+		checksum += utils.Hash(v.IPv6Address)
+	}
+
 	if len(v.VipNetworks) > 0 {
 		var vipNetworkStringList []string
 		for _, vipNetwork := range v.VipNetworks {
@@ -1596,6 +1627,7 @@ type AviApplicationPersistenceProfileNode struct {
 	AviMarkers                   utils.AviObjectMarkers
 	PersistenceType              string
 	HTTPCookiePersistenceProfile *HTTPCookiePersistenceProfileNode
+	AppCookiePersistenceProfile  *AppCookiePersistenceProfileNode
 }
 
 type HTTPCookiePersistenceProfileNode struct {
@@ -1604,6 +1636,11 @@ type HTTPCookiePersistenceProfileNode struct {
 	IsPersistentCookie *bool
 }
 
+type AppCookiePersistenceProfileNode struct {
+	HeaderName string
+	Timeout    *int32
+}
+
 func (v *AviApplicationPersistenceProfileNode) GetNodeType() string {
 	return lib.ApplicationPersistenceProfileNode
 }
@@ -1632,9 +1669,88 @@ func (v *AviApplicationPersistenceProfileNode) CalculateCheckSum() {
 		cookieProfile := v.HTTPCookiePersistenceProfile
 		checksum += lib.HTTPCookiePersistenceProfileChecksum(cookieProfile.CookieName, cookieProfile.Timeout, cookieProfile.IsPersistentCookie)
 	}
+	if v.AppCookiePersistenceProfile != nil {
+		checksum += lib.AppCookiePersistenceProfileChecksum(v.AppCookiePersistenceProfile.HeaderName, v.AppCookiePersistenceProfile.Timeout)
+	}
 	v.CloudConfigCksum = checksum
 }
 
+type AviTrafficCloneProfileNode struct {
+	Name             string
+	Tenant           string
+	CloudConfigCksum uint32
+	AviMarkers       utils.AviObjectMarkers
+	CloneServers     []string
+}
+
+func (v *AviTrafficCloneProfileNode) GetNodeType() string {
+	return lib.TrafficCloneProfileNode
+}
+
+func (v *AviTrafficCloneProfileNode) CopyNode() AviModelNode {
+	newNode := AviTrafficCloneProfileNode{}
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		utils.AviLog.Warnf("Unable to marshal AviTrafficCloneProfileNode: %s", err)
+	}
+	err = json.Unmarshal(bytes, &newNode)
+	if err != nil {
+		utils.AviLog.Warnf("Unable to unmarshal AviTrafficCloneProfileNode: %s", err)
+	}
+	return &newNode
+}
+
+func (v *AviTrafficCloneProfileNode) GetCheckSum() uint32 {
+	v.CalculateCheckSum()
+	return v.CloudConfigCksum
+}
+
+func (v *AviTrafficCloneProfileNode) CalculateCheckSum() {
+	v.CloudConfigCksum = lib.TrafficCloneProfileChecksum(v.Name, v.CloneServers, v.AviMarkers, nil, false)
+}
+
+type AviApplicationProfileNode struct {
+	Name                     string
+	Tenant                   string
+	CloudConfigCksum         uint32
+	AviMarkers               utils.AviObjectMarkers
+	SSLClientCertificateMode string
+	PkiProfile               *AviPkiProfileNode
+	// ParentApplicationProfile is the application profile the AKO managed profile inherits its settings from,
+	// as fetched during the cache population.
+	ParentApplicationProfile string
+}
+
+func (v *AviApplicationProfileNode) GetNodeType() string {
+	return lib.ApplicationProfileNode
+}
+
+func (v *AviApplicationProfileNode) CopyNode() AviModelNode {
+	newNode := AviApplicationProfileNode{}
+	bytes, err := json.Marshal(v)
+	if err != nil {
+		utils.AviLog.Warnf("Unable to marshal AviApplicationProfileNode: %s", err)
+	}
+	err = json.Unmarshal(bytes, &newNode)
+	if err != nil {
+		utils.AviLog.Warnf("Unable to unmarshal AviApplicationProfileNode: %s", err)
+	}
+	return &newNode
+}
+
+func (v *AviApplicationProfileNode) GetCheckSum() uint32 {
+	v.CalculateCheckSum()
+	return v.CloudConfigCksum
+}
+
+func (v *AviApplicationProfileNode) CalculateCheckSum() {
+	var settingsChecksum uint32
+	if parentAppProfile, found := avicache.GetParentApplicationProfile(v.ParentApplicationProfile); found {
+		settingsChecksum = lib.ApplicationProfileSettingsChecksum(parentAppProfile)
+	}
+	v.CloudConfigCksum = lib.ApplicationProfileChecksum(v.Name, v.SSLClientCertificateMode, settingsChecksum, v.AviMarkers, nil, false)
+}
+
 type AviPoolNode struct {
 	Name                          string
 	Tenant                        string
@@ -1655,6 +1771,7 @@ type AviPoolNode struct {
 	T1Lr                          string // Only applicable to NSX-T cloud, if this value is set, we automatically should unset the VRF context value.
 	AviMarkers                    utils.AviObjectMarkers
 	AttachedWithSharedVS          bool
+	HTTPRuleCanary                bool
 
 	AviPoolCommonFields
 
@@ -1679,6 +1796,9 @@ type AviPoolCommonFields struct {
 	EnableHttp2                      *bool
 	HostCheckEnabled                 *bool
 	DomainName                       []string
+	ServerName                       *string
+	ServerTimeout                    *uint32
+	ServerReselect                   *avimodels.HttpserverReselect
 }
 
 func (v *AviPoolNode) GetCheckSum() uint32 {
@@ -1736,6 +1856,9 @@ func (v *AviPoolNode) CalculateCheckSum() {
 	if v.DomainName != nil {
 		checksumStringSlice = append(checksumStringSlice, utils.Stringify(v.DomainName))
 	}
+	if v.ServerName != nil {
+		checksumStringSlice = append(checksumStringSlice, *v.ServerName)
+	}
 
 	if len(v.ServiceMetadata.NamespaceServiceName) > 0 {
 		sort.Strings(v.ServiceMetadata.NamespaceServiceName)
@@ -1750,6 +1873,14 @@ func (v *AviPoolNode) CalculateCheckSum() {
 	if v.EnableHttp2 != nil {
 		checksumStringSlice = append(checksumStringSlice, utils.Stringify(*v.EnableHttp2))
 	}
+
+	if v.ServerTimeout != nil {
+		checksumStringSlice = append(checksumStringSlice, strconv.Itoa(int(*v.ServerTimeout)))
+	}
+
+	if v.ServerReselect != nil {
+		checksumStringSlice = append(checksumStringSlice, utils.Stringify(v.ServerReselect))
+	}
 	chksumStr := fmt.Sprint(strings.Join(checksumStringSlice, delim))
 
 	checksum := utils.Hash(chksumStr)
@@ -1836,6 +1967,8 @@ type AviPoolMetaServer struct {
 	ServerNode string
 	Port       int32
 	Enabled    *bool
+	// Hostname is set for the servers resolved by DNS on the Avi controller.
+	Hostname string
 }
 
 type IngressHostPathSvc struct {
@@ -1883,6 +2016,7 @@ type IngressConfig struct {
 	TlsCollection         []TlsSettings
 	IngressHostMap
 	InsecureEdgeTermAllow bool
+	DefaultBackend        *IngressHostPathSvc
 }
 
 type SecureHostNameMapProp struct {
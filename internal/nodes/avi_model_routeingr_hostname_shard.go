@@ -77,6 +77,13 @@ func HostNameShardAndPublish(objType, objname, namespace, key string, fullsync b
 		// Detect a delete condition here.
 		if k8serrors.IsNotFound(err) || !processObj {
 			utils.AviLog.Infof("key: %s, Deleting Pool for ingress delete", key)
+			var modelList []string
+			DeleteStaleDefaultBackends(routeIgrObj, nil, key, &modelList)
+			if !fullsync {
+				for _, modelName := range modelList {
+					PublishKeyToRestLayer(modelName, key, sharedQueue)
+				}
+			}
 			if lib.IsEvhEnabled() {
 				RouteIngrDeletePoolsByHostnameForEvh(routeIgrObj, namespace, objname, key, fullsync, sharedQueue)
 			} else {
@@ -93,6 +100,8 @@ func HostNameShardAndPublish(objType, objname, namespace, key string, fullsync b
 	var modelList []string
 
 	parsedIng = routeIgrObj.ParseHostPath()
+	defaultBackendModels := GetDefaultBackendModels(routeIgrObj, parsedIng, key)
+	DeleteStaleDefaultBackends(routeIgrObj, defaultBackendModels, key, &modelList)
 
 	// Check if this ingress and had any previous mappings, if so - delete them first.
 	_, Storedhosts := routeIgrObj.GetSvcLister().IngressMappings(namespace).GetRouteIngToHost(objname)
@@ -107,6 +116,7 @@ func HostNameShardAndPublish(objType, objname, namespace, key string, fullsync b
 		ProcessPassthroughHosts(routeIgrObj, key, parsedIng, &modelList, Storedhosts, hostsMap)
 		// delete stale data
 		DeleteStaleDataForEvh(routeIgrObj, key, &modelList, Storedhosts, hostsMap)
+		ProcessDefaultBackends(routeIgrObj, defaultBackendModels, key, &modelList)
 		// hostNamePathStore cache operation
 		_, oldHostMap := routeIgrObj.GetSvcLister().IngressMappings(namespace).GetRouteIngToHost(objname)
 		updateHostPathCache(namespace, objname, oldHostMap, hostsMap)
@@ -133,6 +143,7 @@ func HostNameShardAndPublish(objType, objname, namespace, key string, fullsync b
 
 	utils.AviLog.Debugf("key: %s, msg: Stored hosts: %v, hosts map: %v", key, Storedhosts, hostsMap)
 	DeleteStaleData(routeIgrObj, key, &modelList, Storedhosts, hostsMap)
+	ProcessDefaultBackends(routeIgrObj, defaultBackendModels, key, &modelList)
 
 	// hostNamePathStore cache operation
 	_, oldHostMap := routeIgrObj.GetSvcLister().IngressMappings(namespace).GetRouteIngToHost(objname)
@@ -250,20 +250,33 @@ func (o *AviObjectGraph) BuildGraphForPassthrough(svclist []IngressHostPathSvc,
 }
 
 func (o *AviObjectGraph) ConstructL4DataScript(vsName string, key string, vsNode *AviVsNode) *AviHTTPDataScriptNode {
+	dsScriptNode := buildL4DataScriptNode(vsName, vsNode.GetTenant())
+	vsNode.HTTPDSrefs = append(vsNode.HTTPDSrefs, dsScriptNode)
+	o.AddModelNode(dsScriptNode)
+	return dsScriptNode
+}
+
+// ConstructL4DataScriptForEvh attaches the SNI based passthrough datascript to an EVH parent VS, which serves
+// the passthrough traffic on the ports with an L4 application profile override. The datascript is tracked
+// only through the VS, so that it goes away along with the last passthrough poolgroup.
+func ConstructL4DataScriptForEvh(vsName string, key string, vsNode *AviEvhVsNode) *AviHTTPDataScriptNode {
+	dsScriptNode := buildL4DataScriptNode(vsName, vsNode.GetTenant())
+	vsNode.HTTPDSrefs = append(vsNode.HTTPDSrefs, dsScriptNode)
+	utils.AviLog.Debugf("key: %s, msg: added the passthrough datascript %s to the VS %s", key, dsScriptNode.Name, vsNode.Name)
+	return dsScriptNode
+}
+
+func buildL4DataScriptNode(vsName, tenant string) *AviHTTPDataScriptNode {
 	dsScriptNode := &AviHTTPDataScriptNode{
 		Name:   lib.GetL7InsecureDSName(vsName),
-		Tenant: vsNode.GetTenant(),
+		Tenant: tenant,
 		DataScript: &DataScript{
 			Script: lib.PassthroughDatascript,
 			Evt:    "VS_DATASCRIPT_EVT_L4_REQUEST",
 		},
 		ProtocolParsers: []string{"/api/protocolparser/?name=Default-TLS"},
 	}
-
 	dsScriptNode.Script = strings.Replace(dsScriptNode.Script, "CLUSTER", lib.GetClusterName(), 1)
-
-	vsNode.HTTPDSrefs = append(vsNode.HTTPDSrefs, dsScriptNode)
-	o.AddModelNode(dsScriptNode)
 	return dsScriptNode
 }
 
@@ -0,0 +1,407 @@
+/*
+ * Copyright © 2025 Broadcom Inc. and/or its subsidiaries. All Rights Reserved.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package nodes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vmware/alb-sdk/go/models"
+	"google.golang.org/protobuf/proto"
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/status"
+	akov1beta1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1beta1"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// ValidateHTTPRuleCanary checks that the canary of an HTTPRule path is either weighted, or selected by a header
+// and/or a cookie match.
+func ValidateHTTPRuleCanary(canary *akov1beta1.HTTPRuleCanary) error {
+	if canary.Service == "" {
+		return fmt.Errorf("canary service is not specified")
+	}
+	if canary.Port < 1 || canary.Port > 65535 {
+		return fmt.Errorf("canary port %d of service %s is not a valid port", canary.Port, canary.Service)
+	}
+	hasMatch := canary.Header != nil || canary.Cookie != nil
+	if canary.Weight == nil && !hasMatch {
+		return fmt.Errorf("canary service %s requires either a weight or a header/cookie match", canary.Service)
+	}
+	if canary.Weight != nil && hasMatch {
+		return fmt.Errorf("canary service %s cannot have both a weight and a header/cookie match", canary.Service)
+	}
+	if canary.Weight != nil && (*canary.Weight < 0 || *canary.Weight > 100) {
+		return fmt.Errorf("canary weight %d of service %s is not in the range 0-100", *canary.Weight, canary.Service)
+	}
+	for _, match := range []*akov1beta1.HTTPRuleCanaryMatch{canary.Header, canary.Cookie} {
+		if match != nil && (match.Name == "" || match.Value == "") {
+			return fmt.Errorf("canary header/cookie match of service %s requires a name and a value", canary.Service)
+		}
+	}
+	return nil
+}
+
+// buildHTTPRuleCanary attaches the canary services of the HTTPRule paths of the host to the paths of the Ingress/Route.
+// A weighted canary is added to the pool group of the path, and shares the requests with the pools of the path as per
+// the weight. A canary matched on header and/or cookie is selected by the switching rules of an HTTPPolicySet, which
+// is evaluated ahead of the HTTPPolicySet of the paths.
+func buildHTTPRuleCanary(host, ingName, namespace, infraSettingName, key string, vsNode AviVsEvhSniModel, isDedicated bool, pathRules map[string]string, httpruleNameObjMap map[string]akov1beta1.HTTPRulePaths) {
+	// the canary pools of the Ingress/Route are built from scratch every time, which restores the pool groups and
+	// the HTTPPolicySet of the host as they were before the canaries were attached.
+	removeHTTPRuleCanaryPools(vsNode, ingName, namespace, nil)
+
+	var requestRules []*models.HTTPRequestRule
+	var canaryPaths []string
+	var updatedRules []string
+	for path, rule := range pathRules {
+		httpRulePath, ok := httpruleNameObjMap[rule+path]
+		if !ok || httpRulePath.Canary == nil {
+			continue
+		}
+		canary := httpRulePath.Canary
+		var pathPools []*AviPoolNode
+		for _, pool := range vsNode.GetPoolRefs() {
+			if isHTTPRuleCanaryPathPool(vsNode, pool, ingName, namespace, path) {
+				pathPools = append(pathPools, pool)
+			}
+		}
+		canaryStatus := &akov1beta1.HTTPRuleCanaryStatus{Target: path, Service: canary.Service}
+		if len(pathPools) == 0 {
+			// the path is not served by the Ingress/Route
+			canaryStatus = nil
+		} else {
+			canaryPool, reason := buildHTTPRuleCanaryPool(host, ingName, namespace, infraSettingName, key, pathPools[0], canary, isDedicated)
+			if canaryPool != nil && canary.Weight != nil {
+				if split, found := addHTTPRuleCanaryToPG(vsNode, pathPools, canaryPool, *canary.Weight); found {
+					canaryStatus.Split = split
+				} else {
+					canaryPool, reason = nil, "weighted canary requires a pool group for the path"
+				}
+			} else if canaryPool != nil {
+				requestRules = append(requestRules, buildHTTPRuleCanaryRequestRule(pathPools[0].AviMarkers.Path[0], canary, canaryPool.Name))
+				canaryStatus.Split = buildHTTPRuleCanaryMatchSplit(canary)
+			}
+			if canaryPool != nil {
+				vsNode.SetPoolRefs(append(vsNode.GetPoolRefs(), canaryPool))
+				canaryPaths = append(canaryPaths, path)
+				utils.AviLog.Infof("key: %s, msg: Attached canary pool %s for service %s on path %s of vs %s", key, canaryPool.Name, canary.Service, path, vsNode.GetName())
+			} else {
+				utils.AviLog.Warnf("key: %s, msg: canary service %s not attached to path %s of host %s, %s", key, canary.Service, path, host, reason)
+				canaryStatus.Reason = reason
+			}
+		}
+		if objects.SharedCRDLister().UpdateHTTPRuleCanaryStatus(rule+path, namespace+"/"+ingName, canaryStatus) && !utils.HasElem(updatedRules, rule) {
+			updatedRules = append(updatedRules, rule)
+		}
+	}
+	for _, rule := range updatedRules {
+		publishHTTPRuleCanaryStatus(key, rule)
+	}
+	if len(requestRules) == 0 {
+		return
+	}
+
+	policyName := lib.GetHTTPRuleCanaryPolicy(vsNode.GetName())
+	policy := &AviHttpPolicySetNode{Name: policyName, Tenant: vsNode.GetTenant()}
+	var httpPolicyRefs []*AviHttpPolicySetNode
+	for _, httpPolicyRef := range vsNode.GetHttpPolicyRefs() {
+		if httpPolicyRef.Name == policyName {
+			// the switching rules of the other Ingresses/Routes of the host
+			requestRules = append(requestRules, httpPolicyRef.RequestRules...)
+			continue
+		}
+		httpPolicyRefs = append(httpPolicyRefs, httpPolicyRef)
+	}
+	setHTTPRuleCanaryRequestRules(policy, requestRules)
+	policy.AviMarkers = lib.PopulateHTTPPolicysetNodeMarkers(namespace, host, infraSettingName, nil, canaryPaths)
+	// the canary switching rules must be evaluated before the switching rules of the paths.
+	vsNode.SetHttpPolicyRefs(append([]*AviHttpPolicySetNode{policy}, httpPolicyRefs...))
+}
+
+// removeHTTPRuleCanaryPools removes the canary pools of the Ingress/Route for the given paths, or for all the paths
+// when none are given, along with their pool group members and switching rules. The pools which shared the requests
+// with a weighted canary get their ratio back.
+func removeHTTPRuleCanaryPools(vsNode AviVsEvhSniModel, ingName, namespace string, paths []string) {
+	if paths != nil {
+		// the canary status of the removed paths no longer applies
+		objName := namespace + "/" + ingName
+		for _, rule := range objects.SharedCRDLister().DeleteHTTPRuleCanaryStatusOfPaths(objName, paths) {
+			publishHTTPRuleCanaryStatus(objName, rule)
+		}
+	}
+	var canaryRefs []string
+	var poolNodes []*AviPoolNode
+	poolRatios := make(map[string]uint32)
+	for _, pool := range vsNode.GetPoolRefs() {
+		poolRef := fmt.Sprintf("/api/pool?name=%s", pool.Name)
+		if pool.HTTPRuleCanary && pool.AviMarkers.Namespace == namespace && utils.HasElem(pool.AviMarkers.IngressName, ingName) &&
+			(paths == nil || (len(pool.AviMarkers.Path) > 0 && utils.HasElem(paths, pool.AviMarkers.Path[0]))) {
+			canaryRefs = append(canaryRefs, poolRef)
+			continue
+		}
+		poolRatios[poolRef] = pool.ServiceMetadata.PoolRatio
+		poolNodes = append(poolNodes, pool)
+	}
+	if len(canaryRefs) == 0 {
+		return
+	}
+	vsNode.SetPoolRefs(poolNodes)
+
+	for _, pgNode := range vsNode.GetPoolGroupRefs() {
+		var members []*models.PoolGroupMember
+		for _, member := range pgNode.Members {
+			if !utils.HasElem(canaryRefs, *member.PoolRef) {
+				members = append(members, member)
+			}
+		}
+		if len(members) == len(pgNode.Members) {
+			continue
+		}
+		for _, member := range members {
+			if ratio, ok := poolRatios[*member.PoolRef]; ok {
+				member.Ratio = &ratio
+			}
+		}
+		pgNode.Members = members
+	}
+
+	policyName := lib.GetHTTPRuleCanaryPolicy(vsNode.GetName())
+	var httpPolicyRefs []*AviHttpPolicySetNode
+	for _, httpPolicyRef := range vsNode.GetHttpPolicyRefs() {
+		if httpPolicyRef.Name != policyName {
+			httpPolicyRefs = append(httpPolicyRefs, httpPolicyRef)
+			continue
+		}
+		var requestRules []*models.HTTPRequestRule
+		for _, rule := range httpPolicyRef.RequestRules {
+			poolRef := "/api/pool?name=" + getHTTPRuleCanaryRulePool(rule)
+			if !utils.HasElem(canaryRefs, poolRef) {
+				requestRules = append(requestRules, rule)
+			}
+		}
+		if len(requestRules) > 0 {
+			setHTTPRuleCanaryRequestRules(httpPolicyRef, requestRules)
+			httpPolicyRefs = append(httpPolicyRefs, httpPolicyRef)
+		}
+	}
+	vsNode.SetHttpPolicyRefs(httpPolicyRefs)
+}
+
+// isHTTPRuleCanaryPathPool returns true if the pool serves the path of the Ingress/Route targeted by the HTTPRule.
+// Unlike the other HTTPRule path settings, the canary applies to the exact path only.
+func isHTTPRuleCanaryPathPool(vsNode AviVsEvhSniModel, pool *AviPoolNode, ingName, namespace, path string) bool {
+	if pool.HTTPRuleCanary || pool.AviMarkers.Namespace != namespace || !utils.HasElem(pool.AviMarkers.IngressName, ingName) ||
+		len(pool.AviMarkers.Path) == 0 || isDefaultBackendPool(vsNode, pool.Name) {
+		return false
+	}
+	poolPath := pool.AviMarkers.Path[0]
+	// the path of an openshift Route can be empty, which is targeted by the HTTPRule path /.
+	return poolPath == path || (poolPath == "" && path == "/")
+}
+
+// buildHTTPRuleCanaryPool builds the pool of the canary service for the path, or returns the reason it can not be built.
+func buildHTTPRuleCanaryPool(host, ingName, namespace, infraSettingName, key string, pathPool *AviPoolNode, canary *akov1beta1.HTTPRuleCanary, isDedicated bool) (*AviPoolNode, string) {
+	if _, err := utils.GetInformers().ServiceInformer.Lister().Services(namespace).Get(canary.Service); err != nil {
+		return nil, fmt.Sprintf("canary service %s not found", canary.Service)
+	}
+	validator := NewNodesValidator()
+	obj := IngressHostPathSvc{
+		Path:        pathPool.AviMarkers.Path[0],
+		PathType:    networkingv1.PathTypePrefix,
+		ServiceName: canary.Service,
+		Port:        canary.Port,
+		PortName:    validator.findPortName(canary.Service, namespace, canary.Port, key),
+		TargetPort:  validator.findTargetPort(canary.Service, namespace, &networkingv1.ServiceBackendPort{Number: canary.Port}, key),
+	}
+	poolName := lib.GetHTTPRuleCanaryPoolName(ingName, namespace, host, obj.Path, infraSettingName, canary.Service, isDedicated)
+	if lib.CheckObjectNameLength(poolName, lib.Pool) {
+		return nil, fmt.Sprintf("name of the canary pool %s exceeds the maximum length", poolName)
+	}
+	poolNode := buildPoolNode(key, poolName, ingName, namespace, "", host, nil, canary.Service, []string{host}, false, obj)
+	// the placement of the canary pool follows the pools of the path, which carry the AviInfraSetting settings.
+	poolNode.Tenant = pathPool.Tenant
+	poolNode.NetworkPlacementSettings = pathPool.NetworkPlacementSettings
+	poolNode.VrfContext = pathPool.VrfContext
+	poolNode.T1Lr = pathPool.T1Lr
+	poolNode.AviMarkers = lib.PopulatePoolNodeMarkers(namespace, host, infraSettingName, canary.Service, []string{ingName}, []string{obj.Path})
+	poolNode.HTTPRuleCanary = true
+	return poolNode, ""
+}
+
+// addHTTPRuleCanaryToPG adds the canary pool to the pool groups of the path, the canary gets the weight percent of the
+// requests and the pools of the path share the rest as per their ratio. The effective split of the requests is returned.
+func addHTTPRuleCanaryToPG(vsNode AviVsEvhSniModel, pathPools []*AviPoolNode, canaryPool *AviPoolNode, weight int32) (string, bool) {
+	poolRatios := make(map[string]uint32)
+	for _, pool := range pathPools {
+		poolRatios[fmt.Sprintf("/api/pool?name=%s", pool.Name)] = pool.ServiceMetadata.PoolRatio
+	}
+	split := ""
+	for _, pgNode := range vsNode.GetPoolGroupRefs() {
+		var total uint32
+		for _, member := range pgNode.Members {
+			total += poolRatios[*member.PoolRef]
+		}
+		if total == 0 {
+			continue
+		}
+		// the ratios are scaled up by the weight rather than divided by 100, so that small ratios are not truncated to 0
+		ratios := make([]uint32, 0, len(pgNode.Members)+1)
+		for _, member := range pgNode.Members {
+			ratios = append(ratios, poolRatios[*member.PoolRef]*uint32(100-weight))
+		}
+		ratios = append(ratios, total*uint32(weight))
+		ratios = normalizePoolGroupRatios(ratios)
+		for i, member := range pgNode.Members {
+			member.Ratio = &ratios[i]
+		}
+		canaryRef := fmt.Sprintf("/api/pool?name=%s", canaryPool.Name)
+		canaryRatio := ratios[len(ratios)-1]
+		pgNode.Members = append(pgNode.Members, &models.PoolGroupMember{PoolRef: &canaryRef, Ratio: &canaryRatio})
+		if split == "" {
+			var sum uint32
+			for _, ratio := range ratios {
+				sum += ratio
+			}
+			canaryPercent := (canaryRatio*100 + sum/2) / sum
+			split = fmt.Sprintf("%d%% primary, %d%% canary", 100-canaryPercent, canaryPercent)
+		}
+	}
+	return split, split != ""
+}
+
+// normalizePoolGroupRatios divides the ratios scaled up by 100 back when none of them gets truncated, and scales them
+// down to the range accepted for the pool group members, without turning a non-zero ratio into 0.
+func normalizePoolGroupRatios(ratios []uint32) []uint32 {
+	exact := true
+	for _, ratio := range ratios {
+		exact = exact && ratio%100 == 0
+	}
+	var maxRatio uint32
+	for i := range ratios {
+		if exact {
+			ratios[i] /= 100
+		}
+		maxRatio = max(maxRatio, ratios[i])
+	}
+	if maxRatio <= lib.MaxPoolGroupMemberRatio {
+		return ratios
+	}
+	for i, ratio := range ratios {
+		if ratio != 0 {
+			ratios[i] = max(uint32(uint64(ratio)*lib.MaxPoolGroupMemberRatio/uint64(maxRatio)), 1)
+		}
+	}
+	return ratios
+}
+
+// buildHTTPRuleCanaryMatchSplit describes the split of the requests of a canary matched on header and/or cookie.
+func buildHTTPRuleCanaryMatchSplit(canary *akov1beta1.HTTPRuleCanary) string {
+	var matches []string
+	if canary.Header != nil {
+		matches = append(matches, fmt.Sprintf("header %s=%s", canary.Header.Name, canary.Header.Value))
+	}
+	if canary.Cookie != nil {
+		matches = append(matches, fmt.Sprintf("cookie %s=%s", canary.Cookie.Name, canary.Cookie.Value))
+	}
+	return fmt.Sprintf("canary for requests with %s, primary otherwise", strings.Join(matches, " and "))
+}
+
+// publishHTTPRuleCanaryStatus queues the status update of the HTTPRule, whose effective canary status of the paths
+// has changed, so that the status is patched by the leader.
+func publishHTTPRuleCanaryStatus(key, rule string) {
+	ruleNSName := strings.Split(rule, "/")
+	statusOption := status.StatusOptions{
+		ObjType:   lib.HTTPRule,
+		Op:        lib.UpdateStatus,
+		ObjName:   ruleNSName[1],
+		Namespace: ruleNSName[0],
+		Key:       key,
+	}
+	status.PublishToStatusQueue(rule, statusOption)
+}
+
+func buildHTTPRuleCanaryRequestRule(path string, canary *akov1beta1.HTTPRuleCanary, poolName string) *models.HTTPRequestRule {
+	match := &models.MatchTarget{}
+	if path != "" {
+		match.Path = &models.PathMatch{
+			MatchCriteria: proto.String("BEGINS_WITH"),
+			MatchCase:     proto.String("SENSITIVE"),
+			MatchStr:      []string{path},
+		}
+	}
+	if canary.Header != nil {
+		match.Hdrs = []*models.HdrMatch{
+			{
+				Hdr:           proto.String(canary.Header.Name),
+				MatchCriteria: proto.String("HDR_EQUALS"),
+				MatchCase:     proto.String("SENSITIVE"),
+				Value:         []string{canary.Header.Value},
+			},
+		}
+	}
+	if canary.Cookie != nil {
+		match.Cookie = &models.CookieMatch{
+			Name:          proto.String(canary.Cookie.Name),
+			MatchCriteria: proto.String("HDR_EQUALS"),
+			MatchCase:     proto.String("SENSITIVE"),
+			Value:         proto.String(canary.Cookie.Value),
+		}
+	}
+	return &models.HTTPRequestRule{
+		Enable: proto.Bool(true),
+		Match:  match,
+		SwitchingAction: &models.HttpswitchingAction{
+			Action:  proto.String("HTTP_SWITCHING_SELECT_POOL"),
+			PoolRef: proto.String(fmt.Sprintf("/api/pool/?name=%s", poolName)),
+		},
+	}
+}
+
+func getHTTPRuleCanaryRulePool(rule *models.HTTPRequestRule) string {
+	if rule.SwitchingAction == nil || rule.SwitchingAction.PoolRef == nil {
+		return ""
+	}
+	return strings.TrimPrefix(*rule.SwitchingAction.PoolRef, "/api/pool/?name=")
+}
+
+// setHTTPRuleCanaryRequestRules orders the switching rules so that the longer paths are matched first, and names
+// and indexes them as per the order.
+func setHTTPRuleCanaryRequestRules(policy *AviHttpPolicySetNode, requestRules []*models.HTTPRequestRule) {
+	rulePath := func(rule *models.HTTPRequestRule) string {
+		if rule.Match.Path == nil {
+			return ""
+		}
+		return rule.Match.Path.MatchStr[0]
+	}
+	sort.Slice(requestRules, func(i, j int) bool {
+		pathI, pathJ := rulePath(requestRules[i]), rulePath(requestRules[j])
+		if len(pathI) != len(pathJ) {
+			return len(pathI) > len(pathJ)
+		}
+		if pathI != pathJ {
+			return pathI < pathJ
+		}
+		return getHTTPRuleCanaryRulePool(requestRules[i]) < getHTTPRuleCanaryRulePool(requestRules[j])
+	})
+	for i, rule := range requestRules {
+		rule.Name = proto.String(fmt.Sprintf("%s-%d", policy.Name, i))
+		rule.Index = proto.Int32(int32(i + 1))
+	}
+	policy.RequestRules = requestRules
+}
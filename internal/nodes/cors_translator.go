@@ -0,0 +1,204 @@
+/*
+ * Copyright © 2025 Broadcom Inc. and/or its subsidiaries. All Rights Reserved.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package nodes
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/vmware/alb-sdk/go/models"
+	"google.golang.org/protobuf/proto"
+
+	akov1beta1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1beta1"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// CORSPolicy holds the cross-origin resource sharing settings of an HTTPRoute rule or an HTTPRule path,
+// from which AKO builds the preflight and response header rules of an HTTPPolicySet.
+type CORSPolicy struct {
+	AllowOrigins     []string
+	AllowMethods     []string
+	AllowHeaders     []string
+	ExposeHeaders    []string
+	AllowCredentials bool
+	MaxAge           int32
+}
+
+// NewCORSPolicyFromHTTPRule returns the CORS policy of an HTTPRule path.
+func NewCORSPolicyFromHTTPRule(cors *akov1beta1.HTTPRuleCORS) *CORSPolicy {
+	return &CORSPolicy{
+		AllowOrigins:     cors.AllowOrigins,
+		AllowMethods:     cors.AllowMethods,
+		AllowHeaders:     cors.AllowHeaders,
+		ExposeHeaders:    cors.ExposeHeaders,
+		AllowCredentials: cors.AllowCredentials,
+		MaxAge:           cors.MaxAge,
+	}
+}
+
+// Validate checks that the CORS policy can be expressed with HTTPPolicySet rules. The allowed origin is
+// echoed from a rule matching the exact origin, hence wildcard origins are supported only as "*", and
+// only when credentials are not allowed.
+func (c *CORSPolicy) Validate() error {
+	for _, origin := range c.AllowOrigins {
+		if origin == "*" {
+			continue
+		}
+		if strings.Contains(origin, "*") {
+			return fmt.Errorf("CORS origin %s with wildcard is not supported", origin)
+		}
+		originURL, err := url.Parse(origin)
+		if err != nil || originURL.Scheme == "" || originURL.Host == "" || (originURL.Path != "" && originURL.Path != "/") {
+			return fmt.Errorf("CORS origin %s is not a valid origin", origin)
+		}
+	}
+	if c.AllowCredentials {
+		for _, values := range [][]string{c.AllowOrigins, c.AllowMethods, c.AllowHeaders, c.ExposeHeaders} {
+			if utils.HasElem(values, "*") {
+				return fmt.Errorf("CORS wildcard value * is not supported when credentials are allowed")
+			}
+		}
+	}
+	return nil
+}
+
+// BuildCORSHTTPPolicyRules builds the HTTP request rule which answers the preflight requests with a local
+// response, and the HTTP response rules which add the CORS headers to the responses of the allowed origins.
+// The rules are restricted to the path and host matches when set, and are indexed starting from the given
+// request and response rule indices.
+func BuildCORSHTTPPolicyRules(ruleName string, cors *CORSPolicy, pathMatch *models.PathMatch, hostMatch *models.HostHdrMatch, requestIndex, responseIndex int32) ([]*models.HTTPRequestRule, []*models.HTTPResponseRule) {
+	if cors == nil || len(cors.AllowOrigins) == 0 {
+		return nil, nil
+	}
+	origins := cors.AllowOrigins
+	if utils.HasElem(origins, "*") {
+		origins = []string{"*"}
+	}
+	preflightMethod := &models.MethodMatch{
+		MatchCriteria: proto.String("IS_IN"),
+		Methods:       []string{"HTTP_METHOD_OPTIONS"},
+	}
+	preflightHdrs := []*models.HdrMatch{
+		buildCORSOriginMatch(origins),
+		{
+			Hdr:           proto.String("Access-Control-Request-Method"),
+			MatchCriteria: proto.String("HDR_EXISTS"),
+		},
+	}
+
+	requestRules := []*models.HTTPRequestRule{
+		{
+			Name:   proto.String(ruleName + "-preflight"),
+			Enable: proto.Bool(true),
+			Index:  proto.Int32(requestIndex),
+			Match: &models.MatchTarget{
+				Method:  preflightMethod,
+				Hdrs:    preflightHdrs,
+				Path:    pathMatch,
+				HostHdr: hostMatch,
+			},
+			SwitchingAction: &models.HttpswitchingAction{
+				Action:     proto.String("HTTP_SWITCHING_SELECT_LOCAL"),
+				StatusCode: proto.String("HTTP_LOCAL_RESPONSE_STATUS_CODE_204"),
+			},
+		},
+	}
+
+	var responseRules []*models.HTTPResponseRule
+	for i, origin := range origins {
+		var hdrActions []*models.HTTPHdrAction
+		hdrActions = append(hdrActions, buildCORSHdrAction("HTTP_REPLACE_HDR", "Access-Control-Allow-Origin", origin, len(hdrActions)))
+		if cors.AllowCredentials {
+			hdrActions = append(hdrActions, buildCORSHdrAction("HTTP_REPLACE_HDR", "Access-Control-Allow-Credentials", "true", len(hdrActions)))
+		}
+		if len(cors.ExposeHeaders) > 0 {
+			hdrActions = append(hdrActions, buildCORSHdrAction("HTTP_REPLACE_HDR", "Access-Control-Expose-Headers", strings.Join(cors.ExposeHeaders, ", "), len(hdrActions)))
+		}
+		if origin != "*" {
+			// the response varies with the origin of the request, caches must not serve it to other origins
+			hdrActions = append(hdrActions, buildCORSHdrAction("HTTP_ADD_HDR", "Vary", "Origin", len(hdrActions)))
+		}
+		responseRules = append(responseRules, &models.HTTPResponseRule{
+			Name:   proto.String(fmt.Sprintf("%s-origin-%d", ruleName, i)),
+			Enable: proto.Bool(true),
+			Index:  proto.Int32(responseIndex),
+			Match: &models.ResponseMatchTarget{
+				Hdrs:    []*models.HdrMatch{buildCORSOriginMatch([]string{origin})},
+				Path:    pathMatch,
+				HostHdr: hostMatch,
+			},
+			HdrAction: hdrActions,
+		})
+		responseIndex++
+	}
+
+	var preflightHdrActions []*models.HTTPHdrAction
+	if len(cors.AllowMethods) > 0 {
+		preflightHdrActions = append(preflightHdrActions, buildCORSHdrAction("HTTP_REPLACE_HDR", "Access-Control-Allow-Methods", strings.Join(cors.AllowMethods, ", "), len(preflightHdrActions)))
+	}
+	if len(cors.AllowHeaders) > 0 {
+		preflightHdrActions = append(preflightHdrActions, buildCORSHdrAction("HTTP_REPLACE_HDR", "Access-Control-Allow-Headers", strings.Join(cors.AllowHeaders, ", "), len(preflightHdrActions)))
+	}
+	if cors.MaxAge > 0 {
+		preflightHdrActions = append(preflightHdrActions, buildCORSHdrAction("HTTP_REPLACE_HDR", "Access-Control-Max-Age", strconv.Itoa(int(cors.MaxAge)), len(preflightHdrActions)))
+	}
+	if len(preflightHdrActions) > 0 {
+		responseRules = append(responseRules, &models.HTTPResponseRule{
+			Name:   proto.String(ruleName + "-preflight"),
+			Enable: proto.Bool(true),
+			Index:  proto.Int32(responseIndex),
+			Match: &models.ResponseMatchTarget{
+				Method:  preflightMethod,
+				Hdrs:    preflightHdrs,
+				Path:    pathMatch,
+				HostHdr: hostMatch,
+			},
+			HdrAction: preflightHdrActions,
+		})
+	}
+	return requestRules, responseRules
+}
+
+func buildCORSOriginMatch(origins []string) *models.HdrMatch {
+	if utils.HasElem(origins, "*") {
+		return &models.HdrMatch{
+			Hdr:           proto.String("Origin"),
+			MatchCriteria: proto.String("HDR_EXISTS"),
+		}
+	}
+	return &models.HdrMatch{
+		Hdr:           proto.String("Origin"),
+		MatchCriteria: proto.String("HDR_EQUALS"),
+		MatchCase:     proto.String("INSENSITIVE"),
+		Value:         origins,
+	}
+}
+
+func buildCORSHdrAction(action, name, value string, index int) *models.HTTPHdrAction {
+	hdrIndex := uint32(index)
+	return &models.HTTPHdrAction{
+		Action:   proto.String(action),
+		HdrIndex: &hdrIndex,
+		Hdr: &models.HTTPHdrData{
+			Name: proto.String(name),
+			Value: &models.HTTPHdrValue{
+				IsSensitive: proto.Bool(false),
+				Val:         proto.String(value),
+			},
+		},
+	}
+}
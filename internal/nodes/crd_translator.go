@@ -17,6 +17,7 @@ package nodes
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/jinzhu/copier"
@@ -416,12 +417,12 @@ func BuildRegexAppRootForHostRule(hostrule *akov1beta1.HostRule, vsNode AviVsEvh
 	}
 	if !lib.IsEvhEnabled() && hostrule.Spec.VirtualHost.UseRegex {
 		for _, pool := range vsNode.GetPoolRefs() {
-			if !lib.IsNameEncoded(pool.Name) {
+			if !lib.IsNameEncoded(pool.Name) && !isDefaultBackendPool(vsNode, pool.Name) {
 				pool.Name = lib.GetEncodedSniPGPoolNameforRegex(pool.Name)
 			}
 		}
 		for _, pg := range vsNode.GetPoolGroupRefs() {
-			if !lib.IsNameEncoded(pg.Name) {
+			if !lib.IsNameEncoded(pg.Name) && pg.Name != vsNode.GetDefaultPoolGroup() {
 				pg.Name = lib.GetEncodedSniPGPoolNameforRegex(pg.Name)
 				for _, member := range pg.Members {
 					poolName := strings.TrimPrefix(*member.PoolRef, "/api/pool?name=")
@@ -443,6 +444,11 @@ func BuildPoolHTTPRule(host, poolPath, ingName, namespace, infraSettingName, key
 	found, pathRules := objects.SharedCRDLister().GetFqdnHTTPRulesMapping(host)
 	if !found {
 		utils.AviLog.Debugf("key: %s, msg: HTTPRules for fqdn %s not found", key, host)
+		if isSNI {
+			buildHTTPRuleCORSPolicy(host, namespace, infraSettingName, key, vsNode, nil, nil)
+			buildHTTPRuleCanary(host, ingName, namespace, infraSettingName, key, vsNode, isDedicated, nil, nil)
+			buildHTTPRuleRateLimitPolicy(host, namespace, infraSettingName, key, vsNode, nil, nil)
+		}
 		return
 	}
 
@@ -475,6 +481,14 @@ func BuildPoolHTTPRule(host, poolPath, ingName, namespace, infraSettingName, key
 		}
 	}
 
+	// the CORS and the rate limit rules are added to the virtualservices dedicated to the host, i.e. the SNI/EVH child and dedicated virtualservices
+	if isSNI {
+		buildHTTPRuleCORSPolicy(host, namespace, infraSettingName, key, vsNode, pathRules, httpruleNameObjMap)
+		// the canary pools are built ahead of the other path settings, which apply to the canary pools as well
+		buildHTTPRuleCanary(host, ingName, namespace, infraSettingName, key, vsNode, isDedicated, pathRules, httpruleNameObjMap)
+		buildHTTPRuleRateLimitPolicy(host, namespace, infraSettingName, key, vsNode, pathRules, httpruleNameObjMap)
+	}
+
 	// iterate through httpRule which we get from GetFqdnHTTPRulesMapping
 	// must contain fqdn.com: {path1: rr1, path2: rr1, path3: rr2}
 	for path, rule := range pathRules {
@@ -559,6 +573,7 @@ func BuildPoolHTTPRule(host, poolPath, ingName, namespace, infraSettingName, key
 
 				pool.SniEnabled = isPathSniEnabled
 				pool.SslProfileRef = pathSslProfile
+				setExternalNameServerName(pool, pool.Servers)
 				pool.PkiProfileRef = pathPkiProfile
 				pool.PkiProfile = destinationCertNode
 				pool.HealthMonitorRefs = pathHMs
@@ -603,6 +618,60 @@ func BuildPoolHTTPRule(host, poolPath, ingName, namespace, infraSettingName, key
 
 }
 
+// buildHTTPRuleCORSPolicy rebuilds the HTTPPolicySet with the CORS rules of the HTTPRule paths of the host,
+// the HTTPPolicySet is removed from the virtualservice when none of the paths has CORS settings.
+func buildHTTPRuleCORSPolicy(host, namespace, infraSettingName, key string, vsNode AviVsEvhSniModel, pathRules map[string]string, httpruleNameObjMap map[string]akov1beta1.HTTPRulePaths) {
+	policyName := lib.GetCORSPolicy(vsNode.GetName())
+	var corsPaths []string
+	corsPolicies := make(map[string]*akov1beta1.HTTPRuleCORS)
+	for path, rule := range pathRules {
+		httpRulePath, ok := httpruleNameObjMap[rule+path]
+		if !ok || httpRulePath.CORS == nil {
+			continue
+		}
+		corsPaths = append(corsPaths, path)
+		corsPolicies[path] = httpRulePath.CORS
+	}
+	// longer paths are matched first, so that the preflight requests are answered as per the most specific path
+	sort.Slice(corsPaths, func(i, j int) bool {
+		if len(corsPaths[i]) != len(corsPaths[j]) {
+			return len(corsPaths[i]) > len(corsPaths[j])
+		}
+		return corsPaths[i] < corsPaths[j]
+	})
+
+	policy := &AviHttpPolicySetNode{Name: policyName, Tenant: vsNode.GetTenant()}
+	var requestIndex, responseIndex int32 = 1, 1
+	for i, path := range corsPaths {
+		pathMatch := &models.PathMatch{
+			MatchCriteria: proto.String("BEGINS_WITH"),
+			MatchCase:     proto.String("SENSITIVE"),
+			MatchStr:      []string{path},
+		}
+		requestRules, responseRules := BuildCORSHTTPPolicyRules(fmt.Sprintf("%s-%d", policyName, i), NewCORSPolicyFromHTTPRule(corsPolicies[path]),
+			pathMatch, nil, requestIndex, responseIndex)
+		policy.RequestRules = append(policy.RequestRules, requestRules...)
+		policy.ResponseRules = append(policy.ResponseRules, responseRules...)
+		requestIndex += int32(len(requestRules))
+		responseIndex += int32(len(responseRules))
+	}
+
+	httpPolicyRefs := vsNode.GetHttpPolicyRefs()
+	for i, httpPolicyRef := range httpPolicyRefs {
+		if httpPolicyRef.Name == policyName {
+			httpPolicyRefs = append(httpPolicyRefs[:i], httpPolicyRefs[i+1:]...)
+			break
+		}
+	}
+	if len(policy.RequestRules) == 0 {
+		vsNode.SetHttpPolicyRefs(httpPolicyRefs)
+		return
+	}
+	policy.AviMarkers = lib.PopulateHTTPPolicysetNodeMarkers(namespace, host, infraSettingName, nil, corsPaths)
+	vsNode.SetHttpPolicyRefs(append(httpPolicyRefs, policy))
+	utils.AviLog.Infof("key: %s, msg: Attached CORS policy %s on vs %s for paths %v", key, policyName, vsNode.GetName(), corsPaths)
+}
+
 func BuildL7SSORule(host, key string, vsNode AviVsEvhSniModel) {
 	// use host to find out SSORule CRD if it exists
 	// The host that comes here will have a proper FQDN, either from the Ingress/Route (foo.com)
@@ -207,6 +207,7 @@ func SvcToRoute(svcName string, namespace string, key string) ([]string, bool) {
 		}
 	}
 	_, routes := objects.OshiftRouteSvcLister().IngressMappings(namespace).GetSvcToIng(svcName)
+	routes = appendHTTPRuleCanaryRoutes(routes, svcName, namespace, key)
 	utils.AviLog.Debugf("key: %s, msg: Routes retrieved %s", key, routes)
 	if len(routes) == 0 {
 		return nil, false
@@ -453,7 +454,7 @@ func SvcToIng(svcName string, namespace string, key string) ([]string, bool) {
 			if len(ingresses) == 0 {
 				objects.SharedSvcLister().IngressMappings(namespace).DeleteSvcToIngMapping(svcName)
 			}
-			return ingresses, true
+			return appendHTTPRuleCanaryIngresses(ingresses, svcName, namespace, key), true
 		}
 		return nil, false
 	}
@@ -470,12 +471,14 @@ func SvcToIng(svcName string, namespace string, key string) ([]string, bool) {
 	}
 
 	_, ingresses := objects.SharedSvcLister().IngressMappings(namespace).GetSvcToIng(svcName)
+	ingresses = appendHTTPRuleCanaryIngresses(ingresses, svcName, namespace, key)
 	if len(ingresses) == 0 {
 		return nil, false
 	}
 
 	// Check if the svc has the NPL Annotation. If not, annotate and exit without returning any ingress
-	if lib.AutoAnnotateNPLSvc() {
+	// ExternalName services have no pods to be annotated.
+	if lib.AutoAnnotateNPLSvc() && svc.Spec.Type != corev1.ServiceTypeExternalName {
 		if !status.CheckNPLSvcAnnotation(key, namespace, svcName) {
 			statusOption := status.StatusOptions{
 				ObjType:   lib.NPLService,
@@ -823,6 +826,75 @@ func HTTPRuleToIng(rrname string, namespace string, key string) ([]string, bool)
 	return allIngresses, true
 }
 
+// httpRuleCanarySvcToObjs returns the names of the Ingresses/Routes in the namespace, whose paths have the service
+// as the canary of an HTTPRule.
+func httpRuleCanarySvcToObjs(svcName, namespace, key string) []string {
+	var objNames []string
+	if lib.AKOControlConfig().CRDInformers() == nil || lib.AKOControlConfig().CRDInformers().HTTPRuleInformer == nil {
+		return objNames
+	}
+	httpRules, err := lib.AKOControlConfig().CRDInformers().HTTPRuleInformer.Lister().HTTPRules(namespace).List(labels.Everything())
+	if err != nil {
+		utils.AviLog.Warnf("key: %s, msg: error in listing HTTPRules: %v", key, err)
+		return objNames
+	}
+	for _, httpRule := range httpRules {
+		if httpRule.Status.Status != lib.StatusAccepted {
+			continue
+		}
+		for _, path := range httpRule.Spec.Paths {
+			if path.Canary == nil || path.Canary.Service != svcName {
+				continue
+			}
+			_, pathObjs := SharedHostNameLister().GetHostPathStore(httpRule.Spec.Fqdn)
+			for objPath, objs := range pathObjs {
+				if objPath != path.Target && !(objPath == "" && path.Target == "/") {
+					continue
+				}
+				for _, obj := range objs {
+					objNamespace, objName := utils.ExtractNamespaceObjectName(obj)
+					if objNamespace == namespace && !utils.HasElem(objNames, objName) {
+						objNames = append(objNames, objName)
+					}
+				}
+			}
+		}
+	}
+	return objNames
+}
+
+func appendHTTPRuleCanaryIngresses(ingresses []string, svcName, namespace, key string) []string {
+	if utils.GetInformers().IngressInformer == nil {
+		return ingresses
+	}
+	for _, ingName := range httpRuleCanarySvcToObjs(svcName, namespace, key) {
+		if utils.HasElem(ingresses, ingName) {
+			continue
+		}
+		if _, err := utils.GetInformers().IngressInformer.Lister().Ingresses(namespace).Get(ingName); err == nil {
+			utils.AviLog.Debugf("key: %s, msg: service %s is the canary of ingress %s", key, svcName, ingName)
+			ingresses = append(ingresses, ingName)
+		}
+	}
+	return ingresses
+}
+
+func appendHTTPRuleCanaryRoutes(routes []string, svcName, namespace, key string) []string {
+	if utils.GetInformers().RouteInformer == nil {
+		return routes
+	}
+	for _, routeName := range httpRuleCanarySvcToObjs(svcName, namespace, key) {
+		if utils.HasElem(routes, routeName) {
+			continue
+		}
+		if _, err := utils.GetInformers().RouteInformer.Lister().Routes(namespace).Get(routeName); err == nil {
+			utils.AviLog.Debugf("key: %s, msg: service %s is the canary of route %s", key, svcName, routeName)
+			routes = append(routes, routeName)
+		}
+	}
+	return routes
+}
+
 func AviSettingToIng(infraSettingName, namespace, key string) ([]string, bool) {
 	allIngresses := make([]string, 0)
 
@@ -979,6 +1051,9 @@ func parseServicesForIngress(ingSpec networkingv1.IngressSpec, key string) []str
 			}
 		}
 	}
+	if ingSpec.DefaultBackend != nil && ingSpec.DefaultBackend.Service != nil {
+		services = append(services, ingSpec.DefaultBackend.Service.Name)
+	}
 	utils.AviLog.Debugf("key: %s, msg: total services retrieved from corev1: %s", key, services)
 	return services
 }
@@ -0,0 +1,319 @@
+/*
+ * Copyright © 2025 Broadcom Inc. and/or its subsidiaries. All Rights Reserved.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package nodes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vmware/alb-sdk/go/models"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	akov1beta1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1beta1"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+const (
+	rateLimitKeyClientIP = "ClientIP"
+	rateLimitKeyHeader   = "Header"
+
+	rateLimitActionDrop            = "Drop"
+	rateLimitActionTooManyRequests = "TooManyRequests"
+	rateLimitActionRedirect        = "Redirect"
+
+	// maximum count, period and burst size of the Avi rate limiter
+	rateLimiterMax = 1000000000
+)
+
+// ValidateHTTPRuleRateLimit checks the rate limit of an HTTPRule path, the header key and the redirect action
+// require the header values and the redirect location respectively.
+func ValidateHTTPRuleRateLimit(rateLimit *akov1beta1.HTTPRuleRateLimit) error {
+	if rateLimit.Requests < 1 || rateLimit.Requests > rateLimiterMax {
+		return fmt.Errorf("rate limit requests %d is not in the range 1-%d", rateLimit.Requests, rateLimiterMax)
+	}
+	// the period defaults to 1 second when not set
+	if rateLimit.Period != 0 && (rateLimit.Period < 1 || rateLimit.Period > rateLimiterMax) {
+		return fmt.Errorf("rate limit period %d is not in the range 1-%d", rateLimit.Period, rateLimiterMax)
+	}
+	if rateLimit.Burst < 0 || rateLimit.Burst > rateLimiterMax {
+		return fmt.Errorf("rate limit burst %d is not in the range 0-%d", rateLimit.Burst, rateLimiterMax)
+	}
+
+	switch rateLimit.KeyBy {
+	case "", rateLimitKeyClientIP:
+		if rateLimit.Header != nil {
+			return fmt.Errorf("rate limit header is allowed only with keyBy %s", rateLimitKeyHeader)
+		}
+	case rateLimitKeyHeader:
+		if rateLimit.Header == nil || rateLimit.Header.Name == "" || len(rateLimit.Header.Values) == 0 {
+			return fmt.Errorf("rate limit with keyBy %s requires the header name and values", rateLimitKeyHeader)
+		}
+	default:
+		return fmt.Errorf("rate limit keyBy %s is not supported", rateLimit.KeyBy)
+	}
+
+	switch rateLimit.Action {
+	case "", rateLimitActionDrop, rateLimitActionTooManyRequests:
+		if rateLimit.Redirect != nil {
+			return fmt.Errorf("rate limit redirect is allowed only with action %s", rateLimitActionRedirect)
+		}
+	case rateLimitActionRedirect:
+		if rateLimit.Redirect == nil {
+			return fmt.Errorf("rate limit with action %s requires the redirect settings", rateLimitActionRedirect)
+		}
+		redirect := rateLimit.Redirect
+		if redirect.Protocol != "" && redirect.Protocol != "HTTP" && redirect.Protocol != "HTTPS" {
+			return fmt.Errorf("rate limit redirect protocol %s is not supported", redirect.Protocol)
+		}
+		if redirect.Port < 0 || redirect.Port > 65535 {
+			return fmt.Errorf("rate limit redirect port %d is not a valid port", redirect.Port)
+		}
+		if redirect.Path != "" && !strings.HasPrefix(redirect.Path, "/") {
+			return fmt.Errorf("rate limit redirect path %s does not begin with /", redirect.Path)
+		}
+		if redirect.StatusCode != 0 && redirect.StatusCode != 301 && redirect.StatusCode != 302 && redirect.StatusCode != 307 {
+			return fmt.Errorf("rate limit redirect status code %d is not supported", redirect.StatusCode)
+		}
+	default:
+		return fmt.Errorf("rate limit action %s is not supported", rateLimit.Action)
+	}
+	return nil
+}
+
+// IsHTTPRuleRateLimitHostShared returns true if the host is served without TLS by the shared virtualservices, which
+// serve multiple FQDNs, so the rate limit can not be applied to the host. The host is served with TLS when it is in
+// the secure hostname store, or a HostRule sets its SSL key and certificate.
+func IsHTTPRuleRateLimitHostShared(key, fqdn string) bool {
+	if lib.IsEvhEnabled() || lib.GetshardSize() == 0 {
+		return false
+	}
+	if found, _ := SharedHostNameLister().GetHostPathStore(fqdn); !found {
+		return false
+	}
+	if found, ingressHostMap := SharedHostNameLister().Get(fqdn); found && len(ingressHostMap.HostNameMap) > 0 {
+		return false
+	}
+	if found, hostRuleObj := findHostRuleMappingForFqdn(key, fqdn); found {
+		if secure, _ := sslKeyCertHostRulePresent(hostRuleObj, key); secure {
+			return false
+		}
+	}
+	return true
+}
+
+// buildHTTPRuleRateLimitPolicy rebuilds the HTTPPolicySet with the rate limiting security rules of the HTTPRule
+// paths of the host, the HTTPPolicySet is removed from the virtualservice when none of the paths has a rate limit.
+func buildHTTPRuleRateLimitPolicy(host, namespace, infraSettingName, key string, vsNode AviVsEvhSniModel, pathRules map[string]string, httpruleNameObjMap map[string]akov1beta1.HTTPRulePaths) {
+	policyName := lib.GetHTTPRuleRateLimitPolicy(vsNode.GetName())
+	var rateLimitPaths []string
+	var securityRules []*models.HttpsecurityRule
+	for path, rule := range pathRules {
+		httpRulePath, ok := httpruleNameObjMap[rule+path]
+		if !ok || httpRulePath.RateLimit == nil || !isHTTPRuleRateLimitPathServed(vsNode, path) {
+			continue
+		}
+		rateLimitPaths = append(rateLimitPaths, path)
+		securityRules = append(securityRules, buildHTTPRuleRateLimitRules(path, httpRulePath.RateLimit)...)
+	}
+
+	httpPolicyRefs := vsNode.GetHttpPolicyRefs()
+	for i, httpPolicyRef := range httpPolicyRefs {
+		if httpPolicyRef.Name == policyName {
+			httpPolicyRefs = append(httpPolicyRefs[:i], httpPolicyRefs[i+1:]...)
+			break
+		}
+	}
+	if len(securityRules) == 0 {
+		vsNode.SetHttpPolicyRefs(httpPolicyRefs)
+		return
+	}
+	policy := &AviHttpPolicySetNode{Name: policyName, Tenant: vsNode.GetTenant()}
+	setHTTPRuleRateLimitRules(policy, securityRules)
+	policy.AviMarkers = lib.PopulateHTTPPolicysetNodeMarkers(namespace, host, infraSettingName, nil, rateLimitPaths)
+	vsNode.SetHttpPolicyRefs(append(httpPolicyRefs, policy))
+	utils.AviLog.Infof("key: %s, msg: Attached rate limit policy %s on vs %s for paths %v", key, policyName, vsNode.GetName(), rateLimitPaths)
+}
+
+// removeHTTPRuleRateLimitRules removes the rate limiting security rules of the paths which are no longer served by
+// the virtualservice, and the HTTPPolicySet when none of the rules remain.
+func removeHTTPRuleRateLimitRules(vsNode AviVsEvhSniModel) {
+	policyName := lib.GetHTTPRuleRateLimitPolicy(vsNode.GetName())
+	var httpPolicyRefs []*AviHttpPolicySetNode
+	for _, httpPolicyRef := range vsNode.GetHttpPolicyRefs() {
+		if httpPolicyRef.Name != policyName {
+			httpPolicyRefs = append(httpPolicyRefs, httpPolicyRef)
+			continue
+		}
+		var securityRules []*models.HttpsecurityRule
+		for _, rule := range httpPolicyRef.HTTPSecurityRules {
+			if isHTTPRuleRateLimitPathServed(vsNode, rule.Match.Path.MatchStr[0]) {
+				securityRules = append(securityRules, rule)
+			}
+		}
+		if len(securityRules) > 0 {
+			setHTTPRuleRateLimitRules(httpPolicyRef, securityRules)
+			httpPolicyRefs = append(httpPolicyRefs, httpPolicyRef)
+		}
+	}
+	vsNode.SetHttpPolicyRefs(httpPolicyRefs)
+}
+
+// isHTTPRuleRateLimitPathServed returns true if any of the pools of the virtualservice serves the HTTPRule path,
+// the HTTPRule path applies to the paths of the Ingresses/Routes which begin with it.
+func isHTTPRuleRateLimitPathServed(vsNode AviVsEvhSniModel, path string) bool {
+	for _, pool := range vsNode.GetPoolRefs() {
+		if len(pool.AviMarkers.Path) == 0 {
+			continue
+		}
+		poolPath := pool.AviMarkers.Path[0]
+		// the path of an openshift Route can be empty, which is targeted by the HTTPRule path /.
+		if strings.HasPrefix(poolPath, path) || (poolPath == "" && path == "/") {
+			return true
+		}
+	}
+	return false
+}
+
+// buildHTTPRuleRateLimitRules returns the security rules limiting the requests of the path, the requests are limited
+// per client IP, or for each of the header values, in which case a rule is added for every value. The requests
+// without any of the header values are limited per client IP by a last rule, so that the limit can not be bypassed
+// by leaving out the header, or changing its value. The requests can not be keyed by arbitrary header values.
+func buildHTTPRuleRateLimitRules(path string, rateLimit *akov1beta1.HTTPRuleRateLimit) []*models.HttpsecurityRule {
+	buildRule := func(hdrs []*models.HdrMatch) *models.HttpsecurityRule {
+		period := rateLimit.Period
+		if period == 0 {
+			period = 1
+		}
+		rateProfile := &models.HttpsecurityActionRateProfile{
+			Action:      buildHTTPRuleRateLimiterAction(rateLimit),
+			PerClientIP: proto.Bool(len(hdrs) == 0),
+			RateLimiter: &models.RateLimiter{
+				Count:   proto.Uint32(uint32(rateLimit.Requests)),
+				Period:  proto.Uint32(uint32(period)),
+				BurstSz: proto.Uint32(uint32(rateLimit.Burst)),
+			},
+		}
+		return &models.HttpsecurityRule{
+			Enable: proto.Bool(true),
+			Match: &models.MatchTarget{
+				Path: &models.PathMatch{
+					MatchCriteria: proto.String("BEGINS_WITH"),
+					MatchCase:     proto.String("SENSITIVE"),
+					MatchStr:      []string{path},
+				},
+				Hdrs: hdrs,
+			},
+			Action: &models.HttpsecurityAction{
+				Action:      proto.String("HTTP_SECURITY_ACTION_RATE_LIMIT"),
+				RateProfile: rateProfile,
+			},
+		}
+	}
+
+	if rateLimit.KeyBy != rateLimitKeyHeader {
+		return []*models.HttpsecurityRule{buildRule(nil)}
+	}
+	var rules []*models.HttpsecurityRule
+	for _, value := range rateLimit.Header.Values {
+		rules = append(rules, buildRule([]*models.HdrMatch{
+			{
+				Hdr:           proto.String(rateLimit.Header.Name),
+				MatchCriteria: proto.String("HDR_EQUALS"),
+				MatchCase:     proto.String("SENSITIVE"),
+				Value:         []string{value},
+			},
+		}))
+	}
+	return append(rules, buildRule(nil))
+}
+
+func buildHTTPRuleRateLimiterAction(rateLimit *akov1beta1.HTTPRuleRateLimit) *models.RateLimiterAction {
+	switch rateLimit.Action {
+	case rateLimitActionTooManyRequests:
+		return &models.RateLimiterAction{
+			Type:       proto.String("RL_ACTION_LOCAL_RSP"),
+			StatusCode: proto.String("HTTP_LOCAL_RESPONSE_STATUS_CODE_429"),
+		}
+	case rateLimitActionRedirect:
+		redirect := rateLimit.Redirect
+		protocol := redirect.Protocol
+		if protocol == "" {
+			protocol = "HTTPS"
+		}
+		statusCode := redirect.StatusCode
+		if statusCode == 0 {
+			statusCode = 302
+		}
+		redirectAction := &models.HTTPRedirectAction{
+			Protocol:   proto.String(protocol),
+			StatusCode: proto.String(fmt.Sprintf("HTTP_REDIRECT_STATUS_CODE_%d", statusCode)),
+			KeepQuery:  proto.Bool(true),
+		}
+		if redirect.Host != "" {
+			redirectAction.Host = &models.URIParam{
+				Type:   proto.String("URI_PARAM_TYPE_TOKENIZED"),
+				Tokens: []*models.URIParamToken{{Type: proto.String("URI_TOKEN_TYPE_STRING"), StrValue: proto.String(redirect.Host)}},
+			}
+		}
+		if redirect.Port != 0 {
+			redirectAction.Port = proto.Uint32(uint32(redirect.Port))
+		}
+		if redirect.Path != "" {
+			redirectAction.Path = &models.URIParam{
+				Type:   proto.String("URI_PARAM_TYPE_TOKENIZED"),
+				Tokens: []*models.URIParamToken{{Type: proto.String("URI_TOKEN_TYPE_STRING"), StrValue: proto.String(redirect.Path)}},
+			}
+		}
+		return &models.RateLimiterAction{
+			Type:     proto.String("RL_ACTION_REDIRECT"),
+			Redirect: redirectAction,
+		}
+	default:
+		return &models.RateLimiterAction{Type: proto.String("RL_ACTION_DROP_CONN")}
+	}
+}
+
+// setHTTPRuleRateLimitRules orders the security rules so that the longer paths are matched first, and names and
+// indexes them as per the order.
+func setHTTPRuleRateLimitRules(policy *AviHttpPolicySetNode, securityRules []*models.HttpsecurityRule) {
+	ruleHdrValue := func(rule *models.HttpsecurityRule) string {
+		if len(rule.Match.Hdrs) == 0 {
+			return ""
+		}
+		return rule.Match.Hdrs[0].Value[0]
+	}
+	sort.SliceStable(securityRules, func(i, j int) bool {
+		pathI, pathJ := securityRules[i].Match.Path.MatchStr[0], securityRules[j].Match.Path.MatchStr[0]
+		if len(pathI) != len(pathJ) {
+			return len(pathI) > len(pathJ)
+		}
+		if pathI != pathJ {
+			return pathI < pathJ
+		}
+		// the rule limiting the requests per client IP follows the rules of the header values of the path
+		hdrI, hdrJ := len(securityRules[i].Match.Hdrs) > 0, len(securityRules[j].Match.Hdrs) > 0
+		if hdrI != hdrJ {
+			return hdrI
+		}
+		return ruleHdrValue(securityRules[i]) < ruleHdrValue(securityRules[j])
+	})
+	for i, rule := range securityRules {
+		rule.Name = proto.String(fmt.Sprintf("%s-%d", policy.Name, i))
+		rule.Index = proto.Int32(int32(i + 1))
+	}
+	policy.HTTPSecurityRules = securityRules
+}
@@ -395,12 +395,39 @@ func (v *Validator) ParseHostPathForIngress(ns string, ingName string, ingSpec n
 		tlsConfigs = append(tlsConfigs, additionalTLS)
 	}
 
+	if ingSpec.DefaultBackend != nil {
+		ingressConfig.DefaultBackend = v.parseDefaultBackend(ns, ingName, ingSpec.DefaultBackend, key)
+	}
 	ingressConfig.TlsCollection = tlsConfigs
 	ingressConfig.IngressHostMap = hostMap
 	utils.AviLog.Infof("key: %s, msg: host path config from ingress: %+v", key, utils.Stringify(ingressConfig))
 	return ingressConfig
 }
 
+// parseDefaultBackend translates the defaultBackend of an Ingress into a catch-all path, only Service backends are supported.
+func (v *Validator) parseDefaultBackend(ns, ingName string, backend *networkingv1.IngressBackend, key string) *IngressHostPathSvc {
+	if backend.Service == nil {
+		utils.AviLog.Warnf("key: %s, msg: only Service backends are supported as defaultBackend of ingress %s/%s", key, ns, ingName)
+		return nil
+	}
+	defaultBackend := &IngressHostPathSvc{
+		Path:        "/",
+		PathType:    networkingv1.PathTypePrefix,
+		ServiceName: backend.Service.Name,
+		Port:        backend.Service.Port.Number,
+		PortName:    backend.Service.Port.Name,
+		TargetPort:  v.findTargetPort(backend.Service.Name, ns, &backend.Service.Port, key),
+		weight:      100,
+	}
+	if defaultBackend.PortName == "" {
+		defaultBackend.PortName = v.findPortName(backend.Service.Name, ns, backend.Service.Port.Number, key)
+	}
+	if defaultBackend.Port == 0 {
+		defaultBackend.Port = 80
+	}
+	return defaultBackend
+}
+
 func (v *Validator) findTargetPort(serviceName, ns string, serviceBackendPort *networkingv1.ServiceBackendPort, key string) intstr.IntOrString {
 	// Query the service and obtain the targetPort
 	svcObj, err := utils.GetInformers().ServiceInformer.Lister().Services(ns).Get(serviceName)
@@ -19,6 +19,7 @@ import (
 	"sync"
 
 	akov1beta1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1beta1"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 )
 
 var CRDinstance *CRDLister
@@ -41,6 +42,7 @@ func SharedCRDLister() *CRDLister {
 			SSORuleFQDNCache:         NewObjectMapStore(),
 			L7RuleHostRuleCache:      NewObjectMapStore(),
 			HealthMonitorL4RuleCache: NewObjectMapStore(),
+			HTTPRuleCanaryCache:      NewObjectMapStore(),
 		}
 	})
 	return CRDinstance
@@ -92,6 +94,9 @@ type CRDLister struct {
 
 	// HealthMonitor : L4RuleCRD
 	HealthMonitorL4RuleCache *ObjectMapStore
+
+	// rr1/target: {ns/ing1: effective canary status of the path of ing1}
+	HTTPRuleCanaryCache *ObjectMapStore
 }
 
 // FqdnHostRuleCache
@@ -432,3 +437,75 @@ func (c *CRDLister) UpdateHealthMonitorToL4RuleMapping(healthMonitor string, l4R
 	l4RulesMap[l4Rule] = true
 	c.HealthMonitorL4RuleCache.AddOrUpdate(healthMonitor, l4RulesMap)
 }
+
+// HTTPRule path canary to the canary status of the Ingresses/Routes functions
+
+func (c *CRDLister) GetHTTPRuleCanaryStatus(httpRuleTarget string) map[string]akov1beta1.HTTPRuleCanaryStatus {
+	c.NSLock.RLock()
+	defer c.NSLock.RUnlock()
+	canaryStatus := make(map[string]akov1beta1.HTTPRuleCanaryStatus)
+	found, objStatus := c.HTTPRuleCanaryCache.Get(httpRuleTarget)
+	if found {
+		for objName, objCanaryStatus := range objStatus.(map[string]akov1beta1.HTTPRuleCanaryStatus) {
+			canaryStatus[objName] = objCanaryStatus
+		}
+	}
+	return canaryStatus
+}
+
+// UpdateHTTPRuleCanaryStatus records the canary status of the path of the Ingress/Route, or removes it when nil,
+// and returns true if the recorded status changed.
+func (c *CRDLister) UpdateHTTPRuleCanaryStatus(httpRuleTarget, objName string, canaryStatus *akov1beta1.HTTPRuleCanaryStatus) bool {
+	c.NSLock.Lock()
+	defer c.NSLock.Unlock()
+	found, objStatus := c.HTTPRuleCanaryCache.Get(httpRuleTarget)
+	objStatusMap := make(map[string]akov1beta1.HTTPRuleCanaryStatus)
+	if found {
+		objStatusMap = objStatus.(map[string]akov1beta1.HTTPRuleCanaryStatus)
+	}
+	oldStatus, ok := objStatusMap[objName]
+	if canaryStatus == nil {
+		if !ok {
+			return false
+		}
+		delete(objStatusMap, objName)
+		if len(objStatusMap) == 0 {
+			c.HTTPRuleCanaryCache.Delete(httpRuleTarget)
+		} else {
+			c.HTTPRuleCanaryCache.AddOrUpdate(httpRuleTarget, objStatusMap)
+		}
+		return true
+	}
+	if ok && oldStatus == *canaryStatus {
+		return false
+	}
+	objStatusMap[objName] = *canaryStatus
+	c.HTTPRuleCanaryCache.AddOrUpdate(httpRuleTarget, objStatusMap)
+	return true
+}
+
+// DeleteHTTPRuleCanaryStatusOfPaths removes the canary status of the paths of the Ingress/Route, and returns the
+// HTTPRules whose status changed.
+func (c *CRDLister) DeleteHTTPRuleCanaryStatusOfPaths(objName string, paths []string) []string {
+	c.NSLock.Lock()
+	defer c.NSLock.Unlock()
+	var httpRules []string
+	for httpRuleTarget, objStatus := range c.HTTPRuleCanaryCache.CopyAllObjects() {
+		objStatusMap := objStatus.(map[string]akov1beta1.HTTPRuleCanaryStatus)
+		canaryStatus, ok := objStatusMap[objName]
+		if !ok || !utils.HasElem(paths, canaryStatus.Target) {
+			continue
+		}
+		delete(objStatusMap, objName)
+		if len(objStatusMap) == 0 {
+			c.HTTPRuleCanaryCache.Delete(httpRuleTarget)
+		} else {
+			c.HTTPRuleCanaryCache.AddOrUpdate(httpRuleTarget, objStatusMap)
+		}
+		httpRule := strings.TrimSuffix(httpRuleTarget, canaryStatus.Target)
+		if !utils.HasElem(httpRules, httpRule) {
+			httpRules = append(httpRules, httpRule)
+		}
+	}
+	return httpRules
+}
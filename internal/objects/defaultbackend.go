@@ -0,0 +1,112 @@
+/*
+ * Copyright © 2025 Broadcom Inc. and/or its subsidiaries. All Rights Reserved.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package objects
+
+import (
+	"sync"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+var defaultbackendlister *DefaultBackendLister
+var defaultbackendonce sync.Once
+
+func SharedDefaultBackendLister() *DefaultBackendLister {
+	defaultbackendonce.Do(func() {
+		defaultbackendlister = &DefaultBackendLister{
+			IngressModelStore: NewObjectMapStore(),
+			ModelIngressStore: NewObjectMapStore(),
+		}
+	})
+	return defaultbackendlister
+}
+
+type DefaultBackendLister struct {
+	DefaultBackendLock sync.RWMutex
+
+	// namespaced ingress -> model name -> hostname of the dedicated VS, empty for shared VSes
+	IngressModelStore *ObjectMapStore
+
+	// model name -> namespaced ingresses declaring a defaultBackend on the VS
+	ModelIngressStore *ObjectMapStore
+}
+
+func (v *DefaultBackendLister) GetIngressToModels(ingNsName string) map[string]string {
+	v.DefaultBackendLock.RLock()
+	defer v.DefaultBackendLock.RUnlock()
+	found, models := v.IngressModelStore.Get(ingNsName)
+	if !found {
+		return make(map[string]string)
+	}
+	modelsCopy := make(map[string]string)
+	for modelName, hostname := range models.(map[string]string) {
+		modelsCopy[modelName] = hostname
+	}
+	return modelsCopy
+}
+
+func (v *DefaultBackendLister) GetModelToIngresses(modelName string) []string {
+	v.DefaultBackendLock.RLock()
+	defer v.DefaultBackendLock.RUnlock()
+	found, ingresses := v.ModelIngressStore.Get(modelName)
+	if !found {
+		return []string{}
+	}
+	return ingresses.([]string)
+}
+
+// AddIngressToModelMapping records that the Ingress declares its defaultBackend on the given model.
+func (v *DefaultBackendLister) AddIngressToModelMapping(ingNsName, modelName, hostname string) {
+	v.DefaultBackendLock.Lock()
+	defer v.DefaultBackendLock.Unlock()
+	models := make(map[string]string)
+	if found, obj := v.IngressModelStore.Get(ingNsName); found {
+		models = obj.(map[string]string)
+	}
+	models[modelName] = hostname
+	v.IngressModelStore.AddOrUpdate(ingNsName, models)
+
+	var ingresses []string
+	if found, obj := v.ModelIngressStore.Get(modelName); found {
+		ingresses = obj.([]string)
+	}
+	if !utils.HasElem(ingresses, ingNsName) {
+		ingresses = append(ingresses, ingNsName)
+	}
+	v.ModelIngressStore.AddOrUpdate(modelName, ingresses)
+}
+
+func (v *DefaultBackendLister) RemoveIngressToModelMapping(ingNsName, modelName string) {
+	v.DefaultBackendLock.Lock()
+	defer v.DefaultBackendLock.Unlock()
+	if found, obj := v.IngressModelStore.Get(ingNsName); found {
+		models := obj.(map[string]string)
+		delete(models, modelName)
+		if len(models) == 0 {
+			v.IngressModelStore.Delete(ingNsName)
+		} else {
+			v.IngressModelStore.AddOrUpdate(ingNsName, models)
+		}
+	}
+
+	if found, obj := v.ModelIngressStore.Get(modelName); found {
+		ingresses := utils.Remove(obj.([]string), ingNsName)
+		if len(ingresses) == 0 {
+			v.ModelIngressStore.Delete(modelName)
+		} else {
+			v.ModelIngressStore.AddOrUpdate(modelName, ingresses)
+		}
+	}
+}
@@ -36,10 +36,11 @@ func (rest *RestOperations) RestOperationForEvh(vsName string, namespace string,
 	var vsvip_to_delete []avicache.NamespaceName
 	var sni_to_delete []avicache.NamespaceName
 	var httppol_to_delete []avicache.NamespaceName
-	var l4pol_to_delete []avicache.NamespaceName
+	var ds_to_delete []avicache.NamespaceName
 	var sslkey_cert_delete []avicache.NamespaceName
 	var string_groups_to_delete []avicache.NamespaceName
-	var vsvipErr error
+	var app_profiles_to_delete []avicache.NamespaceName
+	var vsvipErr, appProfileErr error
 	var publishKey string
 
 	vsKey := avicache.NamespaceName{Namespace: namespace, Name: vsName}
@@ -55,6 +56,10 @@ func (rest *RestOperations) RestOperationForEvh(vsName string, namespace string,
 		}
 	}
 	nsPublishKey := avicache.NamespaceName{Namespace: namespace, Name: publishKey}
+	var l4ChildToDelete string
+	if vs_cache_obj != nil {
+		l4ChildToDelete = vs_cache_obj.ServiceMetadataObj.L4ChildRef
+	}
 	// Order would be this: 1. Pools 2. PGs  3. DS. 4. SSLKeyCert 5. VS
 	if vs_cache_obj != nil {
 		var rest_ops []*utils.RestOp
@@ -72,6 +77,13 @@ func (rest *RestOperations) RestOperationForEvh(vsName string, namespace string,
 		pgs_to_delete, rest_ops = rest.PoolGroupCU(aviVsNode.PoolGroupRefs, vs_cache_obj, namespace, rest_ops, key)
 		string_groups_to_delete, rest_ops = rest.StringGroupVsCU(aviVsNode.StringGroupRefs, vs_cache_obj, namespace, rest_ops, key)
 		httppol_to_delete, rest_ops = rest.HTTPPolicyCU(aviVsNode.HttpPolicyRefs, vs_cache_obj, namespace, rest_ops, key)
+		ds_to_delete, rest_ops = rest.DatascriptCU(aviVsNode.HTTPDSrefs, vs_cache_obj, namespace, rest_ops, key)
+		app_profiles_to_delete, rest_ops, appProfileErr = rest.ApplicationProfileCU(aviVsNode.ApplicationProfileRefs, vs_cache_obj, namespace, rest_ops, key)
+		if appProfileErr != nil {
+			utils.AviLog.Warnf("key: %s, msg: not processing VS %s, err: %v, adding to slow retry queue", key, vsName, appProfileErr)
+			rest.PublishKeyToSlowRetryLayer(nsPublishKey, key)
+			return
+		}
 		utils.AviLog.Debugf("key: %s, msg: stored checksum for VS: %s, model checksum: %s", key, vs_cache_obj.CloudConfigCksum, strconv.Itoa(int(aviVsNode.GetCheckSum())))
 		if vs_cache_obj.CloudConfigCksum == strconv.Itoa(int(aviVsNode.GetCheckSum())) {
 			utils.AviLog.Debugf("key: %s, msg: the checksums are same for vs %s, not doing anything", key, vs_cache_obj.Name)
@@ -101,6 +113,13 @@ func (rest *RestOperations) RestOperationForEvh(vsName string, namespace string,
 		_, rest_ops = rest.PoolGroupCU(aviVsNode.PoolGroupRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.StringGroupVsCU(aviVsNode.StringGroupRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.HTTPPolicyCU(aviVsNode.HttpPolicyRefs, nil, namespace, rest_ops, key)
+		_, rest_ops = rest.DatascriptCU(aviVsNode.HTTPDSrefs, nil, namespace, rest_ops, key)
+		_, rest_ops, appProfileErr = rest.ApplicationProfileCU(aviVsNode.ApplicationProfileRefs, nil, namespace, rest_ops, key)
+		if appProfileErr != nil {
+			utils.AviLog.Warnf("key: %s, msg: not processing VS %s, err: %v, adding to slow retry queue", key, vsName, appProfileErr)
+			rest.PublishKeyToSlowRetryLayer(nsPublishKey, key)
+			return
+		}
 
 		// The cache was not found - it's a POST call.
 		restOp := rest.AviVsBuildForEvh(aviVsNode, utils.RestPost, nil, key)
@@ -129,13 +148,37 @@ func (rest *RestOperations) RestOperationForEvh(vsName string, namespace string,
 	rest_ops = rest.VSVipDelete(vsvip_to_delete, namespace, rest_ops, key)
 	rest_ops = rest.HTTPPolicyDelete(httppol_to_delete, namespace, rest_ops, key)
 	rest_ops = rest.StringGroupDelete(string_groups_to_delete, namespace, rest_ops, key)
-	rest_ops = rest.L4PolicyDelete(l4pol_to_delete, namespace, rest_ops, key)
+	rest_ops = rest.DSDelete(ds_to_delete, namespace, rest_ops, key)
+	rest_ops = rest.ApplicationProfileDelete(app_profiles_to_delete, namespace, rest_ops, key)
 	rest_ops = rest.PoolGroupDelete(pgs_to_delete, namespace, rest_ops, key)
 	rest_ops = rest.PoolDelete(pools_to_delete, namespace, rest_ops, nil, key)
 	if success, _ := rest.ExecuteRestAndPopulateCache(rest_ops, vsKey, avimodel, key, true); !success {
 		return
 	}
 
+	// The L4 VSes are processed once the vsvip they share with the parent VS is created.
+	for _, l4ChildNode := range aviVsNode.L4ChildNodes {
+		var rest_ops []*utils.RestOp
+		utils.AviLog.Debugf("key: %s, msg: processing L4 child node: %s", key, l4ChildNode.Name)
+		if l4ChildNode.Name == l4ChildToDelete {
+			l4ChildToDelete = ""
+		}
+		l4ChildKey := avicache.NamespaceName{Namespace: namespace, Name: l4ChildNode.Name}
+		rest_ops = rest.L4ChildCU(l4ChildNode, rest.getVsCacheObj(l4ChildKey, key), namespace, rest_ops, key)
+		if success, _ := rest.ExecuteRestAndPopulateCache(rest_ops, l4ChildKey, avimodel, key, true); !success {
+			return
+		}
+	}
+	if l4ChildToDelete != "" {
+		utils.AviLog.Infof("key: %s, msg: deleting the L4 child %s", key, l4ChildToDelete)
+		l4ChildKey := avicache.NamespaceName{Namespace: namespace, Name: l4ChildToDelete}
+		if l4ChildCache := rest.getVsCacheObj(l4ChildKey, key); l4ChildCache != nil {
+			if success := rest.DeleteVSOper(l4ChildKey, l4ChildCache, namespace, key, false, true); !success {
+				return
+			}
+		}
+	}
+
 	for _, evhNode := range aviVsNode.EvhNodes {
 		utils.AviLog.Debugf("key: %s, msg: processing EVH node: %s", key, evhNode.Name)
 		utils.AviLog.Debugf("key: %s, msg: probable EVH delete candidates: %s", key, sni_to_delete)
@@ -169,12 +212,48 @@ func (rest *RestOperations) RestOperationForEvh(vsName string, namespace string,
 
 }
 
+// L4ChildCU creates or updates the L4 VS sharing the vsvip of the EVH parent VS, along with its pools, poolgroups
+// and L4 policysets.
+func (rest *RestOperations) L4ChildCU(l4ChildNode *nodes.AviVsNode, vsCacheObj *avicache.AviVsCache, namespace string, restOps []*utils.RestOp, key string) []*utils.RestOp {
+	var poolsToDelete, pgsToDelete, l4PoliciesToDelete []avicache.NamespaceName
+	if vsCacheObj != nil {
+		poolsToDelete, restOps = rest.PoolCU(l4ChildNode.PoolRefs, vsCacheObj, namespace, restOps, key)
+		pgsToDelete, restOps = rest.PoolGroupCU(l4ChildNode.PoolGroupRefs, vsCacheObj, namespace, restOps, key)
+		l4PoliciesToDelete, restOps = rest.L4PolicyCU(l4ChildNode.L4PolicyRefs, vsCacheObj, namespace, restOps, key)
+
+		// The checksums are different, so it should be a PUT call.
+		if vsCacheObj.CloudConfigCksum != strconv.Itoa(int(l4ChildNode.GetCheckSum())) {
+			restOp := rest.AviVsBuild(l4ChildNode, utils.RestPut, vsCacheObj, key)
+			if restOp != nil {
+				restOps = append(restOps, restOp...)
+			}
+			utils.AviLog.Debugf("key: %s, msg: the checksums are different for L4 child %s, operation: PUT", key, l4ChildNode.Name)
+		}
+		restOps = rest.L4PolicyDelete(l4PoliciesToDelete, namespace, restOps, key)
+		restOps = rest.PoolGroupDelete(pgsToDelete, namespace, restOps, key)
+		restOps = rest.PoolDelete(poolsToDelete, namespace, restOps, nil, key)
+	} else {
+		utils.AviLog.Infof("key: %s, msg: L4 child %s not found in cache", key, l4ChildNode.Name)
+		_, restOps = rest.PoolCU(l4ChildNode.PoolRefs, nil, namespace, restOps, key)
+		_, restOps = rest.PoolGroupCU(l4ChildNode.PoolGroupRefs, nil, namespace, restOps, key)
+		_, restOps = rest.L4PolicyCU(l4ChildNode.L4PolicyRefs, nil, namespace, restOps, key)
+
+		// Not found - it should be a POST call.
+		restOp := rest.AviVsBuild(l4ChildNode, utils.RestPost, nil, key)
+		if restOp != nil {
+			restOps = append(restOps, restOp...)
+		}
+	}
+	return restOps
+}
+
 func (rest *RestOperations) EvhNodeCU(sni_node *nodes.AviEvhVsNode, vs_cache_obj *avicache.AviVsCache, namespace string, cache_sni_nodes []avicache.NamespaceName, rest_ops []*utils.RestOp, key string) ([]avicache.NamespaceName, []*utils.RestOp) {
 	var sni_pools_to_delete []avicache.NamespaceName
 	var sni_pgs_to_delete []avicache.NamespaceName
 	var http_policies_to_delete []avicache.NamespaceName
 	var sslkey_cert_delete []avicache.NamespaceName
 	var string_groups_to_delete []avicache.NamespaceName
+	var traffic_clone_profiles_to_delete []avicache.NamespaceName
 	var sni_cache_obj *avicache.AviVsCache
 	if vs_cache_obj != nil {
 		sni_key := avicache.NamespaceName{Namespace: namespace, Name: sni_node.Name}
@@ -195,6 +274,7 @@ func (rest *RestOperations) EvhNodeCU(sni_node *nodes.AviEvhVsNode, vs_cache_obj
 				sni_pgs_to_delete, rest_ops = rest.PoolGroupCU(sni_node.PoolGroupRefs, sni_cache_obj, namespace, rest_ops, key)
 				string_groups_to_delete, rest_ops = rest.StringGroupVsCU(sni_node.StringGroupRefs, sni_cache_obj, namespace, rest_ops, key)
 				http_policies_to_delete, rest_ops = rest.HTTPPolicyCU(sni_node.HttpPolicyRefs, sni_cache_obj, namespace, rest_ops, key)
+				traffic_clone_profiles_to_delete, rest_ops = rest.TrafficCloneProfileCU(sni_node.TrafficCloneProfile, sni_cache_obj, namespace, rest_ops, key)
 
 				// The checksums are different, so it should be a PUT call.
 				if sni_cache_obj.CloudConfigCksum != strconv.Itoa(int(sni_node.GetCheckSum())) {
@@ -214,6 +294,7 @@ func (rest *RestOperations) EvhNodeCU(sni_node *nodes.AviEvhVsNode, vs_cache_obj
 			_, rest_ops = rest.PoolGroupCU(sni_node.PoolGroupRefs, nil, namespace, rest_ops, key)
 			_, rest_ops = rest.StringGroupVsCU(sni_node.StringGroupRefs, nil, namespace, rest_ops, key)
 			_, rest_ops = rest.HTTPPolicyCU(sni_node.HttpPolicyRefs, nil, namespace, rest_ops, key)
+			_, rest_ops = rest.TrafficCloneProfileCU(sni_node.TrafficCloneProfile, nil, namespace, rest_ops, key)
 
 			// Not found - it should be a POST call.
 			restOp := rest.AviVsBuildForEvh(sni_node, utils.RestPost, nil, key)
@@ -224,6 +305,7 @@ func (rest *RestOperations) EvhNodeCU(sni_node *nodes.AviEvhVsNode, vs_cache_obj
 		rest_ops = rest.SSLKeyCertDelete(sslkey_cert_delete, namespace, rest_ops, key)
 		rest_ops = rest.HTTPPolicyDelete(http_policies_to_delete, namespace, rest_ops, key)
 		rest_ops = rest.StringGroupDelete(string_groups_to_delete, namespace, rest_ops, key)
+		rest_ops = rest.TrafficCloneProfileDelete(traffic_clone_profiles_to_delete, namespace, rest_ops, key)
 		rest_ops = rest.PoolGroupDelete(sni_pgs_to_delete, namespace, rest_ops, key)
 		rest_ops = rest.PoolDelete(sni_pools_to_delete, namespace, rest_ops, sni_cache_obj, key)
 		utils.AviLog.Debugf("key: %s, msg: the EVH VSes to be deleted are: %s", key, cache_sni_nodes)
@@ -235,6 +317,7 @@ func (rest *RestOperations) EvhNodeCU(sni_node *nodes.AviEvhVsNode, vs_cache_obj
 		_, rest_ops = rest.PoolGroupCU(sni_node.PoolGroupRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.StringGroupVsCU(sni_node.StringGroupRefs, nil, namespace, rest_ops, key)
 		_, rest_ops = rest.HTTPPolicyCU(sni_node.HttpPolicyRefs, nil, namespace, rest_ops, key)
+		_, rest_ops = rest.TrafficCloneProfileCU(sni_node.TrafficCloneProfile, nil, namespace, rest_ops, key)
 
 		// Not found - it should be a POST call.
 		restOp := rest.AviVsBuildForEvh(sni_node, utils.RestPost, nil, key)
@@ -381,6 +464,15 @@ func (rest *RestOperations) AviVsBuildForEvh(vs_meta *nodes.AviEvhVsNode, rest_m
 		for i, pp := range vs_meta.PortProto {
 			port := uint32(pp.Port)
 			svc := avimodels.Service{Port: &port, EnableSsl: &vs_meta.PortProto[i].EnableSSL, EnableHttp2: &vs_meta.PortProto[i].EnableHTTP2}
+			// TLS listeners of a Gateway are served by the passthrough datascript, override the L7 profiles for these ports.
+			if pp.Protocol == utils.TLS {
+				svc.OverrideApplicationProfileRef = proto.String("/api/applicationprofile/?name=" + utils.DEFAULT_L4_APP_PROFILE)
+				svc.OverrideNetworkProfileRef = proto.String("/api/networkprofile/?name=" + utils.DEFAULT_TCP_NW_PROFILE)
+			}
+			// AKO managed application profile, e.g. enforcing client certificate validation for the port.
+			if pp.ApplicationProfile != "" {
+				svc.OverrideApplicationProfileRef = proto.String("/api/applicationprofile/?name=" + pp.ApplicationProfile)
+			}
 			vs.Services = append(vs.Services, &svc)
 		}
 
@@ -405,10 +497,15 @@ func (rest *RestOperations) AviVsBuildForEvh(vs_meta *nodes.AviEvhVsNode, rest_m
 		}
 		vs.HTTPPolicies = httpPolicyCollection
 
-		// Datascripts from hostrule.
 		var datascriptCollection []*avimodels.VSDataScripts
-		for i, script := range vs_meta.VsDatascriptRefs {
+		for i, ds := range vs_meta.HTTPDSrefs {
 			j := int32(i)
+			dsRef := "/api/vsdatascriptset/?name=" + ds.Name
+			datascriptCollection = append(datascriptCollection, &avimodels.VSDataScripts{Index: &j, VsDatascriptSetRef: &dsRef})
+		}
+		// Datascripts from hostrule.
+		for i, script := range vs_meta.VsDatascriptRefs {
+			j := int32(i + len(vs_meta.HTTPDSrefs))
 			datascript := script
 			datascripts := &avimodels.VSDataScripts{VsDatascriptSetRef: &datascript, Index: &j}
 			datascriptCollection = append(datascriptCollection, datascripts)
@@ -595,6 +692,12 @@ func (rest *RestOperations) AviVsChildEvhBuild(vs_meta *nodes.AviEvhVsNode, rest
 		pg_ref := "/api/poolgroup/?name=" + vs_meta.DefaultPoolGroup
 		evhChild.PoolGroupRef = &pg_ref
 	}
+
+	// TrafficCloneProfileRef set via L7Rule overrides this ref.
+	if vs_meta.TrafficCloneProfile != nil {
+		trafficCloneProfileRef := "/api/trafficcloneprofile?name=" + vs_meta.TrafficCloneProfile.Name
+		evhChild.TrafficCloneProfileRef = &trafficCloneProfileRef
+	}
 	var datascriptCollection []*avimodels.VSDataScripts
 
 	//DS from hostrule
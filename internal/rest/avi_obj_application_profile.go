@@ -0,0 +1,245 @@
+/*
+ * Copyright © 2025 Broadcom Inc. and/or its subsidiaries. All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	avimodels "github.com/vmware/alb-sdk/go/models"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+func (rest *RestOperations) AviApplicationProfileBuild(appProfileNode *nodes.AviApplicationProfileNode, cacheObj *avicache.AviApplicationProfileCache, key string) *utils.RestOp {
+	if appProfileNode == nil {
+		utils.AviLog.Debugf("key: %s, msg: ApplicationProfileNode is nil", key)
+		return nil
+	}
+
+	if lib.CheckObjectNameLength(appProfileNode.Name, lib.ApplicationProfile) {
+		utils.AviLog.Warnf("key: %s, msg: not processing ApplicationProfile object %s due to name length limit", key, appProfileNode.Name)
+		return nil
+	}
+
+	name := appProfileNode.Name
+	tenant := fmt.Sprintf("/api/tenant/?name=%s", lib.GetEscapedValue(appProfileNode.Tenant))
+	appProfileType := lib.AllowedL7ApplicationProfile
+	createdBy := lib.AKOUser
+
+	// The AKO managed application profile overrides the application profile of the VS for a port, hence it
+	// inherits the settings of the parent profile and only enforces the client certificate validation on top.
+	appProfile := avimodels.ApplicationProfile{}
+	if appProfileNode.ParentApplicationProfile != "" {
+		parentAppProfile, found := avicache.GetParentApplicationProfile(appProfileNode.ParentApplicationProfile)
+		if !found {
+			utils.AviLog.Warnf("key: %s, msg: not processing ApplicationProfile object %s, parent ApplicationProfile %s not found in cache",
+				key, name, appProfileNode.ParentApplicationProfile)
+			return nil
+		}
+		appProfile = parentAppProfile
+		appProfile.UUID = nil
+		appProfile.URL = nil
+		appProfile.LastModified = nil
+		appProfile.Description = nil
+		appProfile.ConfigpbAttributes = nil
+		appProfile.CloudConfigCksum = nil
+	}
+	appProfile.Name = &name
+	appProfile.TenantRef = &tenant
+	appProfile.CreatedBy = &createdBy
+	appProfile.Type = &appProfileType
+	appProfile.Markers = lib.GetAllMarkers(appProfileNode.AviMarkers)
+
+	// The HTTP profile is copied, as it is shared with the cached parent profile.
+	httpProfile := avimodels.HTTPApplicationProfile{}
+	if appProfile.HTTPProfile != nil {
+		httpProfile = *appProfile.HTTPProfile
+	}
+	if appProfileNode.SSLClientCertificateMode != "" {
+		sslClientCertificateMode := appProfileNode.SSLClientCertificateMode
+		httpProfile.SslClientCertificateMode = &sslClientCertificateMode
+	}
+	if appProfileNode.PkiProfile != nil {
+		pkiProfileRef := "/api/pkiprofile?name=" + appProfileNode.PkiProfile.Name
+		httpProfile.PkiProfileRef = &pkiProfileRef
+	}
+
+	appProfile.HTTPProfile = &httpProfile
+
+	var path string
+	var restOp utils.RestOp
+
+	if cacheObj != nil {
+		path = "/api/applicationprofile/" + cacheObj.Uuid
+		restOp = utils.RestOp{
+			ObjName: name,
+			Path:    path,
+			Method:  utils.RestPut,
+			Obj:     appProfile,
+			Tenant:  appProfileNode.Tenant,
+			Model:   lib.ApplicationProfile,
+		}
+	} else {
+		// Patch an existing ApplicationProfile if it exists in the cache but not associated with this VS.
+		appProfileKey := avicache.NamespaceName{Namespace: appProfileNode.Tenant, Name: name}
+		existingCache, ok := rest.cache.AppProfileCache.AviCacheGet(appProfileKey)
+		if ok {
+			existingCacheObj, _ := existingCache.(*avicache.AviApplicationProfileCache)
+			path = "/api/applicationprofile/" + existingCacheObj.Uuid
+			restOp = utils.RestOp{
+				ObjName: name,
+				Path:    path,
+				Method:  utils.RestPut,
+				Obj:     appProfile,
+				Tenant:  appProfileNode.Tenant,
+				Model:   lib.ApplicationProfile,
+			}
+		} else {
+			path = "/api/applicationprofile"
+			restOp = utils.RestOp{
+				ObjName: name,
+				Path:    path,
+				Method:  utils.RestPost,
+				Obj:     appProfile,
+				Tenant:  appProfileNode.Tenant,
+				Model:   lib.ApplicationProfile,
+			}
+		}
+	}
+
+	utils.AviLog.Debugf(spew.Sprintf("key: %s, msg: ApplicationProfile RestOp: %v, Object: %v", key, utils.Stringify(restOp), utils.Stringify(appProfile)))
+	return &restOp
+}
+
+func (rest *RestOperations) AviApplicationProfileDel(uuid string, tenant string, key string) *utils.RestOp {
+	path := "/api/applicationprofile/" + uuid
+	restOp := utils.RestOp{
+		Path:   path,
+		Method: "DELETE",
+		Tenant: tenant,
+		Model:  lib.ApplicationProfile,
+	}
+	utils.AviLog.Infof(spew.Sprintf("key: %s, msg: ApplicationProfile DELETE RestOp: %v", key, utils.Stringify(restOp)))
+	return &restOp
+}
+
+func (rest *RestOperations) AviApplicationProfileCacheAdd(restOp *utils.RestOp, vsKey avicache.NamespaceName, key string) error {
+	if restOp.Err != nil || restOp.Response == nil {
+		utils.AviLog.Warnf("key: %s, rest_op has err or no response for ApplicationProfile, err: %v, response: %v", key, restOp.Err, restOp.Response)
+		return errors.New("errored rest_op")
+	}
+
+	respElems := rest.restOperator.RestRespArrToObjByType(restOp, "applicationprofile", key)
+	if respElems == nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to find ApplicationProfile obj in resp %v", key, restOp.Response)
+		return errors.New("ApplicationProfile not found")
+	}
+
+	for _, resp := range respElems {
+		name, ok := resp["name"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: Name not present in response %v for ApplicationProfile", key, resp)
+			continue
+		}
+
+		uuid, ok := resp["uuid"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: Uuid not present in response %v for ApplicationProfile", key, resp)
+			continue
+		}
+
+		var lastModifiedStr string
+		if lastModifiedIntf, ok := resp["_last_modified"]; ok {
+			lastModifiedStr, _ = lastModifiedIntf.(string)
+		} else {
+			utils.AviLog.Warnf("key: %s, msg: _last_modified not present in response %v for ApplicationProfile %s", key, resp, name)
+		}
+
+		var pkiKey avicache.NamespaceName
+		if httpProfile, ok := resp["http_profile"].(map[string]interface{}); ok {
+			if pkiProfileRef, ok := httpProfile["pki_profile_ref"].(string); ok && pkiProfileRef != "" {
+				pkiUuid := avicache.ExtractUUID(pkiProfileRef, "pkiprofile-.*.#")
+				pkiName, foundPki := rest.cache.PKIProfileCache.AviCacheGetNameByUuid(pkiUuid)
+				if foundPki {
+					pkiKey = avicache.NamespaceName{Namespace: restOp.Tenant, Name: pkiName.(string)}
+				}
+			}
+		}
+
+		var appProfileModel avimodels.ApplicationProfile
+		switch restOp.Obj.(type) {
+		case utils.AviRestObjMacro:
+			appProfileModel = restOp.Obj.(utils.AviRestObjMacro).Data.(avimodels.ApplicationProfile)
+		case avimodels.ApplicationProfile:
+			appProfileModel = restOp.Obj.(avimodels.ApplicationProfile)
+		default:
+			utils.AviLog.Warnf("key: %s, msg: Unknown object type for ApplicationProfile %v", key, restOp.Obj)
+		}
+
+		appProfileCacheObj := avicache.AviApplicationProfileCache{
+			Name:                 name,
+			Tenant:               restOp.Tenant,
+			Uuid:                 uuid,
+			PkiProfileCollection: pkiKey,
+			LastModified:         lastModifiedStr,
+		}
+		if appProfileModel.Name != nil {
+			appProfileCacheObj.CloudConfigCksum = avicache.CalculateApplicationProfileChecksum(appProfileModel)
+		}
+		if lastModifiedStr == "" {
+			appProfileCacheObj.InvalidData = true
+		}
+
+		k := avicache.NamespaceName{Namespace: restOp.Tenant, Name: name}
+		rest.cache.AppProfileCache.AviCacheAdd(k, &appProfileCacheObj)
+
+		if strings.HasPrefix(name, lib.GetNamePrefix()) {
+			vs_cache, ok := rest.cache.VsCacheMeta.AviCacheGet(vsKey)
+			if ok {
+				vs_cache_obj, found := vs_cache.(*avicache.AviVsCache)
+				if found {
+					vs_cache_obj.AddToApplicationProfileKeyCollection(k)
+					utils.AviLog.Debugf("key: %s, msg: modified the VS cache for ApplicationProfile object. The cache now is :%v", key, utils.Stringify(vs_cache_obj))
+				}
+			} else {
+				vs_cache_obj := rest.cache.VsCacheMeta.AviCacheAddVS(vsKey)
+				vs_cache_obj.AddToApplicationProfileKeyCollection(k)
+				utils.AviLog.Debug(spew.Sprintf("key: %s, msg: added VS cache key %v during ApplicationProfile update with val %v", key, vsKey,
+					vs_cache_obj))
+			}
+		}
+		utils.AviLog.Infof("key: %s, msg: Added ApplicationProfile cache k %v val %v", key, k, utils.Stringify(appProfileCacheObj))
+	}
+	return nil
+}
+
+func (rest *RestOperations) AviApplicationProfileCacheDel(restOp *utils.RestOp, vsKey avicache.NamespaceName, key string) error {
+	appProfileKey := avicache.NamespaceName{Namespace: restOp.Tenant, Name: restOp.ObjName}
+	rest.cache.AppProfileCache.AviCacheDelete(appProfileKey)
+	vs_cache, ok := rest.cache.VsCacheMeta.AviCacheGet(vsKey)
+	if ok {
+		if vs_cache_obj, found := vs_cache.(*avicache.AviVsCache); found {
+			vs_cache_obj.RemoveFromApplicationProfileKeyCollection(appProfileKey)
+		}
+	}
+	utils.AviLog.Infof("key: %s, msg: Deleted ApplicationProfile cache k %v", key, appProfileKey)
+	return nil
+}
@@ -283,6 +283,12 @@ func (rest *RestOperations) AviHttpPSBuild(hps_meta *nodes.AviHttpPolicySetNode,
 		}
 	}
 
+	if hps_meta.HTTPSecurityRules != nil {
+		hps.HTTPSecurityPolicy = &avimodels.HttpsecurityPolicy{
+			Rules: hps_meta.HTTPSecurityRules,
+		}
+	}
+
 	var path string
 	var rest_op utils.RestOp
 	if cache_obj != nil {
@@ -56,6 +56,10 @@ func (rest *RestOperations) AviL4PSBuild(hps_meta *nodes.AviL4PolicyNode, cache_
 		if hppmap.Port != 0 {
 			// Keep the l4 policy rule name similar to the Pool name it corresponds to.
 			ruleName := hppmap.Pool
+			if hppmap.Pool == "" && hppmap.PoolGroup != "" {
+				// A PoolGroup can be selected for multiple ports, so suffix the port to keep the rule names unique.
+				ruleName = fmt.Sprintf("%s-%d", hppmap.PoolGroup, hppmap.Port)
+			}
 			if lib.CheckObjectNameLength(ruleName, lib.L4PSRule) {
 				utils.AviLog.Warnf("key: %s not adding L4 PolicyRule to Policyset object", key)
 				continue
@@ -67,10 +71,17 @@ func (rest *RestOperations) AviL4PSBuild(hps_meta *nodes.AviL4PolicyNode, cache_
 			ports = append(ports, int64(hppmap.Port))
 			l4action := &avimodels.L4RuleAction{}
 			actionSelect := &avimodels.L4RuleActionSelectPool{}
-			poolName := hppmap.Pool
-			actionSelect.PoolRef = &poolName
-			poolSelect := "L4_RULE_ACTION_SELECT_POOL"
-			actionSelect.ActionType = &poolSelect
+			if hppmap.Pool == "" && hppmap.PoolGroup != "" {
+				pgRef := fmt.Sprintf("/api/poolgroup/?name=%s", hppmap.PoolGroup)
+				actionSelect.PoolGroupRef = &pgRef
+				pgSelect := "L4_RULE_ACTION_SELECT_POOLGROUP"
+				actionSelect.ActionType = &pgSelect
+			} else {
+				poolName := hppmap.Pool
+				actionSelect.PoolRef = &poolName
+				poolSelect := "L4_RULE_ACTION_SELECT_POOL"
+				actionSelect.ActionType = &poolSelect
+			}
 			l4action.SelectPool = actionSelect
 			l4rule.Action = l4action
 			j := idx
@@ -212,8 +223,12 @@ func (rest *RestOperations) AviL4PolicyCacheAdd(rest_op *utils.RestOp, vsKey avi
 			// cannot create an external load balancer with mix protocol - hence just caching the protocol once
 			protocols = append(protocols, *rule.Match.Protocol.Protocol)
 			ports = rule.Match.Port.Ports
-			pool := strings.TrimPrefix(*rule.Action.SelectPool.PoolRef, "/api/pool?name=")
-			pools = append(pools, pool)
+			if rule.Action.SelectPool.PoolGroupRef != nil {
+				pools = append(pools, strings.TrimPrefix(*rule.Action.SelectPool.PoolGroupRef, "/api/poolgroup/?name="))
+			} else {
+				pool := strings.TrimPrefix(*rule.Action.SelectPool.PoolRef, "/api/pool?name=")
+				pools = append(pools, pool)
+			}
 		}
 		emptyIngestionMarkers := utils.AviObjectMarkers{}
 		//This is fetching data from response send at avi controller.
@@ -55,6 +55,11 @@ func (rest *RestOperations) AviPersistenceProfileBuild(appPersProfileNode *nodes
 			Timeout:            appPersProfileNode.HTTPCookiePersistenceProfile.Timeout,
 			IsPersistentCookie: appPersProfileNode.HTTPCookiePersistenceProfile.IsPersistentCookie,
 		}
+	case "PERSISTENCE_TYPE_APP_COOKIE":
+		appPersProfile.AppCookiePersistenceProfile = &avimodels.AppCookiePersistenceProfile{
+			PrstHdrName: &appPersProfileNode.AppCookiePersistenceProfile.HeaderName,
+			Timeout:     appPersProfileNode.AppCookiePersistenceProfile.Timeout,
+		}
 	default:
 		utils.AviLog.Warnf("Unknown persistence type: %s", appPersProfileNode.PersistenceType)
 		return nil
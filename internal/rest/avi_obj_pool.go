@@ -105,6 +105,7 @@ func (rest *RestOperations) AviPoolBuild(pool_meta *nodes.AviPoolNode, cache_obj
 		SslKeyAndCertificateRef: pool_meta.SslKeyAndCertificateRef,
 		PkiProfileRef:           pool_meta.PkiProfileRef,
 		HostCheckEnabled:        pool_meta.HostCheckEnabled,
+		ServerName:              pool_meta.ServerName,
 		PlacementNetworks:       placementNetworks,
 	}
 
@@ -157,6 +158,14 @@ func (rest *RestOperations) AviPoolBuild(pool_meta *nodes.AviPoolNode, cache_obj
 		pool.EnableHttp2 = pool_meta.EnableHttp2
 	}
 
+	if pool_meta.ServerTimeout != nil {
+		pool.ServerTimeout = pool_meta.ServerTimeout
+	}
+
+	if pool_meta.ServerReselect != nil {
+		pool.ServerReselect = pool_meta.ServerReselect
+	}
+
 	for i, server := range pool_meta.Servers {
 		port := pool_meta.Port
 		sip := server.Ip
@@ -170,6 +179,11 @@ func (rest *RestOperations) AviPoolBuild(pool_meta *nodes.AviPoolNode, cache_obj
 			sn := server.ServerNode
 			s.ServerNode = &sn
 		}
+		if server.Hostname != "" {
+			hostname := server.Hostname
+			s.Hostname = &hostname
+			s.ResolveServerByDNS = proto.Bool(true)
+		}
 		pool.Servers = append(pool.Servers, &s)
 	}
 
@@ -427,6 +441,7 @@ func (rest *RestOperations) AviPoolCacheDel(rest_op *utils.RestOp, vsKey avicach
 		}
 	}
 	rest.cache.PoolCache.AviCacheDelete(poolKey)
+	nodes.ForgetAppProtocolEvent(poolKey.Name)
 	if (cacheServiceMetadataCRD != lib.CRDMetadata{}) {
 		status.HttpRuleEventBroadcast(poolKey.Name, cacheServiceMetadataCRD, lib.CRDMetadata{})
 	}
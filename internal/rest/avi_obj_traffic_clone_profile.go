@@ -0,0 +1,209 @@
+/*
+ * Copyright © 2025 Broadcom Inc. and/or its subsidiaries. All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	avimodels "github.com/vmware/alb-sdk/go/models"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+func (rest *RestOperations) AviTrafficCloneProfileBuild(trafficCloneProfileNode *nodes.AviTrafficCloneProfileNode, cacheObj *avicache.AviTrafficCloneProfileCache, key string) *utils.RestOp {
+	if trafficCloneProfileNode == nil {
+		utils.AviLog.Debugf("key: %s, msg: TrafficCloneProfileNode is nil", key)
+		return nil
+	}
+
+	if lib.CheckObjectNameLength(trafficCloneProfileNode.Name, lib.TrafficCloneProfile) {
+		utils.AviLog.Warnf("key: %s, msg: not processing TrafficCloneProfile object %s due to name length limit", key, trafficCloneProfileNode.Name)
+		return nil
+	}
+
+	name := trafficCloneProfileNode.Name
+	tenant := fmt.Sprintf("/api/tenant/?name=%s", lib.GetEscapedValue(trafficCloneProfileNode.Tenant))
+	cloudRef := fmt.Sprintf("/api/cloud?name=%s", utils.CloudName)
+
+	trafficCloneProfile := avimodels.TrafficCloneProfile{
+		Name:      &name,
+		TenantRef: &tenant,
+		CloudRef:  &cloudRef,
+		Markers:   lib.GetAllMarkers(trafficCloneProfileNode.AviMarkers),
+	}
+	for _, server := range trafficCloneProfileNode.CloneServers {
+		addr := server
+		addrType := "V6"
+		if utils.IsV4(addr) {
+			addrType = "V4"
+		}
+		trafficCloneProfile.CloneServers = append(trafficCloneProfile.CloneServers, &avimodels.CloneServer{
+			IPAddress: &avimodels.IPAddr{Addr: &addr, Type: &addrType},
+		})
+	}
+
+	var path string
+	var restOp utils.RestOp
+
+	if cacheObj != nil {
+		path = "/api/trafficcloneprofile/" + cacheObj.Uuid
+		restOp = utils.RestOp{
+			ObjName: name,
+			Path:    path,
+			Method:  utils.RestPut,
+			Obj:     trafficCloneProfile,
+			Tenant:  trafficCloneProfileNode.Tenant,
+			Model:   lib.TrafficCloneProfile,
+		}
+	} else {
+		// Patch an existing TrafficCloneProfile if it exists in the cache but not associated with this VS.
+		trafficCloneProfileKey := avicache.NamespaceName{Namespace: trafficCloneProfileNode.Tenant, Name: name}
+		existingCache, ok := rest.cache.TrafficCloneProfileCache.AviCacheGet(trafficCloneProfileKey)
+		if ok {
+			existingCacheObj, _ := existingCache.(*avicache.AviTrafficCloneProfileCache)
+			path = "/api/trafficcloneprofile/" + existingCacheObj.Uuid
+			restOp = utils.RestOp{
+				ObjName: name,
+				Path:    path,
+				Method:  utils.RestPut,
+				Obj:     trafficCloneProfile,
+				Tenant:  trafficCloneProfileNode.Tenant,
+				Model:   lib.TrafficCloneProfile,
+			}
+		} else {
+			path = "/api/trafficcloneprofile"
+			restOp = utils.RestOp{
+				ObjName: name,
+				Path:    path,
+				Method:  utils.RestPost,
+				Obj:     trafficCloneProfile,
+				Tenant:  trafficCloneProfileNode.Tenant,
+				Model:   lib.TrafficCloneProfile,
+			}
+		}
+	}
+
+	utils.AviLog.Debugf(spew.Sprintf("key: %s, msg: TrafficCloneProfile RestOp: %v, Object: %v", key, utils.Stringify(restOp), utils.Stringify(trafficCloneProfile)))
+	return &restOp
+}
+
+func (rest *RestOperations) AviTrafficCloneProfileDel(uuid string, tenant string, key string) *utils.RestOp {
+	path := "/api/trafficcloneprofile/" + uuid
+	restOp := utils.RestOp{
+		Path:   path,
+		Method: "DELETE",
+		Tenant: tenant,
+		Model:  lib.TrafficCloneProfile,
+	}
+	utils.AviLog.Infof(spew.Sprintf("key: %s, msg: TrafficCloneProfile DELETE RestOp: %v", key, utils.Stringify(restOp)))
+	return &restOp
+}
+
+func (rest *RestOperations) AviTrafficCloneProfileCacheAdd(restOp *utils.RestOp, vsKey avicache.NamespaceName, key string) error {
+	if restOp.Err != nil || restOp.Response == nil {
+		utils.AviLog.Warnf("key: %s, rest_op has err or no response for TrafficCloneProfile, err: %v, response: %v", key, restOp.Err, restOp.Response)
+		return errors.New("errored rest_op")
+	}
+
+	respElems := rest.restOperator.RestRespArrToObjByType(restOp, "trafficcloneprofile", key)
+	if respElems == nil {
+		utils.AviLog.Warnf("key: %s, msg: unable to find TrafficCloneProfile obj in resp %v", key, restOp.Response)
+		return errors.New("TrafficCloneProfile not found")
+	}
+
+	for _, resp := range respElems {
+		name, ok := resp["name"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: Name not present in response %v for TrafficCloneProfile", key, resp)
+			continue
+		}
+
+		uuid, ok := resp["uuid"].(string)
+		if !ok {
+			utils.AviLog.Warnf("key: %s, msg: Uuid not present in response %v for TrafficCloneProfile", key, resp)
+			continue
+		}
+
+		var lastModifiedStr string
+		if lastModifiedIntf, ok := resp["_last_modified"]; ok {
+			lastModifiedStr, _ = lastModifiedIntf.(string)
+		} else {
+			utils.AviLog.Warnf("key: %s, msg: _last_modified not present in response %v for TrafficCloneProfile %s", key, resp, name)
+		}
+
+		var trafficCloneProfileModel avimodels.TrafficCloneProfile
+		switch restOp.Obj.(type) {
+		case utils.AviRestObjMacro:
+			trafficCloneProfileModel = restOp.Obj.(utils.AviRestObjMacro).Data.(avimodels.TrafficCloneProfile)
+		case avimodels.TrafficCloneProfile:
+			trafficCloneProfileModel = restOp.Obj.(avimodels.TrafficCloneProfile)
+		default:
+			utils.AviLog.Warnf("key: %s, msg: Unknown object type for TrafficCloneProfile %v", key, restOp.Obj)
+		}
+
+		trafficCloneProfileCacheObj := avicache.AviTrafficCloneProfileCache{
+			Name:         name,
+			Tenant:       restOp.Tenant,
+			Uuid:         uuid,
+			LastModified: lastModifiedStr,
+		}
+		if trafficCloneProfileModel.Name != nil {
+			trafficCloneProfileCacheObj.CloudConfigCksum = avicache.CalculateTrafficCloneProfileChecksum(trafficCloneProfileModel)
+		}
+		if lastModifiedStr == "" {
+			trafficCloneProfileCacheObj.InvalidData = true
+		}
+
+		k := avicache.NamespaceName{Namespace: restOp.Tenant, Name: name}
+		rest.cache.TrafficCloneProfileCache.AviCacheAdd(k, &trafficCloneProfileCacheObj)
+
+		if strings.HasPrefix(name, lib.GetNamePrefix()) {
+			vs_cache, ok := rest.cache.VsCacheMeta.AviCacheGet(vsKey)
+			if ok {
+				vs_cache_obj, found := vs_cache.(*avicache.AviVsCache)
+				if found {
+					vs_cache_obj.AddToTrafficCloneProfileKeyCollection(k)
+					utils.AviLog.Debugf("key: %s, msg: modified the VS cache for TrafficCloneProfile object. The cache now is :%v", key, utils.Stringify(vs_cache_obj))
+				}
+			} else {
+				vs_cache_obj := rest.cache.VsCacheMeta.AviCacheAddVS(vsKey)
+				vs_cache_obj.AddToTrafficCloneProfileKeyCollection(k)
+				utils.AviLog.Debug(spew.Sprintf("key: %s, msg: added VS cache key %v during TrafficCloneProfile update with val %v", key, vsKey,
+					vs_cache_obj))
+			}
+		}
+		utils.AviLog.Infof("key: %s, msg: Added TrafficCloneProfile cache k %v val %v", key, k, utils.Stringify(trafficCloneProfileCacheObj))
+	}
+	return nil
+}
+
+func (rest *RestOperations) AviTrafficCloneProfileCacheDel(restOp *utils.RestOp, vsKey avicache.NamespaceName, key string) error {
+	trafficCloneProfileKey := avicache.NamespaceName{Namespace: restOp.Tenant, Name: restOp.ObjName}
+	rest.cache.TrafficCloneProfileCache.AviCacheDelete(trafficCloneProfileKey)
+	vs_cache, ok := rest.cache.VsCacheMeta.AviCacheGet(vsKey)
+	if ok {
+		if vs_cache_obj, found := vs_cache.(*avicache.AviVsCache); found {
+			vs_cache_obj.RemoveFromTrafficCloneProfileKeyCollection(trafficCloneProfileKey)
+		}
+	}
+	utils.AviLog.Infof("key: %s, msg: Deleted TrafficCloneProfile cache k %v", key, trafficCloneProfileKey)
+	return nil
+}
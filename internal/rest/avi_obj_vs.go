@@ -245,13 +245,10 @@ func (rest *RestOperations) AviVsBuild(vs_meta *nodes.AviVsNode, rest_method uti
 		if len(vs_meta.L4PolicyRefs) > 0 {
 			vsDownOnPoolDown := true
 			vs.RemoveListeningPortOnVsDown = &vsDownOnPoolDown
-			var i int32
-			i = 0
 			var l4Policies []*avimodels.L4Policies
-			for _, l4pol := range vs_meta.L4PolicyRefs {
-				// Update them on the VS object
-				var j int32
-				i = i + 1
+			for i, l4pol := range vs_meta.L4PolicyRefs {
+				// Update them on the VS object, each with its own index
+				j := int32(i)
 				l4PolicyRef := fmt.Sprintf("/api/l4policyset/?name=%s", l4pol.Name)
 				l4Policy := &avimodels.L4Policies{L4PolicySetRef: &l4PolicyRef, Index: &j}
 				l4Policies = append(l4Policies, l4Policy)
@@ -782,8 +779,8 @@ func (rest *RestOperations) AviVsCacheDel(rest_op *utils.RestOp, vsKey avicache.
 			}
 
 			// try to delete the vsvip from cache only if the vs is not of type insecure passthrough
-			// and if controller version is >= 20.1.1
-			if vsCacheObj.ServiceMetadataObj.PassthroughParentRef == "" {
+			// or a L4 VS sharing the vsvip of its parent, and if controller version is >= 20.1.1
+			if vsCacheObj.ServiceMetadataObj.PassthroughParentRef == "" && vsCacheObj.ServiceMetadataObj.L4ParentRef == "" {
 				if len(vsCacheObj.VSVipKeyCollection) > 0 {
 					vsvip := vsCacheObj.VSVipKeyCollection[0].Name
 					vsvipKey := avicache.NamespaceName{Namespace: vsKey.Namespace, Name: vsvip}
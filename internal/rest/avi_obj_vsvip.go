@@ -105,13 +105,7 @@ func (rest *RestOperations) AviVsVipBuild(vsvip_meta *nodes.AviVSVIPNode, vsCach
 			}
 
 			// This would throw an error for advl4 the error is propagated to the gateway status.
-			if vsvip_meta.IPAddress != "" {
-				if utils.IsV4(vsvip_meta.IPAddress) {
-					vip.IPAddress = &avimodels.IPAddr{Type: &ipType, Addr: &vsvip_meta.IPAddress}
-				} else {
-					vip.Ip6Address = &avimodels.IPAddr{Type: &ip6Type, Addr: &vsvip_meta.IPAddress}
-				}
-			}
+			setVipStaticAddresses(vip, vsvip_meta)
 
 			if lib.IsPublicCloud() && lib.GetCloudType() != lib.CLOUD_GCP {
 				vips := networkNamesToVips(vsvip_meta.VipNetworks, vsvip_meta.EnablePublicIP)
@@ -142,9 +136,7 @@ func (rest *RestOperations) AviVsVipBuild(vsvip_meta *nodes.AviVSVIPNode, vsCach
 					}
 					vip.IPAMNetworkSubnet.NetworkRef = &networkRef
 					utils.AviLog.Debugf("Network: %s Network ref in rest layer: %s", vsvip_meta.VipNetworks[0].NetworkName, *vip.IPAMNetworkSubnet.NetworkRef)
-					if vsvip_meta.VipNetworks[0].V6Cidr != "" {
-						lib.UpdateV6(vip, &vsvip_meta.VipNetworks[0])
-					}
+					updateVipIPType(vip, vsvip_meta, &vsvip_meta.VipNetworks[0])
 					if lib.GetCloudType() == lib.CLOUD_NSXT &&
 						lib.GetNSXTTransportZone() == lib.VLAN_TRANSPORT_ZONE {
 						setVipPlacementNetwork(vip, vsvip_meta.VipNetworks[0].Cidr, &networkRef)
@@ -185,13 +177,7 @@ func (rest *RestOperations) AviVsVipBuild(vsvip_meta *nodes.AviVSVIPNode, vsCach
 		}
 
 		// configuring static IP, from gateway.Addresses (advl4, svcapi) and service.loadBalancerIP (l4)
-		if vsvip_meta.IPAddress != "" {
-			if utils.IsV4(vsvip_meta.IPAddress) {
-				vip.IPAddress = &avimodels.IPAddr{Type: &ipType, Addr: &vsvip_meta.IPAddress}
-			} else {
-				vip.Ip6Address = &avimodels.IPAddr{Type: &ip6Type, Addr: &vsvip_meta.IPAddress}
-			}
-		}
+		setVipStaticAddresses(&vip, vsvip_meta)
 
 		// selecting network with user input, in case user input is not provided AKO relies on
 		// usable network configuration in ipamdnsproviderprofile
@@ -249,9 +235,7 @@ func (rest *RestOperations) AviVsVipBuild(vsvip_meta *nodes.AviVSVIPNode, vsCach
 
 					}
 				}
-				if vipNetwork.V6Cidr != "" {
-					lib.UpdateV6(&vip, &vipNetwork)
-				}
+				updateVipIPType(&vip, vsvip_meta, &vipNetwork)
 			}
 		}
 
@@ -759,3 +743,37 @@ func setVipPlacementNetwork(vip *avimodels.Vip, cidr string, networkRef *string)
 	}
 	vip.PlacementNetworks = []*avimodels.VipPlacementNetwork{placementNetwork}
 }
+
+// setVipStaticAddresses configures the static address of the vsvip node on the vip. When both
+// a v4 and a v6 address are present, the vip is allocated as dual-stack.
+func setVipStaticAddresses(vip *avimodels.Vip, vsvip_meta *nodes.AviVSVIPNode) {
+	ipType, ip6Type := "V4", "V6"
+	if vsvip_meta.IPAddress != "" {
+		if utils.IsV4(vsvip_meta.IPAddress) {
+			vip.IPAddress = &avimodels.IPAddr{Type: &ipType, Addr: &vsvip_meta.IPAddress}
+		} else {
+			vip.Ip6Address = &avimodels.IPAddr{Type: &ip6Type, Addr: &vsvip_meta.IPAddress}
+		}
+	}
+	if vsvip_meta.IPv6Address != "" {
+		vip.Ip6Address = &avimodels.IPAddr{Type: &ip6Type, Addr: &vsvip_meta.IPv6Address}
+	}
+	updateVipIPType(vip, vsvip_meta, nil)
+}
+
+// updateVipIPType sets the allocation type of the vip. With a static v6 address configured along with the
+// v4 one, the vip is dual-stack when a v4 address or a v4 CIDR is available, and v6 only otherwise. Without
+// it, the type is derived from the CIDRs of the VIP network through lib.UpdateV6.
+func updateVipIPType(vip *avimodels.Vip, vsvip_meta *nodes.AviVSVIPNode, vipNetwork *akov1beta1.AviInfraSettingVipNetwork) {
+	if vsvip_meta.IPv6Address != "" {
+		if vip.IPAddress != nil || (vipNetwork != nil && vipNetwork.Cidr != "") {
+			vip.AutoAllocateIPType = proto.String("V4_V6")
+		} else {
+			vip.AutoAllocateIPType = proto.String("V6_ONLY")
+		}
+		return
+	}
+	if vipNetwork != nil && vipNetwork.V6Cidr != "" {
+		lib.UpdateV6(vip, vipNetwork)
+	}
+}
@@ -532,6 +532,18 @@ func (rest *RestOperations) DeleteVSOper(vsKey avicache.NamespaceName, vs_cache_
 				return false
 			}
 		}
+		// The L4 VS shares the vsvip of its parent, which is deleted along with the parent.
+		if l4Child := vs_cache_obj.ServiceMetadataObj.L4ChildRef; l4Child != "" {
+			l4ChildKey := avicache.NamespaceName{
+				Namespace: namespace,
+				Name:      l4Child,
+			}
+			if l4ChildCache := rest.getVsCacheObj(l4ChildKey, key); l4ChildCache != nil {
+				if success := rest.DeleteVSOper(l4ChildKey, l4ChildCache, namespace, key, skipVS, true); !success {
+					return false
+				}
+			}
+		}
 		for _, sni_uuid := range sni_vs_keys {
 			sniVsKey, ok := rest.cache.VsCacheMeta.AviCacheGetKeyByUuid(sni_uuid)
 			if ok {
@@ -554,6 +566,8 @@ func (rest *RestOperations) DeleteVSOper(vsKey avicache.NamespaceName, vs_cache_
 		rest_ops = rest.SSLKeyCertDelete(vs_cache_obj.SSLKeyCertCollection, namespace, rest_ops, key)
 		rest_ops = rest.HTTPPolicyDelete(vs_cache_obj.HTTPKeyCollection, namespace, rest_ops, key)
 		rest_ops = rest.StringGroupDelete(vs_cache_obj.StringGroupKeyCollection, namespace, rest_ops, key)
+		rest_ops = rest.TrafficCloneProfileDelete(vs_cache_obj.TrafficCloneProfileKeyCollection, namespace, rest_ops, key)
+		rest_ops = rest.ApplicationProfileDelete(vs_cache_obj.ApplicationProfileKeyCollection, namespace, rest_ops, key)
 		rest_ops = rest.L4PolicyDelete(vs_cache_obj.L4PolicyCollection, namespace, rest_ops, key)
 		rest_ops = rest.PoolGroupDelete(vs_cache_obj.PGKeyCollection, namespace, rest_ops, key)
 		rest_ops = rest.PoolDelete(vs_cache_obj.PoolKeyCollection, namespace, rest_ops, nil, key)
@@ -597,6 +611,7 @@ func (rest *RestOperations) deleteSniVs(vsKey avicache.NamespaceName, vs_cache_o
 		rest_ops = rest.PoolGroupDelete(vs_cache_obj.PGKeyCollection, namespace, rest_ops, key)
 		rest_ops = rest.PoolDelete(vs_cache_obj.PoolKeyCollection, namespace, rest_ops, nil, key)
 		rest_ops = rest.StringGroupDelete(vs_cache_obj.StringGroupKeyCollection, namespace, rest_ops, key)
+		rest_ops = rest.TrafficCloneProfileDelete(vs_cache_obj.TrafficCloneProfileKeyCollection, namespace, rest_ops, key)
 		success, _ := rest.ExecuteRestAndPopulateCache(rest_ops, vsKey, avimodel, key, false)
 		return success
 	}
@@ -830,6 +845,10 @@ func (rest *RestOperations) PopulateOneCache(rest_op *utils.RestOp, aviObjKey av
 			rest.AviStringGroupCacheAdd(rest_op, aviObjKey, key)
 		} else if rest_op.Model == "ApplicationPersistenceProfile" {
 			rest.AviPersistenceProfileCacheAdd(rest_op, aviObjKey, key)
+		} else if rest_op.Model == "TrafficCloneProfile" {
+			rest.AviTrafficCloneProfileCacheAdd(rest_op, aviObjKey, key)
+		} else if rest_op.Model == "ApplicationProfile" {
+			rest.AviApplicationProfileCacheAdd(rest_op, aviObjKey, key)
 		}
 
 	} else if (rest_op.Err == nil || aviErr.HttpStatusCode == 404) &&
@@ -857,6 +876,10 @@ func (rest *RestOperations) PopulateOneCache(rest_op *utils.RestOp, aviObjKey av
 			rest.AviStringGroupCacheDel(rest_op, aviObjKey, key)
 		} else if rest_op.Model == "ApplicationPersistenceProfile" {
 			rest.AviPersistenceProfileCacheDel(rest_op, aviObjKey, key)
+		} else if rest_op.Model == "TrafficCloneProfile" {
+			rest.AviTrafficCloneProfileCacheDel(rest_op, aviObjKey, key)
+		} else if rest_op.Model == "ApplicationProfile" {
+			rest.AviApplicationProfileCacheDel(rest_op, aviObjKey, key)
 		}
 	}
 }
@@ -1065,6 +1088,30 @@ func (rest *RestOperations) RefreshCacheForRetryLayer(parentVsKey string, aviObj
 					rest_op.ObjName = ApplicationPersistenceProfile
 				}
 				rest.AviPersistenceProfileCacheDel(rest_op, aviObjKey, key)
+			case "TrafficCloneProfile":
+				var TrafficCloneProfile string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					TrafficCloneProfile = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.TrafficCloneProfile).Name
+				case avimodels.TrafficCloneProfile:
+					TrafficCloneProfile = *rest_op.Obj.(avimodels.TrafficCloneProfile).Name
+				}
+				if TrafficCloneProfile != "" {
+					rest_op.ObjName = TrafficCloneProfile
+				}
+				rest.AviTrafficCloneProfileCacheDel(rest_op, aviObjKey, key)
+			case "ApplicationProfile":
+				var ApplicationProfile string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					ApplicationProfile = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.ApplicationProfile).Name
+				case avimodels.ApplicationProfile:
+					ApplicationProfile = *rest_op.Obj.(avimodels.ApplicationProfile).Name
+				}
+				if ApplicationProfile != "" {
+					rest_op.ObjName = ApplicationProfile
+				}
+				rest.AviApplicationProfileCacheDel(rest_op, aviObjKey, key)
 			case "VirtualService":
 				rest.AviVsCacheDel(rest_op, aviObjKey, key)
 			case "VSDataScriptSet":
@@ -1168,6 +1215,24 @@ func (rest *RestOperations) RefreshCacheForRetryLayer(parentVsKey string, aviObj
 					PersistenceProfile = *rest_op.Obj.(avimodels.ApplicationPersistenceProfile).Name
 				}
 				aviObjCache.AviPopulateOnePersistenceProfileCache(c, PersistenceProfile)
+			case "TrafficCloneProfile":
+				var TrafficCloneProfile string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					TrafficCloneProfile = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.TrafficCloneProfile).Name
+				case avimodels.TrafficCloneProfile:
+					TrafficCloneProfile = *rest_op.Obj.(avimodels.TrafficCloneProfile).Name
+				}
+				aviObjCache.AviPopulateOneTrafficCloneProfileCache(c, TrafficCloneProfile)
+			case "ApplicationProfile":
+				var ApplicationProfile string
+				switch rest_op.Obj.(type) {
+				case utils.AviRestObjMacro:
+					ApplicationProfile = *rest_op.Obj.(utils.AviRestObjMacro).Data.(avimodels.ApplicationProfile).Name
+				case avimodels.ApplicationProfile:
+					ApplicationProfile = *rest_op.Obj.(avimodels.ApplicationProfile).Name
+				}
+				aviObjCache.AviPopulateOneApplicationProfileCache(c, ApplicationProfile)
 			case "VirtualService":
 				aviObjCache.AviObjOneVSCachePopulate(c, utils.CloudName, aviObjKey.Name, aviObjKey.Namespace)
 				vsObjMeta, ok := rest.cache.VsCacheMeta.AviCacheGet(aviObjKey)
@@ -2021,6 +2086,125 @@ func (rest *RestOperations) ApplicationPersistenceProfileDelete(appPersProfileDe
 	}
 	return rest_ops
 }
+
+// TrafficCloneProfileCU handles Create/Update for the TrafficCloneProfile attached to a VS and returns the
+// cached TrafficCloneProfiles of the VS which are no longer present in the model.
+func (rest *RestOperations) TrafficCloneProfileCU(trafficCloneProfileNode *nodes.AviTrafficCloneProfileNode, vs_cache_obj *avicache.AviVsCache, namespace string, rest_ops []*utils.RestOp, key string) ([]avicache.NamespaceName, []*utils.RestOp) {
+	var cacheTrafficCloneProfileNodes []avicache.NamespaceName
+	if vs_cache_obj != nil {
+		cacheTrafficCloneProfileNodes = make([]avicache.NamespaceName, len(vs_cache_obj.TrafficCloneProfileKeyCollection))
+		copy(cacheTrafficCloneProfileNodes, vs_cache_obj.TrafficCloneProfileKeyCollection)
+	}
+	if trafficCloneProfileNode == nil {
+		return cacheTrafficCloneProfileNodes, rest_ops
+	}
+
+	trafficCloneProfileKey := avicache.NamespaceName{Namespace: namespace, Name: trafficCloneProfileNode.Name}
+	cacheTrafficCloneProfileNodes = avicache.RemoveNamespaceName(cacheTrafficCloneProfileNodes, trafficCloneProfileKey)
+	var trafficCloneProfileCacheObj *avicache.AviTrafficCloneProfileCache
+	if trafficCloneProfileCache, found := rest.cache.TrafficCloneProfileCache.AviCacheGet(trafficCloneProfileKey); found {
+		trafficCloneProfileCacheObj, _ = trafficCloneProfileCache.(*avicache.AviTrafficCloneProfileCache)
+	}
+
+	if trafficCloneProfileCacheObj != nil && trafficCloneProfileCacheObj.CloudConfigCksum == trafficCloneProfileNode.GetCheckSum() {
+		utils.AviLog.Debugf("key: %s, msg: checksums are same for TrafficCloneProfile %s, not doing anything", key, trafficCloneProfileNode.Name)
+	} else {
+		restOp := rest.AviTrafficCloneProfileBuild(trafficCloneProfileNode, trafficCloneProfileCacheObj, key)
+		if restOp != nil {
+			rest_ops = append(rest_ops, restOp)
+		}
+	}
+	return cacheTrafficCloneProfileNodes, rest_ops
+}
+
+func (rest *RestOperations) TrafficCloneProfileDelete(trafficCloneProfileDelete []avicache.NamespaceName, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
+	utils.AviLog.Debugf("key: %s, msg: about to delete TrafficCloneProfiles %s", key, utils.Stringify(trafficCloneProfileDelete))
+	for _, delTrafficCloneProfile := range trafficCloneProfileDelete {
+		trafficCloneProfileKey := avicache.NamespaceName{Namespace: namespace, Name: delTrafficCloneProfile.Name}
+		trafficCloneProfileCache, ok := rest.cache.TrafficCloneProfileCache.AviCacheGet(trafficCloneProfileKey)
+		if ok {
+			trafficCloneProfileCacheObj, _ := trafficCloneProfileCache.(*avicache.AviTrafficCloneProfileCache)
+			restOp := rest.AviTrafficCloneProfileDel(trafficCloneProfileCacheObj.Uuid, namespace, key)
+			restOp.ObjName = delTrafficCloneProfile.Name
+			rest_ops = append(rest_ops, restOp)
+		}
+	}
+	return rest_ops
+}
+
+// ApplicationProfileCU handles Create/Update for the AKO managed ApplicationProfiles, along with their PKI profiles,
+// attached to a VS and returns the cached ApplicationProfiles of the VS which are no longer present in the model.
+// An error is returned when an ApplicationProfile can not be built, as the VS refers to it.
+func (rest *RestOperations) ApplicationProfileCU(appProfileNodes []*nodes.AviApplicationProfileNode, vs_cache_obj *avicache.AviVsCache, namespace string, rest_ops []*utils.RestOp, key string) ([]avicache.NamespaceName, []*utils.RestOp, error) {
+	var cacheAppProfileNodes []avicache.NamespaceName
+	if vs_cache_obj != nil {
+		cacheAppProfileNodes = make([]avicache.NamespaceName, len(vs_cache_obj.ApplicationProfileKeyCollection))
+		copy(cacheAppProfileNodes, vs_cache_obj.ApplicationProfileKeyCollection)
+	}
+
+	for _, appProfileNode := range appProfileNodes {
+		appProfileKey := avicache.NamespaceName{Namespace: namespace, Name: appProfileNode.Name}
+		cacheAppProfileNodes = avicache.RemoveNamespaceName(cacheAppProfileNodes, appProfileKey)
+		var appProfileCacheObj *avicache.AviApplicationProfileCache
+		if appProfileCache, found := rest.cache.AppProfileCache.AviCacheGet(appProfileKey); found {
+			appProfileCacheObj, _ = appProfileCache.(*avicache.AviApplicationProfileCache)
+		}
+
+		// PKI profile has to be created first, as it is referred by the application profile
+		var pkiProfilesToDelete []avicache.NamespaceName
+		if appProfileNode.PkiProfile != nil {
+			pkiKey := avicache.NamespaceName{Namespace: namespace, Name: appProfileNode.PkiProfile.Name}
+			pkiCache, found := rest.cache.PKIProfileCache.AviCacheGet(pkiKey)
+			if !found {
+				restOp := rest.AviPkiProfileBuild(appProfileNode.PkiProfile, nil)
+				if restOp != nil {
+					rest_ops = append(rest_ops, restOp)
+				}
+			} else if pkiCacheObj, _ := pkiCache.(*avicache.AviPkiProfileCache); pkiCacheObj.CloudConfigCksum != appProfileNode.PkiProfile.GetCheckSum() {
+				restOp := rest.AviPkiProfileBuild(appProfileNode.PkiProfile, pkiCacheObj)
+				if restOp != nil {
+					rest_ops = append(rest_ops, restOp)
+				}
+			}
+		}
+		if appProfileCacheObj != nil && appProfileCacheObj.PkiProfileCollection.Name != "" &&
+			(appProfileNode.PkiProfile == nil || appProfileCacheObj.PkiProfileCollection.Name != appProfileNode.PkiProfile.Name) {
+			pkiProfilesToDelete = append(pkiProfilesToDelete, appProfileCacheObj.PkiProfileCollection)
+		}
+
+		if appProfileCacheObj != nil && appProfileCacheObj.CloudConfigCksum == appProfileNode.GetCheckSum() {
+			utils.AviLog.Debugf("key: %s, msg: checksums are same for ApplicationProfile %s, not doing anything", key, appProfileNode.Name)
+		} else {
+			restOp := rest.AviApplicationProfileBuild(appProfileNode, appProfileCacheObj, key)
+			if restOp == nil {
+				return cacheAppProfileNodes, rest_ops, fmt.Errorf("failed to build ApplicationProfile %s", appProfileNode.Name)
+			}
+			rest_ops = append(rest_ops, restOp)
+		}
+		rest_ops = rest.PkiProfileDelete(pkiProfilesToDelete, namespace, rest_ops, key)
+	}
+	return cacheAppProfileNodes, rest_ops, nil
+}
+
+// ApplicationProfileDelete deletes the AKO managed ApplicationProfiles followed by the PKI profiles referred by them.
+func (rest *RestOperations) ApplicationProfileDelete(appProfileDelete []avicache.NamespaceName, namespace string, rest_ops []*utils.RestOp, key string) []*utils.RestOp {
+	utils.AviLog.Debugf("key: %s, msg: about to delete ApplicationProfiles %s", key, utils.Stringify(appProfileDelete))
+	for _, delAppProfile := range appProfileDelete {
+		appProfileKey := avicache.NamespaceName{Namespace: namespace, Name: delAppProfile.Name}
+		appProfileCache, ok := rest.cache.AppProfileCache.AviCacheGet(appProfileKey)
+		if ok {
+			appProfileCacheObj, _ := appProfileCache.(*avicache.AviApplicationProfileCache)
+			restOp := rest.AviApplicationProfileDel(appProfileCacheObj.Uuid, namespace, key)
+			restOp.ObjName = delAppProfile.Name
+			rest_ops = append(rest_ops, restOp)
+			if appProfileCacheObj.PkiProfileCollection.Name != "" {
+				rest_ops = rest.PkiProfileDelete([]avicache.NamespaceName{appProfileCacheObj.PkiProfileCollection}, namespace, rest_ops, key)
+			}
+		}
+	}
+	return rest_ops
+}
+
 func (rest *RestOperations) PkiProfileCU(pki_node *nodes.AviPkiProfileNode, pool_cache_obj *avicache.AviPoolCache, namespace string, rest_ops []*utils.RestOp, key string) ([]avicache.NamespaceName, []*utils.RestOp) {
 	// Default is POST
 	var cache_pki_nodes []avicache.NamespaceName
@@ -18,10 +18,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
 	akov1alpha2 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1alpha2"
 	akov1beta1 "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1beta1"
 
@@ -139,8 +142,15 @@ func UpdateHTTPRuleStatus(key string, rr *akov1beta1.HTTPRule, updateStatus Upda
 		}
 	}
 
+	httpRuleStatus := akov1beta1.HTTPRuleStatus{
+		Status: updateStatus.Status,
+		Error:  updateStatus.Error,
+	}
+	if updateStatus.Status == lib.StatusAccepted {
+		httpRuleStatus.Canary = BuildHTTPRuleCanaryStatus(rr)
+	}
 	patchPayload, _ := json.Marshal(map[string]interface{}{
-		"status": akov1beta1.HTTPRuleStatus(updateStatus),
+		"status": httpRuleStatus,
 	})
 
 	_, err := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HTTPRules(rr.Namespace).Patch(context.TODO(), rr.Name, types.MergePatchType, patchPayload, metav1.PatchOptions{}, "status")
@@ -160,6 +170,62 @@ func UpdateHTTPRuleStatus(key string, rr *akov1beta1.HTTPRule, updateStatus Upda
 	utils.AviLog.Infof("key: %s, msg: Successfully updated the httprule %s/%s status %+v", key, rr.Namespace, rr.Name, utils.Stringify(updateStatus))
 }
 
+// UpdateHTTPRuleCanaryStatus updates the status of the HTTPRule once the effective canary status of its paths changes.
+// The paths of the HTTPRules, which are not rejected, are applied to the Ingresses/Routes.
+func (l *leader) UpdateHTTPRuleCanaryStatus(key, namespace, name string) {
+	httpRuleObj, err := lib.AKOControlConfig().CRDInformers().HTTPRuleInformer.Lister().HTTPRules(namespace).Get(name)
+	if err != nil || httpRuleObj.Status.Status == lib.StatusRejected {
+		return
+	}
+	if reflect.DeepEqual(httpRuleObj.Status.Canary, BuildHTTPRuleCanaryStatus(httpRuleObj)) {
+		return
+	}
+	UpdateHTTPRuleStatus(key, httpRuleObj, UpdateCRDStatusOptions{
+		Status: lib.StatusAccepted,
+		Error:  "",
+	})
+}
+
+// BuildHTTPRuleCanaryStatus returns the effective traffic split between the backends of the HTTPRule paths and their
+// canary services, as attached to the paths of the Ingresses/Routes, or the reason the canary services are not attached.
+func BuildHTTPRuleCanaryStatus(rr *akov1beta1.HTTPRule) []akov1beta1.HTTPRuleCanaryStatus {
+	var canaryStatus []akov1beta1.HTTPRuleCanaryStatus
+	for _, path := range rr.Spec.Paths {
+		canary := path.Canary
+		if canary == nil {
+			continue
+		}
+		pathStatus := akov1beta1.HTTPRuleCanaryStatus{
+			Target:  path.Target,
+			Service: canary.Service,
+			Reason:  "canary service is not attached to any Ingress/Route path",
+		}
+		objStatus := objects.SharedCRDLister().GetHTTPRuleCanaryStatus(rr.Namespace + "/" + rr.Name + path.Target)
+		objNames := make([]string, 0, len(objStatus))
+		for objName := range objStatus {
+			objNames = append(objNames, objName)
+		}
+		sort.Strings(objNames)
+		// the canary attached to any of the Ingresses/Routes is reported, the first skip reason otherwise
+		skipped := false
+		for _, objName := range objNames {
+			if objStatus[objName].Service != canary.Service {
+				continue
+			}
+			if objStatus[objName].Reason == "" {
+				pathStatus = objStatus[objName]
+				break
+			}
+			if !skipped {
+				pathStatus = objStatus[objName]
+				skipped = true
+			}
+		}
+		canaryStatus = append(canaryStatus, pathStatus)
+	}
+	return canaryStatus
+}
+
 // HttpRuleEventBroadcast is responsible from broadcasting HttpRule specific events when the Pool Cache is Added/Updated/Deleted.
 func HttpRuleEventBroadcast(poolName string, poolCacheMetadataOld, vsMetadataNew lib.CRDMetadata) {
 	if poolCacheMetadataOld.Value != vsMetadataNew.Value {
@@ -35,12 +35,14 @@ import (
 	types "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 )
 
 type Status struct {
 	*gatewayv1.GatewayClassStatus
 	*gatewayv1.GatewayStatus
 	*gatewayv1.HTTPRouteStatus
+	*gatewayv1alpha2.PolicyStatus
 }
 type UpdateOptions struct {
 	// IngSvc format: namespace/name, not supposed to be provided by the caller
@@ -53,6 +55,7 @@ type UpdateOptions struct {
 	Message            string
 	Tenant             string
 	Status             *Status
+	RuntimeStatus      *lib.VSRuntimeStatus
 }
 
 // VSUuidAnnotation is maps a hostname to the UUID of the virtual service where it is placed.
@@ -117,6 +120,10 @@ func updateObject(mIngress *networkingv1.Ingress, updateOption UpdateOptions, re
 	for _, rule := range mIngress.Spec.Rules {
 		hostListIng = append(hostListIng, rule.Host)
 	}
+	if mIngress.Spec.DefaultBackend != nil && len(mIngress.Spec.Rules) == 0 {
+		// hostless Ingress served by its defaultBackend.
+		hostListIng = append(hostListIng, "")
+	}
 
 	// If we find a hostname in the present update, let's first remove it from the existing status.
 	for i := len(mIngress.Status.LoadBalancer.Ingress) - 1; i >= 0; i-- {
@@ -392,6 +399,10 @@ func deleteObject(option UpdateOptions, key string, isVSDelete bool, retryNum ..
 	for _, rule := range mIngress.Spec.Rules {
 		hostListIng = append(hostListIng, rule.Host)
 	}
+	if mIngress.Spec.DefaultBackend != nil && len(mIngress.Spec.Rules) == 0 {
+		// hostless Ingress served by its defaultBackend.
+		hostListIng = append(hostListIng, "")
+	}
 
 	for _, host := range option.ServiceMetadata.HostNames {
 		for i := len(mIngress.Status.LoadBalancer.Ingress) - 1; i >= 0; i-- {
@@ -45,6 +45,8 @@ type StatusPublisher interface {
 	UpdateMultiClusterIngressStatusAndAnnotation(key string, option *UpdateOptions)
 	DeleteMultiClusterIngressStatusAndAnnotation(key string, option *UpdateOptions)
 
+	RecordVSRuntimeStatus(objType string, option UpdateOptions)
+
 	AddStatefulSetAnnotation(statusName string, reason string)
 	ResetStatefulSetAnnotation(statusName string)
 }
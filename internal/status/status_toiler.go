@@ -44,6 +44,10 @@ func (l *leader) DequeueStatus(objIntf interface{}) error {
 		return nil
 	}
 	utils.AviLog.Infof("key: %s, msg: start status layer sync.", obj.Key)
+	if obj.Op == lib.UpdateRuntimeStatus {
+		l.RecordVSRuntimeStatus(obj.ObjType, *obj.Options)
+		return nil
+	}
 	switch obj.ObjType {
 	case utils.L4LBService:
 		if obj.Op == lib.UpdateStatus {
@@ -81,6 +85,10 @@ func (l *leader) DequeueStatus(objIntf interface{}) error {
 		} else if obj.Op == lib.DeleteStatus {
 			l.DeleteNPLAnnotation(obj.Key, obj.Namespace, obj.ObjName)
 		}
+	case lib.HTTPRule:
+		if obj.Op == lib.UpdateStatus {
+			l.UpdateHTTPRuleCanaryStatus(obj.Key, obj.Namespace, obj.ObjName)
+		}
 	case lib.MultiClusterIngress:
 		if obj.Op == lib.UpdateStatus {
 			l.UpdateMultiClusterIngressStatusAndAnnotation(obj.Key, obj.Options)
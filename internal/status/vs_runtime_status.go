@@ -0,0 +1,198 @@
+/*
+ * Copyright © 2025 Broadcom Inc. and/or its subsidiaries. All Rights Reserved.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package status
+
+import (
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+)
+
+// vsRuntimeStatusStore holds the operational state of the virtual services fetched in the last poll, shared
+// by all the objects placed on the virtual services.
+var vsRuntimeStatusStore = struct {
+	sync.RWMutex
+	statuses map[avicache.NamespaceName]lib.VSRuntimeStatus
+}{statuses: make(map[avicache.NamespaceName]lib.VSRuntimeStatus)}
+
+// GetVSRuntimeStatus returns the operational state of the virtual service fetched in the last poll.
+func GetVSRuntimeStatus(tenant, vsName string) (lib.VSRuntimeStatus, bool) {
+	vsRuntimeStatusStore.RLock()
+	defer vsRuntimeStatusStore.RUnlock()
+	vsRuntimeStatus, ok := vsRuntimeStatusStore.statuses[avicache.NamespaceName{Namespace: tenant, Name: vsName}]
+	return vsRuntimeStatus, ok
+}
+
+func VSRuntimeStatusMessage(vsName string, vsRuntimeStatus lib.VSRuntimeStatus) string {
+	if vsRuntimeStatus.Reason == "" {
+		return fmt.Sprintf("Virtual service %s is %s", vsName, vsRuntimeStatus.OperState)
+	}
+	return fmt.Sprintf("Virtual service %s is %s: %s", vsName, vsRuntimeStatus.OperState, vsRuntimeStatus.Reason)
+}
+
+// SyncVSRuntimeStatus polls the operational state of the virtual services created by AKO, and publishes a
+// status update for the objects placed on the virtual services whose state has changed since the last poll.
+func SyncVSRuntimeStatus() {
+	if lib.DisableSync || !lib.AKOControlConfig().IsLeader() {
+		return
+	}
+	aviRestClientPool := avicache.SharedAVIClients(lib.GetTenant())
+	if aviRestClientPool == nil || len(aviRestClientPool.AviClient) == 0 {
+		utils.AviLog.Warnf("No Avi client available to fetch the runtime status of virtual services")
+		return
+	}
+	vsRuntimeStatuses, err := avicache.FetchVSRuntimeStatus(aviRestClientPool.AviClient[0], utils.CloudName)
+	if err != nil {
+		utils.AviLog.Warnf("Unable to fetch the runtime status of virtual services, err: %v", err)
+		return
+	}
+
+	vsRuntimeStatusStore.Lock()
+	oldVSRuntimeStatuses := vsRuntimeStatusStore.statuses
+	vsRuntimeStatusStore.statuses = vsRuntimeStatuses
+	vsRuntimeStatusStore.Unlock()
+
+	for vsKey, vsRuntimeStatus := range vsRuntimeStatuses {
+		oldVSRuntimeStatus, found := oldVSRuntimeStatuses[vsKey]
+		if (!found && vsRuntimeStatus.IsUp()) || (found && oldVSRuntimeStatus == vsRuntimeStatus) {
+			continue
+		}
+		utils.AviLog.Infof("key: %s, msg: virtual service runtime status changed to %s", vsKey, utils.Stringify(vsRuntimeStatus))
+		publishVSRuntimeStatus(vsKey, vsRuntimeStatus)
+	}
+}
+
+func publishVSRuntimeStatus(vsKey avicache.NamespaceName, vsRuntimeStatus lib.VSRuntimeStatus) {
+	aviObjCache := avicache.SharedAviObjCache()
+	vsCache, ok := aviObjCache.VsCacheMeta.AviCacheGet(vsKey)
+	if !ok {
+		return
+	}
+	vsCacheObj, ok := vsCache.(*avicache.AviVsCache)
+	if !ok {
+		return
+	}
+
+	serviceMetadataList := []lib.ServiceMetadataObj{vsCacheObj.ServiceMetadataObj}
+	if vsCacheObj.ServiceMetadataObj.ServiceMetadataMapping("VS") == "" {
+		// shared virtual services carry the ingresses and routes in the service metadata of their pools.
+		serviceMetadataList = nil
+		ingresses := make(map[string]bool)
+		for _, poolKey := range vsCacheObj.PoolKeyCollection {
+			poolCache, ok := aviObjCache.PoolCache.AviCacheGet(poolKey)
+			if !ok {
+				continue
+			}
+			poolCacheObj, ok := poolCache.(*avicache.AviPoolCache)
+			if !ok || poolCacheObj.ServiceMetadataObj.ServiceMetadataMapping("Pool") != lib.SNIInsecureOrEVHPool {
+				continue
+			}
+			ingress := poolCacheObj.ServiceMetadataObj.Namespace + "/" + poolCacheObj.ServiceMetadataObj.IngressName
+			if !ingresses[ingress] {
+				ingresses[ingress] = true
+				serviceMetadataList = append(serviceMetadataList, poolCacheObj.ServiceMetadataObj)
+			}
+		}
+	}
+
+	key := vsKey.Namespace + "/" + vsKey.Name
+	for _, serviceMetadata := range serviceMetadataList {
+		objType := getVSRuntimeStatusObjType(serviceMetadata)
+		if objType == "" {
+			continue
+		}
+		runtimeStatus := vsRuntimeStatus
+		PublishToStatusQueue(key, StatusOptions{
+			ObjType: objType,
+			Op:      lib.UpdateRuntimeStatus,
+			Key:     key,
+			Options: &UpdateOptions{
+				ServiceMetadata:    serviceMetadata,
+				Key:                key,
+				VirtualServiceUUID: vsCacheObj.Uuid,
+				VSName:             vsKey.Name,
+				Tenant:             vsKey.Namespace,
+				RuntimeStatus:      &runtimeStatus,
+			},
+		})
+	}
+}
+
+func getVSRuntimeStatusObjType(serviceMetadata lib.ServiceMetadataObj) string {
+	if serviceMetadata.HTTPRoute != "" {
+		return lib.HTTPRoute
+	} else if serviceMetadata.Gateway != "" {
+		return lib.Gateway
+	} else if len(serviceMetadata.NamespaceServiceName) > 0 {
+		return utils.L4LBService
+	} else if !serviceMetadata.IsMCIIngress && (len(serviceMetadata.NamespaceIngressName) > 0 || serviceMetadata.IngressName != "") {
+		if utils.GetInformers().RouteInformer != nil {
+			return utils.OshiftRoute
+		}
+		return utils.Ingress
+	}
+	return ""
+}
+
+// RecordVSRuntimeStatus raises an event on the Services, Ingresses and Routes placed on a virtual service whose
+// operational state has changed.
+func (l *leader) RecordVSRuntimeStatus(objType string, option UpdateOptions) {
+	if option.RuntimeStatus == nil {
+		return
+	}
+	eventType, reason := corev1.EventTypeWarning, lib.VirtualServiceDown
+	if option.RuntimeStatus.IsUp() {
+		eventType, reason = corev1.EventTypeNormal, lib.VirtualServiceUp
+	}
+
+	var objs []runtime.Object
+	switch objType {
+	case utils.L4LBService:
+		for _, svc := range getServices(option.ServiceMetadata.NamespaceServiceName, false) {
+			objs = append(objs, svc)
+		}
+	case utils.Ingress:
+		for _, ingress := range getIngresses(getIngressNamesFromMetadata(option.ServiceMetadata), false) {
+			objs = append(objs, ingress)
+		}
+	case utils.OshiftRoute:
+		for _, route := range getRoutes(getIngressNamesFromMetadata(option.ServiceMetadata), false) {
+			objs = append(objs, route)
+		}
+	}
+
+	message := VSRuntimeStatusMessage(option.VSName, *option.RuntimeStatus)
+	for _, obj := range objs {
+		lib.AKOControlConfig().EventRecorder().Event(obj, eventType, reason, message)
+	}
+	utils.AviLog.Debugf("key: %s, msg: recorded runtime status of virtual service %s for %d objects", option.Key, option.VSName, len(objs))
+}
+
+func (f *follower) RecordVSRuntimeStatus(objType string, option UpdateOptions) {
+	utils.AviLog.Debugf("key: %s, AKO is not a leader, not recording the virtual service runtime status", option.Key)
+}
+
+func getIngressNamesFromMetadata(serviceMetadata lib.ServiceMetadataObj) []string {
+	if len(serviceMetadata.NamespaceIngressName) > 0 {
+		return serviceMetadata.NamespaceIngressName
+	}
+	return []string{serviceMetadata.Namespace + "/" + serviceMetadata.IngressName}
+}
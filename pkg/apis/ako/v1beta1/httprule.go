@@ -39,12 +39,15 @@ type HTTPRuleSpec struct {
 
 // HTTPRulePaths has settings for a specific target path
 type HTTPRulePaths struct {
-	Target                 string           `json:"target,omitempty"`
-	LoadBalancerPolicy     HTTPRuleLBPolicy `json:"loadBalancerPolicy,omitempty"`
-	TLS                    HTTPRuleTLS      `json:"tls,omitempty"`
-	HealthMonitors         []string         `json:"healthMonitors,omitempty"`
-	ApplicationPersistence string           `json:"applicationPersistence,omitempty"`
-	EnableHttp2            *bool            `json:"enableHTTP2,omitempty"`
+	Target                 string             `json:"target,omitempty"`
+	LoadBalancerPolicy     HTTPRuleLBPolicy   `json:"loadBalancerPolicy,omitempty"`
+	TLS                    HTTPRuleTLS        `json:"tls,omitempty"`
+	HealthMonitors         []string           `json:"healthMonitors,omitempty"`
+	ApplicationPersistence string             `json:"applicationPersistence,omitempty"`
+	EnableHttp2            *bool              `json:"enableHTTP2,omitempty"`
+	CORS                   *HTTPRuleCORS      `json:"cors,omitempty"`
+	Canary                 *HTTPRuleCanary    `json:"canary,omitempty"`
+	RateLimit              *HTTPRuleRateLimit `json:"rateLimit,omitempty"`
 }
 
 // HTTPRuleLBPolicy holds a path/pool's load balancer policies
@@ -62,10 +65,74 @@ type HTTPRuleTLS struct {
 	DestinationCA string `json:"destinationCA,omitempty"`
 }
 
+// HTTPRuleCORS holds the cross-origin resource sharing settings of a path
+type HTTPRuleCORS struct {
+	AllowOrigins     []string `json:"allowOrigins,omitempty"`
+	AllowMethods     []string `json:"allowMethods,omitempty"`
+	AllowHeaders     []string `json:"allowHeaders,omitempty"`
+	ExposeHeaders    []string `json:"exposeHeaders,omitempty"`
+	AllowCredentials bool     `json:"allowCredentials,omitempty"`
+	MaxAge           int32    `json:"maxAge,omitempty"`
+}
+
+// HTTPRuleCanary names the alternate service of a path, which receives either a weighted share
+// of the requests, or the requests matching the header and/or cookie
+type HTTPRuleCanary struct {
+	Service string               `json:"service"`
+	Port    int32                `json:"port"`
+	Weight  *int32               `json:"weight,omitempty"`
+	Header  *HTTPRuleCanaryMatch `json:"header,omitempty"`
+	Cookie  *HTTPRuleCanaryMatch `json:"cookie,omitempty"`
+}
+
+// HTTPRuleCanaryMatch holds the name and the exact value of a header or a cookie
+type HTTPRuleCanaryMatch struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HTTPRuleRateLimit limits the requests of a path to a number of requests per period, either per client IP
+// or for each of the listed values of a header. The requests are not keyed on arbitrary header values
+type HTTPRuleRateLimit struct {
+	Requests int32                      `json:"requests"`
+	Period   int32                      `json:"period,omitempty"`
+	Burst    int32                      `json:"burst,omitempty"`
+	KeyBy    string                     `json:"keyBy,omitempty"`
+	Header   *HTTPRuleRateLimitHeader   `json:"header,omitempty"`
+	Action   string                     `json:"action,omitempty"`
+	Redirect *HTTPRuleRateLimitRedirect `json:"redirect,omitempty"`
+}
+
+// HTTPRuleRateLimitHeader holds the name of the header and the values, each of which is limited separately,
+// the requests with any other value are limited per client IP
+type HTTPRuleRateLimitHeader struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+// HTTPRuleRateLimitRedirect holds the location the requests are redirected to once the limit is hit
+type HTTPRuleRateLimitRedirect struct {
+	Protocol   string `json:"protocol,omitempty"`
+	Host       string `json:"host,omitempty"`
+	Port       int32  `json:"port,omitempty"`
+	Path       string `json:"path,omitempty"`
+	StatusCode int32  `json:"statusCode,omitempty"`
+}
+
 // HTTPRuleStatus holds the status of the HTTPRule
 type HTTPRuleStatus struct {
-	Status string `json:"status,omitempty"`
-	Error  string `json:"error"`
+	Status string                 `json:"status,omitempty"`
+	Error  string                 `json:"error"`
+	Canary []HTTPRuleCanaryStatus `json:"canary"`
+}
+
+// HTTPRuleCanaryStatus holds the effective traffic split between the backends of a path and its canary service,
+// or the reason the canary service is not attached to the path
+type HTTPRuleCanaryStatus struct {
+	Target  string `json:"target"`
+	Service string `json:"service"`
+	Split   string `json:"split"`
+	Reason  string `json:"reason,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
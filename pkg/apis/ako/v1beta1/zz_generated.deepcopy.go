@@ -324,7 +324,7 @@ func (in *HTTPRule) DeepCopyInto(out *HTTPRule) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	in.Spec.DeepCopyInto(&out.Spec)
 	return
 }
@@ -347,6 +347,105 @@ func (in *HTTPRule) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRuleCORS) DeepCopyInto(out *HTTPRuleCORS) {
+	*out = *in
+	if in.AllowOrigins != nil {
+		in, out := &in.AllowOrigins, &out.AllowOrigins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowMethods != nil {
+		in, out := &in.AllowMethods, &out.AllowMethods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowHeaders != nil {
+		in, out := &in.AllowHeaders, &out.AllowHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExposeHeaders != nil {
+		in, out := &in.ExposeHeaders, &out.ExposeHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRuleCORS.
+func (in *HTTPRuleCORS) DeepCopy() *HTTPRuleCORS {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRuleCORS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRuleCanary) DeepCopyInto(out *HTTPRuleCanary) {
+	*out = *in
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Header != nil {
+		in, out := &in.Header, &out.Header
+		*out = new(HTTPRuleCanaryMatch)
+		**out = **in
+	}
+	if in.Cookie != nil {
+		in, out := &in.Cookie, &out.Cookie
+		*out = new(HTTPRuleCanaryMatch)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRuleCanary.
+func (in *HTTPRuleCanary) DeepCopy() *HTTPRuleCanary {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRuleCanary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRuleCanaryMatch) DeepCopyInto(out *HTTPRuleCanaryMatch) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRuleCanaryMatch.
+func (in *HTTPRuleCanaryMatch) DeepCopy() *HTTPRuleCanaryMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRuleCanaryMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRuleCanaryStatus) DeepCopyInto(out *HTTPRuleCanaryStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRuleCanaryStatus.
+func (in *HTTPRuleCanaryStatus) DeepCopy() *HTTPRuleCanaryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRuleCanaryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPRuleLBPolicy) DeepCopyInto(out *HTTPRuleLBPolicy) {
 	*out = *in
@@ -411,6 +510,21 @@ func (in *HTTPRulePaths) DeepCopyInto(out *HTTPRulePaths) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.CORS != nil {
+		in, out := &in.CORS, &out.CORS
+		*out = new(HTTPRuleCORS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(HTTPRuleCanary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(HTTPRuleRateLimit)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -424,6 +538,69 @@ func (in *HTTPRulePaths) DeepCopy() *HTTPRulePaths {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRuleRateLimit) DeepCopyInto(out *HTTPRuleRateLimit) {
+	*out = *in
+	if in.Header != nil {
+		in, out := &in.Header, &out.Header
+		*out = new(HTTPRuleRateLimitHeader)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Redirect != nil {
+		in, out := &in.Redirect, &out.Redirect
+		*out = new(HTTPRuleRateLimitRedirect)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRuleRateLimit.
+func (in *HTTPRuleRateLimit) DeepCopy() *HTTPRuleRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRuleRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRuleRateLimitHeader) DeepCopyInto(out *HTTPRuleRateLimitHeader) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRuleRateLimitHeader.
+func (in *HTTPRuleRateLimitHeader) DeepCopy() *HTTPRuleRateLimitHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRuleRateLimitHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRuleRateLimitRedirect) DeepCopyInto(out *HTTPRuleRateLimitRedirect) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRuleRateLimitRedirect.
+func (in *HTTPRuleRateLimitRedirect) DeepCopy() *HTTPRuleRateLimitRedirect {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRuleRateLimitRedirect)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPRuleSpec) DeepCopyInto(out *HTTPRuleSpec) {
 	*out = *in
@@ -450,6 +627,11 @@ func (in *HTTPRuleSpec) DeepCopy() *HTTPRuleSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPRuleStatus) DeepCopyInto(out *HTTPRuleStatus) {
 	*out = *in
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = make([]HTTPRuleCanaryStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -84,6 +84,7 @@ const (
 	GlobalVRF                     = "global"
 	VRF_CONTEXT                   = "VRF_CONTEXT"
 	FULL_SYNC_INTERVAL            = "FULL_SYNC_INTERVAL"
+	VS_RUNTIME_SYNC_INTERVAL      = "VS_RUNTIME_SYNC_INTERVAL"
 	DEFAULT_FILE_SUFFIX           = "avi.log"
 	K8S_ETIMEDOUT                 = "timed out"
 	K8S_UNAUTHORIZED              = "Unauthorized"
@@ -99,6 +100,8 @@ const (
 	VPC_MODE                      = "VPC_MODE"
 	MCI_ENABLED                   = "MCI_ENABLED"
 	USE_DEFAULT_SECRETS_ONLY      = "USE_DEFAULT_SECRETS_ONLY"
+	CRD_VALIDATION_WEBHOOK        = "CRD_VALIDATION_WEBHOOK"
+	CRD_WEBHOOK_FAIL_OPEN         = "CRD_WEBHOOK_FAIL_OPEN"
 	Namespace                     = "Namespace"
 	MaxAviVersion                 = "30.2.1"
 	ControllerAPIHeader           = "userHeader"
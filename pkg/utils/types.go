@@ -224,6 +224,7 @@ type AviObjectMarkers struct {
 	HTTPRouteRuleName  string
 	BackendName        string
 	BackendNs          string
+	Labels             map[string]string
 }
 
 /*
@@ -36,6 +36,7 @@ import (
 
 	"github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
 )
@@ -533,3 +534,75 @@ func TestPortsForSecureDedicatedShard(t *testing.T) {
 	g.Expect(ports[1]).To(gomega.Equal(443))
 	TearDownIngressForCacheSyncCheck(t, secretName, ingressName, svcName, modelName)
 }
+
+func TestDefaultBackendForDedicatedShard(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	modelName := "admin/cluster--foo.com-L7-dedicated"
+	vsName := "cluster--foo.com-L7-dedicated"
+	ingressName := objNameMap.GenerateName("foo-with-targets")
+	svcName := objNameMap.GenerateName("avisvc")
+	defaultSvcName := objNameMap.GenerateName("avisvc")
+
+	SetUpTestForIngress(t, svcName, modelName)
+	integrationtest.CreateSVC(t, "default", defaultSvcName, corev1.ProtocolTCP, corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPS(t, "default", defaultSvcName, false, false, "2.2.2")
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        ingressName,
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		Paths:       []string{"/foo"},
+		ServiceName: svcName,
+	}).Ingress()
+	ingrFake.Spec.DefaultBackend = &networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{
+			Name: defaultSvcName,
+			Port: networkingv1.ServiceBackendPort{Number: 8080},
+		},
+	}
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+
+	pgName := lib.GetL7DefaultBackendPGName(vsName, "default", ingressName)
+	poolName := lib.GetL7DefaultBackendPoolName(vsName, "default", ingressName)
+	g.Eventually(func() string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes, ok := aviModel.(*avinodes.AviObjectGraph)
+		if !ok || len(nodes.GetAviVS()) == 0 {
+			return ""
+		}
+		return nodes.GetAviVS()[0].DefaultPoolGroup
+	}, 20*time.Second).Should(gomega.Equal(pgName))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes[0].PoolRefs).To(gomega.HaveLen(2))
+	g.Expect(nodes[0].PoolGroupRefs).To(gomega.HaveLen(2))
+	for _, pool := range nodes[0].PoolRefs {
+		if pool.Name == poolName {
+			g.Expect(pool.Servers).NotTo(gomega.BeEmpty())
+			g.Expect(*pool.Servers[0].Ip.Addr).To(gomega.HavePrefix("2.2.2"))
+		}
+	}
+
+	// dropping the defaultBackend removes the default pool group from the VS.
+	ingrFake.Spec.DefaultBackend = nil
+	ingrFake.ResourceVersion = "2"
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Update(context.TODO(), ingrFake, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Ingress: %v", err)
+	}
+	g.Eventually(func() string {
+		return aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].DefaultPoolGroup
+	}, 20*time.Second).Should(gomega.BeEmpty())
+	g.Expect(aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].PoolRefs).To(gomega.HaveLen(1))
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), ingressName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	VerifyIngressDeletion(t, g, aviModel, 0)
+	integrationtest.DelSVC(t, "default", defaultSvcName)
+	integrationtest.DelEPS(t, "default", defaultSvcName)
+	TearDownTestForIngress(t, svcName, modelName)
+}
@@ -1591,6 +1591,649 @@ func TestHTTPRuleCreateDeleteWithEnableHTTP2ForEvh(t *testing.T) {
 	TearDownIngressForCacheSyncCheck(t, secretName, ingressName, svcName, modelName)
 }
 
+func setServiceAppProtocol(t *testing.T, svcName, appProtocol string) {
+	svcObj, err := KubeClient.CoreV1().Services("default").Get(context.TODO(), svcName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("error in getting Service: %v", err)
+	}
+	svcObj.Spec.Ports[0].AppProtocol = &appProtocol
+	svcObj.ResourceVersion = strconv.Itoa(int(time.Now().UnixNano()))
+	if _, err := KubeClient.CoreV1().Services("default").Update(context.TODO(), svcObj, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+}
+
+func TestServiceAppProtocolWithHTTPRuleForEvh(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName, _ := GetModelName("foo.com", "default")
+	rrName := objNameMap.GenerateName("samplerr-foo")
+
+	SetupDomain()
+	secretName := objNameMap.GenerateName("my-secret")
+	ingressName := objNameMap.GenerateName("foo-with-targets")
+	svcName := objNameMap.GenerateName("avisvc")
+	SetUpTestForIngress(t, svcName, modelName)
+	setServiceAppProtocol(t, svcName, lib.AppProtocolH2C)
+	integrationtest.AddSecret(secretName, "default", "tlsCert", "tlsKey")
+	integrationtest.PollForCompletion(t, modelName, 5)
+	ingressObject := integrationtest.FakeIngress{
+		Name:        ingressName,
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		Paths:       []string{"/foo", "/bar"},
+		ServiceName: svcName,
+		TlsSecretDNS: map[string][]string{
+			secretName: {"foo.com"},
+		},
+	}
+
+	ingrFake := ingressObject.Ingress(true)
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	getPool := func() *avinodes.AviPoolNode {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found || aviModel == nil {
+			return nil
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes) != 1 || len(nodes[0].EvhNodes) != 1 || len(nodes[0].EvhNodes[0].PoolRefs) != 2 {
+			return nil
+		}
+		return nodes[0].EvhNodes[0].PoolRefs[0]
+	}
+	getEnableHTTP2 := func() *bool {
+		if pool := getPool(); pool != nil {
+			return pool.EnableHttp2
+		}
+		return nil
+	}
+
+	// kubernetes.io/h2c enables HTTP/2 on the pool
+	g.Eventually(getEnableHTTP2, 10*time.Second).ShouldNot(gomega.BeNil())
+	g.Expect(*getEnableHTTP2()).To(gomega.BeTrue())
+	g.Expect(getPool().SslProfileRef).To(gomega.BeNil())
+
+	// enableHTTP2 of the HTTPRule takes precedence over the appProtocol
+	httpRulePath := "/"
+	httprule := integrationtest.FakeHTTPRule{
+		Name:      rrName,
+		Namespace: "default",
+		Fqdn:      "foo.com",
+		PathProperties: []integrationtest.FakeHTTPRulePath{{
+			Path:        httpRulePath,
+			EnableHTTP2: false,
+		}},
+	}
+	rrCreate := httprule.HTTPRule()
+	if _, err := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HTTPRules("default").Create(context.TODO(), rrCreate, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+	g.Eventually(func() bool {
+		enableHTTP2 := getEnableHTTP2()
+		return enableHTTP2 != nil && !*enableHTTP2
+	}, 10*time.Second).Should(gomega.BeTrue())
+
+	integrationtest.TeardownHTTPRule(t, rrName)
+	g.Eventually(func() bool {
+		enableHTTP2 := getEnableHTTP2()
+		return enableHTTP2 != nil && *enableHTTP2
+	}, 10*time.Second).Should(gomega.BeTrue())
+
+	// https enables SSL towards the backends
+	setServiceAppProtocol(t, svcName, lib.AppProtocolHTTPS)
+	g.Eventually(func() bool {
+		pool := getPool()
+		return pool != nil && pool.SslProfileRef != nil
+	}, 10*time.Second).Should(gomega.BeTrue())
+	g.Expect(getPool().SniEnabled).To(gomega.BeTrue())
+	g.Expect(*getPool().SslProfileRef).To(gomega.Equal("/api/sslprofile?name=" + lib.DefaultPoolSSLProfile))
+	g.Expect(getPool().EnableHttp2).To(gomega.BeNil())
+
+	TearDownIngressForCacheSyncCheck(t, secretName, ingressName, svcName, modelName)
+}
+
+func TestHTTPRuleCreateDeleteWithCORSForEvh(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName, _ := GetModelName("foo.com", "default")
+	rrName := objNameMap.GenerateName("samplerr-foo")
+
+	SetupDomain()
+	secretName := objNameMap.GenerateName("my-secret")
+	ingressName := objNameMap.GenerateName("foo-with-targets")
+	svcName := objNameMap.GenerateName("avisvc")
+	SetUpTestForIngress(t, svcName, modelName)
+	integrationtest.AddSecret(secretName, "default", "tlsCert", "tlsKey")
+	integrationtest.PollForCompletion(t, modelName, 5)
+	ingressObject := integrationtest.FakeIngress{
+		Name:        ingressName,
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		Paths:       []string{"/foo", "/bar"},
+		ServiceName: svcName,
+		TlsSecretDNS: map[string][]string{
+			secretName: {"foo.com"},
+		},
+	}
+
+	ingrFake := ingressObject.Ingress(true)
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	httprule := integrationtest.FakeHTTPRule{
+		Name:      rrName,
+		Namespace: "default",
+		Fqdn:      "foo.com",
+		PathProperties: []integrationtest.FakeHTTPRulePath{{
+			Path: "/foo",
+			CORS: &v1beta1.HTTPRuleCORS{
+				AllowOrigins:     []string{"https://app.foo.com"},
+				AllowMethods:     []string{"GET", "PUT"},
+				AllowCredentials: true,
+				MaxAge:           600,
+			},
+		}},
+	}
+	rrCreate := httprule.HTTPRule()
+	if _, err := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HTTPRules("default").Create(context.TODO(), rrCreate, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+
+	getCORSPolicy := func() *avinodes.AviHttpPolicySetNode {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes) == 0 || len(nodes[0].EvhNodes) == 0 {
+			return nil
+		}
+		for _, policy := range nodes[0].EvhNodes[0].HttpPolicyRefs {
+			if policy.Name == lib.GetCORSPolicy(nodes[0].EvhNodes[0].Name) {
+				return policy
+			}
+		}
+		return nil
+	}
+	g.Eventually(func() bool {
+		return getCORSPolicy() != nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	policy := getCORSPolicy()
+	g.Expect(policy.RequestRules).To(gomega.HaveLen(1))
+	g.Expect(*policy.RequestRules[0].Match.Path.MatchCriteria).To(gomega.Equal("BEGINS_WITH"))
+	g.Expect(policy.RequestRules[0].Match.Path.MatchStr).To(gomega.Equal([]string{"/foo"}))
+	g.Expect(*policy.RequestRules[0].SwitchingAction.StatusCode).To(gomega.Equal("HTTP_LOCAL_RESPONSE_STATUS_CODE_204"))
+	g.Expect(policy.ResponseRules).To(gomega.HaveLen(2))
+	g.Expect(*policy.ResponseRules[0].HdrAction[0].Hdr.Value.Val).To(gomega.Equal("https://app.foo.com"))
+	g.Expect(*policy.ResponseRules[1].HdrAction[0].Hdr.Value.Val).To(gomega.Equal("GET, PUT"))
+	g.Expect(*policy.ResponseRules[1].HdrAction[1].Hdr.Value.Val).To(gomega.Equal("600"))
+
+	// delete httprule removes the CORS policy
+	integrationtest.TeardownHTTPRule(t, rrName)
+	g.Eventually(func() bool {
+		return getCORSPolicy() == nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	// wildcard origin can not be echoed when credentials are allowed
+	httprule.PathProperties[0].CORS.AllowOrigins = []string{"*"}
+	rrCreate = httprule.HTTPRule()
+	if _, err := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HTTPRules("default").Create(context.TODO(), rrCreate, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		httpRule, _ := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HTTPRules("default").Get(context.TODO(), rrName, metav1.GetOptions{})
+		return httpRule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Rejected"))
+	g.Expect(getCORSPolicy()).To(gomega.BeNil())
+
+	integrationtest.TeardownHTTPRule(t, rrName)
+	TearDownIngressForCacheSyncCheck(t, secretName, ingressName, svcName, modelName)
+}
+
+func TestHTTPRuleCreateUpdateDeleteWithCanaryForEvh(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName, _ := GetModelName("foo.com", "default")
+	rrName := objNameMap.GenerateName("samplerr-foo")
+
+	SetupDomain()
+	secretName := objNameMap.GenerateName("my-secret")
+	ingressName := objNameMap.GenerateName("foo-with-targets")
+	svcName := objNameMap.GenerateName("avisvc")
+	canarySvcName := objNameMap.GenerateName("avisvc-canary")
+	SetUpTestForIngress(t, svcName, modelName)
+	integrationtest.CreateSVC(t, "default", canarySvcName, corev1.ProtocolTCP, corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPS(t, "default", canarySvcName, false, false, "2.1.1")
+	integrationtest.AddSecret(secretName, "default", "tlsCert", "tlsKey")
+	integrationtest.PollForCompletion(t, modelName, 5)
+	ingressObject := integrationtest.FakeIngress{
+		Name:        ingressName,
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		Paths:       []string{"/foo", "/bar"},
+		ServiceName: svcName,
+		TlsSecretDNS: map[string][]string{
+			secretName: {"foo.com"},
+		},
+	}
+
+	ingrFake := ingressObject.Ingress(true)
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	weight := int32(20)
+	httprule := integrationtest.FakeHTTPRule{
+		Name:      rrName,
+		Namespace: "default",
+		Fqdn:      "foo.com",
+		PathProperties: []integrationtest.FakeHTTPRulePath{{
+			Path: "/foo",
+			Canary: &v1beta1.HTTPRuleCanary{
+				Service: canarySvcName,
+				Port:    8080,
+				Weight:  &weight,
+			},
+		}},
+	}
+	rrCreate := httprule.HTTPRule()
+	if _, err := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HTTPRules("default").Create(context.TODO(), rrCreate, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+
+	getEvhNode := func() *avinodes.AviEvhVsNode {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return nil
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes) == 0 || len(nodes[0].EvhNodes) == 0 {
+			return nil
+		}
+		return nodes[0].EvhNodes[0]
+	}
+	getCanaryPool := func() *avinodes.AviPoolNode {
+		evhNode := getEvhNode()
+		if evhNode == nil {
+			return nil
+		}
+		for _, pool := range evhNode.PoolRefs {
+			if pool.HTTPRuleCanary {
+				return pool
+			}
+		}
+		return nil
+	}
+	getCanaryPolicy := func() *avinodes.AviHttpPolicySetNode {
+		evhNode := getEvhNode()
+		if evhNode == nil {
+			return nil
+		}
+		for _, policy := range evhNode.HttpPolicyRefs {
+			if policy.Name == lib.GetHTTPRuleCanaryPolicy(evhNode.Name) {
+				return policy
+			}
+		}
+		return nil
+	}
+	getPGRatios := func() []uint32 {
+		evhNode := getEvhNode()
+		if evhNode == nil {
+			return nil
+		}
+		var ratios []uint32
+		for _, pgNode := range evhNode.PoolGroupRefs {
+			if len(pgNode.AviMarkers.Path) == 0 || pgNode.AviMarkers.Path[0] != "/foo" {
+				continue
+			}
+			for _, member := range pgNode.Members {
+				ratios = append(ratios, *member.Ratio)
+			}
+		}
+		sort.Slice(ratios, func(i, j int) bool { return ratios[i] > ratios[j] })
+		return ratios
+	}
+
+	// weighted canary shares the requests of the path /foo
+	g.Eventually(func() []uint32 {
+		return getPGRatios()
+	}, 25*time.Second).Should(gomega.Equal([]uint32{80, 20}))
+	canaryPool := getCanaryPool()
+	g.Expect(canaryPool).NotTo(gomega.BeNil())
+	g.Expect(canaryPool.AviMarkers.ServiceName).To(gomega.Equal(canarySvcName))
+	g.Expect(canaryPool.Servers).To(gomega.HaveLen(1))
+	g.Expect(*canaryPool.Servers[0].Ip.Addr).To(gomega.Equal("2.1.1.1"))
+	g.Expect(getCanaryPolicy()).To(gomega.BeNil())
+	g.Eventually(func() []v1beta1.HTTPRuleCanaryStatus {
+		httpRule, _ := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HTTPRules("default").Get(context.TODO(), rrName, metav1.GetOptions{})
+		return httpRule.Status.Canary
+	}, 10*time.Second).Should(gomega.Equal([]v1beta1.HTTPRuleCanaryStatus{{
+		Target:  "/foo",
+		Service: canarySvcName,
+		Split:   "80% primary, 20% canary",
+	}}))
+
+	// header/cookie canary is selected by the switching rule of the canary policy
+	httprule.PathProperties[0].Canary.Weight = nil
+	httprule.PathProperties[0].Canary.Header = &v1beta1.HTTPRuleCanaryMatch{Name: "x-canary", Value: "always"}
+	httprule.PathProperties[0].Canary.Cookie = &v1beta1.HTTPRuleCanaryMatch{Name: "release", Value: "canary"}
+	rrUpdate := httprule.HTTPRule()
+	rrUpdate.ResourceVersion = "2"
+	if _, err := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HTTPRules("default").Update(context.TODO(), rrUpdate, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HTTPRule: %v", err)
+	}
+	g.Eventually(func() bool {
+		return getCanaryPolicy() != nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+	g.Expect(getPGRatios()).To(gomega.Equal([]uint32{100}))
+	canaryPool = getCanaryPool()
+	g.Expect(canaryPool).NotTo(gomega.BeNil())
+	evhNode := getEvhNode()
+	g.Expect(evhNode.HttpPolicyRefs[0].Name).To(gomega.Equal(lib.GetHTTPRuleCanaryPolicy(evhNode.Name)))
+	policy := getCanaryPolicy()
+	g.Expect(policy.RequestRules).To(gomega.HaveLen(1))
+	g.Expect(policy.RequestRules[0].Match.Path.MatchStr).To(gomega.Equal([]string{"/foo"}))
+	g.Expect(*policy.RequestRules[0].Match.Hdrs[0].Hdr).To(gomega.Equal("x-canary"))
+	g.Expect(policy.RequestRules[0].Match.Hdrs[0].Value).To(gomega.Equal([]string{"always"}))
+	g.Expect(*policy.RequestRules[0].Match.Cookie.Name).To(gomega.Equal("release"))
+	g.Expect(*policy.RequestRules[0].Match.Cookie.Value).To(gomega.Equal("canary"))
+	g.Expect(*policy.RequestRules[0].SwitchingAction.Action).To(gomega.Equal("HTTP_SWITCHING_SELECT_POOL"))
+	g.Expect(*policy.RequestRules[0].SwitchingAction.PoolRef).To(gomega.Equal("/api/pool/?name=" + canaryPool.Name))
+
+	// delete httprule removes the canary pool and the canary policy
+	integrationtest.TeardownHTTPRule(t, rrName)
+	g.Eventually(func() bool {
+		return getCanaryPool() == nil && getCanaryPolicy() == nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+	g.Expect(getPGRatios()).To(gomega.Equal([]uint32{100}))
+
+	// weight and header/cookie match can not be set together
+	httprule.PathProperties[0].Canary.Weight = &weight
+	rrCreate = httprule.HTTPRule()
+	if _, err := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HTTPRules("default").Create(context.TODO(), rrCreate, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		httpRule, _ := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HTTPRules("default").Get(context.TODO(), rrName, metav1.GetOptions{})
+		return httpRule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Rejected"))
+	g.Expect(getCanaryPool()).To(gomega.BeNil())
+
+	integrationtest.TeardownHTTPRule(t, rrName)
+	integrationtest.DelSVC(t, "default", canarySvcName)
+	integrationtest.DelEPS(t, "default", canarySvcName)
+	TearDownIngressForCacheSyncCheck(t, secretName, ingressName, svcName, modelName)
+}
+
+func TestHTTPRuleCanaryStatusWithMissingServiceForEvh(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName, _ := GetModelName("foo.com", "default")
+	rrName := objNameMap.GenerateName("samplerr-foo")
+
+	SetupDomain()
+	secretName := objNameMap.GenerateName("my-secret")
+	ingressName := objNameMap.GenerateName("foo-with-targets")
+	svcName := objNameMap.GenerateName("avisvc")
+	canarySvcName := objNameMap.GenerateName("avisvc-canary")
+	SetUpTestForIngress(t, svcName, modelName)
+	integrationtest.AddSecret(secretName, "default", "tlsCert", "tlsKey")
+	integrationtest.PollForCompletion(t, modelName, 5)
+	ingressObject := integrationtest.FakeIngress{
+		Name:        ingressName,
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		Paths:       []string{"/foo", "/bar"},
+		ServiceName: svcName,
+		TlsSecretDNS: map[string][]string{
+			secretName: {"foo.com"},
+		},
+	}
+
+	ingrFake := ingressObject.Ingress(true)
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	weight := int32(33)
+	httprule := integrationtest.FakeHTTPRule{
+		Name:      rrName,
+		Namespace: "default",
+		Fqdn:      "foo.com",
+		PathProperties: []integrationtest.FakeHTTPRulePath{{
+			Path: "/foo",
+			Canary: &v1beta1.HTTPRuleCanary{
+				Service: canarySvcName,
+				Port:    8080,
+				Weight:  &weight,
+			},
+		}},
+	}
+	rrCreate := httprule.HTTPRule()
+	if _, err := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HTTPRules("default").Create(context.TODO(), rrCreate, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+	getCanaryStatus := func() []v1beta1.HTTPRuleCanaryStatus {
+		httpRule, _ := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HTTPRules("default").Get(context.TODO(), rrName, metav1.GetOptions{})
+		return httpRule.Status.Canary
+	}
+	hasCanaryPool := func() bool {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return false
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes) == 0 || len(nodes[0].EvhNodes) == 0 {
+			return false
+		}
+		for _, pool := range nodes[0].EvhNodes[0].PoolRefs {
+			if pool.HTTPRuleCanary {
+				return true
+			}
+		}
+		return false
+	}
+
+	// the canary is not attached until its service exists, and the reason is reported in the status
+	g.Eventually(getCanaryStatus, 25*time.Second).Should(gomega.Equal([]v1beta1.HTTPRuleCanaryStatus{{
+		Target:  "/foo",
+		Service: canarySvcName,
+		Reason:  "canary service " + canarySvcName + " not found",
+	}}))
+	g.Expect(hasCanaryPool()).To(gomega.BeFalse())
+
+	integrationtest.CreateSVC(t, "default", canarySvcName, corev1.ProtocolTCP, corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPS(t, "default", canarySvcName, false, false, "2.1.1")
+	g.Eventually(getCanaryStatus, 25*time.Second).Should(gomega.Equal([]v1beta1.HTTPRuleCanaryStatus{{
+		Target:  "/foo",
+		Service: canarySvcName,
+		Split:   "67% primary, 33% canary",
+	}}))
+	g.Expect(hasCanaryPool()).To(gomega.BeTrue())
+
+	integrationtest.TeardownHTTPRule(t, rrName)
+	integrationtest.DelSVC(t, "default", canarySvcName)
+	integrationtest.DelEPS(t, "default", canarySvcName)
+	TearDownIngressForCacheSyncCheck(t, secretName, ingressName, svcName, modelName)
+}
+
+func TestHTTPRuleCreateUpdateDeleteWithRateLimitForEvh(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	modelName, _ := GetModelName("foo.com", "default")
+	rrName := objNameMap.GenerateName("samplerr-foo")
+
+	SetupDomain()
+	secretName := objNameMap.GenerateName("my-secret")
+	ingressName := objNameMap.GenerateName("foo-with-targets")
+	svcName := objNameMap.GenerateName("avisvc")
+	SetUpTestForIngress(t, svcName, modelName)
+	integrationtest.AddSecret(secretName, "default", "tlsCert", "tlsKey")
+	integrationtest.PollForCompletion(t, modelName, 5)
+	ingressObject := integrationtest.FakeIngress{
+		Name:        ingressName,
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		Paths:       []string{"/foo", "/bar"},
+		ServiceName: svcName,
+		TlsSecretDNS: map[string][]string{
+			secretName: {"foo.com"},
+		},
+	}
+
+	ingrFake := ingressObject.Ingress(true)
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	httprule := integrationtest.FakeHTTPRule{
+		Name:      rrName,
+		Namespace: "default",
+		Fqdn:      "foo.com",
+		PathProperties: []integrationtest.FakeHTTPRulePath{{
+			Path: "/foo",
+			RateLimit: &v1beta1.HTTPRuleRateLimit{
+				Requests: 10,
+				Period:   60,
+				Burst:    20,
+				KeyBy:    "Header",
+				Header: &v1beta1.HTTPRuleRateLimitHeader{
+					Name:   "x-api-key",
+					Values: []string{"key-b", "key-a"},
+				},
+				Action: "TooManyRequests",
+			},
+		}},
+	}
+	rrCreate := httprule.HTTPRule()
+	if _, err := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HTTPRules("default").Create(context.TODO(), rrCreate, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+
+	getRateLimitPolicy := func() *avinodes.AviHttpPolicySetNode {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return nil
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes) == 0 || len(nodes[0].EvhNodes) == 0 {
+			return nil
+		}
+		for _, policy := range nodes[0].EvhNodes[0].HttpPolicyRefs {
+			if policy.Name == lib.GetHTTPRuleRateLimitPolicy(nodes[0].EvhNodes[0].Name) {
+				return policy
+			}
+		}
+		return nil
+	}
+	g.Eventually(func() bool {
+		return getRateLimitPolicy() != nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	// a rule is added for each of the header values, followed by a rule per client IP for the other requests
+	policy := getRateLimitPolicy()
+	g.Expect(policy.HTTPSecurityRules).To(gomega.HaveLen(3))
+	for i, value := range []string{"key-a", "key-b"} {
+		rule := policy.HTTPSecurityRules[i]
+		g.Expect(*rule.Index).To(gomega.Equal(int32(i + 1)))
+		g.Expect(rule.Match.Path.MatchStr).To(gomega.Equal([]string{"/foo"}))
+		g.Expect(*rule.Match.Hdrs[0].Hdr).To(gomega.Equal("x-api-key"))
+		g.Expect(rule.Match.Hdrs[0].Value).To(gomega.Equal([]string{value}))
+		g.Expect(*rule.Action.Action).To(gomega.Equal("HTTP_SECURITY_ACTION_RATE_LIMIT"))
+		g.Expect(*rule.Action.RateProfile.PerClientIP).To(gomega.Equal(false))
+		g.Expect(*rule.Action.RateProfile.RateLimiter.Count).To(gomega.Equal(uint32(10)))
+		g.Expect(*rule.Action.RateProfile.RateLimiter.Period).To(gomega.Equal(uint32(60)))
+		g.Expect(*rule.Action.RateProfile.RateLimiter.BurstSz).To(gomega.Equal(uint32(20)))
+		g.Expect(*rule.Action.RateProfile.Action.Type).To(gomega.Equal("RL_ACTION_LOCAL_RSP"))
+		g.Expect(*rule.Action.RateProfile.Action.StatusCode).To(gomega.Equal("HTTP_LOCAL_RESPONSE_STATUS_CODE_429"))
+	}
+	catchAllRule := policy.HTTPSecurityRules[2]
+	g.Expect(*catchAllRule.Index).To(gomega.Equal(int32(3)))
+	g.Expect(catchAllRule.Match.Path.MatchStr).To(gomega.Equal([]string{"/foo"}))
+	g.Expect(catchAllRule.Match.Hdrs).To(gomega.BeEmpty())
+	g.Expect(*catchAllRule.Action.RateProfile.PerClientIP).To(gomega.Equal(true))
+	g.Expect(*catchAllRule.Action.RateProfile.RateLimiter.Count).To(gomega.Equal(uint32(10)))
+	g.Expect(*catchAllRule.Action.RateProfile.Action.StatusCode).To(gomega.Equal("HTTP_LOCAL_RESPONSE_STATUS_CODE_429"))
+
+	// client IP key with the default drop action
+	httprule.PathProperties[0].RateLimit = &v1beta1.HTTPRuleRateLimit{Requests: 5}
+	rrUpdate := httprule.HTTPRule()
+	rrUpdate.ResourceVersion = "2"
+	if _, err := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HTTPRules("default").Update(context.TODO(), rrUpdate, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HTTPRule: %v", err)
+	}
+	g.Eventually(func() int {
+		policy := getRateLimitPolicy()
+		if policy == nil {
+			return 0
+		}
+		return len(policy.HTTPSecurityRules)
+	}, 25*time.Second).Should(gomega.Equal(1))
+	rule := getRateLimitPolicy().HTTPSecurityRules[0]
+	g.Expect(rule.Match.Hdrs).To(gomega.BeNil())
+	g.Expect(*rule.Action.RateProfile.PerClientIP).To(gomega.Equal(true))
+	g.Expect(*rule.Action.RateProfile.RateLimiter.Period).To(gomega.Equal(uint32(1)))
+	g.Expect(*rule.Action.RateProfile.Action.Type).To(gomega.Equal("RL_ACTION_DROP_CONN"))
+
+	// the rate limit is removed along with the path
+	ingressObject.Paths = []string{"/bar"}
+	ingressUpdate := ingressObject.Ingress()
+	ingressUpdate.ResourceVersion = "2"
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Update(context.TODO(), ingressUpdate, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Ingress: %v", err)
+	}
+	g.Eventually(func() bool {
+		return getRateLimitPolicy() == nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+	integrationtest.TeardownHTTPRule(t, rrName)
+
+	// redirect action requires the redirect settings
+	httprule.PathProperties[0].Path = "/bar"
+	httprule.PathProperties[0].RateLimit = &v1beta1.HTTPRuleRateLimit{Requests: 5, Action: "Redirect"}
+	rrCreate = httprule.HTTPRule()
+	if _, err := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HTTPRules("default").Create(context.TODO(), rrCreate, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		httpRule, _ := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HTTPRules("default").Get(context.TODO(), rrName, metav1.GetOptions{})
+		return httpRule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Rejected"))
+	g.Expect(getRateLimitPolicy()).To(gomega.BeNil())
+	integrationtest.TeardownHTTPRule(t, rrName)
+
+	// the period, when set, must be at least 1 second
+	httprule.PathProperties[0].RateLimit = &v1beta1.HTTPRuleRateLimit{Requests: 5, Period: -1}
+	rrCreate = httprule.HTTPRule()
+	if _, err := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HTTPRules("default").Create(context.TODO(), rrCreate, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		httpRule, _ := lib.AKOControlConfig().V1beta1CRDClientset().AkoV1beta1().HTTPRules("default").Get(context.TODO(), rrName, metav1.GetOptions{})
+		return httpRule.Status.Error
+	}, 10*time.Second).Should(gomega.ContainSubstring("rate limit period -1 is not in the range 1-"))
+	g.Expect(getRateLimitPolicy()).To(gomega.BeNil())
+
+	integrationtest.TeardownHTTPRule(t, rrName)
+	TearDownIngressForCacheSyncCheck(t, secretName, ingressName, svcName, modelName)
+}
+
 func TestCreateUpdateDeleteSSORuleForEvh(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
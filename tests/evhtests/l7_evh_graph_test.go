@@ -17,6 +17,7 @@ package evhtests
 import (
 	"context"
 	"sort"
+	"strconv"
 	"testing"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 
 	"github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -2324,3 +2326,59 @@ func TestMultiIngressSameHostDifferentNamespaceForEvh(t *testing.T) {
 	VerifyEvhVsCacheChildDeletion(t, g, cache.NamespaceName{Namespace: "admin", Name: modelName})
 	TearDownTestForIngress(t, svcName, modelName)
 }
+
+func TestHostlessIngressDefaultBackendForEvh(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	vsName := "cluster--Shared-L7-EVH-" + strconv.Itoa(int(utils.Bkt("", lib.GetshardSize())))
+	modelName := "admin/" + vsName
+	svcName := objNameMap.GenerateName("avisvc")
+	ingName := objNameMap.GenerateName("ingress-defaultbackend")
+	SetUpTestForIngress(t, svcName, modelName)
+
+	ingrFake := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ingName,
+			Namespace: "default",
+		},
+		Spec: networkingv1.IngressSpec{
+			DefaultBackend: &networkingv1.IngressBackend{
+				Service: &networkingv1.IngressServiceBackend{
+					Name: svcName,
+					Port: networkingv1.ServiceBackendPort{Number: 8080},
+				},
+			},
+		},
+	}
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+
+	pgName := lib.GetL7DefaultBackendPGName(vsName, "default", ingName)
+	g.Eventually(func() string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes, ok := aviModel.(*avinodes.AviObjectGraph)
+		if !ok || len(nodes.GetAviEvhVS()) == 0 {
+			return ""
+		}
+		return nodes.GetAviEvhVS()[0].DefaultPoolGroup
+	}, 20*time.Second).Should(gomega.Equal(pgName))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	g.Expect(nodes[0].EvhNodes).To(gomega.BeEmpty())
+	g.Expect(nodes[0].PoolRefs).To(gomega.HaveLen(1))
+	g.Expect(nodes[0].PoolRefs[0].Name).To(gomega.Equal(lib.GetL7DefaultBackendPoolName(vsName, "default", ingName)))
+	g.Expect(nodes[0].PoolRefs[0].Servers).NotTo(gomega.BeEmpty())
+	g.Expect(nodes[0].PoolGroupRefs).To(gomega.HaveLen(1))
+	g.Expect(nodes[0].PoolGroupRefs[0].Name).To(gomega.Equal(pgName))
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), ingName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	g.Eventually(func() string {
+		return aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()[0].DefaultPoolGroup
+	}, 20*time.Second).Should(gomega.BeEmpty())
+	VerifyEvhPoolDeletion(t, g, aviModel, 0)
+	TearDownTestForIngress(t, svcName, modelName)
+}
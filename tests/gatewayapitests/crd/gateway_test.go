@@ -430,3 +430,172 @@ func TestGatewayDeleteInfraSetting(t *testing.T) {
 	integrationtest.DeleteSecret(secrets[0], DEFAULT_NAMESPACE)
 	integrationtest.RemoveAnnotateAKONamespaceWithInfraSetting(t, DEFAULT_NAMESPACE)
 }
+
+func TestGatewayWithInfraSettingParametersRef(t *testing.T) {
+	gatewayName := "gateway-06"
+	gatewayClassName := "gateway-class-06"
+	classInfraSettingName := "infrasetting-06-class"
+	gatewayInfraSettingName := "infrasetting-06-gateway"
+	namespaceInfraSettingName := "infrasetting-06-namespace"
+	lateInfraSettingName := "infrasetting-06-late"
+
+	integrationtest.SetupAviInfraSetting(t, classInfraSettingName, "", true)
+	integrationtest.SetupAviInfraSetting(t, gatewayInfraSettingName, "", true)
+	integrationtest.SetupAviInfraSetting(t, namespaceInfraSettingName, "", true)
+
+	gatewayClass := (&tests.FakeGatewayClass{
+		Name:           gatewayClassName,
+		ControllerName: akogatewayapilib.GatewayController,
+	}).GatewayClassV1()
+	gatewayClass.Spec.ParametersRef = &gatewayv1.ParametersReference{
+		Group: lib.AkoGroup,
+		Kind:  lib.AviInfraSetting,
+		Name:  classInfraSettingName,
+	}
+	if _, err := tests.GatewayClient.GatewayV1().GatewayClasses().Create(context.TODO(), gatewayClass, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Couldn't create the gateway class, err: %+v", err)
+	}
+	time.Sleep(10 * time.Second)
+
+	integrationtest.AnnotateAKONamespaceWithInfraSetting(t, DEFAULT_NAMESPACE, namespaceInfraSettingName)
+	integrationtest.AnnotateNamespaceWithTenant(t, DEFAULT_NAMESPACE, "nonadmin")
+
+	ports := []int32{8080}
+	listeners := tests.GetListenersV1(ports, false, false)
+	ports = []int32{6443}
+	secrets := []string{"secret-06"}
+	for _, secret := range secrets {
+		integrationtest.AddSecret(secret, DEFAULT_NAMESPACE, "cert", "key")
+	}
+	tlsListeners := tests.GetListenersV1(ports, false, false, secrets...)
+	listeners = append(listeners, tlsListeners...)
+
+	// The parametersRef of the GatewayClass takes precedence over the namespace annotation
+	tests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+	g := gomega.NewGomegaWithT(t)
+	validateGatewayModelWithInfraSetting(g, "nonadmin", gatewayName, classInfraSettingName)
+
+	// The parametersRef of the Gateway takes precedence over the one of the GatewayClass
+	gw := &tests.Gateway{}
+	gw.Gateway = gw.GatewayV1(gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+	gw.Gateway.Spec.Infrastructure = &gatewayv1.GatewayInfrastructure{
+		// labels with the keys of the AKO markers are skipped
+		Labels: map[gatewayv1.LabelKey]gatewayv1.LabelValue{"team": "payments", "clustername": "other", "GatewayName": "other"},
+		ParametersRef: &gatewayv1.LocalParametersReference{
+			Group: lib.AkoGroup,
+			Kind:  lib.AviInfraSetting,
+			Name:  gatewayInfraSettingName,
+		},
+	}
+	gw.Update(t)
+
+	modelName := lib.GetModelName("nonadmin", akogatewayapilib.GetGatewayParentName(DEFAULT_NAMESPACE, gatewayName))
+	g.Eventually(func() string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		return nodes[0].ServiceEngineGroup
+	}, 25*time.Second).Should(gomega.Equal("thisisaviref-" + gatewayInfraSettingName + "-seGroup"))
+	validateGatewayModelWithInfraSetting(g, "nonadmin", gatewayName, gatewayInfraSettingName)
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	g.Expect(nodes[0].AviMarkers.Labels).To(gomega.Equal(map[string]string{"team": "payments"}))
+	// the markers read back from the controller match the checksum of the model
+	lib.SetClusterLabelChecksum()
+	markers := lib.GetAllMarkers(nodes[0].AviMarkers)
+	g.Expect(lib.GetMarkersChecksum(nodes[0].AviMarkers)).To(gomega.Equal(lib.ObjectLabelChecksum(markers)))
+	markerKeys := make([]string, 0, len(markers))
+	for _, marker := range markers {
+		markerKeys = append(markerKeys, *marker.Key)
+	}
+	g.Expect(markerKeys).To(gomega.ConsistOf(lib.ClusterNameLabelKey, "GatewayName", "GatewayNamespace", "team"))
+
+	// A reference to a missing AviInfraSetting rejects the Gateway and retains the virtual service
+	gw.Gateway.Spec.Infrastructure.ParametersRef.Name = lateInfraSettingName
+	gw.Gateway.ResourceVersion = "2"
+	gw.Update(t)
+	g.Eventually(func() string {
+		gateway, err := tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+		if err != nil || gateway == nil {
+			return ""
+		}
+		condition := apimeta.FindStatusCondition(gateway.Status.Conditions, string(gatewayv1.GatewayConditionAccepted))
+		if condition == nil || condition.Status != metav1.ConditionFalse {
+			return ""
+		}
+		return condition.Reason
+	}, 30*time.Second).Should(gomega.Equal(string(gatewayv1.GatewayReasonInvalidParameters)))
+	validateGatewayModelWithInfraSetting(g, "nonadmin", gatewayName, gatewayInfraSettingName)
+
+	// Creating the referred AviInfraSetting moves the virtual service
+	integrationtest.SetupAviInfraSetting(t, lateInfraSettingName, "", true)
+	g.Eventually(func() string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		return nodes[0].ServiceEngineGroup
+	}, 25*time.Second).Should(gomega.Equal("thisisaviref-" + lateInfraSettingName + "-seGroup"))
+	g.Eventually(func() string {
+		gateway, err := tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+		if err != nil || gateway == nil {
+			return ""
+		}
+		condition := apimeta.FindStatusCondition(gateway.Status.Conditions, string(gatewayv1.GatewayConditionAccepted))
+		if condition == nil || condition.Status != metav1.ConditionTrue {
+			return ""
+		}
+		return condition.Reason
+	}, 30*time.Second).Should(gomega.Equal(string(gatewayv1.GatewayReasonAccepted)))
+
+	// A referred AviInfraSetting which is not Accepted rejects the Gateway and retains the virtual service
+	integrationtest.SetAviInfraSettingStatus(t, lateInfraSettingName, lib.StatusRejected)
+	g.Eventually(func() string {
+		gateway, err := tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+		if err != nil || gateway == nil {
+			return ""
+		}
+		condition := apimeta.FindStatusCondition(gateway.Status.Conditions, string(gatewayv1.GatewayConditionAccepted))
+		if condition == nil || condition.Status != metav1.ConditionFalse {
+			return ""
+		}
+		return condition.Reason
+	}, 30*time.Second).Should(gomega.Equal(string(gatewayv1.GatewayReasonInvalidParameters)))
+	validateGatewayModelWithInfraSetting(g, "nonadmin", gatewayName, lateInfraSettingName)
+
+	tests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	tests.TeardownGatewayClass(t, gatewayClassName)
+	integrationtest.DeleteSecret(secrets[0], DEFAULT_NAMESPACE)
+	integrationtest.RemoveAnnotateAKONamespaceWithInfraSetting(t, DEFAULT_NAMESPACE)
+	integrationtest.TeardownAviInfraSetting(t, classInfraSettingName)
+	integrationtest.TeardownAviInfraSetting(t, gatewayInfraSettingName)
+	integrationtest.TeardownAviInfraSetting(t, namespaceInfraSettingName)
+	integrationtest.TeardownAviInfraSetting(t, lateInfraSettingName)
+}
+
+func TestGatewayClassWithInvalidParametersRef(t *testing.T) {
+	gatewayClassName := "gateway-class-07"
+
+	gatewayClass := (&tests.FakeGatewayClass{
+		Name:           gatewayClassName,
+		ControllerName: akogatewayapilib.GatewayController,
+	}).GatewayClassV1()
+	gatewayClass.Spec.ParametersRef = &gatewayv1.ParametersReference{
+		Group: "",
+		Kind:  "ConfigMap",
+		Name:  "gateway-parameters",
+	}
+	if _, err := tests.GatewayClient.GatewayV1().GatewayClasses().Create(context.TODO(), gatewayClass, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Couldn't create the gateway class, err: %+v", err)
+	}
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		gatewayClass, err := tests.GatewayClient.GatewayV1().GatewayClasses().Get(context.TODO(), gatewayClassName, metav1.GetOptions{})
+		if err != nil || gatewayClass == nil {
+			return false
+		}
+		condition := apimeta.FindStatusCondition(gatewayClass.Status.Conditions, string(gatewayv1.GatewayClassConditionStatusAccepted))
+		return condition != nil && condition.Status == metav1.ConditionFalse &&
+			condition.Reason == string(gatewayv1.GatewayClassReasonInvalidParameters)
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	tests.TeardownGatewayClass(t, gatewayClassName)
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright © 2025 Broadcom Inc. and/or its subsidiaries. All Rights Reserved.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package graphlayer
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	akogatewayapitests "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/gatewayapitests"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+func setServiceAppProtocol(t *testing.T, svcName, namespace, appProtocol string) {
+	svcObj, err := akogatewayapitests.KubeClient.CoreV1().Services(namespace).Get(context.TODO(), svcName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("error in getting Service: %v", err)
+	}
+	svcObj.Spec.Ports[0].AppProtocol = &appProtocol
+	svcObj.ResourceVersion = strconv.Itoa(int(time.Now().UnixNano()))
+	if _, err := akogatewayapitests.KubeClient.CoreV1().Services(namespace).Update(context.TODO(), svcObj, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+}
+
+func TestHTTPRouteWithServiceAppProtocol(t *testing.T) {
+	gatewayName := "gateway-appprotocol-01"
+	gatewayClassName := "gateway-class-appprotocol-01"
+	httpRouteName := "http-route-appprotocol-01"
+	svcName := "avisvc-appprotocol-01"
+	policyName := "btls-appprotocol-01"
+	configMapName := "btls-ca-appprotocol-01"
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	rule := akogatewayapitests.GetHTTPRouteRuleV1(integrationtest.PATHPREFIX, []string{"/foo"}, []string{}, nil,
+		[][]string{{svcName, DEFAULT_NAMESPACE, "8080", "1"}}, nil)
+	setupBackendTLSPolicyGatewayAndRoute(t, gatewayClassName, gatewayName, httpRouteName, svcName, "appprotocol-01.com", rule)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		return getBackendTLSPolicyPoolNode(modelName) != nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+	g.Expect(getBackendTLSPolicyPoolNode(modelName).EnableHttp2).To(gomega.BeNil())
+
+	// kubernetes.io/h2c enables HTTP/2 towards the backends
+	setServiceAppProtocol(t, svcName, DEFAULT_NAMESPACE, lib.AppProtocolH2C)
+	g.Eventually(func() bool {
+		poolNode := getBackendTLSPolicyPoolNode(modelName)
+		return poolNode != nil && poolNode.EnableHttp2 != nil && *poolNode.EnableHttp2
+	}, 25*time.Second).Should(gomega.Equal(true))
+	g.Expect(getBackendTLSPolicyPoolNode(modelName).SslProfileRef).To(gomega.BeNil())
+
+	// https enables SSL towards the backends
+	setServiceAppProtocol(t, svcName, DEFAULT_NAMESPACE, lib.AppProtocolHTTPS)
+	g.Eventually(func() bool {
+		poolNode := getBackendTLSPolicyPoolNode(modelName)
+		return poolNode != nil && poolNode.SslProfileRef != nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+	poolNode := getBackendTLSPolicyPoolNode(modelName)
+	g.Expect(poolNode.SniEnabled).To(gomega.Equal(true))
+	g.Expect(*poolNode.SslProfileRef).To(gomega.ContainSubstring(lib.DefaultPoolSSLProfile))
+	g.Expect(poolNode.EnableHttp2).To(gomega.BeNil())
+	g.Expect(poolNode.ServerName).To(gomega.BeNil())
+	g.Expect(poolNode.PkiProfile).To(gomega.BeNil())
+
+	// the BackendTLSPolicy of the Service takes precedence over the appProtocol
+	akogatewayapitests.SetupCACertConfigMap(t, configMapName, DEFAULT_NAMESPACE, backendTLSPolicyCACert)
+	akogatewayapitests.SetupBackendTLSPolicy(t, policyName, DEFAULT_NAMESPACE, svcName, configMapName, "backend.appprotocol-01.com")
+	g.Eventually(func() bool {
+		poolNode := getBackendTLSPolicyPoolNode(modelName)
+		return poolNode != nil && poolNode.PkiProfile != nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+	poolNode = getBackendTLSPolicyPoolNode(modelName)
+	g.Expect(poolNode.ServerName).NotTo(gomega.BeNil())
+	g.Expect(*poolNode.ServerName).To(gomega.Equal("backend.appprotocol-01.com"))
+	g.Expect(poolNode.PkiProfile.CACert).To(gomega.Equal(backendTLSPolicyCACert))
+
+	akogatewayapitests.TeardownBackendTLSPolicy(t, policyName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownCACertConfigMap(t, configMapName, DEFAULT_NAMESPACE)
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+	integrationtest.DelEPS(t, DEFAULT_NAMESPACE, svcName)
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestAppProtocolEventRecordedOnChange(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	fakeRecorder := record.NewFakeRecorder(10)
+	recorder := &utils.EventRecorder{Recorder: fakeRecorder, Enabled: true}
+	svcObj := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "avisvc-appprotocol-event-01", Namespace: DEFAULT_NAMESPACE}}
+	poolName := "pool-appprotocol-event-01"
+
+	// the event is recorded once for the rebuilds deriving the same protocol
+	avinodes.RecordAppProtocolEvent(recorder, svcObj, poolName, "Pool uses HTTP/2")
+	avinodes.RecordAppProtocolEvent(recorder, svcObj, poolName, "Pool uses HTTP/2")
+	g.Expect(fakeRecorder.Events).To(gomega.HaveLen(1))
+	<-fakeRecorder.Events
+
+	// and again when the derived protocol changes
+	avinodes.RecordAppProtocolEvent(recorder, svcObj, poolName, "Pool uses HTTP/1.1 over SSL")
+	g.Expect(fakeRecorder.Events).To(gomega.HaveLen(1))
+	<-fakeRecorder.Events
+
+	// or is set again after the appProtocol was removed
+	avinodes.RecordAppProtocolEvent(recorder, svcObj, poolName, "")
+	avinodes.RecordAppProtocolEvent(recorder, svcObj, poolName, "Pool uses HTTP/1.1 over SSL")
+	g.Expect(fakeRecorder.Events).To(gomega.HaveLen(1))
+	<-fakeRecorder.Events
+
+	// or is recorded again for a pool created after the pool was deleted
+	avinodes.ForgetAppProtocolEvent(poolName)
+	avinodes.RecordAppProtocolEvent(recorder, svcObj, poolName, "Pool uses HTTP/1.1 over SSL")
+	g.Expect(fakeRecorder.Events).To(gomega.HaveLen(1))
+}
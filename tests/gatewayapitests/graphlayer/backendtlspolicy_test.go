@@ -0,0 +1,253 @@
+/*
+ * Copyright © 2025 Broadcom Inc. and/or its subsidiaries. All Rights Reserved.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package graphlayer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	akogatewayapitests "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/gatewayapitests"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+const backendTLSPolicyCACert = "-----BEGIN CERTIFICATE-----\nMIIBszCCAVmgAwIBAgIUYmFja2VuZHRscw==\n-----END CERTIFICATE-----"
+
+func getBackendTLSPolicyAcceptedReason(t *testing.T, name, namespace string) string {
+	policy, err := akogatewayapitests.GatewayClient.GatewayV1alpha3().BackendTLSPolicies(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil || policy == nil {
+		t.Logf("Couldn't get the BackendTLSPolicy, err: %+v", err)
+		return ""
+	}
+	if len(policy.Status.Ancestors) != 1 {
+		return ""
+	}
+	condition := apimeta.FindStatusCondition(policy.Status.Ancestors[0].Conditions, string(gatewayv1alpha2.PolicyConditionAccepted))
+	if condition == nil {
+		return ""
+	}
+	return condition.Reason
+}
+
+func setupBackendTLSPolicyGatewayAndRoute(t *testing.T, gatewayClassName, gatewayName, httpRouteName, svcName, hostname string, rule gatewayv1.HTTPRouteRule) {
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersOnHostname([]string{hostname})
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		gateway, err := akogatewayapitests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+		if err != nil || gateway == nil {
+			return false
+		}
+		return apimeta.IsStatusConditionTrue(gateway.Status.Conditions, string(gatewayv1.GatewayConditionAccepted))
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, svcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPS(t, DEFAULT_NAMESPACE, svcName, false, false, "1.2.3")
+
+	parentRefs := akogatewayapitests.GetParentReferencesFromListeners(listeners, gatewayName, DEFAULT_NAMESPACE)
+	hostnames := []gatewayv1.Hostname{gatewayv1.Hostname(hostname)}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, []gatewayv1.HTTPRouteRule{rule})
+}
+
+func getBackendTLSPolicyPoolNode(modelName string) *avinodes.AviPoolNode {
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	if aviModel == nil {
+		return nil
+	}
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	if len(nodes) == 0 || len(nodes[0].EvhNodes) == 0 || len(nodes[0].EvhNodes[0].PoolRefs) == 0 {
+		return nil
+	}
+	return nodes[0].EvhNodes[0].PoolRefs[0]
+}
+
+func TestBackendTLSPolicyAppliedToPool(t *testing.T) {
+	gatewayName := "gateway-btls-01"
+	gatewayClassName := "gateway-class-btls-01"
+	httpRouteName := "http-route-btls-01"
+	svcName := "avisvc-btls-01"
+	policyName := "btls-01"
+	configMapName := "btls-ca-01"
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	rule := akogatewayapitests.GetHTTPRouteRuleV1(integrationtest.PATHPREFIX, []string{"/foo"}, []string{}, nil,
+		[][]string{{svcName, DEFAULT_NAMESPACE, "8080", "1"}}, nil)
+	setupBackendTLSPolicyGatewayAndRoute(t, gatewayClassName, gatewayName, httpRouteName, svcName, "btls-01.com", rule)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		return getBackendTLSPolicyPoolNode(modelName) != nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+	g.Expect(getBackendTLSPolicyPoolNode(modelName).SniEnabled).To(gomega.Equal(false))
+
+	akogatewayapitests.SetupCACertConfigMap(t, configMapName, DEFAULT_NAMESPACE, backendTLSPolicyCACert)
+	akogatewayapitests.SetupBackendTLSPolicy(t, policyName, DEFAULT_NAMESPACE, svcName, configMapName, "backend.btls-01.com")
+
+	g.Eventually(func() bool {
+		poolNode := getBackendTLSPolicyPoolNode(modelName)
+		return poolNode != nil && poolNode.PkiProfile != nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	poolNode := getBackendTLSPolicyPoolNode(modelName)
+	g.Expect(poolNode.SniEnabled).To(gomega.Equal(true))
+	g.Expect(poolNode.SslProfileRef).NotTo(gomega.BeNil())
+	g.Expect(*poolNode.SslProfileRef).To(gomega.ContainSubstring("System-Standard"))
+	g.Expect(poolNode.ServerName).NotTo(gomega.BeNil())
+	g.Expect(*poolNode.ServerName).To(gomega.Equal("backend.btls-01.com"))
+	g.Expect(poolNode.HostCheckEnabled).NotTo(gomega.BeNil())
+	g.Expect(*poolNode.HostCheckEnabled).To(gomega.Equal(true))
+	g.Expect(poolNode.DomainName).To(gomega.Equal([]string{"backend.btls-01.com"}))
+	g.Expect(poolNode.PkiProfile.CACert).To(gomega.Equal(backendTLSPolicyCACert))
+
+	g.Eventually(func() string {
+		return getBackendTLSPolicyAcceptedReason(t, policyName, DEFAULT_NAMESPACE)
+	}, 25*time.Second).Should(gomega.Equal(string(gatewayv1alpha2.PolicyReasonAccepted)))
+
+	// deleting the policy removes the TLS settings from the pool
+	akogatewayapitests.TeardownBackendTLSPolicy(t, policyName, DEFAULT_NAMESPACE)
+	g.Eventually(func() bool {
+		poolNode := getBackendTLSPolicyPoolNode(modelName)
+		return poolNode != nil && poolNode.PkiProfile == nil && !poolNode.SniEnabled
+	}, 25*time.Second).Should(gomega.Equal(true))
+	poolNode = getBackendTLSPolicyPoolNode(modelName)
+	g.Expect(poolNode.SslProfileRef).To(gomega.BeNil())
+	g.Expect(poolNode.ServerName).To(gomega.BeNil())
+
+	akogatewayapitests.TeardownCACertConfigMap(t, configMapName, DEFAULT_NAMESPACE)
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+	integrationtest.DelEPS(t, DEFAULT_NAMESPACE, svcName)
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestBackendTLSPolicyWithMissingCACertificate(t *testing.T) {
+	gatewayName := "gateway-btls-02"
+	gatewayClassName := "gateway-class-btls-02"
+	httpRouteName := "http-route-btls-02"
+	svcName := "avisvc-btls-02"
+	policyName := "btls-02"
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	rule := akogatewayapitests.GetHTTPRouteRuleV1(integrationtest.PATHPREFIX, []string{"/foo"}, []string{}, nil,
+		[][]string{{svcName, DEFAULT_NAMESPACE, "8080", "1"}}, nil)
+	setupBackendTLSPolicyGatewayAndRoute(t, gatewayClassName, gatewayName, httpRouteName, svcName, "btls-02.com", rule)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		return getBackendTLSPolicyPoolNode(modelName) != nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	// the referenced CA ConfigMap does not exist
+	akogatewayapitests.SetupBackendTLSPolicy(t, policyName, DEFAULT_NAMESPACE, svcName, "btls-ca-02", "backend.btls-02.com")
+	g.Eventually(func() string {
+		return getBackendTLSPolicyAcceptedReason(t, policyName, DEFAULT_NAMESPACE)
+	}, 25*time.Second).Should(gomega.Equal(string(gatewayv1alpha2.PolicyReasonInvalid)))
+
+	poolNode := getBackendTLSPolicyPoolNode(modelName)
+	g.Expect(poolNode).NotTo(gomega.BeNil())
+	g.Expect(poolNode.SniEnabled).To(gomega.Equal(false))
+	g.Expect(poolNode.SslProfileRef).To(gomega.BeNil())
+	g.Expect(poolNode.PkiProfile).To(gomega.BeNil())
+
+	// creating the referenced ConfigMap applies the policy
+	akogatewayapitests.SetupCACertConfigMap(t, "btls-ca-02", DEFAULT_NAMESPACE, backendTLSPolicyCACert)
+	g.Eventually(func() string {
+		return getBackendTLSPolicyAcceptedReason(t, policyName, DEFAULT_NAMESPACE)
+	}, 25*time.Second).Should(gomega.Equal(string(gatewayv1alpha2.PolicyReasonAccepted)))
+	g.Eventually(func() string {
+		poolNode := getBackendTLSPolicyPoolNode(modelName)
+		if poolNode == nil || poolNode.PkiProfile == nil {
+			return ""
+		}
+		return poolNode.PkiProfile.CACert
+	}, 25*time.Second).Should(gomega.Equal(backendTLSPolicyCACert))
+
+	akogatewayapitests.TeardownBackendTLSPolicy(t, policyName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownCACertConfigMap(t, "btls-ca-02", DEFAULT_NAMESPACE)
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+	integrationtest.DelEPS(t, DEFAULT_NAMESPACE, svcName)
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestBackendTLSPolicyConflictWithRouteBackendExtension(t *testing.T) {
+	gatewayName := "gateway-btls-03"
+	gatewayClassName := "gateway-class-btls-03"
+	httpRouteName := "http-route-btls-03"
+	svcName := "avisvc-btls-03"
+	policyName := "btls-03"
+	configMapName := "btls-ca-03"
+	routeBackendExtensionName := "rbe-btls-03"
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	rbe := akogatewayapitests.GetFakeRBEObjWithBackendTLS(
+		routeBackendExtensionName,
+		DEFAULT_NAMESPACE,
+		akogatewayapitests.BoolPtr(true), // hostCheckEnabled
+		nil,                              // domainName not set
+		nil,                              // pkiProfile not set
+	)
+	rbe.CreateRouteBackendExtensionCRWithStatus(t)
+
+	rule := akogatewayapitests.GetHTTPRouteRuleWithRouteBackendExtensionAndHMFilters(integrationtest.PATHPREFIX, []string{"/foo"}, []string{},
+		map[string][]string{"RequestHeaderModifier": {"add"}},
+		[][]string{{svcName, DEFAULT_NAMESPACE, "8080", "1"}}, []string{routeBackendExtensionName})
+	setupBackendTLSPolicyGatewayAndRoute(t, gatewayClassName, gatewayName, httpRouteName, svcName, "btls-03.com", rule)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		poolNode := getBackendTLSPolicyPoolNode(modelName)
+		return poolNode != nil && poolNode.SslProfileRef != nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	akogatewayapitests.SetupCACertConfigMap(t, configMapName, DEFAULT_NAMESPACE, backendTLSPolicyCACert)
+	akogatewayapitests.SetupBackendTLSPolicy(t, policyName, DEFAULT_NAMESPACE, svcName, configMapName, "backend.btls-03.com")
+
+	// backendTLS of the RouteBackendExtension takes precedence over the policy
+	g.Eventually(func() string {
+		return getBackendTLSPolicyAcceptedReason(t, policyName, DEFAULT_NAMESPACE)
+	}, 25*time.Second).Should(gomega.Equal(string(gatewayv1alpha2.PolicyReasonConflicted)))
+
+	poolNode := getBackendTLSPolicyPoolNode(modelName)
+	g.Expect(poolNode).NotTo(gomega.BeNil())
+	g.Expect(*poolNode.SslProfileRef).To(gomega.ContainSubstring("System-Standard"))
+	g.Expect(poolNode.HostCheckEnabled).NotTo(gomega.BeNil())
+	g.Expect(*poolNode.HostCheckEnabled).To(gomega.Equal(true))
+	g.Expect(poolNode.ServerName).To(gomega.BeNil())
+	g.Expect(poolNode.PkiProfile).To(gomega.BeNil())
+
+	akogatewayapitests.TeardownBackendTLSPolicy(t, policyName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownCACertConfigMap(t, configMapName, DEFAULT_NAMESPACE)
+	rbe.DeleteRouteBackendExtensionCR(t)
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+	integrationtest.DelEPS(t, DEFAULT_NAMESPACE, svcName)
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
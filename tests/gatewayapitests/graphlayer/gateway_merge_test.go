@@ -0,0 +1,375 @@
+/*
+ * Copyright © 2025 Broadcom Inc. and/or its subsidiaries. All Rights Reserved.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package graphlayer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	tests "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/gatewayapitests"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+func setupMergedGatewayClass(t *testing.T, name string) {
+	gc := &tests.FakeGatewayClass{
+		Name:           name,
+		ControllerName: akogatewayapilib.GatewayController,
+		Annotations:    map[string]string{akogatewayapilib.MergeGatewaysAnnotation: "true"},
+	}
+	gc.Create(t)
+	time.Sleep(10 * time.Second)
+}
+
+func waitForGatewayAccepted(t *testing.T, g *gomega.WithT, name string) {
+	g.Eventually(func() bool {
+		gateway, err := tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil || gateway == nil {
+			t.Logf("Couldn't get the gateway, err: %+v", err)
+			return false
+		}
+		return apimeta.FindStatusCondition(gateway.Status.Conditions, string(gatewayv1.GatewayConditionAccepted)) != nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+}
+
+func isModelDeleted(modelName string) bool {
+	found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	return !found || aviModel == nil
+}
+
+func TestMergedGatewaysSharedParentVS(t *testing.T) {
+	gatewayClassName := "gateway-class-merge-01"
+	gatewayName1 := "gateway-merge-01a"
+	gatewayName2 := "gateway-merge-01b"
+	httpRouteName1 := "httproute-merge-01a"
+	httpRouteName2 := "httproute-merge-01b"
+	svcName := "avisvc-merge-01"
+
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, svcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPS(t, DEFAULT_NAMESPACE, svcName, false, false, "1.1.1")
+	setupMergedGatewayClass(t, gatewayClassName)
+
+	g := gomega.NewGomegaWithT(t)
+	tests.SetupGateway(t, gatewayName1, DEFAULT_NAMESPACE, gatewayClassName, nil, tests.GetListenersV1([]int32{8080}, false, false))
+	waitForGatewayAccepted(t, g, gatewayName1)
+	tests.SetupGateway(t, gatewayName2, DEFAULT_NAMESPACE, gatewayClassName, nil, tests.GetListenersV1([]int32{8081}, false, false))
+	waitForGatewayAccepted(t, g, gatewayName2)
+
+	rules := []gatewayv1.HTTPRouteRule{tests.GetHTTPRouteRuleV1(integrationtest.PATHPREFIX, []string{"/foo"}, []string{},
+		nil, [][]string{{svcName, DEFAULT_NAMESPACE, "8080", "1"}}, nil)}
+	tests.SetupHTTPRoute(t, httpRouteName1, DEFAULT_NAMESPACE, tests.GetParentReferencesV1([]string{gatewayName1}, DEFAULT_NAMESPACE, []int32{8080}),
+		[]gatewayv1.Hostname{"foo-8080.com"}, rules)
+	tests.SetupHTTPRoute(t, httpRouteName2, DEFAULT_NAMESPACE, tests.GetParentReferencesV1([]string{gatewayName2}, DEFAULT_NAMESPACE, []int32{8081}),
+		[]gatewayv1.Hostname{"foo-8081.com"}, rules)
+
+	modelName := lib.GetModelName(lib.GetTenant(), akogatewayapilib.GetMergedGatewayParentName(gatewayClassName))
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found || aviModel == nil {
+			return 0
+		}
+		return len(aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()[0].EvhNodes)
+	}, 40*time.Second).Should(gomega.Equal(2))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	g.Expect(nodes).To(gomega.HaveLen(1))
+	g.Expect(nodes[0].Name).To(gomega.Equal(akogatewayapilib.GetMergedGatewayParentName(gatewayClassName)))
+	g.Expect(nodes[0].ServiceMetadata.Gateway).To(gomega.Equal(DEFAULT_NAMESPACE + "/" + gatewayName1))
+	g.Expect(nodes[0].PortProto).To(gomega.HaveLen(2))
+	g.Expect(nodes[0].VSVIPRefs).To(gomega.HaveLen(1))
+	g.Expect(nodes[0].VSVIPRefs[0].Name).To(gomega.Equal(lib.GetVsVipName(nodes[0].Name)))
+	g.Expect(nodes[0].VSVIPRefs[0].FQDNs).To(gomega.ConsistOf("foo-8080.com", "foo-8081.com"))
+	gatewayNames := []string{nodes[0].EvhNodes[0].AviMarkers.GatewayName, nodes[0].EvhNodes[1].AviMarkers.GatewayName}
+	g.Expect(gatewayNames).To(gomega.ConsistOf(gatewayName1, gatewayName2))
+	for _, gatewayName := range []string{gatewayName1, gatewayName2} {
+		g.Expect(isModelDeleted(lib.GetModelName(lib.GetTenant(), akogatewayapilib.GetGatewayParentName(DEFAULT_NAMESPACE, gatewayName)))).To(gomega.BeTrue())
+	}
+
+	// the remaining gateway takes over the parent VS along with the child VS of its route
+	tests.TeardownHTTPRoute(t, httpRouteName1, DEFAULT_NAMESPACE)
+	tests.TeardownGateway(t, gatewayName1, DEFAULT_NAMESPACE)
+	g.Eventually(func() string {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found || aviModel == nil {
+			return ""
+		}
+		return aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()[0].ServiceMetadata.Gateway
+	}, 40*time.Second).Should(gomega.Equal(DEFAULT_NAMESPACE + "/" + gatewayName2))
+
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	nodes = aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	g.Expect(nodes[0].PortProto).To(gomega.HaveLen(1))
+	g.Expect(nodes[0].PortProto[0].Port).To(gomega.Equal(int32(8081)))
+	g.Expect(nodes[0].VSVIPRefs[0].FQDNs).To(gomega.Equal([]string{"foo-8081.com"}))
+	g.Expect(nodes[0].EvhNodes).To(gomega.HaveLen(1))
+	g.Expect(nodes[0].EvhNodes[0].AviMarkers.GatewayName).To(gomega.Equal(gatewayName2))
+
+	tests.TeardownHTTPRoute(t, httpRouteName2, DEFAULT_NAMESPACE)
+	tests.TeardownGateway(t, gatewayName2, DEFAULT_NAMESPACE)
+	g.Eventually(func() bool {
+		return isModelDeleted(modelName)
+	}, 40*time.Second).Should(gomega.Equal(true))
+
+	tests.TeardownGatewayClass(t, gatewayClassName)
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+	integrationtest.DelEPS(t, DEFAULT_NAMESPACE, svcName)
+}
+
+func TestMergedGatewaysProtocolConflict(t *testing.T) {
+	gatewayClassName := "gateway-class-merge-02"
+	gatewayName1 := "gateway-merge-02a"
+	gatewayName2 := "gateway-merge-02b"
+
+	setupMergedGatewayClass(t, gatewayClassName)
+
+	g := gomega.NewGomegaWithT(t)
+	tests.SetupGateway(t, gatewayName1, DEFAULT_NAMESPACE, gatewayClassName, nil, tests.GetListenersV1([]int32{8080}, false, false))
+	waitForGatewayAccepted(t, g, gatewayName1)
+
+	listeners := tests.GetListenersV1([]int32{8080, 8081}, false, false)
+	listeners[0].Protocol = gatewayv1.HTTPProtocolType
+	tests.SetListenerHostname(&listeners[0], "bar-8080.com")
+	tests.SetupGateway(t, gatewayName2, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g.Eventually(func() string {
+		gateway, err := tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName2, metav1.GetOptions{})
+		if err != nil || gateway == nil || len(gateway.Status.Listeners) != 2 {
+			return ""
+		}
+		condition := apimeta.FindStatusCondition(gateway.Status.Listeners[0].Conditions, string(gatewayv1.ListenerConditionConflicted))
+		if condition == nil || condition.Status != metav1.ConditionTrue {
+			return ""
+		}
+		return condition.Reason
+	}, 30*time.Second).Should(gomega.Equal(string(gatewayv1.ListenerReasonProtocolConflict)))
+
+	// the port is served with the protocol of the gateway which takes precedence
+	modelName := lib.GetModelName(lib.GetTenant(), akogatewayapilib.GetMergedGatewayParentName(gatewayClassName))
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found || aviModel == nil {
+			return 0
+		}
+		return len(aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()[0].PortProto)
+	}, 40*time.Second).Should(gomega.Equal(2))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	for _, portProto := range nodes[0].PortProto {
+		g.Expect(portProto.Protocol).To(gomega.Equal(string(gatewayv1.HTTPSProtocolType)))
+	}
+
+	tests.TeardownGateway(t, gatewayName2, DEFAULT_NAMESPACE)
+	tests.TeardownGateway(t, gatewayName1, DEFAULT_NAMESPACE)
+	g.Eventually(func() bool {
+		return isModelDeleted(modelName)
+	}, 40*time.Second).Should(gomega.Equal(true))
+	tests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func getGatewayAcceptedReason(name string) string {
+	gateway, err := tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil || gateway == nil {
+		return ""
+	}
+	condition := apimeta.FindStatusCondition(gateway.Status.Conditions, string(gatewayv1.GatewayConditionAccepted))
+	if condition == nil {
+		return ""
+	}
+	return condition.Reason
+}
+
+func TestMergedGatewaysMismatchedAddresses(t *testing.T) {
+	gatewayClassName := "gateway-class-merge-04"
+	gatewayName1 := "gateway-merge-04a"
+	gatewayName2 := "gateway-merge-04b"
+
+	setupMergedGatewayClass(t, gatewayClassName)
+
+	g := gomega.NewGomegaWithT(t)
+	tests.SetupGateway(t, gatewayName1, DEFAULT_NAMESPACE, gatewayClassName, nil, tests.GetListenersV1([]int32{8080}, false, false))
+	waitForGatewayAccepted(t, g, gatewayName1)
+
+	// the VIP of the shared parent VS is taken from the gateway which takes precedence
+	ipAddressType := gatewayv1.IPAddressType
+	addresses := []gatewayv1.GatewaySpecAddress{{Type: &ipAddressType, Value: "10.10.10.10"}}
+	tests.SetupGateway(t, gatewayName2, DEFAULT_NAMESPACE, gatewayClassName, addresses, tests.GetListenersV1([]int32{8081}, false, false))
+	g.Eventually(func() string {
+		return getGatewayAcceptedReason(gatewayName2)
+	}, 30*time.Second).Should(gomega.Equal(string(gatewayv1.GatewayReasonUnsupportedAddress)))
+
+	modelName := lib.GetModelName(lib.GetTenant(), akogatewayapilib.GetMergedGatewayParentName(gatewayClassName))
+	g.Eventually(func() bool {
+		return isModelDeleted(modelName)
+	}, 40*time.Second).Should(gomega.Equal(false))
+	g.Consistently(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found || aviModel == nil {
+			return 0
+		}
+		return len(aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()[0].PortProto)
+	}, 5*time.Second).Should(gomega.Equal(1))
+
+	tests.UpdateGateway(t, gatewayName2, DEFAULT_NAMESPACE, gatewayClassName, nil, tests.GetListenersV1([]int32{8081}, false, false))
+	g.Eventually(func() string {
+		return getGatewayAcceptedReason(gatewayName2)
+	}, 30*time.Second).Should(gomega.Equal(string(gatewayv1.GatewayReasonAccepted)))
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found || aviModel == nil {
+			return 0
+		}
+		return len(aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()[0].PortProto)
+	}, 40*time.Second).Should(gomega.Equal(2))
+
+	tests.TeardownGateway(t, gatewayName2, DEFAULT_NAMESPACE)
+	tests.TeardownGateway(t, gatewayName1, DEFAULT_NAMESPACE)
+	g.Eventually(func() bool {
+		return isModelDeleted(modelName)
+	}, 40*time.Second).Should(gomega.Equal(true))
+	tests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestMergedGatewaysFrontendValidationConflict(t *testing.T) {
+	gatewayClassName := "gateway-class-merge-05"
+	gatewayName1 := "gateway-merge-05a"
+	gatewayName2 := "gateway-merge-05b"
+	configMapName := "frontend-ca-merge-05"
+	secrets := []string{"secret-merge-05"}
+
+	setupMergedGatewayClass(t, gatewayClassName)
+	for _, secret := range secrets {
+		integrationtest.AddSecret(secret, DEFAULT_NAMESPACE, "cert", "key")
+	}
+	tests.SetupCACertConfigMap(t, configMapName, DEFAULT_NAMESPACE, frontendValidationCACert)
+
+	g := gomega.NewGomegaWithT(t)
+	tests.SetupGateway(t, gatewayName1, DEFAULT_NAMESPACE, gatewayClassName, nil, tests.GetListenersV1([]int32{8443}, false, false, secrets...))
+	waitForGatewayAccepted(t, g, gatewayName1)
+
+	// the client certificates on the port are validated as per the gateway which takes precedence
+	listeners := tests.GetListenersV1([]int32{8443, 8444}, false, false, secrets...)
+	tests.SetListenerHostname(&listeners[0], "bar-8443.com")
+	listeners[0].TLS.FrontendValidation = &gatewayv1.FrontendTLSValidation{
+		CACertificateRefs: []gatewayv1.ObjectReference{{Kind: "ConfigMap", Name: gatewayv1.ObjectName(configMapName)}},
+	}
+	tests.SetupGateway(t, gatewayName2, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g.Eventually(func() string {
+		gateway, err := tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName2, metav1.GetOptions{})
+		if err != nil || gateway == nil || len(gateway.Status.Listeners) != 2 {
+			return ""
+		}
+		condition := apimeta.FindStatusCondition(gateway.Status.Listeners[0].Conditions, string(gatewayv1.ListenerConditionConflicted))
+		if condition == nil || condition.Status != metav1.ConditionTrue {
+			return ""
+		}
+		return condition.Message
+	}, 30*time.Second).Should(gomega.ContainSubstring("FrontendValidation differs"))
+
+	modelName := lib.GetModelName(lib.GetTenant(), akogatewayapilib.GetMergedGatewayParentName(gatewayClassName))
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found || aviModel == nil {
+			return -1
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].PortProto) != 2 {
+			return -1
+		}
+		return len(nodes[0].ApplicationProfileRefs)
+	}, 40*time.Second).Should(gomega.Equal(0))
+
+	tests.TeardownGateway(t, gatewayName2, DEFAULT_NAMESPACE)
+	tests.TeardownGateway(t, gatewayName1, DEFAULT_NAMESPACE)
+	g.Eventually(func() bool {
+		return isModelDeleted(modelName)
+	}, 40*time.Second).Should(gomega.Equal(true))
+	tests.TeardownCACertConfigMap(t, configMapName, DEFAULT_NAMESPACE)
+	for _, secret := range secrets {
+		integrationtest.DeleteSecret(secret, DEFAULT_NAMESPACE)
+	}
+	tests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestMergedGatewaysMergeModeDisabled(t *testing.T) {
+	gatewayClassName := "gateway-class-merge-03"
+	gatewayName1 := "gateway-merge-03a"
+	gatewayName2 := "gateway-merge-03b"
+
+	setupMergedGatewayClass(t, gatewayClassName)
+
+	g := gomega.NewGomegaWithT(t)
+	tests.SetupGateway(t, gatewayName1, DEFAULT_NAMESPACE, gatewayClassName, nil, tests.GetListenersV1([]int32{8080}, false, false))
+	waitForGatewayAccepted(t, g, gatewayName1)
+	tests.SetupGateway(t, gatewayName2, DEFAULT_NAMESPACE, gatewayClassName, nil, tests.GetListenersV1([]int32{8081}, false, false))
+	waitForGatewayAccepted(t, g, gatewayName2)
+
+	modelName := lib.GetModelName(lib.GetTenant(), akogatewayapilib.GetMergedGatewayParentName(gatewayClassName))
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found || aviModel == nil {
+			return 0
+		}
+		return len(aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()[0].PortProto)
+	}, 40*time.Second).Should(gomega.Equal(2))
+
+	// removing the annotation moves the gateways back to their own parent VS
+	gatewayClass, err := tests.GatewayClient.GatewayV1().GatewayClasses().Get(context.TODO(), gatewayClassName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't get the gateway class, err: %+v", err)
+	}
+	delete(gatewayClass.Annotations, akogatewayapilib.MergeGatewaysAnnotation)
+	// updates without a change in the resource version are treated as resyncs by the informer
+	gatewayClass.ResourceVersion = "2"
+	if _, err := tests.GatewayClient.GatewayV1().GatewayClasses().Update(context.TODO(), gatewayClass, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Couldn't update the gateway class, err: %+v", err)
+	}
+
+	g.Eventually(func() bool {
+		return isModelDeleted(modelName)
+	}, 40*time.Second).Should(gomega.Equal(true))
+	for _, gatewayName := range []string{gatewayName1, gatewayName2} {
+		gwModelName := lib.GetModelName(lib.GetTenant(), akogatewayapilib.GetGatewayParentName(DEFAULT_NAMESPACE, gatewayName))
+		g.Eventually(func() bool {
+			return isModelDeleted(gwModelName)
+		}, 40*time.Second).Should(gomega.Equal(false))
+		_, aviModel := objects.SharedAviGraphLister().Get(gwModelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		g.Expect(nodes[0].PortProto).To(gomega.HaveLen(1))
+		g.Expect(nodes[0].ServiceMetadata.Gateway).To(gomega.Equal(DEFAULT_NAMESPACE + "/" + gatewayName))
+	}
+
+	tests.TeardownGateway(t, gatewayName1, DEFAULT_NAMESPACE)
+	tests.TeardownGateway(t, gatewayName2, DEFAULT_NAMESPACE)
+	for _, gatewayName := range []string{gatewayName1, gatewayName2} {
+		gwModelName := lib.GetModelName(lib.GetTenant(), akogatewayapilib.GetGatewayParentName(DEFAULT_NAMESPACE, gatewayName))
+		g.Eventually(func() bool {
+			return isModelDeleted(gwModelName)
+		}, 40*time.Second).Should(gomega.Equal(true))
+	}
+	tests.TeardownGatewayClass(t, gatewayClassName)
+}
@@ -34,6 +34,7 @@ import (
 
 	akogatewayapik8s "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/k8s"
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
@@ -66,6 +67,8 @@ func TestMain(m *testing.M) {
 	os.Setenv("TENANT", "admin")
 	os.Setenv("POD_NAME", "ako-0")
 	os.Setenv("AKO_CRD_OPERATOR_ENABLED", "true")
+	os.Setenv("ENABLE_GATEWAY_API_BACKEND_TLS_POLICY", "true")
+	os.Setenv("ENABLE_GATEWAY_API_SERVICE_IMPORT", "true")
 
 	// Set the user with prefix
 	_ = lib.AKOControlConfig()
@@ -955,3 +958,226 @@ func TestGatewayWithTrafficDisabledAnnotation(t *testing.T) {
 	tests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
 	tests.TeardownGatewayClass(t, gatewayClassName)
 }
+
+func TestGatewayWithDualStackAndNamedAddresses(t *testing.T) {
+
+	gatewayName := "gateway-dual-stack-01"
+	gatewayClassName := "gateway-class-dual-stack-01"
+	ports := []int32{8080}
+
+	tests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := tests.GetListenersV1(ports, false, false)
+	ipAddressType := gatewayv1.IPAddressType
+	namedAddressType := gatewayv1.NamedAddressType
+	addresses := []gatewayv1.GatewaySpecAddress{
+		{Type: &ipAddressType, Value: "2001:db8::10"},
+		{Type: &ipAddressType, Value: "10.10.10.10"},
+	}
+	tests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, addresses, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	modelName := lib.GetModelName(lib.GetTenant(), akogatewayapilib.GetGatewayParentName(DEFAULT_NAMESPACE, gatewayName))
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	g.Expect(nodes).To(gomega.HaveLen(1))
+	g.Expect(nodes[0].VSVIPRefs).To(gomega.HaveLen(1))
+	g.Expect(nodes[0].VSVIPRefs[0].IPAddress).To(gomega.Equal("10.10.10.10"))
+	g.Expect(nodes[0].VSVIPRefs[0].IPv6Address).To(gomega.Equal("2001:db8::10"))
+
+	// The NamedAddress selects the VIP network, a single v6 address is retained
+	addresses = []gatewayv1.GatewaySpecAddress{
+		{Type: &namedAddressType, Value: "vip-network-01"},
+		{Type: &ipAddressType, Value: "2001:db8::10"},
+	}
+	tests.UpdateGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, addresses, listeners)
+	g.Eventually(func() string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes) != 1 || len(nodes[0].VSVIPRefs) != 1 || len(nodes[0].VSVIPRefs[0].VipNetworks) != 1 {
+			return ""
+		}
+		return nodes[0].VSVIPRefs[0].VipNetworks[0].NetworkName
+	}, 25*time.Second).Should(gomega.Equal("vip-network-01"))
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	nodes = aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	g.Expect(nodes[0].VSVIPRefs[0].IPAddress).To(gomega.Equal("2001:db8::10"))
+	g.Expect(nodes[0].VSVIPRefs[0].IPv6Address).To(gomega.BeEmpty())
+
+	// Two addresses of the same family are rejected
+	addresses = []gatewayv1.GatewaySpecAddress{
+		{Type: &ipAddressType, Value: "2001:db8::10"},
+		{Type: &ipAddressType, Value: "2001:db8::11"},
+	}
+	tests.UpdateGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, addresses, listeners)
+	g.Eventually(func() string {
+		gateway, err := tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+		if err != nil || gateway == nil {
+			return ""
+		}
+		condition := apimeta.FindStatusCondition(gateway.Status.Conditions, string(gatewayv1.GatewayConditionAccepted))
+		if condition == nil || condition.Status != metav1.ConditionFalse {
+			return ""
+		}
+		return condition.Message
+	}, 30*time.Second).Should(gomega.Equal("More than one IPv6 address is not supported"))
+
+	tests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	tests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+const frontendValidationCACert = `-----BEGIN CERTIFICATE-----
+MIIBmDCCAT+gAwIBAgIUH3wkk+2+glItXt93SNI41W0/OFUwCgYIKoZIzj0EAwIw
+ITEfMB0GA1UEAwwWZnJvbnRlbmQtdmFsaWRhdGlvbi1jYTAgFw0yNjEwMTgwNDQw
+MjdaGA8yMTI2MDkyNDA0NDAyN1owITEfMB0GA1UEAwwWZnJvbnRlbmQtdmFsaWRh
+dGlvbi1jYTBZMBMGByqGSM49AgEGCCqGSM49AwEHA0IABEIvGp7EIN1yZjReeBQ7
+iAD9D5foBvJHODqaAoc4xgFthfuPjfkjhu7OkNkwYg+iPhPOjfi9K1MnZ7rVm9Ni
+OlKjUzBRMB0GA1UdDgQWBBThS09hLMMyvf6HWZLRLNKuNfBuezAfBgNVHSMEGDAW
+gBThS09hLMMyvf6HWZLRLNKuNfBuezAPBgNVHRMBAf8EBTADAQH/MAoGCCqGSM49
+BAMCA0cAMEQCIHpapOvcUwRiEMbSecS0BUXj3bWizyRRfGNaKwTb3yXwAiBK/WRO
+4+q8E26jdyTWyQd6xlTy84/wPcg/vYTKcB2YFg==
+-----END CERTIFICATE-----`
+
+func TestGatewayWithFrontendValidation(t *testing.T) {
+	// The markers read back from the controller are compared against the model
+	lib.SetClusterLabelChecksum()
+
+	gatewayName := "gateway-frontend-validation-01"
+	gatewayClassName := "gateway-class-frontend-validation-01"
+	configMapName := "frontend-ca-01"
+	invalidConfigMapName := "frontend-ca-invalid-01"
+	ports := []int32{8443, 8444}
+	secrets := []string{"secret-frontend-validation-01"}
+
+	tests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	for _, secret := range secrets {
+		integrationtest.AddSecret(secret, DEFAULT_NAMESPACE, "cert", "key")
+	}
+	tests.SetupCACertConfigMap(t, configMapName, DEFAULT_NAMESPACE, frontendValidationCACert)
+	tests.SetupCACertConfigMap(t, invalidConfigMapName, DEFAULT_NAMESPACE, "-----BEGIN CERTIFICATE-----\ninvalid\n-----END CERTIFICATE-----")
+
+	listeners := tests.GetListenersV1(ports, false, false, secrets...)
+	listeners[0].TLS.FrontendValidation = &gatewayv1.FrontendTLSValidation{
+		CACertificateRefs: []gatewayv1.ObjectReference{{Kind: "ConfigMap", Name: gatewayv1.ObjectName(configMapName)}},
+	}
+	tests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	modelName := lib.GetModelName(lib.GetTenant(), akogatewayapilib.GetGatewayParentName(DEFAULT_NAMESPACE, gatewayName))
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found || aviModel == nil {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes) != 1 {
+			return 0
+		}
+		return len(nodes[0].ApplicationProfileRefs)
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	appProfile := nodes[0].ApplicationProfileRefs[0]
+	g.Expect(appProfile.Name).To(gomega.Equal(akogatewayapilib.GetFrontendApplicationProfileName(DEFAULT_NAMESPACE, gatewayName, ports[0])))
+	g.Expect(appProfile.SSLClientCertificateMode).To(gomega.Equal(lib.SSLClientCertificateModeRequire))
+	g.Expect(appProfile.ParentApplicationProfile).To(gomega.Equal(utils.DEFAULT_L7_SECURE_APP_PROFILE))
+	g.Expect(appProfile.PkiProfile).NotTo(gomega.BeNil())
+	g.Expect(appProfile.PkiProfile.Name).To(gomega.Equal(akogatewayapilib.GetFrontendPkiProfileName(DEFAULT_NAMESPACE, gatewayName, ports[0])))
+	g.Expect(appProfile.PkiProfile.CACert).To(gomega.Equal(frontendValidationCACert))
+
+	// The profile inherits the settings of the parent profile fetched during the cache population and the
+	// checksum of the created profile matches the model, hence it is not updated on every sync.
+	parentAppProfile, found := avicache.GetParentApplicationProfile(utils.DEFAULT_L7_SECURE_APP_PROFILE)
+	g.Expect(found).To(gomega.BeTrue())
+	g.Expect(appProfile.GetCheckSum()).To(gomega.Equal(lib.ApplicationProfileChecksum(appProfile.Name, appProfile.SSLClientCertificateMode,
+		lib.ApplicationProfileSettingsChecksum(parentAppProfile), appProfile.AviMarkers, nil, false)))
+	appProfileKey := avicache.NamespaceName{Namespace: lib.GetTenant(), Name: appProfile.Name}
+	g.Eventually(func() uint32 {
+		appProfileCache, found := avicache.SharedAviObjCache().AppProfileCache.AviCacheGet(appProfileKey)
+		if !found {
+			return 0
+		}
+		return appProfileCache.(*avicache.AviApplicationProfileCache).CloudConfigCksum
+	}, 25*time.Second).Should(gomega.Equal(appProfile.GetCheckSum()))
+	g.Expect(nodes[0].PortProto).To(gomega.HaveLen(2))
+	for _, pp := range nodes[0].PortProto {
+		if pp.Port == ports[0] {
+			g.Expect(pp.ApplicationProfile).To(gomega.Equal(appProfile.Name))
+		} else {
+			g.Expect(pp.ApplicationProfile).To(gomega.BeEmpty())
+		}
+	}
+
+	// A CA bundle which does not hold a valid certificate invalidates the listener
+	listeners[0].TLS.FrontendValidation.CACertificateRefs[0].Name = gatewayv1.ObjectName(invalidConfigMapName)
+	tests.UpdateGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+	g.Eventually(func() string {
+		gateway, err := tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+		if err != nil || gateway == nil || len(gateway.Status.Listeners) != 2 {
+			return ""
+		}
+		condition := apimeta.FindStatusCondition(gateway.Status.Listeners[0].Conditions, string(gatewayv1.ListenerConditionResolvedRefs))
+		if condition == nil || condition.Status != metav1.ConditionFalse {
+			return ""
+		}
+		return condition.Reason
+	}, 30*time.Second).Should(gomega.Equal(string(gatewayv1.ListenerReasonInvalidCertificateRef)))
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes) != 1 {
+			return -1
+		}
+		return len(nodes[0].ApplicationProfileRefs) + len(nodes[0].PortProto)
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	// Fixing the CA bundle in the referred ConfigMap revalidates the listener
+	tests.UpdateCACertConfigMap(t, invalidConfigMapName, DEFAULT_NAMESPACE, frontendValidationCACert)
+	g.Eventually(func() metav1.ConditionStatus {
+		gateway, err := tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+		if err != nil || gateway == nil || len(gateway.Status.Listeners) != 2 {
+			return ""
+		}
+		condition := apimeta.FindStatusCondition(gateway.Status.Listeners[0].Conditions, string(gatewayv1.ListenerConditionResolvedRefs))
+		if condition == nil {
+			return ""
+		}
+		return condition.Status
+	}, 30*time.Second).Should(gomega.Equal(metav1.ConditionTrue))
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes) != 1 {
+			return -1
+		}
+		return len(nodes[0].ApplicationProfileRefs) + len(nodes[0].PortProto)
+	}, 25*time.Second).Should(gomega.Equal(3))
+
+	// Removing the frontend validation removes the application profile
+	listeners[0].TLS.FrontendValidation = nil
+	tests.UpdateGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes) != 1 {
+			return -1
+		}
+		return len(nodes[0].ApplicationProfileRefs)
+	}, 25*time.Second).Should(gomega.Equal(0))
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	nodes = aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	g.Expect(nodes[0].PortProto).To(gomega.HaveLen(2))
+
+	tests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	tests.TeardownCACertConfigMap(t, configMapName, DEFAULT_NAMESPACE)
+	tests.TeardownCACertConfigMap(t, invalidConfigMapName, DEFAULT_NAMESPACE)
+	for _, secret := range secrets {
+		integrationtest.DeleteSecret(secret, DEFAULT_NAMESPACE)
+	}
+	tests.TeardownGatewayClass(t, gatewayClassName)
+}
@@ -0,0 +1,253 @@
+/*
+ * Copyright © 2025 Broadcom Inc. and/or its subsidiaries. All Rights Reserved.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package graphlayer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	akogatewayapitests "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/gatewayapitests"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+func getGRPCRoute(name, namespace string, parentRefs []gatewayv1.ParentReference, hostnames []gatewayv1.Hostname, svcName string, port int32, matches ...gatewayv1.GRPCRouteMatch) *gatewayv1.GRPCRoute {
+	backendPort := gatewayv1.PortNumber(port)
+	return &gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: gatewayv1.GRPCRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: parentRefs},
+			Hostnames:       hostnames,
+			Rules: []gatewayv1.GRPCRouteRule{{
+				Matches: matches,
+				BackendRefs: []gatewayv1.GRPCBackendRef{{
+					BackendRef: gatewayv1.BackendRef{
+						BackendObjectReference: gatewayv1.BackendObjectReference{
+							Name: gatewayv1.ObjectName(svcName),
+							Port: &backendPort,
+						},
+					},
+				}},
+			}},
+		},
+	}
+}
+
+func getGRPCMethodMatch(service, method string) gatewayv1.GRPCRouteMatch {
+	matchType := gatewayv1.GRPCMethodMatchExact
+	methodMatch := &gatewayv1.GRPCMethodMatch{Type: &matchType}
+	if service != "" {
+		methodMatch.Service = &service
+	}
+	if method != "" {
+		methodMatch.Method = &method
+	}
+	return gatewayv1.GRPCRouteMatch{Method: methodMatch}
+}
+
+func TestGRPCRouteCRUD(t *testing.T) {
+	gatewayClassName := "gateway-class-grpc-01"
+	gatewayName := "gateway-grpc-01"
+	grpcRouteName := "grpcroute-01"
+	namespace := "default"
+	svcName := "avisvc-grpc-01"
+	ports := []int32{8080, 8081}
+
+	integrationtest.CreateSVC(t, namespace, svcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPS(t, namespace, svcName, false, false, "1.1.1")
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, namespace, gatewayClassName, nil, listeners)
+
+	modelName := lib.GetModelName(lib.GetTenant(), akogatewayapilib.GetGatewayParentName(namespace, gatewayName))
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	// attach the GRPCRoute only to the listener on port 8080
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, namespace, []int32{8080})
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	route := getGRPCRoute(grpcRouteName, namespace, parentRefs, hostnames, svcName, 8080, getGRPCMethodMatch("helloworld.Greeter", "SayHello"))
+	if _, err := akogatewayapitests.GatewayClient.GatewayV1().GRPCRoutes(namespace).Create(context.TODO(), route, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding GRPCRoute: %v", err)
+	}
+
+	g.Eventually(func() bool {
+		grpcRoute, err := akogatewayapitests.GatewayClient.GatewayV1().GRPCRoutes(namespace).Get(context.TODO(), grpcRouteName, metav1.GetOptions{})
+		if err != nil || len(grpcRoute.Status.Parents) != 1 {
+			return false
+		}
+		return apimeta.IsStatusConditionTrue(grpcRoute.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionAccepted))
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	g.Eventually(func() bool {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return false
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		return len(nodes) > 0 && len(nodes[0].EvhNodes) == 1
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	g.Expect(nodes[0].PortProto).To(gomega.HaveLen(2))
+	for _, portProto := range nodes[0].PortProto {
+		g.Expect(portProto.EnableHTTP2).To(gomega.Equal(portProto.Port == 8080))
+	}
+	childNode := nodes[0].EvhNodes[0]
+	g.Expect(childNode.VHMatches).To(gomega.HaveLen(1))
+	g.Expect(*childNode.VHMatches[0].Host).To(gomega.Equal("foo-8080.com"))
+	g.Expect(*childNode.VHMatches[0].Rules[0].Matches.Path.MatchCriteria).To(gomega.Equal("EQUALS"))
+	g.Expect(childNode.VHMatches[0].Rules[0].Matches.Path.MatchStr).To(gomega.Equal([]string{"/helloworld.Greeter/SayHello"}))
+	g.Expect(childNode.PoolRefs).To(gomega.HaveLen(1))
+	g.Expect(childNode.PoolRefs[0].EnableHttp2).NotTo(gomega.BeNil())
+	g.Expect(*childNode.PoolRefs[0].EnableHttp2).To(gomega.BeTrue())
+	g.Expect(childNode.PoolRefs[0].Servers).To(gomega.HaveLen(1))
+
+	// a service-only match selects every method of the service
+	route.Spec.Rules[0].Matches = []gatewayv1.GRPCRouteMatch{getGRPCMethodMatch("helloworld.Greeter", "")}
+	if _, err := akogatewayapitests.GatewayClient.GatewayV1().GRPCRoutes(namespace).Update(context.TODO(), route, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating GRPCRoute: %v", err)
+	}
+	g.Eventually(func() []string {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return nil
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes) == 0 || len(nodes[0].EvhNodes) != 1 || len(nodes[0].EvhNodes[0].VHMatches) != 1 {
+			return nil
+		}
+		return nodes[0].EvhNodes[0].VHMatches[0].Rules[0].Matches.Path.MatchStr
+	}, 25*time.Second).Should(gomega.Equal([]string{"/helloworld.Greeter/"}))
+
+	// moving the GRPCRoute to the listener on port 8081 disables HTTP/2 on port 8080
+	route.Spec.ParentRefs = akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, namespace, []int32{8081})
+	route.Spec.Hostnames = []gatewayv1.Hostname{"foo-8081.com"}
+	if _, err := akogatewayapitests.GatewayClient.GatewayV1().GRPCRoutes(namespace).Update(context.TODO(), route, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating GRPCRoute: %v", err)
+	}
+	g.Eventually(func() []int32 {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return nil
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes) == 0 {
+			return nil
+		}
+		var http2Ports []int32
+		for _, portProto := range nodes[0].PortProto {
+			if portProto.EnableHTTP2 {
+				http2Ports = append(http2Ports, portProto.Port)
+			}
+		}
+		return http2Ports
+	}, 25*time.Second).Should(gomega.Equal([]int32{8081}))
+
+	if err := akogatewayapitests.GatewayClient.GatewayV1().GRPCRoutes(namespace).Delete(context.TODO(), grpcRouteName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("error in deleting GRPCRoute: %v", err)
+	}
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return -1
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes) == 0 {
+			return -1
+		}
+		return len(nodes[0].EvhNodes)
+	}, 25*time.Second).Should(gomega.Equal(0))
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	nodes = aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	for _, portProto := range nodes[0].PortProto {
+		g.Expect(portProto.EnableHTTP2).To(gomega.BeFalse())
+	}
+
+	integrationtest.DelSVC(t, namespace, svcName)
+	integrationtest.DelEPS(t, namespace, svcName)
+	akogatewayapitests.TeardownGateway(t, gatewayName, namespace)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestGRPCRouteWithUnsupportedFilter(t *testing.T) {
+	gatewayClassName := "gateway-class-grpc-02"
+	gatewayName := "gateway-grpc-02"
+	grpcRouteName := "grpcroute-02"
+	namespace := "default"
+	svcName := "avisvc-grpc-02"
+	ports := []int32{8080}
+
+	integrationtest.CreateSVC(t, namespace, svcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPS(t, namespace, svcName, false, false, "1.1.1")
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, namespace, gatewayClassName, nil, listeners)
+
+	modelName := lib.GetModelName(lib.GetTenant(), akogatewayapilib.GetGatewayParentName(namespace, gatewayName))
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, namespace, ports)
+	route := getGRPCRoute(grpcRouteName, namespace, parentRefs, []gatewayv1.Hostname{"foo-8080.com"}, svcName, 8080)
+	route.Spec.Rules[0].Filters = []gatewayv1.GRPCRouteFilter{{
+		Type:          gatewayv1.GRPCRouteFilterRequestMirror,
+		RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{BackendRef: route.Spec.Rules[0].BackendRefs[0].BackendObjectReference},
+	}}
+	if _, err := akogatewayapitests.GatewayClient.GatewayV1().GRPCRoutes(namespace).Create(context.TODO(), route, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding GRPCRoute: %v", err)
+	}
+
+	g.Eventually(func() bool {
+		grpcRoute, err := akogatewayapitests.GatewayClient.GatewayV1().GRPCRoutes(namespace).Get(context.TODO(), grpcRouteName, metav1.GetOptions{})
+		if err != nil || len(grpcRoute.Status.Parents) != 1 {
+			return false
+		}
+		condition := apimeta.FindStatusCondition(grpcRoute.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionAccepted))
+		return condition != nil && condition.Status == metav1.ConditionFalse &&
+			condition.Reason == string(gatewayv1.RouteReasonUnsupportedValue)
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	g.Expect(nodes[0].EvhNodes).To(gomega.HaveLen(0))
+
+	if err := akogatewayapitests.GatewayClient.GatewayV1().GRPCRoutes(namespace).Delete(context.TODO(), grpcRouteName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("error in deleting GRPCRoute: %v", err)
+	}
+	integrationtest.DelSVC(t, namespace, svcName)
+	integrationtest.DelEPS(t, namespace, svcName)
+	akogatewayapitests.TeardownGateway(t, gatewayName, namespace)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
@@ -2,6 +2,7 @@ package graphlayer
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -1142,3 +1143,195 @@ func TestDedicatedGatewayWithMultipleMatches(t *testing.T) {
 	tests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
 	tests.TeardownGatewayClass(t, gatewayClassName)
 }
+
+// TestDedicatedGatewayWithQueryParamAndMethodMatching tests query param and method matches in dedicated mode
+func TestDedicatedGatewayWithQueryParamAndMethodMatching(t *testing.T) {
+	gatewayName := "gateway-dedicated-query"
+	gatewayClassName := "gateway-class-dedicated-query"
+	httpRouteName := "http-route-dedicated-query"
+	svcName := "avisvc-dedicated-query"
+	ports := []int32{8080}
+	modelName := "admin/" + akogatewayapilib.Prefix + "cluster--" + DEFAULT_NAMESPACE + "-" + gatewayName + "-L7-dedicated-EVH"
+
+	tests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := tests.GetDedicatedListenersV1(ports)
+	tests.SetupDedicatedGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, svcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPS(t, DEFAULT_NAMESPACE, svcName, false, false, "1.1.1")
+
+	parentRefs := tests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := tests.GetHTTPRouteRuleV1(integrationtest.PATHPREFIX, []string{"/api"}, []string{}, nil,
+		[][]string{{svcName, DEFAULT_NAMESPACE, "8080", "1"}}, nil)
+	method := gatewayv1.HTTPMethodGet
+	queryParamType := gatewayv1.QueryParamMatchRegularExpression
+	rule.Matches[0].Method = &method
+	rule.Matches[0].QueryParams = []gatewayv1.HTTPQueryParamMatch{{Type: &queryParamType, Name: "version", Value: "v[0-9]+"}}
+	tests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, []gatewayv1.Hostname{}, []gatewayv1.HTTPRouteRule{rule})
+
+	g.Eventually(func() bool {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return false
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		return len(nodes) > 0 && len(nodes[0].HttpPolicyRefs) > 0 && len(nodes[0].HttpPolicyRefs[0].RequestRules) == 2
+	}, 60*time.Second).Should(gomega.Equal(true))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	requestRule := nodes[0].HttpPolicyRefs[0].RequestRules[0]
+	g.Expect(requestRule.Match.Method).NotTo(gomega.BeNil())
+	g.Expect(requestRule.Match.Method.Methods).To(gomega.Equal([]string{"HTTP_METHOD_GET"}))
+	g.Expect(requestRule.Match.Query).NotTo(gomega.BeNil())
+	g.Expect(*requestRule.Match.Query.MatchCriteria).To(gomega.Equal("QUERY_MATCH_REGEX_MATCH"))
+	g.Expect(requestRule.Match.Query.StringGroupRefs).To(gomega.HaveLen(1))
+
+	// the query regex is attached via string group
+	g.Expect(nodes[0].StringGroupRefs).To(gomega.HaveLen(1))
+	g.Expect(*nodes[0].StringGroupRefs[0].StringGroup.Kv[0].Key).To(gomega.Equal("(^|&)version=(v[0-9]+)(&|$)"))
+
+	integrationtest.DelEPS(t, DEFAULT_NAMESPACE, svcName)
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+	tests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	tests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	tests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+// TestDedicatedGatewayWithRequestMirror tests that a rule with RequestMirror filter is dropped in dedicated mode
+func TestDedicatedGatewayWithRequestMirror(t *testing.T) {
+	gatewayName := "gateway-dedicated-mirror-01"
+	gatewayClassName := "gateway-class-dedicated-mirror-01"
+	httpRouteName := "http-route-dedicated-mirror-01"
+	svcName := "avisvc-dedicated-mirror-01"
+	mirrorSvcName := "avisvc-dedicated-mirror-01-shadow"
+	ports := []int32{8080}
+	modelName := "admin/" + akogatewayapilib.Prefix + "cluster--" + DEFAULT_NAMESPACE + "-" + gatewayName + "-L7-dedicated-EVH"
+
+	tests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := tests.GetDedicatedListenersV1(ports)
+	tests.SetupDedicatedGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, svcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPS(t, DEFAULT_NAMESPACE, svcName, false, false, "1.2.3")
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, mirrorSvcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPS(t, DEFAULT_NAMESPACE, mirrorSvcName, false, false, "2.3.4")
+
+	parentRefs := tests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rules := []gatewayv1.HTTPRouteRule{
+		tests.GetHTTPRouteRuleV1(integrationtest.PATHPREFIX, []string{"/bar"}, []string{}, nil,
+			[][]string{{svcName, DEFAULT_NAMESPACE, "8080", "1"}}, nil),
+		getRequestMirrorRule(svcName, mirrorSvcName, nil),
+	}
+	tests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, []gatewayv1.Hostname{}, rules)
+
+	g.Eventually(func() bool {
+		httpRoute, err := tests.GatewayClient.GatewayV1().HTTPRoutes(DEFAULT_NAMESPACE).Get(context.TODO(), httpRouteName, metav1.GetOptions{})
+		if err != nil || len(httpRoute.Status.Parents) != 1 {
+			return false
+		}
+		condition := apimeta.FindStatusCondition(httpRoute.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionPartiallyInvalid))
+		return condition != nil && condition.Status == metav1.ConditionTrue &&
+			condition.Reason == string(gatewayv1.RouteReasonUnsupportedValue) &&
+			strings.Contains(condition.Message, "RequestMirror is not supported in dedicated mode")
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes) == 0 {
+			return 0
+		}
+		return len(nodes[0].PoolRefs)
+	}, 25*time.Second).Should(gomega.Equal(1))
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	g.Expect(nodes[0].TrafficCloneProfile).To(gomega.BeNil())
+
+	integrationtest.DelEPS(t, DEFAULT_NAMESPACE, mirrorSvcName)
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, mirrorSvcName)
+	integrationtest.DelEPS(t, DEFAULT_NAMESPACE, svcName)
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+	tests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	tests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	tests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+// TestDedicatedGatewayWithUrlRewritePrefixMatch tests that the exact path prefix is rewritten without the separator in dedicated mode
+func TestDedicatedGatewayWithUrlRewritePrefixMatch(t *testing.T) {
+	gatewayName := "gateway-dedicated-rewrite-prefix"
+	gatewayClassName := "gateway-class-dedicated-rewrite-prefix"
+	httpRouteName := "http-route-dedicated-rewrite-prefix"
+	svcName := "avisvc-dedicated-rewrite-prefix"
+	ports := []int32{8080}
+	modelName := "admin/" + akogatewayapilib.Prefix + "cluster--" + DEFAULT_NAMESPACE + "-" + gatewayName + "-L7-dedicated-EVH"
+
+	tests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := tests.GetDedicatedListenersV1(ports)
+	tests.SetupDedicatedGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, svcName, corev1.ProtocolTCP, corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPS(t, DEFAULT_NAMESPACE, svcName, false, false, "1.1.1")
+
+	parentRefs := tests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := tests.GetHTTPRouteRuleV1(integrationtest.PATHPREFIX, []string{"/foo"}, []string{},
+		map[string][]string{"URLRewrite": {}},
+		[][]string{{svcName, DEFAULT_NAMESPACE, "8080", "1"}}, nil)
+	replacePrefix := "/bar"
+	rule.Filters[0].URLRewrite.Path = &gatewayv1.HTTPPathModifier{
+		Type:               gatewayv1.PrefixMatchHTTPPathModifier,
+		ReplacePrefixMatch: &replacePrefix,
+	}
+	tests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, []gatewayv1.Hostname{}, []gatewayv1.HTTPRouteRule{rule})
+
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes) == 0 || len(nodes[0].HttpPolicyRefs) == 0 {
+			return 0
+		}
+		rewriteRules := 0
+		for _, requestRule := range nodes[0].HttpPolicyRefs[0].RequestRules {
+			if requestRule.RewriteURLAction != nil {
+				rewriteRules++
+			}
+		}
+		return rewriteRules
+	}, 25*time.Second).Should(gomega.Equal(2))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	requestRules := nodes[0].HttpPolicyRefs[0].RequestRules
+	// /foo is rewritten to /bar, ahead of /foo/* being rewritten to /bar/*
+	g.Expect(*requestRules[0].Match.Path.MatchCriteria).To(gomega.Equal("EQUALS"))
+	g.Expect(requestRules[0].Match.Path.MatchStr).To(gomega.Equal([]string{"/foo"}))
+	g.Expect(requestRules[0].RewriteURLAction.Path.Tokens).To(gomega.HaveLen(1))
+	g.Expect(*requestRules[0].RewriteURLAction.Path.Tokens[0].StrValue).To(gomega.Equal("bar"))
+	g.Expect(requestRules[0].SwitchingAction).NotTo(gomega.BeNil())
+	g.Expect(*requestRules[1].Match.Path.MatchCriteria).To(gomega.Equal("BEGINS_WITH"))
+	g.Expect(*requestRules[1].RewriteURLAction.Path.Tokens[0].StrValue).To(gomega.Equal("bar/"))
+	g.Expect(*requestRules[1].Index).To(gomega.Equal(*requestRules[0].Index + 1))
+
+	integrationtest.DelEPS(t, DEFAULT_NAMESPACE, svcName)
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+	tests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	tests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	tests.TeardownGatewayClass(t, gatewayClassName)
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	akogatewayapiobjects "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/objects"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
@@ -43,6 +45,7 @@ import (
  * - HTTPRouteFilter with Request Header Modifier
  * - HTTPRouteFilter with Response Header Modifier
  * - HTTPRouteFilter with Request Redirect
+ * - HTTPRouteFilter with Request Mirror
  * - HTTPRouteBackendRef CRUD (TODO)
  */
 func TestHTTPRouteCRUD(t *testing.T) {
@@ -2762,6 +2765,104 @@ func TestHTTPRouteBackendServiceInvalidType(t *testing.T) {
 	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
 }
 
+func TestHTTPRouteBackendServiceExternalName(t *testing.T) {
+
+	gatewayName := "gateway-hr-extname"
+	gatewayClassName := "gateway-class-hr-extname"
+	httpRouteName := "http-route-hr-extname"
+	svcName := "avisvc-hr-extname"
+
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	svcExample := (integrationtest.FakeService{
+		Name:         svcName,
+		Namespace:    DEFAULT_NAMESPACE,
+		Type:         corev1.ServiceTypeExternalName,
+		ExternalName: "legacy.example.com",
+		ServicePorts: []integrationtest.Serviceport{{PortName: "foo0", Protocol: "TCP", PortNumber: 8080, TargetPort: intstr.FromInt(8080)}},
+	}).Service()
+	if _, err := akogatewayapitests.KubeClient.CoreV1().Services(DEFAULT_NAMESPACE).Create(context.TODO(), svcExample, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Service: %v", err)
+	}
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1(integrationtest.PATHPREFIX, []string{"/foo"}, []string{},
+		map[string][]string{},
+		[][]string{{svcName, DEFAULT_NAMESPACE, "8080", "1"}}, nil)
+	rules := []gatewayv1.HTTPRouteRule{rule}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) == 0 || len(nodes[0].EvhNodes[0].PoolRefs) == 0 {
+			return 0
+		}
+		return len(nodes[0].EvhNodes[0].PoolRefs[0].Servers)
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	pool := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()[0].EvhNodes[0].PoolRefs[0]
+	g.Expect(pool.Servers[0].Hostname).To(gomega.Equal("legacy.example.com"))
+	g.Expect(*pool.Servers[0].Ip.Type).To(gomega.Equal("DNS"))
+	g.Expect(pool.Servers[0].Port).To(gomega.Equal(int32(8080)))
+	g.Expect(pool.SslProfileRef).To(gomega.BeNil())
+
+	// changes to the externalName are reflected on the pool server.
+	svcExample.Spec.ExternalName = "legacy2.example.com"
+	svcExample.ResourceVersion = "2"
+	if _, err := akogatewayapitests.KubeClient.CoreV1().Services(DEFAULT_NAMESPACE).Update(context.TODO(), svcExample, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+	g.Eventually(func() string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) == 0 || len(nodes[0].EvhNodes[0].PoolRefs) == 0 ||
+			len(nodes[0].EvhNodes[0].PoolRefs[0].Servers) == 0 {
+			return ""
+		}
+		return nodes[0].EvhNodes[0].PoolRefs[0].Servers[0].Hostname
+	}, 25*time.Second).Should(gomega.Equal("legacy2.example.com"))
+
+	// the https appProtocol enables SSL on the pool with the externalName as SNI.
+	appProtocol := lib.AppProtocolHTTPS
+	svcExample.Spec.Ports[0].AppProtocol = &appProtocol
+	svcExample.ResourceVersion = "3"
+	if _, err := akogatewayapitests.KubeClient.CoreV1().Services(DEFAULT_NAMESPACE).Update(context.TODO(), svcExample, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+	g.Eventually(func() string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) == 0 || len(nodes[0].EvhNodes[0].PoolRefs) == 0 ||
+			nodes[0].EvhNodes[0].PoolRefs[0].ServerName == nil {
+			return ""
+		}
+		return *nodes[0].EvhNodes[0].PoolRefs[0].ServerName
+	}, 25*time.Second).Should(gomega.Equal("legacy2.example.com"))
+
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
 func TestSecretCreateDeleteWithHTTPRoute(t *testing.T) {
 
 	/*
@@ -3406,6 +3507,256 @@ func TestHTTPRouteWithSessionPersistence(t *testing.T) {
 	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
 }
 
+func TestHTTPRouteWithHeaderSessionPersistence(t *testing.T) {
+	gatewayClassName := "gateway-class-hr-34"
+	gatewayName := "gateway-hr-34"
+	httpRouteName := "httproute-34"
+	namespace := "default"
+	svcName := "avisvc-hr-34"
+	ports := []int32{8080}
+	ruleName := "sticky-header-rule"
+	headerName := "x-session-id"
+	var absoluteTimeout gatewayv1.Duration = "2h"
+	var idleTimeout gatewayv1.Duration = "30m"
+
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, svcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPS(t, "default", svcName, false, false, "1.1.1")
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, namespace, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		gateway, err := akogatewayapitests.GatewayClient.GatewayV1().Gateways(namespace).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+		if err != nil || gateway == nil {
+			t.Logf("Couldn't get the gateway, err: %+v", err)
+			return false
+		}
+		return apimeta.FindStatusCondition(gateway.Status.Conditions, string(gatewayv1.GatewayConditionAccepted)) != nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, namespace, ports)
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	rule := akogatewayapitests.GetHTTPRouteRuleV1(integrationtest.PATHPREFIX, []string{"/foo"}, []string{},
+		nil,
+		[][]string{{svcName, namespace, "8080", "1"}}, nil)
+
+	rule.Name = (*gatewayv1.SectionName)(&ruleName)
+	headerType := gatewayv1.HeaderBasedSessionPersistence
+	rule.SessionPersistence = &gatewayv1.SessionPersistence{
+		Type:            &headerType,
+		SessionName:     &headerName,
+		AbsoluteTimeout: &absoluteTimeout,
+		IdleTimeout:     &idleTimeout,
+	}
+
+	rules := []gatewayv1.HTTPRouteRule{rule}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, namespace, parentRefs, hostnames, rules)
+	g.Eventually(func() bool {
+		httpRoute, err := akogatewayapitests.GatewayClient.GatewayV1().HTTPRoutes(namespace).Get(context.TODO(), httpRouteName, metav1.GetOptions{})
+		if err != nil || httpRoute == nil || len(httpRoute.Status.Parents) != len(ports) {
+			return false
+		}
+		return apimeta.IsStatusConditionTrue(httpRoute.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionAccepted))
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	modelName := lib.GetModelName(lib.GetTenant(), akogatewayapilib.GetGatewayParentName(DEFAULT_NAMESPACE, gatewayName))
+
+	g.Eventually(func() bool {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found || aviModel == nil {
+			return false
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes) == 0 || len(nodes[0].EvhNodes) == 0 || len(nodes[0].EvhNodes[0].PoolRefs) == 0 {
+			return false
+		}
+		return nodes[0].EvhNodes[0].PoolRefs[0].ApplicationPersistenceProfile != nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	appPersistProfile := nodes[0].EvhNodes[0].PoolRefs[0].ApplicationPersistenceProfile
+	g.Expect(appPersistProfile.PersistenceType).To(gomega.Equal("PERSISTENCE_TYPE_APP_COOKIE"))
+	g.Expect(appPersistProfile.HTTPCookiePersistenceProfile).To(gomega.BeNil())
+	g.Expect(appPersistProfile.AppCookiePersistenceProfile).NotTo(gomega.BeNil())
+	g.Expect(appPersistProfile.AppCookiePersistenceProfile.HeaderName).To(gomega.Equal(headerName))
+	// The smaller of the absolute and idle timeouts is used
+	g.Expect(*appPersistProfile.AppCookiePersistenceProfile.Timeout).To(gomega.Equal(int32(30)))
+
+	// Timeouts beyond the Avi limit are clamped
+	absoluteTimeout = "24h"
+	rule.SessionPersistence.IdleTimeout = nil
+	rules = []gatewayv1.HTTPRouteRule{rule}
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, namespace, parentRefs, hostnames, rules)
+
+	g.Eventually(func() int32 {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		appPersistProfile := nodes[0].EvhNodes[0].PoolRefs[0].ApplicationPersistenceProfile
+		if appPersistProfile == nil || appPersistProfile.AppCookiePersistenceProfile == nil ||
+			appPersistProfile.AppCookiePersistenceProfile.Timeout == nil {
+			return 0
+		}
+		return *appPersistProfile.AppCookiePersistenceProfile.Timeout
+	}, 25*time.Second).Should(gomega.Equal(int32(akogatewayapilib.AppCookiePersistenceTimeoutMax)))
+
+	// Remove session persistence
+	rule.SessionPersistence = nil
+	rules = []gatewayv1.HTTPRouteRule{rule}
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, namespace, parentRefs, hostnames, rules)
+
+	g.Eventually(func() bool {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		return nodes[0].EvhNodes[0].PoolRefs[0].ApplicationPersistenceProfile == nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	// Teardown
+	integrationtest.DelSVC(t, namespace, svcName)
+	integrationtest.DelEPS(t, namespace, svcName)
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, namespace)
+	akogatewayapitests.TeardownGateway(t, gatewayName, namespace)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestHTTPRouteFilterWithCORS(t *testing.T) {
+	gatewayName := "gateway-hr-35"
+	gatewayClassName := "gateway-class-hr-35"
+	httpRouteName := "http-route-hr-35"
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1(integrationtest.PATHPREFIX, []string{"/foo"}, []string{},
+		map[string][]string{"RequestHeaderModifier": {"add"}},
+		[][]string{{"avisvc", "default", "8080", "1"}}, nil)
+	rule.Filters = append(rule.Filters, gatewayv1.HTTPRouteFilter{
+		Type: gatewayv1.HTTPRouteFilterCORS,
+		CORS: &gatewayv1.HTTPCORSFilter{
+			AllowOrigins:     []gatewayv1.AbsoluteURI{"https://app.foo.com", "https://admin.foo.com"},
+			AllowCredentials: true,
+			AllowMethods:     []gatewayv1.HTTPMethodWithWildcard{"GET", "POST"},
+			AllowHeaders:     []gatewayv1.HTTPHeaderName{"x-token"},
+			ExposeHeaders:    []gatewayv1.HTTPHeaderName{"x-request-id"},
+		},
+	})
+	rules := []gatewayv1.HTTPRouteRule{rule}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return -1
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) != 1 || len(nodes[0].EvhNodes[0].HttpPolicyRefs) != 1 {
+			return -1
+		}
+		return len(nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules)
+	}, 25*time.Second).Should(gomega.Equal(2))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	policy := nodes[0].EvhNodes[0].HttpPolicyRefs[0]
+
+	// the header modifier rule is followed by the preflight rule answered locally
+	g.Expect(policy.RequestRules[0].HdrAction).To(gomega.HaveLen(1))
+	preflightRule := policy.RequestRules[1]
+	g.Expect(*preflightRule.Name).To(gomega.Equal(policy.Name + "-cors-preflight"))
+	g.Expect(*preflightRule.Index).To(gomega.Equal(int32(2)))
+	g.Expect(preflightRule.Match.Method.Methods).To(gomega.Equal([]string{"HTTP_METHOD_OPTIONS"}))
+	g.Expect(preflightRule.Match.Hdrs).To(gomega.HaveLen(2))
+	g.Expect(preflightRule.Match.Hdrs[0].Value).To(gomega.Equal([]string{"https://app.foo.com", "https://admin.foo.com"}))
+	g.Expect(*preflightRule.SwitchingAction.Action).To(gomega.Equal("HTTP_SWITCHING_SELECT_LOCAL"))
+	g.Expect(*preflightRule.SwitchingAction.StatusCode).To(gomega.Equal("HTTP_LOCAL_RESPONSE_STATUS_CODE_204"))
+
+	// a response rule per origin, followed by the preflight response rule
+	g.Expect(policy.ResponseRules).To(gomega.HaveLen(3))
+	for i, origin := range []string{"https://app.foo.com", "https://admin.foo.com"} {
+		responseRule := policy.ResponseRules[i]
+		g.Expect(*responseRule.Index).To(gomega.Equal(int32(i + 1)))
+		g.Expect(responseRule.Match.Hdrs[0].Value).To(gomega.Equal([]string{origin}))
+		g.Expect(responseRule.HdrAction).To(gomega.HaveLen(4))
+		g.Expect(*responseRule.HdrAction[0].Hdr.Name).To(gomega.Equal("Access-Control-Allow-Origin"))
+		g.Expect(*responseRule.HdrAction[0].Hdr.Value.Val).To(gomega.Equal(origin))
+		g.Expect(*responseRule.HdrAction[1].Hdr.Name).To(gomega.Equal("Access-Control-Allow-Credentials"))
+		g.Expect(*responseRule.HdrAction[2].Hdr.Value.Val).To(gomega.Equal("x-request-id"))
+		g.Expect(*responseRule.HdrAction[3].Hdr.Name).To(gomega.Equal("Vary"))
+	}
+	preflightResponseRule := policy.ResponseRules[2]
+	g.Expect(*preflightResponseRule.Index).To(gomega.Equal(int32(3)))
+	g.Expect(preflightResponseRule.HdrAction).To(gomega.HaveLen(3))
+	g.Expect(*preflightResponseRule.HdrAction[0].Hdr.Value.Val).To(gomega.Equal("GET, POST"))
+	g.Expect(*preflightResponseRule.HdrAction[1].Hdr.Value.Val).To(gomega.Equal("x-token"))
+	g.Expect(*preflightResponseRule.HdrAction[2].Hdr.Value.Val).To(gomega.Equal("5"))
+
+	// any origin without credentials, the CORS filter alone builds the HTTPPolicySet
+	rule.Filters = []gatewayv1.HTTPRouteFilter{
+		{
+			Type: gatewayv1.HTTPRouteFilterCORS,
+			CORS: &gatewayv1.HTTPCORSFilter{
+				AllowOrigins: []gatewayv1.AbsoluteURI{"*"},
+				AllowMethods: []gatewayv1.HTTPMethodWithWildcard{"*"},
+				MaxAge:       60,
+			},
+		},
+	}
+	rules = []gatewayv1.HTTPRouteRule{rule}
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) != 1 || len(nodes[0].EvhNodes[0].HttpPolicyRefs) != 1 {
+			return -1
+		}
+		return len(nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules)
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	nodes = aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	policy = nodes[0].EvhNodes[0].HttpPolicyRefs[0]
+	g.Expect(*policy.RequestRules[0].Index).To(gomega.Equal(int32(1)))
+	g.Expect(*policy.RequestRules[0].Match.Hdrs[0].MatchCriteria).To(gomega.Equal("HDR_EXISTS"))
+	g.Expect(policy.ResponseRules).To(gomega.HaveLen(2))
+	g.Expect(policy.ResponseRules[0].HdrAction).To(gomega.HaveLen(1))
+	g.Expect(*policy.ResponseRules[0].HdrAction[0].Hdr.Value.Val).To(gomega.Equal("*"))
+	g.Expect(*policy.ResponseRules[1].HdrAction[1].Hdr.Value.Val).To(gomega.Equal("60"))
+
+	// wildcard origin with credentials can not be translated, the rule is rejected
+	rule.Filters[0].CORS.AllowCredentials = true
+	rules = []gatewayv1.HTTPRouteRule{rule}
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() bool {
+		httpRoute, err := akogatewayapitests.GatewayClient.GatewayV1().HTTPRoutes(DEFAULT_NAMESPACE).Get(context.TODO(), httpRouteName, metav1.GetOptions{})
+		if err != nil || httpRoute == nil || len(httpRoute.Status.Parents) != len(ports) {
+			return false
+		}
+		condition := apimeta.FindStatusCondition(httpRoute.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionAccepted))
+		return condition != nil && condition.Status == metav1.ConditionFalse &&
+			condition.Reason == string(gatewayv1.RouteReasonUnsupportedValue)
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	// delete httproute
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
 func TestHTTPRouteWithRouteRuleName(t *testing.T) {
 	gatewayClassName := "gateway-class-hr-31"
 	gatewayName := "gateway-hr-31"
@@ -3977,6 +4328,24 @@ func TestHTTPRouteWithHealthMonitorMultipleBackends(t *testing.T) {
 		return len(nodes[0].EvhNodes)
 	}, 25*time.Second).Should(gomega.Equal(1))
 
+	// Wait for both pools to pick up the HealthMonitors
+	g.Eventually(func() bool {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return false
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes) == 0 || len(nodes[0].EvhNodes) == 0 || len(nodes[0].EvhNodes[0].PoolRefs) != 2 {
+			return false
+		}
+		for _, pool := range nodes[0].EvhNodes[0].PoolRefs {
+			if len(pool.HealthMonitorRefs) != 2 {
+				return false
+			}
+		}
+		return true
+	}, 25*time.Second).Should(gomega.Equal(true))
+
 	// Verify pools have their respective HealthMonitors
 	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
 	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
@@ -6189,3 +6558,755 @@ func TestHTTPRouteWithRouteBackendExtensionPKIProfileUpdate(t *testing.T) {
 	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
 	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
 }
+
+func getRequestMirrorRule(svcName, mirrorSvcName string, percent *int32) gatewayv1.HTTPRouteRule {
+	rule := akogatewayapitests.GetHTTPRouteRuleV1(integrationtest.PATHPREFIX, []string{"/foo"}, []string{}, nil,
+		[][]string{{svcName, DEFAULT_NAMESPACE, "8080", "1"}}, nil)
+	port := gatewayv1.PortNumber(8080)
+	rule.Filters = []gatewayv1.HTTPRouteFilter{{
+		Type: gatewayv1.HTTPRouteFilterRequestMirror,
+		RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{
+			BackendRef: gatewayv1.BackendObjectReference{
+				Name: gatewayv1.ObjectName(mirrorSvcName),
+				Port: &port,
+			},
+			Percent: percent,
+		},
+	}}
+	return rule
+}
+
+func TestHTTPRouteFilterWithRequestMirror(t *testing.T) {
+	gatewayName := "gateway-hr-mirror-01"
+	gatewayClassName := "gateway-class-hr-mirror-01"
+	httpRouteName := "http-route-hr-mirror-01"
+	svcName := "avisvc-hr-mirror-01"
+	mirrorSvcName := "avisvc-hr-mirror-01-shadow"
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, svcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPS(t, DEFAULT_NAMESPACE, svcName, false, false, "1.2.3")
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, mirrorSvcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPS(t, DEFAULT_NAMESPACE, mirrorSvcName, false, false, "2.3.4")
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rules := []gatewayv1.HTTPRouteRule{getRequestMirrorRule(svcName, mirrorSvcName, nil)}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() bool {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return false
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		return len(nodes[0].EvhNodes) == 1 && nodes[0].EvhNodes[0].TrafficCloneProfile != nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	childVS := nodes[0].EvhNodes[0]
+	g.Expect(childVS.TrafficCloneProfile.Name).NotTo(gomega.BeEmpty())
+	g.Expect(childVS.TrafficCloneProfile.Tenant).To(gomega.Equal(childVS.Tenant))
+	g.Expect(childVS.TrafficCloneProfile.CloneServers).To(gomega.Equal([]string{"2.3.4.1"}))
+	g.Expect(childVS.PoolRefs).To(gomega.HaveLen(1))
+	g.Expect(childVS.PoolRefs[0].Servers).To(gomega.HaveLen(1))
+	g.Expect(*childVS.PoolRefs[0].Servers[0].Ip.Addr).To(gomega.Equal("1.2.3.1"))
+
+	// removing the filter detaches the traffic clone profile
+	rules = []gatewayv1.HTTPRouteRule{akogatewayapitests.GetHTTPRouteRuleV1(integrationtest.PATHPREFIX, []string{"/foo"}, []string{}, nil,
+		[][]string{{svcName, DEFAULT_NAMESPACE, "8080", "1"}}, nil)}
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+	g.Eventually(func() bool {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		return len(nodes[0].EvhNodes) == 1 && nodes[0].EvhNodes[0].TrafficCloneProfile == nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	integrationtest.DelEPS(t, DEFAULT_NAMESPACE, mirrorSvcName)
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, mirrorSvcName)
+	integrationtest.DelEPS(t, DEFAULT_NAMESPACE, svcName)
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestHTTPRouteFilterWithRequestMirrorUnsupportedPercent(t *testing.T) {
+	gatewayName := "gateway-hr-mirror-02"
+	gatewayClassName := "gateway-class-hr-mirror-02"
+	httpRouteName := "http-route-hr-mirror-02"
+	svcName := "avisvc-hr-mirror-02"
+	mirrorSvcName := "avisvc-hr-mirror-02-shadow"
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, svcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPS(t, DEFAULT_NAMESPACE, svcName, false, false, "1.2.3")
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, mirrorSvcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPS(t, DEFAULT_NAMESPACE, mirrorSvcName, false, false, "2.3.4")
+
+	percent := int32(50)
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rules := []gatewayv1.HTTPRouteRule{getRequestMirrorRule(svcName, mirrorSvcName, &percent)}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() bool {
+		httpRoute, err := akogatewayapitests.GatewayClient.GatewayV1().HTTPRoutes(DEFAULT_NAMESPACE).Get(context.TODO(), httpRouteName, metav1.GetOptions{})
+		if err != nil || len(httpRoute.Status.Parents) != 1 {
+			return false
+		}
+		condition := apimeta.FindStatusCondition(httpRoute.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionAccepted))
+		return condition != nil && condition.Status == metav1.ConditionFalse &&
+			condition.Reason == string(gatewayv1.RouteReasonUnsupportedValue)
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	g.Expect(nodes[0].EvhNodes).To(gomega.HaveLen(0))
+
+	integrationtest.DelEPS(t, DEFAULT_NAMESPACE, mirrorSvcName)
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, mirrorSvcName)
+	integrationtest.DelEPS(t, DEFAULT_NAMESPACE, svcName)
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestHTTPRouteFilterWithRequestMirrorTargetPortMismatch(t *testing.T) {
+	gatewayName := "gateway-hr-mirror-03"
+	gatewayClassName := "gateway-class-hr-mirror-03"
+	httpRouteName := "http-route-hr-mirror-03"
+	svcName := "avisvc-hr-mirror-03"
+	mirrorSvcName := "avisvc-hr-mirror-03-shadow"
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, svcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPS(t, DEFAULT_NAMESPACE, svcName, false, false, "1.2.3")
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, mirrorSvcName, "TCP", corev1.ServiceTypeClusterIP, true)
+	integrationtest.CreateEPS(t, DEFAULT_NAMESPACE, mirrorSvcName, true, false, "2.3.4")
+
+	// mirror backend port 8081 has target port 8081, while the rule backend has target port 8080
+	mirrorPort := gatewayv1.PortNumber(8081)
+	rule := getRequestMirrorRule(svcName, mirrorSvcName, nil)
+	rule.Filters[0].RequestMirror.BackendRef.Port = &mirrorPort
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, []gatewayv1.HTTPRouteRule{rule})
+
+	g.Eventually(func() bool {
+		httpRoute, err := akogatewayapitests.GatewayClient.GatewayV1().HTTPRoutes(DEFAULT_NAMESPACE).Get(context.TODO(), httpRouteName, metav1.GetOptions{})
+		if err != nil || len(httpRoute.Status.Parents) != 1 {
+			return false
+		}
+		condition := apimeta.FindStatusCondition(httpRoute.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionAccepted))
+		return condition != nil && condition.Status == metav1.ConditionFalse &&
+			condition.Reason == string(gatewayv1.RouteReasonUnsupportedValue) &&
+			strings.Contains(condition.Message, "target port 8081")
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	// mirroring on the target port of the rule backend is accepted
+	mirrorPort = gatewayv1.PortNumber(8080)
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, []gatewayv1.HTTPRouteRule{rule})
+	g.Eventually(func() bool {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return false
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		return len(nodes[0].EvhNodes) == 1 && nodes[0].EvhNodes[0].TrafficCloneProfile != nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	g.Expect(nodes[0].EvhNodes[0].TrafficCloneProfile.CloneServers).To(gomega.Equal([]string{"2.3.4.1"}))
+
+	integrationtest.DelEPS(t, DEFAULT_NAMESPACE, mirrorSvcName)
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, mirrorSvcName)
+	integrationtest.DelEPS(t, DEFAULT_NAMESPACE, svcName)
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestHTTPRouteFilterWithRequestMirrorWithoutEndpoints(t *testing.T) {
+	gatewayName := "gateway-hr-mirror-04"
+	gatewayClassName := "gateway-class-hr-mirror-04"
+	httpRouteName := "http-route-hr-mirror-04"
+	svcName := "avisvc-hr-mirror-04"
+	mirrorSvcName := "avisvc-hr-mirror-04-shadow"
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, svcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPS(t, DEFAULT_NAMESPACE, svcName, false, false, "1.2.3")
+	// the mirror service has no endpoints
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, mirrorSvcName, "TCP", corev1.ServiceTypeClusterIP, false)
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rules := []gatewayv1.HTTPRouteRule{getRequestMirrorRule(svcName, mirrorSvcName, nil)}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() bool {
+		httpRoute, err := akogatewayapitests.GatewayClient.GatewayV1().HTTPRoutes(DEFAULT_NAMESPACE).Get(context.TODO(), httpRouteName, metav1.GetOptions{})
+		if err != nil || len(httpRoute.Status.Parents) != 1 {
+			return false
+		}
+		condition := apimeta.FindStatusCondition(httpRoute.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionResolvedRefs))
+		return condition != nil && condition.Status == metav1.ConditionFalse &&
+			condition.Reason == string(gatewayv1.RouteReasonBackendNotFound)
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	g.Expect(nodes[0].EvhNodes).To(gomega.HaveLen(1))
+	g.Expect(nodes[0].EvhNodes[0].TrafficCloneProfile).To(gomega.BeNil())
+
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, mirrorSvcName)
+	integrationtest.DelEPS(t, DEFAULT_NAMESPACE, svcName)
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestHTTPRouteWithQueryParamAndMethodMatch(t *testing.T) {
+	gatewayName := "gateway-hr-query-01"
+	gatewayClassName := "gateway-class-hr-query-01"
+	httpRouteName := "http-route-hr-query-01"
+	svcName := "avisvc-hr-query-01"
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, svcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPS(t, DEFAULT_NAMESPACE, svcName, false, false, "1.2.3")
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1(integrationtest.PATHPREFIX, []string{"/api"}, []string{}, nil,
+		[][]string{{svcName, DEFAULT_NAMESPACE, "8080", "1"}}, nil)
+	method := gatewayv1.HTTPMethodPost
+	rule.Matches[0].Method = &method
+	rule.Matches[0].QueryParams = []gatewayv1.HTTPQueryParamMatch{{Name: "version", Value: "v2"}}
+	rules := []gatewayv1.HTTPRouteRule{rule}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, rules)
+
+	g.Eventually(func() bool {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return false
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		return len(nodes[0].EvhNodes) == 1 && len(nodes[0].EvhNodes[0].VHMatches) == 1
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	vhMatch := nodes[0].EvhNodes[0].VHMatches[0]
+	g.Expect(vhMatch.Rules).To(gomega.HaveLen(1))
+	matchTarget := vhMatch.Rules[0].Matches
+	g.Expect(matchTarget.Method).NotTo(gomega.BeNil())
+	g.Expect(*matchTarget.Method.MatchCriteria).To(gomega.Equal("IS_IN"))
+	g.Expect(matchTarget.Method.Methods).To(gomega.Equal([]string{"HTTP_METHOD_POST"}))
+	g.Expect(matchTarget.Query).NotTo(gomega.BeNil())
+	g.Expect(*matchTarget.Query.MatchCriteria).To(gomega.Equal("QUERY_MATCH_REGEX_MATCH"))
+	g.Expect(matchTarget.Query.MatchStr).To(gomega.BeEmpty())
+	g.Expect(matchTarget.Query.StringGroupRefs).To(gomega.HaveLen(1))
+	// the query regex is attached to the child vs via string group
+	g.Expect(nodes[0].EvhNodes[0].StringGroupRefs).To(gomega.HaveLen(1))
+	g.Expect(*nodes[0].EvhNodes[0].StringGroupRefs[0].StringGroup.Kv[0].Key).To(gomega.Equal("(^|&)version=v2(&|$)"))
+	g.Expect(matchTarget.Query.StringGroupRefs[0]).To(gomega.HaveSuffix(*nodes[0].EvhNodes[0].StringGroupRefs[0].StringGroup.Name))
+
+	// multiple query params in a match can not be expressed with a single Avi query match
+	rule.Matches[0].QueryParams = append(rule.Matches[0].QueryParams, gatewayv1.HTTPQueryParamMatch{Name: "env", Value: "prod"})
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, []gatewayv1.HTTPRouteRule{rule})
+
+	g.Eventually(func() bool {
+		httpRoute, err := akogatewayapitests.GatewayClient.GatewayV1().HTTPRoutes(DEFAULT_NAMESPACE).Get(context.TODO(), httpRouteName, metav1.GetOptions{})
+		if err != nil || len(httpRoute.Status.Parents) != 1 {
+			return false
+		}
+		condition := apimeta.FindStatusCondition(httpRoute.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionAccepted))
+		return condition != nil && condition.Status == metav1.ConditionFalse &&
+			condition.Reason == string(gatewayv1.RouteReasonUnsupportedValue)
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	integrationtest.DelEPS(t, DEFAULT_NAMESPACE, svcName)
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestHTTPRouteWithTimeoutsAndRetry(t *testing.T) {
+	gatewayName := "gateway-hr-timeout-01"
+	gatewayClassName := "gateway-class-hr-timeout-01"
+	httpRouteName := "http-route-hr-timeout-01"
+	svcName := "avisvc-hr-timeout-01"
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, svcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPS(t, DEFAULT_NAMESPACE, svcName, false, false, "1.2.3")
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1(integrationtest.PATHPREFIX, []string{"/reports"}, []string{}, nil,
+		[][]string{{svcName, DEFAULT_NAMESPACE, "8080", "1"}}, nil)
+	backendRequestTimeout := gatewayv1.Duration("5m30s")
+	attempts := 2
+	rule.Timeouts = &gatewayv1.HTTPRouteTimeouts{BackendRequest: &backendRequestTimeout}
+	rule.Retry = &gatewayv1.HTTPRouteRetry{Attempts: &attempts, Codes: []gatewayv1.HTTPRouteRetryStatusCode{502, 503}}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, []gatewayv1.HTTPRouteRule{rule})
+
+	g.Eventually(func() bool {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return false
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		return len(nodes[0].EvhNodes) == 1 && len(nodes[0].EvhNodes[0].PoolRefs) == 1
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	pool := nodes[0].EvhNodes[0].PoolRefs[0]
+	g.Expect(pool.ServerTimeout).NotTo(gomega.BeNil())
+	g.Expect(*pool.ServerTimeout).To(gomega.Equal(uint32(330000)))
+	g.Expect(pool.ServerReselect).NotTo(gomega.BeNil())
+	g.Expect(*pool.ServerReselect.Enabled).To(gomega.BeTrue())
+	g.Expect(*pool.ServerReselect.NumRetries).To(gomega.Equal(uint32(2)))
+	g.Expect(*pool.ServerReselect.RetryTimeout).To(gomega.Equal(uint32(330000)))
+	g.Expect(pool.ServerReselect.SvrRespCode.Codes).To(gomega.Equal([]int64{502, 503}))
+
+	// zero request timeout disables the timeout, the longest server timeout is used
+	requestTimeout := gatewayv1.Duration("0s")
+	rule.Timeouts = &gatewayv1.HTTPRouteTimeouts{Request: &requestTimeout}
+	rule.Retry = nil
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, []gatewayv1.HTTPRouteRule{rule})
+
+	g.Eventually(func() bool {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) != 1 || len(nodes[0].EvhNodes[0].PoolRefs) != 1 {
+			return false
+		}
+		pool := nodes[0].EvhNodes[0].PoolRefs[0]
+		return pool.ServerReselect == nil && pool.ServerTimeout != nil && *pool.ServerTimeout == 21600000
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	// Avi can not wait between the retries, so backoff is rejected
+	backoff := gatewayv1.Duration("100ms")
+	rule.Retry = &gatewayv1.HTTPRouteRetry{Backoff: &backoff}
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, []gatewayv1.HTTPRouteRule{rule})
+
+	g.Eventually(func() bool {
+		httpRoute, err := akogatewayapitests.GatewayClient.GatewayV1().HTTPRoutes(DEFAULT_NAMESPACE).Get(context.TODO(), httpRouteName, metav1.GetOptions{})
+		if err != nil || len(httpRoute.Status.Parents) != 1 {
+			return false
+		}
+		condition := apimeta.FindStatusCondition(httpRoute.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionAccepted))
+		return condition != nil && condition.Status == metav1.ConditionFalse &&
+			condition.Reason == string(gatewayv1.RouteReasonUnsupportedValue)
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	// Avi retries at most 5 times
+	attempts = 6
+	rule.Retry = &gatewayv1.HTTPRouteRetry{Attempts: &attempts}
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, []gatewayv1.HTTPRouteRule{rule})
+
+	g.Eventually(func() bool {
+		httpRoute, err := akogatewayapitests.GatewayClient.GatewayV1().HTTPRoutes(DEFAULT_NAMESPACE).Get(context.TODO(), httpRouteName, metav1.GetOptions{})
+		if err != nil || len(httpRoute.Status.Parents) != 1 {
+			return false
+		}
+		condition := apimeta.FindStatusCondition(httpRoute.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionAccepted))
+		return condition != nil && condition.Status == metav1.ConditionFalse &&
+			condition.Reason == string(gatewayv1.RouteReasonUnsupportedValue) &&
+			strings.Contains(condition.Message, "retry attempts 6 is not supported")
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	integrationtest.DelEPS(t, DEFAULT_NAMESPACE, svcName)
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestHTTPRouteFilterWithUrlRewritePrefixMatch(t *testing.T) {
+	gatewayName := "gateway-hr-rewrite-prefix-01"
+	gatewayClassName := "gateway-class-hr-rewrite-prefix-01"
+	httpRouteName := "http-route-hr-rewrite-prefix-01"
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1(integrationtest.PATHPREFIX, []string{"/api/v1"}, []string{},
+		map[string][]string{"URLRewrite": {}},
+		[][]string{{"avisvc", "default", "8080", "1"}}, nil)
+	replacePrefix := "/"
+	rule.Filters[0].URLRewrite.Path = &gatewayv1.HTTPPathModifier{
+		Type:               gatewayv1.PrefixMatchHTTPPathModifier,
+		ReplacePrefixMatch: &replacePrefix,
+	}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, []gatewayv1.HTTPRouteRule{rule})
+
+	g.Eventually(func() bool {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return false
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) != 1 || len(nodes[0].EvhNodes[0].HttpPolicyRefs) != 1 {
+			return false
+		}
+		requestRules := nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules
+		return len(requestRules) == 1 && requestRules[0].RewriteURLAction != nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	rewritePath := nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules[0].RewriteURLAction.Path
+	// /api/v1/* is rewritten to /*
+	g.Expect(rewritePath.Tokens).To(gomega.HaveLen(1))
+	g.Expect(*rewritePath.Tokens[0].Type).To(gomega.Equal("URI_TOKEN_TYPE_PATH"))
+	g.Expect(*rewritePath.Tokens[0].StartIndex).To(gomega.Equal(uint32(2)))
+	g.Expect(*rewritePath.Tokens[0].EndIndex).To(gomega.Equal(uint32(65535)))
+
+	// /api/v1/* is rewritten to /v2/*
+	replacePrefix = "/v2"
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, []gatewayv1.HTTPRouteRule{rule})
+
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) != 1 || len(nodes[0].EvhNodes[0].HttpPolicyRefs) != 1 {
+			return 0
+		}
+		return len(nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules)
+	}, 25*time.Second).Should(gomega.Equal(2))
+
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	nodes = aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	requestRules := nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules
+	// /api/v1 is rewritten to /v2, without the trailing separator
+	g.Expect(*requestRules[0].Index).To(gomega.Equal(int32(1)))
+	g.Expect(*requestRules[0].Match.Path.MatchCriteria).To(gomega.Equal("EQUALS"))
+	g.Expect(requestRules[0].Match.Path.MatchStr).To(gomega.Equal([]string{"/api/v1"}))
+	rewritePath = requestRules[0].RewriteURLAction.Path
+	g.Expect(rewritePath.Tokens).To(gomega.HaveLen(1))
+	g.Expect(*rewritePath.Tokens[0].StrValue).To(gomega.Equal("v2"))
+	g.Expect(*requestRules[1].Index).To(gomega.Equal(int32(2)))
+	g.Expect(requestRules[1].Match).To(gomega.BeNil())
+	rewritePath = requestRules[1].RewriteURLAction.Path
+	g.Expect(rewritePath.Tokens).To(gomega.HaveLen(2))
+	g.Expect(*rewritePath.Tokens[0].Type).To(gomega.Equal("URI_TOKEN_TYPE_STRING"))
+	g.Expect(*rewritePath.Tokens[0].StrValue).To(gomega.Equal("v2/"))
+	g.Expect(*rewritePath.Tokens[1].StartIndex).To(gomega.Equal(uint32(2)))
+
+	// the prefix of each of the matches is replaced, the longer prefix first
+	rule = akogatewayapitests.GetHTTPRouteRuleV1(integrationtest.PATHPREFIX, []string{"/api", "/api/v1"}, []string{},
+		map[string][]string{"URLRewrite": {}},
+		[][]string{{"avisvc", "default", "8080", "1"}}, nil)
+	rule.Filters[0].URLRewrite.Path = &gatewayv1.HTTPPathModifier{
+		Type:               gatewayv1.PrefixMatchHTTPPathModifier,
+		ReplacePrefixMatch: &replacePrefix,
+	}
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, []gatewayv1.HTTPRouteRule{rule})
+
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) != 1 || len(nodes[0].EvhNodes[0].HttpPolicyRefs) != 1 {
+			return 0
+		}
+		return len(nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules)
+	}, 25*time.Second).Should(gomega.Equal(4))
+
+	_, aviModel = objects.SharedAviGraphLister().Get(modelName)
+	nodes = aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	requestRules = nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules
+	expectedRules := []struct {
+		criteria   string
+		matchStr   string
+		strValue   string
+		startIndex uint32
+	}{
+		{"EQUALS", "/api/v1", "v2", 0},
+		{"BEGINS_WITH", "/api/v1/", "v2/", 2},
+		{"EQUALS", "/api", "v2", 0},
+		{"BEGINS_WITH", "/api/", "v2/", 1},
+	}
+	for i, expected := range expectedRules {
+		g.Expect(*requestRules[i].Index).To(gomega.Equal(int32(i + 1)))
+		g.Expect(*requestRules[i].Match.Path.MatchCriteria).To(gomega.Equal(expected.criteria))
+		g.Expect(requestRules[i].Match.Path.MatchStr).To(gomega.Equal([]string{expected.matchStr}))
+		rewritePath = requestRules[i].RewriteURLAction.Path
+		g.Expect(*rewritePath.Tokens[0].StrValue).To(gomega.Equal(expected.strValue))
+		if expected.startIndex != 0 {
+			g.Expect(rewritePath.Tokens).To(gomega.HaveLen(2))
+			g.Expect(*rewritePath.Tokens[1].StartIndex).To(gomega.Equal(expected.startIndex))
+		} else {
+			g.Expect(rewritePath.Tokens).To(gomega.HaveLen(1))
+		}
+	}
+
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestHTTPRouteFilterWithRequestRedirectSchemePortAndPath(t *testing.T) {
+	gatewayName := "gateway-hr-redirect-scheme-01"
+	gatewayClassName := "gateway-class-hr-redirect-scheme-01"
+	httpRouteName := "http-route-hr-redirect-scheme-01"
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := akogatewayapitests.GetHTTPRouteRuleV1(integrationtest.PATHPREFIX, []string{"/foo"}, []string{},
+		map[string][]string{"RequestRedirect": {}},
+		[][]string{{"avisvc", "default", "8080", "1"}}, nil)
+	scheme := "https"
+	port := gatewayv1.PortNumber(8443)
+	replacePrefix := "/bar"
+	rule.Filters[0].RequestRedirect.Scheme = &scheme
+	rule.Filters[0].RequestRedirect.Port = &port
+	rule.Filters[0].RequestRedirect.Path = &gatewayv1.HTTPPathModifier{
+		Type:               gatewayv1.PrefixMatchHTTPPathModifier,
+		ReplacePrefixMatch: &replacePrefix,
+	}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, []gatewayv1.HTTPRouteRule{rule})
+
+	g.Eventually(func() bool {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return false
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) != 1 || len(nodes[0].EvhNodes[0].HttpPolicyRefs) != 1 {
+			return false
+		}
+		requestRules := nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules
+		return len(requestRules) == 2 && requestRules[0].RedirectAction != nil && requestRules[1].RedirectAction != nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	// /foo is redirected to /bar, without the trailing separator
+	exactPrefixRule := nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules[0]
+	g.Expect(exactPrefixRule.Match.Path.MatchStr).To(gomega.Equal([]string{"/foo"}))
+	g.Expect(exactPrefixRule.RedirectAction.Path.Tokens).To(gomega.HaveLen(1))
+	g.Expect(*exactPrefixRule.RedirectAction.Path.Tokens[0].StrValue).To(gomega.Equal("bar"))
+	g.Expect(*exactPrefixRule.RedirectAction.Port).To(gomega.Equal(uint32(8443)))
+	redirectAction := nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules[1].RedirectAction
+	g.Expect(*redirectAction.Protocol).To(gomega.Equal("HTTPS"))
+	g.Expect(*redirectAction.Port).To(gomega.Equal(uint32(8443)))
+	g.Expect(*redirectAction.Host.Tokens[0].StrValue).To(gomega.Equal("redirect.com"))
+	g.Expect(redirectAction.Path.Tokens).To(gomega.HaveLen(2))
+	g.Expect(*redirectAction.Path.Tokens[0].StrValue).To(gomega.Equal("bar/"))
+	g.Expect(*redirectAction.Path.Tokens[1].Type).To(gomega.Equal("URI_TOKEN_TYPE_PATH"))
+	g.Expect(*redirectAction.Path.Tokens[1].StartIndex).To(gomega.Equal(uint32(1)))
+
+	// the port defaults to the well-known port of the scheme
+	rule.Filters[0].RequestRedirect.Port = nil
+	rule.Filters[0].RequestRedirect.Path = nil
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, []gatewayv1.HTTPRouteRule{rule})
+
+	g.Eventually(func() bool {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		if len(nodes[0].EvhNodes) != 1 || len(nodes[0].EvhNodes[0].HttpPolicyRefs) != 1 {
+			return false
+		}
+		requestRules := nodes[0].EvhNodes[0].HttpPolicyRefs[0].RequestRules
+		return len(requestRules) == 1 && requestRules[0].RedirectAction.Path == nil && *requestRules[0].RedirectAction.Port == 443
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestHTTPRouteWithPartiallyInvalidRules(t *testing.T) {
+	gatewayName := "gateway-hr-partial-01"
+	gatewayClassName := "gateway-class-hr-partial-01"
+	httpRouteName := "http-route-hr-partial-01"
+	svcName := "avisvc-hr-partial-01"
+	ports := []int32{8080}
+	modelName, _ := akogatewayapitests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	integrationtest.CreateSVC(t, DEFAULT_NAMESPACE, svcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPS(t, DEFAULT_NAMESPACE, svcName, false, false, "1.2.3")
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	validRule := akogatewayapitests.GetHTTPRouteRuleV1(integrationtest.PATHPREFIX, []string{"/foo"}, []string{}, nil,
+		[][]string{{svcName, DEFAULT_NAMESPACE, "8080", "1"}}, nil)
+	validRuleName := gatewayv1.SectionName("valid-rule")
+	validRule.Name = &validRuleName
+	invalidRule := akogatewayapitests.GetHTTPRouteRuleV1(integrationtest.PATHPREFIX, []string{"/bar"}, []string{}, nil,
+		[][]string{{svcName, DEFAULT_NAMESPACE, "8080", "1"}}, nil)
+	invalidRuleName := gatewayv1.SectionName("invalid-rule")
+	invalidRule.Name = &invalidRuleName
+	invalidRule.Matches[0].QueryParams = []gatewayv1.HTTPQueryParamMatch{{Name: "a", Value: "1"}, {Name: "b", Value: "2"}}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, []gatewayv1.HTTPRouteRule{validRule, invalidRule})
+
+	// the valid rule is programmed and the route is accepted with the invalid rule dropped
+	g.Eventually(func() bool {
+		httpRoute, err := akogatewayapitests.GatewayClient.GatewayV1().HTTPRoutes(DEFAULT_NAMESPACE).Get(context.TODO(), httpRouteName, metav1.GetOptions{})
+		if err != nil || len(httpRoute.Status.Parents) != 1 {
+			return false
+		}
+		accepted := apimeta.FindStatusCondition(httpRoute.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionAccepted))
+		partiallyInvalid := apimeta.FindStatusCondition(httpRoute.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionPartiallyInvalid))
+		return accepted != nil && accepted.Status == metav1.ConditionTrue &&
+			partiallyInvalid != nil && partiallyInvalid.Status == metav1.ConditionTrue &&
+			partiallyInvalid.Reason == string(gatewayv1.RouteReasonUnsupportedValue) &&
+			strings.Contains(partiallyInvalid.Message, "invalid-rule: multiple QueryParams")
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	g.Eventually(func() int {
+		found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if !found {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		return len(nodes[0].EvhNodes)
+	}, 25*time.Second).Should(gomega.Equal(1))
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	g.Expect(nodes[0].EvhNodes[0].AviMarkers.HTTPRouteRuleName).To(gomega.Equal("valid-rule"))
+
+	// the rule is programmed once it is valid
+	invalidRule.Matches[0].QueryParams = invalidRule.Matches[0].QueryParams[:1]
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, []gatewayv1.HTTPRouteRule{validRule, invalidRule})
+
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		return len(nodes[0].EvhNodes)
+	}, 25*time.Second).Should(gomega.Equal(2))
+	g.Eventually(func() bool {
+		httpRoute, err := akogatewayapitests.GatewayClient.GatewayV1().HTTPRoutes(DEFAULT_NAMESPACE).Get(context.TODO(), httpRouteName, metav1.GetOptions{})
+		if err != nil || len(httpRoute.Status.Parents) != 1 {
+			return false
+		}
+		return apimeta.FindStatusCondition(httpRoute.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionPartiallyInvalid)) == nil
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	// the child VS of the rule is removed when the rule turns invalid
+	invalidRule.Matches[0].QueryParams = append(invalidRule.Matches[0].QueryParams, gatewayv1.HTTPQueryParamMatch{Name: "b", Value: "2"})
+	akogatewayapitests.UpdateHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, []gatewayv1.HTTPRouteRule{validRule, invalidRule})
+
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+		return len(nodes[0].EvhNodes)
+	}, 25*time.Second).Should(gomega.Equal(1))
+	_, childVSNames := akogatewayapiobjects.GatewayApiLister().GetRouteToChildVS(lib.HTTPRoute + "/" + DEFAULT_NAMESPACE + "/" + httpRouteName)
+	g.Expect(childVSNames).To(gomega.HaveLen(1))
+
+	integrationtest.DelEPS(t, DEFAULT_NAMESPACE, svcName)
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, svcName)
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
@@ -0,0 +1,312 @@
+/*
+ * Copyright © 2025 Broadcom Inc. and/or its subsidiaries. All Rights Reserved.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package graphlayer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	akogatewayapitests "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/gatewayapitests"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+func setupMultiClusterServiceImport(t *testing.T, name, namespace string, port int64) {
+	serviceImport := &unstructured.Unstructured{}
+	serviceImport.SetUnstructuredContent(map[string]interface{}{
+		"apiVersion": "multicluster.x-k8s.io/v1alpha1",
+		"kind":       "ServiceImport",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+		},
+		"spec": map[string]interface{}{
+			"type": "ClusterSetIP",
+			"ports": []interface{}{
+				map[string]interface{}{"name": "http", "port": port, "protocol": "TCP"},
+			},
+		},
+	})
+	if _, err := akogatewayapitests.DynamicClient.Resource(akogatewayapilib.MultiClusterServiceImportGVR).Namespace(namespace).Create(context.TODO(), serviceImport, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in creating ServiceImport: %v", err)
+	}
+}
+
+func setupAKOServiceImport(t *testing.T, name, cluster, svcNamespace, svcName string, port int32, endpoints []string) {
+	var eps []interface{}
+	for _, ep := range endpoints {
+		eps = append(eps, map[string]interface{}{"ip": ep, "port": int64(30000)})
+	}
+	serviceImport := &unstructured.Unstructured{}
+	serviceImport.SetUnstructuredContent(map[string]interface{}{
+		"apiVersion": "ako.vmware.com/v1alpha1",
+		"kind":       "ServiceImport",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": utils.GetAKONamespace(),
+		},
+		"spec": map[string]interface{}{
+			"cluster":   cluster,
+			"namespace": svcNamespace,
+			"service":   svcName,
+			"svcPorts": []interface{}{
+				map[string]interface{}{"port": int64(port), "endpoints": eps},
+			},
+		},
+	})
+	if _, err := akogatewayapitests.DynamicClient.Resource(akogatewayapilib.AKOServiceImportGVR).Namespace(utils.GetAKONamespace()).Create(context.TODO(), serviceImport, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in creating ServiceImport: %v", err)
+	}
+}
+
+func getMultiClusterEndpointSlice(name, namespace, siName, cluster string, addresses []string) *discovery.EndpointSlice {
+	portName := "http"
+	port := int32(8080)
+	protocol := corev1.ProtocolTCP
+	epSlice := &discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels: map[string]string{
+				akogatewayapilib.MultiClusterServiceNameLabel:   siName,
+				akogatewayapilib.MultiClusterSourceClusterLabel: cluster,
+			},
+		},
+		AddressType: discovery.AddressTypeIPv4,
+		Ports:       []discovery.EndpointPort{{Name: &portName, Port: &port, Protocol: &protocol}},
+	}
+	for _, address := range addresses {
+		epSlice.Endpoints = append(epSlice.Endpoints, discovery.Endpoint{Addresses: []string{address}})
+	}
+	return epSlice
+}
+
+func getServiceImportHTTPRoute(name, namespace, gatewayName, group, siName string, ports []int32, clusterWeights string) *gatewayv1.HTTPRoute {
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, namespace, ports)
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	rule := akogatewayapitests.GetHTTPRouteRuleV1(integrationtest.PATHPREFIX, []string{"/foo"}, []string{}, nil,
+		[][]string{{siName, namespace, "8080", "2"}}, nil)
+	backendGroup := gatewayv1.Group(group)
+	backendKind := gatewayv1.Kind(lib.ServiceImport)
+	rule.BackendRefs[0].Group = &backendGroup
+	rule.BackendRefs[0].Kind = &backendKind
+	hr := &akogatewayapitests.HTTPRoute{}
+	httpRoute := hr.HTTPRouteV1(name, namespace, parentRefs, hostnames, []gatewayv1.HTTPRouteRule{rule})
+	if clusterWeights != "" {
+		httpRoute.Annotations = map[string]string{akogatewayapilib.ClusterWeightsAnnotation: clusterWeights}
+	}
+	return httpRoute
+}
+
+func getChildNode(modelName string) *avinodes.AviEvhVsNode {
+	found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	if !found || aviModel == nil {
+		return nil
+	}
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	if len(nodes) != 1 || len(nodes[0].EvhNodes) != 1 {
+		return nil
+	}
+	return nodes[0].EvhNodes[0]
+}
+
+func TestHTTPRouteWithMultiClusterServiceImport(t *testing.T) {
+	gatewayClassName := "gateway-class-si-01"
+	gatewayName := "gateway-si-01"
+	httpRouteName := "httproute-si-01"
+	siName := "avisvc-si-01"
+	namespace := DEFAULT_NAMESPACE
+	ports := []int32{8080}
+
+	setupMultiClusterServiceImport(t, siName, namespace, 8080)
+	epSliceA := getMultiClusterEndpointSlice(siName+"-cluster-a", namespace, siName, "cluster-a", []string{"10.10.1.1", "10.10.1.2"})
+	epSliceB := getMultiClusterEndpointSlice(siName+"-cluster-b", namespace, siName, "cluster-b", []string{"10.10.2.1"})
+	for _, epSlice := range []*discovery.EndpointSlice{epSliceA, epSliceB} {
+		if _, err := akogatewayapitests.KubeClient.DiscoveryV1().EndpointSlices(namespace).Create(context.TODO(), epSlice, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("error in creating EndpointSlice: %v", err)
+		}
+	}
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, namespace, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	httpRoute := getServiceImportHTTPRoute(httpRouteName, namespace, gatewayName, akogatewayapilib.MultiClusterServiceGroup, siName, ports, `{"cluster-a": 3}`)
+	if _, err := akogatewayapitests.GatewayClient.GatewayV1().HTTPRoutes(namespace).Create(context.TODO(), httpRoute, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Couldn't create the HTTPRoute, err: %+v", err)
+	}
+
+	modelName := lib.GetModelName(lib.GetTenant(), akogatewayapilib.GetGatewayParentName(namespace, gatewayName))
+	g.Eventually(func() int {
+		childNode := getChildNode(modelName)
+		if childNode == nil {
+			return 0
+		}
+		return len(childNode.PoolRefs)
+	}, 25*time.Second).Should(gomega.Equal(2))
+
+	childNode := getChildNode(modelName)
+	g.Expect(childNode.PoolGroupRefs).To(gomega.HaveLen(1))
+	g.Expect(childNode.PoolGroupRefs[0].Members).To(gomega.HaveLen(2))
+	g.Expect(*childNode.PoolGroupRefs[0].Members[0].Ratio).To(gomega.Equal(uint32(6)))
+	g.Expect(*childNode.PoolGroupRefs[0].Members[1].Ratio).To(gomega.Equal(uint32(2)))
+	g.Expect(childNode.PoolRefs[0].Servers).To(gomega.HaveLen(2))
+	g.Expect(*childNode.PoolRefs[0].Servers[0].Ip.Addr).To(gomega.Equal("10.10.1.1"))
+	g.Expect(childNode.PoolRefs[0].Servers[0].Port).To(gomega.Equal(int32(8080)))
+	g.Expect(childNode.PoolRefs[0].AviMarkers.BackendName).To(gomega.Equal(siName))
+	g.Expect(childNode.PoolRefs[1].Servers).To(gomega.HaveLen(1))
+	g.Expect(*childNode.PoolRefs[1].Servers[0].Ip.Addr).To(gomega.Equal("10.10.2.1"))
+
+	// endpoints added in an exporting cluster are added to the pool of the cluster
+	epSliceA.Endpoints = append(epSliceA.Endpoints, discovery.Endpoint{Addresses: []string{"10.10.1.3"}})
+	epSliceA.ResourceVersion = "2"
+	if _, err := akogatewayapitests.KubeClient.DiscoveryV1().EndpointSlices(namespace).Update(context.TODO(), epSliceA, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating EndpointSlice: %v", err)
+	}
+	g.Eventually(func() int {
+		childNode := getChildNode(modelName)
+		if childNode == nil || len(childNode.PoolRefs) != 2 {
+			return 0
+		}
+		return len(childNode.PoolRefs[0].Servers)
+	}, 25*time.Second).Should(gomega.Equal(3))
+
+	// a cluster with weight 0 receives no traffic
+	httpRoute = getServiceImportHTTPRoute(httpRouteName, namespace, gatewayName, akogatewayapilib.MultiClusterServiceGroup, siName, ports, `{"cluster-a": 3, "cluster-b": 0}`)
+	if _, err := akogatewayapitests.GatewayClient.GatewayV1().HTTPRoutes(namespace).Update(context.TODO(), httpRoute, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Couldn't update the HTTPRoute, err: %+v", err)
+	}
+	g.Eventually(func() int {
+		childNode := getChildNode(modelName)
+		if childNode == nil {
+			return 0
+		}
+		return len(childNode.PoolRefs)
+	}, 25*time.Second).Should(gomega.Equal(1))
+	childNode = getChildNode(modelName)
+	g.Expect(childNode.PoolGroupRefs[0].Members).To(gomega.HaveLen(1))
+	g.Expect(*childNode.PoolGroupRefs[0].Members[0].Ratio).To(gomega.Equal(uint32(6)))
+
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, namespace)
+	akogatewayapitests.TeardownGateway(t, gatewayName, namespace)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+	for _, epSlice := range []*discovery.EndpointSlice{epSliceA, epSliceB} {
+		akogatewayapitests.KubeClient.DiscoveryV1().EndpointSlices(namespace).Delete(context.TODO(), epSlice.Name, metav1.DeleteOptions{})
+	}
+	akogatewayapitests.DynamicClient.Resource(akogatewayapilib.MultiClusterServiceImportGVR).Namespace(namespace).Delete(context.TODO(), siName, metav1.DeleteOptions{})
+}
+
+func TestHTTPRouteWithAKOServiceImport(t *testing.T) {
+	gatewayClassName := "gateway-class-si-02"
+	gatewayName := "gateway-si-02"
+	httpRouteName := "httproute-si-02"
+	svcName := "avisvc-si-02"
+	namespace := DEFAULT_NAMESPACE
+	ports := []int32{8080}
+
+	setupAKOServiceImport(t, "cluster-a--default--"+svcName, "cluster-a", namespace, svcName, 8080, []string{"10.20.1.1", "10.20.1.2"})
+	setupAKOServiceImport(t, "cluster-b--default--"+svcName, "cluster-b", namespace, svcName, 8080, []string{"10.20.2.1"})
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, namespace, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	httpRoute := getServiceImportHTTPRoute(httpRouteName, namespace, gatewayName, lib.AkoGroup, svcName, ports, "")
+	if _, err := akogatewayapitests.GatewayClient.GatewayV1().HTTPRoutes(namespace).Create(context.TODO(), httpRoute, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Couldn't create the HTTPRoute, err: %+v", err)
+	}
+
+	modelName := lib.GetModelName(lib.GetTenant(), akogatewayapilib.GetGatewayParentName(namespace, gatewayName))
+	g.Eventually(func() int {
+		childNode := getChildNode(modelName)
+		if childNode == nil {
+			return 0
+		}
+		return len(childNode.PoolRefs)
+	}, 25*time.Second).Should(gomega.Equal(2))
+
+	childNode := getChildNode(modelName)
+	g.Expect(childNode.PoolGroupRefs[0].Members).To(gomega.HaveLen(2))
+	g.Expect(*childNode.PoolGroupRefs[0].Members[0].Ratio).To(gomega.Equal(uint32(2)))
+	g.Expect(*childNode.PoolGroupRefs[0].Members[1].Ratio).To(gomega.Equal(uint32(2)))
+	g.Expect(childNode.PoolRefs[0].Servers).To(gomega.HaveLen(2))
+	g.Expect(childNode.PoolRefs[0].Servers[0].Port).To(gomega.Equal(int32(30000)))
+	g.Expect(childNode.PoolRefs[1].Servers).To(gomega.HaveLen(1))
+	g.Expect(*childNode.PoolRefs[1].Servers[0].Ip.Addr).To(gomega.Equal("10.20.2.1"))
+
+	// the pool of a cluster is removed with its ServiceImport
+	akogatewayapitests.DynamicClient.Resource(akogatewayapilib.AKOServiceImportGVR).Namespace(utils.GetAKONamespace()).Delete(context.TODO(), "cluster-b--default--"+svcName, metav1.DeleteOptions{})
+	g.Eventually(func() int {
+		childNode := getChildNode(modelName)
+		if childNode == nil {
+			return 0
+		}
+		return len(childNode.PoolRefs)
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, namespace)
+	akogatewayapitests.TeardownGateway(t, gatewayName, namespace)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+	akogatewayapitests.DynamicClient.Resource(akogatewayapilib.AKOServiceImportGVR).Namespace(utils.GetAKONamespace()).Delete(context.TODO(), "cluster-a--default--"+svcName, metav1.DeleteOptions{})
+}
+
+func TestHTTPRouteWithServiceImportOfInvalidGroup(t *testing.T) {
+	gatewayClassName := "gateway-class-si-03"
+	gatewayName := "gateway-si-03"
+	httpRouteName := "httproute-si-03"
+	namespace := DEFAULT_NAMESPACE
+	ports := []int32{8080}
+
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, namespace, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	httpRoute := getServiceImportHTTPRoute(httpRouteName, namespace, gatewayName, "foo.example.com", "avisvc-si-03", ports, "")
+	if _, err := akogatewayapitests.GatewayClient.GatewayV1().HTTPRoutes(namespace).Create(context.TODO(), httpRoute, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Couldn't create the HTTPRoute, err: %+v", err)
+	}
+
+	g.Eventually(func() string {
+		httpRoute, err := akogatewayapitests.GatewayClient.GatewayV1().HTTPRoutes(namespace).Get(context.TODO(), httpRouteName, metav1.GetOptions{})
+		if err != nil || httpRoute == nil || len(httpRoute.Status.Parents) != 1 {
+			return ""
+		}
+		condition := apimeta.FindStatusCondition(httpRoute.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionResolvedRefs))
+		if condition == nil || condition.Status != metav1.ConditionFalse {
+			return ""
+		}
+		return condition.Reason
+	}, 25*time.Second).Should(gomega.Equal(string(gatewayv1.RouteReasonInvalidKind)))
+
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, namespace)
+	akogatewayapitests.TeardownGateway(t, gatewayName, namespace)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+}
@@ -0,0 +1,498 @@
+/*
+ * Copyright © 2025 Broadcom Inc. and/or its subsidiaries. All Rights Reserved.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package l4routetests
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayfake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
+
+	akogatewayapik8s "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/k8s"
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	avicache "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	tests "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/gatewayapitests"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+var ctrl *akogatewayapik8s.GatewayController
+
+const (
+	DEFAULT_NAMESPACE = "default"
+)
+
+func TestMain(m *testing.M) {
+	tests.KubeClient = k8sfake.NewSimpleClientset()
+	tests.GatewayClient = gatewayfake.NewSimpleClientset()
+	testData := tests.GetL7RuleFakeData()
+	tests.DynamicClient = dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), tests.GvrToKind, &testData)
+	integrationtest.KubeClient = tests.KubeClient
+
+	// Sets the environment variables
+	os.Setenv("CLUSTER_NAME", "cluster")
+	os.Setenv("CLOUD_NAME", "CLOUD_VCENTER")
+	os.Setenv("SEG_NAME", "Default-Group")
+	os.Setenv("POD_NAMESPACE", utils.AKO_DEFAULT_NS)
+	os.Setenv("FULL_SYNC_INTERVAL", utils.AKO_DEFAULT_NS)
+	os.Setenv("ENABLE_EVH", "true")
+	os.Setenv("TENANT", "admin")
+	os.Setenv("POD_NAME", "ako-0")
+	os.Setenv("AKO_CRD_OPERATOR_ENABLED", "true")
+	os.Setenv("ENABLE_GATEWAY_API_EXPERIMENTAL_ROUTES", "true")
+
+	// Set the user with prefix
+	_ = lib.AKOControlConfig()
+	lib.SetAKOUser(akogatewayapilib.Prefix)
+	lib.SetNamePrefix(akogatewayapilib.Prefix)
+	lib.AKOControlConfig().SetIsLeaderFlag(true)
+	akoControlConfig := akogatewayapilib.AKOControlConfig()
+	akoControlConfig.SetEventRecorder(lib.AKOGatewayEventComponent, tests.KubeClient, true)
+	akogatewayapilib.SetDynamicClientSet(tests.DynamicClient)
+	akogatewayapilib.NewDynamicInformers(tests.DynamicClient, false)
+	registeredInformers := []string{
+		utils.ServiceInformer,
+		utils.SecretInformer,
+		utils.NSInformer,
+	}
+
+	registeredInformers = append(registeredInformers, utils.EndpointSlicesInformer)
+
+	utils.AviLog.SetLevel("DEBUG")
+	utils.NewInformers(utils.KubeClientIntf{ClientSet: tests.KubeClient}, registeredInformers, make(map[string]interface{}))
+	data := map[string][]byte{
+		"username": []byte("admin"),
+		"password": []byte("admin"),
+	}
+	object := metav1.ObjectMeta{Name: "avi-secret", Namespace: utils.GetAKONamespace()}
+	secret := &corev1.Secret{Data: data, ObjectMeta: object}
+	tests.KubeClient.CoreV1().Secrets(utils.GetAKONamespace()).Create(context.TODO(), secret, metav1.CreateOptions{})
+
+	akoApi := integrationtest.InitializeFakeAKOAPIServer()
+	defer akoApi.ShutDown()
+
+	tests.NewAviFakeClientInstance(tests.KubeClient)
+	defer integrationtest.AviFakeClientInstance.Close()
+
+	ctrl = akogatewayapik8s.SharedGatewayController()
+	ctrl.DisableSync = false
+	ctrl.InitGatewayAPIInformers(tests.GatewayClient)
+	akoControlConfig.SetGatewayAPIClientset(tests.GatewayClient)
+
+	stopCh := utils.SetupSignalHandler()
+	ctrlCh := make(chan struct{})
+	quickSyncCh := make(chan struct{})
+
+	waitGroupMap := make(map[string]*sync.WaitGroup)
+	wgIngestion := &sync.WaitGroup{}
+	waitGroupMap["ingestion"] = wgIngestion
+	wgFastRetry := &sync.WaitGroup{}
+	waitGroupMap["fastretry"] = wgFastRetry
+	wgSlowRetry := &sync.WaitGroup{}
+	waitGroupMap["slowretry"] = wgSlowRetry
+	wgGraph := &sync.WaitGroup{}
+	waitGroupMap["graph"] = wgGraph
+	wgStatus := &sync.WaitGroup{}
+	waitGroupMap["status"] = wgStatus
+
+	integrationtest.AddConfigMap(tests.KubeClient)
+	go ctrl.InitController(k8s.K8sinformers{Cs: tests.KubeClient, DynamicClient: tests.DynamicClient}, registeredInformers, ctrlCh, stopCh, quickSyncCh, waitGroupMap)
+	os.Exit(m.Run())
+}
+
+func getL4Listener(name string, port int32, protocol gatewayv1.ProtocolType) gatewayv1.Listener {
+	return gatewayv1.Listener{
+		Name:     gatewayv1.SectionName(name),
+		Port:     gatewayv1.PortNumber(port),
+		Protocol: protocol,
+	}
+}
+
+func getL4BackendRefs(name string, port int32) []gatewayv1.BackendRef {
+	backendPort := gatewayv1.PortNumber(port)
+	return []gatewayv1.BackendRef{{
+		BackendObjectReference: gatewayv1.BackendObjectReference{
+			Name: gatewayv1.ObjectName(name),
+			Port: &backendPort,
+		},
+	}}
+}
+
+func setupBackendService(t *testing.T, name string, protocol corev1.Protocol, port int32) {
+	svcExample := (integrationtest.FakeService{
+		Name:         name,
+		Namespace:    DEFAULT_NAMESPACE,
+		Type:         corev1.ServiceTypeClusterIP,
+		ServicePorts: []integrationtest.Serviceport{{PortName: "foo", Protocol: protocol, PortNumber: port, TargetPort: intstr.FromInt(int(port))}},
+	}).Service()
+	if _, err := tests.KubeClient.CoreV1().Services(DEFAULT_NAMESPACE).Create(context.TODO(), svcExample, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Service: %v", err)
+	}
+	integrationtest.CreateEPS(t, DEFAULT_NAMESPACE, name, false, false, "1.1.1")
+}
+
+func teardownBackendService(t *testing.T, name string) {
+	integrationtest.DelSVC(t, DEFAULT_NAMESPACE, name)
+	integrationtest.DelEPS(t, DEFAULT_NAMESPACE, name)
+}
+
+func getL4ChildVS(modelName string) *avinodes.AviVsNode {
+	found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	if !found || aviModel == nil {
+		return nil
+	}
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	if len(nodes) == 0 || len(nodes[0].L4ChildNodes) == 0 {
+		return nil
+	}
+	return nodes[0].L4ChildNodes[0]
+}
+
+func getL4PolicyRefs(modelName string) []*avinodes.AviL4PolicyNode {
+	l4VsNode := getL4ChildVS(modelName)
+	if l4VsNode == nil {
+		return nil
+	}
+	return l4VsNode.L4PolicyRefs
+}
+
+func TestTCPRouteCRUD(t *testing.T) {
+	gatewayName := "gateway-tcp-01"
+	gatewayClassName := "gateway-class-tcp-01"
+	routeName := "tcp-route-01"
+	svcName := "avisvc-tcp-01"
+	modelName, _ := tests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	tests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := []gatewayv1.Listener{getL4Listener("tcp-8081", 8081, gatewayv1.TCPProtocolType)}
+	tests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+	setupBackendService(t, svcName, corev1.ProtocolTCP, 8081)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	route := &gatewayv1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: routeName, Namespace: DEFAULT_NAMESPACE},
+		Spec: gatewayv1alpha2.TCPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: tests.GetParentReferencesV1WithGatewayNameOnly([]string{gatewayName}, DEFAULT_NAMESPACE)},
+			Rules:           []gatewayv1alpha2.TCPRouteRule{{BackendRefs: getL4BackendRefs(svcName, 8081)}},
+		},
+	}
+	if _, err := tests.GatewayClient.GatewayV1alpha2().TCPRoutes(DEFAULT_NAMESPACE).Create(context.TODO(), route, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding TCPRoute: %v", err)
+	}
+
+	g.Eventually(func() int {
+		return len(getL4PolicyRefs(modelName))
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	parentNode := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()[0]
+	g.Expect(parentNode.PortProto).To(gomega.HaveLen(0))
+	g.Expect(parentNode.PoolGroupRefs).To(gomega.HaveLen(0))
+	g.Expect(parentNode.PoolRefs).To(gomega.HaveLen(0))
+	l4VsNode := parentNode.L4ChildNodes[0]
+	g.Expect(l4VsNode.Name).To(gomega.Equal(akogatewayapilib.GetGatewayL4VSName(parentNode.Name)))
+	g.Expect(l4VsNode.VSVIPRefs).To(gomega.Equal(parentNode.VSVIPRefs))
+	g.Expect(l4VsNode.ServiceMetadata.L4ParentRef).To(gomega.Equal(parentNode.Name))
+	g.Expect(parentNode.ServiceMetadata.L4ChildRef).To(gomega.Equal(l4VsNode.Name))
+	g.Expect(l4VsNode.PortProto).To(gomega.HaveLen(1))
+	g.Expect(l4VsNode.PortProto[0].Port).To(gomega.Equal(int32(8081)))
+	g.Expect(l4VsNode.PortProto[0].Protocol).To(gomega.Equal("TCP"))
+	g.Expect(l4VsNode.L4PolicyRefs[0].PortPool).To(gomega.HaveLen(1))
+	g.Expect(l4VsNode.L4PolicyRefs[0].PortPool[0].Port).To(gomega.Equal(uint32(8081)))
+	g.Expect(l4VsNode.L4PolicyRefs[0].PortPool[0].Protocol).To(gomega.Equal("TCP"))
+	g.Expect(l4VsNode.PoolGroupRefs).To(gomega.HaveLen(1))
+	g.Expect(l4VsNode.L4PolicyRefs[0].PortPool[0].PoolGroup).To(gomega.Equal(l4VsNode.PoolGroupRefs[0].Name))
+	g.Expect(l4VsNode.PoolRefs).To(gomega.HaveLen(1))
+	g.Expect(l4VsNode.PoolRefs[0].Protocol).To(gomega.Equal("TCP"))
+	g.Expect(l4VsNode.PoolRefs[0].Servers).To(gomega.HaveLen(1))
+
+	// The L4 VS is created on the controller with the vsvip of the parent VS.
+	l4VsKey := avicache.NamespaceName{Namespace: lib.GetTenant(), Name: l4VsNode.Name}
+	g.Eventually(func() string {
+		vsCache, found := avicache.SharedAviObjCache().VsCacheMeta.AviCacheGet(l4VsKey)
+		if !found {
+			return ""
+		}
+		return vsCache.(*avicache.AviVsCache).ServiceMetadataObj.L4ParentRef
+	}, 25*time.Second).Should(gomega.Equal(parentNode.Name))
+
+	g.Eventually(func() bool {
+		route, err := tests.GatewayClient.GatewayV1alpha2().TCPRoutes(DEFAULT_NAMESPACE).Get(context.TODO(), routeName, metav1.GetOptions{})
+		if err != nil || len(route.Status.Parents) != 1 {
+			return false
+		}
+		for _, condition := range route.Status.Parents[0].Conditions {
+			if condition.Type == string(gatewayv1.RouteConditionAccepted) {
+				return condition.Status == metav1.ConditionTrue
+			}
+		}
+		return false
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	if err := tests.GatewayClient.GatewayV1alpha2().TCPRoutes(DEFAULT_NAMESPACE).Delete(context.TODO(), routeName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("error in deleting TCPRoute: %v", err)
+	}
+	g.Eventually(func() int {
+		return len(getL4PolicyRefs(modelName))
+	}, 25*time.Second).Should(gomega.Equal(0))
+	l4VsNode = getL4ChildVS(modelName)
+	g.Expect(l4VsNode.PoolGroupRefs).To(gomega.HaveLen(0))
+	g.Expect(l4VsNode.PoolRefs).To(gomega.HaveLen(0))
+
+	teardownBackendService(t, svcName)
+	tests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	g.Eventually(func() bool {
+		_, found := avicache.SharedAviObjCache().VsCacheMeta.AviCacheGet(l4VsKey)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(false))
+	tests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestUDPRouteCRUD(t *testing.T) {
+	gatewayName := "gateway-udp-02"
+	gatewayClassName := "gateway-class-udp-02"
+	routeName := "udp-route-02"
+	svcName := "avisvc-udp-02"
+	modelName, _ := tests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	tests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := []gatewayv1.Listener{getL4Listener("udp-8084", 8084, gatewayv1.UDPProtocolType)}
+	tests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+	setupBackendService(t, svcName, corev1.ProtocolUDP, 8084)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	route := &gatewayv1alpha2.UDPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: routeName, Namespace: DEFAULT_NAMESPACE},
+		Spec: gatewayv1alpha2.UDPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: tests.GetParentReferencesV1WithGatewayNameOnly([]string{gatewayName}, DEFAULT_NAMESPACE)},
+			Rules:           []gatewayv1alpha2.UDPRouteRule{{BackendRefs: getL4BackendRefs(svcName, 8084)}},
+		},
+	}
+	if _, err := tests.GatewayClient.GatewayV1alpha2().UDPRoutes(DEFAULT_NAMESPACE).Create(context.TODO(), route, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding UDPRoute: %v", err)
+	}
+
+	g.Eventually(func() int {
+		return len(getL4PolicyRefs(modelName))
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	parentNode := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()[0]
+	g.Expect(parentNode.PortProto).To(gomega.HaveLen(0))
+	g.Expect(parentNode.PoolGroupRefs).To(gomega.HaveLen(0))
+	g.Expect(parentNode.PoolRefs).To(gomega.HaveLen(0))
+	l4VsNode := parentNode.L4ChildNodes[0]
+	g.Expect(l4VsNode.Name).To(gomega.Equal(akogatewayapilib.GetGatewayL4VSName(parentNode.Name)))
+	g.Expect(l4VsNode.VSVIPRefs).To(gomega.Equal(parentNode.VSVIPRefs))
+	g.Expect(l4VsNode.ServiceMetadata.L4ParentRef).To(gomega.Equal(parentNode.Name))
+	g.Expect(parentNode.ServiceMetadata.L4ChildRef).To(gomega.Equal(l4VsNode.Name))
+	g.Expect(l4VsNode.PortProto).To(gomega.HaveLen(1))
+	g.Expect(l4VsNode.PortProto[0].Port).To(gomega.Equal(int32(8084)))
+	g.Expect(l4VsNode.PortProto[0].Protocol).To(gomega.Equal("UDP"))
+	g.Expect(l4VsNode.L4PolicyRefs[0].PortPool).To(gomega.HaveLen(1))
+	g.Expect(l4VsNode.L4PolicyRefs[0].PortPool[0].Port).To(gomega.Equal(uint32(8084)))
+	g.Expect(l4VsNode.L4PolicyRefs[0].PortPool[0].Protocol).To(gomega.Equal("UDP"))
+	g.Expect(l4VsNode.PoolGroupRefs).To(gomega.HaveLen(1))
+	g.Expect(l4VsNode.L4PolicyRefs[0].PortPool[0].PoolGroup).To(gomega.Equal(l4VsNode.PoolGroupRefs[0].Name))
+	g.Expect(l4VsNode.PoolRefs).To(gomega.HaveLen(1))
+	g.Expect(l4VsNode.PoolRefs[0].Protocol).To(gomega.Equal("UDP"))
+	g.Expect(l4VsNode.PoolRefs[0].Servers).To(gomega.HaveLen(1))
+
+	g.Eventually(func() bool {
+		route, err := tests.GatewayClient.GatewayV1alpha2().UDPRoutes(DEFAULT_NAMESPACE).Get(context.TODO(), routeName, metav1.GetOptions{})
+		if err != nil || len(route.Status.Parents) != 1 {
+			return false
+		}
+		accepted := apimeta.FindStatusCondition(route.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionAccepted))
+		return accepted != nil && accepted.Status == metav1.ConditionTrue
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	if err := tests.GatewayClient.GatewayV1alpha2().UDPRoutes(DEFAULT_NAMESPACE).Delete(context.TODO(), routeName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("error in deleting UDPRoute: %v", err)
+	}
+	g.Eventually(func() int {
+		return len(getL4PolicyRefs(modelName))
+	}, 25*time.Second).Should(gomega.Equal(0))
+	l4VsNode = getL4ChildVS(modelName)
+	g.Expect(l4VsNode.PoolGroupRefs).To(gomega.HaveLen(0))
+	g.Expect(l4VsNode.PoolRefs).To(gomega.HaveLen(0))
+
+	teardownBackendService(t, svcName)
+	tests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	tests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestUDPRouteNotAllowedByListener(t *testing.T) {
+	gatewayName := "gateway-udp-01"
+	gatewayClassName := "gateway-class-udp-01"
+	routeName := "udp-route-01"
+	svcName := "avisvc-udp-01"
+	modelName, _ := tests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	tests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := []gatewayv1.Listener{getL4Listener("tcp-8082", 8082, gatewayv1.TCPProtocolType)}
+	tests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+	setupBackendService(t, svcName, corev1.ProtocolUDP, 8082)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	route := &gatewayv1alpha2.UDPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: routeName, Namespace: DEFAULT_NAMESPACE},
+		Spec: gatewayv1alpha2.UDPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: tests.GetParentReferencesV1WithGatewayNameOnly([]string{gatewayName}, DEFAULT_NAMESPACE)},
+			Rules:           []gatewayv1alpha2.UDPRouteRule{{BackendRefs: getL4BackendRefs(svcName, 8082)}},
+		},
+	}
+	if _, err := tests.GatewayClient.GatewayV1alpha2().UDPRoutes(DEFAULT_NAMESPACE).Create(context.TODO(), route, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding UDPRoute: %v", err)
+	}
+
+	g.Eventually(func() string {
+		route, err := tests.GatewayClient.GatewayV1alpha2().UDPRoutes(DEFAULT_NAMESPACE).Get(context.TODO(), routeName, metav1.GetOptions{})
+		if err != nil || len(route.Status.Parents) != 1 || len(route.Status.Parents[0].Conditions) == 0 {
+			return ""
+		}
+		return route.Status.Parents[0].Conditions[0].Reason
+	}, 25*time.Second).Should(gomega.Equal(string(gatewayv1.RouteReasonNotAllowedByListeners)))
+	g.Expect(getL4PolicyRefs(modelName)).To(gomega.HaveLen(0))
+
+	if err := tests.GatewayClient.GatewayV1alpha2().UDPRoutes(DEFAULT_NAMESPACE).Delete(context.TODO(), routeName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("error in deleting UDPRoute: %v", err)
+	}
+	teardownBackendService(t, svcName)
+	tests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	tests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestTCPRouteWithMultipleRules(t *testing.T) {
+	gatewayName := "gateway-tcp-02"
+	gatewayClassName := "gateway-class-tcp-02"
+	routeName := "tcp-route-02"
+	svcName := "avisvc-tcp-02"
+	modelName, _ := tests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	tests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := []gatewayv1.Listener{getL4Listener("tcp-8083", 8083, gatewayv1.TCPProtocolType)}
+	tests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+	setupBackendService(t, svcName, corev1.ProtocolTCP, 8083)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	secondRuleName := gatewayv1.SectionName("second")
+	route := &gatewayv1alpha2.TCPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: routeName, Namespace: DEFAULT_NAMESPACE},
+		Spec: gatewayv1alpha2.TCPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: tests.GetParentReferencesV1WithGatewayNameOnly([]string{gatewayName}, DEFAULT_NAMESPACE)},
+			Rules: []gatewayv1alpha2.TCPRouteRule{
+				{BackendRefs: getL4BackendRefs(svcName, 8083)},
+				{Name: &secondRuleName, BackendRefs: getL4BackendRefs(svcName, 8083)},
+			},
+		},
+	}
+	if _, err := tests.GatewayClient.GatewayV1alpha2().TCPRoutes(DEFAULT_NAMESPACE).Create(context.TODO(), route, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding TCPRoute: %v", err)
+	}
+
+	g.Eventually(func() int {
+		return len(getL4PolicyRefs(modelName))
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	// the route is accepted with the first rule and the extra rule is reported as dropped
+	g.Eventually(func() bool {
+		route, err := tests.GatewayClient.GatewayV1alpha2().TCPRoutes(DEFAULT_NAMESPACE).Get(context.TODO(), routeName, metav1.GetOptions{})
+		if err != nil || len(route.Status.Parents) != 1 {
+			return false
+		}
+		accepted := apimeta.FindStatusCondition(route.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionAccepted))
+		partiallyInvalid := apimeta.FindStatusCondition(route.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionPartiallyInvalid))
+		return accepted != nil && accepted.Status == metav1.ConditionTrue &&
+			partiallyInvalid != nil && partiallyInvalid.Status == metav1.ConditionTrue &&
+			partiallyInvalid.Reason == string(gatewayv1.RouteReasonUnsupportedValue) &&
+			strings.Contains(partiallyInvalid.Message, string(secondRuleName))
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	if err := tests.GatewayClient.GatewayV1alpha2().TCPRoutes(DEFAULT_NAMESPACE).Delete(context.TODO(), routeName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("error in deleting TCPRoute: %v", err)
+	}
+	g.Eventually(func() int {
+		return len(getL4PolicyRefs(modelName))
+	}, 25*time.Second).Should(gomega.Equal(0))
+
+	teardownBackendService(t, svcName)
+	tests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	tests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestTCPListenerWithInvalidAllowedRouteKind(t *testing.T) {
+	gatewayName := "gateway-tcp-03"
+	gatewayClassName := "gateway-class-tcp-03"
+
+	tests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listener := getL4Listener("tcp-8086", 8086, gatewayv1.TCPProtocolType)
+	listener.AllowedRoutes = &gatewayv1.AllowedRoutes{Kinds: []gatewayv1.RouteGroupKind{{Kind: lib.HTTPRoute}}}
+	tests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, []gatewayv1.Listener{listener})
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() string {
+		gateway, err := tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+		if err != nil || len(gateway.Status.Listeners) != 1 {
+			return ""
+		}
+		resolvedRefs := apimeta.FindStatusCondition(gateway.Status.Listeners[0].Conditions, string(gatewayv1.ListenerConditionResolvedRefs))
+		if resolvedRefs == nil || resolvedRefs.Reason != string(gatewayv1.ListenerReasonInvalidRouteKinds) {
+			return ""
+		}
+		return resolvedRefs.Message
+	}, 25*time.Second).Should(gomega.Equal("AllowedRoute kind is invalid. Only TCPRoute is supported currently"))
+
+	tests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	tests.TeardownGatewayClass(t, gatewayClassName)
+}
@@ -0,0 +1,334 @@
+/*
+ * Copyright © 2025 Broadcom Inc. and/or its subsidiaries. All Rights Reserved.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package l4routetests
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+	tests "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/gatewayapitests"
+)
+
+func getTLSPassthroughListener(name string, port int32, hostname string) gatewayv1.Listener {
+	listener := getL4Listener(name, port, gatewayv1.TLSProtocolType)
+	if hostname != "" {
+		listener.Hostname = (*gatewayv1.Hostname)(&hostname)
+	}
+	tlsMode := gatewayv1.TLSModePassthrough
+	listener.TLS = &gatewayv1.GatewayTLSConfig{Mode: &tlsMode}
+	return listener
+}
+
+func getTLSRoute(name, gatewayName, svcName string, port int32, hostnames ...string) *gatewayv1alpha2.TLSRoute {
+	route := &gatewayv1alpha2.TLSRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: DEFAULT_NAMESPACE},
+		Spec: gatewayv1alpha2.TLSRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: tests.GetParentReferencesV1WithGatewayNameOnly([]string{gatewayName}, DEFAULT_NAMESPACE)},
+			Rules:           []gatewayv1alpha2.TLSRouteRule{{BackendRefs: getL4BackendRefs(svcName, port)}},
+		},
+	}
+	for _, hostname := range hostnames {
+		route.Spec.Hostnames = append(route.Spec.Hostnames, gatewayv1.Hostname(hostname))
+	}
+	return route
+}
+
+func getEvhParentVS(modelName string) *avinodes.AviEvhVsNode {
+	found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	if !found || aviModel == nil {
+		return nil
+	}
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviEvhVS()
+	if len(nodes) == 0 {
+		return nil
+	}
+	return nodes[0]
+}
+
+func TestTLSRoutePassthrough(t *testing.T) {
+	gatewayName := "gateway-tls-01"
+	gatewayClassName := "gateway-class-tls-01"
+	routeName := "tls-route-01"
+	svcName := "avisvc-tls-01"
+	hostname := "foo-8443.com"
+	modelName, _ := tests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+	pgName := akogatewayapilib.GetTLSPassthroughPGName(DEFAULT_NAMESPACE, gatewayName, hostname)
+
+	tests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := []gatewayv1.Listener{getTLSPassthroughListener("tls-8443", 8443, hostname)}
+	tests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+	setupBackendService(t, svcName, corev1.ProtocolTCP, 8443)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	route := getTLSRoute(routeName, gatewayName, svcName, 8443, hostname)
+	if _, err := tests.GatewayClient.GatewayV1alpha2().TLSRoutes(DEFAULT_NAMESPACE).Create(context.TODO(), route, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding TLSRoute: %v", err)
+	}
+
+	g.Eventually(func() int {
+		parentNode := getEvhParentVS(modelName)
+		if parentNode == nil {
+			return 0
+		}
+		return len(parentNode.HTTPDSrefs)
+	}, 25*time.Second).Should(gomega.Equal(1))
+
+	parentNode := getEvhParentVS(modelName)
+	g.Expect(parentNode.PortProto).To(gomega.HaveLen(1))
+	g.Expect(parentNode.PortProto[0].Port).To(gomega.Equal(int32(8443)))
+	g.Expect(parentNode.PortProto[0].Protocol).To(gomega.Equal("TLS"))
+	g.Expect(parentNode.PortProto[0].EnableSSL).To(gomega.BeFalse())
+	g.Expect(parentNode.L4ChildNodes).To(gomega.HaveLen(0))
+	g.Expect(parentNode.PoolGroupRefs).To(gomega.HaveLen(1))
+	g.Expect(parentNode.PoolGroupRefs[0].Name).To(gomega.Equal(pgName))
+	g.Expect(parentNode.PoolRefs).To(gomega.HaveLen(1))
+	g.Expect(parentNode.PoolRefs[0].Protocol).To(gomega.Equal("TCP"))
+	g.Expect(parentNode.PoolRefs[0].Servers).To(gomega.HaveLen(1))
+	g.Expect(parentNode.HTTPDSrefs[0].PoolGroupRefs).To(gomega.Equal([]string{pgName}))
+	// the datascript derives the poolgroup name from the SNI
+	g.Expect(parentNode.HTTPDSrefs[0].Script).To(gomega.ContainSubstring(strings.TrimSuffix(pgName, hostname) + `"..sname`))
+
+	g.Eventually(func() bool {
+		route, err := tests.GatewayClient.GatewayV1alpha2().TLSRoutes(DEFAULT_NAMESPACE).Get(context.TODO(), routeName, metav1.GetOptions{})
+		if err != nil || len(route.Status.Parents) != 1 {
+			return false
+		}
+		for _, condition := range route.Status.Parents[0].Conditions {
+			if condition.Type == string(gatewayv1.RouteConditionAccepted) {
+				return condition.Status == metav1.ConditionTrue
+			}
+		}
+		return false
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	if err := tests.GatewayClient.GatewayV1alpha2().TLSRoutes(DEFAULT_NAMESPACE).Delete(context.TODO(), routeName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("error in deleting TLSRoute: %v", err)
+	}
+	g.Eventually(func() int {
+		parentNode := getEvhParentVS(modelName)
+		if parentNode == nil {
+			return -1
+		}
+		return len(parentNode.HTTPDSrefs)
+	}, 25*time.Second).Should(gomega.Equal(0))
+	parentNode = getEvhParentVS(modelName)
+	g.Expect(parentNode.PoolGroupRefs).To(gomega.HaveLen(0))
+	g.Expect(parentNode.PoolRefs).To(gomega.HaveLen(0))
+
+	teardownBackendService(t, svcName)
+	tests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	tests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestTLSListenerWithTerminateModeIsInvalid(t *testing.T) {
+	gatewayName := "gateway-tls-02"
+	gatewayClassName := "gateway-class-tls-02"
+
+	tests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := []gatewayv1.Listener{
+		getTLSPassthroughListener("tls-8444", 8444, "foo-8444.com"),
+		getL4Listener("tls-8445", 8445, gatewayv1.TLSProtocolType),
+	}
+	tlsMode := gatewayv1.TLSModeTerminate
+	listeners[1].TLS = &gatewayv1.GatewayTLSConfig{Mode: &tlsMode}
+	tests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() int {
+		gateway, err := tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+		if err != nil {
+			return 0
+		}
+		return len(gateway.Status.Listeners)
+	}, 25*time.Second).Should(gomega.Equal(2))
+
+	gateway, _ := tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+	g.Expect(gateway.Status.Listeners[0].Conditions[0].Type).To(gomega.Equal(string(gatewayv1.ListenerConditionAccepted)))
+	g.Expect(gateway.Status.Listeners[0].Conditions[0].Status).To(gomega.Equal(metav1.ConditionTrue))
+	g.Expect(gateway.Status.Listeners[0].SupportedKinds).To(gomega.Equal([]gatewayv1.RouteGroupKind{{Kind: "TLSRoute"}}))
+	g.Expect(gateway.Status.Listeners[1].Conditions[0].Type).To(gomega.Equal(string(gatewayv1.ListenerConditionAccepted)))
+	g.Expect(gateway.Status.Listeners[1].Conditions[0].Status).To(gomega.Equal(metav1.ConditionFalse))
+	g.Expect(gateway.Status.Listeners[1].Conditions[0].Message).To(gomega.Equal("Only Passthrough TLS mode is supported for TLS listeners"))
+
+	tests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	tests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestTLSRouteWithWildcardHostnameIsUnsupported(t *testing.T) {
+	gatewayName := "gateway-tls-03"
+	gatewayClassName := "gateway-class-tls-03"
+	routeName := "tls-route-03"
+	svcName := "avisvc-tls-03"
+	modelName, _ := tests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	tests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := []gatewayv1.Listener{getTLSPassthroughListener("tls-8446", 8446, "")}
+	tests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+	setupBackendService(t, svcName, corev1.ProtocolTCP, 8446)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	route := getTLSRoute(routeName, gatewayName, svcName, 8446, "*.foo.com")
+	if _, err := tests.GatewayClient.GatewayV1alpha2().TLSRoutes(DEFAULT_NAMESPACE).Create(context.TODO(), route, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding TLSRoute: %v", err)
+	}
+
+	g.Eventually(func() string {
+		route, err := tests.GatewayClient.GatewayV1alpha2().TLSRoutes(DEFAULT_NAMESPACE).Get(context.TODO(), routeName, metav1.GetOptions{})
+		if err != nil || len(route.Status.Parents) != 1 || len(route.Status.Parents[0].Conditions) == 0 {
+			return ""
+		}
+		return route.Status.Parents[0].Conditions[0].Reason
+	}, 25*time.Second).Should(gomega.Equal(string(gatewayv1.RouteReasonUnsupportedValue)))
+	parentNode := getEvhParentVS(modelName)
+	g.Expect(parentNode.PoolGroupRefs).To(gomega.HaveLen(0))
+	g.Expect(parentNode.HTTPDSrefs).To(gomega.HaveLen(0))
+
+	if err := tests.GatewayClient.GatewayV1alpha2().TLSRoutes(DEFAULT_NAMESPACE).Delete(context.TODO(), routeName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("error in deleting TLSRoute: %v", err)
+	}
+	teardownBackendService(t, svcName)
+	tests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	tests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestTLSRouteWithWildcardListenerAndHostnameIsUnsupported(t *testing.T) {
+	gatewayName := "gateway-tls-04"
+	gatewayClassName := "gateway-class-tls-04"
+	routeName := "tls-route-04"
+	svcName := "avisvc-tls-04"
+	modelName, _ := tests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	tests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := []gatewayv1.Listener{getTLSPassthroughListener("tls-8447", 8447, "*.foo.com")}
+	tests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+	setupBackendService(t, svcName, corev1.ProtocolTCP, 8447)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	// the wildcard hostname overlaps with the wildcard listener, but no SNI can be programmed
+	route := getTLSRoute(routeName, gatewayName, svcName, 8447, "*.bar.foo.com")
+	if _, err := tests.GatewayClient.GatewayV1alpha2().TLSRoutes(DEFAULT_NAMESPACE).Create(context.TODO(), route, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding TLSRoute: %v", err)
+	}
+
+	g.Eventually(func() bool {
+		route, err := tests.GatewayClient.GatewayV1alpha2().TLSRoutes(DEFAULT_NAMESPACE).Get(context.TODO(), routeName, metav1.GetOptions{})
+		if err != nil || len(route.Status.Parents) != 1 || len(route.Status.Parents[0].Conditions) == 0 {
+			return false
+		}
+		condition := route.Status.Parents[0].Conditions[0]
+		return condition.Type == string(gatewayv1.RouteConditionAccepted) &&
+			condition.Status == metav1.ConditionFalse &&
+			condition.Reason == string(gatewayv1.RouteReasonUnsupportedValue)
+	}, 25*time.Second).Should(gomega.Equal(true))
+	parentNode := getEvhParentVS(modelName)
+	g.Expect(parentNode.PoolGroupRefs).To(gomega.HaveLen(0))
+	g.Expect(parentNode.HTTPDSrefs).To(gomega.HaveLen(0))
+
+	gateway, _ := tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+	g.Expect(gateway.Status.Listeners[0].AttachedRoutes).To(gomega.Equal(int32(0)))
+
+	if err := tests.GatewayClient.GatewayV1alpha2().TLSRoutes(DEFAULT_NAMESPACE).Delete(context.TODO(), routeName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("error in deleting TLSRoute: %v", err)
+	}
+	teardownBackendService(t, svcName)
+	tests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	tests.TeardownGatewayClass(t, gatewayClassName)
+}
+
+func TestTLSRouteWithWildcardAndExactHostnamesReportsIgnoredHostnames(t *testing.T) {
+	gatewayName := "gateway-tls-05"
+	gatewayClassName := "gateway-class-tls-05"
+	routeName := "tls-route-05"
+	svcName := "avisvc-tls-05"
+	hostname := "bar-8448.com"
+	modelName, _ := tests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+	pgName := akogatewayapilib.GetTLSPassthroughPGName(DEFAULT_NAMESPACE, gatewayName, hostname)
+
+	tests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := []gatewayv1.Listener{getTLSPassthroughListener("tls-8448", 8448, "")}
+	tests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+	setupBackendService(t, svcName, corev1.ProtocolTCP, 8448)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	route := getTLSRoute(routeName, gatewayName, svcName, 8448, "*.foo.com", hostname)
+	if _, err := tests.GatewayClient.GatewayV1alpha2().TLSRoutes(DEFAULT_NAMESPACE).Create(context.TODO(), route, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding TLSRoute: %v", err)
+	}
+
+	g.Eventually(func() int {
+		parentNode := getEvhParentVS(modelName)
+		if parentNode == nil {
+			return 0
+		}
+		return len(parentNode.PoolGroupRefs)
+	}, 25*time.Second).Should(gomega.Equal(1))
+	parentNode := getEvhParentVS(modelName)
+	g.Expect(parentNode.PoolGroupRefs[0].Name).To(gomega.Equal(pgName))
+
+	g.Eventually(func() string {
+		route, err := tests.GatewayClient.GatewayV1alpha2().TLSRoutes(DEFAULT_NAMESPACE).Get(context.TODO(), routeName, metav1.GetOptions{})
+		if err != nil || len(route.Status.Parents) != 1 {
+			return ""
+		}
+		accepted := apimeta.FindStatusCondition(route.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionAccepted))
+		if accepted == nil || accepted.Status != metav1.ConditionTrue {
+			return ""
+		}
+		partiallyInvalid := apimeta.FindStatusCondition(route.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionPartiallyInvalid))
+		if partiallyInvalid == nil {
+			return ""
+		}
+		return partiallyInvalid.Message
+	}, 25*time.Second).Should(gomega.Equal("Ignored Hostname(s): *.foo.com: wildcard hostnames are not supported for TLS passthrough"))
+
+	if err := tests.GatewayClient.GatewayV1alpha2().TLSRoutes(DEFAULT_NAMESPACE).Delete(context.TODO(), routeName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("error in deleting TLSRoute: %v", err)
+	}
+	teardownBackendService(t, svcName)
+	tests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	tests.TeardownGatewayClass(t, gatewayClassName)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	dynamicfake "k8s.io/client-go/dynamic/fake"
@@ -636,3 +637,48 @@ func TestSvcUpdateAutoAnnotate(t *testing.T) {
 
 	cleanupGatewayForNPL(t, gatewayClassName, gatewayName, httpRouteName)
 }
+
+func TestHTTPRouteWithRequestMirrorIsUnsupported(t *testing.T) {
+	gatewayName := "gateway-npl-mirror-01"
+	gatewayClassName := "gateway-class-npl-mirror-01"
+	httpRouteName := "http-route-npl-mirror-01"
+	ports := []int32{8080}
+	modelName, _ := tests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	g := gomega.NewGomegaWithT(t)
+	tests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := tests.GetListenersV1(ports, false, false)
+	tests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+	g.Eventually(func() bool {
+		found, _ := objects.SharedAviGraphLister().Get(modelName)
+		return found
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	parentRefs := tests.GetParentReferencesV1([]string{gatewayName}, DEFAULT_NAMESPACE, ports)
+	rule := tests.GetHTTPRouteRuleV1(integrationtest.PATHPREFIX, []string{"/foo"}, []string{}, nil,
+		[][]string{{"avisvc", DEFAULT_NAMESPACE, "8080", "1"}}, nil)
+	mirrorPort := gatewayv1.PortNumber(8080)
+	rule.Filters = []gatewayv1.HTTPRouteFilter{{
+		Type: gatewayv1.HTTPRouteFilterRequestMirror,
+		RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{
+			BackendRef: gatewayv1.BackendObjectReference{Name: "avisvc-shadow", Port: &mirrorPort},
+		},
+	}}
+	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
+	tests.SetupHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE, parentRefs, hostnames, []gatewayv1.HTTPRouteRule{rule})
+
+	g.Eventually(func() bool {
+		httpRoute, err := tests.GatewayClient.GatewayV1().HTTPRoutes(DEFAULT_NAMESPACE).Get(context.TODO(), httpRouteName, metav1.GetOptions{})
+		if err != nil || len(httpRoute.Status.Parents) != 1 {
+			return false
+		}
+		condition := apimeta.FindStatusCondition(httpRoute.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionAccepted))
+		return condition != nil && condition.Status == metav1.ConditionFalse &&
+			condition.Reason == string(gatewayv1.RouteReasonUnsupportedValue) &&
+			condition.Message == "RequestMirror is not supported in NodePortLocal mode"
+	}, 25*time.Second).Should(gomega.Equal(true))
+
+	tests.TeardownHTTPRoute(t, httpRouteName, DEFAULT_NAMESPACE)
+	tests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	tests.TeardownGatewayClass(t, gatewayClassName)
+}
@@ -39,6 +39,14 @@ import (
  * - Gateway transition from dedicated to normal mode
  */
 
+// getDedicatedListenerStatus sets the route kinds supported by listeners of a dedicated Gateway.
+func getDedicatedListenerStatus(listeners []gatewayv1.ListenerStatus) []gatewayv1.ListenerStatus {
+	for i := range listeners {
+		listeners[i].SupportedKinds = akogatewayapilib.GetSupportedKinds(gatewayv1.HTTPProtocolType, true)
+	}
+	return listeners
+}
+
 func TestDedicatedGatewayWithValidListeners(t *testing.T) {
 	gatewayName := "dedicated-gateway-01"
 	gatewayClassName := "dedicated-gateway-class-01"
@@ -75,7 +83,7 @@ func TestDedicatedGatewayWithValidListeners(t *testing.T) {
 				Reason:             string(gatewayv1.GatewayReasonProgrammed),
 			},
 		},
-		Listeners: tests.GetListenerStatusV1(ports, []int32{0, 0}, true, true),
+		Listeners: getDedicatedListenerStatus(tests.GetListenerStatusV1(ports, []int32{0, 0}, true, true)),
 	}
 
 	gateway, err := tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName, metav1.GetOptions{})
@@ -129,7 +137,7 @@ func TestDedicatedGatewayWithTLSListeners(t *testing.T) {
 				Reason:             string(gatewayv1.GatewayReasonProgrammed),
 			},
 		},
-		Listeners: tests.GetListenerStatusV1(ports, []int32{0, 0}, true, false),
+		Listeners: getDedicatedListenerStatus(tests.GetListenerStatusV1(ports, []int32{0, 0}, true, false)),
 	}
 
 	gateway, err := tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName, metav1.GetOptions{})
@@ -204,7 +212,7 @@ func TestGatewayTransitionFromNormalToDedicatedMode(t *testing.T) {
 				Reason:             string(gatewayv1.GatewayReasonProgrammed),
 			},
 		},
-		Listeners: tests.GetListenerStatusV1(ports, []int32{0}, true, false),
+		Listeners: getDedicatedListenerStatus(tests.GetListenerStatusV1(ports, []int32{0}, true, false)),
 	}
 
 	gateway, err = tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName, metav1.GetOptions{})
@@ -451,6 +459,34 @@ func TestDedicatedGatewayWithAllowedRoutesFromAllNamespaces(t *testing.T) {
 	tests.TeardownGatewayClass(t, gatewayClassName)
 }
 
+func TestDedicatedGatewayWithGRPCRouteInAllowedRoutes(t *testing.T) {
+	gatewayName := "dedicated-gateway-neg-04"
+	gatewayClassName := "dedicated-gateway-class-neg-04"
+	ports := []int32{8080}
+
+	tests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := tests.GetListenersV1(ports, true, false)
+	// GRPCRoute is not supported in dedicated mode
+	listeners[0].AllowedRoutes = &gatewayv1.AllowedRoutes{
+		Kinds: []gatewayv1.RouteGroupKind{{Kind: "GRPCRoute"}},
+	}
+	tests.SetupDedicatedGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		gateway, err := tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+		if err != nil || gateway == nil || len(gateway.Status.Listeners) != 1 {
+			return false
+		}
+		resolvedRefs := apimeta.FindStatusCondition(gateway.Status.Listeners[0].Conditions, string(gatewayv1.ListenerConditionResolvedRefs))
+		return apimeta.IsStatusConditionFalse(gateway.Status.Listeners[0].Conditions, string(gatewayv1.ListenerConditionAccepted)) &&
+			resolvedRefs != nil && resolvedRefs.Reason == string(gatewayv1.ListenerReasonInvalidRouteKinds)
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	tests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	tests.TeardownGatewayClass(t, gatewayClassName)
+}
+
 func TestDedicatedGatewayWithMixedValidInvalidListeners(t *testing.T) {
 	gatewayName := "dedicated-gateway-neg-03"
 	gatewayClassName := "dedicated-gateway-class-neg-03"
@@ -491,7 +527,7 @@ func TestDedicatedGatewayWithMixedValidInvalidListeners(t *testing.T) {
 				Reason:             string(gatewayv1.GatewayReasonProgrammed),
 			},
 		},
-		Listeners: tests.GetListenerStatusV1(ports, []int32{0, 0}, true, false),
+		Listeners: getDedicatedListenerStatus(tests.GetListenerStatusV1(ports, []int32{0, 0}, true, false)),
 	}
 	// First listener should be invalid due to hostname
 	expectedStatus.Listeners[0].Conditions[0].Reason = string(gatewayv1.ListenerReasonInvalid)
@@ -540,11 +576,13 @@ func TestDedicatedGatewaySecretCreateDelete(t *testing.T) {
 		t.Fatalf("Couldn't get the gateway, err: %+v", err)
 	}
 	expectedStatus := tests.GetNegativeConditions(ports)
+	expectedStatus.Listeners = getDedicatedListenerStatus(expectedStatus.Listeners)
 	tests.ValidateGatewayStatus(t, &gateway.Status, expectedStatus)
 
 	// Add secret
 	integrationtest.AddSecret(secrets[0], DEFAULT_NAMESPACE, "cert", "key")
 	expectedStatus = tests.GetPositiveConditions(ports)
+	expectedStatus.Listeners = getDedicatedListenerStatus(expectedStatus.Listeners)
 
 	g.Eventually(func() bool {
 		gateway, err := tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName, metav1.GetOptions{})
@@ -574,6 +612,7 @@ func TestDedicatedGatewaySecretCreateDelete(t *testing.T) {
 		return condition != nil && condition.Status == metav1.ConditionTrue
 	}, 40*time.Second).Should(gomega.Equal(false))
 	expectedStatus = tests.GetNegativeConditions(ports)
+	expectedStatus.Listeners = getDedicatedListenerStatus(expectedStatus.Listeners)
 
 	gateway, err = tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName, metav1.GetOptions{})
 	if err != nil || gateway == nil {
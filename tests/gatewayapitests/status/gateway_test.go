@@ -16,7 +16,10 @@ package status
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"os"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -36,6 +39,7 @@ import (
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	akostatus "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/status"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 	tests "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/gatewayapitests"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
@@ -63,6 +67,7 @@ func TestMain(m *testing.M) {
 	os.Setenv("POD_NAME", "ako-0")
 	os.Setenv("ENABLE_EVH", "true")
 	os.Setenv("AKO_CRD_OPERATOR_ENABLED", "true")
+	os.Setenv("VS_RUNTIME_SYNC_INTERVAL", "0")
 
 	utils.AviLog.SetLevel("DEBUG")
 	// Set the user with prefix
@@ -650,7 +655,7 @@ func TestGatewayWithMoreThanOneAddress(t *testing.T) {
 			{
 				Type:               string(gatewayv1.GatewayConditionAccepted),
 				Status:             metav1.ConditionFalse,
-				Message:            "More than one address is not supported",
+				Message:            "More than one IPv4 address is not supported",
 				ObservedGeneration: 1,
 				Reason:             string(gatewayv1.GatewayReasonInvalid),
 			},
@@ -894,7 +899,8 @@ func TestGatewayWithInvalidAllowedRoute(t *testing.T) {
 
 	expectedStatus.Listeners[0].Conditions[1].Reason = string(gatewayv1.ListenerReasonInvalidRouteKinds)
 	expectedStatus.Listeners[0].Conditions[1].Status = metav1.ConditionFalse
-	expectedStatus.Listeners[0].Conditions[1].Message = "AllowedRoute kind is invalid. Only HTTPRoute is supported currently"
+	expectedStatus.Listeners[0].Conditions[1].Message = fmt.Sprintf("AllowedRoute kind is invalid. Only %s supported currently",
+		akogatewayapilib.SupportedKindsMessage(akogatewayapilib.GetSupportedKinds(gatewayv1.HTTPProtocolType, false)))
 	//expectedStatus.Listeners[0].Conditions[1].Type = string(gatewayv1.ListenerConditionResolvedRefs)
 
 	expectedStatus.Listeners[0].Conditions[2].Message = "Virtual service not configured/updated for this listener"
@@ -1238,12 +1244,17 @@ func TestMultipleGatewaySameHostname(t *testing.T) {
 	}
 	expectedStatus.Listeners[0].Conditions[0].Reason = string(gatewayv1.ListenerReasonInvalid)
 	expectedStatus.Listeners[0].Conditions[0].Status = metav1.ConditionFalse
-	expectedStatus.Listeners[0].Conditions[0].Message = "Hostname overlaps or is same as an existing gateway hostname"
+	expectedStatus.Listeners[0].Conditions[0].Message = "Hostname and port are already in use by listener default/gateway-neg-08/listener-8080"
 
 	expectedStatus.Listeners[0].Conditions[1].Reason = string(gatewayv1.ListenerReasonInvalid)
 	expectedStatus.Listeners[0].Conditions[1].Status = metav1.ConditionFalse
 	expectedStatus.Listeners[0].Conditions[1].Message = "Virtual service not configured/updated for this listener"
-	expectedStatus.Listeners[0].Conditions[0].Message = "Hostname is same as an existing gateway hostname"
+	expectedStatus.Listeners[0].Conditions = append(expectedStatus.Listeners[0].Conditions, metav1.Condition{
+		Type:    string(gatewayv1.ListenerConditionConflicted),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(gatewayv1.ListenerReasonHostnameConflict),
+		Message: "Hostname and port are already in use by listener default/gateway-neg-08/listener-8080",
+	})
 
 	gateway, err = tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName2, metav1.GetOptions{})
 	if err != nil || gateway == nil {
@@ -1349,6 +1360,78 @@ func TestMultipleGatewayOverlappingHostname(t *testing.T) {
 	tests.TeardownGatewayClass(t, gatewayClassName)
 }
 
+func TestGatewayListenerHostnameConflictAcrossNamespaces(t *testing.T) {
+	gatewayName1 := "gateway-neg-12-a"
+	gatewayName2 := "gateway-neg-12-b"
+	namespace2 := "gateway-neg-12"
+	gatewayClassName := "gateway-class-neg-12"
+	ports := []int32{8080}
+	hostname := "conflict.example.com"
+
+	if err := integrationtest.AddNamespace(t, namespace2, map[string]string{}); err != nil {
+		t.Fatalf("Error creating namespace %s: %v", namespace2, err)
+	}
+	tests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := tests.GetListenersV1(ports, false, false)
+	listeners[0].Hostname = (*gatewayv1.Hostname)(&hostname)
+
+	// the older gateway takes precedence, even though it is later in the alphabetical order
+	olderGateway := &tests.Gateway{}
+	olderGateway.Gateway = olderGateway.GatewayV1(gatewayName2, namespace2, gatewayClassName, nil, listeners)
+	olderGateway.Gateway.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+	olderGateway.Create(t)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		return getListenerConflictedCondition(t, gatewayName2, namespace2) != nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	newerGateway := &tests.Gateway{}
+	newerGateway.Gateway = newerGateway.GatewayV1(gatewayName1, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+	newerGateway.Gateway.CreationTimestamp = metav1.NewTime(time.Now())
+	newerGateway.Create(t)
+
+	g.Eventually(func() bool {
+		condition := getListenerConflictedCondition(t, gatewayName1, DEFAULT_NAMESPACE)
+		return condition != nil && condition.Status == metav1.ConditionTrue
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	conflictedCondition := getListenerConflictedCondition(t, gatewayName1, DEFAULT_NAMESPACE)
+	g.Expect(conflictedCondition.Reason).To(gomega.Equal(string(gatewayv1.ListenerReasonHostnameConflict)))
+	g.Expect(conflictedCondition.Message).To(gomega.Equal("Hostname and port are already in use by listener gateway-neg-12/gateway-neg-12-b/listener-8080"))
+
+	// the winner stays accepted
+	conflictedCondition = getListenerConflictedCondition(t, gatewayName2, namespace2)
+	g.Expect(conflictedCondition.Status).To(gomega.Equal(metav1.ConditionFalse))
+	g.Expect(conflictedCondition.Reason).To(gomega.Equal(string(gatewayv1.ListenerReasonNoConflicts)))
+
+	// the conflict clears once the winner is deleted
+	tests.TeardownGateway(t, gatewayName2, namespace2)
+	g.Eventually(func() bool {
+		condition := getListenerConflictedCondition(t, gatewayName1, DEFAULT_NAMESPACE)
+		return condition != nil && condition.Status == metav1.ConditionFalse
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	gateway, err := tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName1, metav1.GetOptions{})
+	if err != nil || gateway == nil {
+		t.Fatalf("Couldn't get the gateway, err: %+v", err)
+	}
+	g.Expect(apimeta.IsStatusConditionTrue(gateway.Status.Listeners[0].Conditions, string(gatewayv1.ListenerConditionAccepted))).To(gomega.BeTrue())
+
+	tests.TeardownGateway(t, gatewayName1, DEFAULT_NAMESPACE)
+	tests.TeardownGatewayClass(t, gatewayClassName)
+	integrationtest.DeleteNamespace(namespace2)
+}
+
+func getListenerConflictedCondition(t *testing.T, gatewayName, namespace string) *metav1.Condition {
+	gateway, err := tests.GatewayClient.GatewayV1().Gateways(namespace).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+	if err != nil || gateway == nil || len(gateway.Status.Listeners) == 0 {
+		t.Logf("Couldn't get the gateway status, err: %+v", err)
+		return nil
+	}
+	return apimeta.FindStatusCondition(gateway.Status.Listeners[0].Conditions, string(gatewayv1.ListenerConditionConflicted))
+}
+
 func TestGatewayWithUnsupportedProtocolAndHostnameInListeners(t *testing.T) {
 
 	gatewayName := "gateway-neg-11"
@@ -1453,3 +1536,70 @@ func TestSecretCreateDelete(t *testing.T) {
 	tests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
 	tests.TeardownGatewayClass(t, gatewayClassName)
 }
+
+func setVSRuntimeStatus(vsName, operState, reason string) {
+	integrationtest.AddMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && strings.Contains(r.URL.EscapedPath(), "virtualservice-inventory") {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(fmt.Sprintf(`{"count": 1, "results": [{"config": {"name": "%s", "tenant_ref": "https://localhost/api/tenant/admin#admin"}, "runtime": {"oper_status": {"state": "%s", "reason": ["%s"]}}}]}`, vsName, operState, reason)))
+			return
+		}
+		integrationtest.NormalControllerServer(w, r, "../../avimockobjectsgw")
+	})
+}
+
+func TestGatewayProgrammedWithVSRuntimeStatus(t *testing.T) {
+
+	gatewayName := "gateway-runtime-01"
+	gatewayClassName := "gateway-class-runtime-01"
+	ports := []int32{8080}
+	_, vsName := tests.GetModelName(DEFAULT_NAMESPACE, gatewayName)
+
+	tests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	listeners := tests.GetListenersV1(ports, false, false)
+	tests.SetupGateway(t, gatewayName, DEFAULT_NAMESPACE, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	getProgrammedCondition := func() *metav1.Condition {
+		gateway, err := tests.GatewayClient.GatewayV1().Gateways(DEFAULT_NAMESPACE).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+		if err != nil || gateway == nil || len(gateway.Status.Listeners) != 1 {
+			return nil
+		}
+		listenerProgrammed := apimeta.FindStatusCondition(gateway.Status.Listeners[0].Conditions, string(gatewayv1.ListenerConditionProgrammed))
+		programmed := apimeta.FindStatusCondition(gateway.Status.Conditions, string(gatewayv1.GatewayConditionProgrammed))
+		if programmed == nil || listenerProgrammed == nil || programmed.Status != listenerProgrammed.Status {
+			return nil
+		}
+		return programmed
+	}
+	g.Eventually(func() bool {
+		programmed := getProgrammedCondition()
+		return programmed != nil && programmed.Status == metav1.ConditionTrue
+	}, 40*time.Second).Should(gomega.Equal(true))
+
+	// virtual service goes down, the gateway is no longer programmed
+	setVSRuntimeStatus(vsName, "OPER_DOWN", "No Service Engine placed")
+	defer integrationtest.ResetMiddleware()
+	akostatus.SyncVSRuntimeStatus()
+	g.Eventually(func() bool {
+		programmed := getProgrammedCondition()
+		return programmed != nil && programmed.Status == metav1.ConditionFalse
+	}, 30*time.Second).Should(gomega.Equal(true))
+	programmed := getProgrammedCondition()
+	g.Expect(programmed.Reason).To(gomega.Equal(string(gatewayv1.GatewayReasonPending)))
+	g.Expect(programmed.Message).To(gomega.Equal("Virtual service " + vsName + " is OPER_DOWN: No Service Engine placed"))
+
+	// virtual service is up again, the gateway is programmed
+	setVSRuntimeStatus(vsName, "OPER_UP", "")
+	akostatus.SyncVSRuntimeStatus()
+	g.Eventually(func() bool {
+		programmed := getProgrammedCondition()
+		return programmed != nil && programmed.Status == metav1.ConditionTrue
+	}, 30*time.Second).Should(gomega.Equal(true))
+	programmed = getProgrammedCondition()
+	g.Expect(programmed.Reason).To(gomega.Equal(string(gatewayv1.GatewayReasonProgrammed)))
+	g.Expect(programmed.Message).To(gomega.Equal("Virtual service configured/updated"))
+
+	tests.TeardownGateway(t, gatewayName, DEFAULT_NAMESPACE)
+	tests.TeardownGatewayClass(t, gatewayClassName)
+}
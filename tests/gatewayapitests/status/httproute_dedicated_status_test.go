@@ -27,6 +27,8 @@ import (
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 	akogatewayapitests "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/gatewayapitests"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
 )
@@ -561,14 +563,16 @@ func TestHTTPRouteWithUnsupportedFilterInDedicatedMode(t *testing.T) {
 		return apimeta.FindStatusCondition(gateway.Status.Conditions, string(gatewayv1.GatewayConditionAccepted)) != nil
 	}, 30*time.Second).Should(gomega.Equal(true))
 
-	// Create HTTPRoute with unsupported URLRewrite filter (PrefixMatchHTTPPathModifier)
+	// Create HTTPRoute with unsupported URLRewrite filter (PrefixMatchHTTPPathModifier on an exact path match)
 	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, namespace, ports)
-	rule := akogatewayapitests.GetHTTPRouteRuleV1(integrationtest.PATHPREFIX, []string{"/foo"}, []string{},
+	rule := akogatewayapitests.GetHTTPRouteRuleV1("Exact", []string{"/foo"}, []string{},
 		map[string][]string{"URLRewrite": {}},
 		[][]string{{svcName, namespace, "8080", "1"}}, nil)
 
 	// Set unsupported URLRewrite path type
 	rule.Filters[0].URLRewrite.Path.Type = gatewayv1.PrefixMatchHTTPPathModifier
+	replacePrefix := "/bar"
+	rule.Filters[0].URLRewrite.Path.ReplacePrefixMatch = &replacePrefix
 	rules := []gatewayv1.HTTPRouteRule{rule}
 
 	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, namespace, parentRefs, nil, rules)
@@ -589,14 +593,14 @@ func TestHTTPRouteWithUnsupportedFilterInDedicatedMode(t *testing.T) {
 			condition.Status == metav1.ConditionFalse
 	}, 30*time.Second).Should(gomega.Equal(true))
 
-	// Verify the error message mentions unsupported URLRewrite path type
+	// Verify the error message mentions unsupported URLRewrite prefix replacement
 	httpRoute, err := akogatewayapitests.GatewayClient.GatewayV1().HTTPRoutes(namespace).Get(context.TODO(), httpRouteName, metav1.GetOptions{})
 	g.Expect(err).ToNot(gomega.HaveOccurred())
 	g.Expect(httpRoute.Status.Parents).To(gomega.HaveLen(1))
 
 	condition := apimeta.FindStatusCondition(httpRoute.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionAccepted))
 	g.Expect(condition).ToNot(gomega.BeNil())
-	g.Expect(condition.Message).To(gomega.ContainSubstring("HTTPUrlRewrite PathType has Unsupported value"))
+	g.Expect(condition.Message).To(gomega.ContainSubstring("URLRewrite ReplacePrefixMatch requires PathPrefix match"))
 
 	// Cleanup
 	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, namespace)
@@ -615,6 +619,7 @@ func TestHTTPRouteWithCrossNamespaceBackendInDedicatedMode(t *testing.T) {
 	namespace := DEFAULT_NAMESPACE
 	backendNamespace := "backend-namespace"
 	svcName := "avisvc-dedicated-cross-ns-backend"
+	referenceGrantName := "referencegrant-dedicated-cross-ns-backend"
 	ports := []int32{8080}
 
 	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
@@ -638,6 +643,9 @@ func TestHTTPRouteWithCrossNamespaceBackendInDedicatedMode(t *testing.T) {
 		return apimeta.FindStatusCondition(gateway.Status.Conditions, string(gatewayv1.GatewayConditionAccepted)) != nil
 	}, 30*time.Second).Should(gomega.Equal(true))
 
+	// Permit HTTPRoutes in the Gateway namespace to refer to Services in the backend namespace
+	akogatewayapitests.SetupReferenceGrant(t, referenceGrantName, backendNamespace, lib.HTTPRoute, namespace, utils.Service)
+
 	// Create HTTPRoute with cross-namespace backend reference
 	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, namespace, ports)
 	rule := akogatewayapitests.GetHTTPRouteRuleV1(integrationtest.PATHPREFIX, []string{"/"}, []string{},
@@ -647,8 +655,7 @@ func TestHTTPRouteWithCrossNamespaceBackendInDedicatedMode(t *testing.T) {
 
 	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, namespace, parentRefs, nil, rules)
 
-	// Verify HTTPRoute status - cross-namespace backend should work in dedicated mode
-	// (unlike some other cross-namespace references that might be restricted)
+	// Verify HTTPRoute status - cross-namespace backend permitted by the ReferenceGrant should work in dedicated mode
 	g.Eventually(func() bool {
 		httpRoute, err := akogatewayapitests.GatewayClient.GatewayV1().HTTPRoutes(namespace).Get(context.TODO(), httpRouteName, metav1.GetOptions{})
 		if err != nil || httpRoute == nil {
@@ -689,6 +696,7 @@ func TestHTTPRouteWithCrossNamespaceBackendInDedicatedMode(t *testing.T) {
 	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, namespace)
 	akogatewayapitests.TeardownGateway(t, gatewayName, namespace)
 	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+	akogatewayapitests.TeardownReferenceGrant(t, referenceGrantName, backendNamespace)
 	integrationtest.DelSVC(t, backendNamespace, svcName)
 	integrationtest.DelEPS(t, backendNamespace, svcName)
 	integrationtest.DeleteNamespace(backendNamespace)
@@ -1273,10 +1273,13 @@ func TestHTTPRouteFilterWithUnsupportedUrlRewritePathType(t *testing.T) {
 	}, 5*time.Second).Should(gomega.Equal(true))
 
 	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, namespace, ports)
-	rule := akogatewayapitests.GetHTTPRouteRuleV1(integrationtest.PATHPREFIX, []string{"/foo"}, []string{},
+	// the prefix can not be replaced on the requests matched by an exact path
+	rule := akogatewayapitests.GetHTTPRouteRuleV1("Exact", []string{"/foo"}, []string{},
 		map[string][]string{"URLRewrite": {}},
 		[][]string{{svcName, "default", "8080", "1"}}, nil)
 	rule.Filters[0].URLRewrite.Path.Type = gatewayv1.PrefixMatchHTTPPathModifier
+	replacePrefix := "/bar"
+	rule.Filters[0].URLRewrite.Path.ReplacePrefixMatch = &replacePrefix
 	rules := []gatewayv1.HTTPRouteRule{rule}
 	hostnames := []gatewayv1.Hostname{"foo-8080.com"}
 	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, namespace, parentRefs, hostnames, rules)
@@ -1301,7 +1304,7 @@ func TestHTTPRouteFilterWithUnsupportedUrlRewritePathType(t *testing.T) {
 			Type:    string(gatewayv1.RouteConditionAccepted),
 			Reason:  string(gatewayv1.RouteReasonUnsupportedValue),
 			Status:  metav1.ConditionFalse,
-			Message: "HTTPUrlRewrite PathType has Unsupported value",
+			Message: "URLRewrite ReplacePrefixMatch requires PathPrefix match",
 		}
 		conditions = append(conditions, condition)
 		conditionMap[fmt.Sprintf("%s-%d", gatewayName, port)] = conditions
@@ -0,0 +1,168 @@
+/*
+ * Copyright © 2025 Broadcom Inc. and/or its subsidiaries. All Rights Reserved.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
+	akogatewayapitests "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/gatewayapitests"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
+)
+
+func getHTTPRouteResolvedRefsReason(t *testing.T, name, namespace string) string {
+	httpRoute, err := akogatewayapitests.GatewayClient.GatewayV1().HTTPRoutes(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil || httpRoute == nil {
+		t.Logf("Couldn't get the HTTPRoute, err: %+v", err)
+		return ""
+	}
+	if len(httpRoute.Status.Parents) != 1 {
+		return ""
+	}
+	condition := apimeta.FindStatusCondition(httpRoute.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionResolvedRefs))
+	if condition == nil {
+		return ""
+	}
+	return condition.Reason
+}
+
+func getGatewayListenerResolvedRefsReason(t *testing.T, name, namespace string) string {
+	gateway, err := akogatewayapitests.GatewayClient.GatewayV1().Gateways(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil || gateway == nil {
+		t.Logf("Couldn't get the gateway, err: %+v", err)
+		return ""
+	}
+	if len(gateway.Status.Listeners) != 1 {
+		return ""
+	}
+	condition := apimeta.FindStatusCondition(gateway.Status.Listeners[0].Conditions, string(gatewayv1.ListenerConditionResolvedRefs))
+	if condition == nil {
+		return ""
+	}
+	return condition.Reason
+}
+
+func TestHTTPRouteCrossNamespaceBackendWithReferenceGrant(t *testing.T) {
+	gatewayClassName := "gateway-class-rg-01"
+	gatewayName := "gateway-rg-01"
+	httpRouteName := "httproute-rg-01"
+	referenceGrantName := "referencegrant-rg-01"
+	namespace := DEFAULT_NAMESPACE
+	backendNamespace := "backend-rg-01"
+	svcName := "avisvc-rg-01"
+	ports := []int32{8090}
+
+	if err := integrationtest.AddNamespace(t, backendNamespace, map[string]string{}); err != nil {
+		t.Fatalf("Error creating namespace %s: %v", backendNamespace, err)
+	}
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+	integrationtest.CreateSVC(t, backendNamespace, svcName, "TCP", corev1.ServiceTypeClusterIP, false)
+	integrationtest.CreateEPS(t, backendNamespace, svcName, false, false, "1.1.1")
+
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetupGateway(t, gatewayName, namespace, gatewayClassName, nil, listeners)
+
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() bool {
+		gateway, err := akogatewayapitests.GatewayClient.GatewayV1().Gateways(namespace).Get(context.TODO(), gatewayName, metav1.GetOptions{})
+		if err != nil || gateway == nil {
+			t.Logf("Couldn't get the gateway, err: %+v", err)
+			return false
+		}
+		return apimeta.FindStatusCondition(gateway.Status.Conditions, string(gatewayv1.GatewayConditionAccepted)) != nil
+	}, 30*time.Second).Should(gomega.Equal(true))
+
+	parentRefs := akogatewayapitests.GetParentReferencesV1([]string{gatewayName}, namespace, ports)
+	hostnames := []gatewayv1.Hostname{"foo-8090.com"}
+	rule := akogatewayapitests.GetHTTPRouteRuleV1(integrationtest.PATHPREFIX, []string{"/foo"}, []string{}, nil,
+		[][]string{{svcName, backendNamespace, "8080", "1"}}, nil)
+	rules := []gatewayv1.HTTPRouteRule{rule}
+	akogatewayapitests.SetupHTTPRoute(t, httpRouteName, namespace, parentRefs, hostnames, rules)
+
+	// backend in another namespace without a ReferenceGrant is not permitted
+	g.Eventually(func() string {
+		return getHTTPRouteResolvedRefsReason(t, httpRouteName, namespace)
+	}, 30*time.Second).Should(gomega.Equal(string(gatewayv1.RouteReasonRefNotPermitted)))
+
+	akogatewayapitests.SetupReferenceGrant(t, referenceGrantName, backendNamespace, lib.HTTPRoute, namespace, utils.Service)
+	g.Eventually(func() string {
+		return getHTTPRouteResolvedRefsReason(t, httpRouteName, namespace)
+	}, 30*time.Second).Should(gomega.Equal(string(gatewayv1.RouteReasonResolvedRefs)))
+
+	// removing the ReferenceGrant revokes the permission
+	akogatewayapitests.TeardownReferenceGrant(t, referenceGrantName, backendNamespace)
+	g.Eventually(func() string {
+		return getHTTPRouteResolvedRefsReason(t, httpRouteName, namespace)
+	}, 30*time.Second).Should(gomega.Equal(string(gatewayv1.RouteReasonRefNotPermitted)))
+
+	akogatewayapitests.TeardownHTTPRoute(t, httpRouteName, namespace)
+	akogatewayapitests.TeardownGateway(t, gatewayName, namespace)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+	integrationtest.DelSVC(t, backendNamespace, svcName)
+	integrationtest.DelEPS(t, backendNamespace, svcName)
+	integrationtest.DeleteNamespace(backendNamespace)
+}
+
+func TestGatewayCrossNamespaceCertificateRefWithReferenceGrant(t *testing.T) {
+	gatewayClassName := "gateway-class-rg-02"
+	gatewayName := "gateway-rg-02"
+	referenceGrantName := "referencegrant-rg-02"
+	namespace := DEFAULT_NAMESPACE
+	secretNamespace := "secret-rg-02"
+	secretName := "secret-rg-02"
+	ports := []int32{8091}
+
+	if err := integrationtest.AddNamespace(t, secretNamespace, map[string]string{}); err != nil {
+		t.Fatalf("Error creating namespace %s: %v", secretNamespace, err)
+	}
+	integrationtest.AddSecret(secretName, secretNamespace, "cert", "key")
+	akogatewayapitests.SetupGatewayClass(t, gatewayClassName, akogatewayapilib.GatewayController)
+
+	listeners := akogatewayapitests.GetListenersV1(ports, false, false)
+	akogatewayapitests.SetListenerTLS(&listeners[0], gatewayv1.TLSModeTerminate, secretName, secretNamespace)
+	akogatewayapitests.SetupGateway(t, gatewayName, namespace, gatewayClassName, nil, listeners)
+
+	// certificateRef in another namespace without a ReferenceGrant is not permitted
+	g := gomega.NewGomegaWithT(t)
+	g.Eventually(func() string {
+		return getGatewayListenerResolvedRefsReason(t, gatewayName, namespace)
+	}, 30*time.Second).Should(gomega.Equal(string(gatewayv1.ListenerReasonRefNotPermitted)))
+
+	akogatewayapitests.SetupReferenceGrant(t, referenceGrantName, secretNamespace, lib.Gateway, namespace, utils.Secret)
+	g.Eventually(func() string {
+		return getGatewayListenerResolvedRefsReason(t, gatewayName, namespace)
+	}, 30*time.Second).Should(gomega.Equal(string(gatewayv1.ListenerReasonResolvedRefs)))
+
+	// removing the ReferenceGrant revokes the permission
+	akogatewayapitests.TeardownReferenceGrant(t, referenceGrantName, secretNamespace)
+	g.Eventually(func() string {
+		return getGatewayListenerResolvedRefsReason(t, gatewayName, namespace)
+	}, 30*time.Second).Should(gomega.Equal(string(gatewayv1.ListenerReasonRefNotPermitted)))
+
+	akogatewayapitests.TeardownGateway(t, gatewayName, namespace)
+	akogatewayapitests.TeardownGatewayClass(t, gatewayClassName)
+	integrationtest.DeleteSecret(secretName, secretNamespace)
+	integrationtest.DeleteNamespace(secretNamespace)
+}
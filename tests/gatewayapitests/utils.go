@@ -27,6 +27,7 @@ import (
 
 	"github.com/onsi/gomega"
 	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -34,6 +35,9 @@ import (
 
 	k8sfake "k8s.io/client-go/kubernetes/fake"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1alpha3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	gatewayfake "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
 
 	akogatewayapilib "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/ako-gateway-api/lib"
@@ -47,10 +51,12 @@ var KubeClient *k8sfake.Clientset
 var GatewayClient *gatewayfake.Clientset
 var DynamicClient *dynamicfake.FakeDynamicClient
 var GvrToKind = map[schema.GroupVersionResource]string{
-	akogatewayapilib.L7CRDGVR:                    "l7rulesList",
-	akogatewayapilib.HealthMonitorGVR:            "healthmonitorsList",
-	akogatewayapilib.RouteBackendExtensionCRDGVR: "routebackendextensionsList",
-	akogatewayapilib.AppProfileCRDGVR:            "applicationProfileList",
+	akogatewayapilib.L7CRDGVR:                     "l7rulesList",
+	akogatewayapilib.HealthMonitorGVR:             "healthmonitorsList",
+	akogatewayapilib.RouteBackendExtensionCRDGVR:  "routebackendextensionsList",
+	akogatewayapilib.AppProfileCRDGVR:             "applicationProfileList",
+	akogatewayapilib.AKOServiceImportGVR:          "serviceimportsList",
+	akogatewayapilib.MultiClusterServiceImportGVR: "serviceimportsList",
 }
 var testData unstructured.Unstructured
 
@@ -376,12 +382,14 @@ func TeardownGateway(t *testing.T, name, namespace string) {
 type FakeGatewayClass struct {
 	Name           string
 	ControllerName string
+	Annotations    map[string]string
 }
 
 func (gc *FakeGatewayClass) GatewayClassV1() *gatewayv1.GatewayClass {
 	return &gatewayv1.GatewayClass{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: gc.Name,
+			Name:        gc.Name,
+			Annotations: gc.Annotations,
 		},
 		Spec: gatewayv1.GatewayClassSpec{
 			ControllerName: gatewayv1.GatewayController(gc.ControllerName),
@@ -723,6 +731,108 @@ func TeardownHTTPRoute(t *testing.T, name, namespace string) {
 	hr.Delete(t)
 }
 
+// SetupReferenceGrant creates a ReferenceGrant in namespace permitting fromKind objects in fromNamespace
+// to refer to toKind objects of the core group.
+func SetupReferenceGrant(t *testing.T, name, namespace, fromKind, fromNamespace, toKind string) {
+	referenceGrant := &gatewayv1beta1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: gatewayv1beta1.ReferenceGrantSpec{
+			From: []gatewayv1beta1.ReferenceGrantFrom{{
+				Group:     akogatewayapilib.GatewayGroup,
+				Kind:      gatewayv1.Kind(fromKind),
+				Namespace: gatewayv1.Namespace(fromNamespace),
+			}},
+			To: []gatewayv1beta1.ReferenceGrantTo{{
+				Group: "",
+				Kind:  gatewayv1.Kind(toKind),
+			}},
+		},
+	}
+	_, err := GatewayClient.GatewayV1beta1().ReferenceGrants(namespace).Create(context.TODO(), referenceGrant, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't create the ReferenceGrant, err: %+v", err)
+	}
+	t.Logf("Created ReferenceGrant %s", name)
+}
+
+func TeardownReferenceGrant(t *testing.T, name, namespace string) {
+	err := GatewayClient.GatewayV1beta1().ReferenceGrants(namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't delete the ReferenceGrant, err: %+v", err)
+	}
+	t.Logf("Deleted ReferenceGrant %s", name)
+}
+
+// SetupBackendTLSPolicy creates a BackendTLSPolicy targeting the Service svcName, validated
+// against the CA certificate present in the ConfigMap caConfigMapName.
+func SetupBackendTLSPolicy(t *testing.T, name, namespace, svcName, caConfigMapName, hostname string) {
+	backendTLSPolicy := &gatewayv1alpha3.BackendTLSPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: gatewayv1alpha3.BackendTLSPolicySpec{
+			TargetRefs: []gatewayv1alpha2.LocalPolicyTargetReferenceWithSectionName{{
+				LocalPolicyTargetReference: gatewayv1alpha2.LocalPolicyTargetReference{
+					Group: "",
+					Kind:  "Service",
+					Name:  gatewayv1.ObjectName(svcName),
+				},
+			}},
+			Validation: gatewayv1alpha3.BackendTLSPolicyValidation{
+				CACertificateRefs: []gatewayv1.LocalObjectReference{{
+					Group: "",
+					Kind:  "ConfigMap",
+					Name:  gatewayv1.ObjectName(caConfigMapName),
+				}},
+				Hostname: gatewayv1.PreciseHostname(hostname),
+			},
+		},
+	}
+	_, err := GatewayClient.GatewayV1alpha3().BackendTLSPolicies(namespace).Create(context.TODO(), backendTLSPolicy, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't create the BackendTLSPolicy, err: %+v", err)
+	}
+	t.Logf("Created BackendTLSPolicy %s", name)
+}
+
+func TeardownBackendTLSPolicy(t *testing.T, name, namespace string) {
+	err := GatewayClient.GatewayV1alpha3().BackendTLSPolicies(namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't delete the BackendTLSPolicy, err: %+v", err)
+	}
+	t.Logf("Deleted BackendTLSPolicy %s", name)
+}
+
+func SetupCACertConfigMap(t *testing.T, name, namespace, caCert string) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Data:       map[string]string{"ca.crt": caCert},
+	}
+	_, err := KubeClient.CoreV1().ConfigMaps(namespace).Create(context.TODO(), configMap, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't create the ConfigMap, err: %+v", err)
+	}
+	t.Logf("Created ConfigMap %s", name)
+}
+
+func UpdateCACertConfigMap(t *testing.T, name, namespace, caCert string) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, ResourceVersion: time.Now().Local().String()},
+		Data:       map[string]string{"ca.crt": caCert},
+	}
+	_, err := KubeClient.CoreV1().ConfigMaps(namespace).Update(context.TODO(), configMap, metav1.UpdateOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't update the ConfigMap, err: %+v", err)
+	}
+	t.Logf("Updated ConfigMap %s", name)
+}
+
+func TeardownCACertConfigMap(t *testing.T, name, namespace string) {
+	err := KubeClient.CoreV1().ConfigMaps(namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	if err != nil {
+		t.Fatalf("Couldn't delete the ConfigMap, err: %+v", err)
+	}
+	t.Logf("Deleted ConfigMap %s", name)
+}
+
 func ValidateGatewayStatus(t *testing.T, actualStatus, expectedStatus *gatewayv1.GatewayStatus) {
 
 	g := gomega.NewGomegaWithT(t)
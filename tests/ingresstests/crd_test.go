@@ -16,21 +16,28 @@ package ingresstests
 
 import (
 	"context"
+	"encoding/json"
+	"os"
 	"sort"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/cache"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/k8s"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
 	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/apis/ako/v1beta1"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/pkg/utils"
 
 	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/tests/integrationtest"
 
 	"github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 func TestCreateDeleteHostRule(t *testing.T) {
@@ -1527,6 +1534,86 @@ func TestHTTPRuleCreateDelete(t *testing.T) {
 	TearDownIngressForCacheSyncCheck(t, ingName, svcName, secretName, modelName)
 }
 
+func TestHTTPRuleRateLimitForInsecureHost(t *testing.T) {
+	// ingress insecure foo.com/foo
+	// create httprule with rate limit, httprule gets rejected
+	// make the ingress secure, httprule gets accepted on update
+	g := gomega.NewGomegaWithT(t)
+
+	modelName := MODEL_NAME_PREFIX + "0"
+	svcName := objNameMap.GenerateName("avisvc")
+	rrname := objNameMap.GenerateName("samplerr-foo")
+	secretName := objNameMap.GenerateName("my-secret")
+	ingName := objNameMap.GenerateName("foo-with-targets")
+
+	SetupDomain()
+	SetUpTestForIngress(t, svcName, modelName)
+	integrationtest.PollForCompletion(t, modelName, 5)
+	ingressObject := integrationtest.FakeIngress{
+		Name:        ingName,
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		Paths:       []string{"/foo"},
+		ServiceName: svcName,
+	}
+
+	ingrFake := ingressObject.Ingress()
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	httprule := integrationtest.FakeHTTPRule{
+		Name:      rrname,
+		Namespace: "default",
+		Fqdn:      "foo.com",
+		PathProperties: []integrationtest.FakeHTTPRulePath{{
+			Path:      "/",
+			RateLimit: &v1beta1.HTTPRuleRateLimit{Requests: 10},
+		}},
+	}.HTTPRule()
+	if _, err := v1beta1CRDClient.AkoV1beta1().HTTPRules("default").Create(context.TODO(), httprule, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		httprule, _ := v1beta1CRDClient.AkoV1beta1().HTTPRules("default").Get(context.TODO(), rrname, metav1.GetOptions{})
+		return httprule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Rejected"))
+	httprule, _ = v1beta1CRDClient.AkoV1beta1().HTTPRules("default").Get(context.TODO(), rrname, metav1.GetOptions{})
+	g.Expect(httprule.Status.Error).To(gomega.Equal("rate limit is not supported for the insecure host foo.com of the shared virtualservices"))
+
+	// the httprule is accepted once the host is served by an SNI child
+	integrationtest.AddSecret(secretName, "default", "tlsCert", "tlsKey")
+	ingressObject.TlsSecretDNS = map[string][]string{secretName: {"foo.com"}}
+	ingrFake = ingressObject.Ingress()
+	ingrFake.ResourceVersion = "2"
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Update(context.TODO(), ingrFake, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Ingress: %v", err)
+	}
+	g.Eventually(func() int {
+		if found, aviModel := objects.SharedAviGraphLister().Get(modelName); found && aviModel != nil {
+			if nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS(); len(nodes) > 0 {
+				return len(nodes[0].SniNodes)
+			}
+		}
+		return 0
+	}, 10*time.Second).Should(gomega.Equal(1))
+	httprule.Spec.Paths[0].RateLimit.Requests = 20
+	httprule.ResourceVersion = "2"
+	if _, err := v1beta1CRDClient.AkoV1beta1().HTTPRules("default").Update(context.TODO(), httprule, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating HTTPRule: %v", err)
+	}
+	g.Eventually(func() string {
+		httprule, _ := v1beta1CRDClient.AkoV1beta1().HTTPRules("default").Get(context.TODO(), rrname, metav1.GetOptions{})
+		return httprule.Status.Status
+	}, 10*time.Second).Should(gomega.Equal("Accepted"))
+
+	integrationtest.TeardownHTTPRule(t, rrname)
+	TearDownIngressForCacheSyncCheck(t, ingName, svcName, secretName, modelName)
+}
+
 func TestHTTPRuleCreateDeleteWithPkiRef(t *testing.T) {
 	// ingress secure foo.com/foo /bar
 	// create httprule /foo, nothing happens
@@ -2756,3 +2843,89 @@ func TestCreateDeleteHostRuleWithAlias(t *testing.T) {
 		return hostrule.Status.Status
 	}, 20*time.Second).Should(gomega.Equal("Accepted"))
 }
+
+func newCRDAdmissionRequest(t *testing.T, kind string, operation admissionv1.Operation, obj, oldObj interface{}) *admissionv1.AdmissionRequest {
+	req := &admissionv1.AdmissionRequest{
+		Kind:      metav1.GroupVersionKind{Group: "ako.vmware.com", Version: "v1beta1", Kind: kind},
+		Operation: operation,
+	}
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("error in marshalling %s: %v", kind, err)
+	}
+	req.Object = runtime.RawExtension{Raw: raw}
+	if oldObj != nil {
+		oldRaw, err := json.Marshal(oldObj)
+		if err != nil {
+			t.Fatalf("error in marshalling %s: %v", kind, err)
+		}
+		req.OldObject = runtime.RawExtension{Raw: oldRaw}
+	}
+	return req
+}
+
+func TestCRDValidationWebhookAdmission(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	hrname := objNameMap.GenerateName("samplehr-foo")
+	rrname := objNameMap.GenerateName("samplerr-foo")
+	integrationtest.SetupHostRule(t, hrname, "foo.com", true)
+	g.Eventually(func() string {
+		hostrule, _ := v1beta1CRDClient.AkoV1beta1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+		return hostrule.Status.Status
+	}, 20*time.Second).Should(gomega.Equal("Accepted"))
+	g.Eventually(func() bool {
+		found, _ := objects.SharedCRDLister().GetFQDNToHostruleMapping("foo.com")
+		return found
+	}, 20*time.Second).Should(gomega.BeTrue())
+
+	webhook := k8s.NewCRDValidationWebhook()
+
+	// A HostRule claiming the fqdn of an existing HostRule is denied.
+	duplicateHostRule := integrationtest.FakeHostRule{
+		Name:      objNameMap.GenerateName("samplehr-dup"),
+		Namespace: "default",
+		Fqdn:      "foo.com",
+	}.HostRule()
+	resp := webhook.ProcessAdmissionRequest(newCRDAdmissionRequest(t, lib.HostRule, admissionv1.Create, duplicateHostRule, nil))
+	g.Expect(resp.Allowed).To(gomega.BeFalse())
+	g.Expect(resp.Result.Message).To(gomega.ContainSubstring("duplicate fqdn foo.com found in default/" + hrname))
+
+	// The HostRule itself is admitted.
+	hostrule, _ := v1beta1CRDClient.AkoV1beta1().HostRules("default").Get(context.TODO(), hrname, metav1.GetOptions{})
+	updatedHostRule := hostrule.DeepCopy()
+	updatedHostRule.Spec.VirtualHost.Datascripts = []string{"thisisaviref-ds1"}
+	resp = webhook.ProcessAdmissionRequest(newCRDAdmissionRequest(t, lib.HostRule, admissionv1.Update, updatedHostRule, hostrule))
+	g.Expect(resp.Allowed).To(gomega.BeTrue())
+
+	integrationtest.SetupHTTPRule(t, rrname, "foo.com", "/")
+	g.Eventually(func() string {
+		httprule, _ := v1beta1CRDClient.AkoV1beta1().HTTPRules("default").Get(context.TODO(), rrname, metav1.GetOptions{})
+		return httprule.Status.Status
+	}, 20*time.Second).Should(gomega.Equal("Accepted"))
+
+	// An invalid HTTPRule update is denied, and the status of the stored object is left untouched.
+	httprule, _ := v1beta1CRDClient.AkoV1beta1().HTTPRules("default").Get(context.TODO(), rrname, metav1.GetOptions{})
+	invalidHTTPRule := httprule.DeepCopy()
+	invalidHTTPRule.Spec.Paths[0].RateLimit = &v1beta1.HTTPRuleRateLimit{Requests: 10, Action: "Redirect"}
+	resp = webhook.ProcessAdmissionRequest(newCRDAdmissionRequest(t, lib.HTTPRule, admissionv1.Update, invalidHTTPRule, httprule))
+	g.Expect(resp.Allowed).To(gomega.BeFalse())
+	g.Expect(resp.Result.Message).To(gomega.Equal("rate limit with action Redirect requires the redirect settings"))
+	httprule, _ = v1beta1CRDClient.AkoV1beta1().HTTPRules("default").Get(context.TODO(), rrname, metav1.GetOptions{})
+	g.Expect(httprule.Status.Status).To(gomega.Equal("Accepted"))
+
+	// Updates which do not change the spec are admitted.
+	relabelledHTTPRule := invalidHTTPRule.DeepCopy()
+	relabelledHTTPRule.Labels = map[string]string{"team": "web"}
+	resp = webhook.ProcessAdmissionRequest(newCRDAdmissionRequest(t, lib.HTTPRule, admissionv1.Update, relabelledHTTPRule, invalidHTTPRule))
+	g.Expect(resp.Allowed).To(gomega.BeTrue())
+
+	// The failure policy follows the fail open setting.
+	g.Expect(*k8s.BuildCRDValidationWebhook("default", nil).FailurePolicy).To(gomega.Equal(admissionregistrationv1.Ignore))
+	os.Setenv(utils.CRD_WEBHOOK_FAIL_OPEN, "false")
+	g.Expect(*k8s.BuildCRDValidationWebhook("default", nil).FailurePolicy).To(gomega.Equal(admissionregistrationv1.Fail))
+	os.Unsetenv(utils.CRD_WEBHOOK_FAIL_OPEN)
+
+	integrationtest.TeardownHTTPRule(t, rrname)
+	integrationtest.TearDownHostRuleWithNoVerify(t, g, hrname)
+}
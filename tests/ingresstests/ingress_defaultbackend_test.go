@@ -0,0 +1,159 @@
+/*
+ * Copyright © 2025 Broadcom Inc. and/or its subsidiaries. All Rights Reserved.
+ * All Rights Reserved.
+* Licensed under the Apache License, Version 2.0 (the "License");
+* you may not use this file except in compliance with the License.
+* You may obtain a copy of the License at
+*   http://www.apache.org/licenses/LICENSE-2.0
+* Unless required by applicable law or agreed to in writing, software
+* distributed under the License is distributed on an "AS IS" BASIS,
+* WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+* See the License for the specific language governing permissions and
+* limitations under the License.
+*/
+
+package ingresstests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/lib"
+	avinodes "github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/nodes"
+	"github.com/vmware/load-balancer-and-ingress-services-for-kubernetes/internal/objects"
+)
+
+func hostlessIngress(name, svcName string, creationTimestamp time.Time) *networkingv1.Ingress {
+	return &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(creationTimestamp),
+		},
+		Spec: networkingv1.IngressSpec{
+			DefaultBackend: &networkingv1.IngressBackend{
+				Service: &networkingv1.IngressServiceBackend{
+					Name: svcName,
+					Port: networkingv1.ServiceBackendPort{Number: 8080},
+				},
+			},
+		},
+	}
+}
+
+func getDefaultPoolGroup(modelName string) string {
+	found, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	if !found || aviModel == nil {
+		return ""
+	}
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	if len(nodes) == 0 {
+		return ""
+	}
+	return nodes[0].DefaultPoolGroup
+}
+
+func TestHostlessIngressDefaultBackend(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	vsName := avinodes.GetShardVSName("", "", lib.GetshardSize(), "admin").Name
+	modelName := "admin/" + vsName
+	svcName := objNameMap.GenerateName("avisvc")
+	ingName := objNameMap.GenerateName("ingress-defaultbackend")
+	SetUpTestForIngress(t, svcName, modelName)
+
+	ingrFake := hostlessIngress(ingName, svcName, time.Now())
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+
+	pgName := lib.GetL7DefaultBackendPGName(vsName, "default", ingName)
+	poolName := lib.GetL7DefaultBackendPoolName(vsName, "default", ingName)
+	g.Eventually(func() string {
+		return getDefaultPoolGroup(modelName)
+	}, 20*time.Second).Should(gomega.Equal(pgName))
+
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes[0].PoolRefs).To(gomega.HaveLen(1))
+	g.Expect(nodes[0].PoolRefs[0].Name).To(gomega.Equal(poolName))
+	g.Expect(nodes[0].PoolRefs[0].PriorityLabel).To(gomega.BeEmpty())
+	g.Expect(nodes[0].PoolRefs[0].Servers).NotTo(gomega.BeEmpty())
+	var defaultPG *avinodes.AviPoolGroupNode
+	for _, pgNode := range nodes[0].PoolGroupRefs {
+		if pgNode.Name == pgName {
+			defaultPG = pgNode
+		}
+		if pgNode.Name == lib.GetL7SharedPGName(vsName) {
+			// the default backend pool does not take part in the host/path routing.
+			g.Expect(pgNode.Members).To(gomega.BeEmpty())
+		}
+	}
+	g.Expect(defaultPG).NotTo(gomega.BeNil())
+	g.Expect(defaultPG.Members).To(gomega.HaveLen(1))
+	g.Expect(*defaultPG.Members[0].PoolRef).To(gomega.Equal("/api/pool?name=" + poolName))
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), ingName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	g.Eventually(func() string {
+		return getDefaultPoolGroup(modelName)
+	}, 20*time.Second).Should(gomega.BeEmpty())
+	VerifyIngressDeletion(t, g, aviModel, 0)
+
+	TearDownTestForIngress(t, svcName, modelName)
+}
+
+func TestHostlessIngressDefaultBackendOwnership(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	vsName := avinodes.GetShardVSName("", "", lib.GetshardSize(), "admin").Name
+	modelName := "admin/" + vsName
+	svcName := objNameMap.GenerateName("avisvc")
+	olderIngName := objNameMap.GenerateName("ingress-defaultbackend")
+	newerIngName := objNameMap.GenerateName("ingress-defaultbackend")
+	SetUpTestForIngress(t, svcName, modelName)
+
+	// the newer Ingress is created first, the older one takes over the default backend.
+	now := time.Now()
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), hostlessIngress(newerIngName, svcName, now), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	g.Eventually(func() string {
+		return getDefaultPoolGroup(modelName)
+	}, 20*time.Second).Should(gomega.Equal(lib.GetL7DefaultBackendPGName(vsName, "default", newerIngName)))
+
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), hostlessIngress(olderIngName, svcName, now.Add(-time.Hour)), metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	g.Eventually(func() string {
+		return getDefaultPoolGroup(modelName)
+	}, 20*time.Second).Should(gomega.Equal(lib.GetL7DefaultBackendPGName(vsName, "default", olderIngName)))
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+	g.Expect(nodes[0].PoolRefs).To(gomega.HaveLen(1))
+	g.Expect(nodes[0].PoolRefs[0].Name).To(gomega.Equal(lib.GetL7DefaultBackendPoolName(vsName, "default", olderIngName)))
+
+	// deleting the owner hands the default backend over to the remaining Ingress.
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), olderIngName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	g.Eventually(func() string {
+		return getDefaultPoolGroup(modelName)
+	}, 20*time.Second).Should(gomega.Equal(lib.GetL7DefaultBackendPGName(vsName, "default", newerIngName)))
+
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), newerIngName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	g.Eventually(func() string {
+		return getDefaultPoolGroup(modelName)
+	}, 20*time.Second).Should(gomega.BeEmpty())
+	VerifyIngressDeletion(t, g, aviModel, 0)
+
+	TearDownTestForIngress(t, svcName, modelName)
+}
@@ -377,6 +377,138 @@ func TestIngressWithExternalNameService(t *testing.T) {
 	VerifyIngressDeletion(t, g, aviModel, 0)
 }
 
+func TestIngressWithExternalNameServiceFQDN(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	modelName := MODEL_NAME_PREFIX + "0"
+	svcName := objNameMap.GenerateName("avisvc")
+	ingName := objNameMap.GenerateName("foo-with-targets")
+	objects.SharedAviGraphLister().Delete(modelName)
+	svcExample := (integrationtest.FakeService{
+		Name:         svcName,
+		Namespace:    "default",
+		Type:         corev1.ServiceTypeExternalName,
+		ExternalName: "api.example.com",
+		ServicePorts: []integrationtest.Serviceport{{PortName: "https", Protocol: "TCP", PortNumber: 443, TargetPort: intstr.FromInt(443)}},
+	}).Service()
+	appProtocol := lib.AppProtocolHTTPS
+	svcExample.Spec.Ports[0].AppProtocol = &appProtocol
+	if _, err := KubeClient.CoreV1().Services("default").Create(context.TODO(), svcExample, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Service: %v", err)
+	}
+
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        ingName,
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		ServiceName: svcName,
+	}).Ingress()
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) == 0 || len(nodes[0].PoolRefs) == 0 {
+			return 0
+		}
+		return len(nodes[0].PoolRefs[0].Servers)
+	}, 20*time.Second).Should(gomega.Equal(1))
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	pool := aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].PoolRefs[0]
+	g.Expect(pool.Servers[0].Hostname).To(gomega.Equal("api.example.com"))
+	g.Expect(*pool.Servers[0].Ip.Addr).To(gomega.Equal("api.example.com"))
+	g.Expect(*pool.Servers[0].Ip.Type).To(gomega.Equal("DNS"))
+	g.Expect(pool.Servers[0].Port).To(gomega.Equal(int32(443)))
+	g.Expect(pool.SniEnabled).To(gomega.BeTrue())
+	g.Expect(pool.SslProfileRef).NotTo(gomega.BeNil())
+	g.Expect(*pool.ServerName).To(gomega.Equal("api.example.com"))
+
+	// changes to the externalName are reflected on the pool server.
+	svcExample.Spec.ExternalName = "api2.example.com"
+	svcExample.ResourceVersion = "2"
+	if _, err := KubeClient.CoreV1().Services("default").Update(context.TODO(), svcExample, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error in updating Service: %v", err)
+	}
+	g.Eventually(func() string {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		pools := aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].PoolRefs
+		if len(pools) == 0 || len(pools[0].Servers) == 0 {
+			return ""
+		}
+		return pools[0].Servers[0].Hostname
+	}, 20*time.Second).Should(gomega.Equal("api2.example.com"))
+
+	integrationtest.DelSVC(t, "default", svcName)
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), ingName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	VerifyIngressDeletion(t, g, aviModel, 0)
+}
+
+func TestIngressWithExternalNameServiceOnSSLPort(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	modelName := MODEL_NAME_PREFIX + "0"
+	svcName := objNameMap.GenerateName("avisvc")
+	ingName := objNameMap.GenerateName("foo-with-targets")
+	objects.SharedAviGraphLister().Delete(modelName)
+	svcExample := (integrationtest.FakeService{
+		Name:         svcName,
+		Namespace:    "default",
+		Type:         corev1.ServiceTypeExternalName,
+		ExternalName: "plain.example.com",
+		ServicePorts: []integrationtest.Serviceport{{PortName: "web", Protocol: "TCP", PortNumber: 443, TargetPort: intstr.FromInt(443)}},
+	}).Service()
+	if _, err := KubeClient.CoreV1().Services("default").Create(context.TODO(), svcExample, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Service: %v", err)
+	}
+
+	ingrFake := (integrationtest.FakeIngress{
+		Name:        ingName,
+		Namespace:   "default",
+		DnsNames:    []string{"foo.com"},
+		Ips:         []string{"8.8.8.8"},
+		HostNames:   []string{"v1"},
+		ServiceName: svcName,
+	}).Ingress()
+	if _, err := KubeClient.NetworkingV1().Ingresses("default").Create(context.TODO(), ingrFake, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error in adding Ingress: %v", err)
+	}
+	integrationtest.PollForCompletion(t, modelName, 5)
+
+	g.Eventually(func() int {
+		_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+		if aviModel == nil {
+			return 0
+		}
+		nodes := aviModel.(*avinodes.AviObjectGraph).GetAviVS()
+		if len(nodes) == 0 || len(nodes[0].PoolRefs) == 0 {
+			return 0
+		}
+		return len(nodes[0].PoolRefs[0].Servers)
+	}, 20*time.Second).Should(gomega.Equal(1))
+	_, aviModel := objects.SharedAviGraphLister().Get(modelName)
+	pool := aviModel.(*avinodes.AviObjectGraph).GetAviVS()[0].PoolRefs[0]
+	g.Expect(pool.Servers[0].Hostname).To(gomega.Equal("plain.example.com"))
+	g.Expect(pool.Servers[0].Port).To(gomega.Equal(int32(443)))
+	// port 443 without an https appProtocol keeps the pool on plain HTTP
+	g.Expect(pool.SniEnabled).To(gomega.BeFalse())
+	g.Expect(pool.SslProfileRef).To(gomega.BeNil())
+	g.Expect(pool.ServerName).To(gomega.BeNil())
+
+	integrationtest.DelSVC(t, "default", svcName)
+	if err := KubeClient.NetworkingV1().Ingresses("default").Delete(context.TODO(), ingName, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("Couldn't DELETE the Ingress %v", err)
+	}
+	VerifyIngressDeletion(t, g, aviModel, 0)
+}
+
 func TestMultiIngressToSameSvc(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	modelName := MODEL_NAME_PREFIX + "0"
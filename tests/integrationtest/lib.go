@@ -695,6 +695,7 @@ type FakeService struct {
 	Selectors         map[string]string
 	Annotations       map[string]string
 	LoadBalancerClass string
+	ExternalName      string
 }
 
 type Serviceport struct {
@@ -736,6 +737,9 @@ func (svc FakeService) Service() *corev1.Service {
 	if svc.LoadBalancerClass != "" {
 		svcExample.Spec.LoadBalancerClass = &svc.LoadBalancerClass
 	}
+	if svc.ExternalName != "" {
+		svcExample.Spec.ExternalName = svc.ExternalName
+	}
 	return svcExample
 }
 
@@ -1511,6 +1515,11 @@ func NormalControllerServer(w http.ResponseWriter, r *http.Request, args ...stri
 		strings.HasSuffix(r.URL.RawQuery, lib.GetProxyEnabledApplicationProfileName()) {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(`{"results": [], "count": 0}`))
+	} else if r.Method == "GET" && strings.Contains(url, "/api/applicationprofile/") &&
+		strings.HasSuffix(r.URL.RawQuery, utils.DEFAULT_L7_SECURE_APP_PROFILE) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"results": [{"name": "System-Secure-HTTP", "type": "APPLICATION_PROFILE_TYPE_HTTP", "uuid": "applicationprofile-secure-http",
+			"http_profile": {"connection_multiplexing_enabled": true, "hsts_enabled": true, "x_forwarded_proto_enabled": true}}], "count": 1}`))
 	} else if r.Method == "GET" && strings.Contains(url, "/api/healthmonitor/") &&
 		strings.HasSuffix(r.URL.RawQuery, lib.GetTcpHalfOpenHealthMonitorName()) {
 		w.WriteHeader(http.StatusOK)
@@ -1973,6 +1982,9 @@ type FakeHTTPRulePath struct {
 	LbAlgorithm    string
 	Hash           string
 	EnableHTTP2    bool
+	CORS           *akov1beta1.HTTPRuleCORS
+	Canary         *akov1beta1.HTTPRuleCanary
+	RateLimit      *akov1beta1.HTTPRuleRateLimit
 }
 
 func (rr FakeHTTPRule) HTTPRule() *akov1beta1.HTTPRule {
@@ -1990,6 +2002,9 @@ func (rr FakeHTTPRule) HTTPRule() *akov1beta1.HTTPRule {
 				Hash:      p.Hash,
 			},
 			EnableHttp2: &p.EnableHTTP2,
+			CORS:        p.CORS,
+			Canary:      p.Canary,
+			RateLimit:   p.RateLimit,
 		}
 		if p.DestinationCA != "" {
 			rrForPath.TLS.DestinationCA = p.DestinationCA